@@ -0,0 +1,286 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package archive uploads accepted blocks (and their results) to an object
+// store so that chains can maintain full history independently of node disks.
+//
+// Each block at height H (zero-padded to 20 digits) is stored as:
+//
+//	blocks/<H>.blk   block bytes (as returned by [chain.StatelessBlock.Bytes])
+//	blocks/<H>.res   results encoded with [chain.MarshalResults] (omitted if
+//	                 the block was backfilled from disk after a restart and
+//	                 its results were no longer available)
+//	blocks/<H>.json  [BlockInfo]
+//
+// Blocks are uploaded in height order and, after each block is uploaded,
+// "head.json" is overwritten with its [BlockInfo]. Progress is persisted
+// locally, so archiving resumes from the last uploaded block after a restart.
+package archive
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+const (
+	defaultBacklog = 1_024
+	uploadTimeout  = 30 * time.Second
+	minRetryDelay  = 1 * time.Second
+	maxRetryDelay  = 1 * time.Minute
+
+	overloadedDelay = 1 * time.Second
+)
+
+var (
+	progressKey = []byte("archive_next")
+
+	errStopped = errors.New("stopped")
+)
+
+// BlockInfo summarizes an archived block.
+type BlockInfo struct {
+	ID         ids.ID          `json:"id"`
+	Parent     ids.ID          `json:"parent"`
+	Height     uint64          `json:"height"`
+	Timestamp  int64           `json:"timestamp"`
+	Txs        int             `json:"txs"`
+	Units      fees.Dimensions `json:"units"`
+	HasResults bool            `json:"hasResults"`
+}
+
+// Fetcher returns the accepted block at [height] (used to backfill blocks that
+// were dropped from the upload queue or accepted while the node was offline).
+type Fetcher func(ctx context.Context, height uint64) (*chain.StatelessBlock, error)
+
+type Archiver struct {
+	log   logging.Logger
+	store Store
+	db    database.KeyValueReaderWriter
+	fetch Fetcher
+
+	queue      chan *chain.StatelessBlock
+	next       atomic.Uint64
+	overloaded func() bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New creates an [Archiver] that persists its progress in [db]. Uploads are
+// deferred while [overloaded] returns true.
+func New(
+	log logging.Logger,
+	store Store,
+	db database.KeyValueReaderWriter,
+	fetch Fetcher,
+	backlog int,
+	overloaded func() bool,
+) (*Archiver, error) {
+	if backlog <= 0 {
+		backlog = defaultBacklog
+	}
+	if overloaded == nil {
+		overloaded = func() bool { return false }
+	}
+	a := &Archiver{
+		log:   log,
+		store: store,
+		db:    db,
+		fetch: fetch,
+		queue: make(chan *chain.StatelessBlock, backlog),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+
+		overloaded: overloaded,
+	}
+	v, err := db.Get(progressKey)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+	case err != nil:
+		return nil, err
+	default:
+		a.next.Store(binary.BigEndian.Uint64(v))
+	}
+	return a, nil
+}
+
+// Next returns the height of the next block to archive (all blocks below this
+// height have been uploaded).
+func (a *Archiver) Next() uint64 {
+	return a.next.Load()
+}
+
+// Accepted queues [blk] for upload. It never blocks: if the queue is full,
+// [blk] is dropped and backfilled (without results) once the queue drains.
+func (a *Archiver) Accepted(blk *chain.StatelessBlock) {
+	select {
+	case a.queue <- blk:
+	default:
+		a.log.Warn("archive queue full, dropping block", zap.Uint64("height", blk.Hght))
+	}
+}
+
+func (a *Archiver) Run() {
+	defer close(a.done)
+
+	for {
+		select {
+		case blk := <-a.queue:
+			// Uploads can be caught up later, so we don't compete with
+			// verification while overloaded
+			for a.overloaded() {
+				select {
+				case <-time.After(overloadedDelay):
+				case <-a.stop:
+					return
+				}
+			}
+			if err := a.archive(blk); err != nil {
+				return
+			}
+		case <-a.stop:
+			return
+		}
+	}
+}
+
+// Close stops [Run] and waits for any in-progress upload to stop.
+func (a *Archiver) Close() {
+	close(a.stop)
+	<-a.done
+}
+
+func (a *Archiver) archive(blk *chain.StatelessBlock) error {
+	next := a.next.Load()
+	if blk.Hght < next {
+		return nil
+	}
+
+	// Backfill any blocks we missed
+	for height := next; height < blk.Hght; height++ {
+		fblk, err := a.fetchBlock(height)
+		if err != nil {
+			return err
+		}
+		if err := a.upload(fblk, nil); err != nil {
+			return err
+		}
+	}
+	return a.upload(blk, blk.Results())
+}
+
+// fetchBlock returns the accepted block at [height], retrying with backoff
+// until it is fetched or the [Archiver] is closed (so that a transient error
+// never leaves a gap in the archive).
+func (a *Archiver) fetchBlock(height uint64) (*chain.StatelessBlock, error) {
+	delay := minRetryDelay
+	for {
+		blk, err := a.fetch(context.Background(), height)
+		if err == nil {
+			return blk, nil
+		}
+		a.log.Warn("unable to fetch block for archiving",
+			zap.Uint64("height", height),
+			zap.Duration("retry", delay),
+			zap.Error(err),
+		)
+		select {
+		case <-time.After(delay):
+		case <-a.stop:
+			return nil, errStopped
+		}
+		delay *= 2
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+func (a *Archiver) upload(blk *chain.StatelessBlock, results []*chain.Result) error {
+	info := &BlockInfo{
+		ID:         blk.ID(),
+		Parent:     blk.Prnt,
+		Height:     blk.Hght,
+		Timestamp:  blk.Tmstmp,
+		Txs:        len(blk.Txs),
+		Units:      blk.UnitsConsumed,
+		HasResults: results != nil,
+	}
+	infoBytes, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	prefix := fmt.Sprintf("blocks/%020d", blk.Hght)
+	if err := a.put(prefix+".blk", blk.Bytes()); err != nil {
+		return err
+	}
+	if results != nil {
+		resultBytes, err := chain.MarshalResults(results)
+		if err != nil {
+			return err
+		}
+		if err := a.put(prefix+".res", resultBytes); err != nil {
+			return err
+		}
+	}
+	if err := a.put(prefix+".json", infoBytes); err != nil {
+		return err
+	}
+	if err := a.put("head.json", infoBytes); err != nil {
+		return err
+	}
+
+	// Persist progress
+	next := blk.Hght + 1
+	if err := a.db.Put(progressKey, binary.BigEndian.AppendUint64(nil, next)); err != nil {
+		return err
+	}
+	a.next.Store(next)
+	a.log.Debug("archived block",
+		zap.Stringer("blkID", info.ID),
+		zap.Uint64("height", info.Height),
+		zap.Bool("results", info.HasResults),
+	)
+	return nil
+}
+
+// put uploads [data] to [key], retrying with backoff until it succeeds or the
+// [Archiver] is closed.
+func (a *Archiver) put(key string, data []byte) error {
+	delay := minRetryDelay
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+		err := a.store.Put(ctx, key, data)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		a.log.Warn("unable to upload archive object",
+			zap.String("key", key),
+			zap.Duration("retry", delay),
+			zap.Error(err),
+		)
+		select {
+		case <-time.After(delay):
+		case <-a.stop:
+			return errStopped
+		}
+		delay *= 2
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
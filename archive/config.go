@@ -0,0 +1,21 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archive
+
+type Config struct {
+	// Used to flag if accepted blocks should be archived
+	Enabled bool `json:"enabled"`
+
+	// Location of the object store. Supported schemes are "file://<dir>" and
+	// "http(s)://<bucket url>" (objects are uploaded with PUT requests, which
+	// is supported by most S3-compatible object stores).
+	Location string `json:"location"`
+
+	// Headers are included in every upload request (e.g. for authorization).
+	Headers map[string]string `json:"headers"`
+
+	// Backlog is the number of accepted blocks that can be queued for upload
+	// before new blocks are dropped (and later backfilled from disk).
+	Backlog int `json:"backlog"`
+}
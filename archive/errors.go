@@ -0,0 +1,11 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archive
+
+import "errors"
+
+var (
+	ErrUnsupportedLocation = errors.New("unsupported location")
+	ErrUploadFailed        = errors.New("upload failed")
+)
@@ -0,0 +1,110 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Store persists archived objects. Implementations must overwrite any
+// existing object stored at [key].
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+}
+
+// NewStore returns the [Store] for [location] (see [Config]).
+func NewStore(location string, headers map[string]string) (Store, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "file":
+		return NewDirStore(filepath.Join(u.Host, u.Path))
+	case "http", "https":
+		return NewHTTPStore(location, headers), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLocation, u.Scheme)
+	}
+}
+
+var _ Store = (*DirStore)(nil)
+
+// DirStore writes objects to a local directory (which may be a mounted
+// network filesystem).
+type DirStore struct {
+	dir string
+}
+
+func NewDirStore(dir string) (*DirStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DirStore{dir}, nil
+}
+
+func (d *DirStore) Put(_ context.Context, key string, data []byte) error {
+	path := filepath.Join(d.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// Write to a temporary file first so readers never observe a partially
+	// written object
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+var _ Store = (*HTTPStore)(nil)
+
+// HTTPStore uploads objects with PUT requests to <uri>/<key>.
+type HTTPStore struct {
+	uri     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func NewHTTPStore(uri string, headers map[string]string) *HTTPStore {
+	return &HTTPStore{
+		uri:     strings.TrimSuffix(uri, "/"),
+		headers: headers,
+		client:  http.DefaultClient,
+	}
+}
+
+func (h *HTTPStore) Put(ctx context.Context, key string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, h.uri+"/"+key, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(len(data))
+	if strings.HasSuffix(key, ".json") {
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	for k, v := range h.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: %s returned %d", ErrUploadFailed, key, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirStore(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	s, err := NewStore("file://"+dir, nil)
+	require.NoError(err)
+	require.NoError(s.Put(context.TODO(), "blocks/1.blk", []byte{1, 2, 3}))
+	require.NoError(s.Put(context.TODO(), "blocks/1.blk", []byte{4}))
+
+	b, err := os.ReadFile(filepath.Join(dir, "blocks", "1.blk"))
+	require.NoError(err)
+	require.Equal([]byte{4}, b)
+	_, err = os.Stat(filepath.Join(dir, "blocks", "1.blk.tmp"))
+	require.ErrorIs(err, os.ErrNotExist)
+}
+
+func TestHTTPStore(t *testing.T) {
+	require := require.New(t)
+
+	objects := map[string][]byte{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.Header.Get("Authorization") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		objects[r.URL.Path] = b
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(srv.URL+"/bucket/", map[string]string{"Authorization": "secret"})
+	require.NoError(err)
+	require.NoError(s.Put(context.TODO(), "head.json", []byte("{}")))
+	require.Equal([]byte("{}"), objects["/bucket/head.json"])
+
+	s, err = NewStore(srv.URL+"/bucket", nil)
+	require.NoError(err)
+	require.ErrorIs(s.Put(context.TODO(), "head.json", []byte("{}")), ErrUploadFailed)
+
+	_, err = NewStore("ftp://bucket", nil)
+	require.ErrorIs(err, ErrUnsupportedLocation)
+}
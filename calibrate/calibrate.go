@@ -0,0 +1,233 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package calibrate benchmarks the current hardware and suggests
+// [chain.Rules] unit costs and targets that reflect it.
+//
+// A unit is calibrated to the cost of processing (parsing and hashing) a
+// single byte of a transaction, which matches how most auth and action
+// implementations (and [chain.Rules.GetBaseUnits]) already charge for
+// bandwidth. Other costs are expressed as a multiple of that.
+package calibrate
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/pebble"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/ava-labs/hypersdk/window"
+)
+
+type Config struct {
+	// Duration is how long each benchmark is run for.
+	Duration time.Duration
+
+	// TxSize, TxSignatures, and TxStateOperations describe the reference
+	// transaction used to estimate how many units the hardware can process
+	// per second.
+	TxSize            int
+	TxSignatures      int
+	TxStateOperations int
+
+	// Utilization is the fraction of the hardware's capacity that
+	// [Report.WindowTargetUnits] should target (leaving room for consensus,
+	// networking, and bursts).
+	Utilization float64
+
+	// Parallelism is how many signatures can be verified concurrently. This
+	// defaults to the number of CPUs when 0.
+	Parallelism int
+}
+
+func NewDefaultConfig() Config {
+	return Config{
+		Duration:          time.Second,
+		TxSize:            256,
+		TxSignatures:      1,
+		TxStateOperations: 4,
+		Utilization:       0.5,
+	}
+}
+
+// Report contains the benchmark results (in nanoseconds) and the
+// [chain.Rules] values they suggest.
+type Report struct {
+	Config Config `json:"config"`
+
+	ByteNs           float64 `json:"byteNs"`
+	SignatureNs      float64 `json:"signatureNs"`
+	StateOperationNs float64 `json:"stateOperationNs"`
+
+	// SignatureUnits and StateOperationUnits are the suggested charges for
+	// verifying a single ed25519 signature and for reading (or writing) a
+	// single state key.
+	SignatureUnits      uint64 `json:"signatureUnits"`
+	StateOperationUnits uint64 `json:"stateOperationUnits"`
+
+	// TxUnits and TxNs are the units and time consumed by the reference
+	// transaction.
+	TxUnits uint64  `json:"txUnits"`
+	TxNs    float64 `json:"txNs"`
+
+	UnitsPerSecond    uint64 `json:"unitsPerSecond"`
+	WindowTargetUnits uint64 `json:"windowTargetUnits"`
+	MaxBlockUnits     uint64 `json:"maxBlockUnits"`
+}
+
+// Run benchmarks the current hardware using [cfg] and returns a [Report].
+//
+// State operations are benchmarked against a temporary database in
+// [os.TempDir].
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if cfg.Parallelism <= 0 {
+		cfg.Parallelism = runtime.NumCPU()
+	}
+	if cfg.TxSize <= 0 || cfg.Utilization <= 0 || cfg.Utilization > 1 {
+		return nil, ErrInvalidConfig
+	}
+	r := &Report{Config: cfg}
+	var err error
+	r.ByteNs, err = benchmarkBytes(ctx, cfg.Duration, cfg.TxSize)
+	if err != nil {
+		return nil, err
+	}
+	r.SignatureNs, err = benchmarkSignatures(ctx, cfg.Duration)
+	if err != nil {
+		return nil, err
+	}
+	r.StateOperationNs, err = benchmarkState(ctx, cfg.Duration)
+	if err != nil {
+		return nil, err
+	}
+
+	// Express costs as a multiple of the cost of processing a byte
+	r.SignatureUnits = units(r.SignatureNs, r.ByteNs)
+	r.StateOperationUnits = units(r.StateOperationNs, r.ByteNs)
+
+	// Estimate capacity using the reference transaction (signatures are
+	// verified asynchronously, so they can use all available cores)
+	r.TxUnits = uint64(cfg.TxSize) +
+		uint64(cfg.TxSignatures)*r.SignatureUnits +
+		uint64(cfg.TxStateOperations)*r.StateOperationUnits
+	r.TxNs = float64(cfg.TxSize)*r.ByteNs +
+		float64(cfg.TxSignatures)*r.SignatureNs/float64(cfg.Parallelism) +
+		float64(cfg.TxStateOperations)*r.StateOperationNs
+	txsPerSecond := float64(time.Second) / r.TxNs
+	r.UnitsPerSecond = uint64(txsPerSecond * float64(r.TxUnits))
+	r.WindowTargetUnits = uint64(float64(r.UnitsPerSecond) * cfg.Utilization * window.WindowSize)
+
+	// A single block should never take more than a second to process or
+	// exceed the network size limit (every byte is charged at least a unit).
+	r.MaxBlockUnits = r.UnitsPerSecond
+	if r.MaxBlockUnits > consts.NetworkSizeLimit {
+		r.MaxBlockUnits = consts.NetworkSizeLimit
+	}
+	return r, nil
+}
+
+func units(ns float64, byteNs float64) uint64 {
+	return uint64(math.Max(1, math.Ceil(ns/byteNs)))
+}
+
+// benchmark calls [f] until [duration] has elapsed and returns the average
+// time per call.
+func benchmark(ctx context.Context, duration time.Duration, f func(i int) error) (float64, error) {
+	var (
+		start = time.Now()
+		i     int
+	)
+	for ; i == 0 || time.Since(start) < duration; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		if err := f(i); err != nil {
+			return 0, err
+		}
+	}
+	return float64(time.Since(start)) / float64(i), nil
+}
+
+// benchmarkBytes measures the average time to parse and hash a byte of a
+// [size] byte payload.
+func benchmarkBytes(ctx context.Context, duration time.Duration, size int) (float64, error) {
+	msg := make([]byte, size)
+	ns, err := benchmark(ctx, duration, func(i int) error {
+		binary.BigEndian.PutUint64(msg, uint64(i))
+		p := codec.NewReader(msg, consts.NetworkSizeLimit)
+		b := make([]byte, size)
+		p.UnpackFixedBytes(size, &b)
+		if err := p.Err(); err != nil {
+			return err
+		}
+		_ = utils.ToID(b)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return ns / float64(size), nil
+}
+
+// benchmarkSignatures measures the average time to verify an ed25519
+// signature.
+func benchmarkSignatures(ctx context.Context, duration time.Duration) (float64, error) {
+	priv, err := crypto.GeneratePrivateKey()
+	if err != nil {
+		return 0, err
+	}
+	pub := priv.PublicKey()
+	msg := make([]byte, consts.IDLen)
+	sig := crypto.Sign(msg, priv)
+	return benchmark(ctx, duration, func(int) error {
+		if !crypto.Verify(msg, pub, sig) {
+			return crypto.ErrInvalidSignature
+		}
+		return nil
+	})
+}
+
+// benchmarkState measures the average time to write and then read a key in
+// a temporary database.
+func benchmarkState(ctx context.Context, duration time.Duration) (float64, error) {
+	dir, err := os.MkdirTemp("", "hypersdk-calibrate")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(dir)
+	db, _, err := pebble.New(dir, pebble.NewDefaultConfig())
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	key := make([]byte, consts.IDLen)
+	value := make([]byte, consts.Uint64Len)
+	ns, err := benchmark(ctx, duration, func(i int) error {
+		// Spread keys so we don't only exercise the memtable
+		binary.BigEndian.PutUint64(key, uint64(i)*0x9E3779B97F4A7C15)
+		binary.BigEndian.PutUint64(value, uint64(i))
+		if err := db.Put(key, value); err != nil {
+			return err
+		}
+		if _, err := db.Get(key); err != nil && !errors.Is(err, database.ErrNotFound) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	// Each iteration performs a write and a read
+	return ns / 2, nil
+}
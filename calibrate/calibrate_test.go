@@ -0,0 +1,41 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package calibrate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+func TestRun(t *testing.T) {
+	require := require.New(t)
+	cfg := NewDefaultConfig()
+	cfg.Duration = 10 * time.Millisecond
+	r, err := Run(context.Background(), cfg)
+	require.NoError(err)
+	require.Positive(r.ByteNs)
+	require.Positive(r.SignatureNs)
+	require.Positive(r.StateOperationNs)
+	require.GreaterOrEqual(r.SignatureUnits, uint64(1))
+	require.GreaterOrEqual(r.StateOperationUnits, uint64(1))
+	require.Equal(
+		uint64(cfg.TxSize)+r.SignatureUnits+uint64(cfg.TxStateOperations)*r.StateOperationUnits,
+		r.TxUnits,
+	)
+	require.Positive(r.WindowTargetUnits)
+	require.Positive(r.MaxBlockUnits)
+	require.LessOrEqual(r.MaxBlockUnits, uint64(consts.NetworkSizeLimit))
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Utilization = 2
+	_, err := Run(context.Background(), cfg)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+}
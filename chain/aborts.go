@@ -0,0 +1,21 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import "time"
+
+// remainingWork estimates how much longer work that was aborted after
+// processing [done] of [total] items in [elapsed] would have taken.
+func remainingWork(elapsed time.Duration, done int, total int) time.Duration {
+	if done <= 0 || total <= done {
+		return 0
+	}
+	return elapsed * time.Duration(total-done) / time.Duration(done)
+}
+
+func (b *StatelessBlock) recordVerifyAborted(saved time.Duration) {
+	if aborter, ok := b.vm.(AbortableVM); ok {
+		aborter.RecordVerifyAborted(saved)
+	}
+}
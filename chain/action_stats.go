@@ -0,0 +1,99 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const actionStatsLen = consts.ByteLen + 3*consts.Uint64Len
+
+// ActionStats aggregates the accepted transactions of a single action type.
+type ActionStats struct {
+	Count   uint64 `json:"count"`
+	Success uint64 `json:"success"`
+	Units   uint64 `json:"units"`
+}
+
+func (s *ActionStats) Add(o *ActionStats) {
+	s.Count += o.Count
+	s.Success += o.Success
+	s.Units += o.Units
+}
+
+// ActionStatsBucket aggregates the [ActionStats] (keyed by action type ID) of
+// all blocks accepted in [Start, Start+interval).
+type ActionStatsBucket struct {
+	Start   int64                  `json:"start"`
+	Blocks  uint64                 `json:"blocks"`
+	Actions map[uint8]*ActionStats `json:"actions"`
+}
+
+// BlockActionStats returns the [ActionStats] (keyed by action type ID) of the
+// transactions in [blk]. [blk] must be processed.
+func BlockActionStats(
+	blk *StatelessBlock,
+	actionRegistry *codec.TypeParser[Action, *warp.Message, bool],
+) map[uint8]*ActionStats {
+	stats := map[uint8]*ActionStats{}
+	results := blk.Results()
+	for i, tx := range blk.Txs {
+		typeID, _, _, ok := actionRegistry.LookupType(tx.Action)
+		if !ok {
+			// This should never happen (we parsed the action)
+			continue
+		}
+		s, ok := stats[typeID]
+		if !ok {
+			s = &ActionStats{}
+			stats[typeID] = s
+		}
+		s.Count++
+		if results[i].Success {
+			s.Success++
+		}
+		s.Units += results[i].Units
+	}
+	return stats
+}
+
+func MarshalActionStats(stats map[uint8]*ActionStats) ([]byte, error) {
+	p := codec.NewWriter(consts.IntLen+len(stats)*actionStatsLen, consts.MaxInt)
+	p.PackInt(len(stats))
+	typeIDs := maps.Keys(stats)
+	slices.Sort(typeIDs)
+	for _, typeID := range typeIDs {
+		s := stats[typeID]
+		p.PackByte(typeID)
+		p.PackUint64(s.Count)
+		p.PackUint64(s.Success)
+		p.PackUint64(s.Units)
+	}
+	return p.Bytes(), p.Err()
+}
+
+func UnmarshalActionStats(src []byte) (map[uint8]*ActionStats, error) {
+	p := codec.NewReader(src, consts.MaxInt)
+	items := p.UnpackInt(false)
+	stats := make(map[uint8]*ActionStats, items)
+	for i := 0; i < items; i++ {
+		typeID := p.UnpackByte()
+		stats[typeID] = &ActionStats{
+			Count:   p.UnpackUint64(false),
+			Success: p.UnpackUint64(false),
+			Units:   p.UnpackUint64(false),
+		}
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		return nil, ErrInvalidObject
+	}
+	return stats, nil
+}
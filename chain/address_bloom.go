@@ -0,0 +1,85 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+const (
+	// addressBloomBitsPerAddress and addressBloomHashes result in a false
+	// positive rate of ~1%.
+	addressBloomBitsPerAddress = 10
+	addressBloomHashes         = 7
+
+	// minAddressBloomSize ensures that the bloom of a block without any
+	// transactions is still valid.
+	minAddressBloomSize = 8 // bytes
+)
+
+// AddressAction is an optional interface that an [Action] can implement to
+// report the addresses (other than the payer) that it touches. These addresses
+// are added to the [AddressBloom] of each block that includes the [Action].
+type AddressAction interface {
+	Addresses() [][]byte
+}
+
+// AddressBloom is a bloom filter of all addresses touched by a block. If
+// [Contains] returns false, the block definitely does not involve an address.
+type AddressBloom []byte
+
+// NewAddressBloom creates an [AddressBloom] that contains [addresses].
+func NewAddressBloom(addresses [][]byte) AddressBloom {
+	size := (len(addresses)*addressBloomBitsPerAddress + 7) / 8
+	if size < minAddressBloomSize {
+		size = minAddressBloomSize
+	}
+	b := make(AddressBloom, size)
+	for _, addr := range addresses {
+		for _, bit := range b.bits(addr) {
+			b[bit/8] |= 1 << (bit % 8)
+		}
+	}
+	return b
+}
+
+// bits derives the position of each hash of [addr] from a single sha256 digest.
+func (b AddressBloom) bits(addr []byte) [addressBloomHashes]uint32 {
+	h := hashing.ComputeHash256(addr)
+	m := uint32(len(b) * 8)
+	var bits [addressBloomHashes]uint32
+	for i := range bits {
+		bits[i] = binary.BigEndian.Uint32(h[i*4:]) % m
+	}
+	return bits
+}
+
+// Contains returns true if [addr] may have been touched by the block.
+func (b AddressBloom) Contains(addr []byte) bool {
+	if len(b) == 0 {
+		// An empty bloom is invalid, so we can't rule anything out
+		return true
+	}
+	for _, bit := range b.bits(addr) {
+		if b[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// BlockAddressBloom creates an [AddressBloom] of the payer of each transaction
+// in [blk] (and any addresses reported by its [Action]).
+func BlockAddressBloom(blk *StatelessBlock) AddressBloom {
+	addresses := make([][]byte, 0, len(blk.Txs))
+	for _, tx := range blk.Txs {
+		addresses = append(addresses, tx.Auth.Payer())
+		if action, ok := tx.Action.(AddressAction); ok {
+			addresses = append(addresses, action.Addresses()...)
+		}
+	}
+	return NewAddressBloom(addresses)
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+// AuthUnits returns the units charged for [authType] in addition to
+// [Auth.MaxUnits] (if [r] implements [AuthRules]).
+func AuthUnits(r Rules, authType uint8) uint64 {
+	ar, ok := r.(AuthRules)
+	if !ok {
+		return 0
+	}
+	configured, _, units := ar.GetAuthConfig(authType)
+	if !configured {
+		return 0
+	}
+	return units
+}
+
+// verifyAuthSize ensures the [Auth] of [t] does not exceed the max size of its
+// type (if [r] implements [AuthRules]).
+func (t *Transaction) verifyAuthSize(r Rules) error {
+	ar, ok := r.(AuthRules)
+	if !ok {
+		return nil
+	}
+	configured, maxSize, _ := ar.GetAuthConfig(t.authType)
+	if !configured || maxSize == 0 {
+		return nil
+	}
+	if t.Auth.Size() > maxSize {
+		return ErrAuthTooLarge
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
 )
 
 type Base struct {
@@ -17,8 +18,15 @@ type Base struct {
 	// ChainID protects against replay attacks on different VM instances.
 	ChainID ids.ID `json:"chainId"`
 
-	// Unit price is the value per unit to spend on this transaction.
-	UnitPrice uint64 `json:"unitPrice"`
+	// MaxUnitPrices is the most this transaction will pay per unit of each
+	// [fees.Dimension]. It is only included in blocks whose unit prices are
+	// all at or below it.
+	MaxUnitPrices fees.Dimensions `json:"maxUnitPrices"`
+
+	// Sequence is the position of this transaction in the sequence of
+	// transactions paid for by its payer. It must be 0 unless the chain enables
+	// sequence mode (see [SequenceRules]).
+	Sequence uint64 `json:"sequence"`
 }
 
 func (b *Base) Execute(chainID ids.ID, r Rules, timestamp int64) error {
@@ -32,7 +40,7 @@ func (b *Base) Execute(chainID ids.ID, r Rules, timestamp int64) error {
 		return ErrTimestampTooEarly
 	case b.ChainID != chainID:
 		return ErrInvalidChainID
-	case b.UnitPrice < r.GetMinUnitPrice():
+	case r.GetMinUnitPrice().Exceeds(b.MaxUnitPrices):
 		return ErrInvalidUnitPrice
 	default:
 		return nil
@@ -40,13 +48,14 @@ func (b *Base) Execute(chainID ids.ID, r Rules, timestamp int64) error {
 }
 
 func (*Base) Size() int {
-	return consts.Uint64Len*2 + consts.IDLen
+	return consts.Uint64Len*2 + consts.IDLen + fees.DimensionsLen
 }
 
 func (b *Base) Marshal(p *codec.Packer) {
 	p.PackInt64(b.Timestamp)
 	p.PackID(b.ChainID)
-	p.PackUint64(b.UnitPrice)
+	p.PackDimensions(b.MaxUnitPrices)
+	p.PackUint64(b.Sequence)
 }
 
 func UnmarshalBase(p *codec.Packer) (*Base, error) {
@@ -57,6 +66,7 @@ func UnmarshalBase(p *codec.Packer) (*Base, error) {
 		return nil, ErrMisalignedTime
 	}
 	p.UnpackID(true, &base.ChainID)
-	base.UnitPrice = p.UnpackUint64(true)
+	p.UnpackDimensions(&base.MaxUnitPrices)
+	base.Sequence = p.UnpackUint64(false)
 	return &base, p.Err()
 }
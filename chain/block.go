@@ -23,6 +23,8 @@ import (
 
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/tstate"
 	"github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/window"
 	"github.com/ava-labs/hypersdk/workers"
@@ -39,14 +41,14 @@ type StatefulBlock struct {
 	Tmstmp int64  `json:"timestamp"`
 	Hght   uint64 `json:"height"`
 
-	UnitPrice  uint64        `json:"unitPrice"`
-	UnitWindow window.Window `json:"unitWindow"`
+	UnitPrices  fees.Dimensions                   `json:"unitPrices"`
+	UnitWindows [fees.FeeDimensions]window.Window `json:"unitWindows"`
 
 	Txs []*Transaction `json:"txs"`
 
-	StateRoot     ids.ID     `json:"stateRoot"`
-	UnitsConsumed uint64     `json:"unitsConsumed"`
-	WarpResults   set.Bits64 `json:"warpResults"`
+	StateRoot     ids.ID          `json:"stateRoot"`
+	UnitsConsumed fees.Dimensions `json:"unitsConsumed"`
+	WarpResults   set.Bits64      `json:"warpResults"`
 }
 
 // warpJob is used to signal to a listner that a *warp.Message has been
@@ -59,7 +61,7 @@ type warpJob struct {
 	warpNum      int
 }
 
-func NewGenesisBlock(root ids.ID, minUnit uint64) *StatefulBlock {
+func NewGenesisBlock(root ids.ID, minUnitPrices fees.Dimensions) *StatefulBlock {
 	return &StatefulBlock{
 		// We set the genesis block timestamp to be after the ProposerVM fork activation.
 		//
@@ -71,8 +73,7 @@ func NewGenesisBlock(root ids.ID, minUnit uint64) *StatefulBlock {
 		// .../vms/proposervm/pre_fork_block.go#L201
 		Tmstmp: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC).UnixMilli(),
 
-		UnitPrice:  minUnit,
-		UnitWindow: window.Window{},
+		UnitPrices: minUnitPrices,
 
 		StateRoot: root,
 	}
@@ -101,6 +102,9 @@ type StatelessBlock struct {
 	state merkledb.TrieView
 
 	sigJob *workers.Job
+
+	profile *ExecutionProfile
+	diff    []*tstate.Change
 }
 
 func NewBlock(ectx *ExecutionContext, vm VM, parent snowman.Block, tmstp int64) *StatelessBlock {
@@ -110,8 +114,8 @@ func NewBlock(ectx *ExecutionContext, vm VM, parent snowman.Block, tmstp int64)
 			Tmstmp: tmstp,
 			Hght:   parent.Height() + 1,
 
-			UnitPrice:  ectx.NextUnitPrice,
-			UnitWindow: ectx.NextUnitWindow,
+			UnitPrices:  ectx.NextUnitPrices,
+			UnitWindows: ectx.NextUnitWindows,
 		},
 		vm: vm,
 		st: choices.Processing,
@@ -151,8 +155,17 @@ func (b *StatelessBlock) populateTxs(ctx context.Context) error {
 	_, sspan := b.vm.Tracer().Start(ctx, "StatelessBlock.verifySignatures")
 	b.txsSet = set.NewSet[ids.ID](len(b.Txs))
 	b.warpMessages = map[ids.ID]*warpJob{}
+	r := b.vm.Rules(b.Tmstmp)
+	sigs, cached := b.vm.(SignatureCache)
 	for _, tx := range b.Txs {
-		b.sigJob.Go(tx.AuthAsyncVerify())
+		if err := VerifyTxSize(r, tx.Size()); err != nil {
+			return err
+		}
+		// The ID of a tx commits to its signature, so a tx with the same ID as
+		// one we already verified doesn't need to be verified again
+		if !cached || !sigs.SignatureVerified(tx.ID()) {
+			b.sigJob.Go(tx.AuthAsyncVerify())
+		}
 		if b.txsSet.Contains(tx.ID()) {
 			return ErrDuplicateTx
 		}
@@ -329,9 +342,17 @@ func (b *StatelessBlock) verify(ctx context.Context, stateReady bool) error {
 			zap.Stringer("blkID", b.ID()),
 		)
 	default:
+		// Stop verifying as soon as the block no longer needs to be verified
+		vctx := ctx
+		if aborter, ok := b.vm.(AbortableVM); ok {
+			var done func()
+			vctx, done = aborter.VerifyContext(ctx, b.ID(), b.Prnt)
+			defer done()
+		}
+
 		// Parent may not be processed when we verify this block so [verify] may
 		// recursively compute missing state.
-		state, err := b.innerVerify(ctx)
+		state, err := b.innerVerify(vctx)
 		if err != nil {
 			return err
 		}
@@ -431,15 +452,15 @@ func (b *StatelessBlock) innerVerify(ctx context.Context) (merkledb.TrieView, er
 		return nil, err
 	}
 	switch {
-	case b.UnitPrice != ectx.NextUnitPrice:
+	case b.UnitPrices != ectx.NextUnitPrices:
 		return nil, ErrInvalidUnitPrice
-	case b.UnitWindow != ectx.NextUnitWindow:
+	case b.UnitWindows != ectx.NextUnitWindows:
 		return nil, ErrInvalidUnitWindow
 	}
 	log.Info(
 		"verify context",
 		zap.Uint64("height", b.Hght),
-		zap.Uint64("unit price", b.UnitPrice),
+		zap.Stringer("unit prices", b.UnitPrices),
 	)
 
 	// Start validating warp messages, if they exist
@@ -503,13 +524,37 @@ func (b *StatelessBlock) innerVerify(ctx context.Context) (merkledb.TrieView, er
 		return nil, err
 	}
 
+	// Record execution breakdown (if enabled)
+	if executionProfiling(b.vm) {
+		b.profile = &ExecutionProfile{
+			BlockID:   b.ID(),
+			Height:    b.Hght,
+			Timestamp: b.Tmstmp,
+			Txs:       make([]*TxProfile, 0, len(b.Txs)),
+		}
+	}
+
 	// Optimisticaly fetch state
 	processor := NewProcessor(b.vm.Tracer(), b)
 	processor.Prefetch(ctx, state)
 
 	// Process new transactions
+	start := time.Now()
 	unitsConsumed, results, stateChanges, stateOps, err := processor.Execute(ctx, ectx, r)
+	if b.profile != nil {
+		b.profile.Execution = time.Since(start)
+	}
 	if err != nil {
+		if ctx.Err() != nil {
+			b.recordVerifyAborted(remainingWork(time.Since(start), processor.executed, len(b.Txs)))
+			log.Info(
+				"aborted block execution",
+				zap.Stringer("blkID", b.ID()),
+				zap.Int("executed", processor.executed),
+				zap.Error(err),
+			)
+			return nil, err
+		}
 		log.Error("failed to execute block", zap.Error(err))
 		return nil, err
 	}
@@ -518,7 +563,7 @@ func (b *StatelessBlock) innerVerify(ctx context.Context) (merkledb.TrieView, er
 	b.results = results
 	if b.UnitsConsumed != unitsConsumed {
 		return nil, fmt.Errorf(
-			"%w: required=%d found=%d",
+			"%w: required=%v found=%v",
 			ErrInvalidUnitsConsumed,
 			unitsConsumed,
 			b.UnitsConsumed,
@@ -547,13 +592,23 @@ func (b *StatelessBlock) innerVerify(ctx context.Context) (merkledb.TrieView, er
 		return nil, err
 	}
 
+	// Don't compute the root of a block that no longer needs to be verified
+	if err := ctx.Err(); err != nil {
+		b.recordVerifyAborted(0)
+		return nil, err
+	}
+
 	// Compute state root
-	start := time.Now()
+	start = time.Now()
 	computedRoot, err := state.GetMerkleRoot(ctx)
 	if err != nil {
 		return nil, err
 	}
-	b.vm.RecordRootCalculated(time.Since(start))
+	rootCalculated := time.Since(start)
+	b.vm.RecordRootCalculated(rootCalculated)
+	if b.profile != nil {
+		b.profile.RootCalculation = rootCalculated
+	}
 	if b.StateRoot != computedRoot {
 		return nil, fmt.Errorf(
 			"%w: expected=%s found=%s",
@@ -570,7 +625,11 @@ func (b *StatelessBlock) innerVerify(ctx context.Context) (merkledb.TrieView, er
 	if err := b.sigJob.Wait(); err != nil {
 		return nil, err
 	}
-	b.vm.RecordWaitSignatures(time.Since(start))
+	waitSignatures := time.Since(start)
+	b.vm.RecordWaitSignatures(waitSignatures)
+	if b.profile != nil {
+		b.profile.SignatureWait = waitSignatures
+	}
 	return state, nil
 }
 
@@ -752,22 +811,32 @@ func (b *StatelessBlock) GetTimestamp() int64 {
 	return b.Tmstmp
 }
 
-func (b *StatelessBlock) GetUnitPrice() uint64 {
-	return b.UnitPrice
+func (b *StatelessBlock) GetUnitPrices() fees.Dimensions {
+	return b.UnitPrices
 }
 
 func (b *StatelessBlock) Results() []*Result {
 	return b.results
 }
 
+// PChainHeight returns the P-Chain height provided by the ProposerVM when [b]
+// was built or verified (if it was provided). This is the P-Chain height of
+// the parent of [b], which is used to determine who may propose [b].
+func (b *StatelessBlock) PChainHeight() (uint64, bool) {
+	if b.bctx == nil {
+		return 0, false
+	}
+	return b.bctx.PChainHeight, true
+}
+
 func (b *StatefulBlock) Marshal(
 	actionRegistry ActionRegistry,
 	authRegistry AuthRegistry,
 ) ([]byte, error) {
 	size := consts.IDLen + consts.Uint64Len + consts.Uint64Len +
-		consts.Uint64Len + window.WindowSliceSize +
+		fees.DimensionsLen + fees.FeeDimensions*window.WindowSliceSize +
 		consts.IntLen + codec.CummSize(b.Txs) +
-		consts.IDLen + consts.Uint64Len + consts.Uint64Len
+		consts.IDLen + fees.DimensionsLen + consts.Uint64Len
 
 	p := codec.NewWriter(size, consts.NetworkSizeLimit)
 
@@ -775,8 +844,10 @@ func (b *StatefulBlock) Marshal(
 	p.PackInt64(b.Tmstmp)
 	p.PackUint64(b.Hght)
 
-	p.PackUint64(b.UnitPrice)
-	p.PackWindow(b.UnitWindow)
+	p.PackDimensions(b.UnitPrices)
+	for _, w := range b.UnitWindows {
+		p.PackWindow(w)
+	}
 
 	p.PackInt(len(b.Txs))
 	for _, tx := range b.Txs {
@@ -786,7 +857,7 @@ func (b *StatefulBlock) Marshal(
 	}
 
 	p.PackID(b.StateRoot)
-	p.PackUint64(b.UnitsConsumed)
+	p.PackDimensions(b.UnitsConsumed)
 	p.PackUint64(uint64(b.WarpResults))
 	return p.Bytes(), p.Err()
 }
@@ -801,8 +872,10 @@ func UnmarshalBlock(raw []byte, parser Parser) (*StatefulBlock, error) {
 	b.Tmstmp = p.UnpackInt64(false)
 	b.Hght = p.UnpackUint64(false)
 
-	b.UnitPrice = p.UnpackUint64(false)
-	p.UnpackWindow(&b.UnitWindow)
+	p.UnpackDimensions(&b.UnitPrices)
+	for i := range b.UnitWindows {
+		p.UnpackWindow(&b.UnitWindows[i])
+	}
 	if err := p.Err(); err != nil {
 		// Check that header was parsed properly before unwrapping transactions
 		return nil, err
@@ -821,7 +894,7 @@ func UnmarshalBlock(raw []byte, parser Parser) (*StatefulBlock, error) {
 	}
 
 	p.UnpackID(false, &b.StateRoot)
-	b.UnitsConsumed = p.UnpackUint64(false)
+	p.UnpackDimensions(&b.UnitsConsumed)
 	b.WarpResults = set.Bits64(p.UnpackUint64(false))
 
 	if !p.Empty() {
@@ -0,0 +1,136 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// prefetchedKey is the result of reading a single key during [buildPrefetch].
+// [done] is closed once [v] and [err] are populated.
+type prefetchedKey struct {
+	done chan struct{}
+	v    []byte
+	err  error
+}
+
+// buildPrefetcher reads the state keys of the highest paying txs in the
+// [Mempool] in parallel (in batches) while a block is built, so that
+// [BuildBlock] doesn't need to read each key from disk right before it
+// executes the tx that uses it.
+//
+// buildPrefetcher implements [Database] so it can be passed to
+// [tstate.TState.FetchAndSetScope]. Keys that were not prefetched are read
+// from the underlying [Database] directly.
+type buildPrefetcher struct {
+	db   Database
+	keys map[string]*prefetchedKey // not modified after [buildPrefetch] returns
+	stop chan struct{}
+	wg   sync.WaitGroup
+
+	// only accessed by the caller of [GetValue]
+	hits   int
+	misses int
+	stall  time.Duration
+}
+
+// buildPrefetch starts reading the state keys of [txs] from [db] using
+// [concurrency] workers that each read the keys of [batchSize] txs at a time
+// (in the order of [txs]).
+func buildPrefetch(
+	ctx context.Context,
+	sm StateManager,
+	db Database,
+	txs []*Transaction,
+	concurrency int,
+	batchSize int,
+) *buildPrefetcher {
+	p := &buildPrefetcher{
+		db:   db,
+		keys: map[string]*prefetchedKey{},
+		stop: make(chan struct{}),
+	}
+
+	// Register all keys before starting any workers so that [GetValue] can
+	// wait for keys that are still being read
+	batches := make(chan [][]byte, (len(txs)+batchSize-1)/batchSize)
+	batch := [][]byte{}
+	for i, tx := range txs {
+		for _, k := range tx.StateKeys(sm) {
+			sk := string(k)
+			if _, ok := p.keys[sk]; ok {
+				continue
+			}
+			p.keys[sk] = &prefetchedKey{done: make(chan struct{})}
+			batch = append(batch, k)
+		}
+		if (i+1)%batchSize == 0 || i == len(txs)-1 {
+			batches <- batch
+			batch = [][]byte{}
+		}
+	}
+	close(batches)
+
+	for i := 0; i < concurrency; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+
+			for batch := range batches {
+				for _, k := range batch {
+					select {
+					case <-p.stop:
+						return
+					case <-ctx.Done():
+						return
+					default:
+					}
+					pk := p.keys[string(k)]
+					pk.v, pk.err = db.GetValue(ctx, k)
+					close(pk.done)
+				}
+			}
+		}()
+	}
+	return p
+}
+
+// GetValue returns the value of [key], waiting for it to be prefetched if
+// it is still being read.
+func (p *buildPrefetcher) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+	pk, ok := p.keys[string(key)]
+	if !ok {
+		p.misses++
+		return p.db.GetValue(ctx, key)
+	}
+	p.hits++
+	select {
+	case <-pk.done:
+	default:
+		start := time.Now()
+		select {
+		case <-pk.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		p.stall += time.Since(start)
+	}
+	return pk.v, pk.err
+}
+
+func (p *buildPrefetcher) Insert(ctx context.Context, key []byte, value []byte) error {
+	return p.db.Insert(ctx, key, value)
+}
+
+func (p *buildPrefetcher) Remove(ctx context.Context, key []byte) error {
+	return p.db.Remove(ctx, key)
+}
+
+// Stop waits for all workers to exit (without reading any remaining keys).
+func (p *buildPrefetcher) Stop() {
+	close(p.stop)
+	p.wg.Wait()
+}
@@ -0,0 +1,214 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+// countingDB is a [Database] that counts how many times each key is read. If
+// [onRead] is set, it is called before each read.
+type countingDB struct {
+	l      sync.Mutex
+	values map[string][]byte
+	reads  map[string]int
+	onRead func()
+}
+
+func newCountingDB(values map[string][]byte) *countingDB {
+	return &countingDB{values: values, reads: map[string]int{}}
+}
+
+func (db *countingDB) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	if db.onRead != nil {
+		db.onRead()
+	}
+	db.l.Lock()
+	defer db.l.Unlock()
+
+	db.reads[string(key)]++
+	v, ok := db.values[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return v, nil
+}
+
+func (db *countingDB) Insert(_ context.Context, key []byte, value []byte) error {
+	db.l.Lock()
+	defer db.l.Unlock()
+
+	db.values[string(key)] = value
+	return nil
+}
+
+func (db *countingDB) Remove(_ context.Context, key []byte) error {
+	db.l.Lock()
+	defer db.l.Unlock()
+
+	delete(db.values, string(key))
+	return nil
+}
+
+func (db *countingDB) totalReads() int {
+	db.l.Lock()
+	defer db.l.Unlock()
+
+	total := 0
+	for _, reads := range db.reads {
+		total += reads
+	}
+	return total
+}
+
+func TestBuildPrefetch(t *testing.T) {
+	var (
+		ctx    = context.TODO()
+		shared = []byte("shared")
+		txs    = []*Transaction{}
+	)
+	for i := 0; i < 5; i++ {
+		action := &testAction{keys: [][]byte{[]byte(fmt.Sprintf("k%d", i)), shared}}
+		txs = append(txs, newTestTx(fmt.Sprintf("p%d", i%2), 0, 1, action))
+	}
+	for _, concurrency := range []int{1, 2, 4} {
+		for _, batchSize := range []int{1, 2, 8} {
+			require := require.New(t)
+
+			// Only some keys exist
+			db := newCountingDB(map[string][]byte{
+				"k1":                             {1},
+				"shared":                         {2},
+				string(balanceKey([]byte("p0"))): {3},
+			})
+			p := buildPrefetch(ctx, testStateManager{}, db, txs, concurrency, batchSize)
+
+			// Prefetched keys return the value in [db] (or the error reading
+			// it), no matter how many txs use them
+			lookups := 0
+			for _, tx := range txs {
+				for _, k := range tx.StateKeys(testStateManager{}) {
+					v, err := p.GetValue(ctx, k)
+					expected, ok := db.values[string(k)]
+					if !ok {
+						require.ErrorIs(err, database.ErrNotFound)
+					} else {
+						require.NoError(err)
+						require.Equal(expected, v)
+					}
+					lookups++
+				}
+			}
+
+			// Keys that were not prefetched are read from [db]
+			_, err := p.GetValue(ctx, []byte("other"))
+			require.ErrorIs(err, database.ErrNotFound)
+			p.Stop()
+			require.Equal(lookups, p.hits)
+			require.Equal(1, p.misses)
+
+			// Each key is only read once
+			for k, reads := range db.reads {
+				require.Equal(1, reads, k)
+			}
+			require.Len(db.reads, len(p.keys)+1)
+		}
+	}
+}
+
+func TestBuildPrefetchStop(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx     = context.TODO()
+		txs     = []*Transaction{}
+		reading = make(chan struct{}, 1)
+		release = make(chan struct{})
+	)
+	for i := 0; i < 4; i++ {
+		action := &testAction{keys: [][]byte{[]byte(fmt.Sprintf("k%d", i))}}
+		txs = append(txs, newTestTx("a", 0, 1, action))
+	}
+	db := newCountingDB(map[string][]byte{})
+	db.onRead = func() {
+		select {
+		case reading <- struct{}{}:
+		default:
+		}
+		<-release
+	}
+	p := buildPrefetch(ctx, testStateManager{}, db, txs, 1, 1)
+
+	// Once stopped, the remaining keys are not read
+	<-reading
+	stopped := make(chan struct{})
+	go func() {
+		p.Stop()
+		close(stopped)
+	}()
+	<-p.stop
+	close(release)
+	<-stopped
+	require.Equal(1, db.totalReads())
+
+	// Keys that were never read don't block forever
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err := p.GetValue(ctx, []byte("k3"))
+	require.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestBuildBlockPrefetch(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		expiry  = (time.Now().UnixMilli()/1000 + 10) * 1000
+		counter = []byte("counter")
+		rules   = &testRules{maxBlockUnits: fees.Dimensions{1 << 20, 1 << 20, 1 << 20, 1 << 20, 1 << 20}}
+	)
+	txs := []*Transaction{
+		newTestTx("a", expiry, 200, &testAction{keys: [][]byte{counter}}),
+		newTestTx("b", expiry, 190, &testAction{keys: [][]byte{[]byte("b0")}}),
+		newTestTx("e", expiry, 180, &testAction{keys: [][]byte{[]byte("e0")}}), // can't pay
+		newTestTx("c", expiry, 170, &testAction{keys: [][]byte{counter}, fail: true}),
+		newTestTx("a", expiry, 160, &testAction{keys: [][]byte{[]byte("a0")}}),
+		newTestTx("b", expiry, 150, &testAction{keys: [][]byte{counter}}),
+	}
+	payers := []string{"a", "b", "c"}
+
+	for _, workers := range []int{1, 4} {
+		require := require.New(t)
+
+		// Prefetching doesn't change the outcome of building a block
+		expected, _, err := buildTestBlock(ctx, t, &testVM{rules: rules, workers: workers}, txs, payers...)
+		require.NoError(err)
+		require.Len(expected.Txs, 5)
+		for _, batchSize := range []int{1, 4} {
+			vm := &testVM{
+				rules:               rules,
+				workers:             workers,
+				prefetchConcurrency: 2,
+				prefetchBatchSize:   batchSize,
+			}
+			blk, _, err := buildTestBlock(ctx, t, vm, txs, payers...)
+			require.NoError(err)
+			require.Equal(expected.Txs, blk.Txs)
+			require.Equal(expected.UnitsConsumed, blk.UnitsConsumed)
+			require.Equal(expected.results, blk.results)
+			require.Equal(expected.StateRoot, blk.StateRoot)
+
+			// The state of every tx was prefetched
+			require.Positive(vm.prefetchHits)
+			require.Zero(vm.prefetchMisses)
+		}
+	}
+}
@@ -15,6 +15,9 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/mempool"
 	"github.com/ava-labs/hypersdk/tstate"
 )
 
@@ -36,8 +39,16 @@ func HandlePreExecute(
 		return true, false, false
 	case errors.Is(err, ErrAuthFailed):
 		return true, false, false
+	case errors.Is(err, ErrAuthTooLarge):
+		return true, false, false
+	case errors.Is(err, ErrTxTooLarge):
+		return true, false, false
 	case errors.Is(err, ErrActionNotActivated):
 		return true, false, false
+	case errors.Is(err, ErrSequenceTooHigh):
+		return true, true, false
+	case errors.Is(err, ErrSequenceTooLow):
+		return true, false, false
 	default:
 		// If unknown error, drop
 		return true, false, false
@@ -54,6 +65,14 @@ func BuildBlock(
 	defer span.End()
 	log := vm.Logger()
 
+	// Stop building as soon as the block is no longer needed
+	aborter, abortable := vm.(AbortableVM)
+	if abortable {
+		var done func()
+		ctx, done = aborter.BuildContext(ctx, preferred)
+		defer done()
+	}
+
 	mempoolSize := vm.Mempool().Len(ctx)
 	if mempoolSize == 0 {
 		log.Warn("block building failed", zap.Error(ErrNoTxs))
@@ -76,6 +95,7 @@ func BuildBlock(
 		return nil, err
 	}
 	b := NewBlock(ectx, vm, parent, nextTime)
+	b.bctx = blockContext
 
 	changesEstimate := math.Min(mempoolSize, maxViewPreallocation)
 	state, err := parent.childState(ctx, changesEstimate)
@@ -85,13 +105,31 @@ func BuildBlock(
 	}
 	ts := tstate.New(changesEstimate)
 
+	// Read the state of the txs we are most likely to include in parallel
+	var (
+		db         Database = state
+		prefetcher *buildPrefetcher
+	)
+	pb, prefetch := vm.(PrefetchingBuilder)
+	peeker, peeking := vm.Mempool().(PeekingMempool)
+	if prefetch && peeking && pb.GetBuildPrefetchConcurrency() > 0 && pb.GetBuildPrefetchBatchSize() > 0 {
+		prefetcher = buildPrefetch(
+			ctx,
+			vm.StateManager(),
+			state,
+			peeker.PeekTopN(ctx, changesEstimate),
+			pb.GetBuildPrefetchConcurrency(),
+			pb.GetBuildPrefetchBatchSize(),
+		)
+		db = prefetcher
+	}
+
 	// Restorable txs after block attempt finishes
 	b.Txs = []*Transaction{}
 	var (
 		oldestAllowed = nextTime - r.GetValidityWindow()
 
-		surplusFee = uint64(0)
-		mempool    = vm.Mempool()
+		mp = vm.Mempool()
 
 		txsAttempted = 0
 		results      = []*Result{}
@@ -104,147 +142,508 @@ func BuildBlock(
 		start    = time.Now()
 		lockWait time.Duration
 	)
-	mempoolErr := mempool.Build(
-		ctx,
-		func(fctx context.Context, next *Transaction) (cont bool, restore bool, removeAcct bool, err error) {
-			if txsAttempted == 0 {
-				lockWait = time.Since(start)
-			}
-			txsAttempted++
-
-			// Ensure we can process if transaction includes a warp message
-			if next.WarpMessage != nil && blockContext == nil {
-				log.Info(
-					"dropping pending warp message because no context provided",
-					zap.Stringer("txID", next.ID()),
-				)
-				return true, next.Base.Timestamp > oldestAllowed, false, nil
-			}
 
-			// Skip warp message if at max
-			if next.WarpMessage != nil && warpCount == MaxWarpMessages {
-				log.Info(
-					"dropping pending warp message because already have MaxWarpMessages",
-					zap.Stringer("txID", next.ID()),
-				)
-				return true, true, false, nil
-			}
+	// When the VM executes txs in parallel, txs are executed speculatively in
+	// batches (see [flush]). While a batch is included, [dirty] holds the keys
+	// modified differently than they were during its speculative execution.
+	pe, parallel := vm.(ParallelExecutor)
+	parallel = parallel && pe.GetExecutionWorkers() > 1
+	var (
+		batch      []*speculativeTx
+		batchUnits fees.Dimensions
+		batchWarps int
+		dirty      map[string]struct{}
 
-			// Check for repeats
-			//
-			// TODO: check a bunch at once during pre-fetch to avoid re-walking blocks
-			// for every tx
-			dup, err := parent.IsRepeat(ctx, oldestAllowed, []*Transaction{next})
-			if err != nil {
-				return false, false, false, err
+		// payers whose txs are restored (or whose accounts are removed) while
+		// a batch is included are handled as if the mempool had done so
+		restoredPayers = map[string]struct{}{}
+		removedPayers  = map[string]struct{}{}
+		restored       = []*Transaction{}
+
+		busy, elapsed time.Duration
+	)
+	skipping, skippable := mp.(SkippingMempool)
+	markDirty := func(next *Transaction, keys [][]byte) {
+		if dirty == nil {
+			return
+		}
+		// Keys that [next] only reads are never modified by it
+		readOnly := next.ReadOnlyKeys(sm)
+		for _, k := range keys {
+			if _, ok := readOnly[string(k)]; ok {
+				continue
 			}
-			if dup {
-				// tx will be restored when ancestry is rejected
-				return true, false, false, nil
+			dirty[string(k)] = struct{}{}
+		}
+	}
+
+	// hasRoom returns true if the block has room for [next] (which uses at
+	// most [nextUnits]) after any txs in [batch].
+	hasRoom := func(next *Transaction, nextUnits fees.Dimensions) bool {
+		if next.WarpMessage != nil && warpCount+batchWarps == MaxWarpMessages {
+			return false
+		}
+		withNext, err := fees.Add(b.UnitsConsumed, batchUnits)
+		if err != nil {
+			return false
+		}
+		withNext, err = fees.Add(withNext, nextUnits)
+		return err == nil && !withNext.Exceeds(r.GetMaxBlockUnits())
+	}
+
+	// Verify warp message, if it exists
+	//
+	// We don't drop invalid warp messages because we must collect fees for
+	// the work the sender made us do (otherwise this would be a DoS).
+	//
+	// We wait as long as possible to verify the signature to ensure we don't
+	// spend unnecessary time on an invalid tx.
+	verifyWarp := func(ctx context.Context, next *Transaction) error {
+		// We do not check the validity of [SourceChainID] because a VM could send
+		// itself a message to trigger a chain upgrade.
+		var warpErr error
+		allowed, num, denom := r.GetWarpConfig(next.WarpMessage.SourceChainID)
+		if allowed {
+			warpErr = next.WarpMessage.Signature.Verify(
+				ctx, &next.WarpMessage.UnsignedMessage, r.NetworkID(),
+				vdrState, blockContext.PChainHeight, num, denom,
+			)
+		} else {
+			warpErr = ErrDisabledChainID
+		}
+		if warpErr != nil {
+			log.Warn(
+				"warp verification failed",
+				zap.Stringer("txID", next.ID()),
+				zap.Error(warpErr),
+			)
+		}
+		return warpErr
+	}
+
+	// When sequence mode is enabled, txs that are popped before the txs
+	// preceding them (from the same payer) are deferred and attempted again as
+	// soon as their predecessor is included.
+	var (
+		deferred = map[string][]*Transaction{}
+		include  func(context.Context, *Transaction) (bool, bool, bool, error)
+	)
+	preExecuteFailed := func(next *Transaction, err error) (bool, bool, bool, error) {
+		if errors.Is(err, ErrSequenceTooHigh) {
+			// Attempt again once the payer's preceding tx is included
+			payer := next.Payer()
+			deferred[payer] = append(deferred[payer], next)
+			return true, false, false, nil
+		}
+		cont, restore, removeAcct := HandlePreExecute(err)
+		if errors.Is(err, ErrTimestampTooLate) {
+			// Recorded as expired (rather than built) by the mempool
+			return cont, restore, removeAcct, mempool.ErrExpired
+		}
+		return cont, restore, removeAcct, nil
+	}
+	included := func(fctx context.Context, next *Transaction, result *Result, warpErr error) (bool, bool, bool, error) {
+		// Update block with new transaction
+		var err error
+		b.Txs = append(b.Txs, next)
+		b.UnitsConsumed, err = fees.Add(b.UnitsConsumed, result.Consumed)
+		if err != nil {
+			// Should never happen (consumed units are bounded by [nextUnits])
+			return false, false, false, err
+		}
+		results = append(results, result)
+		if next.WarpMessage != nil {
+			if warpErr == nil {
+				// Add a bit if the warp message was verified
+				b.WarpResults.Add(uint(warpCount))
 			}
+			warpCount++
+		}
 
-			// Ensure we have room
-			nextUnits, err := next.MaxUnits(r)
-			if err != nil {
-				// Should never happen
-				log.Debug(
-					"skipping invalid tx",
-					zap.Error(err),
-				)
-				return true, false, false, nil
+		// Attempt any deferred tx that was waiting on [next]
+		payer := next.Payer()
+		for i, dtx := range deferred[payer] {
+			if dtx.Base.Sequence != next.Base.Sequence+1 {
+				continue
+			}
+			deferred[payer] = append(deferred[payer][:i:i], deferred[payer][i+1:]...)
+			dcont, drestore, dremoveAcct, err := include(fctx, dtx)
+			if drestore {
+				deferred[payer] = append(deferred[payer], dtx)
 			}
-			if b.UnitsConsumed+nextUnits > r.GetMaxBlockUnits() {
-				log.Debug(
-					"skipping tx: too many units",
-					zap.Uint64("block units", b.UnitsConsumed),
-					zap.Uint64("tx max units", nextUnits),
-				)
-				return false /* make simpler */, true, false, nil // could be txs that fit that are smaller
+			if errors.Is(err, mempool.ErrExpired) {
+				// [dtx] was already popped from the mempool
+				err = nil
 			}
+			// [next] was included, so it should never be restored
+			return dcont, false, dremoveAcct, err
+		}
+		return true, false, false, nil
+	}
+
+	// include attempts to include [next] by executing it on the state of the
+	// block.
+	include = func(fctx context.Context, next *Transaction) (cont bool, restore bool, removeAcct bool, err error) {
+		if err := fctx.Err(); err != nil {
+			return false, true, false, err
+		}
+		if txsAttempted == 0 {
+			lockWait = time.Since(start)
+		}
+		txsAttempted++
+
+		// Ensure we can process if transaction includes a warp message
+		if next.WarpMessage != nil && blockContext == nil {
+			log.Info(
+				"dropping pending warp message because no context provided",
+				zap.Stringer("txID", next.ID()),
+			)
+			return true, next.Base.Timestamp > oldestAllowed, false, nil
+		}
+
+		// Skip warp message if at max
+		if next.WarpMessage != nil && warpCount == MaxWarpMessages {
+			log.Info(
+				"dropping pending warp message because already have MaxWarpMessages",
+				zap.Stringer("txID", next.ID()),
+			)
+			return true, true, false, nil
+		}
+
+		// Check for repeats
+		//
+		// TODO: check a bunch at once during pre-fetch to avoid re-walking blocks
+		// for every tx
+		dup, err := parent.IsRepeat(ctx, oldestAllowed, []*Transaction{next})
+		if err != nil {
+			return false, false, false, err
+		}
+		if dup {
+			// tx will be restored when ancestry is rejected
+			return true, false, false, nil
+		}
+
+		// Ensure we have room
+		nextUnits, err := next.MaxUnits(r)
+		if err != nil {
+			// Should never happen
+			log.Debug(
+				"skipping invalid tx",
+				zap.Error(err),
+			)
+			return true, false, false, nil
+		}
+		if !hasRoom(next, nextUnits) {
+			log.Debug(
+				"skipping tx: too many units",
+				zap.Stringer("block units", b.UnitsConsumed),
+				zap.Stringer("tx max units", nextUnits),
+			)
+			return false /* make simpler */, true, false, nil // could be txs that fit that are smaller
+		}
+
+		// Populate required transaction state and restrict which keys can be used
+		txStart := ts.OpIndex()
+		keys := next.StateKeys(sm)
+		if err := ts.FetchAndSetScope(ctx, keys, db); err != nil {
+			return false, true, false, err
+		}
+
+		// PreExecute next to see if it is fit
+		if err := next.PreExecute(fctx, ectx, r, sm, ts, nextTime); err != nil {
+			ts.Rollback(ctx, txStart)
+			return preExecuteFailed(next, err)
+		}
+		var warpErr error
+		if next.WarpMessage != nil {
+			warpErr = verifyWarp(ctx, next)
+		}
 
-			// Populate required transaction state and restrict which keys can be used
-			//
-			// TODO: prefetch state of upcoming txs that we will pull (should make much
-			// faster)
-			txStart := ts.OpIndex()
-			if err := ts.FetchAndSetScope(ctx, next.StateKeys(sm), state); err != nil {
-				return false, true, false, err
+		// If execution works, keep moving forward with new state
+		result, err := next.Execute(
+			fctx,
+			ectx,
+			r,
+			sm,
+			ts,
+			nextTime,
+			next.WarpMessage != nil && warpErr == nil,
+		)
+		if err != nil {
+			// This error should only be raised by the handler, not the
+			// implementation itself
+			log.Warn("unexpected post-execution error", zap.Error(err))
+			return false, false, false, err
+		}
+		markDirty(next, keys)
+		return included(fctx, next, result, warpErr)
+	}
+
+	// commit includes [stx] (which was executed speculatively) if [include]
+	// would have. If any state [stx] observed was modified differently since,
+	// [stx] is executed again.
+	commit := func(fctx context.Context, stx *speculativeTx) (bool, bool, bool, error) {
+		if err := fctx.Err(); err != nil {
+			return false, true, false, err
+		}
+		next := stx.tx
+		payer := next.Payer()
+		if _, ok := removedPayers[payer]; ok {
+			markDirty(next, stx.keys)
+			return true, false, false, nil
+		}
+		if _, ok := restoredPayers[payer]; ok {
+			markDirty(next, stx.keys)
+			return true, true, false, nil
+		}
+		for _, k := range stx.keys {
+			if _, ok := dirty[string(k)]; ok {
+				// Later txs may have observed the changes of [stx]
+				markDirty(next, stx.keys)
+				txsAttempted--
+				return include(fctx, next)
 			}
+		}
 
-			// PreExecute next to see if it is fit
-			if err := next.PreExecute(fctx, ectx, r, ts, nextTime); err != nil {
-				ts.Rollback(ctx, txStart)
-				cont, restore, removeAcct := HandlePreExecute(err)
-				return cont, restore, removeAcct, nil
+		// Ensure we still have room (deferred txs may have been included since
+		// [stx] was added to the batch)
+		if next.WarpMessage != nil && warpCount == MaxWarpMessages {
+			markDirty(next, stx.keys)
+			return true, true, false, nil
+		}
+		nextUnits, err := next.MaxUnits(r)
+		if err != nil {
+			// Should never happen
+			return true, false, false, nil
+		}
+		if !hasRoom(next, nextUnits) {
+			markDirty(next, stx.keys)
+			return false, true, false, nil
+		}
+		if stx.preErr != nil {
+			return preExecuteFailed(next, stx.preErr)
+		}
+
+		// Apply the changes of [stx] to the state of the block
+		if err := ts.FetchAndSetScope(ctx, stx.keys, db); err != nil {
+			return false, true, false, err
+		}
+		for k, v := range stx.changes {
+			if v.Exists {
+				err = ts.Insert(ctx, []byte(k), v.V)
+			} else {
+				err = ts.Remove(ctx, []byte(k))
+			}
+			if err != nil {
+				return false, false, false, err
 			}
+		}
+		return included(fctx, next, stx.result, stx.warpErr)
+	}
 
-			// Verify warp message, if it exists
-			//
-			// We don't drop invalid warp messages because we must collect fees for
-			// the work the sender made us do (otherwise this would be a DoS).
-			//
-			// We wait as long as possible to verify the signature to ensure we don't
-			// spend unnecessary time on an invalid tx.
-			var warpErr error
-			if next.WarpMessage != nil {
-				// We do not check the validity of [SourceChainID] because a VM could send
-				// itself a message to trigger a chain upgrade.
-				allowed, num, denom := r.GetWarpConfig(next.WarpMessage.SourceChainID)
-				if allowed {
-					warpErr = next.WarpMessage.Signature.Verify(
-						ctx, &next.WarpMessage.UnsignedMessage, r.NetworkID(),
-						vdrState, blockContext.PChainHeight, num, denom,
-					)
-				} else {
-					warpErr = ErrDisabledChainID
+	// flush executes the txs in [batch] in parallel and then includes them in
+	// order. flush returns false if building should stop.
+	flush := func(fctx context.Context) (bool, error) {
+		txs := batch
+		batch, batchUnits, batchWarps = nil, fees.Dimensions{}, 0
+		if len(txs) == 0 {
+			return true, nil
+		}
+		for i, stx := range txs {
+			if err := ts.FetchAndSetScope(ctx, stx.keys, db); err != nil {
+				for _, rest := range txs[i:] {
+					restored = append(restored, rest.tx)
 				}
-				if warpErr != nil {
-					log.Warn(
-						"warp verification failed",
-						zap.Stringer("txID", next.ID()),
-						zap.Error(warpErr),
-					)
+				return false, err
+			}
+			stx.storage = make(map[string][]byte, len(stx.keys))
+			for _, k := range stx.keys {
+				v, err := ts.GetValue(ctx, k)
+				if err == nil {
+					stx.storage[string(k)] = v
 				}
 			}
-
-			// If execution works, keep moving forward with new state
-			result, err := next.Execute(
-				fctx,
-				r,
-				sm,
-				ts,
-				nextTime,
-				next.WarpMessage != nil && warpErr == nil,
-			)
-			if err != nil {
-				// This error should only be raised by the handler, not the
-				// implementation itself
-				log.Warn("unexpected post-execution error", zap.Error(err))
-				return false, false, false, err
+		}
+		specStart := time.Now()
+		specBusy, err := speculate(fctx, ectx, r, sm, nextTime, txs, pe.GetExecutionWorkers(), verifyWarp)
+		busy += specBusy
+		elapsed += time.Since(specStart)
+		if err != nil {
+			for _, stx := range txs {
+				restored = append(restored, stx.tx)
 			}
+			return false, err
+		}
 
-			// Update block with new transaction
-			b.Txs = append(b.Txs, next)
-			b.UnitsConsumed += result.Units
-			surplusFee += (next.Base.UnitPrice - b.UnitPrice) * result.Units
-			results = append(results, result)
-			if next.WarpMessage != nil {
-				if warpErr == nil {
-					// Add a bit if the warp message was verified
-					b.WarpResults.Add(uint(warpCount))
+		dirty = map[string]struct{}{}
+		defer func() {
+			dirty = nil
+		}()
+		for i, stx := range txs {
+			cont, restore, removeAcct, err := commit(fctx, stx)
+			payer := stx.tx.Payer()
+			if restore {
+				restored = append(restored, stx.tx)
+				if skippable {
+					restoredPayers[payer] = struct{}{}
+				}
+			}
+			if removeAcct {
+				removedPayers[payer] = struct{}{}
+			}
+			if errors.Is(err, mempool.ErrExpired) {
+				// [stx] was already popped from the mempool
+				err = nil
+			}
+			if !cont || err != nil {
+				// The remaining txs would not have been popped from the mempool
+				for _, rest := range txs[i+1:] {
+					restored = append(restored, rest.tx)
 				}
-				warpCount++
+				return false, err
 			}
+		}
+		return true, nil
+	}
+
+	// skipPayer returns true if [next] must not be attempted because its payer
+	// had txs restored (or its account removed) in an earlier batch, along
+	// with how [Mempool.Build] would have handled [next].
+	skipPayer := func(next *Transaction) (bool, bool, bool) {
+		payer := next.Payer()
+		if _, ok := removedPayers[payer]; ok {
+			return true, false, true
+		}
+		if _, ok := restoredPayers[payer]; ok {
+			return true, true, false
+		}
+		return false, false, false
+	}
+
+	// flushAndInclude flushes [batch] and then attempts [next] with [include]
+	// (unless the batch restored or removed its payer).
+	flushAndInclude := func(fctx context.Context, next *Transaction) (bool, bool, bool, error) {
+		if cont, err := flush(fctx); !cont || err != nil {
+			return false, true, false, err
+		}
+		if skip, restore, removeAcct := skipPayer(next); skip {
+			return true, restore, removeAcct, nil
+		}
+		return include(fctx, next)
+	}
+
+	// enqueue adds [next] to [batch] if it can be executed speculatively.
+	// Otherwise, [batch] is flushed and [next] is attempted with [include].
+	enqueue := func(fctx context.Context, next *Transaction) (bool, bool, bool, error) {
+		if err := fctx.Err(); err != nil {
+			return false, true, false, err
+		}
+
+		if skip, restore, removeAcct := skipPayer(next); skip {
+			return true, restore, removeAcct, nil
+		}
+
+		// Only txs that [include] would execute (given enough room) are
+		// executed speculatively
+		nextUnits, err := next.MaxUnits(r)
+		if err != nil || (next.WarpMessage != nil && blockContext == nil) || !hasRoom(next, nextUnits) {
+			return flushAndInclude(fctx, next)
+		}
+		dup, err := parent.IsRepeat(ctx, oldestAllowed, []*Transaction{next})
+		if err != nil || dup {
+			return flushAndInclude(fctx, next)
+		}
+		if txsAttempted == 0 {
+			lockWait = time.Since(start)
+		}
+		txsAttempted++
+		if next.Base.Timestamp%consts.MillisecondsPerSecond == 0 && next.Base.Timestamp < nextTime {
+			// Recorded as expired (like [include] would) by the mempool
+			return true, false, false, mempool.ErrExpired
+		}
+
+		batch = append(batch, &speculativeTx{
+			tx:       next,
+			keys:     next.StateKeys(sm),
+			readOnly: next.ReadOnlyKeys(sm),
+		})
+		batchUnits, err = fees.Add(batchUnits, nextUnits)
+		if err != nil {
+			// Should never happen ([hasRoom] checks the same sum)
+			return false, true, false, err
+		}
+		if next.WarpMessage != nil {
+			batchWarps++
+		}
+		if len(batch) < pe.GetExecutionMaxParallelism() {
 			return true, false, false, nil
-		},
+		}
+		cont, err := flush(fctx)
+		return cont, false, false, err
+	}
+
+	var (
+		f          = include
+		txsSkipped int
+		mempoolErr error
 	)
+	if parallel {
+		f = enqueue
+	}
+	if skippable {
+		txsSkipped, mempoolErr = skipping.BuildSkipping(ctx, f)
+	} else {
+		mempoolErr = mp.Build(ctx, f)
+	}
+	if parallel {
+		if mempoolErr == nil {
+			_, mempoolErr = flush(ctx)
+		}
+		for _, stx := range batch {
+			restored = append(restored, stx.tx)
+		}
+		if elapsed > 0 {
+			pe.RecordExecutionParallelism(float64(busy) / float64(elapsed))
+		}
+	}
+	vm.RecordTxsSkipped(txsSkipped)
+	if prefetcher != nil {
+		prefetcher.Stop()
+		pb.RecordBuildPrefetch(prefetcher.hits, prefetcher.misses, prefetcher.stall)
+	}
+
+	// Restore any deferred (or speculatively executed) txs that were not
+	// included (unless their account was removed)
+	restorable := []*Transaction{}
+	for _, txs := range deferred {
+		restorable = append(restorable, txs...)
+	}
+	for _, tx := range restored {
+		if _, ok := removedPayers[tx.Payer()]; ok {
+			continue
+		}
+		restorable = append(restorable, tx)
+	}
+	if len(restorable) > 0 {
+		mp.Add(ctx, restorable)
+	}
 	span.SetAttributes(
 		attribute.Int("attempted", txsAttempted),
+		attribute.Int("skipped", txsSkipped),
 		attribute.Int("added", len(b.Txs)),
 	)
 	if mempoolErr != nil {
 		b.vm.Mempool().Add(ctx, b.Txs)
-		return nil, err
+		if abortable && ctx.Err() != nil {
+			aborter.RecordBuildAborted(remainingWork(time.Since(start), txsAttempted, mempoolSize))
+			log.Info(
+				"aborted block building",
+				zap.Uint64("hght", b.Hght),
+				zap.Int("attempted", txsAttempted),
+				zap.Error(mempoolErr),
+			)
+		}
+		return nil, mempoolErr
 	}
 
 	// Perform basic validity checks to make sure the block is well-formatted
@@ -256,12 +655,24 @@ func BuildBlock(
 	if err := ts.WriteChanges(ctx, state, vm.Tracer()); err != nil {
 		return nil, err
 	}
+	if recordingStateDiffs(vm) {
+		b.diff = ts.Changes()
+	}
 
 	// Store height in state to prevent duplicate roots
 	if err := state.Insert(ctx, sm.HeightKey(), binary.BigEndian.AppendUint64(nil, b.Hght)); err != nil {
 		return nil, err
 	}
 
+	// Don't compute the root of a block that is no longer needed
+	if err := ctx.Err(); err != nil {
+		b.vm.Mempool().Add(ctx, b.Txs)
+		if abortable {
+			aborter.RecordBuildAborted(0)
+		}
+		return nil, err
+	}
+
 	// Compute state root after all data has been written to trie
 	root, err := state.GetMerkleRoot(ctx)
 	if err != nil {
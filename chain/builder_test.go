@@ -0,0 +1,213 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/mempool"
+	htrace "github.com/ava-labs/hypersdk/trace"
+)
+
+// buildTestBlock builds a block with [vm] (which is populated with the state
+// of [payers] and a mempool that contains [txs]). The mempool is returned with
+// any txs that were not included.
+func buildTestBlock(
+	ctx context.Context,
+	t *testing.T,
+	vm *testVM,
+	txs []*Transaction,
+	payers ...string,
+) (*StatelessBlock, Mempool, error) {
+	require := require.New(t)
+
+	tracer, err := htrace.New(&htrace.Config{Enabled: false})
+	require.NoError(err)
+	for _, tx := range txs {
+		// Like [VM.Submit], the units of each tx are computed before it is
+		// added to the mempool
+		_, err := tx.MaxUnits(vm.rules)
+		require.NoError(err)
+	}
+	mp := mempool.New[*Transaction](tracer, 100, 0, mempool.FixedQuota(100), nil)
+	mp.Add(ctx, txs)
+	require.Equal(len(txs), mp.Len(ctx))
+
+	parent := &StatelessBlock{
+		StatefulBlock: NewGenesisBlock(ids.Empty, testMinUnitPrices),
+		id:            ids.GenerateTestID(),
+	}
+	vm.tracer = tracer
+	vm.state = newTestState(t, payers...)
+	vm.mempool = mp
+	vm.parent = parent
+	if vm.maxParallelism == 0 {
+		vm.maxParallelism = 3
+	}
+	parent.vm = vm
+	blk, err := BuildBlock(ctx, vm, parent.ID(), nil)
+	return blk, mp, err
+}
+
+func TestBuildBlockParallel(t *testing.T) {
+	var (
+		ctx    = context.TODO()
+		expiry = (time.Now().UnixMilli()/1000 + 10) * 1000
+
+		counter = []byte("counter")
+		removed = []byte("removed")
+	)
+	// Txs are popped from the mempool in order (as each pays a lower fee than
+	// the one before it)
+	txs := []*Transaction{
+		newTestTx("a", expiry, 200, &testAction{keys: [][]byte{counter}}),
+		newTestTx("b", expiry, 190, &testAction{keys: [][]byte{[]byte("b0")}}),
+		newTestTx("e", expiry, 180, &testAction{keys: [][]byte{[]byte("e0")}}), // can't pay
+		newTestTx("c", expiry, 170, &testAction{keys: [][]byte{counter}, fail: true}),
+		newTestTx("a", expiry, 160, &testAction{keys: [][]byte{[]byte("a0")}}),
+		newTestTx("e", expiry, 150, &testAction{keys: [][]byte{counter}}), // removed with its payer
+		newTestTx("b", expiry, 140, &testAction{keys: [][]byte{counter, removed}, remove: true}),
+		newTestTx("d", expiry, 130, &testAction{keys: [][]byte{[]byte("d0")}}),
+		newTestTx("c", expiry, 120, &testAction{keys: [][]byte{counter, removed}}),
+		newTestTx("d", expiry, 110, &testAction{keys: [][]byte{[]byte("d1")}}),
+		newTestTx("a", expiry, 100, &testAction{keys: [][]byte{counter}}),
+	}
+	payers := []string{"a", "b", "c", "d"}
+
+	for name, tt := range map[string]struct {
+		maxBlockUnits fees.Dimensions
+		included      int
+		remaining     int
+	}{
+		"all txs fit": {
+			maxBlockUnits: fees.Dimensions{1 << 20, 1 << 20, 1 << 20, 1 << 20, 1 << 20},
+			included:      9,
+			remaining:     0,
+		},
+		"block is full": {
+			maxBlockUnits: fees.Dimensions{5 * testTxSize, 1 << 20, 1 << 20, 1 << 20, 1 << 20},
+			included:      5,
+			remaining:     4,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			// Building a block in parallel has the same outcome as building
+			// it sequentially
+			rules := &testRules{maxBlockUnits: tt.maxBlockUnits}
+			sequential, sequentialMempool, err := buildTestBlock(ctx, t, &testVM{rules: rules, workers: 1}, txs, payers...)
+			require.NoError(err)
+			require.Len(sequential.Txs, tt.included)
+			require.Equal(tt.remaining, sequentialMempool.Len(ctx))
+			for _, workers := range []int{2, 4} {
+				blk, mp, err := buildTestBlock(ctx, t, &testVM{rules: rules, workers: workers}, txs, payers...)
+				require.NoError(err)
+				require.Equal(sequential.Txs, blk.Txs)
+				require.Equal(sequential.UnitsConsumed, blk.UnitsConsumed)
+				require.Equal(sequential.results, blk.results)
+				require.Equal(sequential.StateRoot, blk.StateRoot)
+				require.Equal(sequentialMempool.Len(ctx), mp.Len(ctx))
+			}
+		})
+	}
+}
+
+func TestBuildBlockParallelReadOnly(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx    = context.TODO()
+		expiry = (time.Now().UnixMilli()/1000 + 10) * 1000
+		rules  = &testRules{maxBlockUnits: fees.Dimensions{1 << 20, 1 << 20, 1 << 20, 1 << 20, 1 << 20}}
+
+		// Like a transfer reads the minimum transfer of its asset
+		min = []byte("min")
+	)
+	newTxs := func(onExecute func()) []*Transaction {
+		return []*Transaction{
+			newTestTx("a", expiry, 100, &testAction{keys: [][]byte{min, []byte("a0")}, readOnly: [][]byte{min}, onExecute: onExecute}),
+			newTestTx("b", expiry, 99, &testAction{keys: [][]byte{min, []byte("b0")}, readOnly: [][]byte{min}, onExecute: onExecute}),
+			newTestTx("c", expiry, 98, &testAction{keys: [][]byte{min}}),
+		}
+	}
+	payers := []string{"a", "b", "c"}
+	sequential, _, err := buildTestBlock(ctx, t, &testVM{rules: rules, workers: 1}, newTxs(nil), payers...)
+	require.NoError(err)
+	require.Len(sequential.Txs, 3)
+
+	// Txs of different payers that only read the same key are executed
+	// speculatively at the same time (and aren't executed again)
+	readers := newRendezvous(2)
+	blk, _, err := buildTestBlock(ctx, t, &testVM{rules: rules, workers: 2}, newTxs(readers.wait), payers...)
+	require.NoError(err)
+	require.True(readers.met())
+	require.Equal(2, readers.arrived)
+	require.Equal(sequential.UnitsConsumed, blk.UnitsConsumed)
+	require.Equal(sequential.results, blk.results)
+	require.Equal(sequential.StateRoot, blk.StateRoot)
+}
+
+func TestBuildBlockParallelRemovedPayer(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx    = context.TODO()
+		expiry = (time.Now().UnixMilli()/1000 + 10) * 1000
+		rules  = &testRules{maxBlockUnits: fees.Dimensions{1 << 20, 1 << 20, 1 << 20, 1 << 20, 1 << 20}}
+	)
+	// The second tx of "e" doesn't fit in the block, so the batch holding its
+	// first tx (which removes its account) is flushed before it is attempted
+	large := newTestTx("e", expiry, 190, &testAction{keys: [][]byte{[]byte("e1")}})
+	large.size = 1 << 21
+	txs := []*Transaction{
+		newTestTx("e", expiry, 200, &testAction{keys: [][]byte{[]byte("e0")}}), // can't pay
+		large,
+		newTestTx("a", expiry, 180, &testAction{keys: [][]byte{[]byte("a0")}}),
+	}
+	sequential, sequentialMempool, err := buildTestBlock(ctx, t, &testVM{rules: rules, workers: 1}, txs, "a")
+	require.NoError(err)
+	require.Len(sequential.Txs, 1)
+
+	// Txs of payers removed by a flushed batch are handled like [include]
+	// would have
+	blk, mp, err := buildTestBlock(ctx, t, &testVM{rules: rules, workers: 2}, txs, "a")
+	require.NoError(err)
+	require.Equal(sequential.Txs, blk.Txs)
+	require.Equal(sequential.StateRoot, blk.StateRoot)
+	require.Equal(sequentialMempool.Len(ctx), mp.Len(ctx))
+}
+
+func TestBuildBlockParallelCancel(t *testing.T) {
+	var (
+		expiry  = (time.Now().UnixMilli()/1000 + 10) * 1000
+		counter = []byte("counter")
+	)
+	for _, workers := range []int{1, 4} {
+		require := require.New(t)
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		txs := []*Transaction{
+			newTestTx("a", expiry, 100, &testAction{keys: [][]byte{counter}, onExecute: cancel}),
+			newTestTx("b", expiry, 99, &testAction{keys: [][]byte{counter}}),
+			newTestTx("c", expiry, 98, &testAction{keys: [][]byte{[]byte("c0")}}),
+			newTestTx("d", expiry, 97, &testAction{keys: [][]byte{counter}}),
+		}
+		vm := &testVM{
+			rules:   &testRules{maxBlockUnits: fees.Dimensions{1 << 20, 1 << 20, 1 << 20, 1 << 20, 1 << 20}},
+			workers: workers,
+		}
+		_, mp, err := buildTestBlock(ctx, t, vm, txs, "a", "b", "c", "d")
+		require.ErrorIs(err, context.Canceled)
+
+		// No txs are lost
+		require.Equal(len(txs), mp.Len(ctx))
+	}
+}
@@ -22,4 +22,11 @@ const (
 	// MaxWarpMessages is the maximum number of warp messages allows in a single
 	// block.
 	MaxWarpMessages = 64
+	// MaxEvents is the maximum number of events a single transaction can
+	// emit.
+	MaxEvents = 16
+	// MaxEventTopicSize is the maximum size of the topic of an event.
+	MaxEventTopicSize = 64
+	// MaxEventPayloadSize is the maximum size of the payload of an event.
+	MaxEventPayloadSize = 1 * units.KiB
 )
@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/x/merkledb"
 
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/workers"
 )
 
@@ -66,6 +67,20 @@ type VM interface {
 	RecordWaitSignatures(time.Duration) // only called in Verify
 	RecordStateChanges(int)
 	RecordStateOperations(int)
+	RecordTxsSkipped(int) // only called in BuildBlock
+}
+
+// ExecutionProfiler can optionally be implemented by a [VM] to record an
+// [ExecutionProfile] for each block it verifies (blocks built by the [VM]
+// are not re-executed, so they are not profiled).
+type ExecutionProfiler interface {
+	ExecutionProfiling() bool
+}
+
+// StateDiffRecorder can optionally be implemented by a [VM] to record a
+// [StateDiff] for each block it builds or verifies.
+type StateDiffRecorder interface {
+	RecordingStateDiffs() bool
 }
 
 type Mempool interface {
@@ -75,6 +90,70 @@ type Mempool interface {
 		context.Context,
 		func(context.Context, *Transaction) (bool /* continue */, bool /* restore */, bool /* remove account */, error),
 	) error
+	// IterateByPrice visits a snapshot of the mempool (from the highest to the
+	// lowest paying tx) without removing any txs until [f] returns false.
+	IterateByPrice(context.Context, func(*Transaction) bool)
+}
+
+// SkippingMempool can optionally be implemented by a [Mempool] to skip the
+// remaining txs of a payer once one of their txs is restored during
+// [BuildBlock] (instead of executing each of them only to restore it).
+type SkippingMempool interface {
+	BuildSkipping(
+		context.Context,
+		func(context.Context, *Transaction) (bool /* continue */, bool /* restore */, bool /* remove account */, error),
+	) (int /* skipped */, error)
+}
+
+// SignatureCache can optionally be implemented by a [VM] to skip verifying the
+// signatures of txs in blocks it did not build (if it already verified them,
+// such as when they were gossiped to it).
+type SignatureCache interface {
+	SignatureVerified(txID ids.ID) bool
+}
+
+// PeekingMempool can optionally be implemented by a [Mempool] to return its
+// highest paying txs without removing them.
+type PeekingMempool interface {
+	PeekTopN(context.Context, int) []*Transaction
+}
+
+// PrefetchingBuilder can optionally be implemented by a [VM] to read the state
+// of the highest paying txs in its [Mempool] in parallel while building a block
+// (the [Mempool] must implement [PeekingMempool]).
+type PrefetchingBuilder interface {
+	GetBuildPrefetchConcurrency() int // 0 disables prefetching
+	GetBuildPrefetchBatchSize() int   // txs read by each worker at a time
+	RecordBuildPrefetch(hits int, misses int, stall time.Duration)
+}
+
+// ParallelExecutor can optionally be implemented by a [VM] to execute the txs
+// of the blocks it builds and verifies concurrently. Txs that declare any of
+// the same state keys are still executed in block order (when building, txs
+// are executed in batches of at most [GetExecutionMaxParallelism] txs and any
+// tx that observed state modified differently by the time it is included is
+// executed again).
+type ParallelExecutor interface {
+	GetExecutionWorkers() int        // 1 executes txs sequentially
+	GetExecutionMaxParallelism() int // max txs executed ahead of the first unfinished tx
+	RecordExecutionParallelism(float64)
+}
+
+// AbortableVM can optionally be implemented by a [VM] to stop building or
+// verifying a block as soon as the result is no longer needed (like when the
+// preferred block it is built on is superseded or the VM is shutting down).
+type AbortableVM interface {
+	// BuildContext returns a context that is cancelled once a block built on
+	// [parent] is no longer needed. [done] must be called once building
+	// returns.
+	BuildContext(ctx context.Context, parent ids.ID) (context.Context, func())
+	// VerifyContext returns a context that is cancelled once [blk] (built on
+	// [parent]) no longer needs to be verified. [done] must be called once
+	// verification returns.
+	VerifyContext(ctx context.Context, blk ids.ID, parent ids.ID) (context.Context, func())
+
+	RecordBuildAborted(saved time.Duration)  // estimated time left when aborted
+	RecordVerifyAborted(saved time.Duration) // estimated time left when aborted
 }
 
 type Database interface {
@@ -91,10 +170,11 @@ type Rules interface {
 
 	GetMinBlockGap() int64
 
-	GetMinUnitPrice() uint64
-	GetUnitPriceChangeDenominator() uint64
-	GetWindowTargetUnits() uint64
-	GetMaxBlockUnits() uint64 // should ensure can't get above block max size
+	// Each [fees.Dimension] is priced (and limited) independently
+	GetMinUnitPrice() fees.Dimensions
+	GetUnitPriceChangeDenominator() fees.Dimensions
+	GetWindowTargetUnits() fees.Dimensions
+	GetMaxBlockUnits() fees.Dimensions // should ensure can't get above block max size
 
 	GetBaseUnits() uint64
 	GetWarpBaseUnits() uint64
@@ -116,6 +196,53 @@ type StateManager interface {
 	OutgoingWarpKey(txID ids.ID) []byte
 }
 
+// SequenceRules is optionally implemented by [Rules] to augment expiry-based
+// replay protection with per-payer sequence numbers (like classical account
+// nonces). When enabled, a transaction is only valid if its [Base.Sequence]
+// is the next sequence of its payer.
+//
+// Transactions must still specify an expiry (which bounds how long they can
+// sit in the mempool).
+type SequenceRules interface {
+	GetSequenceMode() bool
+}
+
+// AuthRules is optionally implemented by [Rules] to limit the size of (and
+// charge additional units for) each type of [Auth] independently. This allows
+// a chain to admit expensive auth types (like multisig or BLS) without
+// charging every transaction as if it used one.
+type AuthRules interface {
+	// GetAuthConfig returns whether [authType] is configured, the max size of
+	// its [Auth] (0 means unlimited), and the units charged for verifying it
+	// (in addition to [Auth.MaxUnits]).
+	GetAuthConfig(authType uint8) (bool, int, uint64)
+}
+
+// TxSizeRules is optionally implemented by [Rules] to limit the size of each
+// transaction (which otherwise may be as large as a network message). The
+// limit is enforced when transactions are submitted, gossiped, and parsed in
+// blocks.
+type TxSizeRules interface {
+	// GetMaxTxSize returns the maximum size of a transaction in bytes (0 means
+	// [consts.NetworkSizeLimit]).
+	GetMaxTxSize() int
+}
+
+// VersionedRules is optionally implemented by [Rules] to report their
+// version, which should be incremented whenever [Rules] change in a way that
+// affects which transactions are valid. It is surfaced over RPC so that
+// tooling can check compatibility before submitting transactions.
+type VersionedRules interface {
+	GetRulesVersion() uint16
+}
+
+// SequenceStateManager must be implemented by the [StateManager] of any chain
+// that enables sequence mode.
+type SequenceStateManager interface {
+	// SequenceKey is the key where the next sequence of [payer] is stored.
+	SequenceKey(payer []byte) []byte
+}
+
 type Action interface {
 	MaxUnits(Rules) uint64                     // max units that could be charged via execute
 	ValidRange(Rules) (start int64, end int64) // -1 means no start/end
@@ -151,6 +278,14 @@ type Action interface {
 	Marshal(p *codec.Packer)
 }
 
+// ReadOnlyAction is an optional interface that an [Action] can implement to
+// report which of its [StateKeys] it only reads. Txs that only read a key can
+// be executed in parallel (see [executeConflicting]). If [Execute] modifies a
+// read-only key, the modification fails with [tstate.ErrKeyReadOnly].
+type ReadOnlyAction interface {
+	ReadOnlyKeys(auth Auth, txID ids.ID) [][]byte
+}
+
 type Auth interface {
 	MaxUnits(Rules) uint64
 	ValidRange(Rules) (start int64, end int64) // -1 means no start/end
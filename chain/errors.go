@@ -46,12 +46,18 @@ var (
 	ErrActionNotActivated   = errors.New("action not activated")
 	ErrAuthNotActivated     = errors.New("auth not activated")
 	ErrAuthFailed           = errors.New("auth failed")
+	ErrAuthTooLarge         = errors.New("auth too large")
+	ErrTxTooLarge           = errors.New("tx too large")
 	ErrMisalignedTime       = errors.New("misaligned time")
+	ErrInvalidSequence      = errors.New("invalid sequence")
+	ErrSequenceTooLow       = errors.New("sequence too low")
+	ErrSequenceTooHigh      = errors.New("sequence too high")
 
 	// Execution Correctness
 	ErrInvalidBalance  = errors.New("invalid balance")
 	ErrBlockTooBig     = errors.New("block too big")
 	ErrKeyNotSpecified = errors.New("key not specified")
+	ErrFeeTooLarge     = errors.New("fee exceeds max fee")
 
 	// Warp
 	ErrDisabledChainID           = errors.New("cannot import from chain ID")
@@ -63,7 +69,12 @@ var (
 	ErrTooManyWarpMessages       = errors.New("too many warp messages")
 	ErrWarpResultMismatch        = errors.New("warp result mismatch")
 
+	// Events
+	ErrTooManyEvents = errors.New("too many events")
+	ErrInvalidEvent  = errors.New("invalid event")
+
 	// Misc
-	ErrNotImplemented    = errors.New("not implemented")
-	ErrBlockNotProcessed = errors.New("block is not processed")
+	ErrNotImplemented          = errors.New("not implemented")
+	ErrBlockNotProcessed       = errors.New("block is not processed")
+	ErrMissingSequenceStateKey = errors.New("state manager does not support sequences")
 )
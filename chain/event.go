@@ -0,0 +1,95 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// Event is emitted by an [Action] (via [Result.Events]) to describe an effect
+// of its execution, so indexers don't need to reverse-engineer it from the
+// fields of the [Action].
+//
+// [Topic] identifies the type of the event (and how to parse [Payload]) and is
+// defined by the VM.
+type Event struct {
+	Topic   string
+	Payload []byte
+}
+
+func (e *Event) Size() int {
+	return codec.StringLen(e.Topic) + codec.BytesLen(e.Payload)
+}
+
+func (e *Event) Marshal(p *codec.Packer) {
+	p.PackString(e.Topic)
+	p.PackBytes(e.Payload)
+}
+
+func UnmarshalEvent(p *codec.Packer) (*Event, error) {
+	e := &Event{Topic: p.UnpackString(true)}
+	p.UnpackBytes(MaxEventPayloadSize, false, &e.Payload)
+	if len(e.Payload) == 0 {
+		// Enforce object standardization
+		e.Payload = nil
+	}
+	return e, p.Err()
+}
+
+func eventsSize(events []*Event) int {
+	size := consts.IntLen
+	for _, e := range events {
+		size += e.Size()
+	}
+	return size
+}
+
+func marshalEvents(p *codec.Packer, events []*Event) {
+	p.PackInt(len(events))
+	for _, e := range events {
+		e.Marshal(p)
+	}
+}
+
+func unmarshalEvents(p *codec.Packer) ([]*Event, error) {
+	count := p.UnpackInt(false)
+	if count > MaxEvents {
+		return nil, ErrTooManyEvents
+	}
+	if count == 0 {
+		// Enforce object standardization
+		return nil, p.Err()
+	}
+	events := make([]*Event, count)
+	for i := range events {
+		e, err := UnmarshalEvent(p)
+		if err != nil {
+			return nil, err
+		}
+		events[i] = e
+	}
+	return events, nil
+}
+
+// verifyEvents ensures the events emitted by an [Action] can be persisted.
+func verifyEvents(events []*Event) error {
+	if len(events) > MaxEvents {
+		return ErrTooManyEvents
+	}
+	for _, e := range events {
+		if len(e.Topic) == 0 || len(e.Topic) > MaxEventTopicSize {
+			return ErrInvalidEvent
+		}
+		if len(e.Payload) > MaxEventPayloadSize {
+			return ErrInvalidEvent
+		}
+		if len(e.Payload) == 0 && e.Payload != nil {
+			// Enforce object standardization (this is a VM bug and we should
+			// fail fast)
+			return ErrInvalidObject
+		}
+	}
+	return nil
+}
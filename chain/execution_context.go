@@ -9,12 +9,13 @@ import (
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/window"
 )
 
 type ExecutionContext struct {
-	NextUnitPrice  uint64
-	NextUnitWindow window.Window
+	NextUnitPrices  fees.Dimensions
+	NextUnitWindows [fees.FeeDimensions]window.Window
 }
 
 func computeNextPriceWindow(
@@ -98,21 +99,31 @@ func GenerateExecutionContext(
 	defer span.End()
 
 	// TODO: support more granular fee adjustment periods?
+	//
+	// Each dimension is priced independently (so that heavy usage of one
+	// resource doesn't make the others more expensive).
 	since := int((currTime - parent.Tmstmp) / consts.MillisecondsPerSecond) // convert to seconds
-	nextUnitPrice, nextUnitWindow, err := computeNextPriceWindow(
-		parent.UnitWindow,
-		parent.UnitsConsumed,
-		parent.UnitPrice,
-		r.GetWindowTargetUnits(),
-		r.GetUnitPriceChangeDenominator(),
-		r.GetMinUnitPrice(),
-		since,
+	var (
+		ectx    = &ExecutionContext{}
+		targets = r.GetWindowTargetUnits()
+		denoms  = r.GetUnitPriceChangeDenominator()
+		mins    = r.GetMinUnitPrice()
 	)
-	if err != nil {
-		return nil, err
+	for i := 0; i < fees.FeeDimensions; i++ {
+		nextUnitPrice, nextUnitWindow, err := computeNextPriceWindow(
+			parent.UnitWindows[i],
+			parent.UnitsConsumed[i],
+			parent.UnitPrices[i],
+			targets[i],
+			denoms[i],
+			mins[i],
+			since,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ectx.NextUnitPrices[i] = nextUnitPrice
+		ectx.NextUnitWindows[i] = nextUnitWindow
 	}
-	return &ExecutionContext{
-		NextUnitPrice:  nextUnitPrice,
-		NextUnitWindow: nextUnitWindow,
-	}, nil
+	return ectx, nil
 }
@@ -0,0 +1,48 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/fees"
+	htrace "github.com/ava-labs/hypersdk/trace"
+	"github.com/ava-labs/hypersdk/window"
+)
+
+func TestGenerateExecutionContext(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tracer, err := htrace.New(&htrace.Config{Enabled: false})
+	require.NoError(err)
+	r := NewMockRules(ctrl)
+	r.EXPECT().GetWindowTargetUnits().Return(fees.Dimensions{100, 100, 100, 100, 100})
+	r.EXPECT().GetUnitPriceChangeDenominator().Return(fees.Dimensions{8, 8, 8, 8, 8})
+	r.EXPECT().GetMinUnitPrice().Return(fees.Dimensions{1, 1, 1, 1, 90})
+
+	// Each dimension is priced only by its own usage
+	parent := &StatelessBlock{StatefulBlock: &StatefulBlock{
+		Tmstmp:        1_000,
+		UnitPrices:    fees.Dimensions{100, 100, 100, 100, 100},
+		UnitsConsumed: fees.Dimensions{0, 300, 100, 150, 0},
+	}}
+	ectx, err := GenerateExecutionContext(context.TODO(), 2_000, parent, tracer, r)
+	require.NoError(err)
+	require.Equal(fees.Dimensions{
+		fees.Bandwidth:       88,  // below target
+		fees.Compute:         125, // above target
+		fees.StorageRead:     100, // at target
+		fees.StorageAllocate: 106, // above target
+		fees.StorageWrite:    90,  // below target (but can't go below min)
+	}, ectx.NextUnitPrices)
+	for i, consumed := range parent.UnitsConsumed {
+		require.Equal(consumed, window.Sum(ectx.NextUnitWindows[i]))
+	}
+}
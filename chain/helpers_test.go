@@ -0,0 +1,312 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/fees"
+	htrace "github.com/ava-labs/hypersdk/trace"
+)
+
+const (
+	testTxSize  = 100
+	testBalance = 1_000_000
+)
+
+var (
+	testChainID       = ids.GenerateTestID()
+	testMinUnitPrices = fees.Dimensions{1, 1, 1, 1, 1}
+)
+
+// testRules are the [Rules] used by chain tests (blocks are limited to
+// [maxBlockUnits]).
+type testRules struct {
+	maxBlockUnits fees.Dimensions
+}
+
+func (*testRules) NetworkID() uint32                { return 1 }
+func (*testRules) ChainID() ids.ID                  { return testChainID }
+func (*testRules) GetMinBlockGap() int64            { return 0 }
+func (*testRules) GetMinUnitPrice() fees.Dimensions { return testMinUnitPrices }
+func (*testRules) GetUnitPriceChangeDenominator() fees.Dimensions {
+	return fees.Dimensions{1, 1, 1, 1, 1}
+}
+func (*testRules) GetWindowTargetUnits() fees.Dimensions {
+	return fees.Dimensions{1 << 40, 1 << 40, 1 << 40, 1 << 40, 1 << 40}
+}
+func (r *testRules) GetMaxBlockUnits() fees.Dimensions         { return r.maxBlockUnits }
+func (*testRules) GetBaseUnits() uint64                        { return 1 }
+func (*testRules) GetWarpBaseUnits() uint64                    { return 0 }
+func (*testRules) GetWarpUnitsPerSigner() uint64               { return 0 }
+func (*testRules) GetWarpConfig(ids.ID) (bool, uint64, uint64) { return false, 0, 0 }
+func (*testRules) GetValidityWindow() int64                    { return 60_000 }
+func (*testRules) FetchCustom(string) (any, bool)              { return nil, false }
+
+type testStateManager struct{}
+
+func (testStateManager) HeightKey() []byte { return []byte("height") }
+
+func (testStateManager) IncomingWarpKey(sourceChainID ids.ID, msgID ids.ID) []byte {
+	return append(append([]byte("incoming/"), sourceChainID[:]...), msgID[:]...)
+}
+
+func (testStateManager) OutgoingWarpKey(txID ids.ID) []byte {
+	return append([]byte("outgoing/"), txID[:]...)
+}
+
+func balanceKey(payer []byte) []byte {
+	return append([]byte("balance/"), payer...)
+}
+
+// getUint64 returns the uint64 stored at [key] (or 0 if it doesn't exist).
+func getUint64(ctx context.Context, db Database, key []byte) (uint64, error) {
+	v, err := db.GetValue(ctx, key)
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+// testAction increments the counter stored at each of [keys] (or, if
+// [remove] is set, removes them) and consumes [units] (1 if unset). The
+// [keys] in [readOnly] are only read. If [fail] is set, the action is
+// unsuccessful (after modifying [keys]) and if [err] is set, it is returned by
+// [Execute] (after calling [onExecute], if set).
+type testAction struct {
+	keys     [][]byte
+	readOnly [][]byte
+	remove   bool
+	fail     bool
+	units    uint64
+
+	err       error
+	onExecute func()
+}
+
+func (*testAction) MaxUnits(Rules) uint64           { return 1 }
+func (*testAction) ValidRange(Rules) (int64, int64) { return -1, -1 }
+func (a *testAction) StateKeys(Auth, ids.ID) [][]byte {
+	return a.keys
+}
+
+func (a *testAction) ReadOnlyKeys(Auth, ids.ID) [][]byte {
+	return a.readOnly
+}
+
+func (a *testAction) Execute(
+	ctx context.Context,
+	_ Rules,
+	db Database,
+	_ int64,
+	_ Auth,
+	_ ids.ID,
+	_ bool,
+) (*Result, error) {
+	if a.onExecute != nil {
+		a.onExecute()
+	}
+	if a.err != nil {
+		return nil, a.err
+	}
+	readOnly := map[string]struct{}{}
+	for _, k := range a.readOnly {
+		readOnly[string(k)] = struct{}{}
+	}
+	for _, k := range a.keys {
+		if _, ok := readOnly[string(k)]; ok {
+			if _, err := getUint64(ctx, db, k); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if a.remove {
+			if err := db.Remove(ctx, k); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		v, err := getUint64(ctx, db, k)
+		if err != nil {
+			return nil, err
+		}
+		if err := db.Insert(ctx, k, binary.BigEndian.AppendUint64(nil, v+1)); err != nil {
+			return nil, err
+		}
+	}
+	units := a.units
+	if units == 0 {
+		units = 1
+	}
+	return &Result{Success: !a.fail, Units: units}, nil
+}
+
+func (*testAction) Size() int             { return 0 }
+func (*testAction) Marshal(*codec.Packer) {}
+
+// testAuth pays fees from the balance of [payer].
+type testAuth struct {
+	payer []byte
+}
+
+func (*testAuth) MaxUnits(Rules) uint64           { return 1 }
+func (*testAuth) ValidRange(Rules) (int64, int64) { return -1, -1 }
+func (a *testAuth) StateKeys() [][]byte           { return [][]byte{balanceKey(a.payer)} }
+func (*testAuth) AsyncVerify([]byte) error        { return nil }
+
+func (*testAuth) Verify(context.Context, Rules, Database, Action) (uint64, error) {
+	return 1, nil
+}
+
+func (a *testAuth) Payer() []byte { return a.payer }
+
+func (a *testAuth) CanDeduct(ctx context.Context, db Database, amount uint64) error {
+	bal, err := getUint64(ctx, db, balanceKey(a.payer))
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return ErrInvalidBalance
+	}
+	return nil
+}
+
+func (a *testAuth) Deduct(ctx context.Context, db Database, amount uint64) error {
+	bal, err := getUint64(ctx, db, balanceKey(a.payer))
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return ErrInvalidBalance
+	}
+	return db.Insert(ctx, balanceKey(a.payer), binary.BigEndian.AppendUint64(nil, bal-amount))
+}
+
+func (a *testAuth) Refund(ctx context.Context, db Database, amount uint64) error {
+	bal, err := getUint64(ctx, db, balanceKey(a.payer))
+	if err != nil {
+		return err
+	}
+	return db.Insert(ctx, balanceKey(a.payer), binary.BigEndian.AppendUint64(nil, bal+amount))
+}
+
+func (*testAuth) Size() int             { return 0 }
+func (*testAuth) Marshal(*codec.Packer) {}
+
+// newTestTx returns a tx (that expires at [expiry] and pays at most [price]
+// per unit) that executes [action] and is paid for by [payer].
+func newTestTx(payer string, expiry int64, price uint64, action *testAction) *Transaction {
+	id := ids.GenerateTestID()
+	return &Transaction{
+		Base: &Base{
+			Timestamp:     expiry,
+			ChainID:       testChainID,
+			MaxUnitPrices: fees.Dimensions{price, price, price, price, price},
+		},
+		Action: action,
+		Auth:   &testAuth{payer: []byte(payer)},
+
+		bytes: id[:], // only used to marshal blocks
+		size:  testTxSize,
+		id:    id,
+	}
+}
+
+// newTestState returns a [merkledb.MerkleDB] where each of [payers] has
+// [testBalance].
+func newTestState(t *testing.T, payers ...string) merkledb.MerkleDB {
+	require := require.New(t)
+
+	tracer, err := htrace.New(&htrace.Config{Enabled: false})
+	require.NoError(err)
+	db, err := merkledb.New(context.TODO(), memdb.New(), merkledb.Config{
+		HistoryLength: 16,
+		NodeCacheSize: 1_024,
+		Reg:           prometheus.NewRegistry(),
+		Tracer:        tracer,
+	})
+	require.NoError(err)
+	for _, payer := range payers {
+		require.NoError(db.Put(balanceKey([]byte(payer)), binary.BigEndian.AppendUint64(nil, testBalance)))
+	}
+	return db
+}
+
+// testVM is a [VM] (that executes txs on [workers] goroutines) for chain
+// tests. If [sm] is not set, [testStateManager] is used. Unused methods of
+// [VM] panic.
+type testVM struct {
+	VM
+
+	tracer  trace.Tracer
+	rules   Rules
+	sm      StateManager
+	state   merkledb.MerkleDB
+	mempool Mempool
+	parent  *StatelessBlock
+
+	workers        int
+	maxParallelism int
+
+	prefetchConcurrency int
+	prefetchBatchSize   int
+	prefetchHits        int
+	prefetchMisses      int
+}
+
+func (vm *testVM) Tracer() trace.Tracer                  { return vm.tracer }
+func (*testVM) Logger() logging.Logger                   { return logging.NoLog{} }
+func (vm *testVM) Rules(int64) Rules                     { return vm.rules }
+func (*testVM) Registry() (ActionRegistry, AuthRegistry) { return nil, nil }
+func (vm *testVM) State() (merkledb.MerkleDB, error)     { return vm.state, nil }
+func (*testVM) ValidatorState() validators.State         { return nil }
+func (vm *testVM) Mempool() Mempool                      { return vm.mempool }
+func (*testVM) IsRepeat(context.Context, []*Transaction) bool {
+	return false
+}
+func (*testVM) RecordTxsSkipped(int) {}
+
+func (vm *testVM) StateManager() StateManager {
+	if vm.sm != nil {
+		return vm.sm
+	}
+	return testStateManager{}
+}
+
+func (vm *testVM) GetStatelessBlock(_ context.Context, blkID ids.ID) (*StatelessBlock, error) {
+	if vm.parent == nil || blkID != vm.parent.ID() {
+		return nil, database.ErrNotFound
+	}
+	return vm.parent, nil
+}
+
+func (vm *testVM) GetExecutionWorkers() int        { return vm.workers }
+func (vm *testVM) GetExecutionMaxParallelism() int { return vm.maxParallelism }
+func (*testVM) RecordExecutionParallelism(float64) {}
+
+func (vm *testVM) GetBuildPrefetchConcurrency() int { return vm.prefetchConcurrency }
+func (vm *testVM) GetBuildPrefetchBatchSize() int   { return vm.prefetchBatchSize }
+
+func (vm *testVM) RecordBuildPrefetch(hits int, misses int, _ time.Duration) {
+	vm.prefetchHits += hits
+	vm.prefetchMisses += misses
+}
@@ -0,0 +1,207 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// TypedJSON is the canonical JSON encoding of a registered [Action] or
+// [Auth]. [Value] is the JSON encoding of the registered type and [Type] is
+// its name in the registry (like "Transfer").
+//
+// When decoding, [Type] takes precedence over [TypeID] (if both are provided,
+// they must match).
+type TypedJSON struct {
+	Type   string          `json:"type"`
+	TypeID uint8           `json:"typeId"`
+	Value  json.RawMessage `json:"value"`
+}
+
+// TransactionJSON is the canonical JSON encoding of a [Transaction]. [Auth]
+// is omitted (and [ID] is empty) for unsigned transactions.
+type TransactionJSON struct {
+	ID          ids.ID     `json:"id"`
+	Base        *Base      `json:"base"`
+	WarpMessage []byte     `json:"warpMessage,omitempty"`
+	Action      *TypedJSON `json:"action"`
+	Auth        *TypedJSON `json:"auth,omitempty"`
+}
+
+type marshaler interface {
+	Size() int
+	Marshal(*codec.Packer)
+}
+
+func marshalTypedJSON[T marshaler](
+	registry *codec.TypeParser[T, *warp.Message, bool],
+	o T,
+) (*TypedJSON, error) {
+	index, _, _, ok := registry.LookupType(o)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not registered", ErrInvalidType, o)
+	}
+	name, _ := registry.Name(index)
+	v, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedJSON{Type: name, TypeID: index, Value: v}, nil
+}
+
+// unmarshalTypedJSON decodes [j] and then reloads the result from its binary
+// encoding so that the returned object is identical to one parsed from a
+// transaction (and any JSON that can't be represented is rejected).
+func unmarshalTypedJSON[T marshaler](
+	registry *codec.TypeParser[T, *warp.Message, bool],
+	j *TypedJSON,
+	wm *warp.Message,
+) (T, uint8, error) {
+	var empty T
+	if j == nil {
+		return empty, 0, fmt.Errorf("%w: missing value", ErrInvalidObject)
+	}
+	index := j.TypeID
+	if len(j.Type) > 0 {
+		nameIndex, ok := registry.LookupName(j.Type)
+		if !ok {
+			return empty, 0, fmt.Errorf("%w: %s is not registered", ErrInvalidType, j.Type)
+		}
+		if j.TypeID != 0 && j.TypeID != nameIndex {
+			return empty, 0, fmt.Errorf("%w: %s does not have typeID %d", ErrInvalidType, j.Type, j.TypeID)
+		}
+		index = nameIndex
+	}
+	o, ok := registry.New(index)
+	if !ok {
+		return empty, 0, fmt.Errorf("%w: %d is not registered", ErrInvalidType, index)
+	}
+	if len(j.Value) > 0 {
+		d := json.NewDecoder(bytes.NewReader(j.Value))
+		d.DisallowUnknownFields()
+		if err := d.Decode(o); err != nil {
+			return empty, 0, fmt.Errorf("%w: %v", ErrInvalidObject, err)
+		}
+	}
+	unmarshal, needsWarp, _ := registry.LookupIndex(index)
+	if needsWarp && wm == nil {
+		return empty, 0, fmt.Errorf("%w: type %d", ErrExpectedWarpMessage, index)
+	}
+	p := codec.NewWriter(o.Size(), consts.NetworkSizeLimit)
+	o.Marshal(p)
+	if err := p.Err(); err != nil {
+		return empty, 0, err
+	}
+	p = codec.NewReader(p.Bytes(), consts.NetworkSizeLimit)
+	o, err := unmarshal(p, wm)
+	if err != nil {
+		return empty, 0, err
+	}
+	if !p.Empty() {
+		return empty, 0, ErrInvalidObject
+	}
+	return o, index, nil
+}
+
+// MarshalActionJSON returns the canonical JSON encoding of [action].
+func MarshalActionJSON(actionRegistry ActionRegistry, action Action) (*TypedJSON, error) {
+	return marshalTypedJSON[Action](actionRegistry, action)
+}
+
+// UnmarshalActionJSON parses an [Action] from its canonical JSON encoding.
+// [wm] must be provided if the action requires a warp message.
+func UnmarshalActionJSON(
+	actionRegistry ActionRegistry,
+	j *TypedJSON,
+	wm *warp.Message,
+) (Action, error) {
+	action, _, err := unmarshalTypedJSON[Action](actionRegistry, j, wm)
+	return action, err
+}
+
+// MarshalAuthJSON returns the canonical JSON encoding of [auth].
+func MarshalAuthJSON(authRegistry AuthRegistry, auth Auth) (*TypedJSON, error) {
+	return marshalTypedJSON[Auth](authRegistry, auth)
+}
+
+// UnmarshalAuthJSON parses an [Auth] from its canonical JSON encoding.
+func UnmarshalAuthJSON(authRegistry AuthRegistry, j *TypedJSON, wm *warp.Message) (Auth, error) {
+	auth, _, err := unmarshalTypedJSON[Auth](authRegistry, j, wm)
+	return auth, err
+}
+
+// MarshalTxJSON returns the canonical JSON encoding of [tx]. If [tx] is not
+// signed, [Auth] is omitted and [ID] is empty.
+func MarshalTxJSON(
+	tx *Transaction,
+	actionRegistry ActionRegistry,
+	authRegistry AuthRegistry,
+) (*TransactionJSON, error) {
+	action, err := MarshalActionJSON(actionRegistry, tx.Action)
+	if err != nil {
+		return nil, err
+	}
+	j := &TransactionJSON{
+		ID:     tx.ID(),
+		Base:   tx.Base,
+		Action: action,
+	}
+	if tx.WarpMessage != nil {
+		j.WarpMessage = tx.WarpMessage.Bytes()
+	}
+	if tx.Auth != nil {
+		j.Auth, err = MarshalAuthJSON(authRegistry, tx.Auth)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+// UnmarshalUnsignedTxJSON parses an unsigned [Transaction] from its canonical
+// JSON encoding. [Auth] (if provided) is ignored.
+//
+// The returned transaction can be signed with [Transaction.Sign] or
+// serialized for an external signer with [Transaction.Digest].
+func UnmarshalUnsignedTxJSON(j *TransactionJSON, actionRegistry ActionRegistry) (*Transaction, error) {
+	if j.Base == nil {
+		return nil, fmt.Errorf("%w: missing base", ErrInvalidObject)
+	}
+	if j.Base.Timestamp%consts.MillisecondsPerSecond != 0 {
+		return nil, ErrMisalignedTime
+	}
+	var (
+		wm             *warp.Message
+		numWarpSigners int
+	)
+	if len(j.WarpMessage) > 0 {
+		msg, err := warp.ParseMessage(j.WarpMessage)
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not unmarshal warp message", err)
+		}
+		if len(msg.Payload) == 0 {
+			return nil, ErrEmptyWarpPayload
+		}
+		wm = msg
+		numWarpSigners, err = msg.Signature.NumSigners()
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not calculate number of warp signers", err)
+		}
+	}
+	action, err := UnmarshalActionJSON(actionRegistry, j.Action, wm)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not unmarshal action", err)
+	}
+	tx := NewTx(j.Base, wm, action)
+	tx.numWarpSigners = numWarpSigners // used to compute [MaxUnits]
+	return tx, nil
+}
@@ -11,6 +11,7 @@ import (
 	reflect "reflect"
 
 	ids "github.com/ava-labs/avalanchego/ids"
+	fees "github.com/ava-labs/hypersdk/fees"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -81,10 +82,10 @@ func (mr *MockRulesMockRecorder) GetBaseUnits() *gomock.Call {
 }
 
 // GetMaxBlockUnits mocks base method.
-func (m *MockRules) GetMaxBlockUnits() uint64 {
+func (m *MockRules) GetMaxBlockUnits() fees.Dimensions {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetMaxBlockUnits")
-	ret0, _ := ret[0].(uint64)
+	ret0, _ := ret[0].(fees.Dimensions)
 	return ret0
 }
 
@@ -109,10 +110,10 @@ func (mr *MockRulesMockRecorder) GetMinBlockGap() *gomock.Call {
 }
 
 // GetMinUnitPrice mocks base method.
-func (m *MockRules) GetMinUnitPrice() uint64 {
+func (m *MockRules) GetMinUnitPrice() fees.Dimensions {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetMinUnitPrice")
-	ret0, _ := ret[0].(uint64)
+	ret0, _ := ret[0].(fees.Dimensions)
 	return ret0
 }
 
@@ -123,10 +124,10 @@ func (mr *MockRulesMockRecorder) GetMinUnitPrice() *gomock.Call {
 }
 
 // GetUnitPriceChangeDenominator mocks base method.
-func (m *MockRules) GetUnitPriceChangeDenominator() uint64 {
+func (m *MockRules) GetUnitPriceChangeDenominator() fees.Dimensions {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUnitPriceChangeDenominator")
-	ret0, _ := ret[0].(uint64)
+	ret0, _ := ret[0].(fees.Dimensions)
 	return ret0
 }
 
@@ -195,10 +196,10 @@ func (mr *MockRulesMockRecorder) GetWarpUnitsPerSigner() *gomock.Call {
 }
 
 // GetWindowTargetUnits mocks base method.
-func (m *MockRules) GetWindowTargetUnits() uint64 {
+func (m *MockRules) GetWindowTargetUnits() fees.Dimensions {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetWindowTargetUnits")
-	ret0, _ := ret[0].(uint64)
+	ret0, _ := ret[0].(fees.Dimensions)
 	return ret0
 }
 
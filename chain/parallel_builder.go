@@ -0,0 +1,89 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+// speculativeTx is a tx that [BuildBlock] executes (in parallel with the other
+// txs of its batch) before deciding whether to include it.
+type speculativeTx struct {
+	tx       *Transaction
+	keys     [][]byte
+	readOnly map[string]struct{} // [keys] that [tx] only reads
+	storage  map[string][]byte   // state of [keys] before the batch
+
+	preErr  error // returned by [Transaction.PreExecute]
+	warpErr error
+	result  *Result
+	changes map[string]tstate.Value
+}
+
+// speculate executes [txs] on [workers] goroutines (see [executeConflicting]).
+// Each tx observes the state it was fetched with and the changes of the txs
+// before it in [txs].
+//
+// Txs that fail [Transaction.PreExecute] are not executed (and don't modify
+// the state observed by other txs). speculate returns how long txs were
+// executed for (in total).
+func speculate(
+	ctx context.Context,
+	ectx *ExecutionContext,
+	r Rules,
+	sm StateManager,
+	timestamp int64,
+	txs []*speculativeTx,
+	workers int,
+	verifyWarp func(context.Context, *Transaction) error,
+) (time.Duration, error) {
+	var (
+		keys     = make([][][]byte, len(txs))
+		readOnly = make([]map[string]struct{}, len(txs))
+		values   = map[string]tstate.Value{}
+		valuesL  sync.Mutex
+		busy     atomic.Int64
+	)
+	for i, stx := range txs {
+		keys[i] = stx.keys
+		readOnly[i] = stx.readOnly
+	}
+	execute := func(ctx context.Context, i int) error {
+		stx := txs[i]
+		start := time.Now()
+		defer func() {
+			busy.Add(int64(time.Since(start)))
+		}()
+
+		ts := tstate.New(len(stx.keys))
+		ts.SetScope(ctx, stx.keys, conflictStorage(stx.keys, values, &valuesL, stx.storage))
+		if err := stx.tx.PreExecute(ctx, ectx, r, sm, ts, timestamp); err != nil {
+			stx.preErr = err
+			return nil
+		}
+		if stx.tx.WarpMessage != nil {
+			stx.warpErr = verifyWarp(ctx, stx.tx)
+		}
+		result, err := stx.tx.Execute(ctx, ectx, r, sm, ts, timestamp, stx.tx.WarpMessage != nil && stx.warpErr == nil)
+		if err != nil {
+			return err
+		}
+		stx.result = result
+		stx.changes = ts.ChangedValues()
+
+		valuesL.Lock()
+		for k, v := range stx.changes {
+			values[k] = v
+		}
+		valuesL.Unlock()
+		return nil
+	}
+	err := executeConflicting(ctx, keys, readOnly, workers, 0, nil, execute)
+	return time.Duration(busy.Load()), err
+}
@@ -0,0 +1,367 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+// parallelTx is the state of a single tx during [executeParallel].
+type parallelTx struct {
+	data *txData
+	keys [][]byte
+
+	result  *Result
+	changes map[string]tstate.Value
+	ops     int
+}
+
+// executeConflicting calls [execute] for each of the txs that declare [keys]
+// on [workers] goroutines. The keys of each tx in [readOnly] (if provided) are
+// only read by that tx.
+//
+// Each tx is executed after the last tx before it that writes any of the same
+// keys (and, if it writes a key, after every tx since then that reads it), so
+// every tx observes exactly the state it would if all txs were executed in
+// order (txs that only read the same keys don't conflict). Txs are only
+// executed once they are announced by [fetched] (all txs can be executed right
+// away if [fetched] is nil) and are never executed more than [window] txs
+// ahead of the first tx that has not been executed yet (bounding the changes
+// held in memory).
+//
+// executeConflicting returns the first error returned by [execute] (or the
+// error of [ctx], if it is done before all txs are executed).
+func executeConflicting(
+	ctx context.Context,
+	keys [][][]byte,
+	readOnly []map[string]struct{},
+	workers int,
+	window int,
+	fetched <-chan struct{},
+	execute func(context.Context, int) error,
+) error {
+	n := len(keys)
+	if window <= 0 {
+		window = n
+	}
+
+	// Build the conflict graph
+	var (
+		after      = make([][]int, n) // txs that can't be executed until this tx is
+		deps       = make([]int, n)   // unexecuted txs this tx must be executed after
+		lastWriter = map[string]int{}
+		readers    = map[string][]int{} // txs that read a key since its last writer
+	)
+	for i, txKeys := range keys {
+		txDeps := map[int]struct{}{}
+		addDep := func(j int) {
+			if _, dup := txDeps[j]; j == i || dup {
+				return
+			}
+			txDeps[j] = struct{}{}
+			after[j] = append(after[j], i)
+		}
+		for _, k := range txKeys {
+			sk := string(k)
+			if j, ok := lastWriter[sk]; ok {
+				addDep(j)
+			}
+			if readOnly != nil {
+				if _, ok := readOnly[i][sk]; ok {
+					readers[sk] = append(readers[sk], i)
+					continue
+				}
+			}
+			for _, j := range readers[sk] {
+				addDep(j)
+			}
+			delete(readers, sk)
+			lastWriter[sk] = i
+		}
+		deps[i] = len(txDeps)
+	}
+
+	// Start workers
+	type outcome struct {
+		i   int
+		err error
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	var (
+		jobs = make(chan int, n)
+		done = make(chan outcome, n)
+		wg   sync.WaitGroup
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				err := ctx.Err()
+				if err == nil {
+					err = execute(ctx, i)
+				}
+				done <- outcome{i, err}
+			}
+		}()
+	}
+	defer func() {
+		cancel()
+		close(jobs)
+		wg.Wait()
+	}()
+
+	// Dispatch txs as soon as they are fetched and all txs they conflict with
+	// are executed
+	var (
+		dispatched = make([]bool, n)
+		executed   = make([]bool, n)
+		available  = 0
+		count      = 0
+		first      = 0 // first tx that has not been executed
+	)
+	dispatch := func(i int) {
+		if dispatched[i] || i >= available || deps[i] > 0 || i >= first+window {
+			return
+		}
+		dispatched[i] = true
+		jobs <- i
+	}
+	if fetched == nil {
+		available = n
+		for i := 0; i < n; i++ {
+			dispatch(i)
+		}
+	}
+	for count < n {
+		select {
+		case _, ok := <-fetched:
+			if !ok {
+				fetched = nil
+				continue
+			}
+			available++
+			dispatch(available - 1)
+		case o := <-done:
+			if o.err != nil {
+				return o.err
+			}
+			executed[o.i] = true
+			count++
+			for _, j := range after[o.i] {
+				deps[j]--
+				dispatch(j)
+			}
+			prev := first
+			for first < n && executed[first] {
+				first++
+			}
+			for j := prev + window; j < first+window && j < n; j++ {
+				dispatch(j)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// conflictStorage returns the state that a tx that declares [keys] observes
+// when executed by [executeConflicting]: the latest [values] written by the
+// txs executed before it or, if no such tx wrote a key, its value in [base].
+//
+// Assumes [valuesL] protects [values].
+func conflictStorage(
+	keys [][]byte,
+	values map[string]tstate.Value,
+	valuesL *sync.Mutex,
+	base map[string][]byte,
+) map[string][]byte {
+	storage := make(map[string][]byte, len(keys))
+	valuesL.Lock()
+	defer valuesL.Unlock()
+
+	for _, k := range keys {
+		sk := string(k)
+		if v, ok := values[sk]; ok {
+			if v.Exists {
+				storage[sk] = v.V
+			}
+			continue
+		}
+		if v, ok := base[sk]; ok {
+			storage[sk] = v
+		}
+	}
+	return storage
+}
+
+// executeParallel is like [Execute] but executes txs on [workers] goroutines
+// (see [executeConflicting]).
+//
+// Each tx is executed on its own [tstate.TState] and its changes are applied
+// to the state of the block (in order) once all txs are executed.
+func (p *Processor) executeParallel(
+	ctx context.Context,
+	ectx *ExecutionContext,
+	r Rules,
+	workers int,
+	window int,
+) (fees.Dimensions, []*Result, int, int, float64, error) {
+	ctx, span := p.tracer.Start(ctx, "Processor.ExecuteParallel")
+	defer span.End()
+
+	var (
+		t        = p.blk.GetTimestamp()
+		sm       = p.blk.vm.StateManager()
+		profile  = p.blk.profile
+		txs      = make([]*parallelTx, len(p.blk.Txs))
+		keys     = make([][][]byte, len(p.blk.Txs))
+		readOnly = make([]map[string]struct{}, len(p.blk.Txs))
+	)
+	if profile != nil {
+		profile.Txs = make([]*TxProfile, len(txs))
+	}
+	for i, tx := range p.blk.Txs {
+		keys[i] = tx.StateKeys(sm)
+		readOnly[i] = tx.ReadOnlyKeys(sm)
+		txs[i] = &parallelTx{keys: keys[i]}
+		if profile != nil {
+			profile.Txs[i] = &TxProfile{TxID: tx.ID()}
+		}
+	}
+
+	// Announce each tx once its state is fetched ([readyTxs] is closed once
+	// all txs are fetched or fetching is aborted)
+	fetched := make(chan struct{}, len(txs))
+	go func() {
+		i := 0
+		for data := range p.readyTxs {
+			txs[i].data = data
+			i++
+			fetched <- struct{}{}
+		}
+		close(fetched)
+	}()
+
+	// [values] holds the latest value of each key modified by an executed tx.
+	// A tx only reads the values of its own keys and no other tx that writes
+	// them can be executing at the same time, so [valuesL] only protects the
+	// map itself.
+	var (
+		values  = map[string]tstate.Value{}
+		valuesL sync.Mutex
+
+		executed atomic.Int64
+		busy     atomic.Int64 // time spent executing txs
+	)
+	execute := func(ctx context.Context, i int) error {
+		ptx := txs[i]
+		tx := ptx.data.tx
+		start := time.Now()
+
+		ts := tstate.New(len(ptx.keys))
+		ts.SetScope(ctx, ptx.keys, conflictStorage(ptx.keys, values, &valuesL, ptx.data.storage))
+		if err := tx.PreExecute(ctx, ectx, r, sm, ts, t); err != nil {
+			return err
+		}
+		var (
+			warpVerified bool
+			warpWait     time.Duration
+		)
+		warpMsg, ok := p.blk.warpMessages[tx.ID()]
+		if ok {
+			warpStart := time.Now()
+			select {
+			case warpVerified = <-warpMsg.verifiedChan:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			warpWait = time.Since(warpStart)
+		}
+		result, err := tx.Execute(ctx, ectx, r, sm, ts, t, ok && warpVerified)
+		if err != nil {
+			return err
+		}
+		ptx.result = result
+		ptx.changes = ts.ChangedValues()
+		ptx.ops = ts.OpIndex()
+
+		valuesL.Lock()
+		for k, v := range ptx.changes {
+			values[k] = v
+		}
+		valuesL.Unlock()
+
+		elapsed := time.Since(start) - warpWait
+		busy.Add(int64(elapsed))
+		executed.Add(1)
+		if profile != nil {
+			txProfile := profile.Txs[i]
+			txProfile.WarpWait = warpWait
+			txProfile.Execution = elapsed
+			txProfile.Units = result.Units
+			txProfile.Success = result.Success
+		}
+		return nil
+	}
+	start := time.Now()
+	err := executeConflicting(ctx, keys, readOnly, workers, window, fetched, execute)
+	p.executed = int(executed.Load())
+	if err != nil {
+		return fees.Dimensions{}, nil, 0, 0, 0, err
+	}
+	elapsed := time.Since(start)
+
+	// Apply the changes of each tx (in order) to the state of the block
+	var (
+		unitsConsumed = fees.Dimensions{}
+		ts            = tstate.New(len(txs) * 2) // TODO: tune this heuristic
+		results       = make([]*Result, 0, len(txs))
+		ops           = 0
+	)
+	for _, ptx := range txs {
+		results = append(results, ptx.result)
+		unitsConsumed, err = fees.Add(unitsConsumed, ptx.result.Consumed)
+		if err != nil {
+			return fees.Dimensions{}, nil, 0, 0, 0, err
+		}
+		if unitsConsumed.Exceeds(r.GetMaxBlockUnits()) {
+			return fees.Dimensions{}, nil, 0, 0, 0, ErrBlockTooBig
+		}
+		ops += ptx.ops
+
+		// The state fetched for each tx is the state before the block, so
+		// changes made by earlier txs are still applied correctly
+		ts.SetScope(ctx, ptx.keys, ptx.data.storage)
+		for k, v := range ptx.changes {
+			if v.Exists {
+				err = ts.Insert(ctx, []byte(k), v.V)
+			} else {
+				err = ts.Remove(ctx, []byte(k))
+			}
+			if err != nil {
+				return fees.Dimensions{}, nil, 0, 0, 0, err
+			}
+		}
+	}
+	if err := ts.WriteChanges(ctx, p.db, p.tracer); err != nil {
+		return fees.Dimensions{}, nil, 0, 0, 0, err
+	}
+	if recordingStateDiffs(p.blk.vm) {
+		p.blk.diff = ts.Changes()
+	}
+	var parallelism float64
+	if elapsed > 0 {
+		parallelism = float64(busy.Load()) / float64(elapsed)
+	}
+	return unitsConsumed, results, ts.PendingChanges(), ops, parallelism, nil
+}
@@ -0,0 +1,374 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/fees"
+	htrace "github.com/ava-labs/hypersdk/trace"
+)
+
+var errTestExecution = errors.New("execution failed")
+
+func TestExecuteConflicting(t *testing.T) {
+	var (
+		a = []byte("a")
+		b = []byte("b")
+		c = []byte("c")
+
+		// 2 waits on 0 and 1, 4 waits on 2, and 5 waits on 3
+		keys = [][][]byte{{a}, {b}, {a, b}, {c}, {a}, {c}, {}}
+	)
+	for _, workers := range []int{1, 2, 4} {
+		for _, window := range []int{0, 2} {
+			require := require.New(t)
+
+			var (
+				l        sync.Mutex
+				clock    int
+				started  = make([]int, len(keys))
+				finished = make([]int, len(keys))
+				ahead    = 0 // txs executed too far ahead
+			)
+			execute := func(_ context.Context, i int) error {
+				l.Lock()
+				clock++
+				started[i] = clock
+
+				// Txs are never executed more than [window] txs ahead of the
+				// first unexecuted tx
+				for j := 0; window > 0 && j <= i-window; j++ {
+					if finished[j] == 0 {
+						ahead++
+					}
+				}
+				l.Unlock()
+
+				time.Sleep(time.Millisecond)
+
+				l.Lock()
+				clock++
+				finished[i] = clock
+				l.Unlock()
+				return nil
+			}
+			require.NoError(executeConflicting(context.TODO(), keys, nil, workers, window, nil, execute))
+			require.Zero(ahead)
+
+			// Each tx is executed after every earlier tx it conflicts with
+			for i, txKeys := range keys {
+				require.NotZero(finished[i])
+				for j := 0; j < i; j++ {
+					for _, k := range txKeys {
+						for _, jk := range keys[j] {
+							if string(k) == string(jk) {
+								require.Less(finished[j], started[i])
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestExecuteConflictingReadOnly(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		a = []byte("a")
+		b = []byte("b")
+
+		// 0 and 1 only read [a], 2 writes [a] after them, and 3 reads [a]
+		// after 2
+		keys     = [][][]byte{{a}, {a, b}, {a}, {a}}
+		readOnly = []map[string]struct{}{
+			{string(a): {}},
+			{string(a): {}},
+			nil,
+			{string(a): {}},
+		}
+
+		readers  = newRendezvous(2)
+		l        sync.Mutex
+		clock    int
+		started  = make([]int, len(keys))
+		finished = make([]int, len(keys))
+	)
+	execute := func(_ context.Context, i int) error {
+		l.Lock()
+		clock++
+		started[i] = clock
+		l.Unlock()
+
+		if i < 2 {
+			readers.wait()
+		}
+
+		l.Lock()
+		clock++
+		finished[i] = clock
+		l.Unlock()
+		return nil
+	}
+	require.NoError(executeConflicting(context.TODO(), keys, readOnly, 4, 0, nil, execute))
+
+	// Txs that only read the same key are executed at the same time, but a
+	// tx that writes it waits for every earlier reader (and later readers
+	// wait for it)
+	require.True(readers.met())
+	require.Less(finished[0], started[2])
+	require.Less(finished[1], started[2])
+	require.Less(finished[2], started[3])
+}
+
+func TestExecuteConflictingError(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		a    = []byte("a")
+		keys = [][][]byte{{a}, {a}, {a}}
+
+		l        sync.Mutex
+		executed = []int{}
+	)
+	execute := func(_ context.Context, i int) error {
+		l.Lock()
+		executed = append(executed, i)
+		l.Unlock()
+		if i == 1 {
+			return errTestExecution
+		}
+		return nil
+	}
+	require.ErrorIs(executeConflicting(context.TODO(), keys, nil, 2, 0, nil, execute), errTestExecution)
+
+	// Txs that conflict with a failed tx are never executed
+	require.Equal([]int{0, 1}, executed)
+}
+
+func TestExecuteConflictingCancel(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		keys        = [][][]byte{{[]byte("a")}, {[]byte("b")}, {[]byte("c")}}
+		ctx, cancel = context.WithCancel(context.TODO())
+		fetched     = make(chan struct{}, len(keys))
+
+		l        sync.Mutex
+		executed = []int{}
+	)
+	execute := func(_ context.Context, i int) error {
+		l.Lock()
+		executed = append(executed, i)
+		l.Unlock()
+
+		// Stop before the remaining txs are fetched
+		cancel()
+		return nil
+	}
+	fetched <- struct{}{}
+	require.ErrorIs(executeConflicting(ctx, keys, nil, 2, 0, fetched, execute), context.Canceled)
+
+	// Txs are only executed once they are fetched
+	require.Equal([]int{0}, executed)
+}
+
+// executeTestBlock executes [txs] (on [workers] goroutines) on the state of
+// [payers] and returns the units they consumed, their results, and the
+// resulting state root.
+func executeTestBlock(
+	ctx context.Context,
+	t *testing.T,
+	workers int,
+	timestamp int64,
+	txs []*Transaction,
+	payers ...string,
+) (fees.Dimensions, []*Result, ids.ID, error) {
+	require := require.New(t)
+
+	tracer, err := htrace.New(&htrace.Config{Enabled: false})
+	require.NoError(err)
+	state := newTestState(t, payers...)
+	require.NoError(state.Put([]byte("removed"), binary.BigEndian.AppendUint64(nil, 1)))
+	view, err := state.NewPreallocatedView(len(txs))
+	require.NoError(err)
+
+	rules := &testRules{maxBlockUnits: fees.Dimensions{1 << 20, 1 << 20, 1 << 20, 1 << 20, 1 << 20}}
+	vm := &testVM{tracer: tracer, rules: rules, workers: workers, maxParallelism: 4}
+	blk := &StatelessBlock{
+		StatefulBlock: &StatefulBlock{Tmstmp: timestamp, Txs: txs},
+		vm:            vm,
+	}
+	p := NewProcessor(tracer, blk)
+	p.Prefetch(ctx, view)
+	unitsConsumed, results, _, _, err := p.Execute(ctx, &ExecutionContext{NextUnitPrices: testMinUnitPrices}, rules)
+	if err != nil {
+		return fees.Dimensions{}, nil, ids.Empty, err
+	}
+	root, err := view.GetMerkleRoot(ctx)
+	require.NoError(err)
+	return unitsConsumed, results, root, nil
+}
+
+func TestProcessorExecuteParallel(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx       = context.TODO()
+		timestamp = time.Now().UnixMilli() / 1000 * 1000
+		expiry    = timestamp + 10_000
+
+		counter = []byte("counter")
+		removed = []byte("removed")
+	)
+	txs := []*Transaction{
+		// "a" conflicts with every tx that modifies [counter] (and its own txs)
+		newTestTx("a", expiry, 1, &testAction{keys: [][]byte{counter}}),
+		newTestTx("b", expiry, 1, &testAction{keys: [][]byte{[]byte("b0")}}),
+		newTestTx("a", expiry, 1, &testAction{keys: [][]byte{[]byte("a0")}}),
+		newTestTx("c", expiry, 1, &testAction{keys: [][]byte{counter}, fail: true}),
+		newTestTx("d", expiry, 1, &testAction{keys: [][]byte{[]byte("d0")}}),
+		newTestTx("b", expiry, 1, &testAction{keys: [][]byte{counter, removed}, remove: true}),
+		newTestTx("c", expiry, 1, &testAction{keys: [][]byte{counter, removed}}),
+		newTestTx("d", expiry, 1, &testAction{keys: [][]byte{[]byte("d1")}}),
+		newTestTx("a", expiry, 1, &testAction{keys: [][]byte{counter}}),
+	}
+	payers := []string{"a", "b", "c", "d"}
+
+	// Executing txs in parallel has the same outcome as executing them in
+	// order
+	sequentialUnits, sequentialResults, sequentialRoot, err := executeTestBlock(ctx, t, 1, timestamp, txs, payers...)
+	require.NoError(err)
+	require.Len(sequentialResults, len(txs))
+	require.False(sequentialResults[3].Success)
+	for _, workers := range []int{2, 4, 8} {
+		units, results, root, err := executeTestBlock(ctx, t, workers, timestamp, txs, payers...)
+		require.NoError(err)
+		require.Equal(sequentialUnits, units)
+		require.Equal(sequentialResults, results)
+		require.Equal(sequentialRoot, root)
+	}
+}
+
+func TestProcessorExecuteParallelReadOnly(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx       = context.TODO()
+		timestamp = time.Now().UnixMilli() / 1000 * 1000
+		expiry    = timestamp + 10_000
+
+		// Like a transfer reads the minimum transfer of its asset
+		min = []byte("min")
+	)
+	newTxs := func(onExecute func()) []*Transaction {
+		return []*Transaction{
+			newTestTx("a", expiry, 1, &testAction{keys: [][]byte{min, []byte("a0")}, readOnly: [][]byte{min}, onExecute: onExecute}),
+			newTestTx("b", expiry, 1, &testAction{keys: [][]byte{min, []byte("b0")}, readOnly: [][]byte{min}, onExecute: onExecute}),
+			newTestTx("c", expiry, 1, &testAction{keys: [][]byte{min}}),
+		}
+	}
+	payers := []string{"a", "b", "c"}
+	sequentialUnits, sequentialResults, sequentialRoot, err := executeTestBlock(ctx, t, 1, timestamp, newTxs(nil), payers...)
+	require.NoError(err)
+
+	// Txs of different payers that only read the same key are executed at the
+	// same time
+	readers := newRendezvous(2)
+	units, results, root, err := executeTestBlock(ctx, t, 4, timestamp, newTxs(readers.wait), payers...)
+	require.NoError(err)
+	require.True(readers.met())
+	require.Equal(sequentialUnits, units)
+	require.Equal(sequentialResults, results)
+	require.Equal(sequentialRoot, root)
+}
+
+func TestProcessorExecuteParallelError(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx       = context.TODO()
+		timestamp = time.Now().UnixMilli() / 1000 * 1000
+		expiry    = timestamp + 10_000
+		counter   = []byte("counter")
+	)
+	txs := []*Transaction{
+		newTestTx("a", expiry, 1, &testAction{keys: [][]byte{counter}}),
+		newTestTx("b", expiry, 1, &testAction{keys: [][]byte{counter}, err: errTestExecution}),
+		newTestTx("c", expiry, 1, &testAction{keys: [][]byte{counter}}),
+	}
+	for _, workers := range []int{1, 4} {
+		_, _, _, err := executeTestBlock(ctx, t, workers, timestamp, txs, "a", "b", "c")
+		require.ErrorIs(err, errTestExecution)
+	}
+}
+
+func TestProcessorExecuteParallelCancel(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		timestamp = time.Now().UnixMilli() / 1000 * 1000
+		expiry    = timestamp + 10_000
+		counter   = []byte("counter")
+	)
+	for _, workers := range []int{1, 4} {
+		ctx, cancel := context.WithCancel(context.TODO())
+		txs := []*Transaction{
+			newTestTx("a", expiry, 1, &testAction{keys: [][]byte{counter}, onExecute: cancel}),
+			newTestTx("b", expiry, 1, &testAction{keys: [][]byte{counter}}),
+			newTestTx("c", expiry, 1, &testAction{keys: [][]byte{counter}}),
+		}
+		_, _, _, err := executeTestBlock(ctx, t, workers, timestamp, txs, "a", "b", "c")
+		require.ErrorIs(err, context.Canceled)
+	}
+}
+
+// rendezvous blocks callers of [wait] until [n] of them are waiting at the
+// same time (or a second has passed).
+type rendezvous struct {
+	n   int
+	all chan struct{}
+
+	l        sync.Mutex
+	arrived  int
+	timedOut bool
+}
+
+func newRendezvous(n int) *rendezvous {
+	return &rendezvous{n: n, all: make(chan struct{})}
+}
+
+func (r *rendezvous) wait() {
+	r.l.Lock()
+	r.arrived++
+	if r.arrived == r.n {
+		close(r.all)
+	}
+	r.l.Unlock()
+
+	select {
+	case <-r.all:
+	case <-time.After(time.Second):
+		r.l.Lock()
+		r.timedOut = true
+		r.l.Unlock()
+	}
+}
+
+// met returns true if all [n] callers of [wait] were waiting at the same time.
+func (r *rendezvous) met() bool {
+	r.l.Lock()
+	defer r.l.Unlock()
+	return r.arrived >= r.n && !r.timedOut
+}
@@ -6,10 +6,12 @@ package chain
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/trace"
 
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/tstate"
 )
 
@@ -29,6 +31,8 @@ type Processor struct {
 	blk      *StatelessBlock
 	readyTxs chan *txData
 	db       Database
+
+	executed int // txs executed so far (used to estimate the work left)
 }
 
 // Only prepare for population if above last accepted height
@@ -50,7 +54,12 @@ func (p *Processor) Prefetch(ctx context.Context, db Database) {
 
 		// Store required keys for each set
 		alreadyFetched := make(map[string]*fetchData, len(p.blk.GetTxs()))
+	txs:
 		for _, tx := range p.blk.GetTxs() {
+			// Stop fetching if execution was aborted
+			if ctx.Err() != nil {
+				break
+			}
 			storage := map[string][]byte{}
 			for _, k := range tx.StateKeys(sm) {
 				sk := string(k)
@@ -65,6 +74,10 @@ func (p *Processor) Prefetch(ctx context.Context, db Database) {
 					alreadyFetched[sk] = &fetchData{nil, false}
 					continue
 				} else if err != nil {
+					if ctx.Err() != nil {
+						// [Execute] will return the context error
+						break txs
+					}
 					panic(err)
 				}
 				alreadyFetched[sk] = &fetchData{v, true}
@@ -82,27 +95,58 @@ func (p *Processor) Execute(
 	ctx context.Context,
 	ectx *ExecutionContext,
 	r Rules,
-) (uint64, []*Result, int, int, error) {
+) (fees.Dimensions, []*Result, int, int, error) {
 	ctx, span := p.tracer.Start(ctx, "Processor.Execute")
 	defer span.End()
 
+	if pe, ok := p.blk.vm.(ParallelExecutor); ok && pe.GetExecutionWorkers() > 1 && len(p.blk.Txs) > 1 {
+		unitsConsumed, results, stateChanges, stateOps, parallelism, err := p.executeParallel(
+			ctx,
+			ectx,
+			r,
+			pe.GetExecutionWorkers(),
+			pe.GetExecutionMaxParallelism(),
+		)
+		if err != nil {
+			return fees.Dimensions{}, nil, 0, 0, err
+		}
+		pe.RecordExecutionParallelism(parallelism)
+		return unitsConsumed, results, stateChanges, stateOps, nil
+	}
+
 	var (
-		unitsConsumed = uint64(0)
+		unitsConsumed = fees.Dimensions{}
 		ts            = tstate.New(len(p.blk.Txs) * 2) // TODO: tune this heuristic
 		t             = p.blk.GetTimestamp()
 		results       = []*Result{}
 		sm            = p.blk.vm.StateManager()
 	)
-	for txData := range p.readyTxs {
+	profile := p.blk.profile
+	for {
+		// Stop executing as soon as the block is no longer needed
+		if err := ctx.Err(); err != nil {
+			return fees.Dimensions{}, nil, 0, 0, err
+		}
+		start := time.Now()
+		txData, ok := <-p.readyTxs
+		if !ok {
+			break
+		}
 		tx := txData.tx
+		var txProfile *TxProfile
+		if profile != nil {
+			txProfile = &TxProfile{TxID: tx.ID(), PrefetchWait: time.Since(start)}
+			profile.Txs = append(profile.Txs, txProfile)
+			start = time.Now()
+		}
 
 		// It is critical we explicitly set the scope before each transaction is
 		// processed
 		ts.SetScope(ctx, tx.StateKeys(sm), txData.storage)
 
 		// Execute tx
-		if err := tx.PreExecute(ctx, ectx, r, ts, t); err != nil {
-			return 0, nil, 0, 0, err
+		if err := tx.PreExecute(ctx, ectx, r, sm, ts, t); err != nil {
+			return fees.Dimensions{}, nil, 0, 0, err
 		}
 		// Wait to execute transaction until we have the warp result processed.
 		//
@@ -111,28 +155,49 @@ func (p *Processor) Execute(
 		var warpVerified bool
 		warpMsg, ok := p.blk.warpMessages[tx.ID()]
 		if ok {
+			warpStart := time.Now()
 			select {
 			case warpVerified = <-warpMsg.verifiedChan:
 			case <-ctx.Done():
-				return 0, nil, 0, 0, ctx.Err()
+				return fees.Dimensions{}, nil, 0, 0, ctx.Err()
+			}
+			if txProfile != nil {
+				txProfile.WarpWait = time.Since(warpStart)
 			}
 		}
-		result, err := tx.Execute(ctx, r, sm, ts, t, ok && warpVerified)
+		result, err := tx.Execute(ctx, ectx, r, sm, ts, t, ok && warpVerified)
 		if err != nil {
-			return 0, nil, 0, 0, err
+			return fees.Dimensions{}, nil, 0, 0, err
 		}
 		results = append(results, result)
+		p.executed++
+		if txProfile != nil {
+			txProfile.Execution = time.Since(start) - txProfile.WarpWait
+			txProfile.Units = result.Units
+			txProfile.Success = result.Success
+		}
 
 		// Update block metadata
-		unitsConsumed += result.Units
-		if unitsConsumed > r.GetMaxBlockUnits() {
+		unitsConsumed, err = fees.Add(unitsConsumed, result.Consumed)
+		if err != nil {
+			return fees.Dimensions{}, nil, 0, 0, err
+		}
+		if unitsConsumed.Exceeds(r.GetMaxBlockUnits()) {
 			// Exit as soon as we hit our max
-			return 0, nil, 0, 0, ErrBlockTooBig
+			return fees.Dimensions{}, nil, 0, 0, ErrBlockTooBig
 		}
 	}
+	// [readyTxs] is closed early if fetching was aborted
+	if err := ctx.Err(); err != nil {
+		return fees.Dimensions{}, nil, 0, 0, err
+	}
+
 	// Wait until end to write changes to avoid conflicting with pre-fetching
 	if err := ts.WriteChanges(ctx, p.db, p.tracer); err != nil {
-		return 0, nil, 0, 0, err
+		return fees.Dimensions{}, nil, 0, 0, err
+	}
+	if recordingStateDiffs(p.blk.vm) {
+		p.blk.diff = ts.Changes()
 	}
 	return unitsConsumed, results, ts.PendingChanges(), ts.OpIndex(), nil
 }
@@ -0,0 +1,59 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// TxProfile is the execution breakdown of a single transaction in a block.
+type TxProfile struct {
+	TxID ids.ID `json:"txId"`
+
+	// PrefetchWait is how long execution waited for the state keys of the
+	// transaction to be fetched.
+	PrefetchWait time.Duration `json:"prefetchWait"`
+
+	// WarpWait is how long execution waited for the warp message of the
+	// transaction to be verified.
+	WarpWait time.Duration `json:"warpWait"`
+
+	// Execution is the time spent in [Transaction.PreExecute] and
+	// [Transaction.Execute].
+	Execution time.Duration `json:"execution"`
+
+	Units   uint64 `json:"units"`
+	Success bool   `json:"success"`
+}
+
+// ExecutionProfile is the execution breakdown of a verified block, which can
+// be used to find the transactions (or stages) that slowed it down.
+type ExecutionProfile struct {
+	BlockID   ids.ID `json:"blockId"`
+	Height    uint64 `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+
+	Txs []*TxProfile `json:"txs"`
+
+	// Execution is the time spent processing all transactions (including
+	// any waits), RootCalculation is the time spent computing the state
+	// root, and SignatureWait is how long verification waited for signatures
+	// to be verified after that.
+	Execution       time.Duration `json:"execution"`
+	RootCalculation time.Duration `json:"rootCalculation"`
+	SignatureWait   time.Duration `json:"signatureWait"`
+}
+
+func executionProfiling(vm VM) bool {
+	p, ok := vm.(ExecutionProfiler)
+	return ok && p.ExecutionProfiling()
+}
+
+// ExecutionProfile returns the [ExecutionProfile] of [b] (or nil if [b] was
+// not profiled).
+func (b *StatelessBlock) ExecutionProfile() *ExecutionProfile {
+	return b.profile
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
 )
 
 type Result struct {
@@ -14,10 +15,19 @@ type Result struct {
 	Units       uint64
 	Output      []byte
 	WarpMessage *warp.UnsignedMessage
+
+	// Events emitted by the [Action] (dropped if it fails)
+	Events []*Event
+
+	// Populated by [Transaction.Execute] ([Units] is the compute units
+	// consumed)
+	Consumed fees.Dimensions
+	Fee      uint64
 }
 
 func (r *Result) Size() int {
-	size := consts.BoolLen + consts.Uint64Len + codec.BytesLen(r.Output)
+	size := consts.BoolLen + consts.Uint64Len + codec.BytesLen(r.Output) +
+		fees.DimensionsLen + consts.Uint64Len + eventsSize(r.Events)
 	if r.WarpMessage != nil {
 		size += codec.BytesLen(r.WarpMessage.Bytes())
 	} else {
@@ -35,6 +45,9 @@ func (r *Result) Marshal(p *codec.Packer) {
 		warpBytes = r.WarpMessage.Bytes()
 	}
 	p.PackBytes(warpBytes)
+	p.PackDimensions(r.Consumed)
+	p.PackUint64(r.Fee)
+	marshalEvents(p, r.Events)
 }
 
 func MarshalResults(src []*Result) ([]byte, error) {
@@ -66,6 +79,13 @@ func UnmarshalResult(p *codec.Packer) (*Result, error) {
 		}
 		result.WarpMessage = msg
 	}
+	p.UnpackDimensions(&result.Consumed)
+	result.Fee = p.UnpackUint64(false)
+	events, err := unmarshalEvents(p)
+	if err != nil {
+		return nil, err
+	}
+	result.Events = events
 	return result, p.Err()
 }
 
@@ -0,0 +1,89 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// MaxSequenceGap is how far ahead of its payer's next sequence a transaction
+// can be while waiting (in the mempool) for the transactions before it to be
+// included.
+const MaxSequenceGap = 64
+
+// SequenceMode returns true if [r] requires transactions to use sequences.
+func SequenceMode(r Rules) bool {
+	sr, ok := r.(SequenceRules)
+	return ok && sr.GetSequenceMode()
+}
+
+// GetSequence returns the sequence that must be used by the next transaction
+// paid for by [payer].
+func GetSequence(ctx context.Context, sm StateManager, db Database, payer []byte) (uint64, error) {
+	ssm, ok := sm.(SequenceStateManager)
+	if !ok {
+		return 0, ErrMissingSequenceStateKey
+	}
+	v, err := db.GetValue(ctx, ssm.SequenceKey(payer))
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(v) != consts.Uint64Len {
+		return 0, ErrInvalidObject
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+// verifySequence ensures [t] uses the next sequence of its payer (when
+// sequence mode is enabled).
+//
+// [ErrSequenceTooHigh] is returned if [t] may become valid once the
+// transactions before it are included.
+func (t *Transaction) verifySequence(ctx context.Context, r Rules, sm StateManager, db Database) error {
+	if !SequenceMode(r) {
+		if t.Base.Sequence != 0 {
+			return ErrInvalidSequence
+		}
+		return nil
+	}
+	next, err := GetSequence(ctx, sm, db, t.Auth.Payer())
+	if err != nil {
+		return err
+	}
+	switch {
+	case t.Base.Sequence < next:
+		return ErrSequenceTooLow
+	case t.Base.Sequence-next > MaxSequenceGap:
+		return ErrInvalidSequence
+	case t.Base.Sequence > next:
+		return ErrSequenceTooHigh
+	default:
+		return nil
+	}
+}
+
+// incrementSequence records that [t] has used its payer's next sequence.
+func (t *Transaction) incrementSequence(ctx context.Context, r Rules, sm StateManager, db Database) error {
+	if !SequenceMode(r) {
+		return nil
+	}
+	ssm, ok := sm.(SequenceStateManager)
+	if !ok {
+		return ErrMissingSequenceStateKey
+	}
+	return db.Insert(
+		ctx,
+		ssm.SequenceKey(t.Auth.Payer()),
+		binary.BigEndian.AppendUint64(nil, t.Base.Sequence+1),
+	)
+}
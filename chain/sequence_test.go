@@ -0,0 +1,195 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+// sequenceTestStateManager is a [testStateManager] that supports sequences.
+type sequenceTestStateManager struct {
+	testStateManager
+}
+
+func (sequenceTestStateManager) SequenceKey(payer []byte) []byte {
+	return append([]byte("sequence/"), payer...)
+}
+
+// newSequenceTestState returns a [tstate.TState] scoped to the keys of [tx]
+// where its payer has [testBalance] and will next use sequence [next].
+func newSequenceTestState(ctx context.Context, sm StateManager, tx *Transaction, next uint64) *tstate.TState {
+	payer := tx.Auth.Payer()
+	storage := map[string][]byte{
+		string(balanceKey(payer)): binary.BigEndian.AppendUint64(nil, testBalance),
+	}
+	if next > 0 {
+		storage[string(sequenceTestStateManager{}.SequenceKey(payer))] = binary.BigEndian.AppendUint64(nil, next)
+	}
+	ts := tstate.New(len(storage))
+	ts.SetScope(ctx, tx.StateKeys(sm), storage)
+	return ts
+}
+
+func TestTransactionPreExecuteSequence(t *testing.T) {
+	var (
+		timestamp = int64(1_000)
+		expiry    = timestamp + 10_000
+		ectx      = &ExecutionContext{NextUnitPrices: testMinUnitPrices}
+		sm        = sequenceTestStateManager{}
+	)
+	for name, tt := range map[string]struct {
+		rules    Rules
+		sm       StateManager
+		next     uint64
+		sequence uint64
+		err      error
+	}{
+		"sequence mode disabled": {
+			rules: &testRules{},
+			sm:    sm,
+		},
+		"sequence without sequence mode": {
+			rules:    &testRules{},
+			sm:       sm,
+			sequence: 1,
+			err:      ErrInvalidSequence,
+		},
+		"next sequence": {
+			rules:    &sequenceTestRules{&testRules{}},
+			sm:       sm,
+			next:     3,
+			sequence: 3,
+		},
+		"used sequence": {
+			rules:    &sequenceTestRules{&testRules{}},
+			sm:       sm,
+			next:     3,
+			sequence: 2,
+			err:      ErrSequenceTooLow,
+		},
+		"future sequence": {
+			rules:    &sequenceTestRules{&testRules{}},
+			sm:       sm,
+			next:     3,
+			sequence: 4,
+			err:      ErrSequenceTooHigh,
+		},
+		"sequence too far ahead": {
+			rules:    &sequenceTestRules{&testRules{}},
+			sm:       sm,
+			next:     3,
+			sequence: 3 + MaxSequenceGap + 1,
+			err:      ErrInvalidSequence,
+		},
+		"state manager without sequences": {
+			rules: &sequenceTestRules{&testRules{}},
+			sm:    testStateManager{},
+			err:   ErrMissingSequenceStateKey,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.TODO()
+
+			tx := newTestTx("a", expiry, 1, &testAction{keys: [][]byte{[]byte("k")}})
+			tx.Base.Sequence = tt.sequence
+			ts := newSequenceTestState(ctx, tt.sm, tx, tt.next)
+			err := tx.PreExecute(ctx, ectx, tt.rules, tt.sm, ts, timestamp)
+			require.ErrorIs(err, tt.err)
+		})
+	}
+}
+
+func TestTransactionExecuteSequence(t *testing.T) {
+	var (
+		timestamp = int64(1_000)
+		expiry    = timestamp + 10_000
+		ectx      = &ExecutionContext{NextUnitPrices: testMinUnitPrices}
+		r         = &sequenceTestRules{&testRules{}}
+		sm        = sequenceTestStateManager{}
+	)
+	for name, action := range map[string]*testAction{
+		"action succeeds": {keys: [][]byte{[]byte("k")}},
+		"action fails":    {keys: [][]byte{[]byte("k")}, fail: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.TODO()
+
+			tx := newTestTx("a", expiry, 1, action)
+			tx.Base.Sequence = 3
+
+			// The payer's sequence is read and written by every tx
+			maxUnits, err := tx.MaxUnits(r)
+			require.NoError(err)
+			require.Equal(fees.Dimensions{testTxSize, 3, 4, 4, 4}, maxUnits)
+
+			ts := newSequenceTestState(ctx, sm, tx, 3)
+			require.NoError(tx.PreExecute(ctx, ectx, r, sm, ts, timestamp))
+			result, err := tx.Execute(ctx, ectx, r, sm, ts, timestamp, false)
+			require.NoError(err)
+			require.Equal(!action.fail, result.Success)
+
+			// The sequence is consumed (and the payer is refunded any unused
+			// fee) even if the action fails
+			next, err := GetSequence(ctx, sm, ts, tx.Auth.Payer())
+			require.NoError(err)
+			require.Equal(uint64(4), next)
+			balance, err := getUint64(ctx, ts, balanceKey(tx.Auth.Payer()))
+			require.NoError(err)
+			require.Equal(testBalance-result.Fee, balance)
+			require.ErrorIs(tx.PreExecute(ctx, ectx, r, sm, ts, timestamp), ErrSequenceTooLow)
+		})
+	}
+}
+
+func TestBuildBlockSequence(t *testing.T) {
+	var (
+		ctx    = context.TODO()
+		expiry = (time.Now().UnixMilli()/1000 + 10) * 1000
+		rules  = &sequenceTestRules{&testRules{
+			maxBlockUnits: fees.Dimensions{1 << 20, 1 << 20, 1 << 20, 1 << 20, 1 << 20},
+		}}
+	)
+	newTx := func(payer string, sequence uint64, price uint64) *Transaction {
+		tx := newTestTx(payer, expiry, price, &testAction{keys: [][]byte{[]byte(payer)}})
+		tx.Base.Sequence = sequence
+		return tx
+	}
+	var (
+		a0 = newTx("a", 0, 200)
+		a1 = newTx("a", 1, 100)
+		a2 = newTx("a", 2, 300)
+		a5 = newTx("a", 5, 50)
+		b0 = newTx("b", 0, 250)
+
+		// Txs are popped in order of price
+		txs = []*Transaction{a2, b0, a0, newTx("b", 0, 150), a1, a5}
+	)
+	for _, workers := range []int{1, 4} {
+		require := require.New(t)
+
+		// Txs popped before the txs that precede them are included as soon
+		// as they can be, txs that reuse a sequence are dropped, and txs that
+		// are still waiting on their predecessors are restored
+		vm := &testVM{rules: rules, sm: sequenceTestStateManager{}, workers: workers}
+		blk, mp, err := buildTestBlock(ctx, t, vm, txs, "a", "b")
+		require.NoError(err)
+		require.Equal([]*Transaction{b0, a0, a1, a2}, blk.Txs)
+		remaining := []*Transaction{}
+		mp.IterateByPrice(ctx, func(tx *Transaction) bool {
+			remaining = append(remaining, tx)
+			return true
+		})
+		require.Equal([]*Transaction{a5}, remaining)
+	}
+}
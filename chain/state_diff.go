@@ -0,0 +1,96 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+// StateDiff is the net change made to state by the transactions in the block
+// at [Height] (ordered by key). The height key written by every block is not
+// included.
+type StateDiff struct {
+	Height  uint64           `json:"height"`
+	Changes []*tstate.Change `json:"changes"`
+}
+
+func recordingStateDiffs(vm VM) bool {
+	r, ok := vm.(StateDiffRecorder)
+	return ok && r.RecordingStateDiffs()
+}
+
+// StateDiff returns the [StateDiff] of [b] (or nil if the [VM] was not
+// recording state diffs when [b] was executed).
+func (b *StatelessBlock) StateDiff() *StateDiff {
+	if b.diff == nil {
+		return nil
+	}
+	return &StateDiff{Height: b.Hght, Changes: b.diff}
+}
+
+func packOptionalBytes(p *codec.Packer, b []byte) {
+	p.PackBool(b != nil)
+	if b != nil {
+		p.PackBytes(b)
+	}
+}
+
+func unpackOptionalBytes(p *codec.Packer) []byte {
+	if !p.UnpackBool() {
+		return nil
+	}
+	var b []byte
+	p.UnpackBytes(consts.MaxInt, false, &b)
+	if b == nil {
+		// Distinguish an empty value from a missing one
+		b = []byte{}
+	}
+	return b
+}
+
+func (d *StateDiff) Size() int {
+	size := consts.Uint64Len + consts.IntLen
+	for _, change := range d.Changes {
+		size += codec.BytesLen(change.Key) + 2*consts.BoolLen
+		if change.Old != nil {
+			size += codec.BytesLen(change.Old)
+		}
+		if change.New != nil {
+			size += codec.BytesLen(change.New)
+		}
+	}
+	return size
+}
+
+func (d *StateDiff) Marshal() ([]byte, error) {
+	p := codec.NewWriter(d.Size(), consts.MaxInt) // could be much larger than [NetworkSizeLimit]
+	p.PackUint64(d.Height)
+	p.PackInt(len(d.Changes))
+	for _, change := range d.Changes {
+		p.PackBytes(change.Key)
+		packOptionalBytes(p, change.Old)
+		packOptionalBytes(p, change.New)
+	}
+	return p.Bytes(), p.Err()
+}
+
+func UnmarshalStateDiff(src []byte) (*StateDiff, error) {
+	p := codec.NewReader(src, consts.MaxInt) // could be much larger than [NetworkSizeLimit]
+	d := &StateDiff{Height: p.UnpackUint64(false)}
+	items := p.UnpackInt(false)
+	d.Changes = []*tstate.Change{}
+	for i := 0; i < items && p.Err() == nil; i++ {
+		change := &tstate.Change{}
+		p.UnpackBytes(consts.MaxInt, true, &change.Key)
+		change.Old = unpackOptionalBytes(p)
+		change.New = unpackOptionalBytes(p)
+		d.Changes = append(d.Changes, change)
+	}
+	if !p.Empty() {
+		return nil, ErrInvalidObject
+	}
+	return d, p.Err()
+}
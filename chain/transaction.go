@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
@@ -16,14 +17,16 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/emap"
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/mempool"
 	"github.com/ava-labs/hypersdk/tstate"
 	"github.com/ava-labs/hypersdk/utils"
 )
 
 var (
-	_ emap.Item    = (*Transaction)(nil)
-	_ mempool.Item = (*Transaction)(nil)
+	_ emap.Item            = (*Transaction)(nil)
+	_ mempool.Item         = (*Transaction)(nil)
+	_ mempool.ResourceItem = (*Transaction)(nil)
 )
 
 type Transaction struct {
@@ -36,13 +39,23 @@ type Transaction struct {
 	bytes          []byte
 	size           int
 	id             ids.ID
+	authType       uint8
 	numWarpSigners int
 	// warpID is just the hash of the *warp.Message.Payload. We assumed that
 	// all warp messages from a single source have some unique field that
 	// prevents duplicates (like txID). We will not allow 2 instances of the same
 	// warpID from the same sourceChainID to be accepted.
-	warpID    ids.ID
-	stateKeys [][]byte
+	warpID       ids.ID
+	stateKeys    [][]byte
+	readOnlyKeys map[string]struct{}
+
+	// authVerified is set once [Auth] has been verified (so that it is not
+	// verified again)
+	authVerified atomic.Bool
+
+	// maxUnits are the first [MaxUnits] computed for t once it is signed
+	// (see [UnitPrice])
+	maxUnits atomic.Pointer[fees.Dimensions]
 }
 
 type WarpResult struct {
@@ -113,12 +126,25 @@ func (t *Transaction) Sign(
 	return UnmarshalTx(p, actionRegistry, authRegistry)
 }
 
+// AuthAsyncVerify returns a function that verifies [Auth]. The result of a
+// successful verification is cached on t.
 func (t *Transaction) AuthAsyncVerify() func() error {
 	return func() error {
-		return t.Auth.AsyncVerify(t.digest)
+		if t.authVerified.Load() {
+			return nil
+		}
+		if err := t.Auth.AsyncVerify(t.digest); err != nil {
+			return err
+		}
+		t.authVerified.Store(true)
+		return nil
 	}
 }
 
+// AuthVerified returns true if [Auth] was successfully verified by
+// [AuthAsyncVerify].
+func (t *Transaction) AuthVerified() bool { return t.authVerified.Load() }
+
 func (t *Transaction) Bytes() []byte { return t.bytes }
 
 func (t *Transaction) Size() int { return t.size }
@@ -127,7 +153,49 @@ func (t *Transaction) ID() ids.ID { return t.id }
 
 func (t *Transaction) Expiry() int64 { return t.Base.Timestamp }
 
-func (t *Transaction) UnitPrice() uint64 { return t.Base.UnitPrice }
+// UnitPrice is the max fee t pays (at [Base.MaxUnitPrices]) for the units of
+// every [fees.Dimension] it may consume (used to prioritize and evict
+// transactions in the mempool).
+//
+// The units of t are recorded the first time [MaxUnits] is computed (so that
+// the value of t never changes once it is added to the mempool). Until then,
+// t is valued as if it consumed a single unit of each dimension.
+func (t *Transaction) UnitPrice() uint64 {
+	fee, err := fees.MulSum(t.recordedUnits(), t.Base.MaxUnitPrices)
+	if err != nil {
+		return consts.MaxUint64
+	}
+	return fee
+}
+
+// ResourceUnits returns the units of each [fees.Dimension] t may consume
+// (the same units [UnitPrice] is computed from).
+func (t *Transaction) ResourceUnits() []uint64 {
+	units := t.recordedUnits()
+	return units[:]
+}
+
+// recordedUnits returns the units recorded by [MaxUnits] (or a single unit of
+// each dimension if they haven't been computed yet).
+func (t *Transaction) recordedUnits() fees.Dimensions {
+	if maxUnits := t.maxUnits.Load(); maxUnits != nil {
+		return *maxUnits
+	}
+	return fees.Dimensions{1, 1, 1, 1, 1}
+}
+
+// Nonce is [Base.Sequence] (used to order the transactions of each payer in
+// the mempool when sequence mode is enabled).
+func (t *Transaction) Nonce() uint64 { return t.Base.Sequence }
+
+func (t *Transaction) AuthType() uint8 { return t.authType }
+
+// SetAuth populates the [Auth] (of type [authType]) of an unsigned transaction
+// so that its fee can be estimated before it is signed.
+func (t *Transaction) SetAuth(auth Auth, authType uint8) {
+	t.Auth = auth
+	t.authType = authType
+}
 
 // It is ok to have duplicate ReadKeys...the processor will skip them
 func (t *Transaction) StateKeys(stateMapping StateManager) [][]byte {
@@ -136,19 +204,96 @@ func (t *Transaction) StateKeys(stateMapping StateManager) [][]byte {
 	if len(t.stateKeys) != 0 {
 		return t.stateKeys
 	}
-	keys := append(t.Action.StateKeys(t.Auth, t.ID()), t.Auth.StateKeys()...)
+	actionKeys := t.Action.StateKeys(t.Auth, t.ID())
+	keys := append(actionKeys, t.Auth.StateKeys()...)
 	if t.WarpMessage != nil {
 		keys = append(keys, stateMapping.IncomingWarpKey(t.WarpMessage.SourceChainID, t.warpID))
 	}
 	// Always assume a message could export a warp message
 	keys = append(keys, stateMapping.OutgoingWarpKey(t.id))
+	// Include the payer's sequence if the chain supports sequence mode
+	if ssm, ok := stateMapping.(SequenceStateManager); ok {
+		keys = append(keys, ssm.SequenceKey(t.Auth.Payer()))
+	}
 	t.stateKeys = keys
+	t.readOnlyKeys = t.computeReadOnlyKeys(keys, len(actionKeys))
 	return keys
 }
 
+// ReadOnlyKeys returns the [StateKeys] that t only reads: the keys reported by
+// a [ReadOnlyAction] that are not also used by [Auth] (or to store warp
+// messages and sequences).
+func (t *Transaction) ReadOnlyKeys(stateMapping StateManager) map[string]struct{} {
+	t.StateKeys(stateMapping)
+	return t.readOnlyKeys
+}
+
+// computeReadOnlyKeys returns the read-only keys of t, where the first
+// [actionKeys] of [keys] are the keys of [Action].
+func (t *Transaction) computeReadOnlyKeys(keys [][]byte, actionKeys int) map[string]struct{} {
+	ro, ok := t.Action.(ReadOnlyAction)
+	if !ok {
+		return nil
+	}
+	readOnly := map[string]struct{}{}
+	for _, k := range ro.ReadOnlyKeys(t.Auth, t.ID()) {
+		readOnly[string(k)] = struct{}{}
+	}
+	// Keys after those of [Action] may be written outside of [Action]
+	for _, k := range keys[actionKeys:] {
+		delete(readOnly, string(k))
+	}
+	return readOnly
+}
+
 // Units is charged whether or not a transaction is successful because state
 // lookup is not free.
-func (t *Transaction) MaxUnits(r Rules) (txFee uint64, err error) {
+//
+// Every declared state key is assumed to be read, and could be allocated or
+// written, by the transaction.
+func (t *Transaction) MaxUnits(r Rules) (fees.Dimensions, error) {
+	compute, err := t.maxComputeUnits(r)
+	if err != nil {
+		return fees.Dimensions{}, err
+	}
+	// Always assume a message could export a warp message
+	keys := uint64(len(t.Action.StateKeys(t.Auth, t.ID())) + len(t.Auth.StateKeys()) + 1)
+	if t.WarpMessage != nil {
+		keys++
+	}
+	if SequenceMode(r) {
+		keys++
+	}
+	units := fees.Dimensions{
+		fees.Bandwidth:       uint64(t.bandwidth()),
+		fees.Compute:         compute,
+		fees.StorageRead:     keys,
+		fees.StorageAllocate: keys,
+		fees.StorageWrite:    keys,
+	}
+	if t.size > 0 {
+		t.maxUnits.CompareAndSwap(nil, &units)
+	}
+	return units, nil
+}
+
+// bandwidth is [Size] or, if t is not signed yet, the size it will have
+// once it is signed (assuming the size of [Auth] doesn't change).
+func (t *Transaction) bandwidth() int {
+	if t.size > 0 {
+		return t.size
+	}
+	var warpBytes []byte
+	if t.WarpMessage != nil {
+		warpBytes = t.WarpMessage.Bytes()
+	}
+	return t.Base.Size() +
+		codec.BytesLen(warpBytes) +
+		consts.ByteLen + t.Action.Size() +
+		consts.ByteLen + t.Auth.Size()
+}
+
+func (t *Transaction) maxComputeUnits(r Rules) (txFee uint64, err error) {
 	txFee = r.GetBaseUnits()
 	txFee, err = smath.Add64(txFee, t.Action.MaxUnits(r))
 	if err != nil {
@@ -158,6 +303,10 @@ func (t *Transaction) MaxUnits(r Rules) (txFee uint64, err error) {
 	if err != nil {
 		return 0, err
 	}
+	txFee, err = smath.Add64(txFee, AuthUnits(r, t.authType))
+	if err != nil {
+		return 0, err
+	}
 	if t.WarpMessage != nil {
 		txFee, err = smath.Add64(txFee, r.GetWarpBaseUnits())
 		if err != nil {
@@ -178,11 +327,24 @@ func (t *Transaction) MaxUnits(r Rules) (txFee uint64, err error) {
 	return 1, nil
 }
 
+// MaxFee is the fee paid by t if it consumes [MaxUnits] at [unitPrices].
+func (t *Transaction) MaxFee(r Rules, unitPrices fees.Dimensions) (uint64, error) {
+	maxUnits, err := t.MaxUnits(r)
+	if err != nil {
+		return 0, err
+	}
+	return fees.MulSum(maxUnits, unitPrices)
+}
+
 // PreExecute must not modify state
+//
+// If sequence mode is enabled, [ErrSequenceTooHigh] is only returned if
+// all other checks pass.
 func (t *Transaction) PreExecute(
 	ctx context.Context,
 	ectx *ExecutionContext,
 	r Rules,
+	sm StateManager,
 	db Database,
 	timestamp int64,
 ) error {
@@ -203,33 +365,43 @@ func (t *Transaction) PreExecute(
 	if end >= 0 && timestamp > end {
 		return ErrAuthNotActivated
 	}
-	unitPrice := t.Base.UnitPrice
-	if unitPrice < ectx.NextUnitPrice {
+	if err := t.verifyAuthSize(r); err != nil {
+		return err
+	}
+	if err := VerifyTxSize(r, t.Size()); err != nil {
+		return err
+	}
+	if ectx.NextUnitPrices.Exceeds(t.Base.MaxUnitPrices) {
 		return ErrInsufficientPrice
 	}
 	if _, err := t.Auth.Verify(ctx, r, db, t.Action); err != nil {
 		return fmt.Errorf("%w: %v", ErrAuthFailed, err) //nolint:errorlint
 	}
-	maxUnits, err := t.MaxUnits(r)
+	fee, err := t.MaxFee(r, ectx.NextUnitPrices)
 	if err != nil {
 		return err
 	}
-	fee, err := smath.Mul64(maxUnits, unitPrice)
-	if err != nil {
+	if err := t.Auth.CanDeduct(ctx, db, fee); err != nil {
 		return err
 	}
-	return t.Auth.CanDeduct(ctx, db, fee)
+	return t.verifySequence(ctx, r, sm, db)
 }
 
 // Execute after knowing a transaction can pay a fee
+//
+// The transaction pays the unit prices of [ectx] (not [Base.MaxUnitPrices])
+// for the units it actually consumes.
 func (t *Transaction) Execute(
 	ctx context.Context,
+	ectx *ExecutionContext,
 	r Rules,
 	s StateManager,
 	tdb *tstate.TState,
 	timestamp int64,
 	warpVerified bool,
 ) (*Result, error) {
+	txStart := tdb.OpIndex()
+
 	// Check warp message is not duplicate
 	if t.WarpMessage != nil {
 		_, err := tdb.GetValue(ctx, s.IncomingWarpKey(t.WarpMessage.SourceChainID, t.warpID))
@@ -254,21 +426,35 @@ func (t *Transaction) Execute(
 	}
 
 	// Always charge fee first in case [Action] moves funds
-	unitPrice := t.Base.UnitPrice
 	maxUnits, err := t.MaxUnits(r)
 	if err != nil {
 		// Should never happen
 		return nil, err
 	}
-	if err := t.Auth.Deduct(ctx, tdb, unitPrice*maxUnits); err != nil {
+	maxFee, err := fees.MulSum(maxUnits, ectx.NextUnitPrices)
+	if err != nil {
+		// Should never happen
+		return nil, err
+	}
+	if err := t.Auth.Deduct(ctx, tdb, maxFee); err != nil {
 		// This should never fail for low balance (as we check [CanDeductFee]
 		// immediately before.
 		return nil, err
 	}
 
+	// Consume the payer's sequence (even if [Action] fails, as fees are still
+	// paid)
+	if err := t.incrementSequence(ctx, r, s, tdb); err != nil {
+		return nil, err
+	}
+
 	// We create a temp state to ensure we don't commit failed actions to state.
 	start := tdb.OpIndex()
+	if ro, ok := t.Action.(ReadOnlyAction); ok {
+		tdb.SetReadOnly(ctx, ro.ReadOnlyKeys(t.Auth, t.id))
+	}
 	result, err := t.Action.Execute(ctx, r, tdb, timestamp, t.Auth, t.id, warpVerified)
+	tdb.SetReadOnly(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -277,27 +463,23 @@ func (t *Transaction) Execute(
 		// fast)
 		return nil, ErrInvalidObject
 	}
+	if err := verifyEvents(result.Events); err != nil {
+		return nil, err
+	}
 	if !result.Success {
 		// Only keep changes if successful
 		result.WarpMessage = nil // warp messages can only be emitted on success
+		result.Events = nil      // events can only be emitted on success
 		tdb.Rollback(ctx, start)
 	}
 
 	// Update action units with other items
-	result.Units += r.GetBaseUnits() + authUnits
+	result.Units += r.GetBaseUnits() + authUnits + AuthUnits(r, t.authType)
 	if t.WarpMessage != nil {
 		result.Units += r.GetWarpBaseUnits()
 		result.Units += uint64(t.numWarpSigners) * r.GetWarpUnitsPerSigner()
 	}
 
-	// Return any funds from unused units
-	refund := (maxUnits - result.Units) * unitPrice
-	if refund > 0 {
-		if err := t.Auth.Refund(ctx, tdb, refund); err != nil {
-			return nil, err
-		}
-	}
-
 	// Handle all warp updates (if the transaction was successful)
 	if result.Success {
 		// Store incoming warp messages in state by their ID to prevent replays
@@ -324,6 +506,33 @@ func (t *Transaction) Execute(
 			}
 		}
 	}
+
+	// Return any funds from unused units (the refund itself only modifies the
+	// payer's balance, which was already written to deduct the fee)
+	allocated, written := tdb.KeyUsage(txStart)
+	result.Consumed = fees.Dimensions{
+		fees.Bandwidth:       maxUnits[fees.Bandwidth],
+		fees.Compute:         result.Units,
+		fees.StorageRead:     maxUnits[fees.StorageRead], // all keys are read before execution
+		fees.StorageAllocate: uint64(allocated),
+		fees.StorageWrite:    uint64(written),
+	}
+	result.Fee, err = fees.MulSum(result.Consumed, ectx.NextUnitPrices)
+	if err != nil {
+		// Should never happen
+		return nil, err
+	}
+	refund, err := smath.Sub(maxFee, result.Fee)
+	if err != nil {
+		// Only possible if [Action] or [Auth] consumed more units than they
+		// declared
+		return nil, fmt.Errorf("%w: fee %d exceeds max fee %d", ErrFeeTooLarge, result.Fee, maxFee)
+	}
+	if refund > 0 {
+		if err := t.Auth.Refund(ctx, tdb, refund); err != nil {
+			return nil, err
+		}
+	}
 	return result, nil
 }
 
@@ -472,6 +681,7 @@ func UnmarshalTx(
 	tx.Action = action
 	tx.WarpMessage = warpMessage
 	tx.Auth = auth
+	tx.authType = authType
 	if err := p.Err(); err != nil {
 		return nil, p.Err()
 	}
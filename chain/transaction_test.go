@@ -0,0 +1,144 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+// sequenceTestRules are [testRules] with sequence mode enabled.
+type sequenceTestRules struct {
+	*testRules
+}
+
+func (*sequenceTestRules) GetSequenceMode() bool { return true }
+
+func TestTransactionRefund(t *testing.T) {
+	var (
+		timestamp = int64(1_000)
+		expiry    = timestamp + 10_000
+		prices    = fees.Dimensions{1, 2, 3, 4, 5}
+		ectx      = &ExecutionContext{NextUnitPrices: prices}
+		r         = &testRules{}
+		created   = []byte("created")
+		existing  = []byte("existing")
+	)
+	for name, tt := range map[string]struct {
+		action   *testAction
+		consumed fees.Dimensions
+		err      error
+	}{
+		"allocates key": {
+			action:   &testAction{keys: [][]byte{created}},
+			consumed: fees.Dimensions{testTxSize, 3, 3, 1, 1},
+		},
+		"writes key": {
+			action:   &testAction{keys: [][]byte{existing}},
+			consumed: fees.Dimensions{testTxSize, 3, 3, 0, 2},
+		},
+		"action fails": {
+			action:   &testAction{keys: [][]byte{existing}, fail: true},
+			consumed: fees.Dimensions{testTxSize, 3, 3, 0, 1},
+		},
+		"consumes more units than declared": {
+			action: &testAction{keys: [][]byte{existing}, units: 100},
+			err:    ErrFeeTooLarge,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.TODO()
+
+			tx := newTestTx("a", expiry, 10, tt.action)
+			maxUnits, err := tx.MaxUnits(r)
+			require.NoError(err)
+			require.Equal(fees.Dimensions{testTxSize, 3, 3, 3, 3}, maxUnits)
+			maxFee, err := tx.MaxFee(r, prices)
+			require.NoError(err)
+
+			ts := tstate.New(4)
+			ts.SetScope(ctx, tx.StateKeys(testStateManager{}), map[string][]byte{
+				string(balanceKey([]byte("a"))): binary.BigEndian.AppendUint64(nil, testBalance),
+				string(existing):                binary.BigEndian.AppendUint64(nil, 1),
+			})
+			result, err := tx.Execute(ctx, ectx, r, testStateManager{}, ts, timestamp, false)
+			if tt.err != nil {
+				require.ErrorIs(err, tt.err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.consumed, result.Consumed)
+			fee, err := fees.MulSum(tt.consumed, prices)
+			require.NoError(err)
+			require.Equal(fee, result.Fee)
+
+			// The payer is refunded the difference between the max fee and
+			// the fee for the units consumed
+			require.Less(result.Fee, maxFee)
+			balance, err := getUint64(ctx, ts, balanceKey([]byte("a")))
+			require.NoError(err)
+			require.Equal(testBalance-result.Fee, balance)
+		})
+	}
+}
+
+func TestTransactionUnitPrice(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		r     = &testRules{}
+		small = newTestTx("a", 10_000, 1, &testAction{keys: [][]byte{[]byte("k")}})
+		large = newTestTx("a", 10_000, 1, &testAction{keys: [][]byte{[]byte("k0"), []byte("k1")}})
+	)
+	small.Base.MaxUnitPrices[fees.StorageWrite] = 2
+
+	// Until its units are known, a tx is valued as if it consumed a single
+	// unit of each dimension
+	require.Equal(uint64(6), small.UnitPrice())
+	require.Equal([]uint64{1, 1, 1, 1, 1}, small.ResourceUnits())
+
+	// Once known, a tx is valued by the max fee it pays for the units of
+	// every dimension
+	_, err := small.MaxUnits(r)
+	require.NoError(err)
+	require.Equal(uint64(testTxSize+3+3+3+3*2), small.UnitPrice())
+	require.Equal([]uint64{testTxSize, 3, 3, 3, 3}, small.ResourceUnits())
+	_, err = large.MaxUnits(r)
+	require.NoError(err)
+	require.Equal(uint64(testTxSize+3+4+4+4), large.UnitPrice())
+
+	// The value of a tx doesn't change once its units are recorded
+	units, err := small.MaxUnits(&sequenceTestRules{r})
+	require.NoError(err)
+	require.Equal(uint64(4), units[fees.StorageWrite])
+	require.Equal(uint64(testTxSize+3+3+3+3*2), small.UnitPrice())
+	require.Equal([]uint64{testTxSize, 3, 3, 3, 3}, small.ResourceUnits())
+}
+
+func TestTransactionReadOnlyKeys(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		min     = []byte("min")
+		balance = balanceKey([]byte("a"))
+	)
+
+	// Keys of actions that don't report read-only keys are all written
+	tx := newTestTx("a", 10_000, 1, &testAction{keys: [][]byte{min}})
+	require.Empty(tx.ReadOnlyKeys(testStateManager{}))
+
+	// Keys that [Auth] writes are never read-only
+	tx = newTestTx("a", 10_000, 1, &testAction{
+		keys:     [][]byte{min, balance},
+		readOnly: [][]byte{min, balance},
+	})
+	require.Equal(map[string]struct{}{string(min): {}}, tx.ReadOnlyKeys(testStateManager{}))
+}
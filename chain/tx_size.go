@@ -0,0 +1,49 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// TxTooLargeError is returned when a transaction is larger than [MaxTxSize].
+// It matches [ErrTxTooLarge] with [errors.Is].
+type TxTooLargeError struct {
+	Size  int
+	Limit int
+}
+
+func (e *TxTooLargeError) Error() string {
+	return fmt.Sprintf("%s: size=%d limit=%d", ErrTxTooLarge, e.Size, e.Limit)
+}
+
+func (*TxTooLargeError) Is(target error) bool {
+	return target == ErrTxTooLarge
+}
+
+// MaxTxSize returns the maximum size of a transaction (in bytes) under [r]. If
+// [r] does not implement [TxSizeRules] (or does not set a valid limit), this
+// is [consts.NetworkSizeLimit].
+func MaxTxSize(r Rules) int {
+	tr, ok := r.(TxSizeRules)
+	if !ok {
+		return consts.NetworkSizeLimit
+	}
+	limit := tr.GetMaxTxSize()
+	if limit <= 0 || limit > consts.NetworkSizeLimit {
+		return consts.NetworkSizeLimit
+	}
+	return limit
+}
+
+// VerifyTxSize returns a [*TxTooLargeError] if a transaction of [size] bytes
+// exceeds [MaxTxSize].
+func VerifyTxSize(r Rules, size int) error {
+	if limit := MaxTxSize(r); size > limit {
+		return &TxTooLargeError{Size: size, Limit: limit}
+	}
+	return nil
+}
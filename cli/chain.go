@@ -6,7 +6,6 @@ package cli
 import (
 	"context"
 	"fmt"
-	"math"
 	"os"
 	"strings"
 	"time"
@@ -16,8 +15,9 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/consts"
-	"github.com/ava-labs/hypersdk/pubsub"
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/throughput"
 	"github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/window"
 	"gopkg.in/yaml.v2"
@@ -178,14 +178,93 @@ func (h *Handler) PrintChainInfo() error {
 		return err
 	}
 	utils.Outf(
-		"{{cyan}}networkID:{{/}} %d {{cyan}}subnetID:{{/}} %s {{cyan}}chainID:{{/}} %s",
+		"{{cyan}}networkID:{{/}} %d {{cyan}}subnetID:{{/}} %s {{cyan}}chainID:{{/}} %s\n",
 		networkID,
 		subnetID,
 		chainID,
 	)
+	v, err := cli.Version(context.Background())
+	if err != nil {
+		return err
+	}
+	upgrades := "none"
+	if len(v.Upgrades) > 0 {
+		upgrades = strings.Join(v.Upgrades, ",")
+	}
+	utils.Outf(
+		"{{cyan}}vm:{{/}} %s {{cyan}}hypersdk:{{/}} %s {{cyan}}jsonrpc:{{/}} %v {{cyan}}websocket:{{/}} %v {{cyan}}upgrades:{{/}} %s\n",
+		v.VMVersion,
+		v.HyperSDKVersion,
+		v.JSONRPCVersions,
+		v.WebSocketVersions,
+		upgrades,
+	)
+	info, err := cli.ChainInfo(context.Background())
+	if err != nil {
+		return err
+	}
+	features := "none"
+	if len(info.Features) > 0 {
+		features = strings.Join(info.Features, ",")
+	}
+	utils.Outf(
+		"{{cyan}}genesis:{{/}} %s {{cyan}}rules version:{{/}} %d {{cyan}}descriptor:{{/}} %s {{cyan}}features:{{/}} %s\n",
+		info.GenesisHash,
+		info.RulesVersion,
+		info.DescriptorHash,
+		features,
+	)
+	summary := info.Fees
+	for i, dimension := range summary.Dimensions {
+		utils.Outf(
+			"{{cyan}}[%s]{{/}} {{cyan}}unit price:{{/}} %d {{cyan}}suggested:{{/}} %d {{cyan}}min:{{/}} %d {{cyan}}change denominator:{{/}} %d {{cyan}}target units:{{/}} %d {{cyan}}max block units:{{/}} %d\n",
+			dimension,
+			summary.UnitPrices[i],
+			summary.SuggestedUnitPrices[i],
+			summary.MinUnitPrices[i],
+			summary.UnitPriceChangeDenominators[i],
+			summary.WindowTargetUnits[i],
+			summary.MaxBlockUnits[i],
+		)
+	}
+	utils.Outf(
+		"{{cyan}}base units:{{/}} %d {{cyan}}warp base units:{{/}} %d {{cyan}}warp units per signer:{{/}} %d\n",
+		summary.BaseUnits,
+		summary.WarpBaseUnits,
+		summary.WarpUnitsPerSigner,
+	)
+	utils.Outf("{{cyan}}actions:{{/}} %s\n", formatTypes(info.Actions))
+	utils.Outf("{{cyan}}auths:{{/}} %s\n", formatTypes(info.Auths))
 	return nil
 }
 
+// formatTypes formats registered types as "<id>:<name>".
+func formatTypes(types []*rpc.TypeInfo) string {
+	formatted := make([]string, len(types))
+	for i, t := range types {
+		formatted[i] = fmt.Sprintf("%d:%s", t.ID, t.Name)
+	}
+	return strings.Join(formatted, ",")
+}
+
+// watchThroughputInterval is how often [WatchChain] prints the throughput
+// reported by the node.
+const watchThroughputInterval = time.Minute
+
+// printThroughput prints the windows returned by [rpc.JSONRPCClient.Throughput].
+func printThroughput(windows []*throughput.Summary) {
+	for _, w := range windows {
+		utils.Outf(
+			"{{cyan}}[%s]{{/}} {{green}}blocks:{{/}}%d {{green}}TPS:{{/}}%.2f {{green}}units/s:{{/}}%.2f {{green}}fullness:{{/}}%.2f%%\n",
+			time.Duration(w.Window)*time.Millisecond,
+			w.Blocks,
+			w.TPS,
+			w.UnitsPerSecond,
+			w.Fullness*100,
+		)
+	}
+}
+
 func (h *Handler) WatchChain(hideTxs bool, getParser func(string, uint32, ids.ID) (chain.Parser, error), handleTx func(*chain.Transaction, *chain.Result)) error {
 	ctx := context.Background()
 	chainID, uris, err := h.PromptChain("select chainID", nil)
@@ -205,47 +284,30 @@ func (h *Handler) WatchChain(hideTxs bool, getParser func(string, uint32, ids.ID
 	if err != nil {
 		return err
 	}
-	scli, err := rpc.NewWebSocketClient(uris[0], rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize) // we write the max read
-	if err != nil {
-		return err
-	}
-	defer scli.Close()
-	if err := scli.RegisterBlocks(); err != nil {
-		return err
-	}
+	scli := rpc.NewStreamClient(uris[0], parser)
 	utils.Outf("{{green}}watching for new blocks on %s 👀{{/}}\n", chainID)
 	var (
-		start             time.Time
 		lastBlock         int64
 		lastBlockDetailed time.Time
-		tpsWindow         = window.Window{}
+		lastThroughput    time.Time
+		tracker           = throughput.New(consts.MillisecondsPerSecond, window.WindowSize)
 	)
-	for ctx.Err() == nil {
-		blk, results, err := scli.ListenBlock(ctx, parser)
-		if err != nil {
-			return err
-		}
+	scli.OnBlock(func(blk *chain.StatefulBlock, results []*chain.Result) {
 		now := time.Now()
-		if start.IsZero() {
-			start = now
+		size := 0
+		for _, tx := range blk.Txs {
+			size += tx.Size()
 		}
+		units := blk.UnitsConsumed[fees.Compute]
+		tracker.Add(now.UnixMilli(), uint64(len(blk.Txs)), units, uint64(size))
 		if lastBlock != 0 {
-			since := now.Unix() - lastBlock
-			newWindow, err := window.Roll(tpsWindow, int(since))
-			if err != nil {
-				return err
-			}
-			tpsWindow = newWindow
-			window.Update(&tpsWindow, window.WindowSliceSize-consts.Uint64Len, uint64(len(blk.Txs)))
-			runningDuration := time.Since(start)
-			tpsDivisor := math.Min(window.WindowSize, runningDuration.Seconds())
 			utils.Outf(
 				"{{green}}height:{{/}}%d {{green}}txs:{{/}}%d {{green}}units:{{/}}%d {{green}}root:{{/}}%s {{green}}TPS:{{/}}%.2f {{green}}split:{{/}}%dms\n",
 				blk.Hght,
 				len(blk.Txs),
-				blk.UnitsConsumed,
+				units,
 				blk.StateRoot,
-				float64(window.Sum(tpsWindow))/tpsDivisor,
+				tracker.Rate(now.UnixMilli(), throughput.Txs),
 				time.Since(lastBlockDetailed).Milliseconds(),
 			)
 		} else {
@@ -253,19 +315,29 @@ func (h *Handler) WatchChain(hideTxs bool, getParser func(string, uint32, ids.ID
 				"{{green}}height:{{/}}%d {{green}}txs:{{/}}%d {{green}}units:{{/}}%d {{green}}root:{{/}}%s\n",
 				blk.Hght,
 				len(blk.Txs),
-				blk.UnitsConsumed,
+				units,
 				blk.StateRoot,
 			)
-			window.Update(&tpsWindow, window.WindowSliceSize-consts.Uint64Len, uint64(len(blk.Txs)))
 		}
 		lastBlock = now.Unix()
 		lastBlockDetailed = now
+		if now.Sub(lastThroughput) >= watchThroughputInterval {
+			// Windows are reported by the node (so they cover blocks accepted
+			// before we started watching)
+			lastThroughput = now
+			if windows, err := rcli.Throughput(ctx); err == nil {
+				printThroughput(windows)
+			}
+		}
 		if hideTxs {
-			continue
+			return
 		}
 		for i, tx := range blk.Txs {
 			handleTx(tx, results[i])
 		}
-	}
-	return nil
+	})
+	scli.OnDisconnect(func(err error) {
+		utils.Outf("{{orange}}lost connection (reconnecting):{{/}} %v\n", err)
+	})
+	return scli.Run(ctx)
 }
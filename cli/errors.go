@@ -6,13 +6,16 @@ package cli
 import "errors"
 
 var (
-	ErrInputEmpty          = errors.New("input is empty")
-	ErrInputTooLarge       = errors.New("input is too large")
-	ErrInvalidChoice       = errors.New("invalid choice")
-	ErrIndexOutOfRange     = errors.New("index out-of-range")
-	ErrInsufficientBalance = errors.New("insufficient balance")
-	ErrDuplicate           = errors.New("duplicate")
-	ErrNoChains            = errors.New("no available chains")
-	ErrNoKeys              = errors.New("no available keys")
-	ErrTxFailed            = errors.New("tx failed")
+	ErrInputEmpty           = errors.New("input is empty")
+	ErrInputTooLarge        = errors.New("input is too large")
+	ErrInvalidChoice        = errors.New("invalid choice")
+	ErrIndexOutOfRange      = errors.New("index out-of-range")
+	ErrInsufficientBalance  = errors.New("insufficient balance")
+	ErrDuplicate            = errors.New("duplicate")
+	ErrNoChains             = errors.New("no available chains")
+	ErrNoKeys               = errors.New("no available keys")
+	ErrTxFailed             = errors.New("tx failed")
+	ErrInvalidManifest      = errors.New("manifest is for a different chain")
+	ErrMissingTestVector    = errors.New("missing test vector")
+	ErrInvalidNetworkConfig = errors.New("invalid network config")
 )
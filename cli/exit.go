@@ -0,0 +1,50 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"errors"
+
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+// Exit codes returned by CLIs built on this package (so that scripts can
+// branch on why a command failed).
+const (
+	ExitSuccess           = 0
+	ExitError             = 1 // any failure not covered below
+	ExitInvalidArgs       = 2
+	ExitConnection        = 3 // node unreachable or serving a different chain
+	ExitTxRejected        = 4 // node refused the request or tx
+	ExitTxFailed          = 5 // tx was included but did not succeed
+	ExitTxExpired         = 6 // tx expired before it was included
+	ExitInsufficientFunds = 7
+)
+
+// ExitCode returns the exit code a CLI should exit with after [err].
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitSuccess
+	case errors.Is(err, ErrInsufficientBalance):
+		return ExitInsufficientFunds
+	case errors.Is(err, ErrTxFailed):
+		return ExitTxFailed
+	case errors.Is(err, ErrInputEmpty),
+		errors.Is(err, ErrInputTooLarge),
+		errors.Is(err, ErrInvalidChoice),
+		errors.Is(err, ErrIndexOutOfRange):
+		return ExitInvalidArgs
+	}
+	switch rpc.Classify(err) {
+	case rpc.ErrorConnection:
+		return ExitConnection
+	case rpc.ErrorRejected:
+		return ExitTxRejected
+	case rpc.ErrorExpired:
+		return ExitTxExpired
+	default:
+		return ExitError
+	}
+}
@@ -7,11 +7,17 @@ import (
 	"context"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/pubsub"
 	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/utils"
 )
 
+// fundBatchSize is the number of transfers [Handler.GenerateKeys] issues
+// before waiting for them to be accepted.
+const fundBatchSize = 512
+
 func (h *Handler) GenerateKey() error {
 	// TODO: encrypt key
 	priv, err := crypto.GeneratePrivateKey()
@@ -123,3 +129,106 @@ func (h *Handler) Balance(checkAllChains bool, promptAsset bool, printBalance fu
 	}
 	return nil
 }
+
+// GenerateKeys creates [count] keys, funds each of them with [amount] from
+// the default key (if [amount] > 0), and writes them to a [KeyManifest] at
+// [manifestPath].
+//
+// Generated keys are not stored in the CLI database.
+func (h *Handler) GenerateKeys(
+	count int, amount uint64, manifestPath string,
+	createClient func(string, uint32, ids.ID), // must save on caller side
+	getFactory func(crypto.PrivateKey) chain.AuthFactory,
+	getParser func(context.Context, ids.ID) (chain.Parser, error),
+	getTransfer func(crypto.PublicKey, uint64) chain.Action,
+) error {
+	ctx := context.Background()
+	if count <= 0 {
+		return ErrInvalidChoice
+	}
+	chainID, uris, err := h.GetDefaultChain()
+	if err != nil {
+		return err
+	}
+	if len(uris) == 0 {
+		return ErrNoChains
+	}
+	manifest := &KeyManifest{ChainID: chainID, Keys: make([]*ManifestKey, count)}
+	keys := make([]crypto.PrivateKey, count)
+	for i := 0; i < count; i++ {
+		priv, err := crypto.GeneratePrivateKey()
+		if err != nil {
+			return err
+		}
+		keys[i] = priv
+		manifest.Keys[i] = &ManifestKey{
+			Address:    h.c.Address(priv.PublicKey()),
+			PrivateKey: priv.ToHex(),
+		}
+	}
+	if amount > 0 {
+		priv, err := h.GetDefaultKey()
+		if err != nil {
+			return err
+		}
+		cli := rpc.NewJSONRPCClient(uris[0])
+		networkID, _, _, err := cli.Network(ctx)
+		if err != nil {
+			return err
+		}
+		createClient(uris[0], networkID, chainID)
+		parser, err := getParser(ctx, chainID)
+		if err != nil {
+			return err
+		}
+		dcli, err := rpc.NewWebSocketClient(uris[0], rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize) // we write the max read
+		if err != nil {
+			return err
+		}
+		defer dcli.Close()
+
+		// Issue transfers in batches (waiting for each batch to be accepted
+		// before issuing the next) to avoid overrunning the mempool
+		factory := getFactory(priv)
+		for start := 0; start < count; start += fundBatchSize {
+			end := start + fundBatchSize
+			if end > count {
+				end = count
+			}
+			for i := start; i < end; i++ {
+				_, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, getTransfer(keys[i].PublicKey(), amount), factory)
+				if err != nil {
+					return err
+				}
+				if err := dcli.RegisterTx(tx); err != nil {
+					return err
+				}
+			}
+			for i := start; i < end; i++ {
+				_, dErr, result, err := dcli.ListenTx(ctx)
+				if err != nil {
+					return err
+				}
+				if dErr != nil {
+					return dErr
+				}
+				if !result.Success {
+					return ErrTxFailed
+				}
+			}
+			utils.Outf("{{yellow}}funded keys:{{/}} %d/%d\n", end, count)
+		}
+		for _, k := range manifest.Keys {
+			k.Balance = amount
+		}
+	}
+	if err := manifest.Save(manifestPath); err != nil {
+		return err
+	}
+	utils.Outf(
+		"{{green}}created %d keys:{{/}} %s\n",
+		count,
+		manifestPath,
+	)
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/crypto"
+)
+
+// KeyManifest lists keys generated (and funded) by [Handler.GenerateKeys] so
+// that they can be reused by [Handler.Spam] without distributing funds again.
+type KeyManifest struct {
+	ChainID ids.ID         `json:"chainID"`
+	Keys    []*ManifestKey `json:"keys"`
+}
+
+type ManifestKey struct {
+	Address    string `json:"address"`
+	PrivateKey string `json:"privateKey"` // hex
+	Balance    uint64 `json:"balance"`    // at the time of generation
+}
+
+func LoadKeyManifest(path string) (*KeyManifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m KeyManifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if len(m.Keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	return &m, nil
+}
+
+func (m *KeyManifest) Save(path string) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, fsModeWrite)
+}
+
+// PrivateKeys returns the keys in [m] and the balance each was funded with.
+func (m *KeyManifest) PrivateKeys() ([]crypto.PrivateKey, []uint64, error) {
+	keys := make([]crypto.PrivateKey, len(m.Keys))
+	balances := make([]uint64, len(m.Keys))
+	for i, k := range m.Keys {
+		priv, err := crypto.HexToKey(k.PrivateKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys[i] = priv
+		balances[i] = k.Balance
+	}
+	return keys, balances, nil
+}
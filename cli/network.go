@@ -0,0 +1,380 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	runner "github.com/ava-labs/avalanche-network-runner/client"
+	"github.com/ava-labs/avalanche-network-runner/rpcpb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+const (
+	DefaultNetworkEndpoint = "0.0.0.0:12352"
+
+	networkStartTimeout = 5 * time.Minute
+	networkDialTimeout  = 10 * time.Second
+
+	// Files written to [NetworkConfig.OutputDir]
+	NetworkBundleFile  = "network.json"
+	networkGenesis     = "genesis.json"
+	networkChainConfig = "config.json"
+	networkSubnet      = "subnet.json"
+	networkKeys        = "keys.json"
+	networkAllocations = "allocations.json"
+
+	fsModeDir    = 0o755
+	fsModePlugin = 0o755
+)
+
+// networkNodeConfig is applied to every node started by [StartNetwork]. It
+// removes most throttling so that local networks can be load tested.
+const networkNodeConfig = `{
+	"log-display-level":"info",
+	"proposervm-use-current-height":true,
+	"throttler-inbound-validator-alloc-size":"10737418240",
+	"throttler-inbound-at-large-alloc-size":"10737418240",
+	"throttler-inbound-node-max-processing-msgs":"100000",
+	"throttler-inbound-bandwidth-refill-rate":"1073741824",
+	"throttler-inbound-bandwidth-max-burst-size":"1073741824",
+	"throttler-inbound-cpu-validator-alloc":"100000",
+	"throttler-inbound-disk-validator-alloc":"10737418240000",
+	"throttler-outbound-validator-alloc-size":"10737418240",
+	"throttler-outbound-at-large-alloc-size":"10737418240",
+	"consensus-on-accept-gossip-validator-size":"10",
+	"consensus-on-accept-gossip-peer-size":"10",
+	"network-compression-type":"none",
+	"consensus-app-concurrency":"512"
+}`
+
+// NetworkConfig describes a local network of any hypersdk VM started by
+// [StartNetwork].
+type NetworkConfig struct {
+	// Endpoint is the gRPC endpoint of a running avalanche-network-runner
+	// server.
+	Endpoint        string
+	AvalancheGoPath string
+	PluginDir       string
+
+	// Plugin is the path of a compiled VM binary. If provided, it is copied to
+	// [PluginDir] under the ID derived from [VMName] (otherwise it must
+	// already be there).
+	Plugin string
+	VMName string
+
+	// Genesis is the path of the VM genesis. If it is empty, [GenesisCLI] is
+	// invoked as "<GenesisCLI> genesis generate <allocations> --genesis-file
+	// <genesis> [GenesisArgs...]" to create one that funds the provisioned
+	// keys.
+	Genesis     string
+	GenesisCLI  string
+	GenesisArgs []string
+
+	ChainConfig  string
+	SubnetConfig string
+
+	// HRP is used to format the addresses of provisioned keys.
+	HRP string
+	// Keys is the number of keys to provision, each of which is allocated
+	// [Allocation] in the generated genesis.
+	Keys       int
+	Allocation uint64
+
+	Subnets    int
+	Validators int
+
+	// OutputDir is where the [NetworkBundle] (and copies of all configuration
+	// used to start the network) is written.
+	OutputDir string
+}
+
+// NetworkChain is a chain created by [StartNetwork].
+type NetworkChain struct {
+	ChainID  string   `json:"chainID"`
+	SubnetID string   `json:"subnetID"`
+	URIs     []string `json:"uris"`
+
+	// DescriptorHash is the [rpc.DescriptorHash] of the chain (which wallets
+	// can pin to detect when they are pointed at an incompatible chain).
+	DescriptorHash string `json:"descriptorHash,omitempty"`
+}
+
+// NetworkBundle is everything needed to interact with a network started by
+// [StartNetwork]. It is written to [NetworkBundleFile] in
+// [NetworkConfig.OutputDir].
+type NetworkBundle struct {
+	Endpoint    string          `json:"endpoint"`
+	VMName      string          `json:"vmName"`
+	VMID        string          `json:"vmID"`
+	RootDataDir string          `json:"rootDataDir"`
+	Chains      []*NetworkChain `json:"chains"`
+
+	Genesis      string `json:"genesis"`
+	ChainConfig  string `json:"chainConfig,omitempty"`
+	SubnetConfig string `json:"subnetConfig,omitempty"`
+	Keys         string `json:"keys,omitempty"`
+}
+
+func newNetworkClient(endpoint string) (runner.Client, error) {
+	return runner.New(runner.Config{
+		Endpoint:    endpoint,
+		DialTimeout: networkDialTimeout,
+	}, logging.NoLog{})
+}
+
+// StartNetwork starts a local network with [cfg.Validators] validators for
+// each of [cfg.Subnets] subnets, each of which runs a single chain of
+// [cfg.VMName].
+func StartNetwork(ctx context.Context, cfg *NetworkConfig) (*NetworkBundle, error) {
+	if cfg.Subnets <= 0 || cfg.Validators <= 0 {
+		return nil, fmt.Errorf("%w: must run at least 1 subnet with 1 validator", ErrInvalidNetworkConfig)
+	}
+	if len(cfg.Genesis) == 0 && len(cfg.GenesisCLI) == 0 {
+		return nil, fmt.Errorf("%w: genesis or genesis CLI must be provided", ErrInvalidNetworkConfig)
+	}
+	if err := os.MkdirAll(cfg.OutputDir, fsModeDir); err != nil {
+		return nil, err
+	}
+	anrCli, err := newNetworkClient(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer anrCli.Close()
+
+	// Install plugin
+	vmID, err := anrCli.VMID(ctx, cfg.VMName)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Plugin) > 0 {
+		if err := copyFile(cfg.Plugin, filepath.Join(cfg.PluginDir, vmID), fsModePlugin); err != nil {
+			return nil, err
+		}
+		utils.Outf("{{yellow}}installed plugin:{{/}} %s {{yellow}}vmID:{{/}} %s\n", cfg.Plugin, vmID)
+	}
+
+	// Prepare bundle
+	bundle := &NetworkBundle{
+		Endpoint: cfg.Endpoint,
+		VMName:   cfg.VMName,
+		VMID:     vmID,
+		Genesis:  filepath.Join(cfg.OutputDir, networkGenesis),
+	}
+	if cfg.Keys > 0 {
+		bundle.Keys = filepath.Join(cfg.OutputDir, networkKeys)
+		if err := provisionKeys(cfg, bundle.Keys); err != nil {
+			return nil, err
+		}
+		utils.Outf("{{yellow}}provisioned keys:{{/}} %d {{yellow}}manifest:{{/}} %s\n", cfg.Keys, bundle.Keys)
+	}
+	if len(cfg.Genesis) > 0 {
+		err = copyFile(cfg.Genesis, bundle.Genesis, fsModeWrite)
+	} else {
+		err = generateGenesis(cfg, bundle.Genesis)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.ChainConfig) > 0 {
+		bundle.ChainConfig = filepath.Join(cfg.OutputDir, networkChainConfig)
+		if err := copyFile(cfg.ChainConfig, bundle.ChainConfig, fsModeWrite); err != nil {
+			return nil, err
+		}
+	}
+	if len(cfg.SubnetConfig) > 0 {
+		bundle.SubnetConfig = filepath.Join(cfg.OutputDir, networkSubnet)
+		if err := copyFile(cfg.SubnetConfig, bundle.SubnetConfig, fsModeWrite); err != nil {
+			return nil, err
+		}
+	}
+
+	// Start network
+	sctx, cancel := context.WithTimeout(ctx, networkStartTimeout)
+	resp, err := anrCli.Start(
+		sctx,
+		cfg.AvalancheGoPath,
+		runner.WithPluginDir(cfg.PluginDir),
+		runner.WithGlobalNodeConfig(networkNodeConfig),
+	)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	bundle.RootDataDir = resp.ClusterInfo.RootDataDir
+	utils.Outf("{{green}}started network:{{/}} %s\n", bundle.RootDataDir)
+
+	// Create chains (each new validator has a registered BLS key)
+	specs := make([]*rpcpb.BlockchainSpec, cfg.Subnets)
+	participants := make([][]string, cfg.Subnets)
+	for i := range specs {
+		for j := 1; j <= cfg.Validators; j++ {
+			participants[i] = append(participants[i], fmt.Sprintf("node%d-bls", i*cfg.Validators+j))
+		}
+		specs[i] = &rpcpb.BlockchainSpec{
+			VmName:      cfg.VMName,
+			Genesis:     bundle.Genesis,
+			ChainConfig: bundle.ChainConfig,
+			SubnetSpec: &rpcpb.SubnetSpec{
+				SubnetConfig: bundle.SubnetConfig,
+				Participants: participants[i],
+			},
+		}
+	}
+	cctx, cancel := context.WithTimeout(ctx, networkStartTimeout)
+	cresp, err := anrCli.CreateBlockchains(cctx, specs)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	status, err := anrCli.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodeInfos := status.ClusterInfo.NodeInfos
+	for i, chainID := range cresp.ChainIds {
+		chain := &NetworkChain{
+			ChainID:  chainID,
+			SubnetID: cresp.ClusterInfo.CustomChains[chainID].SubnetId,
+		}
+		for _, name := range participants[i] {
+			chain.URIs = append(chain.URIs, fmt.Sprintf("%s/ext/bc/%s", nodeInfos[name].Uri, chainID))
+		}
+		if len(chain.URIs) > 0 {
+			info, err := rpc.NewJSONRPCClient(chain.URIs[0]).ChainInfo(ctx)
+			if err != nil {
+				utils.Outf("{{orange}}unable to fetch descriptor hash:{{/}} %s %v\n", chainID, err)
+			} else {
+				chain.DescriptorHash = info.DescriptorHash.String()
+			}
+		}
+		bundle.Chains = append(bundle.Chains, chain)
+		utils.Outf(
+			"{{green}}created chain:{{/}} %s {{green}}subnet:{{/}} %s {{green}}participants:{{/}} %+v\n",
+			chain.ChainID,
+			chain.SubnetID,
+			participants[i],
+		)
+	}
+	return bundle, bundle.Save(filepath.Join(cfg.OutputDir, NetworkBundleFile))
+}
+
+// StopNetwork stops the network managed by the avalanche-network-runner server
+// at [endpoint].
+func StopNetwork(ctx context.Context, endpoint string) error {
+	anrCli, err := newNetworkClient(endpoint)
+	if err != nil {
+		return err
+	}
+	defer anrCli.Close()
+
+	_, err = anrCli.Stop(ctx)
+	return err
+}
+
+func LoadNetworkBundle(path string) (*NetworkBundle, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var bundle NetworkBundle
+	if err := json.Unmarshal(b, &bundle); err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+func (b *NetworkBundle) Save(path string) error {
+	bb, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bb, fsModeWrite)
+}
+
+// provisionKeys generates [cfg.Keys] keys and writes them to a [KeyManifest]
+// at [path] (and their allocations to [networkAllocations], in the format
+// expected by "genesis generate").
+func provisionKeys(cfg *NetworkConfig, path string) error {
+	type allocation struct {
+		Address string `json:"address"`
+		Balance uint64 `json:"balance"`
+	}
+	var (
+		manifest    = &KeyManifest{Keys: make([]*ManifestKey, cfg.Keys)}
+		allocations = make([]*allocation, cfg.Keys)
+	)
+	for i := 0; i < cfg.Keys; i++ {
+		priv, err := crypto.GeneratePrivateKey()
+		if err != nil {
+			return err
+		}
+		addr := crypto.Address(cfg.HRP, priv.PublicKey())
+		manifest.Keys[i] = &ManifestKey{
+			Address:    addr,
+			PrivateKey: priv.ToHex(),
+			Balance:    cfg.Allocation,
+		}
+		allocations[i] = &allocation{Address: addr, Balance: cfg.Allocation}
+	}
+	if err := manifest.Save(path); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(allocations, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(cfg.OutputDir, networkAllocations), b, fsModeWrite)
+}
+
+// generateGenesis invokes [cfg.GenesisCLI] to write a genesis that funds the
+// provisioned keys to [path].
+func generateGenesis(cfg *NetworkConfig, path string) error {
+	if cfg.Keys == 0 {
+		return fmt.Errorf("%w: must provision keys to generate genesis", ErrInvalidNetworkConfig)
+	}
+	args := append([]string{
+		"genesis",
+		"generate",
+		filepath.Join(cfg.OutputDir, networkAllocations),
+		"--genesis-file",
+		path,
+	}, cfg.GenesisArgs...)
+	cmd := exec.Command(cfg.GenesisCLI, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func copyFile(src string, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	// Remove [dst] first in case it is a plugin that is currently running
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
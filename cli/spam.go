@@ -6,11 +6,11 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"math"
 	"math/rand"
 	"os"
 	"os/signal"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
@@ -31,8 +31,18 @@ const (
 	defaultRange = 32
 )
 
+// Spam issues transfers between many accounts to load test a chain.
+//
+// If [manifestPath] is provided, the accounts in the [KeyManifest] are used
+// (and are not returned their funds at the end of the test) instead of
+// distributing funds from the root key to new accounts.
+//
+// If [reportPath] is provided, a [SpamReport] is written to it once all
+// issued transactions are decided. If [pushURL] is provided, live progress is
+// pushed to the prometheus pushgateway at [pushURL] every second.
 func (h *Handler) Spam(
-	maxTxBacklog int, randomRecipient bool,
+	maxTxBacklog int, randomRecipient bool, manifestPath string,
+	reportPath string, pushURL string,
 	createClient func(string, uint32, ids.ID), // must save on caller side
 	getFactory func(crypto.PrivateKey) chain.AuthFactory,
 	lookupBalance func(int, string) (uint64, error),
@@ -86,21 +96,30 @@ func (h *Handler) Spam(
 	}
 
 	// Distribute funds
-	numAccounts, err := h.PromptInt("number of accounts")
-	if err != nil {
-		return err
+	var (
+		numAccounts int
+		manifest    *KeyManifest
+	)
+	if len(manifestPath) > 0 {
+		manifest, err = LoadKeyManifest(manifestPath)
+		if err != nil {
+			return err
+		}
+		if manifest.ChainID != chainID {
+			return ErrInvalidManifest
+		}
+		numAccounts = len(manifest.Keys)
+		utils.Outf("{{yellow}}loaded accounts from manifest:{{/}} %d\n", numAccounts)
+	} else {
+		numAccounts, err = h.PromptInt("number of accounts")
+		if err != nil {
+			return err
+		}
 	}
 	numTxsPerAccount, err := h.PromptInt("number of transactions per account per second")
 	if err != nil {
 		return err
 	}
-	witholding := uint64(feePerTx * numAccounts)
-	distAmount := (balance - witholding) / uint64(numAccounts)
-	utils.Outf(
-		"{{yellow}}distributing funds to each account:{{/}} %s %s\n",
-		h.ValueString(ids.Empty, distAmount),
-		h.AssetString(ids.Empty),
-	)
 	accounts := make([]crypto.PrivateKey, numAccounts)
 	dcli, err := rpc.NewWebSocketClient(uris[0], rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize) // we write the max read
 	if err != nil {
@@ -112,43 +131,61 @@ func (h *Handler) Spam(
 		return err
 	}
 	var fundsL sync.Mutex
-	for i := 0; i < numAccounts; i++ {
-		// Create account
-		pk, err := crypto.GeneratePrivateKey()
+	if manifest != nil {
+		keys, balances, err := manifest.PrivateKeys()
 		if err != nil {
 			return err
 		}
-		accounts[i] = pk
-
-		// Send funds
-		_, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, getTransfer(pk.PublicKey(), distAmount), factory)
-		if err != nil {
-			return err
+		for i, pk := range keys {
+			accounts[i] = pk
+			funds[pk.PublicKey()] = balances[i]
 		}
-		if err := dcli.RegisterTx(tx); err != nil {
-			return err
-		}
-		funds[pk.PublicKey()] = distAmount
+	} else {
+		witholding := uint64(feePerTx * numAccounts)
+		distAmount := (balance - witholding) / uint64(numAccounts)
+		utils.Outf(
+			"{{yellow}}distributing funds to each account:{{/}} %s %s\n",
+			h.ValueString(ids.Empty, distAmount),
+			h.AssetString(ids.Empty),
+		)
+		for i := 0; i < numAccounts; i++ {
+			// Create account
+			pk, err := crypto.GeneratePrivateKey()
+			if err != nil {
+				return err
+			}
+			accounts[i] = pk
 
-		// Ensure Snowman++ is activated
-		if i < 10 {
-			time.Sleep(500 * time.Millisecond)
-		}
-	}
-	for i := 0; i < numAccounts; i++ {
-		_, dErr, result, err := dcli.ListenTx(ctx)
-		if err != nil {
-			return err
-		}
-		if dErr != nil {
-			return dErr
+			// Send funds
+			_, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, getTransfer(pk.PublicKey(), distAmount), factory)
+			if err != nil {
+				return err
+			}
+			if err := dcli.RegisterTx(tx); err != nil {
+				return err
+			}
+			funds[pk.PublicKey()] = distAmount
+
+			// Ensure Snowman++ is activated
+			if i < 10 {
+				time.Sleep(500 * time.Millisecond)
+			}
 		}
-		if !result.Success {
-			// Should never happen
-			return ErrTxFailed
+		for i := 0; i < numAccounts; i++ {
+			_, dErr, result, err := dcli.ListenTx(ctx)
+			if err != nil {
+				return err
+			}
+			if dErr != nil {
+				return dErr
+			}
+			if !result.Success {
+				// Should never happen
+				return ErrTxFailed
+			}
 		}
+		utils.Outf("{{yellow}}distributed funds to %d accounts{{/}}\n", numAccounts)
 	}
-	utils.Outf("{{yellow}}distributed funds to %d accounts{{/}}\n", numAccounts)
 
 	// Kickoff txs
 	clients := make([]*txIssuer, len(uris))
@@ -177,15 +214,17 @@ func (h *Handler) Spam(
 	var inflight atomic.Int64
 	var sent atomic.Int64
 	var exiting sync.Once
+	tracker := newSpamTracker(pushURL)
 	for i := 0; i < len(clients); i++ {
 		issuer := clients[i]
 		wg.Add(1)
 		go func() {
 			for {
-				_, dErr, result, err := issuer.d.ListenTx(context.TODO())
+				txID, dErr, result, err := issuer.d.ListenTx(context.TODO())
 				if err != nil {
 					return
 				}
+				tracker.Decided(txID, dErr, result)
 				inflight.Add(-1)
 				issuer.l.Lock()
 				issuer.outstandingTxs--
@@ -198,8 +237,7 @@ func (h *Handler) Spam(
 						utils.Outf("{{orange}}on-chain tx failure:{{/}} %s %t\n", string(result.Output), result.Success)
 					}
 				} else {
-					// We can't error match here because we receive it over the wire.
-					if !strings.Contains(dErr.Error(), rpc.ErrExpired.Error()) {
+					if !errors.Is(dErr, rpc.ErrExpired) {
 						utils.Outf("{{orange}}pre-execute tx failure:{{/}} %v\n", dErr)
 					}
 				}
@@ -244,6 +282,9 @@ func (h *Handler) Spam(
 				}
 				l.Unlock()
 				psent = current
+				if err := tracker.Tick(cctx, inflight.Load()); err != nil {
+					utils.Outf("{{orange}}failed to push progress:{{/}} %v\n", err)
+				}
 			case <-cctx.Done():
 				return
 			}
@@ -251,7 +292,7 @@ func (h *Handler) Spam(
 	}()
 
 	// broadcast txs
-	unitPrice, err := clients[0].c.SuggestedRawFee(ctx)
+	unitPrices, err := clients[0].c.SuggestedRawFee(ctx)
 	if err != nil {
 		return err
 	}
@@ -291,12 +332,13 @@ func (h *Handler) Spam(
 						}
 						v := selected[recipient] + 1
 						selected[recipient] = v
-						_, tx, fees, err := issuer.c.GenerateTransactionManual(parser, nil, getTransfer(recipient, uint64(v)), factory, unitPrice)
+						_, tx, fees, err := issuer.c.GenerateTransactionManual(parser, nil, getTransfer(recipient, uint64(v)), factory, unitPrices)
 						if err != nil {
 							utils.Outf("{{orange}}failed to generate:{{/}} %v\n", err)
 							continue
 						}
 						transferFee = fees
+						tracker.Issued(tx.ID())
 						if err := issuer.d.RegisterTx(tx); err != nil {
 							continue
 						}
@@ -351,6 +393,26 @@ func (h *Handler) Spam(
 	wg.Wait()
 	cancel()
 
+	// Write report
+	if len(reportPath) > 0 {
+		report := tracker.Report()
+		if err := report.Save(reportPath); err != nil {
+			return err
+		}
+		utils.Outf(
+			"{{yellow}}wrote report:{{/}} %s {{yellow}}accepted:{{/}} %d/%d {{yellow}}p50 latency:{{/}} %.0fms\n",
+			reportPath,
+			report.Accepted,
+			report.Submitted,
+			report.LatencyP50,
+		)
+	}
+
+	// Keep funds in manifest accounts so they can be reused
+	if manifest != nil {
+		return nil
+	}
+
 	// Return funds
 	utils.Outf("{{yellow}}returning funds to %s{{/}}\n", h.c.Address(key.PublicKey()))
 	var (
@@ -0,0 +1,189 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+const pushJob = "hypersdk_spam"
+
+// SpamReport summarizes a [Handler.Spam] run so that runs can be compared
+// across commits.
+type SpamReport struct {
+	Start    time.Time `json:"start"`
+	Duration float64   `json:"duration"` // seconds
+
+	Submitted uint64 `json:"submitted"`
+	Accepted  uint64 `json:"accepted"` // included and successful
+	Expired   uint64 `json:"expired"`
+
+	// Failed counts transactions that were dropped before execution or that
+	// failed during execution (keyed by reason).
+	Failed map[string]uint64 `json:"failed"`
+
+	// Latencies (in ms) from submission to acceptance
+	LatencyP50 float64 `json:"latencyP50"`
+	LatencyP90 float64 `json:"latencyP90"`
+	LatencyP99 float64 `json:"latencyP99"`
+	LatencyMax float64 `json:"latencyMax"`
+
+	// TPS is the number of transactions accepted during each second of the
+	// run.
+	TPS []uint64 `json:"tps"`
+}
+
+// spamTracker records the outcome of every transaction issued by
+// [Handler.Spam] and (optionally) pushes live progress to a prometheus
+// pushgateway.
+type spamTracker struct {
+	l sync.Mutex
+
+	start     time.Time
+	issued    map[ids.ID]time.Time
+	latencies []float64
+
+	submitted     uint64
+	accepted      uint64
+	expired       uint64
+	failed        map[string]uint64
+	tps           []uint64
+	acceptedSince uint64
+
+	pusher *push.Pusher
+	gauges map[string]prometheus.Gauge
+}
+
+// newSpamTracker creates a [spamTracker]. If [pushURL] is provided, progress
+// is pushed to the pushgateway at [pushURL] every time [Tick] is called.
+func newSpamTracker(pushURL string) *spamTracker {
+	t := &spamTracker{
+		start:  time.Now(),
+		issued: map[ids.ID]time.Time{},
+		failed: map[string]uint64{},
+	}
+	if len(pushURL) == 0 {
+		return t
+	}
+	t.pusher = push.New(pushURL, pushJob)
+	t.gauges = map[string]prometheus.Gauge{}
+	for _, name := range []string{"submitted", "accepted", "expired", "failed", "inflight", "tps"} {
+		g := prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "spam",
+			Name:      name,
+		})
+		t.gauges[name] = g
+		t.pusher.Collector(g)
+	}
+	return t
+}
+
+func (t *spamTracker) Issued(txID ids.ID) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	t.submitted++
+	t.issued[txID] = time.Now()
+}
+
+func (t *spamTracker) Decided(txID ids.ID, dErr error, result *chain.Result) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	issued, ok := t.issued[txID]
+	delete(t.issued, txID)
+	switch {
+	case result != nil && result.Success:
+		t.accepted++
+		t.acceptedSince++
+		if ok {
+			t.latencies = append(t.latencies, float64(time.Since(issued).Milliseconds()))
+		}
+	case result != nil:
+		t.failed["execution: "+string(result.Output)]++
+	case errors.Is(dErr, rpc.ErrExpired):
+		t.expired++
+	default:
+		t.failed["pre-execution: "+dErr.Error()]++
+	}
+}
+
+// Tick records the number of transactions accepted since the last call to
+// [Tick] (which should be called every second) and pushes progress (if
+// configured).
+func (t *spamTracker) Tick(ctx context.Context, inflight int64) error {
+	t.l.Lock()
+	tps := t.acceptedSince
+	t.tps = append(t.tps, tps)
+	t.acceptedSince = 0
+	if t.pusher == nil {
+		t.l.Unlock()
+		return nil
+	}
+	var failed uint64
+	for _, count := range t.failed {
+		failed += count
+	}
+	t.gauges["submitted"].Set(float64(t.submitted))
+	t.gauges["accepted"].Set(float64(t.accepted))
+	t.gauges["expired"].Set(float64(t.expired))
+	t.gauges["failed"].Set(float64(failed))
+	t.gauges["inflight"].Set(float64(inflight))
+	t.gauges["tps"].Set(float64(tps))
+	t.l.Unlock()
+	return t.pusher.PushContext(ctx)
+}
+
+func (t *spamTracker) Report() *SpamReport {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	r := &SpamReport{
+		Start:     t.start,
+		Duration:  time.Since(t.start).Seconds(),
+		Submitted: t.submitted,
+		Accepted:  t.accepted,
+		Expired:   t.expired,
+		Failed:    map[string]uint64{},
+		TPS:       append([]uint64{}, t.tps...),
+	}
+	for reason, count := range t.failed {
+		r.Failed[reason] = count
+	}
+	if len(t.latencies) > 0 {
+		latencies := append([]float64{}, t.latencies...)
+		sort.Float64s(latencies)
+		r.LatencyP50 = percentile(latencies, 0.5)
+		r.LatencyP90 = percentile(latencies, 0.9)
+		r.LatencyP99 = percentile(latencies, 0.99)
+		r.LatencyMax = latencies[len(latencies)-1]
+	}
+	return r
+}
+
+// percentile returns the [p]th percentile of [sorted].
+func percentile(sorted []float64, p float64) float64 {
+	return sorted[int(p*float64(len(sorted)-1))]
+}
+
+func (r *SpamReport) Save(path string) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, fsModeWrite)
+}
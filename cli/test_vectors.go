@@ -0,0 +1,167 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+// Test vectors use a fixed [chain.Base] so that they are reproducible.
+const (
+	vectorTimestamp = 1_700_000_000_000
+	vectorUnitPrice = 1
+)
+
+// VectorAction is an example of a registered [chain.Action] (with the warp
+// message it requires, if any) used to generate [TestVector]s.
+type VectorAction struct {
+	Action      chain.Action
+	WarpMessage *warp.Message
+}
+
+// TestVector is the canonical encoding of a signed transaction. External
+// implementations of a VM's transaction format (like SDKs in other languages)
+// can use these to verify that they produce identical bytes.
+type TestVector struct {
+	Action string `json:"action"`
+	Auth   string `json:"auth"`
+
+	// Digest is the message signed by [Auth] (the encoding of the transaction
+	// without its [Auth]).
+	Digest string `json:"digest"` // hex
+	// AuthBytes is the encoding of the [Auth] (without its type ID), which
+	// includes any signatures.
+	AuthBytes string `json:"authBytes"` // hex
+	// Tx is the encoding of the signed transaction.
+	Tx   string `json:"tx"` // hex
+	TxID ids.ID `json:"txId"`
+
+	JSON *chain.TransactionJSON `json:"json"`
+}
+
+// VectorKey returns a deterministic private key that can be used to create the
+// [chain.AuthFactory]s passed to [GenerateTestVectors].
+func VectorKey(i uint8) crypto.PrivateKey {
+	seed := hashing.ComputeHash256([]byte{i})
+	return crypto.PrivateKey(ed25519.NewKeyFromSeed(seed[:crypto.PrivateKeySeedLen]))
+}
+
+// VectorID returns a deterministic ID that can be used to populate the
+// [VectorAction]s passed to [GenerateTestVectors].
+func VectorID(i uint8) ids.ID {
+	return ids.ID(hashing.ComputeHash256Array([]byte{i}))
+}
+
+// GenerateTestVectors signs each of [actions] with each of [factories] on
+// [chainID].
+//
+// An error is returned if any registered action or auth is not covered by
+// [actions] or [factories], so that new types aren't silently left out.
+func GenerateTestVectors(
+	chainID ids.ID,
+	actions []*VectorAction,
+	factories []chain.AuthFactory,
+	actionRegistry chain.ActionRegistry,
+	authRegistry chain.AuthRegistry,
+) ([]*TestVector, error) {
+	var (
+		actionParser = (*codec.TypeParser[chain.Action, *warp.Message, bool])(actionRegistry)
+		authParser   = (*codec.TypeParser[chain.Auth, *warp.Message, bool])(authRegistry)
+
+		vectors = make([]*TestVector, 0, len(actions)*len(factories))
+		covered = map[string]struct{}{}
+	)
+	for _, action := range actions {
+		for _, factory := range factories {
+			base := &chain.Base{
+				Timestamp:     vectorTimestamp,
+				ChainID:       chainID,
+				MaxUnitPrices: fees.Dimensions{vectorUnitPrice, vectorUnitPrice, vectorUnitPrice, vectorUnitPrice, vectorUnitPrice},
+			}
+			tx, err := chain.NewTx(base, action.WarpMessage, action.Action).Sign(factory, actionRegistry, authRegistry)
+			if err != nil {
+				return nil, fmt.Errorf("%w: unable to sign %T", err, action.Action)
+			}
+			vector, err := newTestVector(tx, actionRegistry, authRegistry)
+			if err != nil {
+				return nil, err
+			}
+			covered["action:"+vector.Action] = struct{}{}
+			covered["auth:"+vector.Auth] = struct{}{}
+			vectors = append(vectors, vector)
+		}
+	}
+
+	// Ensure all registered types are covered
+	for i := 0; i < actionParser.Len(); i++ {
+		name, _ := actionParser.Name(uint8(i))
+		if _, ok := covered["action:"+name]; !ok {
+			return nil, fmt.Errorf("%w: action %s", ErrMissingTestVector, name)
+		}
+	}
+	for i := 0; i < authParser.Len(); i++ {
+		name, _ := authParser.Name(uint8(i))
+		if _, ok := covered["auth:"+name]; !ok {
+			return nil, fmt.Errorf("%w: auth %s", ErrMissingTestVector, name)
+		}
+	}
+	return vectors, nil
+}
+
+func newTestVector(
+	tx *chain.Transaction,
+	actionRegistry chain.ActionRegistry,
+	authRegistry chain.AuthRegistry,
+) (*TestVector, error) {
+	txJSON, err := chain.MarshalTxJSON(tx, actionRegistry, authRegistry)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := tx.Digest(actionRegistry)
+	if err != nil {
+		return nil, err
+	}
+	p := codec.NewWriter(tx.Auth.Size(), consts.NetworkSizeLimit)
+	tx.Auth.Marshal(p)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return &TestVector{
+		Action:    txJSON.Action.Type,
+		Auth:      txJSON.Auth.Type,
+		Digest:    hex.EncodeToString(digest),
+		AuthBytes: hex.EncodeToString(p.Bytes()),
+		Tx:        hex.EncodeToString(tx.Bytes()),
+		TxID:      tx.ID(),
+		JSON:      txJSON,
+	}, nil
+}
+
+// SaveTestVectors writes [vectors] to [path] as JSON (or to stdout if [path]
+// is empty).
+func SaveTestVectors(path string, vectors []*TestVector) error {
+	b, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		return err
+	}
+	if len(path) == 0 {
+		_, err := fmt.Println(string(b))
+		return err
+	}
+	return os.WriteFile(path, b, fsModeWrite)
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/spf13/cobra"
+)
+
+var netCmd = &cobra.Command{
+	Use: "net",
+	RunE: func(*cobra.Command, []string) error {
+		return ErrMissingSubcommand
+	},
+}
+
+var startNetCmd = &cobra.Command{
+	Use:   "start [options]",
+	Short: "Starts a local network running any hypersdk VM",
+	RunE: func(*cobra.Command, []string) error {
+		netConfig.Endpoint = netEndpoint
+		netConfig.GenesisArgs = netGenesisArgs
+		bundle, err := cli.StartNetwork(context.Background(), netConfig)
+		if err != nil {
+			return err
+		}
+		printBundle(bundle)
+		return nil
+	},
+}
+
+var stopNetCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stops the local network",
+	RunE: func(*cobra.Command, []string) error {
+		if err := cli.StopNetwork(context.Background(), netEndpoint); err != nil {
+			return err
+		}
+		utils.Outf("{{green}}stopped network{{/}}\n")
+		return nil
+	},
+}
+
+var statusNetCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Prints the chains of the local network",
+	RunE: func(*cobra.Command, []string) error {
+		bundle, err := cli.LoadNetworkBundle(netBundleOutput)
+		if err != nil {
+			return err
+		}
+		printBundle(bundle)
+		return nil
+	},
+}
+
+func printBundle(bundle *cli.NetworkBundle) {
+	utils.Outf("{{yellow}}vm:{{/}} %s (%s)\n", bundle.VMName, bundle.VMID)
+	utils.Outf("{{yellow}}data:{{/}} %s\n", bundle.RootDataDir)
+	utils.Outf("{{yellow}}genesis:{{/}} %s\n", bundle.Genesis)
+	if len(bundle.Keys) > 0 {
+		utils.Outf("{{yellow}}keys:{{/}} %s\n", bundle.Keys)
+	}
+	for _, chain := range bundle.Chains {
+		utils.Outf("{{yellow}}chainID:{{/}} %s {{yellow}}subnetID:{{/}} %s\n", chain.ChainID, chain.SubnetID)
+		if len(chain.DescriptorHash) > 0 {
+			utils.Outf("{{yellow}}descriptor:{{/}} %s\n", chain.DescriptorHash)
+		}
+		for _, uri := range chain.URIs {
+			utils.Outf("  %s\n", uri)
+		}
+	}
+}
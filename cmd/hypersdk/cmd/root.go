@@ -0,0 +1,142 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/ava-labs/hypersdk/cli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	netEndpoint     string
+	netConfig       = &cli.NetworkConfig{}
+	netGenesisArgs  []string
+	netBundleOutput string
+
+	rootCmd = &cobra.Command{
+		Use:        "hypersdk",
+		Short:      "HyperSDK CLI",
+		SuggestFor: []string{"hypersdk", "hyper-sdk"},
+	}
+)
+
+func init() {
+	cobra.EnablePrefixMatching = true
+	rootCmd.AddCommand(
+		netCmd,
+	)
+
+	// net
+	netCmd.PersistentFlags().StringVar(
+		&netEndpoint,
+		"endpoint",
+		cli.DefaultNetworkEndpoint,
+		"gRPC endpoint of the avalanche-network-runner server",
+	)
+	startNetCmd.PersistentFlags().StringVar(
+		&netConfig.AvalancheGoPath,
+		"avalanchego-path",
+		"",
+		"path to the avalanchego binary",
+	)
+	startNetCmd.PersistentFlags().StringVar(
+		&netConfig.PluginDir,
+		"plugin-dir",
+		"",
+		"avalanchego plugin directory",
+	)
+	startNetCmd.PersistentFlags().StringVar(
+		&netConfig.Plugin,
+		"plugin",
+		"",
+		"compiled VM binary to install in the plugin directory",
+	)
+	startNetCmd.PersistentFlags().StringVar(
+		&netConfig.VMName,
+		"vm-name",
+		"",
+		"name of the VM (used to derive its ID)",
+	)
+	startNetCmd.PersistentFlags().StringVar(
+		&netConfig.Genesis,
+		"genesis",
+		"",
+		"VM genesis file (if empty, generated with --genesis-cli)",
+	)
+	startNetCmd.PersistentFlags().StringVar(
+		&netConfig.GenesisCLI,
+		"genesis-cli",
+		"",
+		"VM CLI used to generate a genesis that funds provisioned keys",
+	)
+	startNetCmd.PersistentFlags().StringSliceVar(
+		&netGenesisArgs,
+		"genesis-args",
+		[]string{},
+		"additional args passed to \"genesis generate\"",
+	)
+	startNetCmd.PersistentFlags().StringVar(
+		&netConfig.ChainConfig,
+		"chain-config",
+		"",
+		"VM chain config file",
+	)
+	startNetCmd.PersistentFlags().StringVar(
+		&netConfig.SubnetConfig,
+		"subnet-config",
+		"",
+		"subnet config file",
+	)
+	startNetCmd.PersistentFlags().StringVar(
+		&netConfig.HRP,
+		"hrp",
+		"",
+		"human-readable prefix of provisioned key addresses",
+	)
+	startNetCmd.PersistentFlags().IntVar(
+		&netConfig.Keys,
+		"keys",
+		1,
+		"number of keys to provision",
+	)
+	startNetCmd.PersistentFlags().Uint64Var(
+		&netConfig.Allocation,
+		"allocation",
+		1_000_000_000_000,
+		"genesis balance of each provisioned key",
+	)
+	startNetCmd.PersistentFlags().IntVar(
+		&netConfig.Subnets,
+		"subnets",
+		1,
+		"number of subnets (each running a single chain) to create",
+	)
+	startNetCmd.PersistentFlags().IntVar(
+		&netConfig.Validators,
+		"validators",
+		5,
+		"number of validators of each subnet",
+	)
+	startNetCmd.PersistentFlags().StringVar(
+		&netConfig.OutputDir,
+		"output",
+		"/tmp/hypersdk-net",
+		"directory to write the network bundle to",
+	)
+	statusNetCmd.PersistentFlags().StringVar(
+		&netBundleOutput,
+		"bundle",
+		"/tmp/hypersdk-net/"+cli.NetworkBundleFile,
+		"network bundle written by \"net start\"",
+	)
+	netCmd.AddCommand(
+		startNetCmd,
+		stopNetCmd,
+		statusNetCmd,
+	)
+}
+
+func Execute() error {
+	return rootCmd.Execute()
+}
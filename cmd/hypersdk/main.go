@@ -0,0 +1,20 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// "hypersdk" implements tooling shared by all hypersdk VMs.
+package main
+
+import (
+	"os"
+
+	"github.com/ava-labs/hypersdk/cmd/hypersdk/cmd"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+func main() {
+	if err := cmd.Execute(); err != nil {
+		utils.Outf("{{red}}hypersdk exited with error:{{/}} %+v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
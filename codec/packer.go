@@ -11,6 +11,7 @@ import (
 
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/window"
 )
 
@@ -164,6 +165,18 @@ func (p *Packer) UnpackWindow(w *window.Window) {
 	copy((*w)[:], p.p.UnpackFixedBytes(window.WindowSliceSize))
 }
 
+func (p *Packer) PackDimensions(d fees.Dimensions) {
+	for _, v := range d {
+		p.p.PackLong(v)
+	}
+}
+
+func (p *Packer) UnpackDimensions(d *fees.Dimensions) {
+	for i := range *d {
+		(*d)[i] = p.p.UnpackLong()
+	}
+}
+
 func (p *Packer) PackString(s string) {
 	p.p.PackStr(s)
 }
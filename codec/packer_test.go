@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/window"
 	"github.com/stretchr/testify/require"
 )
@@ -153,6 +154,23 @@ func TestPackerWindow(t *testing.T) {
 	})
 }
 
+func TestPackerDimensions(t *testing.T) {
+	require := require.New(t)
+	wp := NewWriter(fees.DimensionsLen, fees.DimensionsLen)
+	d := fees.Dimensions{1, 2, 3, 4, 5}
+	wp.PackDimensions(d)
+	require.Len(wp.Bytes(), fees.DimensionsLen)
+	require.NoError(wp.Err())
+
+	rp := NewReader(wp.Bytes(), fees.DimensionsLen)
+	var unpacked fees.Dimensions
+	rp.UnpackDimensions(&unpacked)
+	require.Equal(d, unpacked)
+	require.NoError(rp.Err())
+	rp.UnpackDimensions(&unpacked)
+	require.Error(rp.Err())
+}
+
 func TestNewReader(t *testing.T) {
 	require := require.New(t)
 	vInt := 900
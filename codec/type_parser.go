@@ -5,6 +5,7 @@ package codec
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/ava-labs/hypersdk/consts"
 )
@@ -12,11 +13,15 @@ import (
 type decoder[T any, X any, Y any] struct {
 	f func(*Packer, X) (T, error)
 	y Y
+
+	name string
+	t    reflect.Type
 }
 
 // The number of types is limited to 255.
 type TypeParser[T any, X any, Y any] struct {
 	typeToIndex    map[string]uint8
+	nameToIndex    map[string]uint8
 	indexToDecoder map[uint8]*decoder[T, X, Y]
 
 	index uint8
@@ -26,13 +31,15 @@ type TypeParser[T any, X any, Y any] struct {
 func NewTypeParser[T any, X any, Y bool]() *TypeParser[T, X, Y] {
 	return &TypeParser[T, X, Y]{
 		typeToIndex:    map[string]uint8{},
+		nameToIndex:    map[string]uint8{},
 		indexToDecoder: map[uint8]*decoder[T, X, Y]{},
 	}
 }
 
 // Register registers a new type into TypeParser [p]. Registers the type by using
 // the string representation of [o], and sets the decoder of that index to [f].
-// Returns an error if [o] has already been registered or the TypeParser is full.
+// Returns an error if [o] (or another type with the same name) has already been
+// registered or the TypeParser is full.
 func (p *TypeParser[T, X, Y]) Register(o T, f func(*Packer, X) (T, error), y Y) error {
 	if p.index == consts.MaxUint8 {
 		return ErrTooManyItems
@@ -41,8 +48,17 @@ func (p *TypeParser[T, X, Y]) Register(o T, f func(*Packer, X) (T, error), y Y)
 	if _, ok := p.typeToIndex[k]; ok {
 		return ErrDuplicateItem
 	}
+	t := reflect.TypeOf(o)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	name := t.Name()
+	if _, ok := p.nameToIndex[name]; ok {
+		return ErrDuplicateItem
+	}
 	p.typeToIndex[k] = p.index
-	p.indexToDecoder[p.index] = &decoder[T, X, Y]{f, y}
+	p.nameToIndex[name] = p.index
+	p.indexToDecoder[p.index] = &decoder[T, X, Y]{f, y, name, t}
 	p.index++
 	return nil
 }
@@ -67,3 +83,36 @@ func (p *TypeParser[T, X, Y]) LookupIndex(index uint8) (func(*Packer, X) (T, err
 	}
 	return nil, *new(Y), false
 }
+
+// LookupName returns the index of the type registered with [name] (the name
+// of the type without its package or pointer qualifiers, like "Transfer").
+func (p *TypeParser[T, X, Y]) LookupName(name string) (uint8, bool) {
+	index, ok := p.nameToIndex[name]
+	return index, ok
+}
+
+// Name returns the name of the type registered at [index].
+func (p *TypeParser[T, X, Y]) Name(index uint8) (string, bool) {
+	d, ok := p.indexToDecoder[index]
+	if !ok {
+		return "", false
+	}
+	return d.name, true
+}
+
+// New returns a new, zero-valued instance of the type registered at [index].
+// This can be used to populate a type without its decoder (like when
+// unmarshaling JSON).
+func (p *TypeParser[T, X, Y]) New(index uint8) (T, bool) {
+	d, ok := p.indexToDecoder[index]
+	if !ok {
+		return *new(T), false
+	}
+	o, ok := reflect.New(d.t).Interface().(T)
+	return o, ok
+}
+
+// Len returns the number of registered types.
+func (p *TypeParser[T, X, Y]) Len() int {
+	return int(p.index)
+}
@@ -92,6 +92,30 @@ func TestTypeParser(t *testing.T) {
 		require.ErrorContains(err, "blah2")
 	})
 
+	t.Run("introspection", func(t *testing.T) {
+		require := require.New(t)
+		require.Equal(2, tp.Len())
+
+		index, ok := tp.LookupName("Blah2")
+		require.True(ok)
+		require.Equal(uint8(1), index)
+		_, ok = tp.LookupName("Blah3")
+		require.False(ok)
+
+		name, ok := tp.Name(0)
+		require.True(ok)
+		require.Equal("Blah1", name)
+		_, ok = tp.Name(2)
+		require.False(ok)
+
+		o, ok := tp.New(1)
+		require.True(ok)
+		require.IsType(&Blah2{}, o)
+		require.Equal("blah2", o.Bark())
+		_, ok = tp.New(2)
+		require.False(ok)
+	})
+
 	t.Run("duplicate item", func(t *testing.T) {
 		require := require.New(t)
 		require.ErrorIs(tp.Register(&Blah1{}, nil, true), ErrDuplicateItem)
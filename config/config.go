@@ -10,6 +10,9 @@ import (
 
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/profiler"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/hypersdk/archive"
+	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/trace"
 	"github.com/ava-labs/hypersdk/vm"
 )
@@ -29,12 +32,15 @@ func (c *Config) GetParallelism() int {
 	return 1
 }
 func (c *Config) GetMempoolSize() int                    { return 2_048 }
+func (c *Config) GetMempoolMaxBytes() int                { return 64 * units.MiB }
 func (c *Config) GetMempoolPayerSize() int               { return 32 }
 func (c *Config) GetMempoolExemptPayers() [][]byte       { return nil }
+func (c *Config) GetMempoolJournal() bool                { return false }
 func (c *Config) GetStreamingBacklogSize() int           { return 1024 }
 func (c *Config) GetStateHistoryLength() int             { return 256 }
 func (c *Config) GetStateCacheSize() int                 { return 65_536 } // nodes
 func (c *Config) GetAcceptorSize() int                   { return 1024 }
+func (c *Config) GetAcceptorWorkers() int                { return 2 }
 func (c *Config) GetTraceConfig() *trace.Config          { return &trace.Config{Enabled: false} }
 func (c *Config) GetStateSyncParallelism() int           { return 4 }
 func (c *Config) GetStateSyncMinBlocks() uint64          { return 256 }
@@ -46,3 +52,39 @@ func (c *Config) GetContinuousProfilerConfig() *profiler.Config {
 	return &profiler.Config{Enabled: false}
 }
 func (c *Config) GetVerifySignatures() bool { return true }
+
+func (c *Config) GetMinParallelism() int                   { return 1 }
+func (c *Config) GetParallelismIdleTimeout() time.Duration { return 10 * time.Second }
+
+func (c *Config) GetBuildPrefetchConcurrency() int { return 4 }
+func (c *Config) GetBuildPrefetchBatchSize() int   { return 16 }
+
+func (c *Config) GetExecutionWorkers() int        { return 4 }
+func (c *Config) GetExecutionMaxParallelism() int { return 1_024 }
+
+func (c *Config) GetMempoolSweepInterval() time.Duration  { return 5 * time.Second }
+func (c *Config) GetMempoolSweepBatchSize() int           { return 256 }
+func (c *Config) GetMempoolExpiryInterval() time.Duration { return 0 } // disabled
+
+func (c *Config) GetAcceptedBlockWindow() uint64 { return 0 } // retain all blocks
+func (c *Config) GetConsumerRetention() uint64   { return 86_400 }
+
+func (c *Config) GetArchiveConfig() *archive.Config { return &archive.Config{Enabled: false} }
+
+func (c *Config) GetRPCListeners() []*rpc.ListenerConfig { return nil } // only the node's HTTP server
+
+func (c *Config) GetStreamingMaxConnections() int                { return 4_096 }
+func (c *Config) GetStreamingMaxConnectionsPerIP() int           { return 0 }
+func (c *Config) GetStreamingMaxSubscriptionsPerConnection() int { return 16_384 }
+func (c *Config) GetStreamingMaxSubscriptions() int              { return 262_144 }
+func (c *Config) GetStateDiffs() bool                            { return false }
+
+func (c *Config) GetExecutionProfileBlocks() int { return 0 } // disabled
+
+func (c *Config) GetOverloadProcessingBlocks() int    { return 32 }
+func (c *Config) GetOverloadVerificationBacklog() int { return 16 }
+
+func (c *Config) GetWarmUpPeriod() time.Duration { return 0 } // disabled
+
+func (c *Config) GetMetricsPushURL() string             { return "" } // disabled
+func (c *Config) GetMetricsPushInterval() time.Duration { return 15 * time.Second }
@@ -0,0 +1,253 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// maxSuggestionDistance is the maximum edit distance between an unknown field
+// and a known field for the known field to be suggested.
+const maxSuggestionDistance = 3
+
+// Issue is a single problem found while validating a config (or genesis).
+type Issue struct {
+	Path       string `json:"path"` // like "customAllocation[2].address"
+	Problem    string `json:"problem"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Warning    bool   `json:"warning,omitempty"` // does not prevent the config from being used
+}
+
+func (i *Issue) String() string {
+	var sb strings.Builder
+	if len(i.Path) > 0 {
+		sb.WriteString(i.Path)
+		sb.WriteString(": ")
+	}
+	sb.WriteString(i.Problem)
+	if len(i.Suggestion) > 0 {
+		sb.WriteString(" (suggested: ")
+		sb.WriteString(i.Suggestion)
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+// Issues collects all problems found while validating a config, so they can be
+// reported at once (instead of failing on the first one).
+type Issues []*Issue
+
+// Add records a problem that prevents the config from being used.
+func (is *Issues) Add(path string, problem string, suggestion string) {
+	*is = append(*is, &Issue{Path: path, Problem: problem, Suggestion: suggestion})
+}
+
+// Warn records a problem that does not prevent the config from being used.
+func (is *Issues) Warn(path string, problem string, suggestion string) {
+	*is = append(*is, &Issue{Path: path, Problem: problem, Suggestion: suggestion, Warning: true})
+}
+
+// Errors returns the number of issues that are not warnings.
+func (is Issues) Errors() int {
+	errs := 0
+	for _, i := range is {
+		if !i.Warning {
+			errs++
+		}
+	}
+	return errs
+}
+
+// Err returns an error describing every issue that is not a warning (or nil if
+// there are none).
+func (is Issues) Err() error {
+	problems := make([]string, 0, len(is))
+	for _, i := range is {
+		if i.Warning {
+			continue
+		}
+		problems = append(problems, i.String())
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidConfig, strings.Join(problems, "; "))
+}
+
+// Decode unmarshals the JSON object [b] into the struct pointed to by [v] one
+// field at a time, so that every malformed field is reported (rather than only
+// the first). Fields that are not provided (or that can't be parsed) retain
+// their current value, which is suggested when a field can't be parsed.
+//
+// Unknown fields are reported as warnings (because they are ignored by
+// [json.Unmarshal]) with the closest known field as a suggestion.
+func Decode(b []byte, v any) Issues {
+	issues := Issues{}
+	if len(b) == 0 {
+		return issues
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		issues.Add("", fmt.Sprintf("not a JSON object: %v", err), "")
+		return issues
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		issues.Add("", fmt.Sprintf("cannot decode into %T", v), "")
+		return issues
+	}
+	fields := map[string]reflect.Value{}
+	collectFields(rv.Elem(), fields)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		field, ok := lookupField(fields, key)
+		if !ok {
+			issues.Warn(key, "unknown field", closest(key, names))
+			continue
+		}
+		// Decode into a copy so that a malformed value doesn't partially
+		// overwrite the default.
+		next := reflect.New(field.Type())
+		next.Elem().Set(field)
+		if err := json.Unmarshal(raw[key], next.Interface()); err != nil {
+			path, problem := describe(key, err)
+			issues.Add(path, problem, suggest(field))
+			continue
+		}
+		field.Set(next.Elem())
+	}
+	return issues
+}
+
+// collectFields adds all settable fields of [v] (including those promoted from
+// embedded structs) to [fields], keyed by their JSON name.
+func collectFields(v reflect.Value, fields map[string]reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+		if f.Anonymous && len(name) == 0 {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				collectFields(fv, fields)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if len(name) == 0 {
+			name = f.Name
+		}
+		fields[name] = fv
+	}
+}
+
+// lookupField finds the field for [key] the same way [json.Unmarshal] does
+// (preferring an exact match but falling back to a case-insensitive one).
+func lookupField(fields map[string]reflect.Value, key string) (reflect.Value, bool) {
+	if f, ok := fields[key]; ok {
+		return f, true
+	}
+	for name, f := range fields {
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func describe(key string, err error) (string, string) {
+	var te *json.UnmarshalTypeError
+	if errors.As(err, &te) {
+		path := key
+		if len(te.Field) > 0 {
+			path = key + "." + te.Field
+		}
+		return path, fmt.Sprintf("expected %s but found %s", te.Type, te.Value)
+	}
+	var se *json.SyntaxError
+	if errors.As(err, &se) {
+		return key, fmt.Sprintf("malformed JSON at offset %d: %v", se.Offset, err)
+	}
+	return key, err.Error()
+}
+
+// suggest returns the JSON encoding of the current value of [field] (or "" if
+// it is empty).
+func suggest(field reflect.Value) string {
+	if field.IsZero() {
+		return ""
+	}
+	b, err := json.Marshal(field.Interface())
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// closest returns the name in [names] nearest to [key] (or "" if none is close
+// enough to be a likely typo).
+func closest(key string, names []string) string {
+	best, bestDistance := "", maxSuggestionDistance+1
+	lower := strings.ToLower(key)
+	for _, name := range names {
+		if d := distance(lower, strings.ToLower(name)); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// distance returns the Levenshtein distance between [a] and [b].
+func distance(a string, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testEmbedded struct {
+	Embedded int `json:"embedded"`
+}
+
+type testConfig struct {
+	*testEmbedded
+
+	Size    int      `json:"size"`
+	Name    string   `json:"name"`
+	Pairs   []string `json:"pairs"`
+	Ignored int      `json:"-"`
+}
+
+func TestDecode(t *testing.T) {
+	require := require.New(t)
+
+	c := &testConfig{testEmbedded: &testEmbedded{}, Size: 10, Name: "default"}
+	issues := Decode([]byte(`{"embedded":2,"size":"big","name":"custom","pairs":[1],"sise":3}`), c)
+	require.Len(issues, 3)
+	require.Equal(2, issues.Errors())
+
+	// Issues are reported in key order
+	require.True(strings.HasPrefix(issues[0].Path, "pairs"))
+	require.Empty(issues[0].Suggestion)
+	require.False(issues[0].Warning)
+	require.Equal("sise", issues[1].Path)
+	require.Equal("size", issues[1].Suggestion)
+	require.True(issues[1].Warning)
+	require.Equal("size", issues[2].Path)
+	require.Equal("10", issues[2].Suggestion)
+	require.False(issues[2].Warning)
+	require.ErrorIs(issues.Err(), ErrInvalidConfig)
+
+	// Valid fields are set and invalid ones retain their default
+	require.Equal(2, c.Embedded)
+	require.Equal(10, c.Size)
+	require.Equal("custom", c.Name)
+	require.Empty(c.Pairs)
+}
+
+func TestDecodeCaseInsensitive(t *testing.T) {
+	require := require.New(t)
+
+	c := &testConfig{}
+	issues := Decode([]byte(`{"SIZE":3,"Ignored":4}`), c)
+	require.Len(issues, 1)
+	require.Equal("Ignored", issues[0].Path)
+	require.True(issues[0].Warning)
+	require.NoError(issues.Err())
+	require.Equal(3, c.Size)
+	require.Zero(c.Ignored)
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	require := require.New(t)
+
+	issues := Decode([]byte(`[1, 2]`), &testConfig{})
+	require.Len(issues, 1)
+	require.Empty(issues[0].Path)
+	require.ErrorIs(issues.Err(), ErrInvalidConfig)
+
+	require.Empty(Decode(nil, &testConfig{}))
+}
@@ -0,0 +1,14 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consts
+
+import "github.com/ava-labs/avalanchego/version"
+
+// Version is the version of the hypersdk. It is reported over RPC alongside
+// the version of the VM built with it.
+var Version = &version.Semantic{
+	Major: 0,
+	Minor: 0,
+	Patch: 1,
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// DerivedAddressLen is the length of a [DerivedAddress]. The first byte is
+// the auth typeID of the key the address was derived from and the rest is a
+// hash of that typeID and key.
+const DerivedAddressLen = 1 + consts.IDLen
+
+type DerivedAddress [DerivedAddressLen]byte
+
+var EmptyDerivedAddress = DerivedAddress{}
+
+// TypeID returns the auth typeID [a] was derived from.
+func (a DerivedAddress) TypeID() uint8 {
+	return a[0]
+}
+
+// AddressRegistry maps auth typeIDs to the keys they use so that chains
+// supporting multiple auth types (e.g. ed25519, secp256k1, and BLS) derive
+// addresses in a consistent way.
+//
+// Because the typeID is both prefixed to and hashed with the key, the same
+// key bytes registered under different auth types never produce the same
+// address.
+type AddressRegistry struct {
+	l     sync.RWMutex
+	types map[uint8]*addressType
+}
+
+type addressType struct {
+	name   string
+	keyLen int
+}
+
+func NewAddressRegistry() *AddressRegistry {
+	return &AddressRegistry{types: map[uint8]*addressType{}}
+}
+
+// Register adds an auth type that uses keys of [keyLen] bytes. [typeID]
+// should match the index of the auth in the chain's [AuthRegistry].
+func (r *AddressRegistry) Register(typeID uint8, name string, keyLen int) error {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	if _, ok := r.types[typeID]; ok {
+		return ErrDuplicateAddressType
+	}
+	r.types[typeID] = &addressType{name, keyLen}
+	return nil
+}
+
+// Name returns the name [typeID] was registered with.
+func (r *AddressRegistry) Name(typeID uint8) (string, bool) {
+	r.l.RLock()
+	defer r.l.RUnlock()
+
+	t, ok := r.types[typeID]
+	if !ok {
+		return "", false
+	}
+	return t.name, true
+}
+
+// Derive returns the address of [key] when used with auth [typeID].
+func (r *AddressRegistry) Derive(typeID uint8, key []byte) (DerivedAddress, error) {
+	r.l.RLock()
+	t, ok := r.types[typeID]
+	r.l.RUnlock()
+	if !ok {
+		return EmptyDerivedAddress, ErrUnknownAddressType
+	}
+	if len(key) != t.keyLen {
+		return EmptyDerivedAddress, ErrInvalidPublicKey
+	}
+	var a DerivedAddress
+	a[0] = typeID
+	copy(a[1:], hashing.ComputeHash256(append([]byte{typeID}, key...)))
+	return a, nil
+}
+
+// FormatDerivedAddress returns a Bech32 address from hrp and a.
+func FormatDerivedAddress(hrp string, a DerivedAddress) string {
+	// TODO: handle error
+	addrString, _ := address.FormatBech32(hrp, a[:])
+	return addrString
+}
+
+// ParseDerivedAddress parses a Bech32 encoded address string and extracts
+// its [DerivedAddress]. Like [ParseAddress], the parsed bytes may be padded.
+func ParseDerivedAddress(hrp, saddr string) (DerivedAddress, error) {
+	phrp, a, err := address.ParseBech32(saddr)
+	if err != nil {
+		return EmptyDerivedAddress, err
+	}
+	if phrp != hrp {
+		return EmptyDerivedAddress, ErrIncorrectHrp
+	}
+	if len(a) < DerivedAddressLen {
+		return EmptyDerivedAddress, ErrInvalidAddress
+	}
+	return DerivedAddress(a[:DerivedAddressLen]), nil
+}
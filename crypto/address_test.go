@@ -0,0 +1,64 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressRegistry(t *testing.T) {
+	require := require.New(t)
+
+	r := NewAddressRegistry()
+	require.NoError(r.Register(0, "ed25519", PublicKeyLen))
+	require.NoError(r.Register(1, "other", PublicKeyLen))
+	require.ErrorIs(r.Register(0, "dup", PublicKeyLen), ErrDuplicateAddressType)
+
+	name, ok := r.Name(1)
+	require.True(ok)
+	require.Equal("other", name)
+	_, ok = r.Name(2)
+	require.False(ok)
+
+	// Derivation is deterministic
+	a0, err := r.Derive(0, TestPublicKey)
+	require.NoError(err)
+	a0again, err := r.Derive(0, TestPublicKey)
+	require.NoError(err)
+	require.Equal(a0, a0again)
+	require.Equal(uint8(0), a0.TypeID())
+
+	// Same key under a different type produces a different address
+	a1, err := r.Derive(1, TestPublicKey)
+	require.NoError(err)
+	require.NotEqual(a0, a1)
+	require.Equal(uint8(1), a1.TypeID())
+
+	_, err = r.Derive(2, TestPublicKey)
+	require.ErrorIs(err, ErrUnknownAddressType)
+	_, err = r.Derive(0, TestPublicKey[1:])
+	require.ErrorIs(err, ErrInvalidPublicKey)
+}
+
+func TestDerivedAddressFormat(t *testing.T) {
+	require := require.New(t)
+
+	r := NewAddressRegistry()
+	require.NoError(r.Register(0, "ed25519", PublicKeyLen))
+	a, err := r.Derive(0, TestPublicKey)
+	require.NoError(err)
+
+	s := FormatDerivedAddress("test", a)
+	parsed, err := ParseDerivedAddress("test", s)
+	require.NoError(err)
+	require.Equal(a, parsed)
+
+	_, err = ParseDerivedAddress("other", s)
+	require.ErrorIs(err, ErrIncorrectHrp)
+
+	_, err = ParseDerivedAddress("test", "test1qqqqqq")
+	require.Error(err)
+}
@@ -10,4 +10,8 @@ var (
 	ErrInvalidPublicKey  = errors.New("invalid public key")
 	ErrIncorrectHrp      = errors.New("incorrect hrp")
 	ErrInvalidSignature  = errors.New("invalid signature")
+
+	ErrInvalidAddress       = errors.New("invalid address")
+	ErrUnknownAddressType   = errors.New("unknown address type")
+	ErrDuplicateAddressType = errors.New("duplicate address type")
 )
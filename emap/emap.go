@@ -8,9 +8,14 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/heap"
 )
 
+// bucketOverhead is the approximate number of bytes used by a [bucket] and
+// its heap entry (excluding the ids it holds).
+const bucketOverhead = 96
+
 type bucket struct {
 	t     int64    // Timestamp
 	items []ids.ID // Array of AvalancheGo ids
@@ -123,3 +128,29 @@ func (e *EMap[T]) Any(items []T) bool {
 	}
 	return false
 }
+
+// Len returns the number of unique ids tracked by e.
+func (e *EMap[T]) Len() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.seen.Len()
+}
+
+// Buckets returns the number of distinct expiry buckets tracked by e.
+func (e *EMap[T]) Buckets() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return len(e.times)
+}
+
+// EstimatedSize returns a rough estimate of the bytes used to track all
+// items in e. Each id is stored twice (once in [seen] and once in its
+// bucket) and each bucket holds its timestamp.
+func (e *EMap[T]) EstimatedSize() uint64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return uint64(e.seen.Len())*2*consts.IDLen + uint64(len(e.times))*bucketOverhead
+}
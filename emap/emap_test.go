@@ -8,7 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/set"
-	"github.com/ava-labs/hypersdk/heap"
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,14 +23,9 @@ func (tx *TestTx) Expiry() int64 { return tx.t }
 func TestEmapNew(t *testing.T) {
 	require := require.New(t)
 	e := NewEMap[*TestTx]()
-	emptyE := &EMap[*TestTx]{
-		seen:  set.Set[ids.ID]{},
-		times: make(map[int64]*bucket),
-		bh:    heap.New[*bucket, int64](0, true),
-	}
-	require.Equal(emptyE.seen, e.seen, "Emap did not return an empty emap struct.")
-	require.Equal(emptyE.times, e.times, "Emap did not return an empty emap struct.")
-	require.Equal(emptyE.bh, e.bh, "Emap did not return an empty emap struct.")
+	require.Equal(set.Set[ids.ID]{}, e.seen, "Emap did not return an empty emap struct.")
+	require.Equal(make(map[int64]*bucket), e.times, "Emap did not return an empty emap struct.")
+	require.Zero(e.bh.Len(), "Emap did not return an empty emap struct.")
 }
 
 func TestEmapAddIDGenesis(t *testing.T) {
@@ -239,7 +234,29 @@ func TestSetMinPopsAll(t *testing.T) {
 	// Check removed_ids = min_ids
 	require.Equal(pushedIds, removedIds, "Not all ids were returned")
 	// Check EMap is empty
-	emptyEmap := NewEMap[*TestTx]()
+	require.Empty(e.seen, "EMap not empty")
+	require.Empty(e.times, "EMap not empty")
+	require.Zero(e.bh.Len(), "EMap not empty")
+	require.Zero(e.Len(), "EMap not empty")
+}
 
-	require.Equal(emptyEmap, e, "EMap not empty")
+func TestEmapStats(t *testing.T) {
+	require := require.New(t)
+	e := NewEMap[*TestTx]()
+	require.Zero(e.Len())
+	require.Zero(e.Buckets())
+	require.Zero(e.EstimatedSize())
+
+	e.Add([]*TestTx{
+		{id: ids.GenerateTestID(), t: 1},
+		{id: ids.GenerateTestID(), t: 1},
+		{id: ids.GenerateTestID(), t: 2},
+	})
+	require.Equal(3, e.Len())
+	require.Equal(2, e.Buckets())
+	require.Equal(uint64(3*2*consts.IDLen+2*bucketOverhead), e.EstimatedSize())
+
+	e.SetMin(2)
+	require.Equal(1, e.Len())
+	require.Equal(1, e.Buckets())
 }
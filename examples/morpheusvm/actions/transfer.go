@@ -17,7 +17,10 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*Transfer)(nil)
+var (
+	_ chain.Action        = (*Transfer)(nil)
+	_ chain.AddressAction = (*Transfer)(nil)
+)
 
 type Transfer struct {
 	// To is the recipient of the [Value].
@@ -34,6 +37,11 @@ func (t *Transfer) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
 	}
 }
 
+// Addresses is used to index the blocks that include this action.
+func (t *Transfer) Addresses() [][]byte {
+	return [][]byte{t.To[:]}
+}
+
 func (t *Transfer) Execute(
 	ctx context.Context,
 	r chain.Rules,
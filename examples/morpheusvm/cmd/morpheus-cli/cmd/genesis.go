@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/genesis"
+	"github.com/ava-labs/hypersdk/fees"
 )
 
 var genesisCmd = &cobra.Command{
@@ -32,13 +33,15 @@ var genGenesisCmd = &cobra.Command{
 	RunE: func(_ *cobra.Command, args []string) error {
 		g := genesis.Default()
 		if minUnitPrice >= 0 {
-			g.MinUnitPrice = uint64(minUnitPrice)
+			for i := range g.MinUnitPrice {
+				g.MinUnitPrice[i] = uint64(minUnitPrice)
+			}
 		}
 		if maxBlockUnits >= 0 {
-			g.MaxBlockUnits = uint64(maxBlockUnits)
+			g.MaxBlockUnits[fees.Compute] = uint64(maxBlockUnits)
 		}
 		if windowTargetUnits >= 0 {
-			g.WindowTargetUnits = uint64(windowTargetUnits)
+			g.WindowTargetUnits[fees.Compute] = uint64(windowTargetUnits)
 		}
 		if minBlockGap >= 0 {
 			g.MinBlockGap = minBlockGap
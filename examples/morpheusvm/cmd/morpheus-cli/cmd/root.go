@@ -31,6 +31,8 @@ var (
 	hideTxs           bool
 	randomRecipient   bool
 	maxTxBacklog      int
+	spamReport        string
+	spamPushURL       string
 	checkAllChains    bool
 	prometheusFile    string
 	prometheusData    string
@@ -84,19 +86,19 @@ func init() {
 		&minUnitPrice,
 		"min-unit-price",
 		-1,
-		"minimum price",
+		"minimum price (of every fee dimension)",
 	)
 	genGenesisCmd.PersistentFlags().Int64Var(
 		&maxBlockUnits,
 		"max-block-units",
 		-1,
-		"max block units",
+		"max block compute units",
 	)
 	genGenesisCmd.PersistentFlags().Int64Var(
 		&windowTargetUnits,
 		"window-target-units",
 		-1,
-		"window target units",
+		"window target compute units",
 	)
 	genGenesisCmd.PersistentFlags().Int64Var(
 		&minBlockGap,
@@ -156,6 +158,18 @@ func init() {
 		72_000,
 		"max tx backlog",
 	)
+	runSpamCmd.PersistentFlags().StringVar(
+		&spamReport,
+		"report",
+		"",
+		"write a JSON report of the run to this file",
+	)
+	runSpamCmd.PersistentFlags().StringVar(
+		&spamPushURL,
+		"push-url",
+		"",
+		"push live progress to the prometheus pushgateway at this URL",
+	)
 	spamCmd.AddCommand(
 		runSpamCmd,
 	)
@@ -28,7 +28,7 @@ var runSpamCmd = &cobra.Command{
 	Use: "run",
 	RunE: func(*cobra.Command, []string) error {
 		var bclient *brpc.JSONRPCClient
-		return handler.Root().Spam(maxTxBacklog, randomRecipient,
+		return handler.Root().Spam(maxTxBacklog, randomRecipient, "", spamReport, spamPushURL,
 			func(uri string, networkID uint32, chainID ids.ID) {
 				bclient = brpc.NewJSONRPCClient(uri, networkID, chainID)
 			},
@@ -32,8 +32,9 @@ type Config struct {
 	*config.Config
 
 	// Tracing
-	TraceEnabled    bool    `json:"traceEnabled"`
-	TraceSampleRate float64 `json:"traceSampleRate"`
+	TraceEnabled     bool               `json:"traceEnabled"`
+	TraceSampleRate  float64            `json:"traceSampleRate"`
+	TraceSampleRates map[string]float64 `json:"traceSampleRates"` // span family => rate
 
 	// Profiling
 	ContinuousProfilerDir string `json:"continuousProfilerDir"` // "*" is replaced with rand int
@@ -99,11 +100,12 @@ func (c *Config) GetMempoolPayerSize() int         { return c.MempoolPayerSize }
 func (c *Config) GetMempoolExemptPayers() [][]byte { return c.parsedExemptPayers }
 func (c *Config) GetTraceConfig() *trace.Config {
 	return &trace.Config{
-		Enabled:         c.TraceEnabled,
-		TraceSampleRate: c.TraceSampleRate,
-		AppName:         consts.Name,
-		Agent:           c.nodeID.String(),
-		Version:         version.Version.String(),
+		Enabled:          c.TraceEnabled,
+		TraceSampleRate:  c.TraceSampleRate,
+		TraceSampleRates: c.TraceSampleRates,
+		AppName:          consts.Name,
+		Agent:            c.nodeID.String(),
+		Version:          version.Version.String(),
 	}
 }
 func (c *Config) GetStateSyncServerDelay() time.Duration { return c.StateSyncServerDelay }
@@ -174,8 +174,11 @@ func (*Controller) Rejected(context.Context, *chain.StatelessBlock) error {
 	return nil
 }
 
-func (*Controller) Shutdown(context.Context) error {
+func (c *Controller) Shutdown(context.Context) error {
 	// Do not close any databases provided during initialization. The VM will
 	// close any databases your provided.
-	return nil
+	//
+	// [metaDB] is only used by the controller, so we must close it here (or it
+	// can't be reopened if the VM is restarted in the same process).
+	return c.metaDB.Close()
 }
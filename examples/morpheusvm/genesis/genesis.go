@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/utils"
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/vm"
 )
 
@@ -33,11 +34,11 @@ type Genesis struct {
 	// Chain Parameters
 	MinBlockGap int64 `json:"minBlockGap"` // ms
 
-	// Chain Fee Parameters
-	MinUnitPrice               uint64 `json:"minUnitPrice"`
-	UnitPriceChangeDenominator uint64 `json:"unitPriceChangeDenominator"`
-	WindowTargetUnits          uint64 `json:"windowTargetUnits"` // 10s
-	MaxBlockUnits              uint64 `json:"maxBlockUnits"`     // must be possible to reach before block too large
+	// Chain Fee Parameters (one entry per [fees.Dimension])
+	MinUnitPrice               fees.Dimensions `json:"minUnitPrice"`
+	UnitPriceChangeDenominator fees.Dimensions `json:"unitPriceChangeDenominator"`
+	WindowTargetUnits          fees.Dimensions `json:"windowTargetUnits"` // 10s
+	MaxBlockUnits              fees.Dimensions `json:"maxBlockUnits"`     // must be possible to reach before block too large
 
 	// Tx Parameters
 	ValidityWindow int64 `json:"validityWindow"` // ms
@@ -59,10 +60,12 @@ func Default() *Genesis {
 		MinBlockGap: 100,
 
 		// Chain Fee Parameters
-		MinUnitPrice:               1,
-		UnitPriceChangeDenominator: 48,
-		WindowTargetUnits:          20_000_000,
-		MaxBlockUnits:              1_800_000, // 1.8 MiB
+		//
+		// Bandwidth is measured in bytes and storage in keys.
+		MinUnitPrice:               fees.Dimensions{1, 1, 1, 1, 1},
+		UnitPriceChangeDenominator: fees.Dimensions{48, 48, 48, 48, 48},
+		WindowTargetUnits:          fees.Dimensions{20_000_000, 20_000_000, 1_000_000, 1_000_000, 1_000_000},
+		MaxBlockUnits:              fees.Dimensions{1_800_000, 1_800_000, 100_000, 100_000, 100_000}, // 1.8 MiB
 
 		// Tx Parameters
 		ValidityWindow: 60 * hconsts.MillisecondsPerSecond, // ms
@@ -81,8 +84,10 @@ func New(b []byte, _ []byte /* upgradeBytes */) (*Genesis, error) {
 			return nil, fmt.Errorf("failed to unmarshal config %s: %w", string(b), err)
 		}
 	}
-	if g.WindowTargetUnits == 0 {
-		return nil, ErrInvalidTarget
+	for _, target := range g.WindowTargetUnits {
+		if target == 0 {
+			return nil, ErrInvalidTarget
+		}
 	}
 	return g, nil
 }
@@ -36,6 +36,7 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/pubsub"
 	"github.com/ava-labs/hypersdk/rpc"
 	hutils "github.com/ava-labs/hypersdk/utils"
@@ -179,7 +180,9 @@ var _ = ginkgo.BeforeSuite(func() {
 
 	gen = genesis.Default()
 	if minPrice >= 0 {
-		gen.MinUnitPrice = uint64(minPrice)
+		for i := range gen.MinUnitPrice {
+			gen.MinUnitPrice[i] = uint64(minPrice)
+		}
 	}
 	gen.MinBlockGap = 0
 	gen.CustomAllocation = []*genesis.CustomAllocation{
@@ -360,9 +363,9 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			actionRegistry, authRegistry := instances[0].vm.Registry()
 			tx := chain.NewTx(
 				&chain.Base{
-					ChainID:   instances[0].chainID,
-					Timestamp: 0,
-					UnitPrice: 1000,
+					ChainID:       instances[0].chainID,
+					Timestamp:     0,
+					MaxUnitPrices: fees.Dimensions{1000, 1000, 1000, 1000, 1000},
 				},
 				nil,
 				&actions.Transfer{
@@ -422,13 +425,21 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			gomega.Ω(results).Should(gomega.HaveLen(1))
 			gomega.Ω(results[0].Success).Should(gomega.BeTrue())
 			gomega.Ω(results[0].Units).Should(gomega.Equal(uint64(transferTxFee)))
+			gomega.Ω(results[0].Consumed).Should(gomega.Equal(fees.Dimensions{
+				uint64(transferTxRoot.Size()),
+				transferTxFee,
+				4, // declared keys
+				1, // recipient balance
+				1, // sender balance
+			}))
 			gomega.Ω(results[0].Output).Should(gomega.BeNil())
 		})
 
 		ginkgo.By("ensure balance is updated", func() {
 			balance, err := instances[1].lcli.Balance(context.Background(), sender)
 			gomega.Ω(err).To(gomega.BeNil())
-			gomega.Ω(balance).To(gomega.Equal(uint64(9899560)))
+			// All unit prices are 1
+			gomega.Ω(balance).To(gomega.Equal(uint64(9899554) - uint64(transferTxRoot.Size())))
 			balance2, err := instances[1].lcli.Balance(context.Background(), sender2)
 			gomega.Ω(err).To(gomega.BeNil())
 			gomega.Ω(balance2).To(gomega.Equal(uint64(100000)))
@@ -643,9 +654,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		g, err := instances[0].lcli.Genesis(context.TODO())
 		gomega.Ω(err).Should(gomega.BeNil())
 		r := g.Rules(time.Now().UnixMilli(), networkID, instances[0].chainID)
-		maxUnits, err := rawTx.MaxUnits(r)
+		maxFee, err := rawTx.MaxFee(r, rawTx.Base.MaxUnitPrices)
+		gomega.Ω(err).Should(gomega.BeNil())
+		fee, err := fees.MulSum(results[0].Consumed, blk.UnitPrices)
 		gomega.Ω(err).Should(gomega.BeNil())
-		gomega.Ω(balance).Should(gomega.Equal(balancea + maxUnits + 1))
+		gomega.Ω(results[0].Fee).Should(gomega.Equal(fee))
+		gomega.Ω(fee).Should(gomega.BeNumerically("<=", maxFee))
+		gomega.Ω(balance).Should(gomega.Equal(balancea + fee + 1))
 
 		// Close connection when done
 		gomega.Ω(cli.Close()).Should(gomega.BeNil())
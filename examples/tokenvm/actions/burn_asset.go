@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/utils"
@@ -68,7 +69,11 @@ func (b *BurnAsset) Execute(
 	if err := storage.SetAsset(ctx, db, b.Asset, metadata, newSupply, owner, warp); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
-	return &chain.Result{Success: true, Units: unitsUsed}, nil
+	events, err := balanceEvents(BurnTopic, actor, crypto.EmptyPublicKey, b.Asset, b.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &chain.Result{Success: true, Units: unitsUsed, Events: events}, nil
 }
 
 func (*BurnAsset) MaxUnits(chain.Rules) uint64 {
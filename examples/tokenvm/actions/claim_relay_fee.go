@@ -0,0 +1,114 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var (
+	_ chain.Action        = (*ClaimRelayFee)(nil)
+	_ chain.AddressAction = (*ClaimRelayFee)(nil)
+)
+
+// ClaimRelayFee pays the relay fee escrowed by an [ExportAsset] to the
+// relayer that delivered it (as attested by a [RelayReceipt] from the
+// destination). Anyone can submit the claim.
+type ClaimRelayFee struct {
+	// relayReceipt is parsed from the inner *warp.Message
+	relayReceipt *RelayReceipt
+
+	// warpMessage is the full *warp.Message parsed from [chain.Transaction]
+	warpMessage *warp.Message
+}
+
+func (c *ClaimRelayFee) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixRelayEscrowKey(c.relayReceipt.TxID),
+		// Relay fees are always paid with the native asset
+		storage.PrefixBalanceKey(c.relayReceipt.Relayer, ids.Empty),
+	}
+}
+
+// Addresses is used to index the blocks that include this action.
+func (c *ClaimRelayFee) Addresses() [][]byte {
+	return [][]byte{c.relayReceipt.Relayer[:]}
+}
+
+func (c *ClaimRelayFee) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	_ chain.Auth,
+	_ ids.ID,
+	warpVerified bool,
+) (*chain.Result, error) {
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if !warpVerified {
+		return &chain.Result{
+			Success: false,
+			Units:   unitsUsed,
+			Output:  OutputWarpVerificationFailed,
+		}, nil
+	}
+	if c.relayReceipt.DestinationChainID != r.ChainID() {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInvalidDestination}, nil
+	}
+	exists, destination, fee, err := storage.GetRelayEscrow(ctx, db, c.relayReceipt.TxID)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputEscrowMissing}, nil
+	}
+	if destination != c.warpMessage.SourceChainID {
+		// Only the chain the transfer was sent to can attest to its delivery
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongSource}, nil
+	}
+	if err := storage.DeleteRelayEscrow(ctx, db, c.relayReceipt.TxID); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, c.relayReceipt.Relayer, ids.Empty, fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (c *ClaimRelayFee) MaxUnits(chain.Rules) uint64 {
+	return uint64(len(c.warpMessage.Payload))
+}
+
+func (*ClaimRelayFee) Size() int {
+	return 0
+}
+
+// Everything we need is in the warp message.
+func (*ClaimRelayFee) Marshal(*codec.Packer) {}
+
+func UnmarshalClaimRelayFee(_ *codec.Packer, wm *warp.Message) (chain.Action, error) {
+	var (
+		claim ClaimRelayFee
+		err   error
+	)
+	claim.warpMessage = wm
+	claim.relayReceipt, err = UnmarshalRelayReceipt(wm.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
+
+func (*ClaimRelayFee) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,202 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+func TestExportAssetRelayFee(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx         = context.TODO()
+		db          = testDB{}
+		r           = &testRules{chainID: ids.GenerateTestID()}
+		actor       = newTestActor(t)
+		pk          = auth.GetActor(actor)
+		destination = ids.GenerateTestID()
+	)
+	asset := newTestAsset(t, db, pk, 100)
+	require.NoError(storage.SetBalance(ctx, db, pk, ids.Empty, 50))
+
+	// The relay fee is escrowed (in the native asset) for the destination
+	txID := ids.GenerateTestID()
+	export := &ExportAsset{To: pk, Asset: asset, Value: 60, RelayFee: 20, Destination: destination}
+	result, err := export.Execute(ctx, r, db, 0, actor, txID, false)
+	require.NoError(err)
+	require.True(result.Success, string(result.Output))
+	requireBalance(t, db, pk, asset, 40)
+	requireBalance(t, db, pk, ids.Empty, 30)
+	exists, escrowDestination, fee, err := storage.GetRelayEscrow(ctx, db, txID)
+	require.NoError(err)
+	require.True(exists)
+	require.Equal(destination, escrowDestination)
+	require.Equal(uint64(20), fee)
+	wt, err := UnmarshalWarpTransfer(result.WarpMessage.Payload)
+	require.NoError(err)
+	require.Equal(uint64(20), wt.RelayFee)
+	require.Equal(txID, wt.TxID)
+
+	// Exports without a relay fee don't escrow anything
+	txID = ids.GenerateTestID()
+	export = &ExportAsset{To: pk, Asset: asset, Value: 10, Destination: destination}
+	result, err = export.Execute(ctx, r, db, 0, actor, txID, false)
+	require.NoError(err)
+	require.True(result.Success, string(result.Output))
+	requireBalance(t, db, pk, ids.Empty, 30)
+	exists, _, _, err = storage.GetRelayEscrow(ctx, db, txID)
+	require.NoError(err)
+	require.False(exists)
+
+	// The relay fee must be covered by the native balance of the actor
+	txID = ids.GenerateTestID()
+	export = &ExportAsset{To: pk, Asset: asset, Value: 10, RelayFee: 31, Destination: destination}
+	result, err = export.Execute(ctx, r, db, 0, actor, txID, false)
+	require.NoError(err)
+	require.False(result.Success)
+	requireBalance(t, db, pk, ids.Empty, 30)
+	exists, _, _, err = storage.GetRelayEscrow(ctx, db, txID)
+	require.NoError(err)
+	require.False(exists)
+}
+
+func TestImportAssetRelayReceipt(t *testing.T) {
+	var (
+		ctx         = context.TODO()
+		source      = ids.GenerateTestID()
+		destination = ids.GenerateTestID()
+		r           = &testRules{chainID: destination}
+		to          = auth.GetActor(newTestActor(t))
+		relayer     = newTestActor(t)
+	)
+	for name, relayFee := range map[string]uint64{
+		"without relay fee": 0,
+		"with relay fee":    20,
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			db := testDB{}
+
+			wt := &WarpTransfer{
+				To:                 to,
+				Asset:              ids.GenerateTestID(),
+				Value:              10,
+				RelayFee:           relayFee,
+				TxID:               ids.GenerateTestID(),
+				DestinationChainID: destination,
+			}
+			imp := newTestImport(t, source, wt, false)
+			result, err := imp.Execute(ctx, r, db, 0, relayer, ids.GenerateTestID(), true)
+			require.NoError(err)
+			require.True(result.Success, string(result.Output))
+			requireBalance(t, db, to, ImportedAssetID(wt.Asset, source), 10)
+
+			// Deliveries are only attested to if a relay fee was escrowed
+			if relayFee == 0 {
+				require.Nil(result.WarpMessage)
+				return
+			}
+			receipt, err := UnmarshalRelayReceipt(result.WarpMessage.Payload)
+			require.NoError(err)
+			require.Equal(&RelayReceipt{
+				TxID:               wt.TxID,
+				Relayer:            auth.GetActor(relayer),
+				DestinationChainID: source,
+			}, receipt)
+		})
+	}
+}
+
+func TestClaimRelayFee(t *testing.T) {
+	var (
+		ctx         = context.TODO()
+		chainID     = ids.GenerateTestID()
+		destination = ids.GenerateTestID()
+		r           = &testRules{chainID: chainID}
+	)
+	for name, tt := range map[string]struct {
+		unverified  bool
+		noEscrow    bool
+		receiptDest ids.ID
+		source      ids.ID
+		output      []byte
+	}{
+		"success": {
+			receiptDest: chainID,
+			source:      destination,
+		},
+		"warp verification failed": {
+			unverified:  true,
+			receiptDest: chainID,
+			source:      destination,
+			output:      OutputWarpVerificationFailed,
+		},
+		"receipt for another chain": {
+			receiptDest: ids.GenerateTestID(),
+			source:      destination,
+			output:      OutputInvalidDestination,
+		},
+		"escrow missing": {
+			noEscrow:    true,
+			receiptDest: chainID,
+			source:      destination,
+			output:      OutputEscrowMissing,
+		},
+		"receipt from wrong source": {
+			receiptDest: chainID,
+			source:      ids.GenerateTestID(),
+			output:      OutputWrongSource,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				db      = testDB{}
+				txID    = ids.GenerateTestID()
+				relayer = auth.GetActor(newTestActor(t))
+			)
+			if !tt.noEscrow {
+				require.NoError(storage.SetRelayEscrow(ctx, db, txID, destination, 20))
+			}
+			receipt := &RelayReceipt{TxID: txID, Relayer: relayer, DestinationChainID: tt.receiptDest}
+			payload, err := receipt.Marshal()
+			require.NoError(err)
+			action, err := UnmarshalClaimRelayFee(nil, newTestWarpMessage(tt.source, payload))
+			require.NoError(err)
+
+			// Anyone can submit the claim (the fee is always paid to the relayer)
+			submitter := newTestActor(t)
+			result, err := action.Execute(ctx, r, db, 0, submitter, ids.GenerateTestID(), !tt.unverified)
+			require.NoError(err)
+			require.Equal(tt.output, result.Output)
+			require.Equal(tt.output == nil, result.Success)
+			requireBalance(t, db, auth.GetActor(submitter), ids.Empty, 0)
+			if !result.Success {
+				// Failed claims leave the escrow untouched
+				requireBalance(t, db, relayer, ids.Empty, 0)
+				exists, _, _, err := storage.GetRelayEscrow(ctx, db, txID)
+				require.NoError(err)
+				require.Equal(!tt.noEscrow, exists)
+				return
+			}
+			requireBalance(t, db, relayer, ids.Empty, 20)
+
+			// The fee can only be claimed once
+			result, err = action.Execute(ctx, r, db, 0, submitter, ids.GenerateTestID(), true)
+			require.NoError(err)
+			require.False(result.Success)
+			require.Equal(OutputEscrowMissing, result.Output)
+			requireBalance(t, db, relayer, ids.Empty, 20)
+		})
+	}
+}
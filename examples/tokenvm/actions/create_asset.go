@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/utils"
@@ -21,10 +22,16 @@ type CreateAsset struct {
 	// Metadata is creator-specified information about the asset. This can be
 	// modified using the [ModifyAsset] action.
 	Metadata []byte `json:"metadata"`
+
+	// MinTransfer is the smallest amount of the asset that can be transferred
+	// (0 if there is no minimum). Balances left below this amount are swept
+	// to the recipient of a transfer. This can be modified using the
+	// [ModifyAsset] action.
+	MinTransfer uint64 `json:"minTransfer"`
 }
 
 func (*CreateAsset) StateKeys(_ chain.Auth, txID ids.ID) [][]byte {
-	return [][]byte{storage.PrefixAssetKey(txID)}
+	return [][]byte{storage.PrefixAssetKey(txID), storage.PrefixMinTransferKey(txID)}
 }
 
 func (c *CreateAsset) Execute(
@@ -46,26 +53,31 @@ func (c *CreateAsset) Execute(
 	if err := storage.SetAsset(ctx, db, txID, c.Metadata, 0, actor, false); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
+	if err := storage.SetMinTransfer(ctx, db, txID, c.MinTransfer); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
 	return &chain.Result{Success: true, Units: unitsUsed}, nil
 }
 
 func (c *CreateAsset) MaxUnits(chain.Rules) uint64 {
 	// We use size as the price of this transaction but we could just as easily
 	// use any other calculation.
-	return uint64(len(c.Metadata))
+	return uint64(len(c.Metadata)) + consts.Uint64Len
 }
 
 func (c *CreateAsset) Size() int {
-	return codec.BytesLen(c.Metadata)
+	return codec.BytesLen(c.Metadata) + consts.Uint64Len
 }
 
 func (c *CreateAsset) Marshal(p *codec.Packer) {
 	p.PackBytes(c.Metadata)
+	p.PackUint64(c.MinTransfer)
 }
 
 func UnmarshalCreateAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
 	var create CreateAsset
 	p.UnpackBytes(MaxMetadataSize, false, &create.Metadata)
+	create.MinTransfer = p.UnpackUint64(false) // 0 means there is no minimum
 	return &create, p.Err()
 }
 
@@ -5,4 +5,7 @@ package actions
 
 import "errors"
 
-var ErrNoSwapToFill = errors.New("no swap to fill")
+var (
+	ErrNoSwapToFill    = errors.New("no swap to fill")
+	ErrTooManyAccounts = errors.New("too many accounts")
+)
@@ -0,0 +1,64 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+)
+
+// Topics of the events emitted by actions (the payload of each is a
+// [BalanceEvent])
+const (
+	TransferTopic = "transfer"
+	MintTopic     = "mint"
+	BurnTopic     = "burn"
+)
+
+const balanceEventSize = crypto.PublicKeyLen*2 + consts.IDLen + consts.Uint64Len
+
+// BalanceEvent is emitted when [Value] of [Asset] is moved from [From] to [To]
+// ([TransferTopic]), created for [To] ([MintTopic]), or destroyed by [From]
+// ([BurnTopic]).
+type BalanceEvent struct {
+	From  crypto.PublicKey `json:"from"` // empty when minting
+	To    crypto.PublicKey `json:"to"`   // empty when burning
+	Asset ids.ID           `json:"asset"`
+	Value uint64           `json:"value"`
+}
+
+func UnmarshalBalanceEvent(b []byte) (*BalanceEvent, error) {
+	p := codec.NewReader(b, balanceEventSize)
+	var event BalanceEvent
+	p.UnpackPublicKey(false, &event.From)
+	p.UnpackPublicKey(false, &event.To)
+	p.UnpackID(false, &event.Asset) // empty ID is the native asset
+	event.Value = p.UnpackUint64(true)
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &event, p.Err()
+}
+
+func (e *BalanceEvent) Marshal() ([]byte, error) {
+	p := codec.NewWriter(balanceEventSize, balanceEventSize)
+	p.PackPublicKey(e.From)
+	p.PackPublicKey(e.To)
+	p.PackID(e.Asset)
+	p.PackUint64(e.Value)
+	return p.Bytes(), p.Err()
+}
+
+// balanceEvents returns the events of a successful [chain.Result] that moved
+// [value] of [asset] from [from] to [to] (as described by [topic]).
+func balanceEvents(topic string, from, to crypto.PublicKey, asset ids.ID, value uint64) ([]*chain.Event, error) {
+	payload, err := (&BalanceEvent{From: from, To: to, Asset: asset, Value: value}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return []*chain.Event{{Topic: topic, Payload: payload}}, nil
+}
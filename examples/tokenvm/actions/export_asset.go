@@ -23,7 +23,7 @@ const exportAssetSize = crypto.PublicKeyLen + consts.IDLen +
 	consts.Uint64Len + consts.BoolLen +
 	consts.Uint64Len + /* op bits */
 	consts.Uint64Len + consts.Uint64Len + consts.IDLen + consts.Uint64Len +
-	consts.Int64Len + consts.IDLen
+	consts.Int64Len + consts.Uint64Len + consts.IDLen
 
 var _ chain.Action = (*ExportAsset)(nil)
 
@@ -38,9 +38,14 @@ type ExportAsset struct {
 	SwapOut     uint64           `json:"swapOut"`
 	SwapExpiry  int64            `json:"swapExpiry"`
 	Destination ids.ID           `json:"destination"`
+
+	// RelayFee is the amount of the native asset to escrow for whoever
+	// delivers this transfer to [Destination]. It is paid out by
+	// [ClaimRelayFee] once the destination attests to the delivery.
+	RelayFee uint64 `json:"relayFee"`
 }
 
-func (e *ExportAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+func (e *ExportAsset) StateKeys(rauth chain.Auth, txID ids.ID) [][]byte {
 	var (
 		keys  [][]byte
 		actor = auth.GetActor(rauth)
@@ -48,6 +53,7 @@ func (e *ExportAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
 	if e.Return {
 		keys = [][]byte{
 			storage.PrefixAssetKey(e.Asset),
+			storage.PrefixImportedAssetKey(e.Asset),
 			storage.PrefixBalanceKey(actor, e.Asset),
 		}
 	} else {
@@ -58,9 +64,33 @@ func (e *ExportAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
 		}
 	}
 
+	// If a relay fee is specified, we add the state keys to escrow it.
+	if e.RelayFee > 0 {
+		keys = append(keys, storage.PrefixRelayEscrowKey(txID))
+		if e.Asset != ids.Empty {
+			keys = append(keys, storage.PrefixBalanceKey(actor, ids.Empty))
+		}
+	}
 	return keys
 }
 
+// escrowRelayFee moves [RelayFee] from [actor] into an escrow that can be
+// claimed by whoever delivers [txID].
+func (e *ExportAsset) escrowRelayFee(
+	ctx context.Context,
+	db chain.Database,
+	actor crypto.PublicKey,
+	txID ids.ID,
+) error {
+	if e.RelayFee == 0 {
+		return nil
+	}
+	if err := storage.SubBalance(ctx, db, actor, ids.Empty, e.RelayFee); err != nil {
+		return err
+	}
+	return storage.SetRelayEscrow(ctx, db, txID, e.Destination, e.RelayFee)
+}
+
 func (e *ExportAsset) executeReturn(
 	ctx context.Context,
 	r chain.Rules,
@@ -103,6 +133,9 @@ func (e *ExportAsset) executeReturn(
 			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 		}
 	}
+	if err := storage.SubImported(ctx, db, e.Asset, supply-newSupply); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
 	if err := storage.SubBalance(ctx, db, actor, e.Asset, e.Value); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
@@ -115,6 +148,9 @@ func (e *ExportAsset) executeReturn(
 	if err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
+	if err := e.escrowRelayFee(ctx, db, actor, txID); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
 	wt := &WarpTransfer{
 		To:                 e.To,
 		Asset:              originalAsset,
@@ -125,6 +161,7 @@ func (e *ExportAsset) executeReturn(
 		AssetOut:           e.AssetOut,
 		SwapOut:            e.SwapOut,
 		SwapExpiry:         e.SwapExpiry,
+		RelayFee:           e.RelayFee,
 		TxID:               txID,
 		DestinationChainID: e.Destination,
 	}
@@ -172,6 +209,9 @@ func (e *ExportAsset) executeLoan(
 			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 		}
 	}
+	if err := e.escrowRelayFee(ctx, db, actor, txID); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
 	wt := &WarpTransfer{
 		To:                 e.To,
 		Asset:              e.Asset,
@@ -182,6 +222,7 @@ func (e *ExportAsset) executeLoan(
 		AssetOut:           e.AssetOut,
 		SwapOut:            e.SwapOut,
 		SwapExpiry:         e.SwapExpiry,
+		RelayFee:           e.RelayFee,
 		TxID:               txID,
 		DestinationChainID: e.Destination,
 	}
@@ -235,12 +276,13 @@ func (e *ExportAsset) Marshal(p *codec.Packer) {
 	p.PackID(e.Asset)
 	p.PackUint64(e.Value)
 	p.PackBool(e.Return)
-	op := codec.NewOptionalWriter(consts.Uint64Len*3 + consts.Int64Len + consts.IDLen)
+	op := codec.NewOptionalWriter(consts.Uint64Len*4 + consts.Int64Len + consts.IDLen)
 	op.PackUint64(e.Reward)
 	op.PackUint64(e.SwapIn)
 	op.PackID(e.AssetOut)
 	op.PackUint64(e.SwapOut)
 	op.PackInt64(e.SwapExpiry)
+	op.PackUint64(e.RelayFee)
 	p.PackOptional(op)
 	p.PackID(e.Destination)
 }
@@ -257,6 +299,7 @@ func UnmarshalExportAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error
 	op.UnpackID(&export.AssetOut)
 	export.SwapOut = op.UnpackUint64()
 	export.SwapExpiry = op.UnpackInt64()
+	export.RelayFee = op.UnpackUint64() // optional
 	op.Done()
 	p.UnpackID(true, &export.Destination)
 	if err := p.Err(); err != nil {
@@ -19,7 +19,11 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*FillOrder)(nil)
+var (
+	_ chain.Action         = (*FillOrder)(nil)
+	_ chain.AddressAction  = (*FillOrder)(nil)
+	_ chain.ReadOnlyAction = (*FillOrder)(nil)
+)
 
 const (
 	basePrice           = 3*consts.IDLen + consts.Uint64Len + crypto.PublicKeyLen
@@ -53,9 +57,22 @@ func (f *FillOrder) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
 		storage.PrefixBalanceKey(f.Owner, f.In),
 		storage.PrefixBalanceKey(actor, f.In),
 		storage.PrefixBalanceKey(actor, f.Out),
+		storage.PrefixMinTransferKey(f.In),
+		storage.PrefixMinTransferKey(f.Out),
 	}
 }
 
+// ReadOnlyKeys allows fills of orders for the same assets to be executed in
+// parallel (the minimum transfers of [In] and [Out] are only read).
+func (f *FillOrder) ReadOnlyKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{storage.PrefixMinTransferKey(f.In), storage.PrefixMinTransferKey(f.Out)}
+}
+
+// Addresses is used to index the blocks that include this action.
+func (f *FillOrder) Addresses() [][]byte {
+	return [][]byte{f.Owner[:]}
+}
+
 func (f *FillOrder) Execute(
 	ctx context.Context,
 	_ chain.Rules,
@@ -129,7 +146,26 @@ func (f *FillOrder) Execute(
 		// Don't allow free trades (can happen due to refund rounding)
 		return &chain.Result{Success: false, Units: basePrice, Output: OutputInsufficientInput}, nil
 	}
-	if err := storage.SubBalance(ctx, db, actor, f.In, inputAmount); err != nil {
+	minIn, err := storage.GetMinTransfer(ctx, db, f.In)
+	if err != nil {
+		return &chain.Result{Success: false, Units: basePrice, Output: utils.ErrBytes(err)}, nil
+	}
+	if inputAmount < minIn {
+		return &chain.Result{Success: false, Units: basePrice, Output: OutputBelowMinTransfer}, nil
+	}
+	minOut, err := storage.GetMinTransfer(ctx, db, f.Out)
+	if err != nil {
+		return &chain.Result{Success: false, Units: basePrice, Output: utils.ErrBytes(err)}, nil
+	}
+	if outputAmount < minOut && !shouldDelete {
+		// Filling whatever is left of an order is always allowed (otherwise it
+		// could never be closed).
+		return &chain.Result{Success: false, Units: basePrice, Output: OutputBelowMinTransfer}, nil
+	}
+	// If the fill would leave [actor] with less than [minIn], the remaining dust
+	// is swept to [f.Owner] as well.
+	inputAmount, err = storage.SubBalanceSweep(ctx, db, actor, f.In, inputAmount, minIn)
+	if err != nil {
 		return &chain.Result{Success: false, Units: basePrice, Output: utils.ErrBytes(err)}, nil
 	}
 	if err := storage.AddBalance(ctx, db, f.Owner, f.In, inputAmount); err != nil {
@@ -0,0 +1,112 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+func TestFillOrderMinTransfer(t *testing.T) {
+	var (
+		ctx = context.TODO()
+		r   = &testRules{chainID: ids.GenerateTestID()}
+		in  = ids.GenerateTestID()
+		out = ids.GenerateTestID()
+	)
+	for name, tt := range map[string]struct {
+		minIn   uint64
+		minOut  uint64
+		balance uint64 // of [in] held by the actor
+		value   uint64
+
+		output    []byte
+		paid      uint64 // [in] paid to the owner
+		received  uint64 // [out] received by the actor
+		remaining uint64
+	}{
+		"fill": {
+			minIn:     5,
+			minOut:    2,
+			balance:   100,
+			value:     10,
+			paid:      10,
+			received:  4,
+			remaining: 6,
+		},
+		"input below minimum": {
+			minIn:   15,
+			balance: 100,
+			value:   10,
+			output:  OutputBelowMinTransfer,
+		},
+		"output below minimum": {
+			minOut:  6,
+			balance: 100,
+			value:   10,
+			output:  OutputBelowMinTransfer,
+		},
+		"rest of order below minimum": {
+			minOut:   20,
+			balance:  100,
+			value:    25,
+			paid:     25,
+			received: 10,
+		},
+		"dust swept": {
+			minIn:     5,
+			balance:   13,
+			value:     10,
+			paid:      13,
+			received:  4,
+			remaining: 6,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				db    = testDB{}
+				actor = newTestActor(t)
+				pk    = auth.GetActor(actor)
+				owner = auth.GetActor(newTestActor(t))
+				order = ids.GenerateTestID()
+			)
+			require.NoError(storage.SetOrder(ctx, db, order, in, 5, out, 2, 10, owner))
+			require.NoError(storage.SetBalance(ctx, db, pk, in, tt.balance))
+			require.NoError(storage.SetMinTransfer(ctx, db, in, tt.minIn))
+			require.NoError(storage.SetMinTransfer(ctx, db, out, tt.minOut))
+
+			fill := &FillOrder{Order: order, Owner: owner, In: in, Out: out, Value: tt.value}
+			result, err := fill.Execute(ctx, r, db, 0, actor, ids.GenerateTestID(), false)
+			require.NoError(err)
+			if tt.output != nil {
+				require.False(result.Success)
+				require.Equal(tt.output, result.Output)
+				requireBalance(t, db, pk, in, tt.balance)
+				requireBalance(t, db, owner, in, 0)
+				return
+			}
+			require.True(result.Success, string(result.Output))
+			or, err := UnmarshalOrderResult(result.Output)
+			require.NoError(err)
+			require.Equal(&OrderResult{In: tt.paid, Out: tt.received, Remaining: tt.remaining}, or)
+			requireBalance(t, db, pk, in, tt.balance-tt.paid)
+			requireBalance(t, db, owner, in, tt.paid)
+			requireBalance(t, db, pk, out, tt.received)
+
+			// Orders are removed once they are filled completely
+			exists, _, _, _, _, remaining, _, err := storage.GetOrder(ctx, db, order)
+			require.NoError(err)
+			require.Equal(tt.remaining > 0, exists)
+			require.Equal(tt.remaining, remaining)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+// testDB is an in-memory [chain.Database].
+type testDB map[string][]byte
+
+func (db testDB) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	v, ok := db[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return v, nil
+}
+
+func (db testDB) Insert(_ context.Context, key []byte, value []byte) error {
+	db[string(key)] = value
+	return nil
+}
+
+func (db testDB) Remove(_ context.Context, key []byte) error {
+	delete(db, string(key))
+	return nil
+}
+
+// testRules are the [chain.Rules] of the chain with [chainID]. Unused methods
+// of [chain.Rules] panic.
+type testRules struct {
+	chain.Rules
+
+	chainID ids.ID
+}
+
+func (r *testRules) ChainID() ids.ID { return r.chainID }
+
+// newTestActor returns the [chain.Auth] of a new account.
+func newTestActor(t *testing.T) chain.Auth {
+	priv, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	return &auth.ED25519{Signer: priv.PublicKey()}
+}
+
+// newTestWarpMessage returns a verified [warp.Message] sent by [sourceChainID]
+// (as it would be parsed from a [chain.Transaction]).
+func newTestWarpMessage(sourceChainID ids.ID, payload []byte) *warp.Message {
+	return &warp.Message{
+		UnsignedMessage: warp.UnsignedMessage{
+			SourceChainID: sourceChainID,
+			Payload:       payload,
+		},
+	}
+}
+
+// newTestImport returns the [ImportAsset] of [wt] sent by [sourceChainID]
+// (parsed as it would be from a [chain.Transaction]).
+func newTestImport(t *testing.T, sourceChainID ids.ID, wt *WarpTransfer, fill bool) *ImportAsset {
+	require := require.New(t)
+
+	payload, err := wt.Marshal()
+	require.NoError(err)
+	p := codec.NewWriter(consts.BoolLen, consts.BoolLen)
+	p.PackBool(fill)
+	action, err := UnmarshalImportAsset(
+		codec.NewReader(p.Bytes(), consts.BoolLen),
+		newTestWarpMessage(sourceChainID, payload),
+	)
+	require.NoError(err)
+	return action.(*ImportAsset)
+}
+
+// newTestAsset stores a native (non-warp) asset with [supply] that is fully
+// held by [owner].
+func newTestAsset(t *testing.T, db chain.Database, owner crypto.PublicKey, supply uint64) ids.ID {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	asset := ids.GenerateTestID()
+	require.NoError(storage.SetAsset(ctx, db, asset, []byte("test"), supply, owner, false))
+	require.NoError(storage.SetBalance(ctx, db, owner, asset, supply))
+	return asset
+}
+
+// requireBalance checks that [pk] holds [expected] of [asset].
+func requireBalance(t *testing.T, db chain.Database, pk crypto.PublicKey, asset ids.ID, expected uint64) {
+	require := require.New(t)
+
+	balance, err := storage.GetBalance(context.TODO(), db, pk, asset)
+	require.NoError(err)
+	require.Equal(expected, balance)
+}
@@ -19,12 +19,16 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*ImportAsset)(nil)
+var (
+	_ chain.Action        = (*ImportAsset)(nil)
+	_ chain.AddressAction = (*ImportAsset)(nil)
+)
 
 type ImportAsset struct {
 	// Fill indicates if the actor wishes to fill the order request in the warp
-	// message. This must be true if the warp message is in a block with
-	// a timestamp < [SwapExpiry].
+	// message. If the warp message is in a block with a timestamp <
+	// [SwapExpiry] and this is false, [SwapIn] is escrowed until the swap is
+	// filled or expires (see [SettleSwap]).
 	Fill bool `json:"fill"`
 
 	// warpTransfer is parsed from the inner *warp.Message
@@ -34,6 +38,11 @@ type ImportAsset struct {
 	warpMessage *warp.Message
 }
 
+// Addresses is used to index the blocks that include this action.
+func (i *ImportAsset) Addresses() [][]byte {
+	return [][]byte{i.warpTransfer.To[:]}
+}
+
 func (i *ImportAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
 	var (
 		keys    [][]byte
@@ -50,6 +59,7 @@ func (i *ImportAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
 		assetID = ImportedAssetID(i.warpTransfer.Asset, i.warpMessage.SourceChainID)
 		keys = [][]byte{
 			storage.PrefixAssetKey(assetID),
+			storage.PrefixImportedAssetKey(assetID),
 			storage.PrefixBalanceKey(i.warpTransfer.To, assetID),
 		}
 	}
@@ -67,6 +77,12 @@ func (i *ImportAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
 		keys = append(keys, storage.PrefixBalanceKey(actor, assetID))
 		keys = append(keys, storage.PrefixBalanceKey(i.warpTransfer.To, i.warpTransfer.AssetOut))
 	}
+
+	// If the [warpTransfer] requests a swap we don't fill, we add the state key
+	// to escrow [SwapIn] (if it has not expired).
+	if !i.Fill && i.warpTransfer.SwapIn > 0 {
+		keys = append(keys, storage.PrefixSwapEscrowKey(i.warpTransfer.TxID))
+	}
 	return keys
 }
 
@@ -98,6 +114,12 @@ func (i *ImportAsset) executeMint(
 	if err := storage.SetAsset(ctx, db, asset, metadata, newSupply, crypto.EmptyPublicKey, true); err != nil {
 		return utils.ErrBytes(err)
 	}
+	if err := storage.AddImported(
+		ctx, db, asset, i.warpMessage.SourceChainID, i.warpTransfer.Asset,
+		i.warpTransfer.Value+i.warpTransfer.Reward, // checked above
+	); err != nil {
+		return utils.ErrBytes(err)
+	}
 	if err := storage.AddBalance(ctx, db, i.warpTransfer.To, asset, i.warpTransfer.Value); err != nil {
 		return utils.ErrBytes(err)
 	}
@@ -178,21 +200,36 @@ func (i *ImportAsset) Execute(
 	}
 	if i.warpTransfer.SwapIn == 0 {
 		// We are ensured that [i.Fill] is false here because of logic in unmarshal
-		return &chain.Result{Success: true, Units: unitsUsed}, nil
+		return i.success(unitsUsed, actor)
 	}
-	if !i.Fill {
-		if i.warpTransfer.SwapExpiry > t {
-			return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMustFill}, nil
-		}
-		return &chain.Result{Success: true, Units: unitsUsed}, nil
-	}
-	// TODO: charge more if swap is performed
 	var assetIn ids.ID
 	if i.warpTransfer.Return {
 		assetIn = i.warpTransfer.Asset
 	} else {
 		assetIn = ImportedAssetID(i.warpTransfer.Asset, i.warpMessage.SourceChainID)
 	}
+	if !i.Fill {
+		if i.warpTransfer.SwapExpiry <= t {
+			return i.success(unitsUsed, actor)
+		}
+		// Hold [SwapIn] until someone fills the swap or it expires (at which
+		// point it is refunded to [To]).
+		if err := storage.SubBalance(ctx, db, i.warpTransfer.To, assetIn, i.warpTransfer.SwapIn); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		if err := storage.SetSwapEscrow(ctx, db, i.warpTransfer.TxID, &storage.SwapEscrow{
+			To:         i.warpTransfer.To,
+			AssetIn:    assetIn,
+			SwapIn:     i.warpTransfer.SwapIn,
+			AssetOut:   i.warpTransfer.AssetOut,
+			SwapOut:    i.warpTransfer.SwapOut,
+			SwapExpiry: i.warpTransfer.SwapExpiry,
+		}); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		return i.success(unitsUsed, actor)
+	}
+	// TODO: charge more if swap is performed
 	if err := storage.SubBalance(ctx, db, i.warpTransfer.To, assetIn, i.warpTransfer.SwapIn); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
@@ -205,7 +242,29 @@ func (i *ImportAsset) Execute(
 	if err := storage.AddBalance(ctx, db, i.warpTransfer.To, i.warpTransfer.AssetOut, i.warpTransfer.SwapOut); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
-	return &chain.Result{Success: true, Units: unitsUsed}, nil
+	return i.success(unitsUsed, actor)
+}
+
+// success returns a successful result (which attests to the delivery of the
+// transfer if it escrowed a relay fee).
+func (i *ImportAsset) success(unitsUsed uint64, actor crypto.PublicKey) (*chain.Result, error) {
+	if i.warpTransfer.RelayFee == 0 {
+		return &chain.Result{Success: true, Units: unitsUsed}, nil
+	}
+	receipt := &RelayReceipt{
+		TxID:               i.warpTransfer.TxID,
+		Relayer:            actor,
+		DestinationChainID: i.warpMessage.SourceChainID,
+	}
+	payload, err := receipt.Marshal()
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	wm := &warp.UnsignedMessage{
+		// NetworkID + SourceChainID is populated by hypersdk
+		Payload: payload,
+	}
+	return &chain.Result{Success: true, Units: unitsUsed, WarpMessage: wm}, nil
 }
 
 func (i *ImportAsset) MaxUnits(chain.Rules) uint64 {
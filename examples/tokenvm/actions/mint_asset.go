@@ -19,7 +19,10 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*MintAsset)(nil)
+var (
+	_ chain.Action        = (*MintAsset)(nil)
+	_ chain.AddressAction = (*MintAsset)(nil)
+)
 
 type MintAsset struct {
 	// To is the recipient of the [Value].
@@ -39,6 +42,11 @@ func (m *MintAsset) StateKeys(chain.Auth, ids.ID) [][]byte {
 	}
 }
 
+// Addresses is used to index the blocks that include this action.
+func (m *MintAsset) Addresses() [][]byte {
+	return [][]byte{m.To[:]}
+}
+
 func (m *MintAsset) Execute(
 	ctx context.Context,
 	r chain.Rules,
@@ -83,7 +91,11 @@ func (m *MintAsset) Execute(
 	if err := storage.AddBalance(ctx, db, m.To, m.Asset, m.Value); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
-	return &chain.Result{Success: true, Units: unitsUsed}, nil
+	events, err := balanceEvents(MintTopic, crypto.EmptyPublicKey, m.To, m.Asset, m.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &chain.Result{Success: true, Units: unitsUsed, Events: events}, nil
 }
 
 func (*MintAsset) MaxUnits(chain.Rules) uint64 {
@@ -33,10 +33,16 @@ type ModifyAsset struct {
 	//
 	// If you want this to stay the same, you must set it to be the same value.
 	Metadata []byte `json:"metadata"`
+
+	// MinTransfer is the new minimum transfer amount of the [Asset] (0 removes
+	// the minimum).
+	//
+	// If you want this to stay the same, you must set it to be the same value.
+	MinTransfer uint64 `json:"minTransfer"`
 }
 
 func (m *ModifyAsset) StateKeys(chain.Auth, ids.ID) [][]byte {
-	return [][]byte{storage.PrefixAssetKey(m.Asset)}
+	return [][]byte{storage.PrefixAssetKey(m.Asset), storage.PrefixMinTransferKey(m.Asset)}
 }
 
 func (m *ModifyAsset) Execute(
@@ -76,23 +82,27 @@ func (m *ModifyAsset) Execute(
 	if err := storage.SetAsset(ctx, db, m.Asset, m.Metadata, supply, m.Owner, isWarp); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
+	if err := storage.SetMinTransfer(ctx, db, m.Asset, m.MinTransfer); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
 	return &chain.Result{Success: true, Units: unitsUsed}, nil
 }
 
 func (m *ModifyAsset) MaxUnits(chain.Rules) uint64 {
 	// We use size as the price of this transaction but we could just as easily
 	// use any other calculation.
-	return consts.IDLen + crypto.PublicKeyLen + uint64(len(m.Metadata))
+	return consts.IDLen + crypto.PublicKeyLen + uint64(len(m.Metadata)) + consts.Uint64Len
 }
 
 func (m *ModifyAsset) Size() int {
-	return consts.IDLen + crypto.PublicKeyLen + codec.BytesLen(m.Metadata)
+	return consts.IDLen + crypto.PublicKeyLen + codec.BytesLen(m.Metadata) + consts.Uint64Len
 }
 
 func (m *ModifyAsset) Marshal(p *codec.Packer) {
 	p.PackID(m.Asset)
 	p.PackPublicKey(m.Owner)
 	p.PackBytes(m.Metadata)
+	p.PackUint64(m.MinTransfer)
 }
 
 func UnmarshalModifyAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
@@ -100,6 +110,7 @@ func UnmarshalModifyAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error
 	p.UnpackID(true, &modify.Asset)         // empty ID is the native asset
 	p.UnpackPublicKey(false, &modify.Owner) // empty revokes ownership
 	p.UnpackBytes(MaxMetadataSize, false, &modify.Metadata)
+	modify.MinTransfer = p.UnpackUint64(false) // 0 removes the minimum
 	return &modify, p.Err()
 }
 
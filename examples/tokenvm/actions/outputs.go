@@ -30,4 +30,9 @@ var (
 	OutputMustFill               = []byte("must fill request")
 	OutputWarpVerificationFailed = []byte("warp verification failed")
 	OutputInvalidDestination     = []byte("invalid destination")
+	OutputEscrowMissing          = []byte("escrow missing")
+	OutputWrongSource            = []byte("wrong source")
+	OutputEscrowMismatch         = []byte("escrow does not match")
+	OutputBelowMinTransfer       = []byte("below min transfer")
+	OutputNothingToSweep         = []byte("nothing to sweep")
 )
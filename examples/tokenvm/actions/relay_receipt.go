@@ -0,0 +1,52 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+)
+
+const RelayReceiptSize = consts.IDLen + crypto.PublicKeyLen + consts.IDLen
+
+// RelayReceipt is emitted (as a warp message) by [ImportAsset] when the
+// imported [WarpTransfer] escrowed a [WarpTransfer.RelayFee]. It is sent back
+// to the chain that exported the transfer, where [ClaimRelayFee] uses it to
+// pay the escrowed fee to [Relayer].
+type RelayReceipt struct {
+	// TxID is the [ExportAsset] transaction that was delivered.
+	TxID ids.ID `json:"txID"`
+
+	// Relayer is the actor that delivered the transfer.
+	Relayer crypto.PublicKey `json:"relayer"`
+
+	// DestinationChainID is the chain that exported the transfer.
+	DestinationChainID ids.ID `json:"destinationChainID"`
+}
+
+func (r *RelayReceipt) Marshal() ([]byte, error) {
+	p := codec.NewWriter(RelayReceiptSize, RelayReceiptSize)
+	p.PackID(r.TxID)
+	p.PackPublicKey(r.Relayer)
+	p.PackID(r.DestinationChainID)
+	return p.Bytes(), p.Err()
+}
+
+func UnmarshalRelayReceipt(b []byte) (*RelayReceipt, error) {
+	var receipt RelayReceipt
+	p := codec.NewReader(b, RelayReceiptSize)
+	p.UnpackID(true, &receipt.TxID)
+	p.UnpackPublicKey(true, &receipt.Relayer)
+	p.UnpackID(true, &receipt.DestinationChainID)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &receipt, nil
+}
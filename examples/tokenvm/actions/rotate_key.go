@@ -0,0 +1,73 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RotateKey)(nil)
+
+type RotateKey struct {
+	// Key will control the actor's account after this action is executed.
+	//
+	// Once rotated, the account must be used with [auth.RotatedED25519]. To
+	// restore control to the original key, set this to the actor.
+	Key crypto.PublicKey `json:"key"`
+}
+
+func (*RotateKey) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	return [][]byte{storage.PrefixAccountKey(auth.GetActor(rauth))}
+}
+
+func (r *RotateKey) Execute(
+	ctx context.Context,
+	rules chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := r.MaxUnits(rules) // max units == units
+	if err := storage.SetAccountKey(ctx, db, actor, r.Key); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*RotateKey) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen
+}
+
+func (*RotateKey) Size() int {
+	return crypto.PublicKeyLen
+}
+
+func (r *RotateKey) Marshal(p *codec.Packer) {
+	p.PackPublicKey(r.Key)
+}
+
+func UnmarshalRotateKey(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var rotate RotateKey
+	p.UnpackPublicKey(true, &rotate.Key) // cannot rotate to the empty key
+	return &rotate, p.Err()
+}
+
+func (*RotateKey) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+const settleSwapSize = consts.IDLen*3 + crypto.PublicKeyLen
+
+var (
+	_ chain.Action        = (*SettleSwap)(nil)
+	_ chain.AddressAction = (*SettleSwap)(nil)
+)
+
+// SettleSwap resolves the swap of a transfer that was imported without being
+// filled. Before [SwapExpiry], the actor fills the swap (paying [SwapOut] of
+// [AssetOut] to [To] and receiving the escrowed [SwapIn]). After
+// [SwapExpiry], anyone can settle the swap to refund [SwapIn] to [To].
+//
+// [To], [AssetIn], and [AssetOut] must match the escrow (they are required to
+// determine the state keys of this action).
+type SettleSwap struct {
+	// Swap is the ID of the [ExportAsset] transaction that requested the swap.
+	Swap ids.ID `json:"swap"`
+
+	To       crypto.PublicKey `json:"to"`
+	AssetIn  ids.ID           `json:"assetIn"`
+	AssetOut ids.ID           `json:"assetOut"`
+}
+
+func (s *SettleSwap) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixSwapEscrowKey(s.Swap),
+		storage.PrefixBalanceKey(s.To, s.AssetIn),
+		storage.PrefixBalanceKey(s.To, s.AssetOut),
+		storage.PrefixBalanceKey(actor, s.AssetIn),
+		storage.PrefixBalanceKey(actor, s.AssetOut),
+	}
+}
+
+// Addresses is used to index the blocks that include this action.
+func (s *SettleSwap) Addresses() [][]byte {
+	return [][]byte{s.To[:]}
+}
+
+func (s *SettleSwap) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := s.MaxUnits(r) // max units == units
+	escrow, err := storage.GetSwapEscrow(ctx, db, s.Swap)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if escrow == nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputEscrowMissing}, nil
+	}
+	if escrow.To != s.To || escrow.AssetIn != s.AssetIn || escrow.AssetOut != s.AssetOut {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputEscrowMismatch}, nil
+	}
+	if err := storage.DeleteSwapEscrow(ctx, db, s.Swap); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if escrow.SwapExpiry <= t {
+		// Swap expired, so we refund [SwapIn]
+		if err := storage.AddBalance(ctx, db, escrow.To, escrow.AssetIn, escrow.SwapIn); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		return &chain.Result{Success: true, Units: unitsUsed}, nil
+	}
+	if err := storage.SubBalance(ctx, db, actor, escrow.AssetOut, escrow.SwapOut); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, escrow.To, escrow.AssetOut, escrow.SwapOut); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, escrow.AssetIn, escrow.SwapIn); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*SettleSwap) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return settleSwapSize
+}
+
+func (*SettleSwap) Size() int {
+	return settleSwapSize
+}
+
+func (s *SettleSwap) Marshal(p *codec.Packer) {
+	p.PackID(s.Swap)
+	p.PackPublicKey(s.To)
+	p.PackID(s.AssetIn)
+	p.PackID(s.AssetOut)
+}
+
+func UnmarshalSettleSwap(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var settle SettleSwap
+	p.UnpackID(true, &settle.Swap)
+	p.UnpackPublicKey(false, &settle.To) // can transfer to blackhole
+	p.UnpackID(false, &settle.AssetIn)   // may be native
+	p.UnpackID(false, &settle.AssetOut)  // may be native
+	return &settle, p.Err()
+}
+
+func (*SettleSwap) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
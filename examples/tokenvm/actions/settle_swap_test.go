@@ -0,0 +1,223 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+func TestImportAssetSwapEscrow(t *testing.T) {
+	var (
+		ctx         = context.TODO()
+		source      = ids.GenerateTestID()
+		destination = ids.GenerateTestID()
+		r           = &testRules{chainID: destination}
+		assetOut    = ids.GenerateTestID()
+	)
+	for name, tt := range map[string]struct {
+		fill      bool
+		timestamp int64
+		balance   uint64 // of [assetOut] held by the actor
+
+		success  bool
+		escrowed bool
+		filled   bool
+	}{
+		"escrowed until filled": {
+			timestamp: 500,
+			success:   true,
+			escrowed:  true,
+		},
+		"not escrowed after expiry": {
+			timestamp: 1_000,
+			success:   true,
+		},
+		"filled on import": {
+			fill:      true,
+			timestamp: 500,
+			balance:   7,
+			success:   true,
+			filled:    true,
+		},
+		"fill without balance": {
+			fill:      true,
+			timestamp: 500,
+			balance:   6,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				db    = testDB{}
+				actor = newTestActor(t)
+				pk    = auth.GetActor(actor)
+				to    = auth.GetActor(newTestActor(t))
+			)
+			require.NoError(storage.SetBalance(ctx, db, pk, assetOut, tt.balance))
+			wt := &WarpTransfer{
+				To:                 to,
+				Asset:              ids.GenerateTestID(),
+				Value:              100,
+				SwapIn:             40,
+				AssetOut:           assetOut,
+				SwapOut:            7,
+				SwapExpiry:         1_000,
+				TxID:               ids.GenerateTestID(),
+				DestinationChainID: destination,
+			}
+			assetIn := ImportedAssetID(wt.Asset, source)
+			imp := newTestImport(t, source, wt, tt.fill)
+			result, err := imp.Execute(ctx, r, db, tt.timestamp, actor, ids.GenerateTestID(), true)
+			require.NoError(err)
+			require.Equal(tt.success, result.Success, string(result.Output))
+			if !tt.success {
+				return
+			}
+
+			escrow, err := storage.GetSwapEscrow(ctx, db, wt.TxID)
+			require.NoError(err)
+			switch {
+			case tt.escrowed:
+				// [SwapIn] is held until the swap is settled
+				requireBalance(t, db, to, assetIn, 60)
+				require.Equal(&storage.SwapEscrow{
+					To:         to,
+					AssetIn:    assetIn,
+					SwapIn:     40,
+					AssetOut:   assetOut,
+					SwapOut:    7,
+					SwapExpiry: 1_000,
+				}, escrow)
+			case tt.filled:
+				requireBalance(t, db, to, assetIn, 60)
+				requireBalance(t, db, to, assetOut, 7)
+				requireBalance(t, db, pk, assetIn, 40)
+				requireBalance(t, db, pk, assetOut, 0)
+				require.Nil(escrow)
+			default:
+				requireBalance(t, db, to, assetIn, 100)
+				requireBalance(t, db, pk, assetIn, 0)
+				require.Nil(escrow)
+			}
+		})
+	}
+}
+
+func TestSettleSwap(t *testing.T) {
+	var (
+		ctx      = context.TODO()
+		r        = &testRules{chainID: ids.GenerateTestID()}
+		assetIn  = ids.GenerateTestID()
+		assetOut = ids.GenerateTestID()
+	)
+	for name, tt := range map[string]struct {
+		noEscrow  bool
+		wrongOut  bool
+		timestamp int64
+		balance   uint64 // of [assetOut] held by the actor
+
+		output   []byte
+		success  bool
+		refunded bool
+	}{
+		"fill before expiry": {
+			timestamp: 500,
+			balance:   7,
+			success:   true,
+		},
+		"refund after expiry": {
+			timestamp: 1_000,
+			success:   true,
+			refunded:  true,
+		},
+		"fill without balance": {
+			timestamp: 500,
+			balance:   6,
+		},
+		"escrow missing": {
+			noEscrow:  true,
+			timestamp: 500,
+			balance:   7,
+			output:    OutputEscrowMissing,
+		},
+		"escrow mismatch": {
+			wrongOut:  true,
+			timestamp: 500,
+			balance:   7,
+			output:    OutputEscrowMismatch,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				db    = testDB{}
+				actor = newTestActor(t)
+				pk    = auth.GetActor(actor)
+				to    = auth.GetActor(newTestActor(t))
+				swap  = ids.GenerateTestID()
+			)
+			require.NoError(storage.SetBalance(ctx, db, pk, assetOut, tt.balance))
+			if !tt.noEscrow {
+				require.NoError(storage.SetSwapEscrow(ctx, db, swap, &storage.SwapEscrow{
+					To:         to,
+					AssetIn:    assetIn,
+					SwapIn:     40,
+					AssetOut:   assetOut,
+					SwapOut:    7,
+					SwapExpiry: 1_000,
+				}))
+			}
+			settle := &SettleSwap{Swap: swap, To: to, AssetIn: assetIn, AssetOut: assetOut}
+			if tt.wrongOut {
+				settle.AssetOut = ids.GenerateTestID()
+			}
+			result, err := settle.Execute(ctx, r, db, tt.timestamp, actor, ids.GenerateTestID(), false)
+			require.NoError(err)
+			require.Equal(tt.success, result.Success, string(result.Output))
+			if tt.output != nil {
+				require.Equal(tt.output, result.Output)
+
+				// The escrow is left untouched
+				escrow, err := storage.GetSwapEscrow(ctx, db, swap)
+				require.NoError(err)
+				require.Equal(!tt.noEscrow, escrow != nil)
+			}
+			if !tt.success {
+				requireBalance(t, db, to, assetIn, 0)
+				requireBalance(t, db, pk, assetIn, 0)
+				return
+			}
+
+			if tt.refunded {
+				// Anyone can refund an expired swap (without paying for it)
+				requireBalance(t, db, to, assetIn, 40)
+				requireBalance(t, db, to, assetOut, 0)
+				requireBalance(t, db, pk, assetIn, 0)
+			} else {
+				requireBalance(t, db, to, assetIn, 0)
+				requireBalance(t, db, to, assetOut, 7)
+				requireBalance(t, db, pk, assetIn, 40)
+				requireBalance(t, db, pk, assetOut, 0)
+			}
+			escrow, err := storage.GetSwapEscrow(ctx, db, swap)
+			require.NoError(err)
+			require.Nil(escrow)
+
+			// Swaps can only be settled once
+			result, err = settle.Execute(ctx, r, db, tt.timestamp, actor, ids.GenerateTestID(), false)
+			require.NoError(err)
+			require.False(result.Success)
+			require.Equal(OutputEscrowMissing, result.Output)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// MaxSweepAccounts is the maximum number of accounts that can be included in
+// a single [SweepBalances].
+const MaxSweepAccounts = 64
+
+var _ chain.Action = (*SweepBalances)(nil)
+
+// SweepBalances removes empty balances of [Asset] from state. Empty balances
+// are no longer written during execution, however, balances written before
+// this was the case may still be stored as zero.
+//
+// Anyone can sweep any account (sweeping an empty balance does not change
+// what an account owns). To encourage keeping state compact, the units of
+// each account that is swept are refunded.
+type SweepBalances struct {
+	// Asset of the balances to sweep.
+	Asset ids.ID `json:"asset"`
+
+	// Accounts whose empty balance of [Asset] should be removed.
+	Accounts []crypto.PublicKey `json:"accounts"`
+}
+
+func (s *SweepBalances) StateKeys(chain.Auth, ids.ID) [][]byte {
+	keys := make([][]byte, len(s.Accounts))
+	for i, account := range s.Accounts {
+		keys[i] = storage.PrefixBalanceKey(account, s.Asset)
+	}
+	return keys
+}
+
+// Addresses is used to index the blocks that include this action.
+func (s *SweepBalances) Addresses() [][]byte {
+	addrs := make([][]byte, len(s.Accounts))
+	for i := range s.Accounts {
+		addrs[i] = s.Accounts[i][:]
+	}
+	return addrs
+}
+
+func (s *SweepBalances) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	_ chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	unitsUsed := s.MaxUnits(r)
+	var swept uint64
+	for _, account := range s.Accounts {
+		removed, err := storage.DeleteZeroBalance(ctx, db, account, s.Asset)
+		if err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		if removed {
+			swept++
+		}
+	}
+	if swept == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNothingToSweep}, nil
+	}
+	sr := &SweepResult{Swept: swept}
+	output, err := sr.Marshal()
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	// Refund the units of each account that was swept
+	return &chain.Result{Success: true, Units: unitsUsed - swept*crypto.PublicKeyLen, Output: output}, nil
+}
+
+func (s *SweepBalances) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return uint64(s.Size())
+}
+
+func (s *SweepBalances) Size() int {
+	return consts.IDLen + consts.IntLen + len(s.Accounts)*crypto.PublicKeyLen
+}
+
+func (s *SweepBalances) Marshal(p *codec.Packer) {
+	p.PackID(s.Asset)
+	p.PackInt(len(s.Accounts))
+	for _, account := range s.Accounts {
+		p.PackPublicKey(account)
+	}
+}
+
+func UnmarshalSweepBalances(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var sweep SweepBalances
+	p.UnpackID(false, &sweep.Asset) // empty ID is the native asset
+	count := p.UnpackInt(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if count > MaxSweepAccounts {
+		return nil, ErrTooManyAccounts
+	}
+	sweep.Accounts = make([]crypto.PublicKey, count)
+	for i := range sweep.Accounts {
+		p.UnpackPublicKey(true, &sweep.Accounts[i])
+	}
+	return &sweep, p.Err()
+}
+
+func (*SweepBalances) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+// SweepResult is a custom successful response output that provides the number
+// of balances that were removed.
+type SweepResult struct {
+	Swept uint64 `json:"swept"`
+}
+
+func UnmarshalSweepResult(b []byte) (*SweepResult, error) {
+	p := codec.NewReader(b, consts.Uint64Len)
+	var result SweepResult
+	result.Swept = p.UnpackUint64(true)
+	return &result, p.Err()
+}
+
+func (s *SweepResult) Marshal() ([]byte, error) {
+	p := codec.NewWriter(consts.Uint64Len, consts.Uint64Len)
+	p.PackUint64(s.Swept)
+	return p.Bytes(), p.Err()
+}
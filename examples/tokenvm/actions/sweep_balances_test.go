@@ -0,0 +1,161 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+// storeZeroBalance writes an empty balance of [asset] for [pk] (as was done
+// before empty balances were deleted during execution).
+func storeZeroBalance(t *testing.T, db chain.Database, pk crypto.PublicKey, asset ids.ID) {
+	require.NoError(t, db.Insert(
+		context.TODO(),
+		storage.PrefixBalanceKey(pk, asset),
+		binary.BigEndian.AppendUint64(nil, 0),
+	))
+}
+
+func TestEmptyBalancesDeleted(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx   = context.TODO()
+		db    = testDB{}
+		r     = &testRules{chainID: ids.GenerateTestID()}
+		actor = newTestActor(t)
+		pk    = auth.GetActor(actor)
+		to    = auth.GetActor(newTestActor(t))
+	)
+	asset := newTestAsset(t, db, pk, 100)
+
+	// Transferring the entire balance removes it from state
+	transfer := &Transfer{To: to, Asset: asset, Value: 100}
+	result, err := transfer.Execute(ctx, r, db, 0, actor, ids.GenerateTestID(), false)
+	require.NoError(err)
+	require.True(result.Success, string(result.Output))
+	require.NotContains(db, string(storage.PrefixBalanceKey(pk, asset)))
+	requireBalance(t, db, to, asset, 100)
+
+	// Empty balances are never written
+	require.NoError(storage.AddBalance(ctx, db, pk, asset, 0))
+	require.NoError(storage.SetBalance(ctx, db, pk, asset, 0))
+	require.NotContains(db, string(storage.PrefixBalanceKey(pk, asset)))
+}
+
+func TestSweepBalances(t *testing.T) {
+	var (
+		ctx   = context.TODO()
+		r     = &testRules{chainID: ids.GenerateTestID()}
+		asset = ids.GenerateTestID()
+	)
+	for name, tt := range map[string]struct {
+		empty   int // accounts with a stored empty balance
+		funded  int // accounts with a non-empty balance
+		missing int // accounts without a balance
+
+		output []byte
+		swept  uint64
+	}{
+		"sweeps empty balances": {
+			empty:   2,
+			funded:  1,
+			missing: 1,
+			swept:   2,
+		},
+		"nothing to sweep": {
+			funded:  1,
+			missing: 1,
+			output:  OutputNothingToSweep,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				db       = testDB{}
+				empty    = []crypto.PublicKey{}
+				funded   = []crypto.PublicKey{}
+				accounts = []crypto.PublicKey{}
+			)
+			for i := 0; i < tt.empty; i++ {
+				pk := auth.GetActor(newTestActor(t))
+				storeZeroBalance(t, db, pk, asset)
+				empty = append(empty, pk)
+			}
+			for i := 0; i < tt.funded; i++ {
+				pk := auth.GetActor(newTestActor(t))
+				require.NoError(storage.SetBalance(ctx, db, pk, asset, 10))
+				funded = append(funded, pk)
+			}
+			accounts = append(append(accounts, empty...), funded...)
+			for i := 0; i < tt.missing; i++ {
+				accounts = append(accounts, auth.GetActor(newTestActor(t)))
+			}
+
+			// Anyone can sweep any account
+			sweep := &SweepBalances{Asset: asset, Accounts: accounts}
+			result, err := sweep.Execute(ctx, r, db, 0, newTestActor(t), ids.GenerateTestID(), false)
+			require.NoError(err)
+			for _, pk := range funded {
+				requireBalance(t, db, pk, asset, 10)
+			}
+			if tt.output != nil {
+				require.False(result.Success)
+				require.Equal(tt.output, result.Output)
+				require.Equal(sweep.MaxUnits(r), result.Units)
+				return
+			}
+			require.True(result.Success, string(result.Output))
+			sr, err := UnmarshalSweepResult(result.Output)
+			require.NoError(err)
+			require.Equal(tt.swept, sr.Swept)
+			for _, pk := range empty {
+				require.NotContains(db, string(storage.PrefixBalanceKey(pk, asset)))
+			}
+
+			// The units of each swept account are refunded
+			require.Equal(sweep.MaxUnits(r)-tt.swept*crypto.PublicKeyLen, result.Units)
+
+			// Sweeping again has nothing left to remove
+			result, err = sweep.Execute(ctx, r, db, 0, newTestActor(t), ids.GenerateTestID(), false)
+			require.NoError(err)
+			require.False(result.Success)
+			require.Equal(OutputNothingToSweep, result.Output)
+		})
+	}
+}
+
+func TestUnmarshalSweepBalances(t *testing.T) {
+	require := require.New(t)
+
+	sweep := &SweepBalances{Asset: ids.GenerateTestID()}
+	for i := 0; i < MaxSweepAccounts+1; i++ {
+		sweep.Accounts = append(sweep.Accounts, auth.GetActor(newTestActor(t)))
+	}
+	for _, count := range []int{MaxSweepAccounts, MaxSweepAccounts + 1} {
+		s := &SweepBalances{Asset: sweep.Asset, Accounts: sweep.Accounts[:count]}
+		p := codec.NewWriter(s.Size(), s.Size())
+		s.Marshal(p)
+		require.NoError(p.Err())
+		action, err := UnmarshalSweepBalances(codec.NewReader(p.Bytes(), s.Size()), nil)
+		if count > MaxSweepAccounts {
+			require.ErrorIs(err, ErrTooManyAccounts)
+			continue
+		}
+		require.NoError(err)
+		require.Equal(s, action)
+	}
+}
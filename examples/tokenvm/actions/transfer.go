@@ -17,7 +17,11 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*Transfer)(nil)
+var (
+	_ chain.Action         = (*Transfer)(nil)
+	_ chain.AddressAction  = (*Transfer)(nil)
+	_ chain.ReadOnlyAction = (*Transfer)(nil)
+)
 
 type Transfer struct {
 	// To is the recipient of the [Value].
@@ -34,9 +38,21 @@ func (t *Transfer) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
 	return [][]byte{
 		storage.PrefixBalanceKey(auth.GetActor(rauth), t.Asset),
 		storage.PrefixBalanceKey(t.To, t.Asset),
+		storage.PrefixMinTransferKey(t.Asset),
 	}
 }
 
+// ReadOnlyKeys allows transfers of the same asset to be executed in parallel
+// (the minimum transfer of [Asset] is only read).
+func (t *Transfer) ReadOnlyKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{storage.PrefixMinTransferKey(t.Asset)}
+}
+
+// Addresses is used to index the blocks that include this action.
+func (t *Transfer) Addresses() [][]byte {
+	return [][]byte{t.To[:]}
+}
+
 func (t *Transfer) Execute(
 	ctx context.Context,
 	r chain.Rules,
@@ -51,13 +67,27 @@ func (t *Transfer) Execute(
 	if t.Value == 0 {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
 	}
-	if err := storage.SubBalance(ctx, db, actor, t.Asset, t.Value); err != nil {
+	min, err := storage.GetMinTransfer(ctx, db, t.Asset)
+	if err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
-	if err := storage.AddBalance(ctx, db, t.To, t.Asset, t.Value); err != nil {
+	if t.Value < min {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputBelowMinTransfer}, nil
+	}
+	// If the transfer would leave [actor] with less than [min], the remaining
+	// dust is swept to [To] as well (closing the account).
+	value, err := storage.SubBalanceSweep(ctx, db, actor, t.Asset, t.Value, min)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, t.To, t.Asset, value); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
-	return &chain.Result{Success: true, Units: unitsUsed}, nil
+	events, err := balanceEvents(TransferTopic, actor, t.To, t.Asset, value)
+	if err != nil {
+		return nil, err
+	}
+	return &chain.Result{Success: true, Units: unitsUsed, Events: events}, nil
 }
 
 func (*Transfer) MaxUnits(chain.Rules) uint64 {
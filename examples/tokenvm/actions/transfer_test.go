@@ -0,0 +1,134 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+func TestTransferMinTransfer(t *testing.T) {
+	var (
+		ctx = context.TODO()
+		r   = &testRules{chainID: ids.GenerateTestID()}
+	)
+	for name, tt := range map[string]struct {
+		min   uint64
+		value uint64
+
+		output      []byte
+		success     bool
+		transferred uint64
+	}{
+		"no minimum": {
+			value:       1,
+			success:     true,
+			transferred: 1,
+		},
+		"minimum": {
+			min:         10,
+			value:       10,
+			success:     true,
+			transferred: 10,
+		},
+		"below minimum": {
+			min:    10,
+			value:  9,
+			output: OutputBelowMinTransfer,
+		},
+		"dust swept": {
+			min:         10,
+			value:       95,
+			success:     true,
+			transferred: 100,
+		},
+		"entire balance": {
+			min:         10,
+			value:       100,
+			success:     true,
+			transferred: 100,
+		},
+		"insufficient balance": {
+			min:   10,
+			value: 101,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				db    = testDB{}
+				actor = newTestActor(t)
+				pk    = auth.GetActor(actor)
+				to    = auth.GetActor(newTestActor(t))
+			)
+			asset := newTestAsset(t, db, pk, 100)
+			require.NoError(storage.SetMinTransfer(ctx, db, asset, tt.min))
+
+			transfer := &Transfer{To: to, Asset: asset, Value: tt.value}
+			result, err := transfer.Execute(ctx, r, db, 0, actor, ids.GenerateTestID(), false)
+			require.NoError(err)
+			require.Equal(tt.success, result.Success, string(result.Output))
+			if tt.output != nil {
+				require.Equal(tt.output, result.Output)
+			}
+			requireBalance(t, db, pk, asset, 100-tt.transferred)
+			requireBalance(t, db, to, asset, tt.transferred)
+			if !tt.success {
+				return
+			}
+
+			// The event reports the amount that was actually transferred
+			require.Len(result.Events, 1)
+			event, err := UnmarshalBalanceEvent(result.Events[0].Payload)
+			require.NoError(err)
+			require.Equal(tt.transferred, event.Value)
+		})
+	}
+}
+
+func TestAssetMinTransfer(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx   = context.TODO()
+		db    = testDB{}
+		r     = &testRules{chainID: ids.GenerateTestID()}
+		actor = newTestActor(t)
+		asset = ids.GenerateTestID()
+	)
+	create := &CreateAsset{Metadata: []byte("test"), MinTransfer: 10}
+	result, err := create.Execute(ctx, r, db, 0, actor, asset, false)
+	require.NoError(err)
+	require.True(result.Success, string(result.Output))
+	min, err := storage.GetMinTransfer(ctx, db, asset)
+	require.NoError(err)
+	require.Equal(uint64(10), min)
+
+	// Only the owner can change the minimum
+	modify := &ModifyAsset{Asset: asset, Owner: auth.GetActor(actor), Metadata: []byte("test"), MinTransfer: 5}
+	result, err = modify.Execute(ctx, r, db, 0, newTestActor(t), ids.GenerateTestID(), false)
+	require.NoError(err)
+	require.False(result.Success)
+	require.Equal(OutputWrongOwner, result.Output)
+	result, err = modify.Execute(ctx, r, db, 0, actor, ids.GenerateTestID(), false)
+	require.NoError(err)
+	require.True(result.Success, string(result.Output))
+	min, err = storage.GetMinTransfer(ctx, db, asset)
+	require.NoError(err)
+	require.Equal(uint64(5), min)
+
+	// Removing the minimum removes its record
+	modify.MinTransfer = 0
+	result, err = modify.Execute(ctx, r, db, 0, actor, ids.GenerateTestID(), false)
+	require.NoError(err)
+	require.True(result.Success, string(result.Output))
+	require.NotContains(db, string(storage.PrefixMinTransferKey(asset)))
+}
@@ -16,7 +16,7 @@ const WarpTransferSize = crypto.PublicKeyLen + consts.IDLen +
 	consts.Uint64Len + consts.BoolLen +
 	consts.Uint64Len + /* op bits */
 	consts.Uint64Len + consts.Uint64Len + consts.IDLen + consts.Uint64Len + consts.Int64Len +
-	consts.IDLen + consts.IDLen
+	consts.Uint64Len + consts.IDLen + consts.IDLen
 
 type WarpTransfer struct {
 	To    crypto.PublicKey `json:"to"`
@@ -41,6 +41,12 @@ type WarpTransfer struct {
 	// the message can be processed without a swap.
 	SwapExpiry int64 `json:"swapExpiry"`
 
+	// RelayFee is the amount of the native asset (on the source chain) that
+	// was escrowed for the [Actor] that submits this transaction. When
+	// non-zero, the import emits a [RelayReceipt] that can be used to claim
+	// it.
+	RelayFee uint64 `json:"relayFee"`
+
 	// TxID is the transaction that created this message. This is used to ensure
 	// there is WarpID uniqueness.
 	TxID ids.ID `json:"txID"`
@@ -56,12 +62,13 @@ func (w *WarpTransfer) Marshal() ([]byte, error) {
 	p.PackID(w.Asset)
 	p.PackUint64(w.Value)
 	p.PackBool(w.Return)
-	op := codec.NewOptionalWriter(consts.Uint64Len*3 + consts.IDLen + consts.Int64Len)
+	op := codec.NewOptionalWriter(consts.Uint64Len*4 + consts.IDLen + consts.Int64Len)
 	op.PackUint64(w.Reward)
 	op.PackUint64(w.SwapIn)
 	op.PackID(w.AssetOut)
 	op.PackUint64(w.SwapOut)
 	op.PackInt64(w.SwapExpiry)
+	op.PackUint64(w.RelayFee)
 	p.PackOptional(op)
 	p.PackID(w.TxID)
 	p.PackID(w.DestinationChainID)
@@ -92,6 +99,7 @@ func UnmarshalWarpTransfer(b []byte) (*WarpTransfer, error) {
 	op.UnpackID(&transfer.AssetOut)
 	transfer.SwapOut = op.UnpackUint64()
 	transfer.SwapExpiry = op.UnpackInt64()
+	transfer.RelayFee = op.UnpackUint64() // optional
 	op.Done()
 	p.UnpackID(true, &transfer.TxID)
 	p.UnpackID(true, &transfer.DestinationChainID)
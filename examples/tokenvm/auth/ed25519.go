@@ -35,6 +35,8 @@ func (d *ED25519) StateKeys() [][]byte {
 	return [][]byte{
 		// We always pay fees with the native asset (which is [ids.Empty])
 		storage.PrefixBalanceKey(d.Signer, ids.Empty),
+		// We must ensure the signer has not rotated its key
+		storage.PrefixAccountKey(d.Signer),
 	}
 }
 
@@ -46,13 +48,20 @@ func (d *ED25519) AsyncVerify(msg []byte) error {
 }
 
 func (d *ED25519) Verify(
-	_ context.Context,
+	ctx context.Context,
 	r chain.Rules,
-	_ chain.Database,
+	db chain.Database,
 	_ chain.Action,
 ) (uint64, error) {
-	// We don't do anything during verify (there is no additional state to check
-	// to authorize the signer other than verifying the signature)
+	// Once an account rotates its key, the original key can no longer be used
+	// to authorize actions for it
+	_, rotated, err := storage.GetAccountKey(ctx, db, d.Signer)
+	if err != nil {
+		return 0, err
+	}
+	if rotated {
+		return 0, ErrKeyRotated
+	}
 	return d.MaxUnits(r), nil
 }
 
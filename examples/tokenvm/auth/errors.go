@@ -5,4 +5,8 @@ package auth
 
 import "errors"
 
-var ErrInvalidSignature = errors.New("invalid signature")
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrKeyRotated       = errors.New("key rotated")
+	ErrNotAccountKey    = errors.New("not account key")
+)
@@ -12,6 +12,8 @@ func GetActor(auth chain.Auth) crypto.PublicKey {
 	switch a := auth.(type) {
 	case *ED25519:
 		return a.Signer
+	case *RotatedED25519:
+		return a.Account
 	default:
 		return crypto.EmptyPublicKey
 	}
@@ -21,6 +23,8 @@ func GetSigner(auth chain.Auth) crypto.PublicKey {
 	switch a := auth.(type) {
 	case *ED25519:
 		return a.Signer
+	case *RotatedED25519:
+		return a.Signer
 	default:
 		return crypto.EmptyPublicKey
 	}
@@ -0,0 +1,136 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+var _ chain.Auth = (*RotatedED25519)(nil)
+
+// RotatedED25519 authorizes [Account] using the key it most recently rotated
+// to (with [actions.RotateKey]). This allows long-lived accounts to change
+// their controlling key without changing their address.
+type RotatedED25519 struct {
+	Account   crypto.PublicKey `json:"account"`
+	Signer    crypto.PublicKey `json:"signer"`
+	Signature crypto.Signature `json:"signature"`
+}
+
+func (*RotatedED25519) MaxUnits(
+	chain.Rules,
+) uint64 {
+	return crypto.PublicKeyLen*2 + crypto.SignatureLen*5 // make signatures more expensive
+}
+
+func (*RotatedED25519) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (d *RotatedED25519) StateKeys() [][]byte {
+	return [][]byte{
+		// We always pay fees with the native asset (which is [ids.Empty])
+		storage.PrefixBalanceKey(d.Account, ids.Empty),
+		storage.PrefixAccountKey(d.Account),
+	}
+}
+
+func (d *RotatedED25519) AsyncVerify(msg []byte) error {
+	if !crypto.Verify(msg, d.Signer, d.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (d *RotatedED25519) Verify(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ chain.Action,
+) (uint64, error) {
+	key, rotated, err := storage.GetAccountKey(ctx, db, d.Account)
+	if err != nil {
+		return 0, err
+	}
+	if !rotated || key != d.Signer {
+		return 0, ErrNotAccountKey
+	}
+	return d.MaxUnits(r), nil
+}
+
+func (d *RotatedED25519) Payer() []byte {
+	return d.Account[:]
+}
+
+func (*RotatedED25519) Size() int {
+	return crypto.PublicKeyLen*2 + crypto.SignatureLen
+}
+
+func (d *RotatedED25519) Marshal(p *codec.Packer) {
+	p.PackPublicKey(d.Account)
+	p.PackPublicKey(d.Signer)
+	p.PackSignature(d.Signature)
+}
+
+func UnmarshalRotatedED25519(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var d RotatedED25519
+	p.UnpackPublicKey(true, &d.Account)
+	p.UnpackPublicKey(true, &d.Signer)
+	p.UnpackSignature(&d.Signature)
+	return &d, p.Err()
+}
+
+func (d *RotatedED25519) CanDeduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, db, d.Account, ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (d *RotatedED25519) Deduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return storage.SubBalance(ctx, db, d.Account, ids.Empty, amount)
+}
+
+func (d *RotatedED25519) Refund(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return storage.AddBalance(ctx, db, d.Account, ids.Empty, amount)
+}
+
+var _ chain.AuthFactory = (*RotatedED25519Factory)(nil)
+
+func NewRotatedED25519Factory(account crypto.PublicKey, priv crypto.PrivateKey) *RotatedED25519Factory {
+	return &RotatedED25519Factory{account, priv}
+}
+
+type RotatedED25519Factory struct {
+	account crypto.PublicKey
+	priv    crypto.PrivateKey
+}
+
+func (d *RotatedED25519Factory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	sig := crypto.Sign(msg, d.priv)
+	return &RotatedED25519{d.account, d.priv.PublicKey(), sig}, nil
+}
@@ -6,7 +6,7 @@ package cmd
 
 import (
 	"context"
-	"errors"
+	"strconv"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -92,6 +92,19 @@ var createAssetCmd = &cobra.Command{
 			return err
 		}
 
+		// Set minimum transfer (empty means there is no minimum)
+		rawMin, err := handler.Root().PromptString("min transfer (can be changed later)", 0, 20)
+		if err != nil {
+			return err
+		}
+		var minTransfer uint64
+		if len(rawMin) > 0 {
+			minTransfer, err = strconv.ParseUint(rawMin, 10, 64)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Confirm action
 		cont, err := handler.Root().PromptContinue()
 		if !cont || err != nil {
@@ -100,7 +113,8 @@ var createAssetCmd = &cobra.Command{
 
 		// Generate transaction
 		_, _, err = sendAndWait(ctx, nil, &actions.CreateAsset{
-			Metadata: []byte(metadata),
+			Metadata:    []byte(metadata),
+			MinTransfer: minTransfer,
 		}, cli, tcli, factory, true)
 		return err
 	},
@@ -510,7 +524,7 @@ func performImport(
 		}
 	}
 	if !fill && wt.SwapExpiry > time.Now().UnixMilli() {
-		return ErrMustFill
+		hutils.Outf("{{yellow}}swap in will be escrowed until it is settled{{/}}\n")
 	}
 
 	// Attempt to send dummy transaction if needed
@@ -595,6 +609,19 @@ var exportAssetCmd = &cobra.Command{
 			return err
 		}
 
+		// Select relay fee (escrowed in the native asset)
+		nativeBalance := balance - amount - reward
+		if assetID != ids.Empty {
+			nativeBalance, err = tcli.Balance(ctx, utils.Address(priv.PublicKey()), ids.Empty)
+			if err != nil {
+				return err
+			}
+		}
+		relayFee, err := handler.Root().PromptAmount("relay fee", ids.Empty, nativeBalance, nil)
+		if err != nil {
+			return err
+		}
+
 		// Determine destination
 		destination := sourceChainID
 		if !ret {
@@ -657,7 +684,7 @@ var exportAssetCmd = &cobra.Command{
 		}
 
 		// Generate transaction
-		success, txID, err := sendAndWait(ctx, nil, &actions.ExportAsset{
+		_, txID, err := sendAndWait(ctx, nil, &actions.ExportAsset{
 			To:          recipient,
 			Asset:       assetID,
 			Value:       amount,
@@ -668,13 +695,11 @@ var exportAssetCmd = &cobra.Command{
 			SwapOut:     swapOut,
 			SwapExpiry:  swapExpiry,
 			Destination: destination,
+			RelayFee:    relayFee,
 		}, cli, tcli, factory, true)
 		if err != nil {
 			return err
 		}
-		if !success {
-			return errors.New("not successful")
-		}
 
 		// Perform import
 		imp, err := handler.Root().PromptBool("perform import on destination")
@@ -706,3 +731,37 @@ var exportAssetCmd = &cobra.Command{
 		return handler.Root().StoreDefaultChain(destination)
 	},
 }
+
+var rotateKeyCmd = &cobra.Command{
+	Use: "rotate-key",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select new key (use the account address to restore the original key)
+		key, err := handler.Root().PromptAddress("new key")
+		if err != nil {
+			return err
+		}
+		hutils.Outf(
+			"{{yellow}}after rotating, txs from %s must be signed by %s{{/}}\n",
+			utils.Address(priv.PublicKey()),
+			utils.Address(key),
+		)
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		_, _, err = sendAndWait(ctx, nil, &actions.RotateKey{
+			Key: key,
+		}, cli, tcli, factory, true)
+		return err
+	},
+}
@@ -6,11 +6,17 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"os"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/chain"
+	hconfig "github.com/ava-labs/hypersdk/config"
+	"github.com/ava-labs/hypersdk/utils"
 	"github.com/spf13/cobra"
 
+	"github.com/ava-labs/hypersdk/examples/tokenvm/config"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
 )
 
@@ -72,3 +78,61 @@ var watchChainCmd = &cobra.Command{
 		}, handleTx)
 	},
 }
+
+var validateConfigChainCmd = &cobra.Command{
+	Use:   "validate-config [options]",
+	Short: "Reports every problem with a genesis and/or VM config",
+	PreRunE: func(*cobra.Command, []string) error {
+		if len(genesisFile) == 0 && len(configFile) == 0 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(*cobra.Command, []string) error {
+		errs := 0
+		for _, f := range []struct {
+			path     string
+			validate func([]byte) hconfig.Issues
+		}{
+			{genesisFile, genesis.Validate},
+			{configFile, config.Validate},
+		} {
+			if len(f.path) == 0 {
+				continue
+			}
+			b, err := os.ReadFile(f.path)
+			if err != nil {
+				return err
+			}
+			issues := f.validate(b)
+			errs += issues.Errors()
+			printIssues(f.path, issues)
+		}
+		if errs > 0 {
+			return fmt.Errorf("%w: found %d errors", hconfig.ErrInvalidConfig, errs)
+		}
+		return nil
+	},
+}
+
+func printIssues(path string, issues hconfig.Issues) {
+	if len(issues) == 0 {
+		utils.Outf("{{green}}%s is valid{{/}}\n", path)
+		return
+	}
+	utils.Outf("{{yellow}}%s:{{/}} %d errors, %d warnings\n", path, issues.Errors(), len(issues)-issues.Errors())
+	for _, issue := range issues {
+		level := "{{red}}error{{/}}"
+		if issue.Warning {
+			level = "{{yellow}}warning{{/}}"
+		}
+		p := issue.Path
+		if len(p) == 0 {
+			p = "(root)"
+		}
+		utils.Outf("  %s {{cyan}}%s:{{/}} %s\n", level, p, issue.Problem)
+		if len(issue.Suggestion) > 0 {
+			utils.Outf("    {{yellow}}suggested:{{/}} %s\n", issue.Suggestion)
+		}
+	}
+}
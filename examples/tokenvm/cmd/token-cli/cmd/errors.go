@@ -3,12 +3,35 @@
 
 package cmd
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/config"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/rpc"
+)
 
 var (
 	ErrInvalidArgs        = errors.New("invalid args")
 	ErrMissingSubcommand  = errors.New("must specify a subcommand")
 	ErrNotMultiple        = errors.New("must be a multiple")
 	ErrInsufficientSupply = errors.New("insufficient supply")
-	ErrMustFill           = errors.New("must fill")
 )
+
+// ExitCode returns the exit code token-cli should exit with after [err] (see
+// [cli.ExitCode] for the codes).
+func ExitCode(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidArgs),
+		errors.Is(err, ErrMissingSubcommand),
+		errors.Is(err, ErrNotMultiple),
+		errors.Is(err, config.ErrInvalidConfig):
+		return cli.ExitInvalidArgs
+	case errors.Is(err, ErrInsufficientSupply),
+		rpc.IsRemote(err, storage.ErrInvalidBalance):
+		return cli.ExitInsufficientFunds
+	default:
+		return cli.ExitCode(err)
+	}
+}
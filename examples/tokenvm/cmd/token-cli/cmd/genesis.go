@@ -4,12 +4,22 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/calibrate"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/utils"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 )
 
@@ -21,7 +31,7 @@ var genesisCmd = &cobra.Command{
 }
 
 var genGenesisCmd = &cobra.Command{
-	Use:   "generate [custom allocations file] [options]",
+	Use:   "generate [custom allocations file (.json or .csv)] [options]",
 	Short: "Creates a new genesis in the default location",
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -32,26 +42,24 @@ var genGenesisCmd = &cobra.Command{
 	RunE: func(_ *cobra.Command, args []string) error {
 		g := genesis.Default()
 		if minUnitPrice >= 0 {
-			g.MinUnitPrice = uint64(minUnitPrice)
+			for i := range g.MinUnitPrice {
+				g.MinUnitPrice[i] = uint64(minUnitPrice)
+			}
 		}
 		if maxBlockUnits >= 0 {
-			g.MaxBlockUnits = uint64(maxBlockUnits)
+			g.MaxBlockUnits[fees.Compute] = uint64(maxBlockUnits)
 		}
 		if windowTargetUnits >= 0 {
-			g.WindowTargetUnits = uint64(windowTargetUnits)
+			g.WindowTargetUnits[fees.Compute] = uint64(windowTargetUnits)
 		}
 		if minBlockGap >= 0 {
 			g.MinBlockGap = minBlockGap
 		}
 
-		a, err := os.ReadFile(args[0])
+		allocs, err := loadAllocations(args[0])
 		if err != nil {
 			return err
 		}
-		allocs := []*genesis.CustomAllocation{}
-		if err := json.Unmarshal(a, &allocs); err != nil {
-			return err
-		}
 		g.CustomAllocation = allocs
 
 		b, err := json.Marshal(g)
@@ -65,3 +73,91 @@ var genGenesisCmd = &cobra.Command{
 		return nil
 	},
 }
+
+var calibrateGenesisCmd = &cobra.Command{
+	Use:   "calibrate [options]",
+	Short: "Benchmarks this machine and suggests fee parameters",
+	RunE: func(*cobra.Command, []string) error {
+		cfg := calibrate.NewDefaultConfig()
+		cfg.Duration = calibrateDuration
+		cfg.Utilization = calibrateUtilization
+		utils.Outf("{{yellow}}running benchmarks (this will take about %s){{/}}\n", 3*cfg.Duration)
+		r, err := calibrate.Run(context.Background(), cfg)
+		if err != nil {
+			return err
+		}
+		utils.Outf(
+			"{{yellow}}byte:{{/}} %.2fns {{yellow}}signature:{{/}} %.0fns {{yellow}}state operation:{{/}} %.0fns {{yellow}}cores:{{/}} %d\n",
+			r.ByteNs,
+			r.SignatureNs,
+			r.StateOperationNs,
+			r.Config.Parallelism,
+		)
+		utils.Outf(
+			"{{yellow}}reference tx (%d bytes, %d signatures, %d state operations):{{/}} %d units %.0fns\n",
+			r.Config.TxSize,
+			r.Config.TxSignatures,
+			r.Config.TxStateOperations,
+			r.TxUnits,
+			r.TxNs,
+		)
+		utils.Outf(
+			"{{yellow}}suggested signature units:{{/}} %d {{yellow}}state operation units:{{/}} %d\n",
+			r.SignatureUnits,
+			r.StateOperationUnits,
+		)
+		utils.Outf(
+			"{{yellow}}capacity:{{/}} %d units/s {{yellow}}suggested window target units (%.0f%% utilization):{{/}} %d {{yellow}}max block units:{{/}} %d\n",
+			r.UnitsPerSecond,
+			r.Config.Utilization*100,
+			r.WindowTargetUnits,
+			r.MaxBlockUnits,
+		)
+		utils.Outf(
+			"{{cyan}}token-cli genesis generate <allocations> --window-target-units %d --max-block-units %d{{/}}\n",
+			r.WindowTargetUnits,
+			r.MaxBlockUnits,
+		)
+		return nil
+	},
+}
+
+// loadAllocations reads allocations from a JSON file or, if [path] ends in
+// ".csv", from "address,amount[,asset]" records.
+func loadAllocations(path string) ([]*genesis.CustomAllocation, error) {
+	if !strings.EqualFold(filepath.Ext(path), ".csv") {
+		a, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		allocs := []*genesis.CustomAllocation{}
+		if err := json.Unmarshal(a, &allocs); err != nil {
+			return nil, err
+		}
+		return allocs, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	allocs, totals, err := genesis.ParseAllocationsCSV(f)
+	if err != nil {
+		return nil, err
+	}
+	assets := make([]ids.ID, 0, len(totals))
+	for asset := range totals {
+		assets = append(assets, asset)
+	}
+	sort.Slice(assets, func(i, j int) bool { return bytes.Compare(assets[i][:], assets[j][:]) < 0 })
+	utils.Outf("{{yellow}}imported allocations:{{/}} %d\n", len(allocs))
+	for _, asset := range assets {
+		if asset == ids.Empty {
+			utils.Outf("{{yellow}}total %s:{{/}} %s\n", consts.Symbol, utils.FormatBalance(totals[asset]))
+			continue
+		}
+		utils.Outf("{{yellow}}total %s:{{/}} %d\n", asset, totals[asset])
+	}
+	return allocs, nil
+}
@@ -5,13 +5,19 @@ package cmd
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/crypto"
 	hutils "github.com/ava-labs/hypersdk/utils"
 	"github.com/spf13/cobra"
 
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
 )
 
 var keyCmd = &cobra.Command{
@@ -28,6 +34,45 @@ var genKeyCmd = &cobra.Command{
 	},
 }
 
+var genBatchKeyCmd = &cobra.Command{
+	Use: "generate-batch [count]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		count, err := strconv.Atoi(args[0])
+		if err != nil {
+			return err
+		}
+		fund, err := hutils.ParseBalance(fundAmount)
+		if err != nil {
+			return err
+		}
+		var tclient *trpc.JSONRPCClient
+		return handler.Root().GenerateKeys(count, fund, keyManifest,
+			func(uri string, networkID uint32, chainID ids.ID) {
+				tclient = trpc.NewJSONRPCClient(uri, networkID, chainID)
+			},
+			func(pk crypto.PrivateKey) chain.AuthFactory {
+				return auth.NewED25519Factory(pk)
+			},
+			func(ctx context.Context, chainID ids.ID) (chain.Parser, error) {
+				return tclient.Parser(ctx)
+			},
+			func(pk crypto.PublicKey, amount uint64) chain.Action {
+				return &actions.Transfer{
+					To:    pk,
+					Asset: ids.Empty,
+					Value: amount,
+				}
+			},
+		)
+	},
+}
+
 var importKeyCmd = &cobra.Command{
 	Use: "import [path]",
 	PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -78,3 +123,23 @@ var balanceKeyCmd = &cobra.Command{
 		return handler.Root().Balance(checkAllChains, true, lookupKeyBalance)
 	},
 }
+
+var addressKeyCmd = &cobra.Command{
+	Use: "address",
+	RunE: func(*cobra.Command, []string) error {
+		priv, err := handler.Root().GetDefaultKey()
+		if err != nil {
+			return err
+		}
+		pk := priv.PublicKey()
+		hutils.Outf("{{cyan}}address:{{/}} %s\n", utils.Address(pk))
+		typeID, _, _, _ := consts.AuthRegistry.LookupType(&auth.ED25519{})
+		name, _ := consts.AddressRegistry.Name(typeID)
+		daddr, err := utils.DerivedAddress(typeID, pk[:])
+		if err != nil {
+			return err
+		}
+		hutils.Outf("{{cyan}}derived address (%s):{{/}} %s\n", name, daddr)
+		return nil
+	},
+}
@@ -33,6 +33,9 @@ var generatePrometheusCmd = &cobra.Command{
 			panels = append(panels, fmt.Sprintf("increase(avalanche_%s_blks_rejected_count[30s])/30", chainID))
 			utils.Outf("{{yellow}}blocks rejected per second:{{/}} %s\n", panels[len(panels)-1])
 
+			panels = append(panels, fmt.Sprintf("increase(avalanche_%s_vm_hypersdk_chain_blocks_proposed[5m])/increase(avalanche_%s_blks_accepted_count[5m])", chainID, chainID))
+			utils.Outf("{{yellow}}share of blocks proposed by this node:{{/}} %s\n", panels[len(panels)-1])
+
 			panels = append(panels, fmt.Sprintf("increase(avalanche_%s_vm_hypersdk_vm_txs_accepted[30s])/30", chainID))
 			utils.Outf("{{yellow}}transactions per second:{{/}} %s\n", panels[len(panels)-1])
 
@@ -5,13 +5,18 @@ package cmd
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/chain"
+	hcli "github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
@@ -21,6 +26,10 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
+// expiryGrace is how long we wait for a tx to be indexed after it expires
+// before considering it expired.
+const expiryGrace = 10 * time.Second
+
 // TODO: use websockets
 func sendAndWait(
 	ctx context.Context, warpMsg *warp.Message, action chain.Action, cli *rpc.JSONRPCClient,
@@ -37,13 +46,21 @@ func sendAndWait(
 	if err := submit(ctx); err != nil {
 		return false, ids.Empty, err
 	}
-	success, err := tcli.WaitForTransaction(ctx, tx.ID())
+	wctx, cancel := context.WithDeadline(ctx, time.UnixMilli(tx.Base.Timestamp).Add(expiryGrace))
+	defer cancel()
+	success, err := tcli.WaitForTransaction(wctx, tx.ID())
 	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			return false, tx.ID(), fmt.Errorf("%w: %s", rpc.ErrExpired, tx.ID())
+		}
 		return false, ids.Empty, err
 	}
 	if printStatus {
 		handler.Root().PrintStatus(tx.ID(), success)
 	}
+	if !success {
+		return false, tx.ID(), fmt.Errorf("%w: %s", hcli.ErrTxFailed, tx.ID())
+	}
 	return success, tx.ID(), nil
 }
 
@@ -156,6 +173,20 @@ func handleTx(tx *chain.Transaction, result *chain.Result) {
 			if wt.SwapIn > 0 {
 				summaryStr += fmt.Sprintf(" | swap in: %s %s swap out: %s %s expiry: %d", handler.Root().ValueString(outputAssetID, wt.SwapIn), handler.Root().AssetString(outputAssetID), handler.Root().ValueString(wt.AssetOut, wt.SwapOut), handler.Root().AssetString(wt.AssetOut), wt.SwapExpiry)
 			}
+			if action.RelayFee > 0 {
+				summaryStr += fmt.Sprintf(" | relay fee: %s %s", utils.FormatBalance(action.RelayFee), consts.Symbol)
+			}
+		case *actions.ClaimRelayFee:
+			summaryStr = fmt.Sprintf("source: %s", tx.WarpMessage.SourceChainID)
+		case *actions.SettleSwap:
+			summaryStr = fmt.Sprintf("swap: %s -> %s", action.Swap, tutils.Address(action.To))
+
+		case *actions.RotateKey:
+			summaryStr = fmt.Sprintf("key: %s", tutils.Address(action.Key))
+
+		case *actions.SweepBalances:
+			sr, _ := actions.UnmarshalSweepResult(result.Output)
+			summaryStr = fmt.Sprintf("asset: %s swept: %d/%d", action.Asset, sr.Swept, len(action.Accounts))
 		}
 	}
 	utils.Outf(
@@ -167,4 +198,31 @@ func handleTx(tx *chain.Transaction, result *chain.Result) {
 		reflect.TypeOf(tx.Action),
 		summaryStr,
 	)
+	for _, event := range result.Events {
+		utils.Outf("  {{yellow}}event (%s):{{/}} [%s]\n", event.Topic, eventString(event))
+	}
+}
+
+// eventString summarizes the payload of [event] (if its topic is known).
+func eventString(event *chain.Event) string {
+	switch event.Topic {
+	case actions.TransferTopic, actions.MintTopic, actions.BurnTopic:
+		be, err := actions.UnmarshalBalanceEvent(event.Payload)
+		if err != nil {
+			return fmt.Sprintf("invalid payload: %v", err)
+		}
+		from, to := "🪙", "🔥"
+		if be.From != crypto.EmptyPublicKey {
+			from = tutils.Address(be.From)
+		}
+		if be.To != crypto.EmptyPublicKey {
+			to = tutils.Address(be.To)
+		}
+		return fmt.Sprintf(
+			"%s %s: %s -> %s",
+			handler.Root().ValueString(be.Asset, be.Value), handler.Root().AssetString(be.Asset), from, to,
+		)
+	default:
+		return hex.EncodeToString(event.Payload)
+	}
 }
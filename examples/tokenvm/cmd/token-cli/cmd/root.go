@@ -21,18 +21,28 @@ const (
 var (
 	handler *Handler
 
-	dbPath            string
-	genesisFile       string
-	minUnitPrice      int64
-	maxBlockUnits     int64
-	windowTargetUnits int64
-	minBlockGap       int64
-	hideTxs           bool
-	randomRecipient   bool
-	maxTxBacklog      int
-	checkAllChains    bool
-	prometheusFile    string
-	prometheusData    string
+	dbPath               string
+	genesisFile          string
+	configFile           string
+	minUnitPrice         int64
+	maxBlockUnits        int64
+	windowTargetUnits    int64
+	minBlockGap          int64
+	calibrateDuration    time.Duration
+	calibrateUtilization float64
+	hideTxs              bool
+	randomRecipient      bool
+	maxTxBacklog         int
+	checkAllChains       bool
+	fundAmount           string
+	keyManifest          string
+	spamManifest         string
+	spamReport           string
+	spamPushURL          string
+	prometheusFile       string
+	prometheusData       string
+	vectorsFile          string
+	vectorsChainID       string
 
 	rootCmd = &cobra.Command{
 		Use:        "token-cli",
@@ -50,6 +60,8 @@ func init() {
 		actionCmd,
 		spamCmd,
 		prometheusCmd,
+		versionCmd,
+		vectorsCmd,
 	)
 	rootCmd.PersistentFlags().StringVar(
 		&dbPath,
@@ -71,6 +83,9 @@ func init() {
 		return handler.Root().CloseDatabase()
 	}
 	rootCmd.SilenceErrors = true
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	})
 
 	// genesis
 	genGenesisCmd.PersistentFlags().StringVar(
@@ -83,19 +98,19 @@ func init() {
 		&minUnitPrice,
 		"min-unit-price",
 		-1,
-		"minimum price",
+		"minimum price (of every fee dimension)",
 	)
 	genGenesisCmd.PersistentFlags().Int64Var(
 		&maxBlockUnits,
 		"max-block-units",
 		-1,
-		"max block units",
+		"max block compute units",
 	)
 	genGenesisCmd.PersistentFlags().Int64Var(
 		&windowTargetUnits,
 		"window-target-units",
 		-1,
-		"window target units",
+		"window target compute units",
 	)
 	genGenesisCmd.PersistentFlags().Int64Var(
 		&minBlockGap,
@@ -103,8 +118,21 @@ func init() {
 		-1,
 		"minimum block gap (ms)",
 	)
+	calibrateGenesisCmd.PersistentFlags().DurationVar(
+		&calibrateDuration,
+		"duration",
+		time.Second,
+		"duration of each benchmark",
+	)
+	calibrateGenesisCmd.PersistentFlags().Float64Var(
+		&calibrateUtilization,
+		"utilization",
+		0.5,
+		"fraction of capacity to target",
+	)
 	genesisCmd.AddCommand(
 		genGenesisCmd,
+		calibrateGenesisCmd,
 	)
 
 	// key
@@ -114,11 +142,25 @@ func init() {
 		false,
 		"check all chains",
 	)
+	genBatchKeyCmd.PersistentFlags().StringVar(
+		&fundAmount,
+		"fund",
+		"0",
+		"amount to fund each key with from the default key",
+	)
+	genBatchKeyCmd.PersistentFlags().StringVar(
+		&keyManifest,
+		"manifest",
+		"keys.json",
+		"manifest file location",
+	)
 	keyCmd.AddCommand(
 		genKeyCmd,
+		genBatchKeyCmd,
 		importKeyCmd,
 		setKeyCmd,
 		balanceKeyCmd,
+		addressKeyCmd,
 	)
 
 	// chain
@@ -128,6 +170,18 @@ func init() {
 		false,
 		"hide txs",
 	)
+	validateConfigChainCmd.PersistentFlags().StringVar(
+		&genesisFile,
+		"genesis-file",
+		defaultGenesis,
+		"genesis file path (empty skips the genesis)",
+	)
+	validateConfigChainCmd.PersistentFlags().StringVar(
+		&configFile,
+		"config-file",
+		"",
+		"VM config file path (empty skips the config)",
+	)
 	chainCmd.AddCommand(
 		importChainCmd,
 		importANRChainCmd,
@@ -135,6 +189,7 @@ func init() {
 		setChainCmd,
 		chainInfoCmd,
 		watchChainCmd,
+		validateConfigChainCmd,
 	)
 
 	// actions
@@ -152,6 +207,8 @@ func init() {
 
 		importAssetCmd,
 		exportAssetCmd,
+
+		rotateKeyCmd,
 	)
 
 	// spam
@@ -167,6 +224,24 @@ func init() {
 		72_000,
 		"max tx backlog",
 	)
+	runSpamCmd.PersistentFlags().StringVar(
+		&spamManifest,
+		"manifest",
+		"",
+		"use the keys in a manifest created by key generate-batch",
+	)
+	runSpamCmd.PersistentFlags().StringVar(
+		&spamReport,
+		"report",
+		"",
+		"write a JSON report of the run to this file",
+	)
+	runSpamCmd.PersistentFlags().StringVar(
+		&spamPushURL,
+		"push-url",
+		"",
+		"push live progress to the prometheus pushgateway at this URL",
+	)
 	spamCmd.AddCommand(
 		runSpamCmd,
 	)
@@ -187,6 +262,23 @@ func init() {
 	prometheusCmd.AddCommand(
 		generatePrometheusCmd,
 	)
+
+	// vectors
+	generateVectorsCmd.PersistentFlags().StringVar(
+		&vectorsFile,
+		"output",
+		"",
+		"write test vectors to this file (instead of stdout)",
+	)
+	generateVectorsCmd.PersistentFlags().StringVar(
+		&vectorsChainID,
+		"chain-id",
+		"",
+		"chain ID to sign test vectors for (defaults to the empty ID)",
+	)
+	vectorsCmd.AddCommand(
+		generateVectorsCmd,
+	)
 }
 
 func Execute() error {
@@ -28,7 +28,7 @@ var runSpamCmd = &cobra.Command{
 	Use: "run",
 	RunE: func(*cobra.Command, []string) error {
 		var tclient *trpc.JSONRPCClient
-		return handler.Root().Spam(maxTxBacklog, randomRecipient,
+		return handler.Root().Spam(maxTxBacklog, randomRecipient, spamManifest, spamReport, spamPushURL,
 			func(uri string, networkID uint32, chainID ids.ID) {
 				tclient = trpc.NewJSONRPCClient(uri, networkID, chainID)
 			},
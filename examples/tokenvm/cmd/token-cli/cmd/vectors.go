@@ -0,0 +1,135 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+)
+
+const vectorNetworkID = 1
+
+var vectorsCmd = &cobra.Command{
+	Use: "vectors",
+	RunE: func(*cobra.Command, []string) error {
+		return ErrMissingSubcommand
+	},
+}
+
+var generateVectorsCmd = &cobra.Command{
+	Use: "generate",
+	RunE: func(*cobra.Command, []string) error {
+		var chainID ids.ID
+		if len(vectorsChainID) > 0 {
+			var err error
+			chainID, err = ids.FromString(vectorsChainID)
+			if err != nil {
+				return err
+			}
+		}
+		testActions, err := vectorActions()
+		if err != nil {
+			return err
+		}
+		vectors, err := cli.GenerateTestVectors(
+			chainID,
+			testActions,
+			[]chain.AuthFactory{
+				auth.NewED25519Factory(cli.VectorKey(0)),
+				auth.NewRotatedED25519Factory(cli.VectorKey(1).PublicKey(), cli.VectorKey(0)),
+			},
+			consts.ActionRegistry,
+			consts.AuthRegistry,
+		)
+		if err != nil {
+			return err
+		}
+		if err := cli.SaveTestVectors(vectorsFile, vectors); err != nil {
+			return err
+		}
+		if len(vectorsFile) > 0 {
+			utils.Outf("{{green}}wrote %d test vectors to:{{/}} %s\n", len(vectors), vectorsFile)
+		}
+		return nil
+	},
+}
+
+// vectorActions returns an example of every registered action.
+func vectorActions() ([]*cli.VectorAction, error) {
+	to := cli.VectorKey(2).PublicKey()
+	asset := cli.VectorID(0)
+	transfer := &actions.WarpTransfer{
+		To:                 to,
+		Asset:              asset,
+		Value:              100,
+		TxID:               cli.VectorID(1),
+		DestinationChainID: cli.VectorID(2),
+	}
+	transferBytes, err := transfer.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	transferMsg, err := vectorWarpMessage(transferBytes)
+	if err != nil {
+		return nil, err
+	}
+	receipt := &actions.RelayReceipt{
+		TxID:               cli.VectorID(1),
+		Relayer:            to,
+		DestinationChainID: cli.VectorID(2),
+	}
+	receiptBytes, err := receipt.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	receiptMsg, err := vectorWarpMessage(receiptBytes)
+	if err != nil {
+		return nil, err
+	}
+	return []*cli.VectorAction{
+		{Action: &actions.Transfer{To: to, Asset: asset, Value: 100}},
+		{Action: &actions.CreateAsset{Metadata: []byte("vector"), MinTransfer: 10}},
+		{Action: &actions.MintAsset{To: to, Asset: asset, Value: 100}},
+		{Action: &actions.BurnAsset{Asset: asset, Value: 100}},
+		{Action: &actions.ModifyAsset{Asset: asset, Owner: to, Metadata: []byte("vector"), MinTransfer: 10}},
+		{Action: &actions.CreateOrder{In: ids.Empty, InTick: 1, Out: asset, OutTick: 10, Supply: 100}},
+		{Action: &actions.FillOrder{Order: cli.VectorID(3), Owner: to, In: ids.Empty, Out: asset, Value: 10}},
+		{Action: &actions.CloseOrder{Order: cli.VectorID(3), Out: asset}},
+		{Action: &actions.ImportAsset{}, WarpMessage: transferMsg},
+		{Action: &actions.ExportAsset{
+			To:          to,
+			Asset:       asset,
+			Value:       100,
+			Reward:      10,
+			SwapIn:      50,
+			AssetOut:    cli.VectorID(4),
+			SwapOut:     5,
+			SwapExpiry:  1_700_000_000_000,
+			Destination: cli.VectorID(2),
+			RelayFee:    1,
+		}},
+		{Action: &actions.RotateKey{Key: to}},
+		{Action: &actions.ClaimRelayFee{}, WarpMessage: receiptMsg},
+		{Action: &actions.SettleSwap{Swap: cli.VectorID(1), To: to, AssetIn: asset, AssetOut: cli.VectorID(4)}},
+		{Action: &actions.SweepBalances{Asset: asset, Accounts: []crypto.PublicKey{to, cli.VectorKey(3).PublicKey()}}},
+	}, nil
+}
+
+// vectorWarpMessage wraps [payload] in an (unsigned) warp message.
+func vectorWarpMessage(payload []byte) (*warp.Message, error) {
+	uwm, err := warp.NewUnsignedMessage(vectorNetworkID, cli.VectorID(5), payload)
+	if err != nil {
+		return nil, err
+	}
+	return warp.NewMessage(uwm, &warp.BitSetSignature{})
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/rpc"
+	hutils "github.com/ava-labs/hypersdk/utils"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/version"
+)
+
+var versionCmd = &cobra.Command{
+	Use: "version",
+	RunE: func(*cobra.Command, []string) error {
+		hutils.Outf(
+			"{{yellow}}token-cli:{{/}} %s {{yellow}}commit:{{/}} %s {{yellow}}built:{{/}} %s\n",
+			version.Version, version.Commit, version.BuildTime,
+		)
+
+		// Display the version of the default chain (if one is set)
+		chainID, uris, err := handler.Root().GetDefaultChain()
+		if errors.Is(err, cli.ErrNoChains) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		rcli := rpc.NewJSONRPCClient(uris[0])
+		networkID, _, _, err := rcli.Network(context.TODO())
+		if err != nil {
+			return err
+		}
+		tcli := trpc.NewJSONRPCClient(uris[0], networkID, chainID)
+		v, commit, buildTime, err := tcli.Version(context.TODO())
+		if err != nil {
+			return err
+		}
+		hutils.Outf(
+			"{{yellow}}%s (%s):{{/}} %s {{yellow}}commit:{{/}} %s {{yellow}}built:{{/}} %s\n",
+			consts.Name, uris[0], v, commit, buildTime,
+		)
+		return nil
+	},
+}
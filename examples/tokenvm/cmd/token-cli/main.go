@@ -14,7 +14,7 @@ import (
 func main() {
 	if err := cmd.Execute(); err != nil {
 		utils.Outf("{{red}}token-cli exited with error:{{/}} %+v\n", err)
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 	os.Exit(0)
 }
@@ -4,21 +4,21 @@
 package config
 
 import (
-	"encoding/json"
-	"fmt"
 	"strings"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/profiler"
+	"github.com/ava-labs/hypersdk/archive"
 	"github.com/ava-labs/hypersdk/config"
 	hconsts "github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/trace"
 	"github.com/ava-labs/hypersdk/vm"
 
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
-	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/version"
 )
 
@@ -26,40 +26,74 @@ var _ vm.Config = (*Config)(nil)
 
 const (
 	defaultGossipInterval              = 1 * time.Second
+	defaultGossipFlushInterval         = 100 * time.Millisecond
+	defaultGossipFilterInterval        = 2 * time.Second
+	defaultRegossipMinAge              = 10 * hconsts.MillisecondsPerSecond
 	defaultGossipMaxSize               = hconsts.NetworkSizeLimit
+	defaultGossipBatchSize             = 4_096
+	defaultGossipTargetSize            = hconsts.NetworkSizeLimit
+	defaultGossipCompression           = true
+	defaultGossipSuppressionWindow     = 5 * hconsts.MillisecondsPerSecond
 	defaultGossipProposerDiff          = 3
 	defaultGossipProposerDepth         = 2
+	defaultForwardProposerDiff         = 2
+	defaultForwardProposerDepth        = 1
 	defaultBuildProposerDiff           = 2
 	defaultVerifyTimeout               = 10
 	defaultContinuousProfilerFrequency = 1 * time.Minute
 	defaultContinuousProfilerMaxFiles  = 10
+	defaultAccountMetricsInterval      = 5 * time.Minute
 )
 
 type Config struct {
 	*config.Config
 
 	// Gossip
-	GossipInterval      time.Duration `json:"gossipInterval"`
-	GossipMaxSize       int           `json:"gossipMaxSize"`
-	GossipProposerDiff  int           `json:"gossipProposerDiff"`
-	GossipProposerDepth int           `json:"gossipProposerDepth"`
-	BuildProposerDiff   int           `json:"buildProposerDiff"`
-	VerifyTimeout       int64         `json:"verifyTimeout"`
+	GossipPolicy            string        `json:"gossipPolicy"` // "proposers" or "all"
+	GossipInterval          time.Duration `json:"gossipInterval"`
+	GossipFlushInterval     time.Duration `json:"gossipFlushInterval"`
+	GossipFilterInterval    time.Duration `json:"gossipFilterInterval"` // 0 disables filter exchange
+	GossipMaxSize           int           `json:"gossipMaxSize"`
+	GossipBatchSize         int           `json:"gossipBatchSize"`
+	GossipTargetSize        int           `json:"gossipTargetSize"`
+	GossipCompression       bool          `json:"gossipCompression"`       // zstd-compress gossip messages
+	GossipSuppressionWindow int64         `json:"gossipSuppressionWindow"` // ms
+	GossipProposerDiff      int           `json:"gossipProposerDiff"`
+	GossipProposerDepth     int           `json:"gossipProposerDepth"`
+	ForwardProposerDiff     int           `json:"forwardProposerDiff"` // 0 disables forwarding
+	ForwardProposerDepth    int           `json:"forwardProposerDepth"`
+	BuildProposerDiff       int           `json:"buildProposerDiff"`
+	VerifyTimeout           int64         `json:"verifyTimeout"`
+
+	// Regossip of stale txs
+	RegossipInterval time.Duration `json:"regossipInterval"` // 0 disables regossip
+	RegossipMinAge   int64         `json:"regossipMinAge"`   // ms
 
 	// Tracing
-	TraceEnabled    bool    `json:"traceEnabled"`
-	TraceSampleRate float64 `json:"traceSampleRate"`
+	TraceEnabled     bool               `json:"traceEnabled"`
+	TraceSampleRate  float64            `json:"traceSampleRate"`
+	TraceSampleRates map[string]float64 `json:"traceSampleRates"` // span family => rate
 
 	// Profiling
-	ContinuousProfilerDir string `json:"continuousProfilerDir"` // "*" is replaced with rand int
+	ContinuousProfilerDir  string `json:"continuousProfilerDir"`  // "*" is replaced with rand int
+	ExecutionProfileBlocks int    `json:"executionProfileBlocks"` // 0 disables the admin API
 
 	// Streaming settings
-	StreamingBacklogSize int `json:"streamingBacklogSize"`
+	StreamingBacklogSize                   int `json:"streamingBacklogSize"`
+	StreamingMaxConnections                int `json:"streamingMaxConnections"`      // 0 is unlimited
+	StreamingMaxConnectionsPerIP           int `json:"streamingMaxConnectionsPerIP"` // 0 is unlimited
+	StreamingMaxSubscriptionsPerConnection int `json:"streamingMaxSubscriptionsPerConnection"`
+	StreamingMaxSubscriptions              int `json:"streamingMaxSubscriptions"`
 
 	// Mempool
-	MempoolSize         int      `json:"mempoolSize"`
-	MempoolPayerSize    int      `json:"mempoolPayerSize"`
-	MempoolExemptPayers []string `json:"mempoolExemptPayers"`
+	MempoolSize           int           `json:"mempoolSize"`
+	MempoolMaxBytes       int           `json:"mempoolMaxBytes"` // 0 is unlimited
+	MempoolPayerSize      int           `json:"mempoolPayerSize"`
+	MempoolExemptPayers   []string      `json:"mempoolExemptPayers"`
+	MempoolSweepInterval  time.Duration `json:"mempoolSweepInterval"` // 0 disables sweeping
+	MempoolSweepBatchSize int           `json:"mempoolSweepBatchSize"`
+	MempoolExpiryInterval time.Duration `json:"mempoolExpiryInterval"` // 0 only purges expired txs on accept
+	MempoolJournal        bool          `json:"mempoolJournal"`        // persist the mempool across restarts
 
 	// Order Book
 	//
@@ -68,70 +102,157 @@ type Config struct {
 	// TODO: add ability to denote min rate/min amount for tracking to avoid spam
 	TrackedPairs []string `json:"trackedPairs"` // which asset ID pairs we care about
 
+	// Account Metrics
+	//
+	// Counting active accounts requires iterating over all balances, so this
+	// should not be done too frequently on large chains.
+	AccountMetricsInterval time.Duration `json:"accountMetricsInterval"` // 0 disables
+
 	// Misc
 	VerifySignatures bool          `json:"verifySignatures"`
 	TestMode         bool          `json:"testMode"` // makes gossip/building manual
 	LogLevel         logging.Level `json:"logLevel"`
 	Parallelism      int           `json:"parallelism"`
+	MinParallelism   int           `json:"minParallelism"`
+	AcceptorWorkers  int           `json:"acceptorWorkers"` // processes side effects of accepted blocks
+
+	// Build Prefetch
+	//
+	// Nodes with fast local disks need less concurrency (and can use larger
+	// batches) than nodes with network-attached storage.
+	BuildPrefetchConcurrency int `json:"buildPrefetchConcurrency"` // 0 disables
+	BuildPrefetchBatchSize   int `json:"buildPrefetchBatchSize"`
+
+	// Parallel Execution
+	//
+	// Txs that don't declare any of the same state keys are executed
+	// concurrently when building and verifying blocks.
+	ExecutionWorkers        int `json:"executionWorkers"` // 1 executes txs sequentially
+	ExecutionMaxParallelism int `json:"executionMaxParallelism"`
 
 	// State Sync
 	StateSyncServerDelay time.Duration `json:"stateSyncServerDelay"` // for testing
+	StateSyncMinBlocks   uint64        `json:"stateSyncMinBlocks"`
+
+	// Block Retention
+	AcceptedBlockWindow uint64 `json:"acceptedBlockWindow"` // 0 retains all blocks
+	ConsumerRetention   uint64 `json:"consumerRetention"`   // consumers further behind are expired
+
+	// State Diffs (pruned with blocks)
+	StateDiffs bool `json:"stateDiffs"` // persist and stream the state diff of each block
+
+	// Load Shedding
+	OverloadProcessingBlocks    int `json:"overloadProcessingBlocks"`    // 0 disables
+	OverloadVerificationBacklog int `json:"overloadVerificationBacklog"` // 0 disables
+
+	// Warm Up
+	WarmUpPeriod time.Duration `json:"warmUpPeriod"` // 0 disables
+
+	// Archival
+	ArchiveLocation string            `json:"archiveLocation"` // file://<dir> or http(s)://<bucket url>
+	ArchiveHeaders  map[string]string `json:"archiveHeaders"`
+	ArchiveBacklog  int               `json:"archiveBacklog"`
+
+	// Additional RPC Listeners (like unix sockets for co-located indexers)
+	RPCListeners []*rpc.ListenerConfig `json:"rpcListeners"`
+
+	// Metrics Push (for nodes that can't be scraped)
+	MetricsPushURL      string        `json:"metricsPushURL"` // "" disables
+	MetricsPushInterval time.Duration `json:"metricsPushInterval"`
 
 	nodeID             ids.NodeID
 	parsedExemptPayers [][]byte
+	parsedGossipPolicy gossiper.GossipPolicy
 }
 
 func New(nodeID ids.NodeID, b []byte) (*Config, error) {
-	c := &Config{nodeID: nodeID}
-	c.setDefault()
-	if len(b) > 0 {
-		if err := json.Unmarshal(b, c); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal config %s: %w", string(b), err)
-		}
-	}
-
-	// Parse any exempt payers (usually used when a single account is
-	// broadcasting many txs at once)
-	c.parsedExemptPayers = make([][]byte, len(c.MempoolExemptPayers))
-	for i, payer := range c.MempoolExemptPayers {
-		p, err := utils.ParseAddress(payer)
-		if err != nil {
-			return nil, err
-		}
-		c.parsedExemptPayers[i] = p[:]
+	// All problems with the config are reported at once (rather than just the
+	// first) to avoid repeatedly restarting a node to find them.
+	c, issues := load(b)
+	if err := issues.Err(); err != nil {
+		return nil, err
 	}
+	c.nodeID = nodeID
 	return c, nil
 }
 
 func (c *Config) setDefault() {
 	c.LogLevel = c.Config.GetLogLevel()
+	c.GossipPolicy = gossiper.GossipToProposers.String()
 	c.GossipInterval = defaultGossipInterval
+	c.GossipFlushInterval = defaultGossipFlushInterval
+	c.GossipFilterInterval = defaultGossipFilterInterval
 	c.GossipMaxSize = defaultGossipMaxSize
+	c.GossipBatchSize = defaultGossipBatchSize
+	c.GossipTargetSize = defaultGossipTargetSize
+	c.GossipCompression = defaultGossipCompression
+	c.GossipSuppressionWindow = defaultGossipSuppressionWindow
 	c.GossipProposerDiff = defaultGossipProposerDiff
 	c.GossipProposerDepth = defaultGossipProposerDepth
+	c.ForwardProposerDiff = defaultForwardProposerDiff
+	c.ForwardProposerDepth = defaultForwardProposerDepth
 	c.BuildProposerDiff = defaultBuildProposerDiff
 	c.VerifyTimeout = defaultVerifyTimeout
+	c.RegossipMinAge = defaultRegossipMinAge
+	c.AccountMetricsInterval = defaultAccountMetricsInterval
 	c.Parallelism = c.Config.GetParallelism()
+	c.MinParallelism = c.Config.GetMinParallelism()
+	c.BuildPrefetchConcurrency = c.Config.GetBuildPrefetchConcurrency()
+	c.BuildPrefetchBatchSize = c.Config.GetBuildPrefetchBatchSize()
+	c.ExecutionWorkers = c.Config.GetExecutionWorkers()
+	c.ExecutionMaxParallelism = c.Config.GetExecutionMaxParallelism()
+	c.AcceptorWorkers = c.Config.GetAcceptorWorkers()
 	c.MempoolSize = c.Config.GetMempoolSize()
+	c.MempoolMaxBytes = c.Config.GetMempoolMaxBytes()
 	c.MempoolPayerSize = c.Config.GetMempoolPayerSize()
+	c.MempoolSweepInterval = c.Config.GetMempoolSweepInterval()
+	c.MempoolSweepBatchSize = c.Config.GetMempoolSweepBatchSize()
+	c.MempoolExpiryInterval = c.Config.GetMempoolExpiryInterval()
+	c.MempoolJournal = c.Config.GetMempoolJournal()
 	c.StateSyncServerDelay = c.Config.GetStateSyncServerDelay()
+	c.StateSyncMinBlocks = c.Config.GetStateSyncMinBlocks()
 	c.StreamingBacklogSize = c.Config.GetStreamingBacklogSize()
+	c.StreamingMaxConnections = c.Config.GetStreamingMaxConnections()
+	c.StreamingMaxConnectionsPerIP = c.Config.GetStreamingMaxConnectionsPerIP()
+	c.StreamingMaxSubscriptionsPerConnection = c.Config.GetStreamingMaxSubscriptionsPerConnection()
+	c.StreamingMaxSubscriptions = c.Config.GetStreamingMaxSubscriptions()
 	c.VerifySignatures = c.Config.GetVerifySignatures()
+	c.AcceptedBlockWindow = c.Config.GetAcceptedBlockWindow()
+	c.ConsumerRetention = c.Config.GetConsumerRetention()
+	c.StateDiffs = c.Config.GetStateDiffs()
+	c.ExecutionProfileBlocks = c.Config.GetExecutionProfileBlocks()
+	c.OverloadProcessingBlocks = c.Config.GetOverloadProcessingBlocks()
+	c.OverloadVerificationBacklog = c.Config.GetOverloadVerificationBacklog()
+	c.WarmUpPeriod = c.Config.GetWarmUpPeriod()
+	c.MetricsPushURL = c.Config.GetMetricsPushURL()
+	c.RPCListeners = c.Config.GetRPCListeners()
+	c.MetricsPushInterval = c.Config.GetMetricsPushInterval()
 }
 
 func (c *Config) GetLogLevel() logging.Level       { return c.LogLevel }
 func (c *Config) GetTestMode() bool                { return c.TestMode }
 func (c *Config) GetParallelism() int              { return c.Parallelism }
+func (c *Config) GetMinParallelism() int           { return c.MinParallelism }
+func (c *Config) GetBuildPrefetchConcurrency() int { return c.BuildPrefetchConcurrency }
+func (c *Config) GetBuildPrefetchBatchSize() int   { return c.BuildPrefetchBatchSize }
+func (c *Config) GetExecutionWorkers() int         { return c.ExecutionWorkers }
+func (c *Config) GetExecutionMaxParallelism() int  { return c.ExecutionMaxParallelism }
+func (c *Config) GetAcceptorWorkers() int          { return c.AcceptorWorkers }
 func (c *Config) GetMempoolSize() int              { return c.MempoolSize }
+func (c *Config) GetMempoolMaxBytes() int          { return c.MempoolMaxBytes }
 func (c *Config) GetMempoolPayerSize() int         { return c.MempoolPayerSize }
 func (c *Config) GetMempoolExemptPayers() [][]byte { return c.parsedExemptPayers }
+func (c *Config) GetGossipPolicy() gossiper.GossipPolicy {
+	return c.parsedGossipPolicy
+}
 func (c *Config) GetTraceConfig() *trace.Config {
 	return &trace.Config{
-		Enabled:         c.TraceEnabled,
-		TraceSampleRate: c.TraceSampleRate,
-		AppName:         consts.Name,
-		Agent:           c.nodeID.String(),
-		Version:         version.Version.String(),
+		Enabled:          c.TraceEnabled,
+		TraceSampleRate:  c.TraceSampleRate,
+		TraceSampleRates: c.TraceSampleRates,
+		AppName:          consts.Name,
+		Agent:            c.nodeID.String(),
+		Version:          version.Version.String(),
 	}
 }
 func (c *Config) GetStateSyncServerDelay() time.Duration { return c.StateSyncServerDelay }
@@ -150,4 +271,40 @@ func (c *Config) GetContinuousProfilerConfig() *profiler.Config {
 		MaxNumFiles: defaultContinuousProfilerMaxFiles,
 	}
 }
-func (c *Config) GetVerifySignatures() bool { return c.VerifySignatures }
+func (c *Config) GetVerifySignatures() bool               { return c.VerifySignatures }
+func (c *Config) GetMempoolSweepInterval() time.Duration  { return c.MempoolSweepInterval }
+func (c *Config) GetMempoolSweepBatchSize() int           { return c.MempoolSweepBatchSize }
+func (c *Config) GetMempoolExpiryInterval() time.Duration { return c.MempoolExpiryInterval }
+func (c *Config) GetMempoolJournal() bool                 { return c.MempoolJournal }
+func (c *Config) GetAcceptedBlockWindow() uint64          { return c.AcceptedBlockWindow }
+func (c *Config) GetConsumerRetention() uint64            { return c.ConsumerRetention }
+func (c *Config) GetStateDiffs() bool                     { return c.StateDiffs }
+func (c *Config) GetArchiveConfig() *archive.Config {
+	if len(c.ArchiveLocation) == 0 {
+		return &archive.Config{Enabled: false}
+	}
+	return &archive.Config{
+		Enabled:  true,
+		Location: c.ArchiveLocation,
+		Headers:  c.ArchiveHeaders,
+		Backlog:  c.ArchiveBacklog,
+	}
+}
+func (c *Config) GetRPCListeners() []*rpc.ListenerConfig {
+	return c.RPCListeners
+}
+func (c *Config) GetStreamingMaxConnections() int      { return c.StreamingMaxConnections }
+func (c *Config) GetStreamingMaxConnectionsPerIP() int { return c.StreamingMaxConnectionsPerIP }
+func (c *Config) GetStreamingMaxSubscriptionsPerConnection() int {
+	return c.StreamingMaxSubscriptionsPerConnection
+}
+func (c *Config) GetStreamingMaxSubscriptions() int   { return c.StreamingMaxSubscriptions }
+func (c *Config) GetExecutionProfileBlocks() int      { return c.ExecutionProfileBlocks }
+func (c *Config) GetStateSyncMinBlocks() uint64       { return c.StateSyncMinBlocks }
+func (c *Config) GetOverloadProcessingBlocks() int    { return c.OverloadProcessingBlocks }
+func (c *Config) GetOverloadVerificationBacklog() int { return c.OverloadVerificationBacklog }
+func (c *Config) GetWarmUpPeriod() time.Duration      { return c.WarmUpPeriod }
+func (c *Config) GetMetricsPushURL() string           { return c.MetricsPushURL }
+func (c *Config) GetMetricsPushInterval() time.Duration {
+	return c.MetricsPushInterval
+}
@@ -0,0 +1,214 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/config"
+	"github.com/ava-labs/hypersdk/gossiper"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
+)
+
+// trackAllPairs must be the only tracked pair if provided.
+const trackAllPairs = "*"
+
+// Validate returns every problem with the config [b] (it can be used if none of
+// them are errors).
+func Validate(b []byte) config.Issues {
+	_, issues := load(b)
+	return issues
+}
+
+func load(b []byte) (*Config, config.Issues) {
+	c := &Config{}
+	c.setDefault()
+	issues := config.Decode(b, c)
+	c.verify(&issues)
+	return c, issues
+}
+
+// verify checks that the values of [c] can be used together (and parses any
+// exempt payers).
+func (c *Config) verify(issues *config.Issues) {
+	d := &Config{}
+	d.setDefault()
+
+	// Gossip
+	policy, err := gossiper.ParseGossipPolicy(c.GossipPolicy)
+	if err != nil {
+		issues.Add("gossipPolicy", err.Error(), d.GossipPolicy)
+	}
+	c.parsedGossipPolicy = policy
+	if c.GossipInterval <= 0 {
+		issues.Add("gossipInterval", "must be positive", strconv.FormatInt(int64(d.GossipInterval), 10))
+	}
+	if c.GossipFlushInterval <= 0 {
+		issues.Add("gossipFlushInterval", "must be positive", strconv.FormatInt(int64(d.GossipFlushInterval), 10))
+	}
+	if c.GossipFilterInterval < 0 {
+		issues.Add("gossipFilterInterval", "must not be negative (0 disables filter exchange)", strconv.FormatInt(int64(d.GossipFilterInterval), 10))
+	}
+	if c.RegossipInterval < 0 {
+		issues.Add("regossipInterval", "must not be negative (0 disables regossip)", strconv.FormatInt(int64(d.RegossipInterval), 10))
+	}
+	if c.RegossipMinAge < 0 {
+		issues.Add("regossipMinAge", "must not be negative", strconv.FormatInt(d.RegossipMinAge, 10))
+	}
+	if c.GossipBatchSize <= 0 {
+		issues.Add("gossipBatchSize", "must be positive", strconv.Itoa(d.GossipBatchSize))
+	}
+	if c.GossipMaxSize <= 0 {
+		issues.Add("gossipMaxSize", "must be positive", strconv.Itoa(d.GossipMaxSize))
+	} else if c.GossipTargetSize > c.GossipMaxSize {
+		issues.Warn("gossipTargetSize", fmt.Sprintf("exceeds gossipMaxSize (%d)", c.GossipMaxSize), strconv.Itoa(c.GossipMaxSize))
+	}
+
+	// Tracing
+	if c.TraceSampleRate < 0 || c.TraceSampleRate > 1 {
+		issues.Add("traceSampleRate", "must be between 0 and 1", "1")
+	}
+	families := make([]string, 0, len(c.TraceSampleRates))
+	for family := range c.TraceSampleRates {
+		families = append(families, family)
+	}
+	sort.Strings(families)
+	for _, family := range families {
+		path := fmt.Sprintf("traceSampleRates[%q]", family)
+		if len(family) == 0 {
+			issues.Add(path, "span family must not be empty", "")
+			continue
+		}
+		if rate := c.TraceSampleRates[family]; rate < 0 || rate > 1 {
+			issues.Add(path, "must be between 0 and 1", strconv.FormatFloat(c.TraceSampleRate, 'g', -1, 64))
+		}
+	}
+
+	// Streaming
+	if c.StreamingMaxConnections > 0 && c.StreamingMaxConnectionsPerIP > c.StreamingMaxConnections {
+		issues.Warn(
+			"streamingMaxConnectionsPerIP",
+			fmt.Sprintf("exceeds streamingMaxConnections (%d)", c.StreamingMaxConnections),
+			strconv.Itoa(c.StreamingMaxConnections),
+		)
+	}
+
+	// Mempool
+	if c.MempoolSize <= 0 {
+		issues.Add("mempoolSize", "must be positive", strconv.Itoa(d.MempoolSize))
+	}
+	if c.MempoolMaxBytes < 0 {
+		issues.Add("mempoolMaxBytes", "must not be negative (0 is unlimited)", strconv.Itoa(d.MempoolMaxBytes))
+	}
+	switch {
+	case c.MempoolPayerSize <= 0:
+		issues.Add("mempoolPayerSize", "must be positive", strconv.Itoa(d.MempoolPayerSize))
+	case c.MempoolSize > 0 && c.MempoolPayerSize > c.MempoolSize:
+		issues.Warn("mempoolPayerSize", fmt.Sprintf("exceeds mempoolSize (%d)", c.MempoolSize), strconv.Itoa(c.MempoolSize))
+	}
+	if c.MempoolSweepInterval > 0 && c.MempoolSweepBatchSize <= 0 {
+		issues.Add("mempoolSweepBatchSize", "must be positive when sweeping is enabled", strconv.Itoa(d.MempoolSweepBatchSize))
+	}
+	c.parsedExemptPayers = make([][]byte, 0, len(c.MempoolExemptPayers))
+	for i, payer := range c.MempoolExemptPayers {
+		p, err := utils.ParseAddress(payer)
+		if err != nil {
+			issues.Add(fmt.Sprintf("mempoolExemptPayers[%d]", i), fmt.Sprintf("invalid address %q: %v", payer, err), "")
+			continue
+		}
+		c.parsedExemptPayers = append(c.parsedExemptPayers, p[:])
+	}
+
+	// Order Book
+	for i, pair := range c.TrackedPairs {
+		path := fmt.Sprintf("trackedPairs[%d]", i)
+		if pair == trackAllPairs {
+			if len(c.TrackedPairs) != 1 {
+				issues.Warn(path, fmt.Sprintf("%q is only honored when it is the only tracked pair", trackAllPairs), `["*"]`)
+			}
+			continue
+		}
+		in, out, ok := strings.Cut(pair, "-")
+		if !ok {
+			issues.Add(path, fmt.Sprintf("%q is not of the form <asset 1>-<asset 2>", pair), "")
+			continue
+		}
+		if _, err := ids.FromString(in); err != nil {
+			issues.Add(path, fmt.Sprintf("invalid asset %q: %v", in, err), "")
+		}
+		if _, err := ids.FromString(out); err != nil {
+			issues.Add(path, fmt.Sprintf("invalid asset %q: %v", out, err), "")
+		}
+	}
+
+	// Misc
+	if c.Parallelism <= 0 {
+		issues.Add("parallelism", "must be positive", strconv.Itoa(d.Parallelism))
+	} else if c.MinParallelism > c.Parallelism {
+		issues.Warn("minParallelism", fmt.Sprintf("exceeds parallelism (%d)", c.Parallelism), strconv.Itoa(c.Parallelism))
+	}
+	if c.MinParallelism <= 0 {
+		issues.Add("minParallelism", "must be positive", strconv.Itoa(d.MinParallelism))
+	}
+	if c.AcceptorWorkers <= 0 {
+		issues.Add("acceptorWorkers", "must be positive", strconv.Itoa(d.AcceptorWorkers))
+	}
+
+	// Build Prefetch
+	if c.BuildPrefetchConcurrency < 0 {
+		issues.Add("buildPrefetchConcurrency", "must not be negative (0 disables prefetching)", strconv.Itoa(d.BuildPrefetchConcurrency))
+	}
+	if c.BuildPrefetchConcurrency > 0 && c.BuildPrefetchBatchSize <= 0 {
+		issues.Add("buildPrefetchBatchSize", "must be positive when prefetching is enabled", strconv.Itoa(d.BuildPrefetchBatchSize))
+	}
+
+	// Parallel Execution
+	if c.ExecutionWorkers <= 0 {
+		issues.Add("executionWorkers", "must be positive (1 executes txs sequentially)", strconv.Itoa(d.ExecutionWorkers))
+	}
+	if c.ExecutionMaxParallelism <= 0 {
+		issues.Add("executionMaxParallelism", "must be positive", strconv.Itoa(d.ExecutionMaxParallelism))
+	}
+
+	// Additional RPC Listeners
+	for i, l := range c.RPCListeners {
+		if err := l.Verify(); err != nil {
+			issues.Add(fmt.Sprintf("rpcListeners[%d]", i), err.Error(), "")
+		}
+	}
+
+	// Archival
+	if len(c.ArchiveLocation) > 0 {
+		u, err := url.Parse(c.ArchiveLocation)
+		switch {
+		case err != nil:
+			issues.Add("archiveLocation", fmt.Sprintf("invalid URL: %v", err), "")
+		case u.Scheme != "file" && u.Scheme != "http" && u.Scheme != "https":
+			issues.Add("archiveLocation", fmt.Sprintf("unsupported scheme %q", u.Scheme), `"file://<dir>"`)
+		}
+		if c.ArchiveBacklog < 0 {
+			issues.Add("archiveBacklog", "must not be negative (0 uses the default)", "0")
+		}
+	}
+
+	// Metrics Push
+	if len(c.MetricsPushURL) > 0 {
+		u, err := url.Parse(c.MetricsPushURL)
+		switch {
+		case err != nil:
+			issues.Add("metricsPushURL", fmt.Sprintf("invalid URL: %v", err), "")
+		case u.Scheme != "http" && u.Scheme != "https":
+			issues.Add("metricsPushURL", fmt.Sprintf("unsupported scheme %q", u.Scheme), "")
+		}
+		if c.MetricsPushInterval <= 0 {
+			issues.Add("metricsPushInterval", "must be positive when pushing metrics", strconv.FormatInt(int64(d.MetricsPushInterval), 10))
+		}
+	}
+}
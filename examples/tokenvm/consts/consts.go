@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
 )
 
 const (
@@ -32,6 +33,7 @@ func init() {
 // Instantiate registry here so it can be imported by any package. We set these
 // values in [controller/registry].
 var (
-	ActionRegistry *codec.TypeParser[chain.Action, *warp.Message, bool]
-	AuthRegistry   *codec.TypeParser[chain.Auth, *warp.Message, bool]
+	ActionRegistry  *codec.TypeParser[chain.Action, *warp.Message, bool]
+	AuthRegistry    *codec.TypeParser[chain.Auth, *warp.Message, bool]
+	AddressRegistry *crypto.AddressRegistry
 )
@@ -0,0 +1,48 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package controller
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+// trackAccounts periodically counts the number of active accounts (and the
+// balances they hold) in state.
+func (c *Controller) trackAccounts() {
+	if c.config.AccountMetricsInterval <= 0 {
+		return
+	}
+	t := time.NewTicker(c.config.AccountMetricsInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-t.C:
+		}
+		state, err := c.inner.State()
+		if err != nil {
+			// State is not available until the node has synced
+			continue
+		}
+		start := time.Now()
+		accounts, balances, err := storage.CountBalances(state)
+		if err != nil {
+			c.snowCtx.Log.Warn("unable to count accounts", zap.Error(err))
+			continue
+		}
+		c.metrics.accountsActive.Set(float64(accounts))
+		c.metrics.balancesActive.Set(float64(balances))
+		c.snowCtx.Log.Debug(
+			"counted accounts",
+			zap.Int("accounts", accounts),
+			zap.Int("balances", balances),
+			zap.Duration("t", time.Since(start)),
+		)
+	}
+}
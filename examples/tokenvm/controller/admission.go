@@ -0,0 +1,53 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package controller
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/vm"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+var _ vm.TxChecker = (*Controller)(nil)
+
+// CheckTx rejects transfers that the actor cannot currently afford, so they
+// don't occupy mempool space only to fail during execution.
+func (*Controller) CheckTx(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	tx *chain.Transaction,
+) error {
+	action, ok := tx.Action.(*actions.Transfer)
+	if !ok {
+		return nil
+	}
+	required := action.Value
+	if action.Asset == ids.Empty {
+		// Fees are also paid with the native asset
+		fee, err := tx.MaxFee(r, tx.Base.MaxUnitPrices)
+		if err != nil {
+			return err
+		}
+		required, err = smath.Add64(required, fee)
+		if err != nil {
+			return err
+		}
+	}
+	bal, err := storage.GetBalance(ctx, db, auth.GetActor(tx.Auth), action.Asset)
+	if err != nil {
+		return err
+	}
+	if bal < required {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
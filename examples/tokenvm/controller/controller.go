@@ -4,11 +4,13 @@
 package controller
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	ametrics "github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/hypersdk/builder"
@@ -30,7 +32,10 @@ import (
 	"github.com/ava-labs/hypersdk/examples/tokenvm/version"
 )
 
-var _ vm.Controller = (*Controller)(nil)
+var (
+	_ vm.Controller       = (*Controller)(nil)
+	_ vm.TxExpiryListener = (*Controller)(nil)
+)
 
 type Controller struct {
 	inner *vm.VM
@@ -45,6 +50,8 @@ type Controller struct {
 	metaDB database.Database
 
 	orderBook *orderbook.OrderBook
+
+	stop chan struct{}
 }
 
 func New() *vm.VM {
@@ -73,6 +80,7 @@ func (c *Controller) Initialize(
 	c.inner = inner
 	c.snowCtx = snowCtx
 	c.stateManager = &StateManager{}
+	c.stop = make(chan struct{})
 
 	// Instantiate metrics
 	var err error
@@ -132,10 +140,21 @@ func (c *Controller) Initialize(
 	} else {
 		build = builder.NewTime(inner)
 		gcfg := gossiper.DefaultProposerConfig()
+		gcfg.GossipPolicy = c.config.GetGossipPolicy()
 		gcfg.GossipInterval = c.config.GossipInterval
+		gcfg.GossipFlushInterval = c.config.GossipFlushInterval
+		gcfg.GossipFilterInterval = c.config.GossipFilterInterval
+		gcfg.RegossipInterval = c.config.RegossipInterval
+		gcfg.RegossipMinAge = c.config.RegossipMinAge
 		gcfg.GossipMaxSize = c.config.GossipMaxSize
+		gcfg.GossipBatchSize = c.config.GossipBatchSize
+		gcfg.GossipTargetSize = c.config.GossipTargetSize
+		gcfg.GossipCompression = c.config.GossipCompression
+		gcfg.GossipSuppressionWindow = c.config.GossipSuppressionWindow
 		gcfg.GossipProposerDiff = c.config.GossipProposerDiff
 		gcfg.GossipProposerDepth = c.config.GossipProposerDepth
+		gcfg.ForwardProposerDiff = c.config.ForwardProposerDiff
+		gcfg.ForwardProposerDepth = c.config.ForwardProposerDepth
 		gcfg.BuildProposerDiff = c.config.BuildProposerDiff
 		gcfg.VerifyTimeout = c.config.VerifyTimeout
 		gossip = gossiper.NewProposer(inner, gcfg)
@@ -143,6 +162,8 @@ func (c *Controller) Initialize(
 
 	// Initialize order book used to track all open orders
 	c.orderBook = orderbook.New(c, c.config.TrackedPairs)
+
+	go c.trackAccounts()
 	return c.config, c.genesis, build, gossip, blockDB, stateDB, apis, consts.ActionRegistry, consts.AuthRegistry, nil
 }
 
@@ -173,6 +194,22 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 		if err != nil {
 			return err
 		}
+		if !result.Success && bytes.Equal(result.Output, actions.OutputOrderMissing) {
+			// The order no longer exists in state (for example, if it was
+			// consumed in a block we didn't process), so it can never be
+			// filled. We drop it so it doesn't linger in the order book.
+			var order ids.ID
+			switch action := tx.Action.(type) {
+			case *actions.FillOrder:
+				order = action.Order
+			case *actions.CloseOrder:
+				order = action.Order
+			}
+			if order != ids.Empty && c.orderBook.Remove(order) {
+				c.metrics.ordersPruned.Inc()
+				c.snowCtx.Log.Info("pruned missing order from order book", zap.Stringer("orderID", order))
+			}
+		}
 		if result.Success {
 			switch action := tx.Action.(type) {
 			case *actions.CreateAsset:
@@ -208,18 +245,51 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 				c.metrics.importAsset.Inc()
 			case *actions.ExportAsset:
 				c.metrics.exportAsset.Inc()
+			case *actions.ClaimRelayFee:
+				c.metrics.claimRelayFee.Inc()
+			case *actions.SettleSwap:
+				c.metrics.settleSwap.Inc()
+			case *actions.RotateKey:
+				c.metrics.rotateKey.Inc()
+			case *actions.SweepBalances:
+				c.metrics.sweepBalances.Inc()
+				sweepResult, err := actions.UnmarshalSweepResult(result.Output)
+				if err != nil {
+					// This should never happen
+					return err
+				}
+				c.metrics.balancesSwept.Add(float64(sweepResult.Swept))
 			}
 		}
 	}
 	return batch.Write()
 }
 
+// TxsExpired records [txIDs] as expired so that clients waiting on them stop
+// polling.
+func (c *Controller) TxsExpired(ctx context.Context, blk *chain.StatelessBlock, txIDs []ids.ID) error {
+	batch := c.metaDB.NewBatch()
+	defer batch.Reset()
+
+	for _, txID := range txIDs {
+		if err := storage.StoreExpiredTransaction(ctx, batch, txID, blk.GetTimestamp()); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
 func (*Controller) Rejected(context.Context, *chain.StatelessBlock) error {
 	return nil
 }
 
-func (*Controller) Shutdown(context.Context) error {
+func (c *Controller) Shutdown(context.Context) error {
+	close(c.stop)
+
 	// Do not close any databases provided during initialization. The VM will
 	// close any databases your provided.
-	return nil
+	//
+	// [metaDB] is only used by the controller, so we must close it here (or it
+	// can't be reopened if the VM is restarted in the same process).
+	return c.metaDB.Close()
 }
@@ -18,12 +18,23 @@ type metrics struct {
 
 	transfer prometheus.Counter
 
-	createOrder prometheus.Counter
-	fillOrder   prometheus.Counter
-	closeOrder  prometheus.Counter
+	createOrder  prometheus.Counter
+	fillOrder    prometheus.Counter
+	closeOrder   prometheus.Counter
+	ordersPruned prometheus.Counter
 
-	importAsset prometheus.Counter
-	exportAsset prometheus.Counter
+	importAsset   prometheus.Counter
+	exportAsset   prometheus.Counter
+	claimRelayFee prometheus.Counter
+	settleSwap    prometheus.Counter
+
+	rotateKey prometheus.Counter
+
+	sweepBalances prometheus.Counter
+	balancesSwept prometheus.Counter
+
+	accountsActive prometheus.Gauge
+	balancesActive prometheus.Gauge
 }
 
 func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
@@ -68,6 +79,11 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 			Name:      "close_order",
 			Help:      "number of close order actions",
 		}),
+		ordersPruned: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "orderbook",
+			Name:      "orders_pruned",
+			Help:      "number of orders dropped from the order book because they no longer exist in state",
+		}),
 		importAsset: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: "actions",
 			Name:      "import_asset",
@@ -78,6 +94,41 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 			Name:      "export_asset",
 			Help:      "number of export asset actions",
 		}),
+		claimRelayFee: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "claim_relay_fee",
+			Help:      "number of claim relay fee actions",
+		}),
+		settleSwap: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "settle_swap",
+			Help:      "number of settle swap actions",
+		}),
+		rotateKey: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "rotate_key",
+			Help:      "number of rotate key actions",
+		}),
+		sweepBalances: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "sweep_balances",
+			Help:      "number of sweep balances actions",
+		}),
+		balancesSwept: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "state",
+			Name:      "balances_swept",
+			Help:      "number of empty balances removed by sweep balances actions",
+		}),
+		accountsActive: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "state",
+			Name:      "accounts_active",
+			Help:      "number of accounts with a balance of any asset",
+		}),
+		balancesActive: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "state",
+			Name:      "balances_active",
+			Help:      "number of balances held by all accounts",
+		}),
 	}
 	r := prometheus.NewRegistry()
 	errs := wrappers.Errs{}
@@ -92,9 +143,20 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 		r.Register(m.createOrder),
 		r.Register(m.fillOrder),
 		r.Register(m.closeOrder),
+		r.Register(m.ordersPruned),
 
 		r.Register(m.importAsset),
 		r.Register(m.exportAsset),
+		r.Register(m.claimRelayFee),
+		r.Register(m.settleSwap),
+
+		r.Register(m.rotateKey),
+
+		r.Register(m.sweepBalances),
+		r.Register(m.balancesSwept),
+
+		r.Register(m.accountsActive),
+		r.Register(m.balancesActive),
 		gatherer.Register(consts.Name, r),
 	)
 	return m, errs.Err
@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
@@ -30,7 +31,7 @@ func (c *Controller) Tracer() trace.Tracer {
 func (c *Controller) GetTransaction(
 	ctx context.Context,
 	txID ids.ID,
-) (bool, int64, bool, uint64, error) {
+) (bool, int64, bool, uint64, bool, error) {
 	return storage.GetTransaction(ctx, c.metaDB, txID)
 }
 
@@ -49,8 +50,53 @@ func (c *Controller) GetBalanceFromState(
 	return storage.GetBalanceFromState(ctx, c.inner.ReadState, pk, asset)
 }
 
-func (c *Controller) Orders(pair string, limit int) []*orderbook.Order {
-	return c.orderBook.Orders(pair, limit)
+func (c *Controller) GetBalancesFromState(
+	ctx context.Context,
+	pks []crypto.PublicKey,
+	assets []ids.ID,
+) ([]uint64, error) {
+	return storage.GetBalancesFromState(ctx, c.inner.ReadState, pks, assets)
+}
+
+// GetBalanceAtHeight returns the balance of [pk] in [asset] after the block at
+// [height] was accepted (which must still be in the state history).
+func (c *Controller) GetBalanceAtHeight(
+	ctx context.Context,
+	pk crypto.PublicKey,
+	asset ids.ID,
+	height uint64,
+) (uint64, error) {
+	read := func(ctx context.Context, keys [][]byte) ([][]byte, []error) {
+		return c.inner.ReadStateAtHeight(ctx, height, keys)
+	}
+	return storage.GetBalanceFromState(ctx, read, pk, asset)
+}
+
+func (c *Controller) AddressBlooms(start uint64, end uint64) ([]uint64, []chain.AddressBloom, error) {
+	return c.inner.AddressBlooms(start, end)
+}
+
+func (c *Controller) GetSequenceFromState(
+	ctx context.Context,
+	pk crypto.PublicKey,
+) (uint64, error) {
+	return storage.GetSequenceFromState(ctx, c.inner.ReadState, pk)
+}
+
+func (c *Controller) Orders(
+	pair string,
+	filter func(*orderbook.Order) bool,
+	cursor string,
+	limit int,
+) ([]*orderbook.Order, string, error) {
+	return c.orderBook.Orders(pair, filter, cursor, limit)
+}
+
+func (c *Controller) GetOrdersFromState(
+	ctx context.Context,
+	orders []ids.ID,
+) ([]*storage.Order, error) {
+	return storage.GetOrdersFromState(ctx, c.inner.ReadState, orders)
 }
 
 func (c *Controller) GetLoanFromState(
@@ -60,3 +106,31 @@ func (c *Controller) GetLoanFromState(
 ) (uint64, error) {
 	return storage.GetLoanFromState(ctx, c.inner.ReadState, asset, destination)
 }
+
+func (c *Controller) GetMinTransferFromState(
+	ctx context.Context,
+	asset ids.ID,
+) (uint64, error) {
+	return storage.GetMinTransferFromState(ctx, c.inner.ReadState, asset)
+}
+
+func (c *Controller) GetImportedAssetFromState(
+	ctx context.Context,
+	asset ids.ID,
+) (bool, ids.ID, ids.ID, uint64, error) {
+	return storage.GetImportedAssetFromState(ctx, c.inner.ReadState, asset)
+}
+
+func (c *Controller) GetRelayEscrowFromState(
+	ctx context.Context,
+	txID ids.ID,
+) (bool, ids.ID, uint64, error) {
+	return storage.GetRelayEscrowFromState(ctx, c.inner.ReadState, txID)
+}
+
+func (c *Controller) GetSwapEscrowFromState(
+	ctx context.Context,
+	txID ids.ID,
+) (*storage.SwapEscrow, error) {
+	return storage.GetSwapEscrowFromState(ctx, c.inner.ReadState, txID)
+}
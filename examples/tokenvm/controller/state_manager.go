@@ -5,9 +5,16 @@ package controller
 
 import (
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 )
 
+var (
+	_ chain.StateManager         = (*StateManager)(nil)
+	_ chain.SequenceStateManager = (*StateManager)(nil)
+)
+
 type StateManager struct{}
 
 func (*StateManager) HeightKey() []byte {
@@ -21,3 +28,7 @@ func (*StateManager) IncomingWarpKey(sourceChainID ids.ID, msgID ids.ID) []byte
 func (*StateManager) OutgoingWarpKey(txID ids.ID) []byte {
 	return storage.OutgoingWarpKeyPrefix(txID)
 }
+
+func (*StateManager) SequenceKey(payer []byte) []byte {
+	return storage.PrefixSequenceKey(crypto.PublicKey(payer))
+}
@@ -5,4 +5,8 @@ package genesis
 
 const (
 	StateLockupField = "state_lockup"
+
+	// RulesVersion must be incremented whenever [Rules] change in a way that
+	// affects which transactions are valid.
+	RulesVersion uint16 = 1
 )
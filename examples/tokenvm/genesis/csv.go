@@ -0,0 +1,98 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
+)
+
+type allocationKey struct {
+	address string
+	asset   ids.ID
+}
+
+// ParseAllocationsCSV reads allocations from [r], where each record is
+// "address,amount[,asset]" (an omitted asset is the native asset). A leading
+// "address,..." header row, blank lines, and lines starting with "#" are
+// ignored.
+//
+// The returned allocations are sorted by asset and then by address so that
+// the same set of records always produces the same genesis. The total
+// allocated for each asset is also returned.
+func ParseAllocationsCSV(r io.Reader) ([]*CustomAllocation, map[ids.ID]uint64, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var (
+		allocs = []*CustomAllocation{}
+		totals = map[ids.ID]uint64{}
+		seen   = map[allocationKey]int{}
+	)
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+			continue
+		}
+		if len(record) < 2 || len(record) > 3 {
+			return nil, nil, fmt.Errorf("%w: line %d has %d fields", ErrInvalidAllocation, line, len(record))
+		}
+		address := strings.TrimSpace(record[0])
+		if _, err := utils.ParseAddress(address); err != nil {
+			return nil, nil, fmt.Errorf("%w: line %d has invalid address %q (%v)", ErrInvalidAllocation, line, address, err) //nolint:errorlint
+		}
+		amount, err := strconv.ParseUint(strings.TrimSpace(record[1]), 10, 64)
+		if err != nil || amount == 0 {
+			return nil, nil, fmt.Errorf("%w: line %d has invalid amount %q", ErrInvalidAllocation, line, record[1])
+		}
+		asset := ids.Empty
+		if len(record) == 3 && len(strings.TrimSpace(record[2])) > 0 {
+			asset, err = ids.FromString(strings.TrimSpace(record[2]))
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: line %d has invalid asset %q (%v)", ErrInvalidAllocation, line, record[2], err) //nolint:errorlint
+			}
+		}
+		k := allocationKey{address, asset}
+		if prev, ok := seen[k]; ok {
+			return nil, nil, fmt.Errorf("%w: line %d duplicates line %d", ErrInvalidAllocation, line, prev)
+		}
+		seen[k] = line
+		totals[asset], err = smath.Add64(totals[asset], amount)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: total for %s overflows on line %d", ErrInvalidAllocation, asset, line)
+		}
+		allocs = append(allocs, &CustomAllocation{
+			Address: address,
+			Balance: amount,
+			Asset:   asset,
+		})
+	}
+	sort.SliceStable(allocs, func(i, j int) bool {
+		if c := bytes.Compare(allocs[i].Asset[:], allocs[j].Asset[:]); c != 0 {
+			return c < 0
+		}
+		return allocs[i].Address < allocs[j].Address
+	})
+	return allocs, totals, nil
+}
@@ -5,7 +5,6 @@ package genesis
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -18,6 +17,7 @@ import (
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/vm"
 )
 
@@ -26,6 +26,14 @@ var _ vm.Genesis = (*Genesis)(nil)
 type CustomAllocation struct {
 	Address string `json:"address"` // bech32 address
 	Balance uint64 `json:"balance"`
+	Asset   ids.ID `json:"asset"` // defaults to the native asset
+}
+
+// AuthConfig limits the size of (and charges additional units for) an auth
+// type.
+type AuthConfig struct {
+	MaxSize int    `json:"maxSize"` // 0 means unlimited
+	Units   uint64 `json:"units"`
 }
 
 type Genesis struct {
@@ -35,22 +43,30 @@ type Genesis struct {
 	// Chain Parameters
 	MinBlockGap int64 `json:"minBlockGap"` // ms
 
-	// Chain Fee Parameters
-	MinUnitPrice               uint64 `json:"minUnitPrice"`
-	UnitPriceChangeDenominator uint64 `json:"unitPriceChangeDenominator"`
-	WindowTargetUnits          uint64 `json:"windowTargetUnits"` // 10s
-	MaxBlockUnits              uint64 `json:"maxBlockUnits"`     // must be possible to reach before block too large
+	// Chain Fee Parameters (one entry per [fees.Dimension])
+	MinUnitPrice               fees.Dimensions `json:"minUnitPrice"`
+	UnitPriceChangeDenominator fees.Dimensions `json:"unitPriceChangeDenominator"`
+	WindowTargetUnits          fees.Dimensions `json:"windowTargetUnits"` // 10s
+	MaxBlockUnits              fees.Dimensions `json:"maxBlockUnits"`     // must be possible to reach before block too large
 
 	// Tx Parameters
 	ValidityWindow int64 `json:"validityWindow"` // ms
+	SequenceMode   bool  `json:"sequenceMode"`   // require per-account sequences
+	MaxTxSize      int   `json:"maxTxSize"`      // bytes
 
 	// Tx Fee Parameters
 	BaseUnits          uint64 `json:"baseUnits"`
 	WarpBaseUnits      uint64 `json:"warpBaseUnits"`
 	WarpUnitsPerSigner uint64 `json:"warpUnitsPerSigner"`
 
+	// Auth Parameters (keyed by auth type ID)
+	AuthConfigs map[uint8]*AuthConfig `json:"authConfigs"`
+
 	// Allocations
 	CustomAllocation []*CustomAllocation `json:"customAllocation"`
+
+	// Minimum transfer amounts (keyed by asset)
+	MinTransfers map[ids.ID]uint64 `json:"minTransfers"`
 }
 
 func Default() *Genesis {
@@ -61,13 +77,16 @@ func Default() *Genesis {
 		MinBlockGap: 100,
 
 		// Chain Fee Parameters
-		MinUnitPrice:               1,
-		UnitPriceChangeDenominator: 48,
-		WindowTargetUnits:          20_000_000,
-		MaxBlockUnits:              1_800_000, // 1.8 MiB
+		//
+		// Bandwidth is measured in bytes and storage in keys.
+		MinUnitPrice:               fees.Dimensions{1, 1, 1, 1, 1},
+		UnitPriceChangeDenominator: fees.Dimensions{48, 48, 48, 48, 48},
+		WindowTargetUnits:          fees.Dimensions{20_000_000, 20_000_000, 1_000_000, 1_000_000, 1_000_000},
+		MaxBlockUnits:              fees.Dimensions{1_800_000, 1_800_000, 100_000, 100_000, 100_000}, // 1.8 MiB
 
 		// Tx Parameters
 		ValidityWindow: 60 * hconsts.MillisecondsPerSecond, // ms
+		MaxTxSize:      hconsts.NetworkSizeLimit,
 
 		// Tx Fee Parameters
 		BaseUnits:          48, // timestamp(8) + chainID(32) + unitPrice(8)
@@ -77,14 +96,9 @@ func Default() *Genesis {
 }
 
 func New(b []byte, _ []byte /* upgradeBytes */) (*Genesis, error) {
-	g := Default()
-	if len(b) > 0 {
-		if err := json.Unmarshal(b, g); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal config %s: %w", string(b), err)
-		}
-	}
-	if g.WindowTargetUnits == 0 {
-		return nil, ErrInvalidTarget
+	g, issues := load(b)
+	if err := issues.Err(); err != nil {
+		return nil, err
 	}
 	return g, nil
 }
@@ -97,18 +111,36 @@ func (g *Genesis) Load(ctx context.Context, tracer trace.Tracer, db chain.Databa
 		return ErrInvalidHRP
 	}
 
-	supply := uint64(0)
+	supplies := map[ids.ID]uint64{}
+	assets := []ids.ID{}
 	for _, alloc := range g.CustomAllocation {
 		pk, err := utils.ParseAddress(alloc.Address)
 		if err != nil {
 			return err
 		}
-		supply, err = smath.Add64(supply, alloc.Balance)
+		supply, ok := supplies[alloc.Asset]
+		if !ok && alloc.Asset != ids.Empty {
+			assets = append(assets, alloc.Asset)
+		}
+		supplies[alloc.Asset], err = smath.Add64(supply, alloc.Balance)
 		if err != nil {
 			return err
 		}
-		if err := storage.SetBalance(ctx, db, pk, ids.Empty, alloc.Balance); err != nil {
-			return fmt.Errorf("%w: addr=%s, bal=%d", err, alloc.Address, alloc.Balance)
+		if err := storage.SetBalance(ctx, db, pk, alloc.Asset, alloc.Balance); err != nil {
+			return fmt.Errorf("%w: addr=%s, bal=%d, asset=%s", err, alloc.Address, alloc.Balance, alloc.Asset)
+		}
+	}
+
+	// Assets (other than the native asset) allocated at genesis have no
+	// metadata or owner, so their supply is fixed
+	for _, asset := range assets {
+		if err := storage.SetAsset(ctx, db, asset, nil, supplies[asset], crypto.EmptyPublicKey, false); err != nil {
+			return err
+		}
+	}
+	for asset, min := range g.MinTransfers {
+		if err := storage.SetMinTransfer(ctx, db, asset, min); err != nil {
+			return fmt.Errorf("%w: asset=%s, min=%d", err, asset, min)
 		}
 	}
 	return storage.SetAsset(
@@ -116,7 +148,7 @@ func (g *Genesis) Load(ctx context.Context, tracer trace.Tracer, db chain.Databa
 		db,
 		ids.Empty,
 		[]byte(consts.Symbol),
-		supply,
+		supplies[ids.Empty],
 		crypto.EmptyPublicKey,
 		false,
 	)
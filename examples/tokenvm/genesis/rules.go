@@ -6,9 +6,16 @@ package genesis
 import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
 )
 
-var _ chain.Rules = (*Rules)(nil)
+var (
+	_ chain.Rules          = (*Rules)(nil)
+	_ chain.SequenceRules  = (*Rules)(nil)
+	_ chain.AuthRules      = (*Rules)(nil)
+	_ chain.TxSizeRules    = (*Rules)(nil)
+	_ chain.VersionedRules = (*Rules)(nil)
+)
 
 type Rules struct {
 	g *Genesis
@@ -30,6 +37,10 @@ func (*Rules) GetWarpConfig(ids.ID) (bool, uint64, uint64) {
 	return true, 4, 5
 }
 
+func (*Rules) GetRulesVersion() uint16 {
+	return RulesVersion
+}
+
 func (r *Rules) NetworkID() uint32 {
 	return r.networkID
 }
@@ -54,7 +65,23 @@ func (r *Rules) GetValidityWindow() int64 {
 	return r.g.ValidityWindow
 }
 
-func (r *Rules) GetMaxBlockUnits() uint64 {
+func (r *Rules) GetSequenceMode() bool {
+	return r.g.SequenceMode
+}
+
+func (r *Rules) GetMaxTxSize() int {
+	return r.g.MaxTxSize
+}
+
+func (r *Rules) GetAuthConfig(authType uint8) (bool, int, uint64) {
+	c, ok := r.g.AuthConfigs[authType]
+	if !ok {
+		return false, 0, 0
+	}
+	return true, c.MaxSize, c.Units
+}
+
+func (r *Rules) GetMaxBlockUnits() fees.Dimensions {
 	return r.g.MaxBlockUnits
 }
 
@@ -62,15 +89,15 @@ func (r *Rules) GetBaseUnits() uint64 {
 	return r.g.BaseUnits
 }
 
-func (r *Rules) GetMinUnitPrice() uint64 {
+func (r *Rules) GetMinUnitPrice() fees.Dimensions {
 	return r.g.MinUnitPrice
 }
 
-func (r *Rules) GetUnitPriceChangeDenominator() uint64 {
+func (r *Rules) GetUnitPriceChangeDenominator() fees.Dimensions {
 	return r.g.UnitPriceChangeDenominator
 }
 
-func (r *Rules) GetWindowTargetUnits() uint64 {
+func (r *Rules) GetWindowTargetUnits() fees.Dimensions {
 	return r.g.WindowTargetUnits
 }
 
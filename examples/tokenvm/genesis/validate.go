@@ -0,0 +1,129 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/hypersdk/config"
+	hconsts "github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
+)
+
+// Validate returns every problem with the genesis [b] (it can be used if none
+// of them are errors).
+func Validate(b []byte) config.Issues {
+	_, issues := load(b)
+	return issues
+}
+
+func load(b []byte) (*Genesis, config.Issues) {
+	g := Default()
+	issues := config.Decode(b, g)
+	g.verify(&issues)
+	return g, issues
+}
+
+// verify checks that the values of [g] can be used together (and that every
+// allocation can be loaded).
+func (g *Genesis) verify(issues *config.Issues) {
+	d := Default()
+
+	if g.HRP != consts.HRP {
+		issues.Add("hrp", fmt.Sprintf("%s: %q is not supported by this VM", ErrInvalidHRP, g.HRP), strconv.Quote(consts.HRP))
+	}
+
+	// Chain Parameters
+	if g.MinBlockGap < 0 {
+		issues.Add("minBlockGap", "must not be negative", strconv.FormatInt(d.MinBlockGap, 10))
+	}
+
+	// Chain Fee Parameters
+	for i := 0; i < fees.FeeDimensions; i++ {
+		dimension := fees.Dimension(i)
+		if g.MinUnitPrice[i] == 0 {
+			issues.Warn(fmt.Sprintf("minUnitPrice[%d]", i), fmt.Sprintf("%s can be consumed for free", dimension), strconv.FormatUint(d.MinUnitPrice[i], 10))
+		}
+		if g.UnitPriceChangeDenominator[i] == 0 {
+			issues.Add(fmt.Sprintf("unitPriceChangeDenominator[%d]", i), fmt.Sprintf("must be positive (%s)", dimension), strconv.FormatUint(d.UnitPriceChangeDenominator[i], 10))
+		}
+		if g.WindowTargetUnits[i] == 0 {
+			issues.Add(fmt.Sprintf("windowTargetUnits[%d]", i), fmt.Sprintf("must be positive (%s)", dimension), strconv.FormatUint(d.WindowTargetUnits[i], 10))
+		}
+		if g.MaxBlockUnits[i] == 0 {
+			issues.Add(fmt.Sprintf("maxBlockUnits[%d]", i), fmt.Sprintf("must be positive (%s)", dimension), strconv.FormatUint(d.MaxBlockUnits[i], 10))
+		}
+	}
+	if compute := g.MaxBlockUnits[fees.Compute]; compute > 0 && compute < g.BaseUnits {
+		issues.Add(fmt.Sprintf("maxBlockUnits[%d]", fees.Compute), fmt.Sprintf("is less than baseUnits (%d)", g.BaseUnits), strconv.FormatUint(d.MaxBlockUnits[fees.Compute], 10))
+	}
+
+	// Tx Parameters
+	if g.ValidityWindow <= 0 {
+		issues.Add("validityWindow", "must be positive", strconv.FormatInt(d.ValidityWindow, 10))
+	}
+	switch {
+	case g.MaxTxSize <= 0:
+		issues.Add("maxTxSize", "must be positive", strconv.Itoa(d.MaxTxSize))
+	case g.MaxTxSize > hconsts.NetworkSizeLimit:
+		issues.Add("maxTxSize", fmt.Sprintf("exceeds the network message limit (%d)", hconsts.NetworkSizeLimit), strconv.Itoa(hconsts.NetworkSizeLimit))
+	}
+
+	// Auth Parameters
+	authTypes := make([]uint8, 0, len(g.AuthConfigs))
+	for authType := range g.AuthConfigs {
+		authTypes = append(authTypes, authType)
+	}
+	sort.Slice(authTypes, func(i, j int) bool { return authTypes[i] < authTypes[j] })
+	for _, authType := range authTypes {
+		path := fmt.Sprintf("authConfigs[%d]", authType)
+		ac := g.AuthConfigs[authType]
+		switch {
+		case ac == nil:
+			issues.Add(path, "must not be null", "")
+		case ac.MaxSize < 0:
+			issues.Add(path+".maxSize", "must not be negative (0 is unlimited)", "0")
+		}
+	}
+
+	// Allocations
+	type holding struct {
+		address string
+		asset   ids.ID
+	}
+	seen := map[holding]int{}
+	supplies := map[ids.ID]uint64{}
+	for i, alloc := range g.CustomAllocation {
+		path := fmt.Sprintf("customAllocation[%d]", i)
+		if alloc == nil {
+			issues.Add(path, "must not be null", "")
+			continue
+		}
+		if _, err := utils.ParseAddress(alloc.Address); err != nil {
+			issues.Add(path+".address", fmt.Sprintf("invalid address %q: %v", alloc.Address, err), "")
+		}
+		if alloc.Balance == 0 {
+			issues.Warn(path+".balance", "allocation is empty", "")
+		}
+		h := holding{alloc.Address, alloc.Asset}
+		if prev, ok := seen[h]; ok {
+			issues.Add(path, fmt.Sprintf("duplicates customAllocation[%d]", prev), "")
+			continue
+		}
+		seen[h] = i
+		supply, err := smath.Add64(supplies[alloc.Asset], alloc.Balance)
+		if err != nil {
+			issues.Add(path+".balance", fmt.Sprintf("total supply of %s overflows", alloc.Asset), "")
+			continue
+		}
+		supplies[alloc.Asset] = supply
+	}
+}
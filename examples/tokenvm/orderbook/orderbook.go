@@ -4,13 +4,17 @@
 package orderbook
 
 import (
+	"encoding/binary"
+	"math"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
 	"github.com/ava-labs/hypersdk/heap"
+	"github.com/ava-labs/hypersdk/rpc"
 	"go.uber.org/zap"
 )
 
@@ -94,25 +98,28 @@ func (o *OrderBook) Add(txID ids.ID, actor crypto.PublicKey, action *actions.Cre
 	o.orderToPair[order.ID] = pair
 }
 
-func (o *OrderBook) Remove(id ids.ID) {
+// Remove drops the order with [id] from the book and returns whether it was
+// tracked.
+func (o *OrderBook) Remove(id ids.ID) bool {
 	o.l.Lock()
 	defer o.l.Unlock()
 	pair, ok := o.orderToPair[id]
 	if !ok {
-		return
+		return false
 	}
 	delete(o.orderToPair, id)
 	h, ok := o.orders[pair]
 	if !ok {
 		// This should never happen
-		return
+		return false
 	}
 	entry, ok := h.Get(id) // O(log 1)
 	if !ok {
 		// This should never happen
-		return
+		return false
 	}
 	h.Remove(entry.Index) // O(log N)
+	return true
 }
 
 func (o *OrderBook) UpdateRemaining(id ids.ID, remaining uint64) {
@@ -135,21 +142,35 @@ func (o *OrderBook) UpdateRemaining(id ids.ID, remaining uint64) {
 	entry.Item.Remaining = remaining
 }
 
-func (o *OrderBook) Orders(pair string, limit int) []*Order {
+// orderKey sorts orders by rate (best first) and then by ID.
+func orderKey(order *Order) []byte {
+	k := make([]byte, consts.Uint64Len+consts.IDLen)
+	// The bits of a positive float sort in the same order as the float
+	rate := float64(order.InTick) / float64(order.OutTick)
+	binary.BigEndian.PutUint64(k, ^math.Float64bits(rate))
+	copy(k[consts.Uint64Len:], order.ID[:])
+	return k
+}
+
+// Orders returns at most [limit] orders for [pair] that match [filter] (all
+// orders if nil), starting after [cursor]. It also returns the cursor of the
+// next page.
+func (o *OrderBook) Orders(
+	pair string,
+	filter func(*Order) bool,
+	cursor string,
+	limit int,
+) ([]*Order, string, error) {
 	o.l.RLock()
 	defer o.l.RUnlock()
 	h, ok := o.orders[pair]
 	if !ok {
-		return nil
-	}
-	items := h.Items()
-	arrLen := len(items)
-	if limit < arrLen {
-		arrLen = limit
+		return nil, "", nil
 	}
-	orders := make([]*Order, arrLen)
-	for i := 0; i < arrLen; i++ {
-		orders[i] = items[i].Item
+	entries := h.Items()
+	orders := make([]*Order, len(entries))
+	for i, entry := range entries {
+		orders[i] = entry.Item
 	}
-	return orders
+	return rpc.Page(orders, orderKey, filter, cursor, limit, limit)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
 
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
@@ -18,6 +19,7 @@ import (
 func init() {
 	consts.ActionRegistry = codec.NewTypeParser[chain.Action, *warp.Message]()
 	consts.AuthRegistry = codec.NewTypeParser[chain.Auth, *warp.Message]()
+	consts.AddressRegistry = crypto.NewAddressRegistry()
 
 	errs := &wrappers.Errs{}
 	errs.Add(
@@ -36,10 +38,25 @@ func init() {
 		consts.ActionRegistry.Register(&actions.ImportAsset{}, actions.UnmarshalImportAsset, true),
 		consts.ActionRegistry.Register(&actions.ExportAsset{}, actions.UnmarshalExportAsset, false),
 
+		consts.ActionRegistry.Register(&actions.RotateKey{}, actions.UnmarshalRotateKey, false),
+
+		consts.ActionRegistry.Register(&actions.ClaimRelayFee{}, actions.UnmarshalClaimRelayFee, true),
+		consts.ActionRegistry.Register(&actions.SettleSwap{}, actions.UnmarshalSettleSwap, false),
+
+		consts.ActionRegistry.Register(&actions.SweepBalances{}, actions.UnmarshalSweepBalances, false),
+
 		// When registering new auth, ALWAYS make sure to append at the end.
 		consts.AuthRegistry.Register(&auth.ED25519{}, auth.UnmarshalED25519, false),
+		consts.AuthRegistry.Register(&auth.RotatedED25519{}, auth.UnmarshalRotatedED25519, false),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
 	}
+
+	// Register address derivation for each auth type (using the typeID
+	// assigned above)
+	ed25519ID, _, _, _ := consts.AuthRegistry.LookupType(&auth.ED25519{})
+	if err := consts.AddressRegistry.Register(ed25519ID, "ed25519", crypto.PublicKeyLen); err != nil {
+		panic(err)
+	}
 }
@@ -7,4 +7,14 @@ const (
 	JSONRPCEndpoint = "/tokenapi"
 
 	ordersToSend = 128
+
+	// maxBalanceChangesBlocks limits the number of blocks (each of which may
+	// require a state proof) that can be inspected by a single call to
+	// [JSONRPCServer.BalanceChanges].
+	maxBalanceChangesBlocks = 1_024
+
+	// maxBatchQueries limits the number of balances or orders that can be
+	// requested by a single call to [JSONRPCServer.Balances] or
+	// [JSONRPCServer.OrdersByID].
+	maxBatchQueries = 256
 )
@@ -8,17 +8,33 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 )
 
 type Controller interface {
 	Genesis() *genesis.Genesis
 	Tracer() trace.Tracer
-	GetTransaction(context.Context, ids.ID) (bool, int64, bool, uint64, error)
+	GetTransaction(context.Context, ids.ID) (bool, int64, bool, uint64, bool, error)
 	GetAssetFromState(context.Context, ids.ID) (bool, []byte, uint64, crypto.PublicKey, bool, error)
 	GetBalanceFromState(context.Context, crypto.PublicKey, ids.ID) (uint64, error)
-	Orders(pair string, limit int) []*orderbook.Order
+	GetBalancesFromState(context.Context, []crypto.PublicKey, []ids.ID) ([]uint64, error)
+	GetBalanceAtHeight(context.Context, crypto.PublicKey, ids.ID, uint64) (uint64, error)
+	AddressBlooms(start uint64, end uint64) ([]uint64, []chain.AddressBloom, error)
+	GetSequenceFromState(context.Context, crypto.PublicKey) (uint64, error)
+	Orders(
+		pair string,
+		filter func(*orderbook.Order) bool,
+		cursor string,
+		limit int,
+	) ([]*orderbook.Order, string, error)
+	GetOrdersFromState(context.Context, []ids.ID) ([]*storage.Order, error)
 	GetLoanFromState(context.Context, ids.ID, ids.ID) (uint64, error)
+	GetMinTransferFromState(context.Context, ids.ID) (uint64, error)
+	GetImportedAssetFromState(context.Context, ids.ID) (bool, ids.ID, ids.ID, uint64, error)
+	GetRelayEscrowFromState(context.Context, ids.ID) (bool, ids.ID, uint64, error)
+	GetSwapEscrowFromState(context.Context, ids.ID) (*storage.SwapEscrow, error)
 }
@@ -7,5 +7,11 @@ import "errors"
 
 var (
 	ErrTxNotFound    = errors.New("tx not found")
+	ErrTxExpired     = errors.New("tx expired")
 	ErrAssetNotFound = errors.New("asset not found")
+	ErrNotImported   = errors.New("asset not imported")
+	ErrInvalidRange  = errors.New("invalid range")
+	ErrRangeTooLarge = errors.New("range too large")
+	ErrBlocksPruned  = errors.New("blocks in range have been pruned")
+	ErrBatchTooLarge = errors.New("batch too large")
 )
@@ -54,6 +54,17 @@ func (cli *JSONRPCClient) Genesis(ctx context.Context) (*genesis.Genesis, error)
 	return resp.Genesis, nil
 }
 
+func (cli *JSONRPCClient) Version(ctx context.Context) (string, string, string, error) {
+	resp := new(VersionReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"version",
+		nil,
+		resp,
+	)
+	return resp.Version, resp.Commit, resp.BuildTime, err
+}
+
 func (cli *JSONRPCClient) Tx(ctx context.Context, id ids.ID) (bool, bool, int64, error) {
 	resp := new(TxReply)
 	err := cli.requester.SendRequest(
@@ -70,6 +81,9 @@ func (cli *JSONRPCClient) Tx(ctx context.Context, id ids.ID) (bool, bool, int64,
 	case err != nil:
 		return false, false, -1, err
 	}
+	if resp.Expired {
+		return true, false, resp.Timestamp, ErrTxExpired
+	}
 	return true, resp.Success, resp.Timestamp, nil
 }
 
@@ -111,13 +125,100 @@ func (cli *JSONRPCClient) Balance(ctx context.Context, addr string, asset ids.ID
 	return resp.Amount, err
 }
 
+// Balances returns each of the balances in [balances] (in the same order)
+// using a single request.
+func (cli *JSONRPCClient) Balances(ctx context.Context, balances []*BalanceArgs) ([]uint64, error) {
+	resp := new(BalancesReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"balances",
+		&BalancesArgs{
+			Balances: balances,
+		},
+		resp,
+	)
+	return resp.Amounts, err
+}
+
+// BalanceChanges returns the balance of [addr] in [asset] after the block at
+// [start] and each change made to it by the blocks in (start, end].
+func (cli *JSONRPCClient) BalanceChanges(
+	ctx context.Context,
+	addr string,
+	asset ids.ID,
+	start uint64,
+	end uint64,
+) (uint64, []*BalanceChange, error) {
+	resp := new(BalanceChangesReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"balanceChanges",
+		&BalanceChangesArgs{
+			Address: addr,
+			Asset:   asset,
+			Start:   start,
+			End:     end,
+		},
+		resp,
+	)
+	return resp.Start, resp.Changes, err
+}
+
+func (cli *JSONRPCClient) Sequence(ctx context.Context, addr string) (uint64, error) {
+	resp := new(SequenceReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"sequence",
+		&SequenceArgs{
+			Address: addr,
+		},
+		resp,
+	)
+	return resp.Sequence, err
+}
+
+// Orders returns the first page of orders for [pair] (best rate first).
 func (cli *JSONRPCClient) Orders(ctx context.Context, pair string) ([]*orderbook.Order, error) {
+	orders, _, err := cli.OrdersPage(ctx, pair, "", "", 0)
+	return orders, err
+}
+
+// OrdersPage returns at most [limit] orders for [pair] (only those created by
+// [owner], if provided) after [cursor] and the cursor of the next page ("" if
+// there are no more orders).
+func (cli *JSONRPCClient) OrdersPage(
+	ctx context.Context,
+	pair string,
+	owner string,
+	cursor string,
+	limit int,
+) ([]*orderbook.Order, string, error) {
 	resp := new(OrdersReply)
 	err := cli.requester.SendRequest(
 		ctx,
 		"orders",
 		&OrdersArgs{
-			Pair: pair,
+			PageArgs: rpc.PageArgs{
+				Cursor: cursor,
+				Limit:  limit,
+			},
+			Pair:  pair,
+			Owner: owner,
+		},
+		resp,
+	)
+	return resp.Orders, resp.Next, err
+}
+
+// OrdersByID returns each of [orders] (nil if it no longer exists) using a
+// single request.
+func (cli *JSONRPCClient) OrdersByID(ctx context.Context, orders []ids.ID) ([]*OrderInfo, error) {
+	resp := new(OrdersByIDReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"ordersByID",
+		&OrdersByIDArgs{
+			Orders: orders,
 		},
 		resp,
 	)
@@ -142,6 +243,70 @@ func (cli *JSONRPCClient) Loan(
 	return resp.Amount, err
 }
 
+// MinTransfer returns the smallest amount of [asset] that can be transferred
+// (0 if there is no minimum).
+func (cli *JSONRPCClient) MinTransfer(
+	ctx context.Context,
+	asset ids.ID,
+) (uint64, error) {
+	resp := new(MinTransferReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"minTransfer",
+		&MinTransferArgs{Asset: asset},
+		resp,
+	)
+	return resp.Amount, err
+}
+
+func (cli *JSONRPCClient) ImportedAsset(
+	ctx context.Context,
+	asset ids.ID,
+) (*ImportedAssetReply, error) {
+	resp := new(ImportedAssetReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"importedAsset",
+		&AssetArgs{
+			Asset: asset,
+		},
+		resp,
+	)
+	return resp, err
+}
+
+func (cli *JSONRPCClient) RelayEscrow(
+	ctx context.Context,
+	txID ids.ID,
+) (bool, ids.ID, uint64, error) {
+	resp := new(RelayEscrowReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"relayEscrow",
+		&TxArgs{
+			TxID: txID,
+		},
+		resp,
+	)
+	return resp.Exists, resp.Destination, resp.Fee, err
+}
+
+func (cli *JSONRPCClient) SwapEscrow(
+	ctx context.Context,
+	txID ids.ID,
+) (*SwapEscrowReply, error) {
+	resp := new(SwapEscrowReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"swapEscrow",
+		&TxArgs{
+			TxID: txID,
+		},
+		resp,
+	)
+	return resp, err
+}
+
 func (cli *JSONRPCClient) WaitForBalance(
 	ctx context.Context,
 	addr string,
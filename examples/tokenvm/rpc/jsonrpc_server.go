@@ -8,9 +8,12 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 
+	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/version"
+	"github.com/ava-labs/hypersdk/rpc"
 )
 
 type JSONRPCServer struct {
@@ -30,6 +33,19 @@ func (j *JSONRPCServer) Genesis(_ *http.Request, _ *struct{}, reply *GenesisRepl
 	return nil
 }
 
+type VersionReply struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime"`
+}
+
+func (*JSONRPCServer) Version(_ *http.Request, _ *struct{}, reply *VersionReply) (err error) {
+	reply.Version = version.Version.String()
+	reply.Commit = version.Commit
+	reply.BuildTime = version.BuildTime
+	return nil
+}
+
 type TxArgs struct {
 	TxID ids.ID `json:"txId"`
 }
@@ -38,13 +54,14 @@ type TxReply struct {
 	Timestamp int64  `json:"timestamp"`
 	Success   bool   `json:"success"`
 	Units     uint64 `json:"units"`
+	Expired   bool   `json:"expired"`
 }
 
 func (j *JSONRPCServer) Tx(req *http.Request, args *TxArgs, reply *TxReply) error {
 	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Tx")
 	defer span.End()
 
-	found, t, success, units, err := j.c.GetTransaction(ctx, args.TxID)
+	found, t, success, units, expired, err := j.c.GetTransaction(ctx, args.TxID)
 	if err != nil {
 		return err
 	}
@@ -54,6 +71,7 @@ func (j *JSONRPCServer) Tx(req *http.Request, args *TxArgs, reply *TxReply) erro
 	reply.Timestamp = t
 	reply.Success = success
 	reply.Units = units
+	reply.Expired = expired
 	return nil
 }
 
@@ -111,11 +129,150 @@ func (j *JSONRPCServer) Balance(req *http.Request, args *BalanceArgs, reply *Bal
 	return err
 }
 
+type BalancesArgs struct {
+	Balances []*BalanceArgs `json:"balances"`
+}
+
+type BalancesReply struct {
+	Amounts []uint64 `json:"amounts"` // in the order of [Balances]
+}
+
+// Balances returns each of the requested balances (reading all of them at
+// once) so that wallets don't need to make a request for each address and
+// asset they track. At most [maxBatchQueries] balances can be requested.
+func (j *JSONRPCServer) Balances(req *http.Request, args *BalancesArgs, reply *BalancesReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Balances")
+	defer span.End()
+
+	if len(args.Balances) > maxBatchQueries {
+		return ErrBatchTooLarge
+	}
+	pks := make([]crypto.PublicKey, len(args.Balances))
+	assets := make([]ids.ID, len(args.Balances))
+	for i, balance := range args.Balances {
+		addr, err := utils.ParseAddress(balance.Address)
+		if err != nil {
+			return err
+		}
+		pks[i] = addr
+		assets[i] = balance.Asset
+	}
+	amounts, err := j.c.GetBalancesFromState(ctx, pks, assets)
+	if err != nil {
+		return err
+	}
+	reply.Amounts = amounts
+	return nil
+}
+
+type BalanceChangesArgs struct {
+	Address string `json:"address"`
+	Asset   ids.ID `json:"asset"`
+	Start   uint64 `json:"start"` // height
+	End     uint64 `json:"end"`   // height (inclusive)
+}
+
+type BalanceChange struct {
+	Height uint64 `json:"height"`
+	Before uint64 `json:"before"`
+	After  uint64 `json:"after"`
+}
+
+type BalanceChangesReply struct {
+	// Start is the balance after the block at [Start] was accepted.
+	Start   uint64           `json:"start"`
+	Changes []*BalanceChange `json:"changes"`
+}
+
+// BalanceChanges returns each change to the balance of [Address] in [Asset]
+// made by the blocks in (Start, End] so that accounting tools don't need to
+// replay every block. Both heights must still be in the state history of the
+// node and the blocks between them must not have been pruned.
+func (j *JSONRPCServer) BalanceChanges(
+	req *http.Request,
+	args *BalanceChangesArgs,
+	reply *BalanceChangesReply,
+) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.BalanceChanges")
+	defer span.End()
+
+	if args.End <= args.Start {
+		return ErrInvalidRange
+	}
+	if args.End-args.Start > maxBalanceChangesBlocks {
+		return ErrRangeTooLarge
+	}
+	addr, err := utils.ParseAddress(args.Address)
+	if err != nil {
+		return err
+	}
+	heights, blooms, err := j.c.AddressBlooms(args.Start+1, args.End)
+	if err != nil {
+		return err
+	}
+	if uint64(len(heights)) != args.End-args.Start {
+		return ErrBlocksPruned
+	}
+	balance, err := j.c.GetBalanceAtHeight(ctx, addr, args.Asset, args.Start)
+	if err != nil {
+		return err
+	}
+	reply.Start = balance
+	reply.Changes = []*BalanceChange{}
+	for i, height := range heights {
+		// Blocks that don't touch [addr] can't change its balance
+		if !blooms[i].Contains(addr[:]) {
+			continue
+		}
+		next, err := j.c.GetBalanceAtHeight(ctx, addr, args.Asset, height)
+		if err != nil {
+			return err
+		}
+		if next == balance {
+			continue
+		}
+		reply.Changes = append(reply.Changes, &BalanceChange{Height: height, Before: balance, After: next})
+		balance = next
+	}
+	return nil
+}
+
+type SequenceArgs struct {
+	Address string `json:"address"`
+}
+
+type SequenceReply struct {
+	Sequence uint64 `json:"sequence"`
+}
+
+// Sequence returns the sequence the next transaction paid for by [Address]
+// must use (only required if the chain enables sequence mode).
+func (j *JSONRPCServer) Sequence(req *http.Request, args *SequenceArgs, reply *SequenceReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Sequence")
+	defer span.End()
+
+	addr, err := utils.ParseAddress(args.Address)
+	if err != nil {
+		return err
+	}
+	sequence, err := j.c.GetSequenceFromState(ctx, addr)
+	if err != nil {
+		return err
+	}
+	reply.Sequence = sequence
+	return nil
+}
+
 type OrdersArgs struct {
-	Pair string `json:"pair"`
+	rpc.PageArgs
+
+	Pair  string `json:"pair"`
+	Owner string `json:"owner"` // optional
 }
 
 type OrdersReply struct {
+	rpc.PageReply
+
 	Orders []*orderbook.Order `json:"orders"`
 }
 
@@ -123,7 +280,78 @@ func (j *JSONRPCServer) Orders(req *http.Request, args *OrdersArgs, reply *Order
 	_, span := j.c.Tracer().Start(req.Context(), "Server.Orders")
 	defer span.End()
 
-	reply.Orders = j.c.Orders(args.Pair, ordersToSend)
+	var filter func(*orderbook.Order) bool
+	if len(args.Owner) > 0 {
+		if _, err := utils.ParseAddress(args.Owner); err != nil {
+			return err
+		}
+		filter = func(order *orderbook.Order) bool {
+			return order.Owner == args.Owner
+		}
+	}
+	orders, next, err := j.c.Orders(
+		args.Pair,
+		filter,
+		args.Cursor,
+		rpc.ClampLimit(args.Limit, ordersToSend),
+	)
+	if err != nil {
+		return err
+	}
+	reply.Orders = orders
+	reply.Next = next
+	return nil
+}
+
+type OrdersByIDArgs struct {
+	Orders []ids.ID `json:"orders"`
+}
+
+type OrderInfo struct {
+	ID        ids.ID `json:"id"`
+	In        ids.ID `json:"in"`
+	InTick    uint64 `json:"inTick"`
+	Out       ids.ID `json:"out"`
+	OutTick   uint64 `json:"outTick"`
+	Remaining uint64 `json:"remaining"`
+	Owner     string `json:"owner"`
+}
+
+type OrdersByIDReply struct {
+	// Orders is in the order of [OrdersByIDArgs.Orders] (orders that don't
+	// exist, such as those that were filled or closed, are null)
+	Orders []*OrderInfo `json:"orders"`
+}
+
+// OrdersByID returns each of the requested orders from state (so, unlike
+// [Orders], it is not limited to the pairs tracked by the node). At most
+// [maxBatchQueries] orders can be requested.
+func (j *JSONRPCServer) OrdersByID(req *http.Request, args *OrdersByIDArgs, reply *OrdersByIDReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.OrdersByID")
+	defer span.End()
+
+	if len(args.Orders) > maxBatchQueries {
+		return ErrBatchTooLarge
+	}
+	orders, err := j.c.GetOrdersFromState(ctx, args.Orders)
+	if err != nil {
+		return err
+	}
+	reply.Orders = make([]*OrderInfo, len(orders))
+	for i, order := range orders {
+		if order == nil {
+			continue
+		}
+		reply.Orders[i] = &OrderInfo{
+			ID:        args.Orders[i],
+			In:        order.In,
+			InTick:    order.InTick,
+			Out:       order.Out,
+			OutTick:   order.OutTick,
+			Remaining: order.Remaining,
+			Owner:     utils.Address(order.Owner),
+		}
+	}
 	return nil
 }
 
@@ -147,3 +375,115 @@ func (j *JSONRPCServer) Loan(req *http.Request, args *LoanArgs, reply *LoanReply
 	reply.Amount = amount
 	return nil
 }
+
+type MinTransferArgs struct {
+	Asset ids.ID `json:"asset"`
+}
+
+type MinTransferReply struct {
+	Amount uint64 `json:"amount"`
+}
+
+func (j *JSONRPCServer) MinTransfer(
+	req *http.Request,
+	args *MinTransferArgs,
+	reply *MinTransferReply,
+) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.MinTransfer")
+	defer span.End()
+
+	amount, err := j.c.GetMinTransferFromState(ctx, args.Asset)
+	if err != nil {
+		return err
+	}
+	reply.Amount = amount
+	return nil
+}
+
+type ImportedAssetReply struct {
+	SourceChainID ids.ID `json:"sourceChainId"`
+	OriginalAsset ids.ID `json:"originalAsset"`
+	Bridged       uint64 `json:"bridged"`
+	Supply        uint64 `json:"supply"`
+}
+
+// ImportedAsset returns the provenance of an asset minted by an import (the
+// chain it was exported from and its ID there), the amount currently bridged
+// in (which should match the loan to this chain on [SourceChainID]), and its
+// supply (which may be lower if some of it was burned).
+func (j *JSONRPCServer) ImportedAsset(req *http.Request, args *AssetArgs, reply *ImportedAssetReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.ImportedAsset")
+	defer span.End()
+
+	exists, sourceChainID, originalAsset, bridged, err := j.c.GetImportedAssetFromState(ctx, args.Asset)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotImported
+	}
+	_, _, supply, _, _, err := j.c.GetAssetFromState(ctx, args.Asset)
+	if err != nil {
+		return err
+	}
+	reply.SourceChainID = sourceChainID
+	reply.OriginalAsset = originalAsset
+	reply.Bridged = bridged
+	reply.Supply = supply
+	return nil
+}
+
+type RelayEscrowReply struct {
+	Exists      bool   `json:"exists"`
+	Destination ids.ID `json:"destination"`
+	Fee         uint64 `json:"fee"`
+}
+
+// RelayEscrow returns the relay fee escrowed by the export [TxID] (if it has
+// not been claimed yet).
+func (j *JSONRPCServer) RelayEscrow(req *http.Request, args *TxArgs, reply *RelayEscrowReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.RelayEscrow")
+	defer span.End()
+
+	exists, destination, fee, err := j.c.GetRelayEscrowFromState(ctx, args.TxID)
+	if err != nil {
+		return err
+	}
+	reply.Exists = exists
+	reply.Destination = destination
+	reply.Fee = fee
+	return nil
+}
+
+type SwapEscrowReply struct {
+	Exists     bool   `json:"exists"`
+	To         string `json:"to"`
+	AssetIn    ids.ID `json:"assetIn"`
+	SwapIn     uint64 `json:"swapIn"`
+	AssetOut   ids.ID `json:"assetOut"`
+	SwapOut    uint64 `json:"swapOut"`
+	SwapExpiry int64  `json:"swapExpiry"`
+}
+
+// SwapEscrow returns the swap requested by the export [TxID] that is waiting
+// to be filled (or refunded) with [actions.SettleSwap].
+func (j *JSONRPCServer) SwapEscrow(req *http.Request, args *TxArgs, reply *SwapEscrowReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.SwapEscrow")
+	defer span.End()
+
+	escrow, err := j.c.GetSwapEscrowFromState(ctx, args.TxID)
+	if err != nil {
+		return err
+	}
+	if escrow == nil {
+		return nil
+	}
+	reply.Exists = true
+	reply.To = utils.Address(escrow.To)
+	reply.AssetIn = escrow.AssetIn
+	reply.SwapIn = escrow.SwapIn
+	reply.AssetOut = escrow.AssetOut
+	reply.SwapOut = escrow.SwapOut
+	reply.SwapExpiry = escrow.SwapExpiry
+	return nil
+}
@@ -20,11 +20,16 @@ fi
 # Set default binary directory location
 name="tHBYNu8ikqo4MWMHehC9iKB9mR5tB3DWzbkYmTfe9buWQ5GZ8"
 
+# Embed the current commit in the binaries (see ./scripts/release for
+# reproducible release builds)
+commit=$(git rev-parse HEAD 2>/dev/null || echo unknown)
+ldflags="-X github.com/ava-labs/hypersdk/examples/tokenvm/version.Commit=$commit"
+
 # Build tokenvm, which is run as a subprocess
 mkdir -p ./build
 
 echo "Building tokenvm in ./build/$name"
-go build -o ./build/$name ./cmd/tokenvm
+go build -ldflags "$ldflags" -o ./build/$name ./cmd/tokenvm
 
 echo "Building token-cli in ./build/token-cli"
-go build -o ./build/token-cli ./cmd/token-cli
+go build -ldflags "$ldflags" -o ./build/token-cli ./cmd/token-cli
@@ -0,0 +1,214 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// release builds reproducible tokenvm and token-cli binaries for all
+// supported platforms.
+//
+// Usage (from the tokenvm root):
+//
+//	go run ./scripts/release [--output ./build/release] [--targets linux/amd64,darwin/arm64]
+//
+// Binaries are built with -trimpath, an empty build ID, and a build time
+// derived from the commit timestamp (or SOURCE_DATE_EPOCH, if set) so that
+// building the same commit twice produces identical artifacts. A SHA256SUMS
+// file is written alongside the binaries.
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const versionPkg = "github.com/ava-labs/hypersdk/examples/tokenvm/version"
+
+type binary struct {
+	name string
+	main string
+}
+
+type target struct {
+	goos   string
+	goarch string
+	// cc is the cross-compiler used when building on a different platform
+	// (matches .goreleaser.yml)
+	cc string
+}
+
+func (t *target) String() string {
+	return t.goos + "/" + t.goarch
+}
+
+var (
+	binaries = []*binary{
+		{"tokenvm", "./cmd/tokenvm"},
+		{"token-cli", "./cmd/token-cli"},
+	}
+	targets = []*target{
+		{"linux", "amd64", ""},
+		{"linux", "arm64", "aarch64-linux-gnu-gcc"},
+		{"darwin", "amd64", "o64-clang"},
+		{"darwin", "arm64", "oa64-clang"},
+	}
+)
+
+func main() {
+	output := flag.String("output", filepath.Join("build", "release"), "directory to write artifacts to")
+	only := flag.String("targets", "", "comma-separated list of os/arch targets to build (default: all)")
+	flag.Parse()
+
+	if err := run(*output, *only); err != nil {
+		fmt.Fprintf(os.Stderr, "release failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(output string, only string) error {
+	selected, err := selectTargets(only)
+	if err != nil {
+		return err
+	}
+	commit, err := git("rev-parse", "HEAD")
+	if err != nil {
+		return err
+	}
+	buildTime, err := sourceDate()
+	if err != nil {
+		return err
+	}
+	ldflags := strings.Join([]string{
+		"-s", "-w", "-buildid=",
+		fmt.Sprintf("-X %s.Commit=%s", versionPkg, commit),
+		fmt.Sprintf("-X %s.BuildTime=%s", versionPkg, buildTime.UTC().Format(time.RFC3339)),
+	}, " ")
+
+	if err := os.MkdirAll(output, 0o755); err != nil {
+		return err
+	}
+	artifacts := []string{}
+	for _, t := range selected {
+		dir := filepath.Join(output, t.goos+"_"+t.goarch)
+		for _, b := range binaries {
+			out := filepath.Join(dir, b.name)
+			fmt.Printf("building %s for %s -> %s\n", b.name, t, out)
+			if err := build(t, b, ldflags, out); err != nil {
+				return fmt.Errorf("%w: unable to build %s for %s", err, b.name, t)
+			}
+			artifacts = append(artifacts, out)
+		}
+	}
+	return writeChecksums(output, artifacts)
+}
+
+func selectTargets(only string) ([]*target, error) {
+	if len(only) == 0 {
+		return targets, nil
+	}
+	selected := []*target{}
+	for _, name := range strings.Split(only, ",") {
+		var found *target
+		for _, t := range targets {
+			if t.String() == strings.TrimSpace(name) {
+				found = t
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("unsupported target %q", name)
+		}
+		selected = append(selected, found)
+	}
+	return selected, nil
+}
+
+func build(t *target, b *binary, ldflags string, out string) error {
+	cmd := exec.Command( //nolint:gosec
+		"go", "build",
+		"-trimpath",
+		"-buildvcs=false",
+		"-ldflags", ldflags,
+		"-o", out,
+		b.main,
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(
+		os.Environ(),
+		"GOOS="+t.goos,
+		"GOARCH="+t.goarch,
+		"CGO_ENABLED=1",
+		// Use the portable version of BLST
+		"CGO_CFLAGS=-O -D__BLST_PORTABLE__",
+	)
+	if t.goarch == "amd64" {
+		cmd.Env = append(cmd.Env, "GOAMD64=v1")
+	}
+	if len(t.cc) > 0 && (t.goos != runtime.GOOS || t.goarch != runtime.GOARCH) {
+		cmd.Env = append(cmd.Env, "CC="+t.cc)
+	}
+	return cmd.Run()
+}
+
+func writeChecksums(output string, artifacts []string) error {
+	sort.Strings(artifacts)
+	lines := make([]string, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		f, err := os.Open(artifact)
+		if err != nil {
+			return err
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, f)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(output, artifact)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, fmt.Sprintf("%s  %s", hex.EncodeToString(h.Sum(nil)), filepath.ToSlash(rel)))
+	}
+	path := filepath.Join(output, "SHA256SUMS")
+	fmt.Printf("writing checksums to %s\n", path)
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600)
+}
+
+// sourceDate returns the time used as the build time. We don't use the
+// current time because it would make builds irreproducible.
+func sourceDate() (time.Time, error) {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); len(epoch) > 0 {
+		s, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: invalid SOURCE_DATE_EPOCH", err)
+		}
+		return time.Unix(s, 0), nil
+	}
+	ts, err := git("log", "-1", "--format=%ct")
+	if err != nil {
+		return time.Time{}, err
+	}
+	s, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(s, 0), nil
+}
+
+func git(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("%w: git %s", err, strings.Join(args, " "))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -22,7 +22,7 @@ rm -f integration.coverage.html
 # to install the ginkgo binary (required for test build and run)
 go install -v github.com/onsi/ginkgo/v2/ginkgo@v2.0.0-rc2 || true
 
-# run with 3 embedded VMs
+# run with 3 embedded VMs (set STATE_SYNC_KEYS to state sync a larger chain)
 ACK_GINKGO_RC=true ginkgo \
 run \
 -v \
@@ -33,7 +33,8 @@ run \
 -coverprofile=integration.coverage.out \
 ./tests/integration \
 --vms 3 \
---min-price 1
+--min-price 1 \
+--state-sync-keys ${STATE_SYNC_KEYS:-100000}
 
 # output generate coverage html
 go tool cover -html=integration.coverage.out -o=integration.coverage.html
@@ -4,6 +4,7 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"errors"
@@ -35,8 +36,21 @@ type ReadState func(context.Context, [][]byte) ([][]byte, []error)
 //   -> [txID] => in|out|rate|remaining|owner
 // 0x3/ (loans)
 //   -> [assetID|destination] => amount
-// 0x4/ (hypersdk-incoming warp)
-// 0x5/ (hypersdk-outgoing warp)
+// 0x4/ (hypersdk-height)
+// 0x5/ (hypersdk-incoming warp)
+// 0x6/ (hypersdk-outgoing warp)
+// 0x7/ (account keys)
+//   -> [account] => key
+// 0x8/ (hypersdk-sequences)
+//   -> [account] => next sequence
+// 0x9/ (imported assets)
+//   -> [asset] => sourceChainID|originalAsset|bridged
+// 0xa/ (relay escrows)
+//   -> [txID] => destination|fee
+// 0xb/ (swap escrows)
+//   -> [txID] => to|assetIn|swapIn|assetOut|swapOut|swapExpiry
+// 0xc/ (min transfers)
+//   -> [asset] => min
 
 const (
 	txPrefix = 0x0
@@ -48,17 +62,26 @@ const (
 	heightPrefix       = 0x4
 	incomingWarpPrefix = 0x5
 	outgoingWarpPrefix = 0x6
+	accountKeyPrefix   = 0x7
+	sequencePrefix     = 0x8
+	importedPrefix     = 0x9
+	relayEscrowPrefix  = 0xa
+	swapEscrowPrefix   = 0xb
+	minTransferPrefix  = 0xc
+
+	balanceKeyLen = 1 + crypto.PublicKeyLen + consts.IDLen
 )
 
 var (
 	failureByte = byte(0x0)
 	successByte = byte(0x1)
+	expiredByte = byte(0x2)
 	heightKey   = []byte{heightPrefix}
 
 	// TODO: extend to other types
 	balancePrefixPool = sync.Pool{
 		New: func() any {
-			return make([]byte, 1+crypto.PublicKeyLen+consts.IDLen)
+			return make([]byte, balanceKeyLen)
 		},
 	}
 )
@@ -92,26 +115,39 @@ func StoreTransaction(
 	return db.Put(k, v)
 }
 
+// StoreExpiredTransaction records that [id] expired at [t] without being
+// included in a block.
+func StoreExpiredTransaction(
+	_ context.Context,
+	db database.KeyValueWriter,
+	id ids.ID,
+	t int64,
+) error {
+	k := PrefixTxKey(id)
+	v := make([]byte, consts.Uint64Len+1+consts.Uint64Len)
+	binary.BigEndian.PutUint64(v, uint64(t))
+	v[consts.Uint64Len] = expiredByte
+	return db.Put(k, v)
+}
+
 func GetTransaction(
 	_ context.Context,
 	db database.KeyValueReader,
 	id ids.ID,
-) (bool, int64, bool, uint64, error) {
+) (bool, int64, bool, uint64, bool, error) {
 	k := PrefixTxKey(id)
 	v, err := db.Get(k)
 	if errors.Is(err, database.ErrNotFound) {
-		return false, 0, false, 0, nil
+		return false, 0, false, 0, false, nil
 	}
 	if err != nil {
-		return false, 0, false, 0, err
+		return false, 0, false, 0, false, err
 	}
 	t := int64(binary.BigEndian.Uint64(v))
-	success := true
-	if v[consts.Uint64Len] == failureByte {
-		success = false
-	}
+	success := v[consts.Uint64Len] == successByte
+	expired := v[consts.Uint64Len] == expiredByte
 	units := binary.BigEndian.Uint64(v[consts.Uint64Len+1:])
-	return true, t, success, units, nil
+	return true, t, success, units, expired, nil
 }
 
 // [accountPrefix] + [address] + [asset]
@@ -160,6 +196,33 @@ func GetBalanceFromState(
 	return bal, err
 }
 
+// GetBalancesFromState returns the balance of each of [pks] in the asset at
+// the same index in [assets] (reading all of them at once).
+//
+// Used to serve RPC queries
+func GetBalancesFromState(
+	ctx context.Context,
+	f ReadState,
+	pks []crypto.PublicKey,
+	assets []ids.ID,
+) ([]uint64, error) {
+	keys := make([][]byte, len(pks))
+	for i, pk := range pks {
+		keys[i] = PrefixBalanceKey(pk, assets[i])
+	}
+	values, errs := f(ctx, keys)
+	balances := make([]uint64, len(keys))
+	for i, k := range keys {
+		balancePrefixPool.Put(k)
+		bal, err := innerGetBalance(values[i], errs[i])
+		if err != nil {
+			return nil, err
+		}
+		balances[i] = bal
+	}
+	return balances, nil
+}
+
 func innerGetBalance(
 	v []byte,
 	err error,
@@ -190,6 +253,12 @@ func setBalance(
 	dbKey []byte,
 	balance uint64,
 ) error {
+	if balance == 0 {
+		// If there is no balance, we should delete the record instead of
+		// setting it to 0 (so that empty accounts don't take up space in
+		// state).
+		return db.Remove(ctx, dbKey)
+	}
 	return db.Insert(ctx, dbKey, binary.BigEndian.AppendUint64(nil, balance))
 }
 
@@ -202,6 +271,57 @@ func DeleteBalance(
 	return db.Remove(ctx, PrefixBalanceKey(pk, asset))
 }
 
+// DeleteZeroBalance removes the balance of [asset] held by [pk] if it is
+// stored but empty (which can only occur for balances written before empty
+// balances were deleted during execution). It returns true if a balance was
+// removed.
+func DeleteZeroBalance(
+	ctx context.Context,
+	db chain.Database,
+	pk crypto.PublicKey,
+	asset ids.ID,
+) (bool, error) {
+	k := PrefixBalanceKey(pk, asset)
+	v, err := db.GetValue(ctx, k)
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if binary.BigEndian.Uint64(v) != 0 {
+		return false, nil
+	}
+	return true, db.Remove(ctx, k)
+}
+
+// CountBalances returns the number of accounts that hold a balance of any
+// asset and the total number of balances held by those accounts.
+func CountBalances(db database.Iteratee) (int, int, error) {
+	iter := db.NewIteratorWithPrefix([]byte{balancePrefix})
+	defer iter.Release()
+
+	var (
+		accounts int
+		balances int
+		last     []byte
+	)
+	for iter.Next() {
+		k := iter.Key()
+		if len(k) != balanceKeyLen {
+			continue
+		}
+		balances++
+		owner := k[1 : 1+crypto.PublicKeyLen]
+		if !bytes.Equal(owner, last) {
+			// Keys are sorted, so all balances of an account are adjacent
+			accounts++
+			last = owner
+		}
+	}
+	return accounts, balances, iter.Error()
+}
+
 func AddBalance(
 	ctx context.Context,
 	db chain.Database,
@@ -249,14 +369,31 @@ func SubBalance(
 			amount,
 		)
 	}
-	if nbal == 0 {
-		// If there is no balance left, we should delete the record instead of
-		// setting it to 0.
-		return db.Remove(ctx, dbKey)
-	}
 	return setBalance(ctx, db, dbKey, nbal)
 }
 
+// SubBalanceSweep subtracts [amount] from the balance of [pk] like
+// [SubBalance] but, if the remaining balance would be non-zero and less than
+// [min], subtracts the entire balance instead (so that dust is not left
+// behind). It returns the amount that was actually subtracted.
+func SubBalanceSweep(
+	ctx context.Context,
+	db chain.Database,
+	pk crypto.PublicKey,
+	asset ids.ID,
+	amount uint64,
+	min uint64,
+) (uint64, error) {
+	bal, err := GetBalance(ctx, db, pk, asset)
+	if err != nil {
+		return 0, err
+	}
+	if bal >= amount && bal-amount < min {
+		amount = bal
+	}
+	return amount, SubBalance(ctx, db, pk, asset, amount)
+}
+
 // [assetPrefix] + [address]
 func PrefixAssetKey(asset ids.ID) (k []byte) {
 	k = make([]byte, 1+consts.IDLen)
@@ -362,6 +499,16 @@ func SetOrder(
 	return db.Insert(ctx, k, v)
 }
 
+// Order is an open order (as stored by [SetOrder]).
+type Order struct {
+	In        ids.ID
+	InTick    uint64
+	Out       ids.ID
+	OutTick   uint64
+	Remaining uint64
+	Owner     crypto.PublicKey
+}
+
 func GetOrder(
 	ctx context.Context,
 	db chain.Database,
@@ -377,23 +524,53 @@ func GetOrder(
 	error,
 ) {
 	k := PrefixOrderKey(order)
-	v, err := db.GetValue(ctx, k)
+	o, err := innerGetOrder(db.GetValue(ctx, k))
+	if err != nil || o == nil {
+		return false, ids.Empty, 0, ids.Empty, 0, 0, crypto.EmptyPublicKey, err
+	}
+	return true, o.In, o.InTick, o.Out, o.OutTick, o.Remaining, o.Owner, nil
+}
+
+// GetOrdersFromState returns each of [orders] (or nil, if an order does not
+// exist) reading all of them at once.
+//
+// Used to serve RPC queries
+func GetOrdersFromState(
+	ctx context.Context,
+	f ReadState,
+	orders []ids.ID,
+) ([]*Order, error) {
+	keys := make([][]byte, len(orders))
+	for i, order := range orders {
+		keys[i] = PrefixOrderKey(order)
+	}
+	values, errs := f(ctx, keys)
+	res := make([]*Order, len(keys))
+	for i := range keys {
+		o, err := innerGetOrder(values[i], errs[i])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = o
+	}
+	return res, nil
+}
+
+func innerGetOrder(v []byte, err error) (*Order, error) {
 	if errors.Is(err, database.ErrNotFound) {
-		return false, ids.Empty, 0, ids.Empty, 0, 0, crypto.EmptyPublicKey, nil
+		return nil, nil
 	}
 	if err != nil {
-		return false, ids.Empty, 0, ids.Empty, 0, 0, crypto.EmptyPublicKey, err
+		return nil, err
 	}
-	var in ids.ID
-	copy(in[:], v[:consts.IDLen])
-	inTick := binary.BigEndian.Uint64(v[consts.IDLen:])
-	var out ids.ID
-	copy(out[:], v[consts.IDLen+consts.Uint64Len:consts.IDLen*2+consts.Uint64Len])
-	outTick := binary.BigEndian.Uint64(v[consts.IDLen*2+consts.Uint64Len:])
-	supply := binary.BigEndian.Uint64(v[consts.IDLen*2+consts.Uint64Len*2:])
-	var owner crypto.PublicKey
-	copy(owner[:], v[consts.IDLen*2+consts.Uint64Len*3:])
-	return true, in, inTick, out, outTick, supply, owner, nil
+	var o Order
+	copy(o.In[:], v[:consts.IDLen])
+	o.InTick = binary.BigEndian.Uint64(v[consts.IDLen:])
+	copy(o.Out[:], v[consts.IDLen+consts.Uint64Len:consts.IDLen*2+consts.Uint64Len])
+	o.OutTick = binary.BigEndian.Uint64(v[consts.IDLen*2+consts.Uint64Len:])
+	o.Remaining = binary.BigEndian.Uint64(v[consts.IDLen*2+consts.Uint64Len*2:])
+	copy(o.Owner[:], v[consts.IDLen*2+consts.Uint64Len*3:])
+	return &o, nil
 }
 
 func DeleteOrder(ctx context.Context, db chain.Database, order ids.ID) error {
@@ -506,6 +683,67 @@ func SubLoan(
 	return SetLoan(ctx, db, asset, destination, nloan)
 }
 
+// [accountKeyPrefix] + [account]
+func PrefixAccountKey(account crypto.PublicKey) (k []byte) {
+	k = make([]byte, 1+crypto.PublicKeyLen)
+	k[0] = accountKeyPrefix
+	copy(k[1:], account[:])
+	return
+}
+
+// Used to serve RPC queries
+func GetAccountKeyFromState(
+	ctx context.Context,
+	f ReadState,
+	account crypto.PublicKey,
+) (crypto.PublicKey, bool, error) {
+	values, errs := f(ctx, [][]byte{PrefixAccountKey(account)})
+	return innerGetAccountKey(account, values[0], errs[0])
+}
+
+// GetAccountKey returns the key that controls [account] and whether that key
+// was rotated (if it was not, [account] controls itself).
+func GetAccountKey(
+	ctx context.Context,
+	db chain.Database,
+	account crypto.PublicKey,
+) (crypto.PublicKey, bool, error) {
+	k := PrefixAccountKey(account)
+	v, err := db.GetValue(ctx, k)
+	return innerGetAccountKey(account, v, err)
+}
+
+func innerGetAccountKey(
+	account crypto.PublicKey,
+	v []byte,
+	err error,
+) (crypto.PublicKey, bool, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return account, false, nil
+	}
+	if err != nil {
+		return crypto.EmptyPublicKey, false, err
+	}
+	var key crypto.PublicKey
+	copy(key[:], v)
+	return key, true, nil
+}
+
+// SetAccountKey sets the key that controls [account]. Rotating back to
+// [account] removes the record.
+func SetAccountKey(
+	ctx context.Context,
+	db chain.Database,
+	account crypto.PublicKey,
+	key crypto.PublicKey,
+) error {
+	k := PrefixAccountKey(account)
+	if key == account {
+		return db.Remove(ctx, k)
+	}
+	return db.Insert(ctx, k, key[:])
+}
+
 func HeightKey() (k []byte) {
 	return heightKey
 }
@@ -524,3 +762,341 @@ func OutgoingWarpKeyPrefix(txID ids.ID) (k []byte) {
 	copy(k[1:], txID[:])
 	return k
 }
+
+// [sequencePrefix] + [account]
+func PrefixSequenceKey(account crypto.PublicKey) (k []byte) {
+	k = make([]byte, 1+crypto.PublicKeyLen)
+	k[0] = sequencePrefix
+	copy(k[1:], account[:])
+	return
+}
+
+// Used to serve RPC queries
+func GetSequenceFromState(
+	ctx context.Context,
+	f ReadState,
+	account crypto.PublicKey,
+) (uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixSequenceKey(account)})
+	if errors.Is(errs[0], database.ErrNotFound) {
+		return 0, nil
+	}
+	if errs[0] != nil {
+		return 0, errs[0]
+	}
+	return binary.BigEndian.Uint64(values[0]), nil
+}
+
+// [importedPrefix] + [asset]
+func PrefixImportedAssetKey(asset ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = importedPrefix
+	copy(k[1:], asset[:])
+	return
+}
+
+// Used to serve RPC queries
+func GetImportedAssetFromState(
+	ctx context.Context,
+	f ReadState,
+	asset ids.ID,
+) (bool, ids.ID, ids.ID, uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixImportedAssetKey(asset)})
+	return innerGetImportedAsset(values[0], errs[0])
+}
+
+// GetImportedAsset returns the provenance of an [asset] minted by
+// [actions.ImportAsset] (the chain it was exported from and its ID there) and
+// the amount currently bridged in (imported minus returned).
+func GetImportedAsset(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+) (bool, ids.ID, ids.ID, uint64, error) {
+	v, err := db.GetValue(ctx, PrefixImportedAssetKey(asset))
+	return innerGetImportedAsset(v, err)
+}
+
+func innerGetImportedAsset(v []byte, err error) (bool, ids.ID, ids.ID, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, ids.Empty, ids.Empty, 0, nil
+	}
+	if err != nil {
+		return false, ids.Empty, ids.Empty, 0, err
+	}
+	var sourceChainID, originalAsset ids.ID
+	copy(sourceChainID[:], v[:consts.IDLen])
+	copy(originalAsset[:], v[consts.IDLen:consts.IDLen*2])
+	bridged := binary.BigEndian.Uint64(v[consts.IDLen*2:])
+	return true, sourceChainID, originalAsset, bridged, nil
+}
+
+func SetImportedAsset(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	sourceChainID ids.ID,
+	originalAsset ids.ID,
+	bridged uint64,
+) error {
+	v := make([]byte, consts.IDLen*2+consts.Uint64Len)
+	copy(v, sourceChainID[:])
+	copy(v[consts.IDLen:], originalAsset[:])
+	binary.BigEndian.PutUint64(v[consts.IDLen*2:], bridged)
+	return db.Insert(ctx, PrefixImportedAssetKey(asset), v)
+}
+
+// AddImported records that [amount] of [asset] was bridged in from
+// [sourceChainID].
+func AddImported(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	sourceChainID ids.ID,
+	originalAsset ids.ID,
+	amount uint64,
+) error {
+	_, _, _, bridged, err := GetImportedAsset(ctx, db, asset)
+	if err != nil {
+		return err
+	}
+	nbridged, err := smath.Add64(bridged, amount)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: could not add imported (asset=%s, amount=%d)",
+			ErrInvalidBalance,
+			asset,
+			amount,
+		)
+	}
+	return SetImportedAsset(ctx, db, asset, sourceChainID, originalAsset, nbridged)
+}
+
+// SubImported records that [amount] of [asset] was returned to the chain it
+// was imported from.
+//
+// The record is kept (even if nothing is bridged in anymore) so that the
+// provenance of [asset] can still be looked up.
+func SubImported(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	amount uint64,
+) error {
+	exists, sourceChainID, originalAsset, bridged, err := GetImportedAsset(ctx, db, asset)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		// Assets imported before the registry was added are not tracked
+		return nil
+	}
+	nbridged, err := smath.Sub(bridged, amount)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: could not subtract imported (asset=%s, amount=%d)",
+			ErrInvalidBalance,
+			asset,
+			amount,
+		)
+	}
+	return SetImportedAsset(ctx, db, asset, sourceChainID, originalAsset, nbridged)
+}
+
+// [relayEscrowPrefix] + [txID]
+func PrefixRelayEscrowKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = relayEscrowPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+// Used to serve RPC queries
+func GetRelayEscrowFromState(
+	ctx context.Context,
+	f ReadState,
+	txID ids.ID,
+) (bool, ids.ID, uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixRelayEscrowKey(txID)})
+	return innerGetRelayEscrow(values[0], errs[0])
+}
+
+// GetRelayEscrow returns the destination of the export [txID] and the relay
+// fee (in the native asset) it escrowed.
+func GetRelayEscrow(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+) (bool, ids.ID, uint64, error) {
+	v, err := db.GetValue(ctx, PrefixRelayEscrowKey(txID))
+	return innerGetRelayEscrow(v, err)
+}
+
+func innerGetRelayEscrow(v []byte, err error) (bool, ids.ID, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, ids.Empty, 0, nil
+	}
+	if err != nil {
+		return false, ids.Empty, 0, err
+	}
+	var destination ids.ID
+	copy(destination[:], v[:consts.IDLen])
+	fee := binary.BigEndian.Uint64(v[consts.IDLen:])
+	return true, destination, fee, nil
+}
+
+func SetRelayEscrow(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	destination ids.ID,
+	fee uint64,
+) error {
+	v := make([]byte, consts.IDLen+consts.Uint64Len)
+	copy(v, destination[:])
+	binary.BigEndian.PutUint64(v[consts.IDLen:], fee)
+	return db.Insert(ctx, PrefixRelayEscrowKey(txID), v)
+}
+
+func DeleteRelayEscrow(ctx context.Context, db chain.Database, txID ids.ID) error {
+	return db.Remove(ctx, PrefixRelayEscrowKey(txID))
+}
+
+const swapEscrowLen = crypto.PublicKeyLen + consts.IDLen*2 + consts.Uint64Len*2 + consts.Int64Len
+
+// SwapEscrow holds the [SwapIn] of an imported transfer that was not filled
+// on import until it is filled or expires.
+type SwapEscrow struct {
+	To         crypto.PublicKey
+	AssetIn    ids.ID
+	SwapIn     uint64
+	AssetOut   ids.ID
+	SwapOut    uint64
+	SwapExpiry int64
+}
+
+// [swapEscrowPrefix] + [txID]
+func PrefixSwapEscrowKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = swapEscrowPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+// Used to serve RPC queries
+func GetSwapEscrowFromState(
+	ctx context.Context,
+	f ReadState,
+	txID ids.ID,
+) (*SwapEscrow, error) {
+	values, errs := f(ctx, [][]byte{PrefixSwapEscrowKey(txID)})
+	return innerGetSwapEscrow(values[0], errs[0])
+}
+
+// GetSwapEscrow returns the escrow created when the transfer exported by
+// [txID] was imported without being filled (or nil if there is none).
+func GetSwapEscrow(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+) (*SwapEscrow, error) {
+	v, err := db.GetValue(ctx, PrefixSwapEscrowKey(txID))
+	return innerGetSwapEscrow(v, err)
+}
+
+func innerGetSwapEscrow(v []byte, err error) (*SwapEscrow, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(v) != swapEscrowLen {
+		return nil, ErrInvalidEscrow
+	}
+	var e SwapEscrow
+	copy(e.To[:], v[:crypto.PublicKeyLen])
+	v = v[crypto.PublicKeyLen:]
+	copy(e.AssetIn[:], v[:consts.IDLen])
+	v = v[consts.IDLen:]
+	e.SwapIn = binary.BigEndian.Uint64(v)
+	v = v[consts.Uint64Len:]
+	copy(e.AssetOut[:], v[:consts.IDLen])
+	v = v[consts.IDLen:]
+	e.SwapOut = binary.BigEndian.Uint64(v)
+	e.SwapExpiry = int64(binary.BigEndian.Uint64(v[consts.Uint64Len:]))
+	return &e, nil
+}
+
+func SetSwapEscrow(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	e *SwapEscrow,
+) error {
+	v := make([]byte, 0, swapEscrowLen)
+	v = append(v, e.To[:]...)
+	v = append(v, e.AssetIn[:]...)
+	v = binary.BigEndian.AppendUint64(v, e.SwapIn)
+	v = append(v, e.AssetOut[:]...)
+	v = binary.BigEndian.AppendUint64(v, e.SwapOut)
+	v = binary.BigEndian.AppendUint64(v, uint64(e.SwapExpiry))
+	return db.Insert(ctx, PrefixSwapEscrowKey(txID), v)
+}
+
+func DeleteSwapEscrow(ctx context.Context, db chain.Database, txID ids.ID) error {
+	return db.Remove(ctx, PrefixSwapEscrowKey(txID))
+}
+
+// [minTransferPrefix] + [asset]
+func PrefixMinTransferKey(asset ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = minTransferPrefix
+	copy(k[1:], asset[:])
+	return
+}
+
+// Used to serve RPC queries
+func GetMinTransferFromState(
+	ctx context.Context,
+	f ReadState,
+	asset ids.ID,
+) (uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixMinTransferKey(asset)})
+	return innerGetMinTransfer(values[0], errs[0])
+}
+
+// GetMinTransfer returns the smallest amount of [asset] that can be
+// transferred (0 if there is no minimum).
+func GetMinTransfer(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+) (uint64, error) {
+	v, err := db.GetValue(ctx, PrefixMinTransferKey(asset))
+	return innerGetMinTransfer(v, err)
+}
+
+func innerGetMinTransfer(v []byte, err error) (uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+func SetMinTransfer(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	min uint64,
+) error {
+	k := PrefixMinTransferKey(asset)
+	if min == 0 {
+		// We don't store a record for assets without a minimum
+		return db.Remove(ctx, k)
+	}
+	return db.Insert(ctx, k, binary.BigEndian.AppendUint64(nil, min))
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/crypto"
+)
+
+func TestCountBalances(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	accounts, balances, err := CountBalances(db)
+	require.NoError(err)
+	require.Zero(accounts)
+	require.Zero(balances)
+
+	var (
+		a      = crypto.PublicKey{1}
+		b      = crypto.PublicKey{2}
+		native = ids.Empty
+		asset  = ids.GenerateTestID()
+		value  = binary.BigEndian.AppendUint64(nil, 10)
+	)
+	require.NoError(db.Put(PrefixBalanceKey(a, native), value))
+	require.NoError(db.Put(PrefixBalanceKey(a, asset), value))
+	require.NoError(db.Put(PrefixBalanceKey(b, asset), value))
+
+	// Keys that share the balance prefix but aren't balances are ignored
+	require.NoError(db.Put(PrefixTxKey(ids.GenerateTestID()), value))
+	require.NoError(db.Put(PrefixAssetKey(asset), value))
+
+	accounts, balances, err = CountBalances(db)
+	require.NoError(err)
+	require.Equal(2, accounts)
+	require.Equal(3, balances)
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package integration_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	ginkgo "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	"github.com/ava-labs/hypersdk/pebble"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
+)
+
+// faults are injected into a [syncNetwork] to deterministically test how VMs
+// recover from misbehaving peers and infrastructure.
+type faults struct {
+	l sync.RWMutex
+
+	dropGossip    bool
+	responseDelay time.Duration
+}
+
+// SetDropGossip drops all gossip sent between nodes while [drop] is true.
+func (f *faults) SetDropGossip(drop bool) {
+	f.l.Lock()
+	defer f.l.Unlock()
+
+	f.dropGossip = drop
+}
+
+func (f *faults) DropGossip() bool {
+	f.l.RLock()
+	defer f.l.RUnlock()
+
+	return f.dropGossip
+}
+
+// SetResponseDelay delays the delivery of all app responses (like state sync
+// chunks) by [delay].
+func (f *faults) SetResponseDelay(delay time.Duration) {
+	f.l.Lock()
+	defer f.l.Unlock()
+
+	f.responseDelay = delay
+}
+
+func (f *faults) ResponseDelay() time.Duration {
+	f.l.RLock()
+	defer f.l.RUnlock()
+
+	return f.responseDelay
+}
+
+// crash abruptly stops [node] (its persisted data is kept so that it can be
+// restarted).
+func (n *syncNetwork) crash(node *syncNode) {
+	gomega.Ω(n.stop(node)).Should(gomega.BeNil())
+}
+
+// restart starts a crashed [node] from its persisted data (as if it had
+// already finished syncing).
+func (n *syncNetwork) restart(node *syncNode) error {
+	return n.start(node, true)
+}
+
+// corruptDB overwrites every value in the database [name] (like "blockdb" or
+// "statedb") of a crashed [node] with random bytes of the same length.
+func (*syncNetwork) corruptDB(node *syncNode, name string) {
+	db, _, err := pebble.New(filepath.Join(node.dataDir, name), pebble.NewDefaultConfig())
+	gomega.Ω(err).Should(gomega.BeNil())
+	defer func() {
+		gomega.Ω(db.Close()).Should(gomega.BeNil())
+	}()
+
+	iter := db.NewIterator()
+	keys := [][]byte{}
+	values := [][]byte{}
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+		values = append(values, make([]byte, len(iter.Value())))
+	}
+	gomega.Ω(iter.Error()).Should(gomega.BeNil())
+	iter.Release()
+	gomega.Ω(keys).ShouldNot(gomega.BeEmpty())
+
+	for i, k := range keys {
+		_, err := rand.Read(values[i])
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(db.Put(k, values[i])).Should(gomega.BeNil())
+	}
+}
+
+var _ = ginkgo.Describe("[Fault Injection]", ginkgo.Ordered, func() {
+	var (
+		network *syncNetwork
+		a       *syncNode
+		b       *syncNode
+		nonce   uint64
+	)
+
+	// submitTransfer submits a unique transfer to [node].
+	submitTransfer := func(node *syncNode) ids.ID {
+		parser, err := node.tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		nonce++
+		submit, tx, _, err := node.cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: nonce, // ensures txs are unique
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		return tx.ID()
+	}
+
+	ginkgo.BeforeAll(func() {
+		g := genesis.Default()
+		g.MinBlockGap = 0
+		g.CustomAllocation = []*genesis.CustomAllocation{
+			{
+				Address: sender,
+				Balance: 10_000_000,
+			},
+		}
+		gBytes, err := json.Marshal(g)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		network = &syncNetwork{nodes: map[ids.NodeID]*syncNode{}}
+		chainID := ids.GenerateTestID()
+		subnetID := ids.GenerateTestID()
+		config := `{"parallelism":3, "testMode":true, "logLevel":"info"}`
+		a = network.addNode(chainID, subnetID, gBytes, config, true)
+		b = network.addNode(chainID, subnetID, gBytes, config, true)
+	})
+
+	ginkgo.AfterAll(func() {
+		network.shutdown()
+	})
+
+	ginkgo.It("drops gossip while injected", func() {
+		ctx := context.Background()
+		submitTransfer(a)
+		gomega.Ω(a.vm.Mempool().Len(ctx)).Should(gomega.Equal(1))
+
+		network.faults.SetDropGossip(true)
+		gomega.Ω(a.vm.Gossiper().ForceGossip(ctx)).Should(gomega.BeNil())
+		gomega.Consistently(func() int {
+			return b.vm.Mempool().Len(ctx)
+		}, time.Second, 100*time.Millisecond).Should(gomega.Equal(0))
+
+		network.faults.SetDropGossip(false)
+		gomega.Ω(a.vm.Gossiper().ForceGossip(ctx)).Should(gomega.BeNil())
+		gomega.Eventually(func() int {
+			return b.vm.Mempool().Len(ctx)
+		}, 5*time.Second, 100*time.Millisecond).Should(gomega.Equal(1))
+
+		// Clear the mempools of both nodes
+		blk := expectBlk(a.instance)
+		gomega.Ω(blk()).Should(gomega.HaveLen(1))
+		accepted := a.vm.LastAcceptedBlock()
+		sblk, err := b.vm.ParseBlock(ctx, accepted.Bytes())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(sblk.Verify(ctx)).Should(gomega.BeNil())
+		gomega.Ω(b.vm.SetPreference(ctx, sblk.ID())).Should(gomega.BeNil())
+		gomega.Ω(sblk.Accept(ctx)).Should(gomega.BeNil())
+	})
+
+	ginkgo.It("recovers accepted blocks and state after a crash", func() {
+		submitTransfer(a)
+		results := expectBlk(a.instance)()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		lastAccepted := a.vm.LastAcceptedBlock()
+		balance, err := a.tcli.Balance(context.Background(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		network.crash(a)
+		gomega.Ω(network.restart(a)).Should(gomega.BeNil())
+		gomega.Ω(a.vm.LastAcceptedBlock().ID()).Should(gomega.Equal(lastAccepted.ID()))
+		rbalance, err := a.tcli.Balance(context.Background(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(rbalance).Should(gomega.Equal(balance))
+
+		// The restarted node can continue to produce blocks
+		submitTransfer(a)
+		results = expectBlk(a.instance)()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("refuses to start with a corrupted database", func() {
+		network.crash(b)
+		network.corruptDB(b, "blockdb")
+		gomega.Ω(network.restart(b)).ShouldNot(gomega.BeNil())
+	})
+})
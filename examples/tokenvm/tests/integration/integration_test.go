@@ -37,6 +37,7 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/pubsub"
 	"github.com/ava-labs/hypersdk/rpc"
 	hutils "github.com/ava-labs/hypersdk/utils"
@@ -174,7 +175,9 @@ var _ = ginkgo.BeforeSuite(func() {
 
 	gen = genesis.Default()
 	if minPrice >= 0 {
-		gen.MinUnitPrice = uint64(minPrice)
+		for i := range gen.MinUnitPrice {
+			gen.MinUnitPrice[i] = uint64(minPrice)
+		}
 	}
 	gen.MinBlockGap = 0
 	gen.CustomAllocation = []*genesis.CustomAllocation{
@@ -362,9 +365,9 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			actionRegistry, authRegistry := instances[0].vm.Registry()
 			tx := chain.NewTx(
 				&chain.Base{
-					ChainID:   instances[0].chainID,
-					Timestamp: 0,
-					UnitPrice: 1000,
+					ChainID:       instances[0].chainID,
+					Timestamp:     0,
+					MaxUnitPrices: fees.Dimensions{1000, 1000, 1000, 1000, 1000},
 				},
 				nil,
 				&actions.Transfer{
@@ -424,13 +427,30 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			gomega.Ω(results).Should(gomega.HaveLen(1))
 			gomega.Ω(results[0].Success).Should(gomega.BeTrue())
 			gomega.Ω(results[0].Units).Should(gomega.Equal(uint64(transferTxFee)))
+			gomega.Ω(results[0].Consumed).Should(gomega.Equal(fees.Dimensions{
+				uint64(transferTxRoot.Size()),
+				transferTxFee,
+				6, // declared keys
+				1, // recipient balance
+				1, // sender balance
+			}))
 			gomega.Ω(results[0].Output).Should(gomega.BeNil())
+			gomega.Ω(results[0].Events).Should(gomega.HaveLen(1))
+			gomega.Ω(results[0].Events[0].Topic).Should(gomega.Equal(actions.TransferTopic))
+			event, err := actions.UnmarshalBalanceEvent(results[0].Events[0].Payload)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(event).Should(gomega.Equal(&actions.BalanceEvent{
+				From:  rsender,
+				To:    rsender2,
+				Value: 100_000,
+			}))
 		})
 
 		ginkgo.By("ensure balance is updated", func() {
 			balance, err := instances[1].tcli.Balance(context.Background(), sender, ids.Empty)
 			gomega.Ω(err).To(gomega.BeNil())
-			gomega.Ω(balance).To(gomega.Equal(uint64(9899528)))
+			// All unit prices are 1
+			gomega.Ω(balance).To(gomega.Equal(uint64(9899520) - uint64(transferTxRoot.Size())))
 			balance2, err := instances[1].tcli.Balance(context.Background(), sender2, ids.Empty)
 			gomega.Ω(err).To(gomega.BeNil())
 			gomega.Ω(balance2).To(gomega.Equal(uint64(100000)))
@@ -465,6 +485,27 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		})
 	})
 
+	ginkgo.It("rejects transfer with insufficient balance", func() {
+		balance, err := instances[0].tcli.Balance(context.Background(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: balance, // leaves nothing for fees
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		err = submit(context.Background())
+		gomega.Ω(err).To(gomega.Not(gomega.BeNil()))
+		gomega.Ω(err.Error()).Should(gomega.ContainSubstring("invalid balance"))
+	})
+
 	ginkgo.It("Test processing block handling", func() {
 		var accept, accept2 func() []*chain.Result
 
@@ -660,9 +701,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		g, err := instances[0].tcli.Genesis(context.TODO())
 		gomega.Ω(err).Should(gomega.BeNil())
 		r := g.Rules(time.Now().UnixMilli(), networkID, instances[0].chainID)
-		maxUnits, err := rawTx.MaxUnits(r)
+		maxFee, err := rawTx.MaxFee(r, rawTx.Base.MaxUnitPrices)
 		gomega.Ω(err).Should(gomega.BeNil())
-		gomega.Ω(balance).Should(gomega.Equal(balancea + maxUnits + 1))
+		fee, err := fees.MulSum(results[0].Consumed, blk.UnitPrices)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(results[0].Fee).Should(gomega.Equal(fee))
+		gomega.Ω(fee).Should(gomega.BeNumerically("<=", maxFee))
+		gomega.Ω(balance).Should(gomega.Equal(balancea + fee + 1))
 
 		// Close connection when done
 		gomega.Ω(cli.Close()).Should(gomega.BeNil())
@@ -792,9 +837,9 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		actionRegistry, authRegistry := instances[0].vm.Registry()
 		tx := chain.NewTx(
 			&chain.Base{
-				ChainID:   instances[0].chainID,
-				Timestamp: hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
-				UnitPrice: 1000,
+				ChainID:       instances[0].chainID,
+				Timestamp:     hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
+				MaxUnitPrices: fees.Dimensions{1000, 1000, 1000, 1000, 1000},
 			},
 			nil,
 			&actions.CreateAsset{
@@ -1012,9 +1057,9 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		actionRegistry, authRegistry := instances[0].vm.Registry()
 		tx := chain.NewTx(
 			&chain.Base{
-				ChainID:   instances[0].chainID,
-				Timestamp: hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
-				UnitPrice: 1000,
+				ChainID:       instances[0].chainID,
+				Timestamp:     hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
+				MaxUnitPrices: fees.Dimensions{1000, 1000, 1000, 1000, 1000},
 			},
 			nil,
 			&actions.MintAsset{
@@ -1158,9 +1203,9 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		actionRegistry, authRegistry := instances[0].vm.Registry()
 		tx := chain.NewTx(
 			&chain.Base{
-				ChainID:   instances[0].chainID,
-				Timestamp: hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
-				UnitPrice: 1000,
+				ChainID:       instances[0].chainID,
+				Timestamp:     hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
+				MaxUnitPrices: fees.Dimensions{1000, 1000, 1000, 1000, 1000},
 			},
 			nil,
 			&actions.MintAsset{
@@ -1682,9 +1727,9 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		actionRegistry, authRegistry := instances[0].vm.Registry()
 		tx := chain.NewTx(
 			&chain.Base{
-				ChainID:   instances[0].chainID,
-				Timestamp: hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
-				UnitPrice: 1000,
+				ChainID:       instances[0].chainID,
+				Timestamp:     hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
+				MaxUnitPrices: fees.Dimensions{1000, 1000, 1000, 1000, 1000},
 			},
 			nil,
 			&actions.ImportAsset{},
@@ -1712,9 +1757,9 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		actionRegistry, authRegistry := instances[0].vm.Registry()
 		tx := chain.NewTx(
 			&chain.Base{
-				ChainID:   instances[0].chainID,
-				Timestamp: hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
-				UnitPrice: 1000,
+				ChainID:       instances[0].chainID,
+				Timestamp:     hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
+				MaxUnitPrices: fees.Dimensions{1000, 1000, 1000, 1000, 1000},
 			},
 			wm,
 			&actions.ImportAsset{},
@@ -1744,9 +1789,9 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		actionRegistry, authRegistry := instances[0].vm.Registry()
 		tx := chain.NewTx(
 			&chain.Base{
-				ChainID:   instances[0].chainID,
-				Timestamp: hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
-				UnitPrice: 1000,
+				ChainID:       instances[0].chainID,
+				Timestamp:     hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
+				MaxUnitPrices: fees.Dimensions{1000, 1000, 1000, 1000, 1000},
 			},
 			wm,
 			&actions.ImportAsset{},
@@ -1779,9 +1824,9 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		actionRegistry, authRegistry := instances[0].vm.Registry()
 		tx := chain.NewTx(
 			&chain.Base{
-				ChainID:   instances[0].chainID,
-				Timestamp: hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
-				UnitPrice: 1000,
+				ChainID:       instances[0].chainID,
+				Timestamp:     hutils.UnixRMilli(-1, 5*consts.MillisecondsPerSecond),
+				MaxUnitPrices: fees.Dimensions{1000, 1000, 1000, 1000, 1000},
 			},
 			wm,
 			&actions.ImportAsset{},
@@ -1922,6 +1967,72 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
 		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("not warp asset"))
 	})
+
+	ginkgo.It("rotate key", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.RotateKey{Key: rsender2},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// Original key can no longer be used
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{To: rsender2, Value: 1},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.MatchError(gomega.ContainSubstring("key rotated")))
+
+		// Rotated key can be used for the original account
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		rfactory := auth.NewRotatedED25519Factory(rsender, priv2)
+		submit, _, fee, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{To: rsender2, Value: 1},
+			rfactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		nbalance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nbalance).Should(gomega.BeNumerically(">=", balance-fee-1))
+		gomega.Ω(nbalance).Should(gomega.BeNumerically("<", balance))
+
+		// Restore original key
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.RotateKey{Key: rsender},
+			rfactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+	})
 })
 
 func expectBlk(i instance) func() []*chain.Result {
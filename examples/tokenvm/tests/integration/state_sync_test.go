@@ -0,0 +1,598 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package integration_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avago_version "github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	ginkgo "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/rpc"
+	hutils "github.com/ava-labs/hypersdk/utils"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/controller"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
+	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
+)
+
+const (
+	// stateSyncMinBlocks is lowered from the default so that we don't need to
+	// produce hundreds of blocks before a node will state sync.
+	stateSyncMinBlocks = 16
+
+	// stateSyncValidityWindow is lowered from the default so that synced nodes
+	// become ready after a few seconds of blocks.
+	stateSyncValidityWindow = 5 * consts.MillisecondsPerSecond
+
+	// stateSyncSamples is the number of populated keys that are compared
+	// between the serving and synced nodes.
+	stateSyncSamples = 256
+
+	// stateSyncResponseDelay is injected into the delivery of every state
+	// sync response to mimic a slow network.
+	stateSyncResponseDelay = 50 * time.Millisecond
+)
+
+var (
+	stateSyncKeys        int
+	stateSyncMaxDuration time.Duration
+)
+
+func init() {
+	flag.IntVar(
+		&stateSyncKeys,
+		"state-sync-keys",
+		100_000,
+		"number of keys to populate before state syncing (use millions to mimic a large chain)",
+	)
+	flag.DurationVar(
+		&stateSyncMaxDuration,
+		"state-sync-max-duration",
+		2*time.Minute,
+		"maximum time a node may take to state sync",
+	)
+}
+
+// syncNode is an embedded VM connected to a [syncNetwork].
+type syncNode struct {
+	instance
+	gatherer metrics.OptionalGatherer
+
+	// Used to restart the node after a crash
+	log          logging.Logger
+	sk           *bls.SecretKey
+	dataDir      string
+	chainID      ids.ID
+	subnetID     ids.ID
+	genesisBytes []byte
+	config       string
+}
+
+// syncNetwork delivers app requests, responses, and gossip between embedded
+// VMs (which [appSender] drops) so that they can state sync from each other.
+//
+// Faults can be injected into the network to test recovery behavior (see
+// faults_test.go).
+type syncNetwork struct {
+	l      sync.RWMutex
+	nodes  map[ids.NodeID]*syncNode
+	faults faults
+}
+
+func (n *syncNetwork) node(nodeID ids.NodeID) (*syncNode, bool) {
+	n.l.RLock()
+	defer n.l.RUnlock()
+
+	node, ok := n.nodes[nodeID]
+	return node, ok
+}
+
+// addNode creates a new VM and connects it to all existing nodes. If [ready]
+// is true, the VM will skip state sync (to mimic bootstrapping from genesis).
+func (n *syncNetwork) addNode(
+	chainID ids.ID,
+	subnetID ids.ID,
+	genesisBytes []byte,
+	config string,
+	ready bool,
+) *syncNode {
+	nodeID := ids.GenerateTestNodeID()
+	l, err := logFactory.Make(nodeID.String())
+	gomega.Ω(err).Should(gomega.BeNil())
+	sk, err := bls.NewSecretKey()
+	gomega.Ω(err).Should(gomega.BeNil())
+	dname, err := os.MkdirTemp("", fmt.Sprintf("%s-chainData", nodeID.String()))
+	gomega.Ω(err).Should(gomega.BeNil())
+	node := &syncNode{
+		instance: instance{
+			chainID: chainID,
+			nodeID:  nodeID,
+		},
+		log:          l,
+		sk:           sk,
+		dataDir:      dname,
+		chainID:      chainID,
+		subnetID:     subnetID,
+		genesisBytes: genesisBytes,
+		config:       config,
+	}
+	gomega.Ω(n.start(node, ready)).Should(gomega.BeNil())
+	return node
+}
+
+// start initializes the VM of [node] (using any data it persisted before it
+// was stopped) and connects it to all running nodes.
+func (n *syncNetwork) start(node *syncNode, ready bool) error {
+	gatherer := metrics.NewOptionalGatherer()
+	snowCtx := &snow.Context{
+		NetworkID:      networkID,
+		SubnetID:       node.subnetID,
+		ChainID:        node.chainID,
+		NodeID:         node.nodeID,
+		Log:            node.log,
+		ChainDataDir:   node.dataDir,
+		Metrics:        gatherer,
+		PublicKey:      bls.PublicFromSecretKey(node.sk),
+		WarpSigner:     warp.NewSigner(node.sk, networkID, node.chainID),
+		ValidatorState: &validators.TestState{},
+	}
+
+	toEngine := make(chan common.Message, 1)
+	db := manager.NewMemDB(avago_version.CurrentDatabase)
+
+	v := controller.New()
+	if err := v.Initialize(
+		context.TODO(),
+		snowCtx,
+		db,
+		node.genesisBytes,
+		nil,
+		[]byte(node.config),
+		toEngine,
+		nil,
+		&syncSender{n, node.nodeID},
+	); err != nil {
+		return err
+	}
+
+	hd, err := v.CreateHandlers(context.TODO())
+	if err != nil {
+		return err
+	}
+	jsonRPCServer := httptest.NewServer(hd[rpc.JSONRPCEndpoint].Handler)
+	tjsonRPCServer := httptest.NewServer(hd[trpc.JSONRPCEndpoint].Handler)
+	webSocketServer := httptest.NewServer(hd[rpc.WebSocketEndpoint].Handler)
+	node.instance = instance{
+		chainID:            node.chainID,
+		nodeID:             node.nodeID,
+		vm:                 v,
+		toEngine:           toEngine,
+		JSONRPCServer:      jsonRPCServer,
+		TokenJSONRPCServer: tjsonRPCServer,
+		WebSocketServer:    webSocketServer,
+		cli:                rpc.NewJSONRPCClient(jsonRPCServer.URL),
+		tcli:               trpc.NewJSONRPCClient(tjsonRPCServer.URL, networkID, node.chainID),
+	}
+	node.gatherer = gatherer
+	if ready {
+		v.ForceReady()
+	}
+
+	n.l.Lock()
+	defer n.l.Unlock()
+	for _, peer := range n.nodes {
+		if err := v.Connected(context.TODO(), peer.nodeID, avago_version.CurrentApp); err != nil {
+			return err
+		}
+		if err := peer.vm.Connected(context.TODO(), node.nodeID, avago_version.CurrentApp); err != nil {
+			return err
+		}
+	}
+	n.nodes[node.nodeID] = node
+	return nil
+}
+
+// stop disconnects [node] from all running nodes and shuts it down.
+func (n *syncNetwork) stop(node *syncNode) error {
+	n.l.Lock()
+	delete(n.nodes, node.nodeID)
+	for _, peer := range n.nodes {
+		if err := peer.vm.Disconnected(context.TODO(), node.nodeID); err != nil {
+			n.l.Unlock()
+			return err
+		}
+	}
+	n.l.Unlock()
+
+	node.JSONRPCServer.Close()
+	node.TokenJSONRPCServer.Close()
+	node.WebSocketServer.Close()
+	return node.vm.Shutdown(context.TODO())
+}
+
+// bytesServed returns the number of bytes served to syncing nodes by all nodes
+// in the network.
+func (n *syncNetwork) bytesServed() float64 {
+	n.l.RLock()
+	defer n.l.RUnlock()
+
+	var served float64
+	for _, node := range n.nodes {
+		served += metricValue(node.gatherer, "state_sync_bytes_served")
+	}
+	return served
+}
+
+func (n *syncNetwork) shutdown() {
+	n.l.RLock()
+	nodes := make([]*syncNode, 0, len(n.nodes))
+	for _, node := range n.nodes {
+		nodes = append(nodes, node)
+	}
+	n.l.RUnlock()
+
+	for _, node := range nodes {
+		gomega.Ω(n.stop(node)).Should(gomega.BeNil())
+	}
+}
+
+var _ common.AppSender = &syncSender{}
+
+// syncSender delivers messages sent by [nodeID] asynchronously (as the
+// networking layer would) to avoid re-entering a handler that is sending.
+//
+// Messages are dropped or delayed according to the [faults] of the network.
+type syncSender struct {
+	net    *syncNetwork
+	nodeID ids.NodeID
+}
+
+func (s *syncSender) SendAppRequest(
+	_ context.Context,
+	nodeIDs set.Set[ids.NodeID],
+	requestID uint32,
+	request []byte,
+) error {
+	deadline := time.Now().Add(requestTimeout)
+	for nodeID := range nodeIDs {
+		node, ok := s.net.node(nodeID)
+		if !ok {
+			continue
+		}
+		go func() {
+			if err := node.vm.AppRequest(context.Background(), s.nodeID, requestID, deadline, request); err != nil {
+				log.Warn("unable to deliver app request", zap.Stringer("nodeID", node.nodeID), zap.Error(err))
+			}
+		}()
+	}
+	return nil
+}
+
+func (s *syncSender) SendAppResponse(
+	_ context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	response []byte,
+) error {
+	node, ok := s.net.node(nodeID)
+	if !ok {
+		return nil
+	}
+	delay := s.net.faults.ResponseDelay()
+	go func() {
+		time.Sleep(delay)
+		if err := node.vm.AppResponse(context.Background(), s.nodeID, requestID, response); err != nil {
+			log.Warn("unable to deliver app response", zap.Stringer("nodeID", node.nodeID), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (s *syncSender) SendAppGossip(_ context.Context, gossip []byte) error {
+	if s.net.faults.DropGossip() {
+		return nil
+	}
+	s.net.l.RLock()
+	defer s.net.l.RUnlock()
+
+	for nodeID, node := range s.net.nodes {
+		if nodeID == s.nodeID {
+			continue
+		}
+		node := node
+		go func() {
+			if err := node.vm.AppGossip(context.Background(), s.nodeID, gossip); err != nil {
+				log.Warn("unable to deliver app gossip", zap.Stringer("nodeID", node.nodeID), zap.Error(err))
+			}
+		}()
+	}
+	return nil
+}
+
+func (*syncSender) SendAppGossipSpecific(context.Context, set.Set[ids.NodeID], []byte) error {
+	return nil
+}
+
+func (*syncSender) SendCrossChainAppRequest(context.Context, ids.ID, uint32, []byte) error {
+	return nil
+}
+
+func (*syncSender) SendCrossChainAppResponse(context.Context, ids.ID, uint32, []byte) error {
+	return nil
+}
+
+// metricValue returns the value of the counter or gauge in [g] whose name ends
+// with [name].
+func metricValue(g metrics.OptionalGatherer, name string) float64 {
+	mfs, err := g.Gather()
+	gomega.Ω(err).Should(gomega.BeNil())
+	for _, mf := range mfs {
+		if !strings.HasSuffix(mf.GetName(), name) {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			if c := m.GetCounter(); c != nil {
+				return c.GetValue()
+			}
+			if g := m.GetGauge(); g != nil {
+				return g.GetValue()
+			}
+		}
+	}
+	ginkgo.Fail(fmt.Sprintf("metric %s not found", name))
+	return 0
+}
+
+var _ = ginkgo.Describe("[State Sync]", ginkgo.Ordered, func() {
+	var (
+		network  *syncNetwork
+		chainID  ids.ID
+		subnetID ids.ID
+		gBytes   []byte
+		producer *syncNode
+		parser   chain.Parser
+		samples  []crypto.PublicKey
+		nonce    uint64
+	)
+
+	// produceBlock issues a transfer on [producer] and returns the accepted
+	// block that includes it.
+	produceBlock := func() *chain.StatelessBlock {
+		nonce++
+		submit, _, _, err := producer.cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: nonce, // ensures txs are unique
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		results := expectBlk(producer.instance)()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		return producer.vm.LastAcceptedBlock()
+	}
+
+	// deliverBlock processes [blk] on [node] as the engine would.
+	deliverBlock := func(node *syncNode, blk *chain.StatelessBlock) {
+		ctx := context.TODO()
+		sblk, err := node.vm.ParseBlock(ctx, blk.Bytes())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(sblk.Verify(ctx)).Should(gomega.BeNil())
+		gomega.Ω(node.vm.SetPreference(ctx, sblk.ID())).Should(gomega.BeNil())
+		gomega.Ω(sblk.Accept(ctx)).Should(gomega.BeNil())
+	}
+
+	// startStateSync syncs [node] to the last accepted block of [producer] as
+	// the engine would. Once the summary is accepted, the engine moves on to
+	// normal operation while the VM fetches state in the background.
+	startStateSync := func(node *syncNode) {
+		ctx := context.TODO()
+		gomega.Ω(node.vm.SetState(ctx, snow.StateSyncing)).Should(gomega.BeNil())
+		summary, err := producer.vm.GetLastStateSummary(ctx)
+		gomega.Ω(err).Should(gomega.BeNil())
+		parsed, err := node.vm.ParseStateSummary(ctx, summary.Bytes())
+		gomega.Ω(err).Should(gomega.BeNil())
+		mode, err := parsed.Accept(ctx)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(mode).Should(gomega.Equal(block.StateSyncDynamic))
+		gomega.Ω(node.vm.SetState(ctx, snow.Bootstrapping)).Should(gomega.BeNil())
+		gomega.Ω(node.vm.SetState(ctx, snow.NormalOp)).Should(gomega.BeNil())
+	}
+
+	// awaitReady delivers new blocks to [node] until it has seen a validity
+	// window of transactions and notifies the engine that it is ready.
+	awaitReady := func(node *syncNode) {
+		deadline := time.Now().Add(stateSyncMaxDuration)
+		for {
+			select {
+			case msg := <-node.toEngine:
+				gomega.Ω(msg).Should(gomega.Equal(common.StateSyncDone))
+				return
+			default:
+			}
+			gomega.Ω(time.Now().Before(deadline)).Should(gomega.BeTrue())
+			deliverBlock(node, produceBlock())
+			time.Sleep(250 * time.Millisecond)
+		}
+	}
+
+	// checkState ensures [node] has the same view of the sampled keys (and of
+	// the keys modified after it synced) as [producer].
+	checkState := func(node *syncNode) {
+		ctx := context.TODO()
+		for _, pk := range append(samples, rsender, rsender2) {
+			expected, err := storage.GetBalanceFromState(ctx, producer.vm.ReadState, pk, ids.Empty)
+			gomega.Ω(err).Should(gomega.BeNil())
+			actual, err := storage.GetBalanceFromState(ctx, node.vm.ReadState, pk, ids.Empty)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(actual).Should(gomega.Equal(expected))
+		}
+	}
+
+	// checkSync ensures the sync of [node] finished within
+	// [stateSyncMaxDuration] and that at least the populated keys were served.
+	checkSync := func(node *syncNode, elapsed time.Duration, served float64) {
+		duration := time.Duration(metricValue(node.gatherer, "state_sync_duration")) * time.Millisecond
+		hutils.Outf(
+			"{{yellow}}synced %d keys in %v (served %d bytes){{/}}\n",
+			stateSyncKeys, duration, uint64(served),
+		)
+		gomega.Ω(duration).Should(gomega.BeNumerically("<=", elapsed))
+		gomega.Ω(elapsed).Should(gomega.BeNumerically("<", stateSyncMaxDuration))
+		gomega.Ω(served).Should(gomega.BeNumerically(">=", float64(stateSyncKeys*crypto.PublicKeyLen)))
+	}
+
+	ginkgo.BeforeAll(func() {
+		gomega.Ω(stateSyncKeys).Should(gomega.BeNumerically(">=", stateSyncSamples))
+
+		// Populate state at genesis (this is much faster than issuing
+		// transactions)
+		g := genesis.Default()
+		g.MinBlockGap = 0
+		g.ValidityWindow = stateSyncValidityWindow
+		g.CustomAllocation = make([]*genesis.CustomAllocation, 0, stateSyncKeys+1)
+		g.CustomAllocation = append(g.CustomAllocation, &genesis.CustomAllocation{
+			Address: sender,
+			Balance: 10_000_000,
+		})
+		for i := 0; i < stateSyncKeys; i++ {
+			var pk crypto.PublicKey
+			_, err := rand.Read(pk[:])
+			gomega.Ω(err).Should(gomega.BeNil())
+			if i < stateSyncSamples {
+				samples = append(samples, pk)
+			}
+			g.CustomAllocation = append(g.CustomAllocation, &genesis.CustomAllocation{
+				Address: utils.Address(pk),
+				Balance: uint64(i + 1),
+			})
+		}
+		var err error
+		gBytes, err = json.Marshal(g)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		network = &syncNetwork{nodes: map[ids.NodeID]*syncNode{}}
+		chainID = ids.GenerateTestID()
+		subnetID = ids.GenerateTestID()
+		producer = network.addNode(
+			chainID,
+			subnetID,
+			gBytes,
+			// Delay serving so that blocks are produced while syncing
+			`{"parallelism":3, "testMode":true, "logLevel":"info", "stateSyncServerDelay":5000000}`,
+			true,
+		)
+		parser, err = producer.tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Nodes will only sync once they are [stateSyncMinBlocks] behind
+		for i := 0; i < stateSyncMinBlocks; i++ {
+			produceBlock()
+		}
+	})
+
+	ginkgo.AfterAll(func() {
+		network.shutdown()
+	})
+
+	ginkgo.It("can state sync a new node when no new blocks are being produced", func() {
+		served := network.bytesServed()
+		node := network.addNode(
+			chainID,
+			subnetID,
+			gBytes,
+			fmt.Sprintf(`{"parallelism":3, "testMode":true, "logLevel":"info", "stateSyncMinBlocks":%d}`, stateSyncMinBlocks),
+			false,
+		)
+		start := time.Now()
+		startStateSync(node)
+		gomega.Eventually(node.vm.StateReady, stateSyncMaxDuration, 100*time.Millisecond).Should(gomega.BeTrue())
+		checkSync(node, time.Since(start), network.bytesServed()-served)
+
+		awaitReady(node)
+		checkState(node)
+	})
+
+	ginkgo.It("can state sync a new node when responses are delayed", func() {
+		network.faults.SetResponseDelay(stateSyncResponseDelay)
+		defer network.faults.SetResponseDelay(0)
+
+		served := network.bytesServed()
+		node := network.addNode(
+			chainID,
+			subnetID,
+			gBytes,
+			fmt.Sprintf(`{"parallelism":3, "testMode":true, "logLevel":"info", "stateSyncMinBlocks":%d}`, stateSyncMinBlocks),
+			false,
+		)
+		start := time.Now()
+		startStateSync(node)
+		gomega.Eventually(node.vm.StateReady, stateSyncMaxDuration, 100*time.Millisecond).Should(gomega.BeTrue())
+		elapsed := time.Since(start)
+		gomega.Ω(elapsed).Should(gomega.BeNumerically(">=", stateSyncResponseDelay))
+		checkSync(node, elapsed, network.bytesServed()-served)
+
+		awaitReady(node)
+		checkState(node)
+	})
+
+	ginkgo.It("can state sync a new node while blocks are being produced", func() {
+		served := network.bytesServed()
+		node := network.addNode(
+			chainID,
+			subnetID,
+			gBytes,
+			fmt.Sprintf(`{"parallelism":3, "testMode":true, "logLevel":"info", "stateSyncMinBlocks":%d}`, stateSyncMinBlocks),
+			false,
+		)
+		start := time.Now()
+		startStateSync(node)
+
+		// Each accepted block updates the sync target of [node]
+		deadline := start.Add(stateSyncMaxDuration)
+		for !node.vm.StateReady() {
+			gomega.Ω(time.Now().Before(deadline)).Should(gomega.BeTrue())
+			deliverBlock(node, produceBlock())
+			time.Sleep(100 * time.Millisecond)
+		}
+		checkSync(node, time.Since(start), network.bytesServed()-served)
+
+		awaitReady(node)
+		checkState(node)
+	})
+})
@@ -36,6 +36,7 @@ import (
 	"github.com/ava-labs/hypersdk/chain"
 	hconsts "github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/pebble"
 	hutils "github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/vm"
@@ -178,7 +179,9 @@ var _ = ginkgo.BeforeSuite(func() {
 	// create embedded VMs
 	instances = make([]*instance, vms)
 	gen = genesis.Default()
-	gen.WindowTargetUnits = 1_000_000_000                      // disable unit price increase
+	for d := range gen.WindowTargetUnits {
+		gen.WindowTargetUnits[d] = 1_000_000_000 // disable unit price increase
+	}
 	gen.MinBlockGap = 0                                        // don't require time between blocks
 	gen.ValidityWindow = 1_000 * hconsts.MillisecondsPerSecond // txs shouldn't expire
 	gen.CustomAllocation = []*genesis.CustomAllocation{
@@ -488,9 +491,9 @@ func issueSimpleTx(
 ) (ids.ID, error) {
 	tx := chain.NewTx(
 		&chain.Base{
-			Timestamp: hutils.UnixRMilli(-1, 100*hconsts.MillisecondsPerSecond),
-			ChainID:   i.chainID,
-			UnitPrice: 1,
+			Timestamp:     hutils.UnixRMilli(-1, 100*hconsts.MillisecondsPerSecond),
+			ChainID:       i.chainID,
+			MaxUnitPrices: fees.Dimensions{1, 1, 1, 1, 1},
 		},
 		nil,
 		&actions.Transfer{
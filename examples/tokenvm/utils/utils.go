@@ -16,3 +16,16 @@ func Address(pk crypto.PublicKey) string {
 func ParseAddress(s string) (crypto.PublicKey, error) {
 	return crypto.ParseAddress(consts.HRP, s)
 }
+
+// DerivedAddress returns the address of [key] when used with auth [typeID].
+func DerivedAddress(typeID uint8, key []byte) (string, error) {
+	a, err := consts.AddressRegistry.Derive(typeID, key)
+	if err != nil {
+		return "", err
+	}
+	return crypto.FormatDerivedAddress(consts.HRP, a), nil
+}
+
+func ParseDerivedAddress(s string) (crypto.DerivedAddress, error) {
+	return crypto.ParseDerivedAddress(consts.HRP, s)
+}
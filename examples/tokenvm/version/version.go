@@ -10,3 +10,13 @@ var Version = &version.Semantic{
 	Minor: 0,
 	Patch: 1,
 }
+
+// Commit and BuildTime are set at build time with:
+//
+//	-ldflags "-X github.com/ava-labs/hypersdk/examples/tokenvm/version.Commit=<commit>"
+//
+// (see scripts/release)
+var (
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
@@ -0,0 +1,93 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// Dimension is a resource that is priced separately.
+type Dimension int
+
+const (
+	Bandwidth Dimension = iota
+	Compute
+	StorageRead
+	StorageAllocate
+	StorageWrite
+
+	FeeDimensions = 5
+	DimensionsLen = consts.Uint64Len * FeeDimensions
+)
+
+var dimensionNames = [FeeDimensions]string{
+	"bandwidth",
+	"compute",
+	"storageRead",
+	"storageAllocate",
+	"storageWrite",
+}
+
+func (d Dimension) String() string {
+	if d < 0 || d >= FeeDimensions {
+		return fmt.Sprintf("unknown(%d)", int(d))
+	}
+	return dimensionNames[d]
+}
+
+// Dimensions holds a value (units, unit prices, limits, etc.) for each
+// [Dimension].
+type Dimensions [FeeDimensions]uint64
+
+// Add returns the sum of [a] and [b] in each dimension.
+func Add(a, b Dimensions) (Dimensions, error) {
+	d := Dimensions{}
+	for i := range d {
+		v, err := math.Add64(a[i], b[i])
+		if err != nil {
+			return Dimensions{}, err
+		}
+		d[i] = v
+	}
+	return d, nil
+}
+
+// MulSum returns the sum of [units] multiplied by [prices] in each dimension
+// (i.e. the fee paid for [units]).
+func MulSum(units, prices Dimensions) (uint64, error) {
+	var sum uint64
+	for i := range units {
+		v, err := math.Mul64(units[i], prices[i])
+		if err != nil {
+			return 0, err
+		}
+		sum, err = math.Add64(sum, v)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return sum, nil
+}
+
+// Exceeds returns true if [d] is greater than [limit] in any dimension.
+func (d Dimensions) Exceeds(limit Dimensions) bool {
+	for i := range d {
+		if d[i] > limit[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func (d Dimensions) String() string {
+	parts := make([]string, FeeDimensions)
+	for i, v := range d {
+		parts[i] = fmt.Sprintf("%s=%d", Dimension(i), v)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"testing"
+
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdd(t *testing.T) {
+	require := require.New(t)
+
+	d, err := Add(Dimensions{1, 2, 3, 4, 5}, Dimensions{5, 4, 3, 2, 1})
+	require.NoError(err)
+	require.Equal(Dimensions{6, 6, 6, 6, 6}, d)
+
+	_, err = Add(Dimensions{0, 0, 0, 0, consts.MaxUint64}, Dimensions{0, 0, 0, 0, 1})
+	require.Error(err)
+}
+
+func TestMulSum(t *testing.T) {
+	require := require.New(t)
+
+	fee, err := MulSum(Dimensions{1, 2, 3, 4, 5}, Dimensions{10, 10, 1, 0, 2})
+	require.NoError(err)
+	require.Equal(uint64(10+20+3+0+10), fee)
+
+	_, err = MulSum(Dimensions{consts.MaxUint64}, Dimensions{2})
+	require.Error(err)
+	_, err = MulSum(Dimensions{consts.MaxUint64, 1}, Dimensions{1, 1})
+	require.Error(err)
+}
+
+func TestExceeds(t *testing.T) {
+	require := require.New(t)
+
+	limit := Dimensions{10, 10, 10, 10, 10}
+	require.False(Dimensions{}.Exceeds(limit))
+	require.False(limit.Exceeds(limit))
+	require.True(Dimensions{0, 0, 0, 11, 0}.Exceeds(limit))
+}
+
+func TestString(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("compute", Compute.String())
+	require.Equal(
+		"bandwidth=1 compute=2 storageRead=3 storageAllocate=4 storageWrite=5",
+		Dimensions{1, 2, 3, 4, 5}.String(),
+	)
+}
@@ -26,5 +26,12 @@ type VM interface {
 	Registry() (chain.ActionRegistry, chain.AuthRegistry)
 	NodeID() ids.NodeID
 	Rules(int64) chain.Rules
+	StateManager() chain.StateManager
 	Submit(ctx context.Context, verify bool, txs []*chain.Transaction) []error
+	RecordGossipBatch(txs int, fill float64)
+	RecordGossipCompression(ratio float64)
+	RecordSuppressedGossip(msgs int, txs int)
+	RecordFilteredGossip(txs int)
+	RecordRegossip(txs int)
+	MempoolFilter() []byte
 }
@@ -0,0 +1,12 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import "errors"
+
+var (
+	ErrInvalidFrame     = errors.New("invalid gossip frame")
+	ErrNotRunning       = errors.New("gossiper not running")
+	ErrForwardQueueFull = errors.New("forward queue full")
+)
@@ -0,0 +1,106 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// Gossip messages sent before frames were introduced are an unframed batch of
+// txs (marshaled with [chain.MarshalTxs]). Because a batch never holds more
+// than 2^24 txs, the first byte of such a message is always [legacyFrame].
+//
+// All other messages start with a version (currently only [frameVersion])
+// followed by their type. A frame with an unknown version or type is rejected
+// with [ErrInvalidFrame].
+//
+// Uncompressed batches are always sent unframed, so only compressed gossip
+// requires peers to understand frames.
+const (
+	legacyFrame  uint8 = 0
+	frameVersion uint8 = 1
+)
+
+// Frame types (of [frameVersion]). Frames of either type are always accepted,
+// regardless of whether compression is enabled locally.
+//
+// A [zstdFrame] includes the size of the decompressed batch (so that a
+// truncated frame is not mistaken for a smaller batch).
+const (
+	rawFrame  uint8 = 0
+	zstdFrame uint8 = 1
+
+	zstdHeaderLen = 2*consts.ByteLen + consts.IntLen
+)
+
+// frameCompressor compresses gossip frames (and limits the size of
+// decompressed frames to [consts.NetworkSizeLimit]).
+var frameCompressor = func() compression.Compressor {
+	c, err := compression.NewZstdCompressor(consts.NetworkSizeLimit)
+	if err != nil {
+		// Only returned if the max size is [math.MaxInt64]
+		panic(err)
+	}
+	return c
+}()
+
+// packFrame wraps the marshaled txs [b] in a gossip frame. If [compress] is
+// true, [b] is compressed with zstd (unless that doesn't make it smaller).
+// Otherwise, [b] is returned as-is (so it can be read by peers that don't
+// understand frames).
+func packFrame(b []byte, compress bool) ([]byte, error) {
+	if !compress {
+		return b, nil
+	}
+	compressed, err := frameCompressor.Compress(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(compressed) >= len(b) {
+		return b, nil
+	}
+	frame := make([]byte, zstdHeaderLen, zstdHeaderLen+len(compressed))
+	frame[0], frame[1] = frameVersion, zstdFrame
+	binary.BigEndian.PutUint32(frame[2:], uint32(len(b)))
+	return append(frame, compressed...), nil
+}
+
+// unpackFrame returns the marshaled txs in [frame].
+func unpackFrame(frame []byte) ([]byte, error) {
+	if len(frame) == 0 {
+		return nil, ErrInvalidFrame
+	}
+	if frame[0] == legacyFrame {
+		return frame, nil
+	}
+	if frame[0] != frameVersion {
+		return nil, fmt.Errorf("%w: unknown version %d", ErrInvalidFrame, frame[0])
+	}
+	if len(frame) < 2 {
+		return nil, fmt.Errorf("%w: missing type", ErrInvalidFrame)
+	}
+	switch frame[1] {
+	case rawFrame:
+		return frame[2:], nil
+	case zstdFrame:
+		if len(frame) < zstdHeaderLen {
+			return nil, fmt.Errorf("%w: missing size", ErrInvalidFrame)
+		}
+		size := binary.BigEndian.Uint32(frame[2:])
+		b, err := frameCompressor.Decompress(frame[zstdHeaderLen:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFrame, err)
+		}
+		if uint32(len(b)) != size {
+			return nil, fmt.Errorf("%w: expected %d bytes but found %d", ErrInvalidFrame, size, len(b))
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown type %d", ErrInvalidFrame, frame[1])
+	}
+}
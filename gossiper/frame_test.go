@@ -0,0 +1,156 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// testBatch returns a marshaled batch of [count] txs (like [chain.MarshalTxs])
+// whose bytes are all [fill].
+func testBatch(count int, fill []byte) []byte {
+	p := codec.NewWriter(consts.IntLen+len(fill), consts.NetworkSizeLimit)
+	p.PackInt(count)
+	p.PackFixedBytes(fill)
+	return p.Bytes()
+}
+
+func TestFrameRoundTrip(t *testing.T) {
+	random := make([]byte, 1024)
+	_, err := rand.Read(random)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		b        []byte
+		compress bool
+		framed   bool
+	}{
+		{
+			name: "uncompressed",
+			b:    testBatch(1, bytes.Repeat([]byte{1}, 1024)),
+		},
+		{
+			name:     "compressed",
+			b:        testBatch(1, bytes.Repeat([]byte{1}, 1024)),
+			compress: true,
+			framed:   true,
+		},
+		{
+			// Compression doesn't make random bytes smaller
+			name:     "incompressible",
+			b:        testBatch(1, random),
+			compress: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			frame, err := packFrame(tt.b, tt.compress)
+			require.NoError(err)
+			if tt.framed {
+				require.Equal([]byte{frameVersion, zstdFrame}, frame[:2])
+				require.Less(len(frame), len(tt.b))
+			} else {
+				// Unframed batches can be read by peers that don't understand
+				// frames
+				require.Equal(tt.b, frame)
+			}
+
+			b, err := unpackFrame(frame)
+			require.NoError(err)
+			require.Equal(tt.b, b)
+		})
+	}
+}
+
+func TestUnpackFrame(t *testing.T) {
+	batch := testBatch(2, bytes.Repeat([]byte{1}, 256))
+	compressed, err := packFrame(batch, true)
+	require.NoError(t, err)
+	require.Equal(t, zstdFrame, compressed[1])
+	header, payload := compressed[:zstdHeaderLen], compressed[zstdHeaderLen:]
+	resized := append([]byte{}, compressed...)
+	resized[zstdHeaderLen-1]++
+
+	tests := []struct {
+		name     string
+		frame    []byte
+		expected []byte
+		err      error
+	}{
+		{
+			name:     "legacy",
+			frame:    batch,
+			expected: batch,
+		},
+		{
+			name:     "raw",
+			frame:    append([]byte{frameVersion, rawFrame}, batch...),
+			expected: batch,
+		},
+		{
+			name:     "zstd",
+			frame:    compressed,
+			expected: batch,
+		},
+		{
+			name:  "empty",
+			frame: []byte{},
+			err:   ErrInvalidFrame,
+		},
+		{
+			name:  "unknown version",
+			frame: append([]byte{frameVersion + 1, rawFrame}, batch...),
+			err:   ErrInvalidFrame,
+		},
+		{
+			name:  "missing type",
+			frame: []byte{frameVersion},
+			err:   ErrInvalidFrame,
+		},
+		{
+			name:  "unknown type",
+			frame: append([]byte{frameVersion, zstdFrame + 1}, batch...),
+			err:   ErrInvalidFrame,
+		},
+		{
+			name:  "missing size",
+			frame: header[:zstdHeaderLen-1],
+			err:   ErrInvalidFrame,
+		},
+		{
+			name:  "corrupt zstd",
+			frame: append(append([]byte{}, header...), batch...),
+			err:   ErrInvalidFrame,
+		},
+		{
+			name:  "truncated zstd",
+			frame: append(append([]byte{}, header...), payload[:len(payload)-1]...),
+			err:   ErrInvalidFrame,
+		},
+		{
+			name:  "wrong size",
+			frame: resized,
+			err:   ErrInvalidFrame,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			b, err := unpackFrame(tt.frame)
+			require.ErrorIs(err, tt.err)
+			require.Equal(tt.expected, b)
+		})
+	}
+}
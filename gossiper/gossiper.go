@@ -8,12 +8,22 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/hypersdk/chain"
 )
 
 type Gossiper interface {
 	Run(common.AppSender)
-	ForceGossip(context.Context) error // may be triggered by run already
+	ForceGossip(context.Context) error                   // may be triggered by run already
+	Forward(context.Context, []*chain.Transaction) error // queue to send directly to upcoming proposers
 	HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error
 	BlockVerified(int64)
 	Done() // wait after stop
 }
+
+// FilterGossiper is an optional interface that a [Gossiper] can implement to
+// exchange mempool filters with peers (so that it doesn't send peers txs they
+// already have).
+type FilterGossiper interface {
+	SetFilterSender(common.AppSender) // must be called before [Run]
+	HandleFilter(ctx context.Context, nodeID ids.NodeID, filter []byte) error
+}
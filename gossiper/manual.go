@@ -10,6 +10,8 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/mempool"
 	"go.uber.org/zap"
 )
 
@@ -38,7 +40,7 @@ func (g *Manual) Run(appSender common.AppSender) {
 func (g *Manual) ForceGossip(ctx context.Context) error {
 	// Gossip highest paying txs
 	txs := []*chain.Transaction{}
-	totalUnits := uint64(0)
+	totalUnits := fees.Dimensions{}
 	now := time.Now().UnixMilli()
 	r := g.vm.Rules(now)
 	mempoolErr := g.vm.Mempool().Build(
@@ -46,7 +48,7 @@ func (g *Manual) ForceGossip(ctx context.Context) error {
 		func(ictx context.Context, next *chain.Transaction) (cont bool, restore bool, removeAcct bool, err error) {
 			// Remove txs that are expired
 			if next.Base.Timestamp < now {
-				return true, false, false, nil
+				return true, false, false, mempool.ErrExpired
 			}
 
 			// Gossip up to a block of content
@@ -57,12 +59,13 @@ func (g *Manual) ForceGossip(ctx context.Context) error {
 				return true, false, false, nil
 			}
 			// TODO: limit to a smaller amount
-			if units+totalUnits > r.GetMaxBlockUnits() {
+			nextTotal, err := fees.Add(totalUnits, units)
+			if err != nil || nextTotal.Exceeds(r.GetMaxBlockUnits()) {
 				// Attempt to mirror the function of building a block without execution
 				return false, true, false, nil
 			}
 			txs = append(txs, next)
-			totalUnits += units
+			totalUnits = nextTotal
 			return true, true, false, nil
 		},
 	)
@@ -77,7 +80,11 @@ func (g *Manual) ForceGossip(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	if err := g.appSender.SendAppGossip(ctx, b); err != nil {
+	frame, err := packFrame(b, false)
+	if err != nil {
+		return err
+	}
+	if err := g.appSender.SendAppGossip(ctx, frame); err != nil {
 		g.vm.Logger().Warn(
 			"GossipTxs failed",
 			zap.Error(err),
@@ -88,9 +95,23 @@ func (g *Manual) ForceGossip(ctx context.Context) error {
 	return nil
 }
 
+// Forward is a no-op because [Manual] only gossips when explicitly triggered.
+func (*Manual) Forward(context.Context, []*chain.Transaction) error {
+	return nil
+}
+
 func (g *Manual) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	b, err := unpackFrame(msg)
+	if err != nil {
+		g.vm.Logger().Warn(
+			"AppGossip provided invalid frame",
+			zap.Stringer("peerID", nodeID),
+			zap.Error(err),
+		)
+		return nil
+	}
 	actionRegistry, authRegistry := g.vm.Registry()
-	txs, err := chain.UnmarshalTxs(msg, initialCapacity, actionRegistry, authRegistry)
+	txs, err := chain.UnmarshalTxs(b, initialCapacity, actionRegistry, authRegistry)
 	if err != nil {
 		g.vm.Logger().Warn(
 			"AppGossip provided invalid txs",
@@ -6,6 +6,8 @@ package gossiper
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ava-labs/avalanchego/cache"
@@ -15,47 +17,136 @@ import (
 	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/emap"
+	"github.com/ava-labs/hypersdk/mempool"
+	"github.com/ava-labs/hypersdk/utils"
 	"go.uber.org/zap"
 )
 
-var _ Gossiper = (*Proposer)(nil)
+var (
+	_ Gossiper       = (*Proposer)(nil)
+	_ FilterGossiper = (*Proposer)(nil)
+)
 
 var proposerWindow = proposer.MaxDelay.Milliseconds()
 
 type Proposer struct {
-	vm         VM
-	cfg        *ProposerConfig
-	appSender  common.AppSender
-	doneGossip chan struct{}
+	vm           VM
+	cfg          *ProposerConfig
+	filterSender common.AppSender
+	doneGossip   chan struct{}
+
+	// txs queued by [Forward] (sent by [Run])
+	forwardQueue chan []*chain.Transaction
+
+	// set by [Run] (may be read concurrently by [Forward])
+	senderL   sync.RWMutex
+	appSender common.AppSender
 
 	lastVerified int64
 
 	// bounded by validator count (may be slightly out of date as composition changes)
+	gossipedL   sync.Mutex
 	gossipedTxs map[ids.NodeID]*cache.LRU[ids.ID, struct{}]
+	peerFilters map[ids.NodeID][]byte // mempool filters sent by validators
 	receivedTxs *cache.LRU[ids.ID, struct{}]
+
+	// recently received messages and txs (dropped if seen again within
+	// [GossipSuppressionWindow])
+	recentGossip *emap.EMap[*seenGossip]
+
+	// when each tx in the mempool was first seen by [Regossip] (only accessed
+	// from the gossip loop)
+	firstSeen map[ids.ID]int64
+}
+
+type seenGossip struct {
+	id     ids.ID
+	expiry int64
+}
+
+func (s *seenGossip) ID() ids.ID    { return s.id }
+func (s *seenGossip) Expiry() int64 { return s.expiry }
+
+// GossipPolicy determines which peers a [Proposer] gossips txs to.
+type GossipPolicy uint8
+
+const (
+	// GossipToProposers only sends txs to the validators that are most likely
+	// to propose the next blocks (falling back to [GossipToAll] if they can't
+	// be determined).
+	GossipToProposers GossipPolicy = iota
+	// GossipToAll sends txs to all peers.
+	GossipToAll
+)
+
+func (p GossipPolicy) String() string {
+	switch p {
+	case GossipToProposers:
+		return "proposers"
+	case GossipToAll:
+		return "all"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseGossipPolicy returns the [GossipPolicy] named [s].
+func ParseGossipPolicy(s string) (GossipPolicy, error) {
+	switch s {
+	case GossipToProposers.String():
+		return GossipToProposers, nil
+	case GossipToAll.String():
+		return GossipToAll, nil
+	default:
+		return 0, fmt.Errorf("unknown gossip policy %q (expected %q or %q)", s, GossipToProposers, GossipToAll)
+	}
 }
 
 type ProposerConfig struct {
+	GossipPolicy            GossipPolicy
 	GossipProposerDiff      int
 	GossipProposerDepth     int
-	GossipInterval          time.Duration
+	GossipInterval          time.Duration // max delay before pending txs are flushed
+	GossipFlushInterval     time.Duration // how often to check for a full batch (0 disables)
 	GossipPeerCacheSize     int
 	GossipReceivedCacheSize int
-	GossipMinLife           int64 // ms
-	GossipMaxSize           int
+	GossipMinLife           int64         // ms
+	GossipMaxSize           int           // max bytes gossiped per flush
+	GossipSuppressionWindow int64         // ms
+	GossipBatchSize         int           // max txs per message
+	GossipTargetSize        int           // target bytes per message (before compression)
+	GossipCompression       bool          // zstd-compress each message (requires all peers to understand frames)
+	GossipFilterInterval    time.Duration // how often to send our mempool filter to proposers (0 disables)
+	RegossipInterval        time.Duration // how often to re-gossip stale txs (0 disables)
+	RegossipMinAge          int64         // ms a tx must sit in the mempool before it is re-gossiped
+	ForwardProposerDiff     int           // 0 disables forwarding
+	ForwardProposerDepth    int
+	ForwardQueueSize        int // max calls to [Forward] waiting to be sent (more are dropped)
 	BuildProposerDiff       int
 	VerifyTimeout           int64 // ms
 }
 
 func DefaultProposerConfig() *ProposerConfig {
 	return &ProposerConfig{
+		GossipPolicy:            GossipToProposers,
 		GossipProposerDiff:      3,
 		GossipProposerDepth:     2,
 		GossipInterval:          1 * time.Second,
+		GossipFlushInterval:     100 * time.Millisecond,
 		GossipPeerCacheSize:     10_240,
 		GossipReceivedCacheSize: 65_536,
 		GossipMinLife:           5 * 1000,
 		GossipMaxSize:           consts.NetworkSizeLimit,
+		GossipSuppressionWindow: 5 * 1000,
+		GossipBatchSize:         4_096,
+		GossipTargetSize:        consts.NetworkSizeLimit,
+		GossipCompression:       true,
+		GossipFilterInterval:    2 * time.Second,
+		RegossipMinAge:          10 * 1000,
+		ForwardProposerDiff:     2,
+		ForwardProposerDepth:    1,
+		ForwardQueueSize:        1_024,
 		BuildProposerDiff:       2,
 		VerifyTimeout:           proposerWindow / 2,
 	}
@@ -66,35 +157,123 @@ func NewProposer(vm VM, cfg *ProposerConfig) *Proposer {
 		vm:           vm,
 		cfg:          cfg,
 		doneGossip:   make(chan struct{}),
+		forwardQueue: make(chan []*chain.Transaction, cfg.ForwardQueueSize),
 		lastVerified: -1,
 
-		gossipedTxs: map[ids.NodeID]*cache.LRU[ids.ID, struct{}]{},
-		receivedTxs: &cache.LRU[ids.ID, struct{}]{Size: cfg.GossipReceivedCacheSize},
+		gossipedTxs:  map[ids.NodeID]*cache.LRU[ids.ID, struct{}]{},
+		peerFilters:  map[ids.NodeID][]byte{},
+		receivedTxs:  &cache.LRU[ids.ID, struct{}]{Size: cfg.GossipReceivedCacheSize},
+		recentGossip: emap.NewEMap[*seenGossip](),
+		firstSeen:    map[ids.ID]int64{},
 	}
 }
 
-func (g *Proposer) sendTxs(ctx context.Context, txs []*chain.Transaction) error {
-	ctx, span := g.vm.Tracer().Start(ctx, "Gossiper.sendTxs")
-	defer span.End()
+// suppress returns true if [id] was seen within the last
+// [GossipSuppressionWindow]. If it was not, [id] is marked as seen.
+func (g *Proposer) suppress(id ids.ID, now int64) bool {
+	item := &seenGossip{id, now + g.cfg.GossipSuppressionWindow}
+	if g.recentGossip.Any([]*seenGossip{item}) {
+		return true
+	}
+	g.recentGossip.Add([]*seenGossip{item})
+	return false
+}
 
-	proposers, err := g.vm.Proposers(
-		ctx,
-		g.cfg.GossipProposerDiff,
-		g.cfg.GossipProposerDepth,
+// batchTxs splits [txs] into batches that contain at most [GossipBatchSize]
+// transactions and are no larger than [GossipTargetSize] (unless a single
+// transaction exceeds it).
+func (g *Proposer) batchTxs(txs []*chain.Transaction) [][]*chain.Transaction {
+	var (
+		batches = [][]*chain.Transaction{}
+		batch   = []*chain.Transaction{}
+		size    = 0
 	)
-	if err != nil || proposers.Len() == 0 {
-		g.vm.Logger().Warn(
-			"unable to find any proposers, falling back to all-to-all gossip",
-			zap.Error(err),
-		)
+	for _, tx := range txs {
+		txSize := tx.Size()
+		if len(batch) > 0 && (len(batch) == g.cfg.GossipBatchSize || size+txSize > g.cfg.GossipTargetSize) {
+			batches = append(batches, batch)
+			batch = []*chain.Transaction{}
+			size = 0
+		}
+		batch = append(batch, tx)
+		size += txSize
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
 
-		actionRegistry, authRegistry := g.vm.Registry()
-		b, err := chain.MarshalTxs(txs, actionRegistry, authRegistry)
+// sendBatches marshals [txs] into batches and sends each of them (in a gossip
+// frame) with [send].
+func (g *Proposer) sendBatches(
+	txs []*chain.Transaction,
+	send func([]byte) error,
+) error {
+	actionRegistry, authRegistry := g.vm.Registry()
+	for _, batch := range g.batchTxs(txs) {
+		b, err := chain.MarshalTxs(batch, actionRegistry, authRegistry)
+		if err != nil {
+			return err
+		}
+		frame, err := packFrame(b, g.cfg.GossipCompression)
 		if err != nil {
 			return err
 		}
+		if g.cfg.GossipCompression {
+			g.vm.RecordGossipCompression(float64(len(frame)) / float64(len(b)))
+		}
+		if err := send(frame); err != nil {
+			return err
+		}
+		g.vm.RecordGossipBatch(len(batch), float64(len(b))/float64(g.cfg.GossipTargetSize))
+	}
+	return nil
+}
+
+// peerCache returns the cache of txs we believe [nodeID] already has.
+func (g *Proposer) peerCache(nodeID ids.NodeID) *cache.LRU[ids.ID, struct{}] {
+	g.gossipedL.Lock()
+	defer g.gossipedL.Unlock()
+
+	c, ok := g.gossipedTxs[nodeID]
+	if !ok {
+		c = &cache.LRU[ids.ID, struct{}]{Size: g.cfg.GossipPeerCacheSize}
+		g.gossipedTxs[nodeID] = c
+	}
+	return c
+}
+
+// peerFilter returns the last mempool filter sent by [nodeID] (if any).
+func (g *Proposer) peerFilter(nodeID ids.NodeID) []byte {
+	g.gossipedL.Lock()
+	defer g.gossipedL.Unlock()
+
+	return g.peerFilters[nodeID]
+}
+
+// sender returns the [common.AppSender] passed to [Run] (or nil if [Run] has
+// not been called yet).
+func (g *Proposer) sender() common.AppSender {
+	g.senderL.RLock()
+	defer g.senderL.RUnlock()
+
+	return g.appSender
+}
+
+// gossipTxs sends [txs] to the peers selected by [GossipPolicy].
+func (g *Proposer) gossipTxs(ctx context.Context, txs []*chain.Transaction, regossip bool) error {
+	if g.cfg.GossipPolicy == GossipToAll {
+		return g.sendAll(ctx, txs)
+	}
+	return g.sendTxs(ctx, txs, g.cfg.GossipProposerDiff, g.cfg.GossipProposerDepth, regossip)
+}
 
-		if err := g.appSender.SendAppGossip(ctx, b); err != nil {
+// sendAll sends [txs] to all peers.
+func (g *Proposer) sendAll(ctx context.Context, txs []*chain.Transaction) error {
+	appSender := g.sender()
+	return g.sendBatches(txs, func(b []byte) error {
+		if err := appSender.SendAppGossip(ctx, b); err != nil {
 			g.vm.Logger().Warn(
 				"GossipTxs failed",
 				zap.Error(err),
@@ -102,28 +281,63 @@ func (g *Proposer) sendTxs(ctx context.Context, txs []*chain.Transaction) error
 			return err
 		}
 		return nil
+	})
+}
+
+// sendTxs sends [txs] to the proposers selected by [diff] and [depth]. Unless
+// [regossip] is true, txs we already sent to a proposer are not sent to it
+// again.
+func (g *Proposer) sendTxs(ctx context.Context, txs []*chain.Transaction, diff int, depth int, regossip bool) error {
+	ctx, span := g.vm.Tracer().Start(ctx, "Gossiper.sendTxs")
+	defer span.End()
+
+	proposers, err := g.vm.Proposers(ctx, diff, depth)
+	if err != nil || proposers.Len() == 0 {
+		g.vm.Logger().Warn(
+			"unable to find any proposers, falling back to all-to-all gossip",
+			zap.Error(err),
+		)
+		return g.sendAll(ctx, txs)
 	}
+	return g.sendProposers(ctx, proposers, txs, regossip)
+}
 
+// sendProposers sends [txs] to each of [proposers]. Unless [regossip] is true,
+// txs we already sent to a proposer are not sent to it again.
+func (g *Proposer) sendProposers(
+	ctx context.Context,
+	proposers set.Set[ids.NodeID],
+	txs []*chain.Transaction,
+	regossip bool,
+) error {
+	appSender := g.sender()
 	for proposer := range proposers {
 		// Don't gossip to self
 		if proposer == g.vm.NodeID() {
 			continue
 		}
 
-		c, ok := g.gossipedTxs[proposer]
-		if !ok {
-			g.gossipedTxs[proposer] = &cache.LRU[ids.ID, struct{}]{Size: g.cfg.GossipPeerCacheSize}
-			c = g.gossipedTxs[proposer]
-		}
-
-		toGossip := make([]*chain.Transaction, 0, len(txs))
+		var (
+			c        = g.peerCache(proposer)
+			filter   = g.peerFilter(proposer)
+			filtered = 0
+			toGossip = make([]*chain.Transaction, 0, len(txs))
+		)
 		for _, tx := range txs {
-			if _, ok := c.Get(tx.ID()); ok {
+			if _, ok := c.Get(tx.ID()); ok && !regossip {
+				continue
+			}
+			// Skip txs that [proposer] (probably) already has
+			if mempool.BloomContains(filter, tx.ID()) {
+				filtered++
 				continue
 			}
 			c.Put(tx.ID(), struct{}{})
 			toGossip = append(toGossip, tx)
 		}
+		if filtered > 0 {
+			g.vm.RecordFilteredGossip(filtered)
+		}
 
 		if len(toGossip) == 0 {
 			g.vm.Logger().Debug("nothing to gossip", zap.Stringer("node", proposer))
@@ -131,18 +345,17 @@ func (g *Proposer) sendTxs(ctx context.Context, txs []*chain.Transaction) error
 		}
 
 		// TODO: cache marshalization
-		actionRegistry, authRegistry := g.vm.Registry()
-		b, err := chain.MarshalTxs(toGossip, actionRegistry, authRegistry)
-		if err != nil {
-			return err
-		}
-
-		if err := g.appSender.SendAppGossipSpecific(ctx, set.Set[ids.NodeID]{proposer: {}}, b); err != nil {
-			g.vm.Logger().Warn(
-				"GossipTxs failed",
-				zap.Stringer("node", proposer),
-				zap.Error(err),
-			)
+		if err := g.sendBatches(toGossip, func(b []byte) error {
+			if err := appSender.SendAppGossipSpecific(ctx, set.Set[ids.NodeID]{proposer: {}}, b); err != nil {
+				g.vm.Logger().Warn(
+					"GossipTxs failed",
+					zap.Stringer("node", proposer),
+					zap.Error(err),
+				)
+				return err
+			}
+			return nil
+		}); err != nil {
 			return err
 		}
 	}
@@ -188,7 +401,7 @@ func (g *Proposer) ForceGossip(ctx context.Context) error {
 		func(ictx context.Context, next *chain.Transaction) (cont bool, restore bool, removeAcct bool, err error) {
 			// Remove txs that are expired
 			if next.Base.Timestamp < now {
-				return true, false, false, nil
+				return true, false, false, mempool.ErrExpired
 			}
 
 			// Don't gossip txs that are about to expire
@@ -211,7 +424,10 @@ func (g *Proposer) ForceGossip(ctx context.Context) error {
 			//
 			// TODO: consider removing this check (requires at least 1 database call
 			// per gossiped tx)
-			if err := next.PreExecute(ctx, ectx, r, state, now); err != nil {
+			//
+			// We still gossip txs waiting on earlier sequences from the same payer
+			// (they may become valid before the gossip is processed).
+			if err := next.PreExecute(ctx, ectx, r, g.vm.StateManager(), state, now); err != nil && !errors.Is(err, chain.ErrSequenceTooHigh) {
 				// Do not gossip invalid txs (may become invalid during normal block
 				// processing)
 				cont, restore, removeAcct := chain.HandlePreExecute(err)
@@ -238,12 +454,179 @@ func (g *Proposer) ForceGossip(ctx context.Context) error {
 		"gossiping transactions", zap.Int("txs", len(txs)),
 		zap.Uint64("preferred height", blk.Hght), zap.Duration("t", time.Since(start)),
 	)
-	return g.sendTxs(ctx, txs)
+	return g.gossipTxs(ctx, txs, false)
+}
+
+// Regossip re-sends txs that have sat in the mempool for at least
+// [RegossipMinAge] (and still pay the current unit price) to the next
+// proposers, even if we already sent those txs to them. This helps txs
+// propagate after a transient network partition (when our earlier gossip may
+// have been dropped).
+func (g *Proposer) Regossip(ctx context.Context) error {
+	ctx, span := g.vm.Tracer().Start(ctx, "Gossiper.Regossip")
+	defer span.End()
+
+	var (
+		txs   = []*chain.Transaction{}
+		size  = 0
+		start = time.Now()
+		now   = start.UnixMilli()
+		seen  = make(map[ids.ID]int64, len(g.firstSeen))
+	)
+	blk, err := g.vm.PreferredBlock(ctx)
+	if err != nil {
+		return err
+	}
+	ectx, err := chain.GenerateExecutionContext(
+		ctx,
+		now,
+		blk,
+		g.vm.Tracer(),
+		g.vm.Rules(now),
+	)
+	if err != nil {
+		return err
+	}
+	// Txs are only read (they are not popped like in [ForceGossip]), so
+	// regossip never races with block building for the contents of the
+	// mempool.
+	g.vm.Mempool().IterateByPrice(ctx, func(next *chain.Transaction) bool {
+		// Expired txs are removed from the mempool by [ForceGossip] and
+		// [BuildBlock]
+		if next.Base.Timestamp < now {
+			return true
+		}
+
+		// Only keep track of txs still in the mempool (so [firstSeen] never
+		// grows larger than it)
+		first, ok := g.firstSeen[next.ID()]
+		if !ok {
+			first = now
+		}
+		seen[next.ID()] = first
+		if now-first < g.cfg.RegossipMinAge {
+			return true
+		}
+
+		// Don't regossip txs that are about to expire, that we received from
+		// other nodes (see [ForceGossip]), or that can't be included in the
+		// next block anyways
+		if next.Base.Timestamp-now < g.cfg.GossipMinLife {
+			return true
+		}
+		if _, has := g.receivedTxs.Get(next.ID()); has {
+			return true
+		}
+		if ectx.NextUnitPrices.Exceeds(next.Base.MaxUnitPrices) {
+			return true
+		}
+
+		// Regossip up to [GossipMaxSize] (but keep iterating to record when
+		// the remaining txs were first seen)
+		txSize := next.Size()
+		if txSize+size > g.cfg.GossipMaxSize {
+			return true
+		}
+		txs = append(txs, next)
+		size += txSize
+		return true
+	})
+	g.firstSeen = seen
+	if len(txs) == 0 {
+		return nil
+	}
+	g.vm.Logger().Info(
+		"regossiping stale transactions", zap.Int("txs", len(txs)),
+		zap.Uint64("preferred height", blk.Hght), zap.Duration("t", time.Since(start)),
+	)
+	if err := g.gossipTxs(ctx, txs, true); err != nil {
+		return err
+	}
+	g.vm.RecordRegossip(len(txs))
+	return nil
+}
+
+// Forward queues [txs] to be sent directly to the next [ForwardProposerDiff]
+// proposers instead of waiting for the next gossip. This is used to reduce the
+// time it takes for txs submitted over RPC to be included in a block. Forward
+// never blocks: if [ForwardQueueSize] calls are already waiting to be sent, it
+// returns [ErrForwardQueueFull].
+//
+// [txs] will still be gossiped normally but will not be re-sent to any
+// proposer they were forwarded to. If the proposers can't be determined, [txs]
+// are only gossiped normally.
+//
+// Forward may be called concurrently with [Run] but returns [ErrNotRunning]
+// until [Run] has been called.
+func (g *Proposer) Forward(_ context.Context, txs []*chain.Transaction) error {
+	if g.cfg.ForwardProposerDiff == 0 || len(txs) == 0 {
+		return nil
+	}
+	if g.sender() == nil {
+		return ErrNotRunning
+	}
+	select {
+	case g.forwardQueue <- txs:
+		return nil
+	default:
+		return ErrForwardQueueFull
+	}
+}
+
+// forward sends the txs queued by [Forward] until [ctx] is cancelled.
+func (g *Proposer) forward(ctx context.Context) {
+	for {
+		select {
+		case txs := <-g.forwardQueue:
+			if err := g.forwardTxs(ctx, txs); err != nil {
+				g.vm.Logger().Warn("unable to forward txs", zap.Int("txs", len(txs)), zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (g *Proposer) forwardTxs(ctx context.Context, txs []*chain.Transaction) error {
+	ctx, span := g.vm.Tracer().Start(ctx, "Gossiper.Forward")
+	defer span.End()
+
+	proposers, err := g.vm.Proposers(ctx, g.cfg.ForwardProposerDiff, g.cfg.ForwardProposerDepth)
+	if err != nil || proposers.Len() == 0 {
+		// Sending [txs] to all peers would defeat the purpose of forwarding
+		// (they will be gossiped normally instead)
+		g.vm.Logger().Debug(
+			"unable to find any proposers, not forwarding txs",
+			zap.Int("txs", len(txs)),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return g.sendProposers(ctx, proposers, txs, false)
 }
 
 func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	// Drop identical messages (often the same batch relayed by multiple peers)
+	// before performing any deserialization
+	now := time.Now().UnixMilli()
+	g.recentGossip.SetMin(now)
+	if g.cfg.GossipSuppressionWindow > 0 && g.suppress(utils.ToID(msg), now) {
+		g.vm.RecordSuppressedGossip(1, 0)
+		g.vm.Logger().Debug("dropping duplicate gossip message", zap.Stringer("peerID", nodeID))
+		return nil
+	}
+
+	b, err := unpackFrame(msg)
+	if err != nil {
+		g.vm.Logger().Warn(
+			"received invalid gossip frame",
+			zap.Stringer("peerID", nodeID),
+			zap.Error(err),
+		)
+		return nil
+	}
 	actionRegistry, authRegistry := g.vm.Registry()
-	txs, err := chain.UnmarshalTxs(msg, initialCapacity, actionRegistry, authRegistry)
+	txs, err := chain.UnmarshalTxs(b, initialCapacity, actionRegistry, authRegistry)
 	if err != nil {
 		g.vm.Logger().Warn(
 			"received invalid txs",
@@ -253,6 +636,30 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 		return nil
 	}
 
+	// Drop txs we recently received in another message before performing
+	// signature verification
+	if g.cfg.GossipSuppressionWindow > 0 {
+		fresh := make([]*chain.Transaction, 0, len(txs))
+		for _, tx := range txs {
+			if g.suppress(tx.ID(), now) {
+				continue
+			}
+			fresh = append(fresh, tx)
+		}
+		if suppressed := len(txs) - len(fresh); suppressed > 0 {
+			g.vm.RecordSuppressedGossip(0, suppressed)
+			g.vm.Logger().Debug(
+				"dropping duplicate gossiped txs",
+				zap.Stringer("peerID", nodeID),
+				zap.Int("txs", suppressed),
+			)
+		}
+		txs = fresh
+		if len(txs) == 0 {
+			return nil
+		}
+	}
+
 	// Mark incoming gossip as held by [nodeID], if it is a validator
 	isValidator, err := g.vm.IsValidator(ctx, nodeID)
 	if err != nil {
@@ -264,12 +671,7 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 	}
 	var c *cache.LRU[ids.ID, struct{}]
 	if isValidator {
-		var ok bool
-		c, ok = g.gossipedTxs[nodeID]
-		if !ok {
-			g.gossipedTxs[nodeID] = &cache.LRU[ids.ID, struct{}]{Size: g.cfg.GossipPeerCacheSize}
-			c = g.gossipedTxs[nodeID]
-		}
+		c = g.peerCache(nodeID)
 	}
 
 	// Add incoming transactions to our caches to prevent useless gossip
@@ -302,41 +704,119 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 	return nil
 }
 
+func (g *Proposer) SetFilterSender(sender common.AppSender) {
+	g.filterSender = sender
+}
+
+// HandleFilter records the mempool filter of [nodeID] (if it is a validator).
+// Txs in the filter are not gossiped to [nodeID] until it sends a new filter.
+func (g *Proposer) HandleFilter(ctx context.Context, nodeID ids.NodeID, filter []byte) error {
+	isValidator, err := g.vm.IsValidator(ctx, nodeID)
+	if err != nil {
+		g.vm.Logger().Warn(
+			"unable to determine if nodeID is validator",
+			zap.Stringer("peerID", nodeID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	if !isValidator {
+		return nil
+	}
+	g.gossipedL.Lock()
+	defer g.gossipedL.Unlock()
+
+	g.peerFilters[nodeID] = filter
+	return nil
+}
+
+// sendFilter sends our mempool filter to the proposers we gossip to.
+func (g *Proposer) sendFilter(ctx context.Context) {
+	proposers, err := g.vm.Proposers(ctx, g.cfg.GossipProposerDiff, g.cfg.GossipProposerDepth)
+	if err != nil {
+		g.vm.Logger().Warn("unable to find proposers to send filter to", zap.Error(err))
+		return
+	}
+	proposers.Remove(g.vm.NodeID())
+	if proposers.Len() == 0 {
+		return
+	}
+	if err := g.filterSender.SendAppGossipSpecific(ctx, proposers, g.vm.MempoolFilter()); err != nil {
+		g.vm.Logger().Warn("unable to send mempool filter", zap.Error(err))
+	}
+}
+
 // periodically but less aggressively force-regossip the pending
 func (g *Proposer) Run(appSender common.AppSender) {
+	g.senderL.Lock()
 	g.appSender = appSender
+	g.senderL.Unlock()
 
-	g.vm.Logger().Info("starting gossiper", zap.Duration("interval", g.cfg.GossipInterval))
+	g.vm.Logger().Info(
+		"starting gossiper",
+		zap.Stringer("policy", g.cfg.GossipPolicy),
+		zap.Duration("interval", g.cfg.GossipInterval),
+		zap.Duration("flush interval", g.cfg.GossipFlushInterval),
+		zap.Duration("regossip interval", g.cfg.RegossipInterval),
+	)
 	defer close(g.doneGossip)
 
+	// Forwarded txs are sent on their own goroutine (so they aren't delayed by
+	// gossip), which is stopped before [Done] returns
+	fctx, cancel := context.WithCancel(context.Background())
+	forwardDone := make(chan struct{})
+	go func() {
+		defer close(forwardDone)
+		g.forward(fctx)
+	}()
+	defer func() {
+		cancel()
+		<-forwardDone
+	}()
+
 	t := time.NewTicker(g.cfg.GossipInterval)
 	defer t.Stop()
+
+	// If early flushing is disabled, [flush] is never populated
+	var flush <-chan time.Time
+	if g.cfg.GossipFlushInterval > 0 {
+		ft := time.NewTicker(g.cfg.GossipFlushInterval)
+		defer ft.Stop()
+		flush = ft.C
+	}
+
+	// If filter exchange is disabled, [filter] is never populated
+	var filter <-chan time.Time
+	if g.filterSender != nil && g.cfg.GossipFilterInterval > 0 {
+		ft := time.NewTicker(g.cfg.GossipFilterInterval)
+		defer ft.Stop()
+		filter = ft.C
+	}
+
+	// If regossip is disabled, [regossip] is never populated
+	var regossip <-chan time.Time
+	if g.cfg.RegossipInterval > 0 {
+		rt := time.NewTicker(g.cfg.RegossipInterval)
+		defer rt.Stop()
+		regossip = rt.C
+	}
 	for {
 		select {
 		case <-t.C:
+			g.gossip(context.Background())
+		case <-flush:
+			// Only flush before [GossipInterval] if we have enough txs to fill a
+			// batch
 			tctx := context.Background()
-
-			// Check if we are going to propose if it has been less than
-			// [VerifyTimeout] since the last time we verified a block.
-			if time.Now().UnixMilli()-g.lastVerified < g.cfg.VerifyTimeout {
-				proposers, err := g.vm.Proposers(
-					tctx,
-					g.cfg.BuildProposerDiff,
-					1,
-				)
-				if err == nil && proposers.Contains(g.vm.NodeID()) {
-					g.vm.Logger().Debug("not gossiping because soon to propose")
-					continue
-				} else if err != nil {
-					g.vm.Logger().Warn("unable to determine if will propose soon, gossiping anyways", zap.Error(err))
-				}
-			} else {
-				g.vm.Logger().Info("gossiping because past verify timeout")
+			if g.vm.Mempool().Len(tctx) < g.cfg.GossipBatchSize {
+				continue
 			}
-
-			// Gossip to proposers who will produce next
-			if err := g.ForceGossip(tctx); err != nil {
-				g.vm.Logger().Warn("gossip txs failed", zap.Error(err))
+			g.gossip(tctx)
+		case <-filter:
+			g.sendFilter(context.Background())
+		case <-regossip:
+			if err := g.Regossip(context.Background()); err != nil {
+				g.vm.Logger().Warn("regossip txs failed", zap.Error(err))
 			}
 		case <-g.vm.StopChan():
 			g.vm.Logger().Info("stopping gossip loop")
@@ -345,6 +825,31 @@ func (g *Proposer) Run(appSender common.AppSender) {
 	}
 }
 
+func (g *Proposer) gossip(ctx context.Context) {
+	// Check if we are going to propose if it has been less than
+	// [VerifyTimeout] since the last time we verified a block.
+	if time.Now().UnixMilli()-g.lastVerified < g.cfg.VerifyTimeout {
+		proposers, err := g.vm.Proposers(
+			ctx,
+			g.cfg.BuildProposerDiff,
+			1,
+		)
+		if err == nil && proposers.Contains(g.vm.NodeID()) {
+			g.vm.Logger().Debug("not gossiping because soon to propose")
+			return
+		} else if err != nil {
+			g.vm.Logger().Warn("unable to determine if will propose soon, gossiping anyways", zap.Error(err))
+		}
+	} else {
+		g.vm.Logger().Info("gossiping because past verify timeout")
+	}
+
+	// Gossip to proposers who will produce next
+	if err := g.ForceGossip(ctx); err != nil {
+		g.vm.Logger().Warn("gossip txs failed", zap.Error(err))
+	}
+}
+
 func (g *Proposer) BlockVerified(t int64) {
 	if t < g.lastVerified {
 		return
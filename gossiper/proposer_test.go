@@ -0,0 +1,492 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/mempool"
+	htrace "github.com/ava-labs/hypersdk/trace"
+)
+
+var testPrices = fees.Dimensions{1, 1, 1, 1, 1}
+
+type testVM struct {
+	t *testing.T
+
+	chainID   ids.ID
+	nodeID    ids.NodeID
+	proposers set.Set[ids.NodeID]
+	parent    *chain.StatelessBlock
+	tracer    trace.Tracer
+	rules     *chain.MockRules
+	mempool   *mempool.Mempool[*chain.Transaction]
+	stop      chan struct{}
+
+	action         *chain.MockAction
+	factory        *chain.MockAuthFactory
+	actionRegistry chain.ActionRegistry
+	authRegistry   chain.AuthRegistry
+
+	l              sync.Mutex
+	batches        []int
+	submitted      []ids.ID
+	suppressedMsgs int
+	suppressedTxs  int
+	regossiped     int
+}
+
+func newTestVM(t *testing.T, ctrl *gomock.Controller) *testVM {
+	require := require.New(t)
+
+	tracer, err := htrace.New(&htrace.Config{Enabled: false})
+	require.NoError(err)
+
+	// Actions and auth don't marshal any bytes (so txs only differ by their
+	// [chain.Base])
+	action := chain.NewMockAction(ctrl)
+	action.EXPECT().Size().Return(0).AnyTimes()
+	action.EXPECT().Marshal(gomock.Any()).AnyTimes()
+	auth := chain.NewMockAuth(ctrl)
+	auth.EXPECT().Size().Return(0).AnyTimes()
+	auth.EXPECT().Marshal(gomock.Any()).AnyTimes()
+	auth.EXPECT().Payer().Return([]byte("payer")).AnyTimes()
+	factory := chain.NewMockAuthFactory(ctrl)
+	factory.EXPECT().Sign(gomock.Any(), gomock.Any()).Return(auth, nil).AnyTimes()
+
+	actionRegistry := codec.NewTypeParser[chain.Action, *warp.Message, bool]()
+	require.NoError(actionRegistry.Register(action, func(*codec.Packer, *warp.Message) (chain.Action, error) {
+		return action, nil
+	}, false))
+	authRegistry := codec.NewTypeParser[chain.Auth, *warp.Message, bool]()
+	require.NoError(authRegistry.Register(auth, func(*codec.Packer, *warp.Message) (chain.Auth, error) {
+		return auth, nil
+	}, false))
+
+	// Unit prices never change
+	rules := chain.NewMockRules(ctrl)
+	rules.EXPECT().GetWindowTargetUnits().Return(fees.Dimensions{}).AnyTimes()
+	rules.EXPECT().GetUnitPriceChangeDenominator().Return(testPrices).AnyTimes()
+	rules.EXPECT().GetMinUnitPrice().Return(testPrices).AnyTimes()
+
+	return &testVM{
+		t:         t,
+		chainID:   ids.GenerateTestID(),
+		nodeID:    ids.GenerateTestNodeID(),
+		proposers: set.Set[ids.NodeID]{},
+		parent: &chain.StatelessBlock{
+			StatefulBlock: &chain.StatefulBlock{
+				Tmstmp:     time.Now().UnixMilli(),
+				UnitPrices: testPrices,
+			},
+		},
+		tracer:  tracer,
+		rules:   rules,
+		mempool: mempool.New[*chain.Transaction](tracer, 100, 0, mempool.FixedQuota(100), nil),
+		stop:    make(chan struct{}),
+
+		action:         action,
+		factory:        factory,
+		actionRegistry: actionRegistry,
+		authRegistry:   authRegistry,
+	}
+}
+
+// newTx returns a signed tx that expires at [expiry] (which must be a
+// multiple of a second).
+func (vm *testVM) newTx(expiry int64, prices fees.Dimensions) *chain.Transaction {
+	tx, err := chain.NewTx(
+		&chain.Base{Timestamp: expiry, ChainID: vm.chainID, MaxUnitPrices: prices},
+		nil,
+		vm.action,
+	).Sign(vm.factory, vm.actionRegistry, vm.authRegistry)
+	require.NoError(vm.t, err)
+	return tx
+}
+
+// sender returns an [common.AppSender] that records the IDs of the txs
+// gossiped to each node in [sent].
+// sender records the IDs of the txs sent to each node in [sent] (which must
+// only be read while holding [vm.l] if txs are sent concurrently).
+func (vm *testVM) sender(sent map[ids.NodeID][]ids.ID) common.AppSender {
+	return &common.SenderTest{
+		T: vm.t,
+		SendAppGossipSpecificF: func(_ context.Context, nodeIDs set.Set[ids.NodeID], msg []byte) error {
+			b, err := unpackFrame(msg)
+			require.NoError(vm.t, err)
+			txs, err := chain.UnmarshalTxs(b, initialCapacity, vm.actionRegistry, vm.authRegistry)
+			require.NoError(vm.t, err)
+
+			vm.l.Lock()
+			defer vm.l.Unlock()
+			for nodeID := range nodeIDs {
+				for _, tx := range txs {
+					sent[nodeID] = append(sent[nodeID], tx.ID())
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func (vm *testVM) NetworkID() uint32             { return 1 }
+func (vm *testVM) ChainID() ids.ID               { return vm.chainID }
+func (vm *testVM) StopChan() chan struct{}       { return vm.stop }
+func (vm *testVM) Tracer() trace.Tracer          { return vm.tracer }
+func (vm *testVM) Mempool() chain.Mempool        { return vm.mempool }
+func (vm *testVM) Logger() logging.Logger        { return logging.NoLog{} }
+func (vm *testVM) NodeID() ids.NodeID            { return vm.nodeID }
+func (vm *testVM) Rules(int64) chain.Rules       { return vm.rules }
+func (*testVM) StateManager() chain.StateManager { return nil }
+func (*testVM) MempoolFilter() []byte            { return nil }
+
+func (vm *testVM) Proposers(context.Context, int, int) (set.Set[ids.NodeID], error) {
+	return vm.proposers, nil
+}
+
+func (vm *testVM) IsValidator(_ context.Context, nodeID ids.NodeID) (bool, error) {
+	return vm.proposers.Contains(nodeID), nil
+}
+
+func (vm *testVM) PreferredBlock(context.Context) (*chain.StatelessBlock, error) {
+	return vm.parent, nil
+}
+
+func (vm *testVM) Registry() (chain.ActionRegistry, chain.AuthRegistry) {
+	return vm.actionRegistry, vm.authRegistry
+}
+
+func (vm *testVM) Submit(ctx context.Context, _ bool, txs []*chain.Transaction) []error {
+	vm.l.Lock()
+	for _, tx := range txs {
+		vm.submitted = append(vm.submitted, tx.ID())
+	}
+	vm.l.Unlock()
+
+	vm.mempool.Add(ctx, txs)
+	return make([]error, len(txs))
+}
+
+func (*testVM) RecordGossipCompression(float64) {}
+func (*testVM) RecordFilteredGossip(int)        {}
+
+func (vm *testVM) RecordGossipBatch(txs int, _ float64) {
+	vm.l.Lock()
+	defer vm.l.Unlock()
+
+	vm.batches = append(vm.batches, txs)
+}
+
+func (vm *testVM) RecordSuppressedGossip(msgs int, txs int) {
+	vm.l.Lock()
+	defer vm.l.Unlock()
+
+	vm.suppressedMsgs += msgs
+	vm.suppressedTxs += txs
+}
+
+func (vm *testVM) RecordRegossip(txs int) {
+	vm.l.Lock()
+	defer vm.l.Unlock()
+
+	vm.regossiped += txs
+}
+
+func TestProposerBatchTxs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vm := newTestVM(t, ctrl)
+	now := time.Now().UnixMilli() / 1000 * 1000
+	txs := make([]*chain.Transaction, 5)
+	for i := range txs {
+		txs[i] = vm.newTx(now+int64(i+1)*1_000, testPrices)
+	}
+	txSize := txs[0].Size()
+
+	tests := []struct {
+		name       string
+		batchSize  int
+		targetSize int
+		expected   []int
+	}{
+		{
+			name:       "limited by count",
+			batchSize:  2,
+			targetSize: 100 * txSize,
+			expected:   []int{2, 2, 1},
+		},
+		{
+			name:       "limited by size",
+			batchSize:  100,
+			targetSize: 3*txSize - 1,
+			expected:   []int{2, 2, 1},
+		},
+		{
+			name:       "exactly target size",
+			batchSize:  100,
+			targetSize: 3 * txSize,
+			expected:   []int{3, 2},
+		},
+		{
+			// Txs larger than [GossipTargetSize] are still sent
+			name:       "oversized txs",
+			batchSize:  100,
+			targetSize: txSize - 1,
+			expected:   []int{1, 1, 1, 1, 1},
+		},
+		{
+			name:       "single batch",
+			batchSize:  100,
+			targetSize: 100 * txSize,
+			expected:   []int{5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			vm.batches = nil
+			cfg := DefaultProposerConfig()
+			cfg.GossipBatchSize = tt.batchSize
+			cfg.GossipTargetSize = tt.targetSize
+			g := NewProposer(vm, cfg)
+
+			// Each batch is sent (and recorded) in order
+			var sent []ids.ID
+			require.NoError(g.sendBatches(txs, func(frame []byte) error {
+				b, err := unpackFrame(frame)
+				require.NoError(err)
+				batch, err := chain.UnmarshalTxs(b, initialCapacity, vm.actionRegistry, vm.authRegistry)
+				require.NoError(err)
+				for _, tx := range batch {
+					sent = append(sent, tx.ID())
+				}
+				return nil
+			}))
+			require.Equal(tt.expected, vm.batches)
+			require.Len(sent, len(txs))
+			for i, tx := range txs {
+				require.Equal(tx.ID(), sent[i])
+			}
+		})
+	}
+}
+
+func TestProposerSuppressGossip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	peer := ids.GenerateTestNodeID()
+	now := time.Now().UnixMilli() / 1000 * 1000
+
+	tests := []struct {
+		name           string
+		window         int64
+		submitted      int
+		suppressedMsgs int
+		suppressedTxs  int
+	}{
+		{
+			name:           "enabled",
+			window:         DefaultProposerConfig().GossipSuppressionWindow,
+			submitted:      3,
+			suppressedMsgs: 1,
+			suppressedTxs:  1,
+		},
+		{
+			name:      "disabled",
+			window:    0,
+			submitted: 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			vm := newTestVM(t, ctrl)
+			cfg := DefaultProposerConfig()
+			cfg.GossipSuppressionWindow = tt.window
+			g := NewProposer(vm, cfg)
+
+			a := vm.newTx(now+60_000, testPrices)
+			b := vm.newTx(now+61_000, testPrices)
+			c := vm.newTx(now+62_000, testPrices)
+			msg := func(txs ...*chain.Transaction) []byte {
+				raw, err := chain.MarshalTxs(txs, vm.actionRegistry, vm.authRegistry)
+				require.NoError(err)
+				frame, err := packFrame(raw, false)
+				require.NoError(err)
+				return frame
+			}
+
+			// The same message relayed by another peer is dropped entirely
+			// while a message with some new txs only drops the txs we already
+			// received
+			require.NoError(g.HandleAppGossip(ctx, peer, msg(a, b)))
+			require.NoError(g.HandleAppGossip(ctx, peer, msg(a, b)))
+			require.NoError(g.HandleAppGossip(ctx, peer, msg(b, c)))
+			require.Len(vm.submitted, tt.submitted)
+			require.Equal(tt.suppressedMsgs, vm.suppressedMsgs)
+			require.Equal(tt.suppressedTxs, vm.suppressedTxs)
+			if tt.window > 0 {
+				require.Equal([]ids.ID{a.ID(), b.ID(), c.ID()}, vm.submitted)
+			}
+		})
+	}
+}
+
+func TestProposerRegossip(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	vm := newTestVM(t, ctrl)
+	peer := ids.GenerateTestNodeID()
+	vm.proposers.Add(peer, vm.nodeID)
+
+	cfg := DefaultProposerConfig()
+	cfg.GossipCompression = false
+	g := NewProposer(vm, cfg)
+	sent := map[ids.NodeID][]ids.ID{}
+	g.appSender = vm.sender(sent)
+
+	now := time.Now().UnixMilli() / 1000 * 1000
+	var (
+		stale       = vm.newTx(now+60_000, testPrices)
+		expiring    = vm.newTx(now+cfg.GossipMinLife-2_000, testPrices)
+		received    = vm.newTx(now+61_000, testPrices)
+		underpriced = vm.newTx(now+62_000, fees.Dimensions{0, 1, 1, 1, 1})
+		fresh       = vm.newTx(now+63_000, testPrices)
+	)
+	vm.mempool.Add(ctx, []*chain.Transaction{stale, expiring, received, underpriced})
+	g.receivedTxs.Put(received.ID(), struct{}{})
+
+	// Nothing has been in the mempool for [RegossipMinAge]
+	require.NoError(g.Regossip(ctx))
+	require.Empty(sent)
+	require.Len(g.firstSeen, 4)
+
+	// Only [stale] is old enough, long-lived enough, paying enough, and not
+	// received from another node
+	for txID := range g.firstSeen {
+		g.firstSeen[txID] -= cfg.RegossipMinAge
+	}
+	vm.mempool.Add(ctx, []*chain.Transaction{fresh})
+	require.NoError(g.Regossip(ctx))
+	require.Equal(map[ids.NodeID][]ids.ID{peer: {stale.ID()}}, sent)
+	require.Equal(1, vm.regossiped)
+	require.Len(g.firstSeen, 5)
+
+	// Regossip doesn't remove anything from the mempool
+	require.Equal(5, vm.mempool.Len(ctx))
+
+	// Txs that are no longer in the mempool are forgotten
+	vm.mempool.Remove(ctx, []*chain.Transaction{stale})
+	require.NoError(g.Regossip(ctx))
+	require.Len(g.firstSeen, 4)
+	require.NotContains(g.firstSeen, stale.ID())
+}
+
+func TestProposerForward(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	vm := newTestVM(t, ctrl)
+	peer1 := ids.GenerateTestNodeID()
+	peer2 := ids.GenerateTestNodeID()
+	vm.proposers.Add(peer1, peer2, vm.nodeID)
+
+	// Only forward (don't gossip on a timer)
+	cfg := DefaultProposerConfig()
+	cfg.GossipInterval = time.Hour
+	cfg.GossipFlushInterval = 0
+	g := NewProposer(vm, cfg)
+
+	now := time.Now().UnixMilli() / 1000 * 1000
+	txs := []*chain.Transaction{
+		vm.newTx(now+60_000, testPrices),
+		vm.newTx(now+61_000, testPrices),
+	}
+
+	// Nothing can be forwarded before [Run] is called
+	require.ErrorIs(g.Forward(ctx, txs), ErrNotRunning)
+
+	sent := map[ids.NodeID][]ids.ID{}
+	go g.Run(vm.sender(sent))
+	defer func() {
+		close(vm.stop)
+		g.Done()
+	}()
+
+	// [Forward] may be called concurrently with [Run] (and txs are sent by
+	// the gossiper)
+	require.Eventually(func() bool {
+		return g.Forward(ctx, txs) == nil
+	}, time.Second, 10*time.Millisecond)
+	expected := []ids.ID{txs[0].ID(), txs[1].ID()}
+	sentTo := func() map[ids.NodeID][]ids.ID {
+		vm.l.Lock()
+		defer vm.l.Unlock()
+
+		copied := make(map[ids.NodeID][]ids.ID, len(sent))
+		for nodeID, txIDs := range sent {
+			copied[nodeID] = append([]ids.ID{}, txIDs...)
+		}
+		return copied
+	}
+	require.Eventually(func() bool {
+		sent := sentTo()
+		return len(sent[peer1]) == 2 && len(sent[peer2]) == 2
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(map[ids.NodeID][]ids.ID{peer1: expected, peer2: expected}, sentTo())
+
+	// Forwarded txs are not sent to the same proposers again (unless they are
+	// regossiped). Queued txs are sent in order, so once [next] is sent,
+	// [txs] were handled.
+	next := vm.newTx(now+62_000, testPrices)
+	require.NoError(g.Forward(ctx, txs))
+	require.NoError(g.Forward(ctx, []*chain.Transaction{next}))
+	require.Eventually(func() bool {
+		sent := sentTo()
+		return len(sent[peer1]) == 3 && len(sent[peer2]) == 3
+	}, time.Second, 10*time.Millisecond)
+	expected = append(expected, next.ID())
+	require.Equal(map[ids.NodeID][]ids.ID{peer1: expected, peer2: expected}, sentTo())
+	require.NoError(g.gossipTxs(ctx, txs, false))
+	require.Equal(map[ids.NodeID][]ids.ID{peer1: expected, peer2: expected}, sentTo())
+
+	// Txs aren't sent to all peers if the proposers are unknown ([SenderTest]
+	// fails the test if [SendAppGossip] is called)
+	proposers := vm.proposers
+	vm.proposers = set.Set[ids.NodeID]{}
+	require.NoError(g.forwardTxs(ctx, []*chain.Transaction{vm.newTx(now+63_000, testPrices)}))
+	vm.proposers = proposers
+	require.Equal(map[ids.NodeID][]ids.ID{peer1: expected, peer2: expected}, sentTo())
+
+	// Forwarding can be disabled
+	g.cfg.ForwardProposerDiff = 0
+	extra := vm.newTx(now+64_000, testPrices)
+	require.NoError(g.Forward(ctx, []*chain.Transaction{extra}))
+	require.Equal(map[ids.NodeID][]ids.ID{peer1: expected, peer2: expected}, sentTo())
+}
@@ -14,13 +14,24 @@ import (
 //
 // This data structure does not perform any synchronization and is not
 // safe to use concurrently without external locking.
-type Heap[I any, V constraints.Ordered] struct {
+type Heap[I any, V any] struct {
 	ih *innerHeap[I, V]
 }
 
 // New returns an instance of Heap[I,V]
 func New[I any, V constraints.Ordered](items int, isMinHeap bool) *Heap[I, V] {
-	return &Heap[I, V]{newInnerHeap[I, V](items, isMinHeap)}
+	if isMinHeap {
+		return NewFunc[I](items, func(a, b V) bool { return a < b })
+	}
+	return NewFunc[I](items, func(a, b V) bool { return a > b })
+}
+
+// NewFunc returns an instance of Heap[I,V] ordered by [less], which returns
+// true if [a] should be returned by [First] before [b]. This allows
+// [Val] to be any type (like a multi-dimensional fee or a tuple with
+// tiebreakers).
+func NewFunc[I any, V any](items int, less func(a, b V) bool) *Heap[I, V] {
+	return &Heap[I, V]{newInnerHeap[I, V](items, less)}
 }
 
 // Len returns the number of items in ih.
@@ -70,7 +81,8 @@ func (h *Heap[I, V]) Remove(index int) *Entry[I, V] {
 }
 
 // First returns the first item in the heap. This is the smallest item in
-// a minHeap and the largest item in a maxHeap.
+// a minHeap, the largest item in a maxHeap, and the item that is less than all
+// others in a heap created with [NewFunc].
 //
 // If no items are in the heap, it will return nil.
 func (h *Heap[I, V]) First() *Entry[I, V] {
@@ -79,3 +91,48 @@ func (h *Heap[I, V]) First() *Entry[I, V] {
 	}
 	return h.ih.items[0]
 }
+
+// Top returns (up to) the first [n] items in the heap in order (smallest first
+// in a minHeap and largest first in a maxHeap) without modifying the heap.
+//
+// Top runs in O(n log n), regardless of the number of items in the heap.
+func (h *Heap[I, V]) Top(n int) []*Entry[I, V] {
+	items := h.ih.items
+	if n > len(items) {
+		n = len(items)
+	}
+	top := make([]*Entry[I, V], 0, n)
+	if n <= 0 {
+		return top
+	}
+
+	// [frontier] contains the indices of all entries that could be next (the
+	// children of entries that have already been returned)
+	frontier := &indexHeap[I, V]{ih: h.ih, indices: []int{0}}
+	for len(top) < n {
+		i := heap.Pop(frontier).(int)
+		top = append(top, items[i])
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(items) {
+				heap.Push(frontier, child)
+			}
+		}
+	}
+	return top
+}
+
+// indexHeap orders indices of [ih] using the order of [ih].
+type indexHeap[I any, V any] struct {
+	ih      *innerHeap[I, V]
+	indices []int
+}
+
+func (x *indexHeap[I, V]) Len() int           { return len(x.indices) }
+func (x *indexHeap[I, V]) Less(i, j int) bool { return x.ih.Less(x.indices[i], x.indices[j]) }
+func (x *indexHeap[I, V]) Swap(i, j int)      { x.indices[i], x.indices[j] = x.indices[j], x.indices[i] }
+func (x *indexHeap[I, V]) Push(v any)         { x.indices = append(x.indices, v.(int)) }
+func (x *indexHeap[I, V]) Pop() any {
+	last := x.indices[len(x.indices)-1]
+	x.indices = x.indices[:len(x.indices)-1]
+	return last
+}
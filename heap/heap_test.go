@@ -4,6 +4,7 @@
 package heap
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -181,3 +182,63 @@ func TestUnit64HeapHasID(t *testing.T) {
 	ok = minHeap.Has(mempoolItem.id)
 	require.True(ok, "Entry was not found in heap.")
 }
+
+func TestUnit64HeapTop(t *testing.T) {
+	require := require.New(t)
+	maxHeap := New[*testItem, uint64](0, false)
+	require.Empty(maxHeap.Top(3))
+
+	values := []uint64{5, 12, 1, 9, 20, 3, 15, 7}
+	for _, v := range values {
+		item := &testItem{ids.GenerateTestID(), v}
+		maxHeap.Push(&Entry[*testItem, uint64]{
+			ID:    item.id,
+			Item:  item,
+			Val:   item.value,
+			Index: maxHeap.Len(),
+		})
+	}
+	first := maxHeap.First()
+
+	top := maxHeap.Top(4)
+	require.Len(top, 4)
+	for i, v := range []uint64{20, 15, 12, 9} {
+		require.Equal(v, top[i].Val)
+	}
+
+	// Top does not modify the heap
+	require.Equal(len(values), maxHeap.Len())
+	require.Equal(first, maxHeap.First())
+
+	// Asking for more items than in the heap returns all items
+	require.Len(maxHeap.Top(100), len(values))
+}
+
+func TestHeapFunc(t *testing.T) {
+	require := require.New(t)
+
+	// Order by value and then by ID (descending)
+	h := NewFunc[*testItem](0, func(a, b *testItem) bool {
+		if a.value != b.value {
+			return a.value < b.value
+		}
+		return bytes.Compare(a.id[:], b.id[:]) > 0
+	})
+	items := []*testItem{
+		{ids.ID{1}, 10},
+		{ids.ID{2}, 5},
+		{ids.ID{3}, 5},
+	}
+	for _, item := range items {
+		h.Push(&Entry[*testItem, *testItem]{
+			ID:    item.id,
+			Item:  item,
+			Val:   item,
+			Index: h.Len(),
+		})
+	}
+	for _, expected := range []*testItem{items[2], items[1], items[0]} {
+		require.Equal(expected, h.Pop().Item)
+	}
+	require.Nil(h.Pop())
+}
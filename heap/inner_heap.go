@@ -8,12 +8,11 @@ import (
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
-	"golang.org/x/exp/constraints"
 )
 
 var _ heap.Interface = (*innerHeap[any, uint64])(nil)
 
-type Entry[I any, V constraints.Ordered] struct {
+type Entry[I any, V any] struct {
 	ID   ids.ID // id of entry
 	Item I      // associated item
 	Val  V      // Value to be prioritized
@@ -21,15 +20,15 @@ type Entry[I any, V constraints.Ordered] struct {
 	Index int // Index of the entry in heap
 }
 
-type innerHeap[I any, V constraints.Ordered] struct {
-	isMinHeap bool                    // true for Min-Heap, false for Max-Heap
-	items     []*Entry[I, V]          // items in this heap
-	lookup    map[ids.ID]*Entry[I, V] // ids in the heap mapping to an entry
+type innerHeap[I any, V any] struct {
+	less   func(a, b V) bool       // true if [a] should be closer to the top than [b]
+	items  []*Entry[I, V]          // items in this heap
+	lookup map[ids.ID]*Entry[I, V] // ids in the heap mapping to an entry
 }
 
-func newInnerHeap[I any, V constraints.Ordered](items int, isMinHeap bool) *innerHeap[I, V] {
+func newInnerHeap[I any, V any](items int, less func(a, b V) bool) *innerHeap[I, V] {
 	return &innerHeap[I, V]{
-		isMinHeap: isMinHeap,
+		less: less,
 
 		items:  make([]*Entry[I, V], 0, items),
 		lookup: make(map[ids.ID]*Entry[I, V], items),
@@ -39,15 +38,12 @@ func newInnerHeap[I any, V constraints.Ordered](items int, isMinHeap bool) *inne
 // Len returns the number of items in ih.
 func (ih *innerHeap[I, V]) Len() int { return len(ih.items) }
 
-// Less compares the priority of [i] and [j] based on th.isMinHeap.
+// Less compares the priority of [i] and [j] based on th.less.
 //
 // This should never be called by an external caller and is required to
 // confirm to `heap.Interface`.
 func (ih *innerHeap[I, V]) Less(i, j int) bool {
-	if ih.isMinHeap {
-		return ih.items[i].Val < ih.items[j].Val
-	}
-	return ih.items[i].Val > ih.items[j].Val
+	return ih.less(ih.items[i].Val, ih.items[j].Val)
 }
 
 // Swap swaps the [i]th and [j]th element in th.
@@ -0,0 +1,139 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	// bloomBitsPerItem and bloomHashes result in a false positive rate of ~1%
+	// when both generations of a [Bloom] are full (and merged by [Bytes]).
+	bloomBitsPerItem = 20
+	bloomHashes      = 7
+)
+
+// Bloom is a rolling bloom filter of item IDs. Items are added to the current
+// generation until it contains [capacity] items, at which point it becomes the
+// previous generation (and the old previous generation is discarded).
+//
+// Because item IDs are already hashes, they are not hashed again.
+type Bloom struct {
+	l sync.RWMutex
+
+	capacity int
+	count    int
+	current  []byte
+	previous []byte
+}
+
+// NewBloom creates a [Bloom] where each generation holds up to [capacity]
+// items.
+func NewBloom(capacity int) *Bloom {
+	size := (capacity*bloomBitsPerItem + 7) / 8
+	if size == 0 {
+		size = 1
+	}
+	return &Bloom{
+		capacity: capacity,
+		current:  make([]byte, size),
+		previous: make([]byte, size),
+	}
+}
+
+// bloomBits returns the bits of a filter of [size] bytes that are set for
+// [id].
+func bloomBits(size int, id ids.ID) [bloomHashes]uint32 {
+	m := uint32(size * 8)
+	var bits [bloomHashes]uint32
+	for i := range bits {
+		bits[i] = binary.BigEndian.Uint32(id[i*4:]) % m
+	}
+	return bits
+}
+
+// Add adds [items] to the current generation, rotating generations whenever
+// it is full.
+func (b *Bloom) Add(items ...ids.ID) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	for _, id := range items {
+		if b.count >= b.capacity {
+			b.rotate()
+		}
+		for _, bit := range bloomBits(len(b.current), id) {
+			b.current[bit/8] |= 1 << (bit % 8)
+		}
+		b.count++
+	}
+}
+
+// Rotate discards the previous generation and makes the current generation
+// the previous generation.
+func (b *Bloom) Rotate() {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.rotate()
+}
+
+func (b *Bloom) rotate() {
+	b.previous, b.current = b.current, b.previous
+	for i := range b.current {
+		b.current[i] = 0
+	}
+	b.count = 0
+}
+
+// Full returns true if the next call to [Add] will rotate generations.
+func (b *Bloom) Full() bool {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	return b.count >= b.capacity
+}
+
+// Has returns false if [id] was definitely not added to either generation.
+func (b *Bloom) Has(id ids.ID) bool {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	for _, filter := range [][]byte{b.current, b.previous} {
+		if BloomContains(filter, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Bytes returns a filter that contains all items in either generation (that
+// can be checked with [BloomContains]).
+func (b *Bloom) Bytes() []byte {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	filter := make([]byte, len(b.current))
+	for i := range filter {
+		filter[i] = b.current[i] | b.previous[i]
+	}
+	return filter
+}
+
+// BloomContains returns false if [id] was definitely not added to the
+// [filter] returned by [Bloom.Bytes].
+func BloomContains(filter []byte, id ids.ID) bool {
+	if len(filter) == 0 {
+		return false
+	}
+	for _, bit := range bloomBits(len(filter), id) {
+		if filter[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloom(t *testing.T) {
+	require := require.New(t)
+
+	itemIDs := make([]ids.ID, 30)
+	for i := range itemIDs {
+		itemIDs[i] = hashing.ComputeHash256Array([]byte{byte(i)})
+	}
+	b := NewBloom(10)
+	require.False(b.Has(itemIDs[0]))
+	require.False(BloomContains(b.Bytes(), itemIDs[0]))
+	require.False(BloomContains(nil, itemIDs[0]))
+
+	// Items are kept for 2 generations
+	b.Add(itemIDs[:10]...)
+	require.True(b.Full())
+	b.Add(itemIDs[10:20]...)
+	filter := b.Bytes()
+	for _, id := range itemIDs[:20] {
+		require.True(b.Has(id))
+		require.True(BloomContains(filter, id))
+	}
+
+	// Items are dropped after 2 generations (some may remain because of false
+	// positives)
+	b.Add(itemIDs[20:]...)
+	filter = b.Bytes()
+	for _, id := range itemIDs[10:] {
+		require.True(BloomContains(filter, id))
+	}
+	remaining := 0
+	for _, id := range itemIDs[:10] {
+		if BloomContains(filter, id) {
+			remaining++
+		}
+	}
+	require.Less(remaining, 3)
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// dropReasonsSize is the number of items whose [DropReason] is retained by a
+// [Mempool] (the reasons of the least recently dropped items are forgotten
+// first).
+const dropReasonsSize = 16_384
+
+// DropReason describes why an item left (or was never added to) a [Mempool].
+type DropReason uint8
+
+const (
+	DropUnknown DropReason = iota
+	// DropIncluded is recorded when an item is removed because it was
+	// included in a block (see [Mempool.Remove]).
+	DropIncluded
+	// DropBuilt is recorded when an item is popped (and not restored) while
+	// building a block. It was either included in the block or found to be
+	// invalid.
+	DropBuilt
+	// DropExpired is recorded when an item is purged because it expired.
+	DropExpired
+	// DropEvicted is recorded when an item is evicted because the mempool was
+	// full and it was selected by the [EvictionPolicy] (by default, the
+	// lowest paying item).
+	DropEvicted
+	// DropPayerQuota is recorded when an item is not added because its payer
+	// already has as many items as their quota allows.
+	DropPayerQuota
+	// DropAccountRemoved is recorded when all items of a payer are removed
+	// (usually because their balance can no longer cover them).
+	DropAccountRemoved
+	// DropBanned is recorded when an item is removed (or not added) because
+	// its payer is banned.
+	DropBanned
+	// DropSwept is recorded when an item is found to be invalid by [Sweep].
+	DropSwept
+	// DropPaused is recorded when an item is not added because the mempool is
+	// paused and its overflow buffer is full (see [Mempool.Pause]).
+	DropPaused
+	// DropTooLarge is recorded when an item is not added because it alone
+	// exceeds the byte limit of the mempool.
+	DropTooLarge
+)
+
+func (r DropReason) String() string {
+	switch r {
+	case DropIncluded:
+		return "included"
+	case DropBuilt:
+		return "built"
+	case DropExpired:
+		return "expired"
+	case DropEvicted:
+		return "evicted"
+	case DropPayerQuota:
+		return "payer quota"
+	case DropAccountRemoved:
+		return "account removed"
+	case DropBanned:
+		return "banned"
+	case DropSwept:
+		return "swept"
+	case DropPaused:
+		return "paused"
+	case DropTooLarge:
+		return "too large"
+	default:
+		return "unknown"
+	}
+}
+
+// DropReason returns why the item with [id] left th (if it was recently
+// dropped). Items that are added back to th (for example, after a block is
+// rejected) no longer have a reason.
+func (th *Mempool[T]) DropReason(ctx context.Context, id ids.ID) (DropReason, bool) {
+	_, span := th.tracer.Start(ctx, "Mempool.DropReason")
+	defer span.End()
+
+	return th.drops.Get(id)
+}
+
+// drop records [reason] for each of [items].
+func (th *Mempool[T]) drop(reason DropReason, items []T) {
+	for _, item := range items {
+		th.drops.Put(item.ID(), reason)
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import "errors"
+
+var (
+	ErrNoncesUnsupported = errors.New("items do not have nonces")
+
+	// ErrExpired can be returned by the function passed to [Mempool.Build] to
+	// indicate that the item it was passed expired. Unlike other errors, it
+	// does not stop building.
+	ErrExpired = errors.New("expired")
+)
@@ -0,0 +1,140 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// subscriptionBuffer is the number of events buffered for each subscriber
+// before the oldest are dropped.
+const subscriptionBuffer = 1_024
+
+type EventKind uint8
+
+const (
+	// EventAdded is emitted when an item is added to the mempool.
+	EventAdded EventKind = iota
+	// EventRemoved is emitted when an item leaves the mempool for any reason
+	// other than expiry (included in a block, evicted, swept, or dropped
+	// during building).
+	EventRemoved
+	// EventExpired is emitted when an item is purged because it expired.
+	EventExpired
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventAdded:
+		return "added"
+	case EventRemoved:
+		return "removed"
+	case EventExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a change to the contents of a [Mempool].
+type Event[T Item] struct {
+	Kind EventKind
+	Item T
+}
+
+// Subscription receives all events emitted by a [Mempool] after it was
+// created (in the order they occurred).
+//
+// Events are never blocked on a slow subscriber. If a subscriber falls more
+// than [subscriptionBuffer] events behind, the oldest buffered event is
+// dropped to make room for the newest.
+type Subscription[T Item] struct {
+	th      *Mempool[T]
+	events  chan Event[T]
+	dropped atomic.Uint64
+}
+
+// Events returns the channel events are delivered on. It is closed by
+// [Subscription.Close].
+func (s *Subscription[T]) Events() <-chan Event[T] {
+	return s.events
+}
+
+// Dropped returns the number of events dropped because the subscriber fell
+// behind.
+func (s *Subscription[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close stops delivery of events to s and closes its channel.
+func (s *Subscription[T]) Close() {
+	s.th.mu.Lock()
+	defer s.th.mu.Unlock()
+
+	if _, ok := s.th.subscriptions[s]; !ok {
+		return
+	}
+	delete(s.th.subscriptions, s)
+	close(s.events)
+}
+
+// send delivers [e] to s, dropping the oldest buffered event if s is full.
+func (s *Subscription[T]) send(e Event[T], dropped prometheus.Counter) {
+	for {
+		select {
+		case s.events <- e:
+			return
+		default:
+		}
+		select {
+		case <-s.events:
+			s.dropped.Add(1)
+			if dropped != nil {
+				dropped.Inc()
+			}
+		default:
+			// The subscriber drained the channel since we tried to send
+		}
+	}
+}
+
+// Subscribe returns a [Subscription] to all changes to th. Subscribers should
+// call [Subscription.Close] when they no longer need events.
+func (th *Mempool[T]) Subscribe() *Subscription[T] {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	s := &Subscription[T]{
+		th:     th,
+		events: make(chan Event[T], subscriptionBuffer),
+	}
+	th.subscriptions[s] = struct{}{}
+	return s
+}
+
+// SetDroppedEvents increments [counter] whenever an event is dropped because a
+// subscriber fell behind.
+func (th *Mempool[T]) SetDroppedEvents(counter prometheus.Counter) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	th.droppedEvents = counter
+}
+
+// publish delivers an event of [kind] for each of [items] to all subscribers.
+//
+// Assumes th.mu is held.
+func (th *Mempool[T]) publish(kind EventKind, items []T) {
+	if len(th.subscriptions) == 0 {
+		return
+	}
+	for _, item := range items {
+		e := Event[T]{Kind: kind, Item: item}
+		for s := range th.subscriptions {
+			s.send(e, th.droppedEvents)
+		}
+	}
+}
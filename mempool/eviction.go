@@ -0,0 +1,156 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+// EvictionPolicy selects which item to evict when a [Mempool] holds more than
+// its maximum number of items (or bytes).
+//
+// When nonce ordering is enabled, the highest nonce item of the payer of the
+// selected item is evicted instead (so that their remaining items can still be
+// executed).
+type EvictionPolicy[T Item] interface {
+	// Victim returns the item to evict from [view]. [bytes] is true if the
+	// mempool is over its byte limit (rather than its item limit). [view]
+	// always contains at least one item.
+	Victim(view EvictionView[T], bytes bool) T
+}
+
+// EvictionView provides read-only access to the contents of a [Mempool] to an
+// [EvictionPolicy]. It must not be retained after [EvictionPolicy.Victim]
+// returns.
+type EvictionView[T Item] interface {
+	// Cheapest returns the lowest valued item (of those that can be built).
+	Cheapest() T
+	// CheapestPerByte returns the item that pays the least per byte (or the
+	// lowest valued item if the mempool has no byte limit).
+	CheapestPerByte() T
+	// Oldest returns the item with the earliest expiry.
+	Oldest() T
+	// BusiestPayer returns the payer with the most items (ties are broken
+	// arbitrarily). This takes O(payers).
+	BusiestPayer() string
+	// PayerItems returns all items from [payer] (in no particular order).
+	PayerItems(payer string) []T
+	// Compare is how the mempool values items.
+	Compare(a, b T) int
+}
+
+// EvictionPolicyFunc adapts a function to an [EvictionPolicy].
+type EvictionPolicyFunc[T Item] func(view EvictionView[T], bytes bool) T
+
+func (f EvictionPolicyFunc[T]) Victim(view EvictionView[T], bytes bool) T {
+	return f(view, bytes)
+}
+
+// LowestPrice evicts the lowest valued item (or, when over the byte limit, the
+// item that pays the least per byte). This is the default policy.
+func LowestPrice[T Item]() EvictionPolicy[T] {
+	return EvictionPolicyFunc[T](func(view EvictionView[T], bytes bool) T {
+		if bytes {
+			return view.CheapestPerByte()
+		}
+		return view.Cheapest()
+	})
+}
+
+// OldestFirst evicts the item with the earliest expiry (which is the least
+// likely to be included before it expires).
+func OldestFirst[T Item]() EvictionPolicy[T] {
+	return EvictionPolicyFunc[T](func(view EvictionView[T], _ bool) T {
+		return view.Oldest()
+	})
+}
+
+// PayerFairness evicts the lowest valued item of the payer with the most items,
+// so that a single payer can't push everyone else out of the mempool by paying
+// slightly more.
+func PayerFairness[T Item]() EvictionPolicy[T] {
+	return EvictionPolicyFunc[T](func(view EvictionView[T], _ bool) T {
+		items := view.PayerItems(view.BusiestPayer())
+		victim := items[0]
+		for _, item := range items[1:] {
+			if view.Compare(item, victim) < 0 {
+				victim = item
+			}
+		}
+		return victim
+	})
+}
+
+// evictionView implements [EvictionView] over th.
+//
+// Assumes th.mu is held while in use.
+type evictionView[T Item] struct {
+	th *Mempool[T]
+}
+
+func (v evictionView[T]) Cheapest() T {
+	item, _ := v.th.pm.PeekMin()
+	return item
+}
+
+func (v evictionView[T]) CheapestPerByte() T {
+	if v.th.bm == nil {
+		return v.Cheapest()
+	}
+	item, _ := v.th.bm.PeekMin()
+	return item
+}
+
+func (v evictionView[T]) Oldest() T {
+	item, _ := v.th.tm.PeekMin()
+	return item
+}
+
+func (v evictionView[T]) BusiestPayer() string {
+	var (
+		busiest string
+		most    int
+	)
+	for payer, acct := range v.th.owned {
+		if acct.Len() > most {
+			busiest, most = payer, acct.Len()
+		}
+	}
+	return busiest
+}
+
+func (v evictionView[T]) PayerItems(payer string) []T {
+	acct := v.th.owned[payer]
+	items := make([]T, 0, acct.Len())
+	for id := range acct {
+		if item, ok := v.th.tm.Get(id); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (v evictionView[T]) Compare(a, b T) int {
+	return v.th.pm.Compare(a, b)
+}
+
+// SetEvictionPolicy replaces the policy used to select which item to evict when
+// th is over capacity (by default, [LowestPrice]).
+func (th *Mempool[T]) SetEvictionPolicy(policy EvictionPolicy[T]) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	th.eviction = policy
+}
+
+// evictOne evicts a single item selected by [th.eviction] and returns it.
+//
+// Assumes th.mu is held and th is not empty.
+func (th *Mempool[T]) evictOne(bytes bool) T {
+	view := evictionView[T]{th}
+	item := th.eviction.Victim(view, bytes)
+	if !th.tm.Has(item.ID()) {
+		// Fall back to the default policy so that th can't exceed its limits
+		item = LowestPrice[T]().Victim(view, bytes)
+	}
+	item = th.victim(item)
+	th.evict(item)
+	return item
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// Journal persists the items in a [Mempool] so that they survive a restart.
+//
+// Writes to the journal are best-effort: if a write fails, it is logged and
+// the [Mempool] continues without it (at worst, some items are not restored
+// or are restored and then dropped because they are invalid).
+type Journal struct {
+	log    logging.Logger
+	db     database.Database
+	prefix byte
+}
+
+// NewJournal creates a [Journal] that stores items in [db] under [prefix].
+func NewJournal(log logging.Logger, db database.Database, prefix byte) *Journal {
+	return &Journal{log, db, prefix}
+}
+
+func (j *Journal) key(id ids.ID) []byte {
+	k := make([]byte, 1+consts.IDLen)
+	k[0] = j.prefix
+	copy(k[1:], id[:])
+	return k
+}
+
+// write atomically stores [puts] and removes [deletes] from the journal.
+func (j *Journal) write(puts map[ids.ID][]byte, deletes []ids.ID) {
+	if len(puts) == 0 && len(deletes) == 0 {
+		return
+	}
+	batch := j.db.NewBatch()
+	for id, b := range puts {
+		if err := batch.Put(j.key(id), b); err != nil {
+			j.log.Warn("unable to journal mempool item", zap.Error(err))
+			return
+		}
+	}
+	for _, id := range deletes {
+		if err := batch.Delete(j.key(id)); err != nil {
+			j.log.Warn("unable to journal mempool removal", zap.Error(err))
+			return
+		}
+	}
+	if err := batch.Write(); err != nil {
+		j.log.Warn("unable to write mempool journal", zap.Error(err))
+	}
+}
+
+// Items returns the bytes of all journaled items.
+func (j *Journal) Items() ([][]byte, error) {
+	iter := j.db.NewIteratorWithPrefix([]byte{j.prefix})
+	defer iter.Release()
+
+	items := [][]byte{}
+	for iter.Next() {
+		// It is safe to use these bytes directly because the database copies the
+		// iterator value for us.
+		items = append(items, iter.Value())
+	}
+	return items, iter.Error()
+}
+
+// Clear removes all journaled items.
+func (j *Journal) Clear() error {
+	iter := j.db.NewIteratorWithPrefix([]byte{j.prefix})
+	defer iter.Release()
+
+	batch := j.db.NewBatch()
+	for iter.Next() {
+		if err := batch.Delete(iter.Key()); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
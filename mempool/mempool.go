@@ -5,59 +5,140 @@ package mempool
 
 import (
 	"context"
+	"errors"
+	"sort"
 	"sync"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/hypersdk/consts"
 )
 
-const maxPrealloc = 4_096
+const (
+	maxPrealloc = 4_096
+
+	// bytePricePrecision scales the unit price of an item before it is divided
+	// by its size so that the order of items that pay similar prices per byte
+	// is preserved.
+	bytePricePrecision = 1 << 20
+)
 
 type Mempool[T Item] struct {
 	tracer trace.Tracer
 
 	mu sync.RWMutex
 
-	maxSize      int
-	maxPayerSize int // Maximum items allowed by a single payer
+	maxSize  int
+	maxBytes int        // 0 is unlimited
+	quota    PayerQuota // Maximum items allowed by each payer
 
 	pm *SortedMempool[T] // Price Mempool
 	tm *SortedMempool[T] // Time Mempool
+	bm *SortedMempool[T] // Byte Price Mempool (nil if [maxBytes] is 0)
+
+	// [bytes] is the sum of the size of all items in th
+	bytes int
 
 	// [Owned] used to remove all items from an account when the balance is
 	// insufficient
 	owned map[string]set.Set[ids.ID]
 
-	// payers that are exempt from [maxPayerSize]
+	// payers that are exempt from [quota]
 	exemptPayers set.Set[string]
+
+	// payers whose items are dropped by [Add] (see [BanPayer])
+	banned set.Set[string]
+
+	// [seen] contains the IDs of all items in th and of items recently added
+	// to th (even if they have since been removed)
+	seen *Bloom
+
+	// [journal] (if set) persists all items in the mempool so that they
+	// survive a restart
+	journal *Journal
+	marshal func(T) []byte
+
+	// [admit] (if set) is run on each item before it is added to th
+	admit func(context.Context, T) error
+
+	// [eviction] selects the item to evict when th is over capacity
+	eviction EvictionPolicy[T]
+
+	// [resourcePrices] are used to value items in [pm] (see
+	// [SetResourcePrices]). [pm] is rebuilt when [repricePending] is set
+	// (until then, it remains keyed on [pmPrices]).
+	resourcePrices []uint64
+	pmPrices       []uint64
+	repricePending bool
+
+	// [drops] records why recently dropped items left th
+	drops *cache.LRU[ids.ID, DropReason]
+
+	// [subscriptions] receive all changes to th
+	subscriptions map[*Subscription[T]]struct{}
+	droppedEvents prometheus.Counter
+
+	// When [nonces] is set, only the lowest nonce item of each payer (its
+	// head) is in [pm] and the rest are queued (in nonce order) until it is
+	// removed
+	nonces bool
+	heads  map[string]T
+	queued map[string][]T
+
+	// While [paused], items passed to [Add] are held in [overflow] (see
+	// [Pause])
+	paused      bool
+	overflow    []T
+	overflowIDs set.Set[ids.ID]
 }
 
 // New creates a new [Mempool]. [maxSize] must be > 0 or else the
-// implementation may panic.
+// implementation may panic. If [maxBytes] is > 0, items are evicted whenever
+// the size of all items exceeds it (see [SetEvictionPolicy]). Each payer
+// (other than [exemptPayers]) may have at most [quota] items in th (use
+// [FixedQuota] to give every payer the same quota).
 func New[T Item](
 	tracer trace.Tracer,
 	maxSize int,
-	maxPayerSize int,
+	maxBytes int,
+	quota PayerQuota,
 	exemptPayers [][]byte,
 ) *Mempool[T] {
 	m := &Mempool[T]{
 		tracer: tracer,
 
-		maxSize:      maxSize,
-		maxPayerSize: maxPayerSize,
+		maxSize:  maxSize,
+		maxBytes: maxBytes,
+		quota:    quota,
 
 		pm: NewSortedMempool(
 			math.Min(maxSize, maxPrealloc),
-			func(item T) uint64 { return item.UnitPrice() },
+			byEffectivePrice[T](nil),
 		),
 		tm: NewSortedMempool(
 			math.Min(maxSize, maxPrealloc),
-			func(item T) uint64 { return uint64(item.Expiry()) },
+			ByValue(func(item T) uint64 { return uint64(item.Expiry()) }),
 		),
-		owned:        map[string]set.Set[ids.ID]{},
-		exemptPayers: set.Set[string]{},
+		owned:         map[string]set.Set[ids.ID]{},
+		exemptPayers:  set.Set[string]{},
+		banned:        set.Set[string]{},
+		eviction:      LowestPrice[T](),
+		drops:         &cache.LRU[ids.ID, DropReason]{Size: dropReasonsSize},
+		subscriptions: map[*Subscription[T]]struct{}{},
+		heads:         map[string]T{},
+		queued:        map[string][]T{},
+
+		// Each generation must be able to hold all items in th (which are
+		// re-added on rotation) and at least as many new items.
+		seen: NewBloom(2 * maxSize),
+	}
+	if maxBytes > 0 {
+		m.bm = NewSortedMempool(math.Min(maxSize, maxPrealloc), ByValue(bytePrice[T]))
 	}
 	for _, payer := range exemptPayers {
 		m.exemptPayers.Add(string(payer))
@@ -65,6 +146,257 @@ func New[T Item](
 	return m
 }
 
+// bytePrice returns the (scaled) unit price [item] pays per byte.
+func bytePrice[T Item](item T) uint64 {
+	size := item.Size()
+	if size <= 0 {
+		size = 1
+	}
+	price, err := math.Mul64(item.UnitPrice(), bytePricePrecision)
+	if err != nil {
+		price = ^uint64(0)
+	}
+	return price / uint64(size)
+}
+
+// track adds [item] to all indexes of th other than [th.pm] (which is
+// temporarily emptied during [Build] and [Sweep]).
+func (th *Mempool[T]) track(item T) {
+	th.tm.Add(item)
+	if th.bm != nil {
+		th.bm.Add(item)
+	}
+	th.bytes += item.Size()
+}
+
+// untrack removes [id] from all indexes of th other than [th.pm].
+func (th *Mempool[T]) untrack(id ids.ID) {
+	item, ok := th.tm.Get(id)
+	if !ok {
+		return
+	}
+	th.tm.Remove(id)
+	if th.bm != nil {
+		th.bm.Remove(id)
+	}
+	th.bytes -= item.Size()
+}
+
+// EnableNonceOrdering makes th only offer the lowest nonce item of each payer
+// (through [Build], [PeekMax], [PopMax], etc.) until it is removed, so that
+// items that can't be executed yet are never attempted. T must implement
+// [NoncedItem]. This should be called before any items are added to th.
+func (th *Mempool[T]) EnableNonceOrdering() error {
+	if _, ok := any(*new(T)).(NoncedItem); !ok {
+		return ErrNoncesUnsupported
+	}
+
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	th.nonces = true
+	return nil
+}
+
+func nonce[T Item](item T) uint64 {
+	return any(item).(NoncedItem).Nonce()
+}
+
+// enqueue adds [item] to [th.pm] (or, in nonce mode, to the queue of its
+// payer if it is not their lowest nonce item).
+func (th *Mempool[T]) enqueue(item T) {
+	if !th.nonces {
+		th.pm.Add(item)
+		return
+	}
+	payer := item.Payer()
+	head, ok := th.heads[payer]
+	if !ok {
+		th.heads[payer] = item
+		th.pm.Add(item)
+		return
+	}
+	if nonce(item) < nonce(head) {
+		// [item] replaces the head of [payer]
+		th.pm.Remove(head.ID())
+		th.heads[payer] = item
+		th.pm.Add(item)
+		item = head
+	}
+	q := th.queued[payer]
+	i := sort.Search(len(q), func(i int) bool { return nonce(q[i]) > nonce(item) })
+	q = append(q, item)
+	copy(q[i+1:], q[i:])
+	q[i] = item
+	th.queued[payer] = q
+}
+
+// dequeue removes [item] from [th.pm] (or, in nonce mode, from the queue of
+// its payer).
+func (th *Mempool[T]) dequeue(item T) {
+	if !th.nonces {
+		th.pm.Remove(item.ID())
+		return
+	}
+	payer := item.Payer()
+	if head, ok := th.heads[payer]; ok && head.ID() == item.ID() {
+		th.pm.Remove(item.ID())
+		th.advance(payer)
+		return
+	}
+	q := th.queued[payer]
+	for i, queued := range q {
+		if queued.ID() != item.ID() {
+			continue
+		}
+		q = append(q[:i], q[i+1:]...)
+		if len(q) == 0 {
+			delete(th.queued, payer)
+		} else {
+			th.queued[payer] = q
+		}
+		return
+	}
+}
+
+// popped must be called when [item] is popped from [th.pm] and will not be
+// restored (so that, in nonce mode, the next item of its payer is offered).
+func (th *Mempool[T]) popped(item T) {
+	if !th.nonces {
+		return
+	}
+	payer := item.Payer()
+	if head, ok := th.heads[payer]; ok && head.ID() == item.ID() {
+		th.advance(payer)
+	}
+}
+
+// advance replaces the head of [payer] (which is no longer in [th.pm]) with
+// the next item in their queue.
+func (th *Mempool[T]) advance(payer string) {
+	q := th.queued[payer]
+	if len(q) == 0 {
+		delete(th.heads, payer)
+		return
+	}
+	next := q[0]
+	if len(q) == 1 {
+		delete(th.queued, payer)
+	} else {
+		th.queued[payer] = q[1:]
+	}
+	th.heads[payer] = next
+	th.pm.Add(next)
+}
+
+// victim returns the item to evict when [low] is selected by [th.eviction].
+// In nonce mode, this is the highest nonce item of its payer (so that all of
+// their remaining items can still be executed).
+func (th *Mempool[T]) victim(low T) T {
+	if !th.nonces {
+		return low
+	}
+	q := th.queued[low.Payer()]
+	if len(q) == 0 {
+		return low
+	}
+	return q[len(q)-1]
+}
+
+// evict removes [item] from th.
+func (th *Mempool[T]) evict(item T) {
+	th.dequeue(item)
+	th.untrack(item.ID())
+	th.removeFromOwned(item)
+}
+
+// SetJournal persists all items added to th (until they are removed) to [j]
+// using [marshal]. This should be called before any items are added to th.
+func (th *Mempool[T]) SetJournal(j *Journal, marshal func(T) []byte) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	th.journal = j
+	th.marshal = marshal
+}
+
+// SetAdmission registers [admit] to be run on each item passed to [Add]
+// before it is inserted. Items for which [admit] returns an error are dropped
+// (so that items that are known to be invalid don't occupy space in th until
+// they fail during block building).
+//
+// [admit] is run without holding any locks in th, so it may be expensive (like
+// checking a balance or verifying a signature).
+func (th *Mempool[T]) SetAdmission(admit func(context.Context, T) error) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	th.admit = admit
+}
+
+// admitted returns the items in [items] that pass [th.admit].
+func (th *Mempool[T]) admitted(ctx context.Context, items []T) []T {
+	th.mu.RLock()
+	admit := th.admit
+	th.mu.RUnlock()
+
+	if admit == nil {
+		return items
+	}
+	admitted := make([]T, 0, len(items))
+	for _, item := range items {
+		if err := admit(ctx, item); err != nil {
+			continue
+		}
+		admitted = append(admitted, item)
+	}
+	return admitted
+}
+
+// quotas resolves [th.quota] for the payer of each item in [items] (other than
+// [th.exemptPayers]).
+func (th *Mempool[T]) quotas(ctx context.Context, items []T) map[string]int {
+	quotas := make(map[string]int, len(items))
+	for _, item := range items {
+		payer := item.Payer()
+		if _, ok := quotas[payer]; ok || th.exemptPayers.Contains(payer) {
+			continue
+		}
+		quotas[payer] = th.quota.Quota(ctx, payer)
+	}
+	return quotas
+}
+
+// journalWrite records that [added] were added to th and that [removed] were
+// removed from th (if a journal is set).
+func (th *Mempool[T]) journalWrite(added []T, removed []ids.ID) {
+	if th.journal == nil {
+		return
+	}
+	puts := make(map[ids.ID][]byte, len(added))
+	for _, item := range added {
+		puts[item.ID()] = th.marshal(item)
+	}
+	deletes := make([]ids.ID, 0, len(removed))
+	for _, id := range removed {
+		if _, ok := puts[id]; ok {
+			// Item was never written
+			delete(puts, id)
+			continue
+		}
+		deletes = append(deletes, id)
+	}
+	th.journal.write(puts, deletes)
+}
+
+func itemIDs[T Item](items []T) []ids.ID {
+	itemIDs := make([]ids.ID, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ID()
+	}
+	return itemIDs
+}
+
 func (th *Mempool[T]) removeFromOwned(item T) {
 	sender := item.Payer()
 	acct, ok := th.owned[sender]
@@ -78,57 +410,134 @@ func (th *Mempool[T]) removeFromOwned(item T) {
 	}
 }
 
-// Has returns if the pm of [th] contains [itemID]
+// see adds [id] to [th.seen]. When [th.seen] rotates, all items in th are
+// re-added to it so that it always contains every item in th.
+//
+// Assumes th.mu is held.
+func (th *Mempool[T]) see(id ids.ID) {
+	if th.seen.Full() {
+		th.seen.Rotate()
+		th.seen.Add(th.tm.IDs()...)
+	}
+	th.seen.Add(id)
+}
+
+// MaybeHas returns false if [itemID] is definitely not in th. Unlike [Has],
+// it does not acquire th's lock (so it can be used to cheaply filter items
+// before calling [Has]).
+func (th *Mempool[T]) MaybeHas(itemID ids.ID) bool {
+	return th.seen.Has(itemID)
+}
+
+// Filter returns a bloom filter (that can be checked with [BloomContains]) of
+// all items in th and items recently added to th. This can be sent to peers so
+// that they avoid sending items th already has.
+func (th *Mempool[T]) Filter() []byte {
+	return th.seen.Bytes()
+}
+
+// Has returns if [th] contains [itemID]
 func (th *Mempool[T]) Has(ctx context.Context, itemID ids.ID) bool {
 	_, span := th.tracer.Start(ctx, "Mempool.Has")
 	defer span.End()
 
 	th.mu.Lock()
 	defer th.mu.Unlock()
-	return th.pm.Has(itemID)
+	return th.tm.Has(itemID)
+}
+
+// Get returns the item with [itemID] in th (if it exists).
+func (th *Mempool[T]) Get(ctx context.Context, itemID ids.ID) (T, bool) {
+	_, span := th.tracer.Start(ctx, "Mempool.Get")
+	defer span.End()
+
+	th.mu.RLock()
+	defer th.mu.RUnlock()
+
+	return th.tm.Get(itemID)
 }
 
 // Add pushes all new items from [items] to th. Does not add a item if
-// the item payer is not exempt and their items in the mempool exceed th.maxPayerSize.
-// If the size of th exceeds th.maxSize, Add pops the lowest value item
-// from th.pm.
+// the item payer is banned (see [BanPayer]) or is not exempt and their items
+// in the mempool would exceed their quota.
+// If the size of th exceeds th.maxSize, Add evicts the item selected by
+// th's [EvictionPolicy]. While th is paused, items are held until [Resume]
+// is called instead (see [Pause]).
 func (th *Mempool[T]) Add(ctx context.Context, items []T) {
-	_, span := th.tracer.Start(ctx, "Mempool.Add")
+	ctx, span := th.tracer.Start(ctx, "Mempool.Add")
 	defer span.End()
 
+	th.mu.Lock()
+	if th.paused {
+		// Admission is deferred until [Resume] (the state it relies on may
+		// not be current)
+		th.hold(items)
+		th.mu.Unlock()
+		return
+	}
+	th.mu.Unlock()
+
+	items = th.admitted(ctx, items)
+	quotas := th.quotas(ctx, items)
+
 	th.mu.Lock()
 	defer th.mu.Unlock()
 
+	th.reprice()
+
+	var (
+		added   []T
+		evicted []T
+	)
 	for _, item := range items {
 		sender := item.Payer()
+		if th.banned.Contains(sender) {
+			th.drops.Put(item.ID(), DropBanned)
+			continue
+		}
 
 		// Ensure no duplicate
-		if th.pm.Has(item.ID()) {
+		if th.tm.Has(item.ID()) {
 			// Don't drop because already exists
 			continue
 		}
 
 		// Optimistically add to both mempools
-		acct, ok := th.owned[sender]
-		if !ok {
+		acct := th.owned[sender]
+		if quota, ok := quotas[sender]; ok && acct.Len() >= quota {
+			th.drops.Put(item.ID(), DropPayerQuota)
+			continue // do nothing, wait for items to expire
+		}
+		if th.maxBytes > 0 && item.Size() > th.maxBytes {
+			th.drops.Put(item.ID(), DropTooLarge)
+			continue // would evict everything else
+		}
+		if acct == nil {
 			acct = set.Set[ids.ID]{}
 			th.owned[sender] = acct
 		}
-		if !th.exemptPayers.Contains(sender) && acct.Len() == th.maxPayerSize {
-			continue // do nothing, wait for items to expire
-		}
-		th.pm.Add(item)
-		th.tm.Add(item)
+		th.enqueue(item)
+		th.track(item)
+		th.see(item.ID())
+		th.drops.Evict(item.ID())
 		acct.Add(item.ID())
+		added = append(added, item)
+
+		// Evict an item (by default, the lowest paying) if at global max
+		if th.tm.Len() > th.maxSize {
+			evicted = append(evicted, th.evictOne(false))
+		}
 
-		// Remove the lowest paying item if at global max
-		if th.pm.Len() > th.maxSize {
-			// Remove the lowest paying item
-			lowItem, _ := th.pm.PopMin()
-			th.tm.Remove(lowItem.ID())
-			th.removeFromOwned(lowItem)
+		// Evict items (by default, the lowest paying per byte) if over the
+		// byte limit
+		for th.maxBytes > 0 && th.bytes > th.maxBytes {
+			evicted = append(evicted, th.evictOne(true))
 		}
 	}
+	th.journalWrite(added, itemIDs(evicted))
+	th.drop(DropEvicted, evicted)
+	th.publish(EventAdded, added)
+	th.publish(EventRemoved, evicted)
 }
 
 // PeekMax returns the highest valued item in th.pm.
@@ -143,6 +552,73 @@ func (th *Mempool[T]) PeekMax(ctx context.Context) (T, bool) {
 	return th.pm.PeekMax()
 }
 
+// PeekTopN returns (up to) the [n] highest valued items in th.pm (in
+// descending order) without removing them. This can be used to preview the
+// items that will be included in the next block.
+func (th *Mempool[T]) PeekTopN(ctx context.Context, n int) []T {
+	_, span := th.tracer.Start(ctx, "Mempool.PeekTopN")
+	defer span.End()
+
+	th.mu.RLock()
+	defer th.mu.RUnlock()
+
+	return th.pm.PeekMaxN(n)
+}
+
+// Iterate calls [f] on each item in th (in no particular order) until [f]
+// returns false. Items are read from a snapshot taken when Iterate is called,
+// so th is not modified (unlike popping and re-adding items) and [f] may call
+// other methods on th.
+func (th *Mempool[T]) Iterate(ctx context.Context, f func(T) bool) {
+	_, span := th.tracer.Start(ctx, "Mempool.Iterate")
+	defer span.End()
+
+	th.mu.RLock()
+	items := th.tm.Items()
+	th.mu.RUnlock()
+
+	iterate(items, f)
+}
+
+// IterateByPrice is like [Iterate] but visits items from the highest to the
+// lowest valued.
+func (th *Mempool[T]) IterateByPrice(ctx context.Context, f func(T) bool) {
+	_, span := th.tracer.Start(ctx, "Mempool.IterateByPrice")
+	defer span.End()
+
+	iterate(th.snapshot(), f)
+}
+
+// Snapshot returns all items in th ordered from the highest to the lowest
+// valued. Items queued behind a lower nonce item of the same payer (see
+// [EnableNonceOrdering]) are included. Unlike [PeekTopN], Snapshot is not
+// limited to the items that can be built, so it can be used to inspect why an
+// item isn't being included.
+func (th *Mempool[T]) Snapshot(ctx context.Context) []T {
+	_, span := th.tracer.Start(ctx, "Mempool.Snapshot")
+	defer span.End()
+
+	return th.snapshot()
+}
+
+func (th *Mempool[T]) snapshot() []T {
+	th.mu.RLock()
+	items := th.tm.Items()
+	cmp := th.pm.Compare // [th.pm] is replaced when prices change
+	th.mu.RUnlock()
+
+	sort.SliceStable(items, func(i, j int) bool { return cmp(items[i], items[j]) > 0 })
+	return items
+}
+
+func iterate[T Item](items []T, f func(T) bool) {
+	for _, item := range items {
+		if !f(item) {
+			return
+		}
+	}
+}
+
 // PeekMin returns the lowest valued item in th.pm.
 // Assumes there is non-zero items in [Mempool]
 func (th *Mempool[T]) PeekMin(ctx context.Context) (T, bool) {
@@ -164,10 +640,16 @@ func (th *Mempool[T]) PopMax(ctx context.Context) (T, bool) { // O(log N)
 	th.mu.Lock()
 	defer th.mu.Unlock()
 
+	th.reprice()
+
 	max, ok := th.pm.PopMax()
 	if ok {
-		th.tm.Remove(max.ID())
+		th.popped(max)
+		th.untrack(max.ID())
 		th.removeFromOwned(max)
+		th.journalWrite(nil, []ids.ID{max.ID()})
+		th.drop(DropBuilt, []T{max})
+		th.publish(EventRemoved, []T{max})
 	}
 	return max, ok
 }
@@ -181,10 +663,16 @@ func (th *Mempool[T]) PopMin(ctx context.Context) (T, bool) { // O(log N)
 	th.mu.Lock()
 	defer th.mu.Unlock()
 
+	th.reprice()
+
 	min, ok := th.pm.PopMin()
 	if ok {
-		th.tm.Remove(min.ID())
+		th.popped(min)
+		th.untrack(min.ID())
 		th.removeFromOwned(min)
+		th.journalWrite(nil, []ids.ID{min.ID()})
+		th.drop(DropBuilt, []T{min})
+		th.publish(EventRemoved, []T{min})
 	}
 	return min, ok
 }
@@ -197,13 +685,43 @@ func (th *Mempool[T]) Remove(ctx context.Context, items []T) {
 	th.mu.Lock()
 	defer th.mu.Unlock()
 
+	removed := make([]T, 0, len(items))
 	for _, item := range items {
-		th.pm.Remove(item.ID())
-		th.tm.Remove(item.ID())
-		th.removeFromOwned(item)
+		if !th.tm.Has(item.ID()) {
+			continue
+		}
+		th.evict(item)
+		removed = append(removed, item)
 		// Remove is called when verifying a block. We should not drop transactions at
 		// this time.
 	}
+	th.journalWrite(nil, itemIDs(removed))
+	th.drop(DropIncluded, removed)
+	th.publish(EventRemoved, removed)
+}
+
+// RemoveByIDs removes the items with [removeIDs] from th (ignoring any that
+// are not in th). This is useful when the caller only knows which items were
+// accepted (and would otherwise need to reconstruct them to call [Remove]).
+func (th *Mempool[T]) RemoveByIDs(ctx context.Context, removeIDs []ids.ID) {
+	_, span := th.tracer.Start(ctx, "Mempool.RemoveByIDs")
+	defer span.End()
+
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	removed := make([]T, 0, len(removeIDs))
+	for _, id := range removeIDs {
+		item, ok := th.tm.Get(id)
+		if !ok {
+			continue
+		}
+		th.evict(item)
+		removed = append(removed, item)
+	}
+	th.journalWrite(nil, itemIDs(removed))
+	th.drop(DropIncluded, removed)
+	th.publish(EventRemoved, removed)
 }
 
 // Len returns the number of items in th.
@@ -214,7 +732,101 @@ func (th *Mempool[T]) Len(ctx context.Context) int {
 	th.mu.RLock()
 	defer th.mu.RUnlock()
 
-	return th.pm.Len()
+	return th.tm.Len()
+}
+
+// Bytes returns the sum of the size of all items in th.
+func (th *Mempool[T]) Bytes(ctx context.Context) int {
+	_, span := th.tracer.Start(ctx, "Mempool.Bytes")
+	defer span.End()
+
+	th.mu.RLock()
+	defer th.mu.RUnlock()
+
+	return th.bytes
+}
+
+// Payers returns the number of distinct payers with items in th.
+func (th *Mempool[T]) Payers(ctx context.Context) int {
+	_, span := th.tracer.Start(ctx, "Mempool.Payers")
+	defer span.End()
+
+	th.mu.RLock()
+	defer th.mu.RUnlock()
+
+	return len(th.owned)
+}
+
+// AccountStats summarizes the items of a single payer in a [Mempool].
+type AccountStats struct {
+	Count int `json:"count"`
+	Bytes int `json:"bytes"` // total [Item.Size] of all items
+	// Units is the total of each resource consumed by all items (only if
+	// they are [ResourceItem]s).
+	Units    []uint64 `json:"units,omitempty"`
+	MinPrice uint64   `json:"minPrice"`
+	MaxPrice uint64   `json:"maxPrice"`
+}
+
+// AccountStats returns a summary of the items by [sender] in th (which is
+// empty if there are none).
+func (th *Mempool[T]) AccountStats(ctx context.Context, sender string) AccountStats {
+	_, span := th.tracer.Start(ctx, "Mempool.AccountStats")
+	defer span.End()
+
+	th.mu.RLock()
+	defer th.mu.RUnlock()
+
+	var stats AccountStats
+	for id := range th.owned[sender] {
+		item, ok := th.tm.Get(id)
+		if !ok {
+			continue
+		}
+		price := item.UnitPrice()
+		if stats.Count == 0 || price < stats.MinPrice {
+			stats.MinPrice = price
+		}
+		if price > stats.MaxPrice {
+			stats.MaxPrice = price
+		}
+		stats.Count++
+		stats.Bytes += item.Size()
+		if ri, ok := any(item).(ResourceItem); ok {
+			for i, units := range ri.ResourceUnits() {
+				if i == len(stats.Units) {
+					stats.Units = append(stats.Units, 0)
+				}
+				total, err := math.Add64(stats.Units[i], units)
+				if err != nil {
+					total = consts.MaxUint64
+				}
+				stats.Units[i] = total
+			}
+		}
+	}
+	return stats
+}
+
+// Floor returns the value of the lowest paying item in th (its
+// [EffectivePrice] at the prices th.pm is currently ordered by) and true if th
+// is full. While th is full, any item that doesn't pay more than this will be
+// evicted as soon as it is added.
+func (th *Mempool[T]) Floor(ctx context.Context) (uint64, bool) {
+	_, span := th.tracer.Start(ctx, "Mempool.Floor")
+	defer span.End()
+
+	th.mu.RLock()
+	defer th.mu.RUnlock()
+
+	if th.tm.Len() < th.maxSize {
+		return 0, false
+	}
+	item, ok := th.pm.PeekMin()
+	if !ok {
+		return 0, false
+	}
+	return EffectivePrice(item, th.pmPrices), true
 }
 
 // RemoveAccount removes all items by [sender] from th.
@@ -225,19 +837,67 @@ func (th *Mempool[T]) RemoveAccount(ctx context.Context, sender string) {
 	th.mu.Lock()
 	defer th.mu.Unlock()
 
-	th.removeAccount(sender)
+	removed := th.removeAccount(sender)
+	th.journalWrite(nil, itemIDs(removed))
+	th.drop(DropAccountRemoved, removed)
+	th.publish(EventRemoved, removed)
+}
+
+// BanPayer removes all items by [sender] from th and drops any items by
+// [sender] passed to [Add] until [UnbanPayer] is called. Unlike
+// [exemptPayers], bans are not persisted. BanPayer returns the removed items.
+func (th *Mempool[T]) BanPayer(ctx context.Context, sender string) []T {
+	_, span := th.tracer.Start(ctx, "Mempool.BanPayer")
+	defer span.End()
+
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	th.banned.Add(sender)
+	removed := th.removeAccount(sender)
+	th.journalWrite(nil, itemIDs(removed))
+	th.drop(DropBanned, removed)
+	th.publish(EventRemoved, removed)
+	return removed
+}
+
+// UnbanPayer allows items by [sender] to be added to th again.
+func (th *Mempool[T]) UnbanPayer(ctx context.Context, sender string) {
+	_, span := th.tracer.Start(ctx, "Mempool.UnbanPayer")
+	defer span.End()
+
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	th.banned.Remove(sender)
+}
+
+// Banned returns true if items by [sender] are dropped by [Add].
+func (th *Mempool[T]) Banned(sender string) bool {
+	th.mu.RLock()
+	defer th.mu.RUnlock()
+
+	return th.banned.Contains(sender)
 }
 
-func (th *Mempool[T]) removeAccount(sender string) {
+// removeAccount returns the removed items.
+func (th *Mempool[T]) removeAccount(sender string) []T {
 	acct, ok := th.owned[sender]
 	if !ok {
-		return
+		return nil
 	}
-	for item := range acct {
-		th.pm.Remove(item)
-		th.tm.Remove(item)
+	removed := make([]T, 0, len(acct))
+	for id := range acct {
+		item, ok := th.tm.Get(id)
+		if !ok {
+			continue
+		}
+		th.dequeue(item)
+		th.untrack(id)
+		removed = append(removed, item)
 	}
 	delete(th.owned, sender)
+	return removed
 }
 
 // SetMinTimestamp removes all items with a lower expiry than [t] from th.
@@ -249,14 +909,79 @@ func (th *Mempool[T]) SetMinTimestamp(ctx context.Context, t int64) []T {
 	th.mu.Lock()
 	defer th.mu.Unlock()
 
-	removed := th.tm.SetMinVal(uint64(t))
+	removed := th.tm.PopMinWhile(func(item T) bool { return item.Expiry() < t })
 	for _, remove := range removed {
-		th.pm.Remove(remove.ID())
+		th.dequeue(remove)
+		if th.bm != nil {
+			th.bm.Remove(remove.ID())
+		}
+		th.bytes -= remove.Size()
 		th.removeFromOwned(remove)
 	}
+	th.journalWrite(nil, itemIDs(removed))
+	th.drop(DropExpired, removed)
+	th.publish(EventExpired, removed)
 	return removed
 }
 
+// Sweep calls [f] on up to [max] of the lowest paying items in th (in
+// ascending order), removing any items for which [f] returns false. Because
+// the lowest paying items are the first to become invalid when fees rise,
+// this allows th to be re-validated in small batches. th is not locked while
+// [f] is called (so that validating items doesn't block other operations on
+// th), so items may be removed from th before Sweep would remove them. Sweep
+// returns the list of removed items.
+func (th *Mempool[T]) Sweep(
+	ctx context.Context,
+	max int,
+	f func(context.Context, T) (keep bool),
+) []T {
+	ctx, span := th.tracer.Start(ctx, "Mempool.Sweep")
+	defer span.End()
+
+	th.mu.Lock()
+	th.reprice()
+	candidates := []T{}
+	for th.pm.Len() > 0 && len(candidates) < max {
+		min, _ := th.pm.PopMin()
+		candidates = append(candidates, min)
+	}
+	for _, item := range candidates {
+		th.pm.Add(item)
+	}
+	th.mu.Unlock()
+
+	invalid := []T{}
+	for _, item := range candidates {
+		if !f(ctx, item) {
+			invalid = append(invalid, item)
+		}
+	}
+
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	removed := make([]T, 0, len(invalid))
+	for _, item := range invalid {
+		if !th.tm.Has(item.ID()) {
+			// Already removed (or included) while [f] was called
+			continue
+		}
+		th.evict(item)
+		removed = append(removed, item)
+	}
+	th.journalWrite(nil, itemIDs(removed))
+	th.drop(DropSwept, removed)
+	th.publish(EventRemoved, removed)
+	return removed
+}
+
+// Build pops items from th (from the highest to the lowest valued) and passes
+// them to [f] until [f] returns false (or an error) or th is empty. Items that
+// [f] restores are added back to th once Build returns. If [f] removes the
+// account of an item, all other items of its payer are removed from th. If [f]
+// returns [ErrExpired], the item is removed as expired and Build continues
+// (unless [f] also returns false).
 func (th *Mempool[T]) Build(
 	ctx context.Context,
 	f func(context.Context, T) (cont bool, restore bool, removeAcct bool, err error),
@@ -264,26 +989,82 @@ func (th *Mempool[T]) Build(
 	ctx, span := th.tracer.Start(ctx, "Mempool.Build")
 	defer span.End()
 
+	_, err := th.build(ctx, f, false)
+	return err
+}
+
+// BuildSkipping is like [Build] but, once [f] restores an item, the remaining
+// items of its payer are set aside (and restored once BuildSkipping returns)
+// without being popped and passed to [f]. Because items are visited from the
+// highest to the lowest valued, the remaining items of a payer are usually
+// restored for the same reason (like an insufficient price), so this saves
+// executing them only to restore them.
+//
+// BuildSkipping returns the number of items that were not passed to [f]
+// because an earlier item of their payer was restored (or its account was
+// removed).
+func (th *Mempool[T]) BuildSkipping(
+	ctx context.Context,
+	f func(context.Context, T) (cont bool, restore bool, removeAcct bool, err error),
+) (int, error) {
+	ctx, span := th.tracer.Start(ctx, "Mempool.BuildSkipping")
+	defer span.End()
+
+	return th.build(ctx, f, true)
+}
+
+func (th *Mempool[T]) build(
+	ctx context.Context,
+	f func(context.Context, T) (cont bool, restore bool, removeAcct bool, err error),
+	skip bool,
+) (int, error) {
 	th.mu.Lock()
 	defer th.mu.Unlock()
 
+	th.reprice()
+
 	restorableItems := []T{}
+	removed := []T{}
+	expired := []T{}
+	skipped := 0
 	var err error
 	for th.pm.Len() > 0 {
 		max, _ := th.pm.PopMax()
 		cont, restore, removeAccount, fErr := f(ctx, max)
+		isExpired := errors.Is(fErr, ErrExpired)
+		if isExpired {
+			fErr = nil
+		}
 		if restore {
 			// Waiting to restore unused transactions ensures that an account will be
 			// excluded from future price mempool iterations
 			restorableItems = append(restorableItems, max)
 		} else {
-			th.tm.Remove(max.ID())
+			// In nonce mode, the next item from the payer can now be executed
+			th.popped(max)
+			th.untrack(max.ID())
 			th.removeFromOwned(max)
+			if isExpired {
+				th.drops.Put(max.ID(), DropExpired)
+				expired = append(expired, max)
+			} else {
+				th.drops.Put(max.ID(), DropBuilt)
+				removed = append(removed, max)
+			}
 		}
-		if removeAccount {
+		switch {
+		case removeAccount:
 			// We remove the account typically when the next execution results in an
 			// invalid balance
-			th.removeAccount(max.Payer())
+			payer := max.Payer()
+			skipped += len(th.setAside(payer))
+			removedAccount := th.removeAccount(payer)
+			th.drop(DropAccountRemoved, removedAccount)
+			removed = append(removed, removedAccount...)
+		case restore && skip:
+			setAside := th.setAside(max.Payer())
+			restorableItems = append(restorableItems, setAside...)
+			skipped += len(setAside)
 		}
 		if !cont || fErr != nil {
 			err = fErr
@@ -291,9 +1072,32 @@ func (th *Mempool[T]) Build(
 		}
 	}
 	//
-	// Restore unused items
+	// Restore unused items (unless their account was removed)
 	for _, item := range restorableItems {
+		if !th.tm.Has(item.ID()) {
+			continue
+		}
 		th.pm.Add(item)
 	}
-	return err
+	th.journalWrite(nil, append(itemIDs(removed), itemIDs(expired)...))
+	th.publish(EventRemoved, removed)
+	th.publish(EventExpired, expired)
+	return skipped, err
+}
+
+// setAside removes all items of [payer] from th.pm (but not from th) and
+// returns them so they can be restored later.
+//
+// Assumes th.mu is held.
+func (th *Mempool[T]) setAside(payer string) []T {
+	var items []T
+	for id := range th.owned[payer] {
+		item, ok := th.pm.Get(id)
+		if !ok {
+			continue
+		}
+		th.pm.Remove(id)
+		items = append(items, item)
+	}
+	return items
 }
@@ -5,8 +5,13 @@ package mempool
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
 	"github.com/ava-labs/hypersdk/trace"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -19,7 +24,7 @@ func TestMempool(t *testing.T) {
 
 	ctx := context.TODO()
 	tracer, _ := trace.New(&trace.Config{Enabled: false})
-	txm := New[*MempoolTestItem](tracer, 3, 16, nil)
+	txm := New[*MempoolTestItem](tracer, 3, 0, FixedQuota(16), nil)
 
 	for _, i := range []uint64{100, 200, 300, 400} {
 		item := GenerateTestItem(testPayer, 1, i)
@@ -41,7 +46,7 @@ func TestMempoolAddDuplicates(t *testing.T) {
 	defer ctrl.Finish()
 	ctx := context.TODO()
 	tracer, _ := trace.New(&trace.Config{Enabled: false})
-	txm := New[*MempoolTestItem](tracer, 3, 16, nil)
+	txm := New[*MempoolTestItem](tracer, 3, 0, FixedQuota(16), nil)
 	// Generate item
 	item := GenerateTestItem(testPayer, 1, 300)
 	items := []*MempoolTestItem{item}
@@ -58,6 +63,74 @@ func TestMempoolAddDuplicates(t *testing.T) {
 	require.Equal(1, txm.Len(ctx), "Item not added.")
 }
 
+func TestMempoolGet(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 3, 0, FixedQuota(16), nil)
+
+	item := GenerateTestItem(testPayer, 1, 300)
+	_, ok := txm.Get(ctx, item.ID())
+	require.False(ok)
+
+	txm.Add(ctx, []*MempoolTestItem{item})
+	got, ok := txm.Get(ctx, item.ID())
+	require.True(ok)
+	require.Equal(item, got)
+
+	txm.Remove(ctx, []*MempoolTestItem{item})
+	_, ok = txm.Get(ctx, item.ID())
+	require.False(ok)
+}
+
+func TestMempoolDropReason(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 2, 0, FixedQuota(2), nil)
+
+	included := GenerateTestItem(testPayer, 1, 300)
+	expired := GenerateTestItem("expired", 1, 200)
+	evicted := GenerateTestItem("evicted", 5, 100)
+	txm.Add(ctx, []*MempoolTestItem{included, expired})
+	_, ok := txm.DropReason(ctx, included.ID())
+	require.False(ok)
+
+	// Evicts the lowest paying item (which is [evicted] itself)
+	txm.Add(ctx, []*MempoolTestItem{evicted})
+	reason, ok := txm.DropReason(ctx, evicted.ID())
+	require.True(ok)
+	require.Equal(DropEvicted, reason)
+
+	txm.Remove(ctx, []*MempoolTestItem{included})
+	reason, _ = txm.DropReason(ctx, included.ID())
+	require.Equal(DropIncluded, reason)
+
+	txm.SetMinTimestamp(ctx, 2)
+	reason, _ = txm.DropReason(ctx, expired.ID())
+	require.Equal(DropExpired, reason)
+
+	// Over quota
+	quota := []*MempoolTestItem{
+		GenerateTestItem(testPayer, 5, 1),
+		GenerateTestItem(testPayer, 5, 2),
+		GenerateTestItem(testPayer, 5, 3),
+	}
+	txm.Add(ctx, quota)
+	reason, _ = txm.DropReason(ctx, quota[2].ID())
+	require.Equal(DropPayerQuota, reason)
+
+	txm.RemoveAccount(ctx, testPayer)
+	reason, _ = txm.DropReason(ctx, quota[0].ID())
+	require.Equal(DropAccountRemoved, reason)
+	require.Equal("account removed", reason.String())
+
+	// Re-adding an item forgets why it was dropped
+	txm.Add(ctx, []*MempoolTestItem{included})
+	_, ok = txm.DropReason(ctx, included.ID())
+	require.False(ok)
+}
+
 func TestMempoolAddExceedMaxPayerSize(t *testing.T) {
 	// Payer1 has reached his max
 	// Payer2 is exempt from max size
@@ -70,7 +143,7 @@ func TestMempoolAddExceedMaxPayerSize(t *testing.T) {
 	payer := "notexempt"
 	exemptPayers := []byte(exemptPayer)
 	// Non exempt payers max of 4
-	txm := New[*MempoolTestItem](tracer, 20, 4, [][]byte{exemptPayers})
+	txm := New[*MempoolTestItem](tracer, 20, 0, FixedQuota(4), [][]byte{exemptPayers})
 	// Add 6 transactions for each payer
 	for i := uint64(0); i <= 5; i++ {
 		itemPayer := GenerateTestItem(payer, 1, i)
@@ -90,7 +163,7 @@ func TestMempoolAddExceedMaxSize(t *testing.T) {
 	ctx := context.TODO()
 	tracer, _ := trace.New(&trace.Config{Enabled: false})
 
-	txm := New[*MempoolTestItem](tracer, 3, 20, nil)
+	txm := New[*MempoolTestItem](tracer, 3, 0, FixedQuota(20), nil)
 	// Add more tx's than txm.maxSize
 	for i := uint64(0); i <= 9; i++ {
 		item := GenerateTestItem(testPayer, 1, i)
@@ -110,6 +183,32 @@ func TestMempoolAddExceedMaxSize(t *testing.T) {
 	require.Equal(0, txm.Len(ctx), "Mempool has incorrect number of txs.")
 }
 
+func TestMempoolFloor(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 3, 0, FixedQuota(16), nil)
+
+	// Not full
+	for _, i := range []uint64{100, 200} {
+		txm.Add(ctx, []*MempoolTestItem{GenerateTestItem(testPayer, 1, i)})
+	}
+	_, full := txm.Floor(ctx)
+	require.False(full)
+
+	// Full
+	txm.Add(ctx, []*MempoolTestItem{GenerateTestItem(testPayer, 1, 300)})
+	floor, full := txm.Floor(ctx)
+	require.True(full)
+	require.Equal(uint64(100), floor)
+
+	// Evicting the lowest paying item raises the floor
+	txm.Add(ctx, []*MempoolTestItem{GenerateTestItem(testPayer, 1, 400)})
+	floor, full = txm.Floor(ctx)
+	require.True(full)
+	require.Equal(uint64(200), floor)
+}
+
 func TestMempoolRemoveTxs(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -117,7 +216,7 @@ func TestMempoolRemoveTxs(t *testing.T) {
 	ctx := context.TODO()
 	tracer, _ := trace.New(&trace.Config{Enabled: false})
 
-	txm := New[*MempoolTestItem](tracer, 3, 20, nil)
+	txm := New[*MempoolTestItem](tracer, 3, 0, FixedQuota(20), nil)
 	// Add
 	item := GenerateTestItem(testPayer, 1, 10)
 	items := []*MempoolTestItem{item}
@@ -137,7 +236,7 @@ func TestMempoolRemoveAccount(t *testing.T) {
 	ctx := context.TODO()
 	tracer, _ := trace.New(&trace.Config{Enabled: false})
 
-	txm := New[*MempoolTestItem](tracer, 3, 20, nil)
+	txm := New[*MempoolTestItem](tracer, 3, 0, FixedQuota(20), nil)
 	// Add
 	item1 := GenerateTestItem(testPayer, 1, 10)
 	item2 := GenerateTestItem(testPayer, 1, 20)
@@ -159,7 +258,7 @@ func TestMempoolSetMinTimestamp(t *testing.T) {
 	ctx := context.TODO()
 	tracer, _ := trace.New(&trace.Config{Enabled: false})
 
-	txm := New[*MempoolTestItem](tracer, 20, 20, nil)
+	txm := New[*MempoolTestItem](tracer, 20, 0, FixedQuota(20), nil)
 	// Add more tx's than txm.maxSize
 	for i := int64(0); i <= 9; i++ {
 		item := GenerateTestItem(testPayer, i, 10)
@@ -186,3 +285,780 @@ func TestMempoolBuild(t *testing.T) {
 	require := require.New(t)
 	require.True(true, "not true")
 }
+
+func TestMempoolBuildExpired(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 10, 0, FixedQuota(10), nil)
+	sub := txm.Subscribe()
+
+	expired := GenerateTestItem(testPayer, 1, 200)
+	built := GenerateTestItem(testPayer, 10, 100)
+	txm.Add(ctx, []*MempoolTestItem{expired, built})
+	require.NoError(txm.Build(ctx, func(_ context.Context, item *MempoolTestItem) (bool, bool, bool, error) {
+		if item.Expiry() < 5 {
+			return true, false, false, ErrExpired
+		}
+		return true, false, false, nil
+	}))
+	require.Zero(txm.Len(ctx))
+
+	reason, _ := txm.DropReason(ctx, expired.ID())
+	require.Equal(DropExpired, reason)
+	reason, _ = txm.DropReason(ctx, built.ID())
+	require.Equal(DropBuilt, reason)
+	for _, expected := range []Event[*MempoolTestItem]{
+		{EventAdded, expired},
+		{EventAdded, built},
+		{EventRemoved, built},
+		{EventExpired, expired},
+	} {
+		e := <-sub.Events()
+		require.Equal(expected.Kind, e.Kind)
+		require.Equal(expected.Item.ID(), e.Item.ID())
+	}
+}
+
+func TestMempoolBuildSkipping(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 10, 0, FixedQuota(10), nil)
+	for _, price := range []uint64{50, 40, 30} {
+		txm.Add(ctx, []*MempoolTestItem{
+			GenerateTestItem(testPayer, 1, price),
+			GenerateTestItem("other", 1, price+5),
+		})
+	}
+
+	// Once the first item of [testPayer] is restored, its other items are set
+	// aside without being attempted
+	attempted := []uint64{}
+	skipped, err := txm.BuildSkipping(ctx, func(_ context.Context, item *MempoolTestItem) (bool, bool, bool, error) {
+		attempted = append(attempted, item.UnitPrice())
+		return true, item.Payer() == testPayer, false, nil
+	})
+	require.NoError(err)
+	require.Equal(2, skipped)
+	require.Equal([]uint64{55, 50, 45, 35}, attempted)
+	require.Equal(3, txm.Len(ctx))
+	max, ok := txm.PeekMax(ctx)
+	require.True(ok)
+	require.Equal(uint64(50), max.UnitPrice())
+
+	// Items of a removed account are skipped as well
+	attempted = attempted[:0]
+	skipped, err = txm.BuildSkipping(ctx, func(_ context.Context, item *MempoolTestItem) (bool, bool, bool, error) {
+		attempted = append(attempted, item.UnitPrice())
+		return true, false, true, nil
+	})
+	require.NoError(err)
+	require.Equal(2, skipped)
+	require.Equal([]uint64{50}, attempted)
+	require.Zero(txm.Len(ctx))
+}
+
+func TestMempoolSweep(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 10, 0, FixedQuota(10), nil)
+	for i := uint64(1); i <= 6; i++ {
+		item := GenerateTestItem(testPayer, int64(i), i*100)
+		txm.Add(ctx, []*MempoolTestItem{item})
+	}
+
+	// Only the lowest [max] items are visited
+	visited := []uint64{}
+	removed := txm.Sweep(ctx, 4, func(_ context.Context, item *MempoolTestItem) bool {
+		visited = append(visited, item.UnitPrice())
+		return item.UnitPrice() > 200
+	})
+	require.Equal([]uint64{100, 200, 300, 400}, visited)
+	require.Len(removed, 2)
+	require.Equal(4, txm.Len(ctx))
+
+	// Kept items are restored
+	min, ok := txm.PeekMin(ctx)
+	require.True(ok)
+	require.Equal(uint64(300), min.UnitPrice())
+	max, ok := txm.PeekMax(ctx)
+	require.True(ok)
+	require.Equal(uint64(600), max.UnitPrice())
+
+	// Removed items are no longer tracked by expiry
+	require.Len(txm.SetMinTimestamp(ctx, 3), 0)
+
+	// th isn't locked while items are validated, so items removed in the
+	// meantime aren't removed again
+	removed = txm.Sweep(ctx, 2, func(_ context.Context, item *MempoolTestItem) bool {
+		if item.UnitPrice() == 300 {
+			txm.Remove(ctx, []*MempoolTestItem{item})
+		}
+		return false
+	})
+	require.Len(removed, 1)
+	require.Equal(uint64(400), removed[0].UnitPrice())
+	require.Equal(2, txm.Len(ctx))
+}
+
+func TestMempoolJournal(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 3, 0, FixedQuota(16), nil)
+	journal := NewJournal(logging.NoLog{}, memdb.New(), 0x0)
+	txm.SetJournal(journal, func(item *MempoolTestItem) []byte {
+		return []byte{byte(item.UnitPrice())}
+	})
+
+	// Evicted items are not journaled
+	for _, i := range []uint64{1, 2, 3, 4} {
+		txm.Add(ctx, []*MempoolTestItem{GenerateTestItem(testPayer, int64(i), i)})
+	}
+	items, err := journal.Items()
+	require.NoError(err)
+	require.ElementsMatch([][]byte{{2}, {3}, {4}}, items)
+
+	// Removed items are deleted from the journal
+	max, ok := txm.PopMax(ctx)
+	require.True(ok)
+	require.Equal(uint64(4), max.UnitPrice())
+	require.Len(txm.SetMinTimestamp(ctx, 3), 1)
+	items, err = journal.Items()
+	require.NoError(err)
+	require.Equal([][]byte{{3}}, items)
+
+	require.NoError(journal.Clear())
+	items, err = journal.Items()
+	require.NoError(err)
+	require.Empty(items)
+}
+
+func TestMempoolAddExceedMaxBytes(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 10, 3*testItemSize, FixedQuota(10), nil)
+
+	for _, i := range []uint64{100, 200, 300} {
+		txm.Add(ctx, []*MempoolTestItem{GenerateTestItem(testPayer, 1, i)})
+	}
+	require.Equal(3*testItemSize, txm.Bytes(ctx))
+
+	// A large item that pays a higher unit price (but less per byte) is
+	// evicted along with the lowest paying item
+	large := GenerateTestItem(testPayer, 1, 350)
+	large.size = 2 * testItemSize
+	txm.Add(ctx, []*MempoolTestItem{large})
+	require.Equal(2, txm.Len(ctx))
+	require.False(txm.Has(ctx, large.ID()))
+	min, ok := txm.PeekMin(ctx)
+	require.True(ok)
+	require.Equal(uint64(200), min.UnitPrice())
+	require.Equal(2*testItemSize, txm.Bytes(ctx))
+
+	// Items larger than the limit are never added
+	huge := GenerateTestItem(testPayer, 1, 1_000)
+	huge.size = 4 * testItemSize
+	txm.Add(ctx, []*MempoolTestItem{huge})
+	require.Equal(2, txm.Len(ctx))
+	reason, ok := txm.DropReason(ctx, huge.ID())
+	require.True(ok)
+	require.Equal(DropTooLarge, reason)
+
+	// Removed items no longer count towards the limit
+	txm.PopMax(ctx)
+	require.Equal(testItemSize, txm.Bytes(ctx))
+	require.Len(txm.SetMinTimestamp(ctx, 2), 1)
+	require.Zero(txm.Bytes(ctx))
+}
+
+func TestMempoolMaybeHas(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 4, 0, FixedQuota(64), nil)
+
+	// Items in the mempool are never dropped from the filter, even after many
+	// rotations
+	kept := GenerateTestItem(testPayer, 1, 1_000)
+	txm.Add(ctx, []*MempoolTestItem{kept})
+	for i := 0; i < 32; i++ {
+		item := GenerateTestItem(testPayer, 1, uint64(i))
+		txm.Add(ctx, []*MempoolTestItem{item})
+		require.True(txm.MaybeHas(item.ID()))
+	}
+	require.True(txm.Has(ctx, kept.ID()))
+	require.True(txm.MaybeHas(kept.ID()))
+	require.True(BloomContains(txm.Filter(), kept.ID()))
+}
+
+func TestMempoolPeekTopN(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 10, 0, FixedQuota(16), nil)
+	require.Empty(txm.PeekTopN(ctx, 3))
+
+	for _, i := range []uint64{300, 100, 500, 200, 400} {
+		txm.Add(ctx, []*MempoolTestItem{GenerateTestItem(testPayer, 1, i)})
+	}
+	top := txm.PeekTopN(ctx, 3)
+	require.Len(top, 3)
+	for i, price := range []uint64{500, 400, 300} {
+		require.Equal(price, top[i].UnitPrice())
+	}
+
+	// Items are not removed
+	require.Equal(5, txm.Len(ctx))
+	max, ok := txm.PopMax(ctx)
+	require.True(ok)
+	require.Equal(uint64(500), max.UnitPrice())
+	require.Len(txm.PeekTopN(ctx, 10), 4)
+}
+
+func TestMempoolRemoveByIDs(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 10, 0, FixedQuota(16), nil)
+
+	items := []*MempoolTestItem{}
+	for _, i := range []uint64{100, 200, 300} {
+		items = append(items, GenerateTestItem(testPayer, 1, i))
+	}
+	txm.Add(ctx, items)
+	require.Equal(3, txm.Len(ctx))
+
+	// Unknown IDs are ignored
+	txm.RemoveByIDs(ctx, []ids.ID{items[0].ID(), items[2].ID(), ids.GenerateTestID()})
+	require.Equal(1, txm.Len(ctx))
+	require.False(txm.Has(ctx, items[0].ID()))
+	require.True(txm.Has(ctx, items[1].ID()))
+	require.False(txm.Has(ctx, items[2].ID()))
+	require.Equal(items[1].Size(), txm.Bytes(ctx))
+	require.Len(txm.owned[testPayer], 1)
+
+	txm.RemoveByIDs(ctx, []ids.ID{items[1].ID()})
+	require.Zero(txm.Len(ctx))
+	require.NotContains(txm.owned, testPayer)
+}
+
+func TestMempoolAdmission(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 10, 0, FixedQuota(16), nil)
+
+	errTooCheap := errors.New("too cheap")
+	rejected := 0
+	txm.SetAdmission(func(_ context.Context, item *MempoolTestItem) error {
+		if item.UnitPrice() < 200 {
+			rejected++
+			return errTooCheap
+		}
+		return nil
+	})
+	items := []*MempoolTestItem{}
+	for _, i := range []uint64{100, 200, 150, 300} {
+		items = append(items, GenerateTestItem(testPayer, 1, i))
+	}
+	txm.Add(ctx, items)
+	require.Equal(2, rejected)
+	require.Equal(2, txm.Len(ctx))
+	require.False(txm.Has(ctx, items[0].ID()))
+	require.True(txm.Has(ctx, items[1].ID()))
+	require.False(txm.Has(ctx, items[2].ID()))
+	require.True(txm.Has(ctx, items[3].ID()))
+	require.Len(txm.owned[testPayer], 2)
+}
+
+func TestMempoolIterate(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 10, 0, FixedQuota(16), nil)
+	txm.Iterate(ctx, func(*MempoolTestItem) bool {
+		require.FailNow("empty mempool should not be iterated")
+		return true
+	})
+
+	for _, i := range []uint64{300, 100, 500, 200, 400} {
+		txm.Add(ctx, []*MempoolTestItem{GenerateTestItem(testPayer, 1, i)})
+	}
+	seen := map[uint64]bool{}
+	txm.Iterate(ctx, func(item *MempoolTestItem) bool {
+		seen[item.UnitPrice()] = true
+		return true
+	})
+	require.Len(seen, 5)
+
+	// Iteration stops early and can modify th (it walks a snapshot)
+	visited := []uint64{}
+	txm.IterateByPrice(ctx, func(item *MempoolTestItem) bool {
+		visited = append(visited, item.UnitPrice())
+		txm.Remove(ctx, []*MempoolTestItem{item})
+		return len(visited) < 3
+	})
+	require.Equal([]uint64{500, 400, 300}, visited)
+	require.Equal(2, txm.Len(ctx))
+	max, ok := txm.PeekMax(ctx)
+	require.True(ok)
+	require.Equal(uint64(200), max.UnitPrice())
+}
+
+func TestMempoolSnapshot(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 10, 0, FixedQuota(16), nil)
+	require.Empty(txm.Snapshot(ctx))
+
+	for _, i := range []uint64{300, 100, 500, 200, 400} {
+		txm.Add(ctx, []*MempoolTestItem{GenerateTestItem(testPayer, 1, i)})
+	}
+	items := txm.Snapshot(ctx)
+	prices := make([]uint64, len(items))
+	for i, item := range items {
+		prices[i] = item.UnitPrice()
+	}
+	require.Equal([]uint64{500, 400, 300, 200, 100}, prices)
+
+	// The snapshot is not modified by later changes to th
+	txm.Remove(ctx, items[:2])
+	require.Len(items, 5)
+	require.Equal(3, txm.Len(ctx))
+}
+
+func TestMempoolSnapshotReprice(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*resourceTestItem](tracer, 500, 0, FixedQuota(500), nil)
+
+	// Snapshots can be taken while th is repriced
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := uint64(1); i <= 500; i++ {
+			txm.SetResourcePrices([]uint64{i % 2, 1 - i%2})
+			txm.Add(ctx, []*resourceTestItem{{GenerateTestItem(testPayer, 1, i), []uint64{i, 500 - i}}})
+		}
+	}()
+	for repriced := false; !repriced; {
+		select {
+		case <-done:
+			repriced = true
+		default:
+		}
+		require.LessOrEqual(len(txm.Snapshot(ctx)), 500)
+	}
+	require.Len(txm.Snapshot(ctx), 500)
+}
+
+func TestMempoolSubscribe(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 2, 0, FixedQuota(16), nil)
+	sub := txm.Subscribe()
+
+	expiring := GenerateTestItem(testPayer, 1, 100)
+	kept := GenerateTestItem(testPayer, 10, 200)
+	txm.Add(ctx, []*MempoolTestItem{expiring, kept})
+	evicting := GenerateTestItem(testPayer, 11, 300)
+	txm.Add(ctx, []*MempoolTestItem{evicting})    // evicts [expiring]
+	txm.Remove(ctx, []*MempoolTestItem{expiring}) // no longer in th
+	require.Len(txm.SetMinTimestamp(ctx, 12), 2)
+
+	for _, expected := range []Event[*MempoolTestItem]{
+		{EventAdded, expiring},
+		{EventAdded, kept},
+		{EventAdded, evicting},
+		{EventRemoved, expiring},
+		{EventExpired, kept},
+		{EventExpired, evicting},
+	} {
+		e := <-sub.Events()
+		require.Equal(expected.Kind, e.Kind)
+		require.Equal(expected.Item.ID(), e.Item.ID())
+	}
+	require.Empty(sub.Events())
+
+	// Closed subscriptions receive no more events
+	sub.Close()
+	sub.Close()
+	_, ok := <-sub.Events()
+	require.False(ok)
+	txm.Add(ctx, []*MempoolTestItem{GenerateTestItem(testPayer, 100, 1_000)})
+	require.Empty(txm.subscriptions)
+}
+
+func TestMempoolSubscribeDrop(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 2*subscriptionBuffer, 0, FixedQuota(2*subscriptionBuffer), nil)
+	sub := txm.Subscribe()
+
+	// Slow subscribers lose the oldest events
+	items := make([]*MempoolTestItem, subscriptionBuffer+2)
+	for i := range items {
+		items[i] = GenerateTestItem(testPayer, 1, uint64(i))
+	}
+	txm.Add(ctx, items)
+	require.Equal(uint64(2), sub.Dropped())
+	require.Len(sub.Events(), subscriptionBuffer)
+	e := <-sub.Events()
+	require.Equal(items[2].ID(), e.Item.ID())
+}
+
+type noncedTestItem struct {
+	*MempoolTestItem
+	nonce uint64
+}
+
+func (i *noncedTestItem) Nonce() uint64 {
+	return i.nonce
+}
+
+func TestMempoolNonceOrdering(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	require.ErrorIs(New[*MempoolTestItem](tracer, 10, 0, FixedQuota(16), nil).EnableNonceOrdering(), ErrNoncesUnsupported)
+
+	txm := New[*noncedTestItem](tracer, 4, 0, FixedQuota(16), nil)
+	require.NoError(txm.EnableNonceOrdering())
+	other := "other"
+	a2 := &noncedTestItem{GenerateTestItem(testPayer, 1, 500), 2}
+	a0 := &noncedTestItem{GenerateTestItem(testPayer, 1, 100), 0}
+	a1 := &noncedTestItem{GenerateTestItem(testPayer, 1, 300), 1}
+	b0 := &noncedTestItem{GenerateTestItem(other, 1, 200), 0}
+	txm.Add(ctx, []*noncedTestItem{a2, a0, a1, b0})
+	require.Equal(4, txm.Len(ctx))
+	require.True(txm.Has(ctx, a2.ID()))
+
+	// Only the lowest nonce of each payer is offered
+	max, ok := txm.PeekMax(ctx)
+	require.True(ok)
+	require.Equal(b0.ID(), max.ID())
+
+	// Successors are not offered if their predecessor is restored
+	offered := []ids.ID{}
+	require.NoError(txm.Build(ctx, func(_ context.Context, item *noncedTestItem) (bool, bool, bool, error) {
+		offered = append(offered, item.ID())
+		return true, item.ID() == a0.ID(), false, nil
+	}))
+	require.Equal([]ids.ID{b0.ID(), a0.ID()}, offered)
+	require.Equal(3, txm.Len(ctx))
+
+	// Successors are offered (in the same pass) once their predecessor is
+	// included
+	offered = offered[:0]
+	require.NoError(txm.Build(ctx, func(_ context.Context, item *noncedTestItem) (bool, bool, bool, error) {
+		offered = append(offered, item.ID())
+		return true, false, false, nil
+	}))
+	require.Equal([]ids.ID{a0.ID(), a1.ID(), a2.ID()}, offered)
+	require.Zero(txm.Len(ctx))
+	require.Empty(txm.heads)
+	require.Empty(txm.queued)
+}
+
+func TestMempoolNonceOrderingEviction(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*noncedTestItem](tracer, 3, 0, FixedQuota(16), nil)
+	require.NoError(txm.EnableNonceOrdering())
+
+	other := "other"
+	a0 := &noncedTestItem{GenerateTestItem(testPayer, 1, 100), 0}
+	a1 := &noncedTestItem{GenerateTestItem(testPayer, 1, 900), 1}
+	b0 := &noncedTestItem{GenerateTestItem(other, 1, 200), 0}
+	b1 := &noncedTestItem{GenerateTestItem(other, 1, 50), 1}
+	txm.Add(ctx, []*noncedTestItem{a0, a1, b0, b1})
+
+	// The highest nonce of the payer with the lowest paying head is evicted
+	require.Equal(3, txm.Len(ctx))
+	require.False(txm.Has(ctx, a1.ID()))
+	require.True(txm.Has(ctx, b1.ID()))
+
+	// Removing a head offers its successor
+	txm.Remove(ctx, []*noncedTestItem{b0})
+	max, ok := txm.PeekMax(ctx)
+	require.True(ok)
+	require.Equal(a0.ID(), max.ID())
+	min, ok := txm.PopMin(ctx)
+	require.True(ok)
+	require.Equal(b1.ID(), min.ID())
+	txm.RemoveAccount(ctx, testPayer)
+	require.Zero(txm.Len(ctx))
+	require.Empty(txm.heads)
+	require.Empty(txm.queued)
+}
+
+func TestMempoolEvictionPolicy(t *testing.T) {
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	whale := "whale"
+	tests := []struct {
+		name   string
+		policy EvictionPolicy[*MempoolTestItem]
+		// [evicted] is the index of the item evicted when the last item is added
+		evicted int
+	}{
+		{"lowest price", LowestPrice[*MempoolTestItem](), 3},
+		{"oldest first", OldestFirst[*MempoolTestItem](), 0},
+		{"payer fairness", PayerFairness[*MempoolTestItem](), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.TODO()
+			txm := New[*MempoolTestItem](tracer, 3, 0, FixedQuota(16), nil)
+			txm.SetEvictionPolicy(tt.policy)
+			items := []*MempoolTestItem{
+				GenerateTestItem(whale, 1, 500),
+				GenerateTestItem(whale, 2, 400),
+				GenerateTestItem(whale, 3, 300),
+				GenerateTestItem(testPayer, 4, 100),
+			}
+			txm.Add(ctx, items)
+			require.Equal(3, txm.Len(ctx))
+			for i, item := range items {
+				require.Equal(i != tt.evicted, txm.Has(ctx, item.ID()))
+			}
+		})
+	}
+}
+
+func TestMempoolBanPayer(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	spammer := "spammer"
+	txm := New[*MempoolTestItem](tracer, 10, 0, FixedQuota(10), nil)
+	item1 := GenerateTestItem(spammer, 1, 10)
+	item2 := GenerateTestItem(spammer, 1, 20)
+	item3 := GenerateTestItem(testPayer, 1, 30)
+	txm.Add(ctx, []*MempoolTestItem{item1, item2, item3})
+	require.Equal(3, txm.Len(ctx))
+
+	// Banning drops all items by the payer
+	sub := txm.Subscribe()
+	defer sub.Close()
+	removed := txm.BanPayer(ctx, spammer)
+	require.Len(removed, 2)
+	require.True(txm.Banned(spammer))
+	require.Equal(1, txm.Len(ctx))
+	require.True(txm.Has(ctx, item3.ID()))
+	for i := 0; i < 2; i++ {
+		e := <-sub.Events()
+		require.Equal(EventRemoved, e.Kind)
+		require.Equal(spammer, e.Item.Payer())
+	}
+
+	// New items by the payer are dropped
+	item4 := GenerateTestItem(spammer, 1, 40)
+	txm.Add(ctx, []*MempoolTestItem{item4})
+	require.False(txm.Has(ctx, item4.ID()))
+
+	// Unbanning allows the payer to add items again
+	txm.UnbanPayer(ctx, spammer)
+	require.False(txm.Banned(spammer))
+	txm.Add(ctx, []*MempoolTestItem{item4})
+	require.True(txm.Has(ctx, item4.ID()))
+}
+
+func TestMempoolAccountStats(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	txm := New[*MempoolTestItem](tracer, 10, 0, FixedQuota(10), nil)
+	txm.Add(ctx, []*MempoolTestItem{
+		GenerateTestItem(testPayer, 1, 30),
+		GenerateTestItem(testPayer, 1, 10),
+		GenerateTestItem(testPayer, 1, 20),
+		GenerateTestItem("other", 1, 100),
+	})
+	require.Equal(2, txm.Payers(ctx))
+	require.Equal(AccountStats{
+		Count:    3,
+		Bytes:    3 * testItemSize,
+		MinPrice: 10,
+		MaxPrice: 30,
+	}, txm.AccountStats(ctx, testPayer))
+	require.Equal(AccountStats{}, txm.AccountStats(ctx, "missing"))
+
+	txm.RemoveAccount(ctx, testPayer)
+	require.Equal(1, txm.Payers(ctx))
+	require.Equal(AccountStats{}, txm.AccountStats(ctx, testPayer))
+
+	// The units of each resource consumed by [ResourceItem]s are totaled
+	rtxm := New[*resourceTestItem](tracer, 10, 0, FixedQuota(10), nil)
+	rtxm.Add(ctx, []*resourceTestItem{
+		{GenerateTestItem(testPayer, 1, 1), []uint64{10, 0}},
+		{GenerateTestItem(testPayer, 1, 2), []uint64{5, 5, 1}},
+	})
+	require.Equal(AccountStats{
+		Count:    2,
+		Bytes:    2 * testItemSize,
+		Units:    []uint64{15, 5, 1},
+		MinPrice: 1,
+		MaxPrice: 2,
+	}, rtxm.AccountStats(ctx, testPayer))
+}
+
+func TestMempoolWeightedQuota(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	whale := "whale"
+	quotas := map[string]int{whale: 6, testPayer: 2}
+	txm := New[*MempoolTestItem](tracer, 20, 0, QuotaFunc(func(_ context.Context, payer string) int {
+		return quotas[payer]
+	}), nil)
+
+	// Add 8 transactions for each payer
+	for i := uint64(0); i < 8; i++ {
+		txm.Add(ctx, []*MempoolTestItem{
+			GenerateTestItem(whale, 1, i),
+			GenerateTestItem(testPayer, 1, i),
+		})
+	}
+	require.Equal(8, txm.Len(ctx))
+	require.Len(txm.owned[whale], 6)
+	require.Len(txm.owned[testPayer], 2)
+
+	// Lowering a quota does not remove existing items
+	quotas[whale] = 1
+	txm.Add(ctx, []*MempoolTestItem{GenerateTestItem(whale, 1, 100)})
+	require.Len(txm.owned[whale], 6)
+}
+
+type resourceTestItem struct {
+	*MempoolTestItem
+	units []uint64
+}
+
+func (i *resourceTestItem) ResourceUnits() []uint64 {
+	return i.units
+}
+
+func TestMempoolResourcePrices(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*resourceTestItem](tracer, 10, 0, FixedQuota(16), nil)
+
+	a := &resourceTestItem{GenerateTestItem(testPayer, 1, 1), []uint64{10, 0}}
+	b := &resourceTestItem{GenerateTestItem(testPayer, 1, 2), []uint64{0, 10}}
+	c := &resourceTestItem{GenerateTestItem(testPayer, 1, 3), []uint64{5, 5}}
+	txm.Add(ctx, []*resourceTestItem{a, b, c})
+
+	// Items are valued by unit price until resource prices are set
+	max, ok := txm.PeekMax(ctx)
+	require.True(ok)
+	require.Equal(c.ID(), max.ID())
+
+	// Repricing is deferred until th is modified
+	txm.SetResourcePrices([]uint64{1, 3})
+	max, ok = txm.PeekMax(ctx)
+	require.True(ok)
+	require.Equal(c.ID(), max.ID())
+
+	built := []ids.ID{}
+	require.NoError(txm.Build(ctx, func(_ context.Context, item *resourceTestItem) (bool, bool, bool, error) {
+		built = append(built, item.ID())
+		return true, false, false, nil
+	}))
+	require.Equal([]ids.ID{b.ID(), c.ID(), a.ID()}, built)
+
+	require.Equal(uint64(40), EffectivePrice(&resourceTestItem{GenerateTestItem(testPayer, 1, 1), []uint64{1, 1, 100}}, []uint64{10, 30}))
+	require.Equal(uint64(7), EffectivePrice(GenerateTestItem(testPayer, 1, 7), []uint64{10, 30}))
+}
+
+func TestMempoolResourceFloor(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*resourceTestItem](tracer, 3, 0, FixedQuota(16), nil)
+
+	a := &resourceTestItem{GenerateTestItem(testPayer, 1, 1), []uint64{10, 0}}
+	b := &resourceTestItem{GenerateTestItem(testPayer, 1, 2), []uint64{0, 10}}
+	c := &resourceTestItem{GenerateTestItem(testPayer, 1, 3), []uint64{5, 5}}
+	txm.Add(ctx, []*resourceTestItem{a, b, c})
+	floor, full := txm.Floor(ctx)
+	require.True(full)
+	require.Equal(uint64(1), floor)
+
+	// The floor is reported at the prices th.pm is ordered by (which don't
+	// change until it is repriced)
+	txm.SetResourcePrices([]uint64{1, 3})
+	floor, full = txm.Floor(ctx)
+	require.True(full)
+	require.Equal(uint64(1), floor)
+
+	// Once repriced, the floor is the effective price of the lowest paying
+	// item (rather than its unit price)
+	d := &resourceTestItem{GenerateTestItem(testPayer, 1, 4), []uint64{100, 0}}
+	txm.Add(ctx, []*resourceTestItem{d})
+	require.False(txm.Has(ctx, a.ID()))
+	floor, full = txm.Floor(ctx)
+	require.True(full)
+	require.Equal(uint64(20), floor)
+}
+
+func TestMempoolPause(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*MempoolTestItem](tracer, 3, 0, FixedQuota(16), nil)
+
+	existing := GenerateTestItem(testPayer, 1, 100)
+	txm.Add(ctx, []*MempoolTestItem{existing})
+
+	admitted := 0
+	txm.SetAdmission(func(context.Context, *MempoolTestItem) error {
+		admitted++
+		return nil
+	})
+	txm.Pause(ctx)
+	require.True(txm.Paused())
+
+	// Items are held (without running admission) until the buffer is full
+	items := []*MempoolTestItem{}
+	for i := uint64(0); i < 4; i++ {
+		items = append(items, GenerateTestItem(testPayer, 1, 200+i))
+	}
+	txm.Add(ctx, append(items, items[0], existing))
+	require.Equal(0, admitted)
+	require.Equal(1, txm.Len(ctx))
+	require.Equal(3, txm.Overflow(ctx))
+	require.False(txm.Has(ctx, items[0].ID()))
+	reason, ok := txm.DropReason(ctx, items[3].ID())
+	require.True(ok)
+	require.Equal(DropPaused, reason)
+
+	// Held items are added on resume
+	txm.Resume(ctx)
+	require.False(txm.Paused())
+	require.Equal(3, admitted)
+	require.Equal(0, txm.Overflow(ctx))
+	require.Equal(3, txm.Len(ctx))
+	for _, item := range items[1:3] {
+		require.True(txm.Has(ctx, item.ID()))
+	}
+	require.False(txm.Has(ctx, items[3].ID()))
+
+	// Not paused anymore
+	txm.Add(ctx, []*MempoolTestItem{items[3]})
+	require.True(txm.Has(ctx, items[3].ID()))
+}
@@ -0,0 +1,266 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempooltest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	"github.com/ava-labs/hypersdk/mempool"
+)
+
+// maxUnitPrice bounds the unit price of generated items.
+const maxUnitPrice = 1 << 32
+
+var ErrDiverged = errors.New("mempool diverged from model")
+
+// NewItem creates an item with the provided fields. This allows [Run] to be
+// used with any [mempool.Item] implementation.
+type NewItem[T mempool.Item] func(id ids.ID, payer string, expiry int64, unitPrice uint64) T
+
+// Config determines the [mempool.Mempool] checked by [Run] and the
+// operations performed on it.
+type Config struct {
+	// Seed makes the operations performed by [Run] deterministic (the same
+	// seed always performs the same operations).
+	Seed       int64
+	Operations int
+
+	MaxSize int
+	Quota   int
+
+	// Items are paid for by [Payers] different payers, the first
+	// [ExemptPayers] of which are exempt from [Quota].
+	Payers       int
+	ExemptPayers int
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Seed:         1,
+		Operations:   2_000,
+		MaxSize:      64,
+		Quota:        8,
+		Payers:       16,
+		ExemptPayers: 2,
+	}
+}
+
+// driver performs random operations on a [mempool.Mempool] and a [Model] and
+// checks that they agree after each one.
+type driver[T mempool.Item] struct {
+	cfg     Config
+	r       *rand.Rand
+	newItem NewItem[T]
+
+	mempool *mempool.Mempool[T]
+	model   *Model[T]
+
+	payers []string
+	now    int64
+	prices set.Set[uint64]
+	// [removed] holds items that have left the mempool (so that they can be
+	// added again)
+	removed []T
+}
+
+// Run performs [cfg.Operations] random operations (adding, removing,
+// popping, and expiring items) on a new [mempool.Mempool] and checks after
+// each one that its contents, ordering, and per-payer quotas match those of a
+// [Model]. Run returns an error wrapping [ErrDiverged] at the first
+// operation after which they don't.
+//
+// Every generated item pays a distinct unit price (the order of items that
+// are valued equally is not defined by [mempool.Mempool]). If T implements
+// [mempool.ResourceItem], its effective price (with no resource prices set)
+// must be its unit price.
+func Run[T mempool.Item](ctx context.Context, cfg Config, newItem NewItem[T]) error {
+	tracer, err := trace.New(trace.Config{Enabled: false})
+	if err != nil {
+		return err
+	}
+	d := &driver[T]{
+		cfg:     cfg,
+		r:       rand.New(rand.NewSource(cfg.Seed)), //nolint:gosec
+		newItem: newItem,
+		payers:  make([]string, cfg.Payers),
+		prices:  set.Set[uint64]{},
+	}
+	for i := range d.payers {
+		d.payers[i] = fmt.Sprintf("payer-%d", i)
+	}
+	exempt := d.payers[:cfg.ExemptPayers]
+	exemptPayers := make([][]byte, len(exempt))
+	for i, payer := range exempt {
+		exemptPayers[i] = []byte(payer)
+	}
+	d.mempool = mempool.New[T](tracer, cfg.MaxSize, 0, mempool.FixedQuota(cfg.Quota), exemptPayers)
+	d.model = NewModel[T](cfg.MaxSize, cfg.Quota, exempt)
+
+	for i := 0; i < cfg.Operations; i++ {
+		op, err := d.step(ctx)
+		if err != nil {
+			return fmt.Errorf("%w: operation %d (%s): %v", ErrDiverged, i, op, err)
+		}
+		if err := d.check(ctx); err != nil {
+			return fmt.Errorf("%w: after operation %d (%s): %v", ErrDiverged, i, op, err)
+		}
+	}
+	return nil
+}
+
+// item returns a new item with a unit price no other item has.
+func (d *driver[T]) item() T {
+	var id ids.ID
+	_, _ = d.r.Read(id[:])
+	payer := d.payers[d.r.Intn(len(d.payers))]
+	expiry := d.now + 1 + d.r.Int63n(20)
+	for {
+		price := uint64(d.r.Int63n(maxUnitPrice))
+		if d.prices.Contains(price) {
+			continue
+		}
+		d.prices.Add(price)
+		return d.newItem(id, payer, expiry, price)
+	}
+}
+
+// existing returns up to [n] items in the model.
+func (d *driver[T]) existing(n int) []T {
+	items := d.model.Items()
+	d.r.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+	if len(items) > n {
+		items = items[:n]
+	}
+	return items
+}
+
+// step performs a single random operation on both the mempool and the model
+// and returns its name.
+func (d *driver[T]) step(ctx context.Context) (string, error) {
+	switch n := d.r.Intn(100); {
+	case n < 45:
+		items := make([]T, 1+d.r.Intn(4))
+		for i := range items {
+			items[i] = d.item()
+		}
+		// Include items that are already in the mempool
+		items = append(items, d.existing(d.r.Intn(2))...)
+		d.add(ctx, items)
+		return "add", nil
+	case n < 55:
+		if len(d.removed) == 0 {
+			return "re-add", nil
+		}
+		i := d.r.Intn(len(d.removed))
+		item := d.removed[i]
+		d.removed = append(d.removed[:i], d.removed[i+1:]...)
+		d.add(ctx, []T{item})
+		return "re-add", nil
+	case n < 65:
+		items := d.existing(1 + d.r.Intn(3))
+		d.mempool.Remove(ctx, items)
+		d.model.Remove(items)
+		d.removed = append(d.removed, items...)
+		return "remove", nil
+	case n < 75:
+		got, gotOK := d.mempool.PopMax(ctx)
+		expected, expectedOK := d.model.PopMax()
+		return "pop max", d.popped(got, gotOK, expected, expectedOK)
+	case n < 80:
+		got, gotOK := d.mempool.PopMin(ctx)
+		expected, expectedOK := d.model.PopMin()
+		return "pop min", d.popped(got, gotOK, expected, expectedOK)
+	case n < 95:
+		d.now += d.r.Int63n(4)
+		got := d.mempool.SetMinTimestamp(ctx, d.now)
+		expected := d.model.SetMinTimestamp(d.now)
+		if !idSet(got).Equals(idSet(expected)) {
+			return "expire", fmt.Errorf("expired %d items but expected %d", len(got), len(expected))
+		}
+		return "expire", nil
+	default:
+		payer := d.payers[d.r.Intn(len(d.payers))]
+		d.mempool.RemoveAccount(ctx, payer)
+		d.model.RemoveAccount(payer)
+		return "remove account", nil
+	}
+}
+
+// add adds [items] to both the mempool and the model and remembers any that
+// were not added (or evicted) so that they can be added again.
+func (d *driver[T]) add(ctx context.Context, items []T) {
+	before := d.model.Items()
+	d.mempool.Add(ctx, items)
+	d.model.Add(items)
+	for _, item := range append(before, items...) {
+		if !d.model.Has(item.ID()) {
+			d.removed = append(d.removed, item)
+		}
+	}
+}
+
+func (d *driver[T]) popped(got T, gotOK bool, expected T, expectedOK bool) error {
+	if gotOK != expectedOK {
+		return fmt.Errorf("popped=%t but expected %t", gotOK, expectedOK)
+	}
+	if gotOK && got.ID() != expected.ID() {
+		return fmt.Errorf("popped %s but expected %s", got.ID(), expected.ID())
+	}
+	return nil
+}
+
+// check returns an error if the mempool does not match the model.
+func (d *driver[T]) check(ctx context.Context) error {
+	if got, expected := d.mempool.Len(ctx), d.model.Len(); got != expected {
+		return fmt.Errorf("len=%d but expected %d", got, expected)
+	}
+	if l := d.mempool.Len(ctx); l > d.cfg.MaxSize {
+		return fmt.Errorf("len=%d exceeds max size %d", l, d.cfg.MaxSize)
+	}
+
+	// Ordering
+	got, expected := d.mempool.Snapshot(ctx), d.model.Items()
+	for i, item := range expected {
+		if got[i].ID() != item.ID() {
+			return fmt.Errorf("item %d is %s but expected %s", i, got[i].ID(), item.ID())
+		}
+	}
+	gotMax, gotOK := d.mempool.PeekMax(ctx)
+	expectedMax, expectedOK := d.model.PeekMax()
+	if gotOK != expectedOK || (gotOK && gotMax.ID() != expectedMax.ID()) {
+		return errors.New("unexpected max item")
+	}
+	gotMin, gotOK := d.mempool.PeekMin(ctx)
+	expectedMin, expectedOK := d.model.PeekMin()
+	if gotOK != expectedOK || (gotOK && gotMin.ID() != expectedMin.ID()) {
+		return errors.New("unexpected min item")
+	}
+
+	// Quotas
+	for i, payer := range d.payers {
+		count := d.mempool.AccountStats(ctx, payer).Count
+		if expected := d.model.Count(payer); count != expected {
+			return fmt.Errorf("%s has %d items but expected %d", payer, count, expected)
+		}
+		if i >= d.cfg.ExemptPayers && count > d.cfg.Quota {
+			return fmt.Errorf("%s has %d items which exceeds quota %d", payer, count, d.cfg.Quota)
+		}
+	}
+	return nil
+}
+
+func idSet[T mempool.Item](items []T) set.Set[ids.ID] {
+	s := set.NewSet[ids.ID](len(items))
+	for _, item := range items {
+		s.Add(item.ID())
+	}
+	return s
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempooltest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	id        ids.ID
+	payer     string
+	expiry    int64
+	unitPrice uint64
+}
+
+func (i *testItem) ID() ids.ID        { return i.id }
+func (i *testItem) Payer() string     { return i.payer }
+func (i *testItem) Expiry() int64     { return i.expiry }
+func (i *testItem) UnitPrice() uint64 { return i.unitPrice }
+func (*testItem) Size() int           { return 100 }
+
+func newTestItem(id ids.ID, payer string, expiry int64, unitPrice uint64) *testItem {
+	return &testItem{id, payer, expiry, unitPrice}
+}
+
+func TestRun(t *testing.T) {
+	for seed := int64(0); seed < 8; seed++ {
+		cfg := DefaultConfig()
+		cfg.Seed = seed
+		require.NoError(t, Run[*testItem](context.TODO(), cfg, newTestItem))
+	}
+}
+
+func TestRunSmallMempool(t *testing.T) {
+	// Evictions and quota checks on nearly every add
+	cfg := DefaultConfig()
+	cfg.MaxSize = 4
+	cfg.Quota = 1
+	cfg.Payers = 3
+	cfg.ExemptPayers = 1
+	require.NoError(t, Run[*testItem](context.TODO(), cfg, newTestItem))
+}
+
+func TestModel(t *testing.T) {
+	require := require.New(t)
+
+	m := NewModel[*testItem](2, 1, []string{"exempt"})
+	low := newTestItem(ids.GenerateTestID(), "a", 1, 1)
+	high := newTestItem(ids.GenerateTestID(), "b", 2, 3)
+	m.Add([]*testItem{low, high})
+
+	// Over quota
+	m.Add([]*testItem{newTestItem(ids.GenerateTestID(), "a", 1, 5)})
+	require.Equal(2, m.Len())
+
+	// Evicts [low]
+	mid := newTestItem(ids.GenerateTestID(), "exempt", 1, 2)
+	m.Add([]*testItem{mid})
+	require.Equal([]*testItem{high, mid}, m.Items())
+
+	require.Equal([]*testItem{mid}, m.SetMinTimestamp(2))
+	popped, ok := m.PopMax()
+	require.True(ok)
+	require.Equal(high, popped)
+	_, ok = m.PopMin()
+	require.False(ok)
+}
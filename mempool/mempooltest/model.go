@@ -0,0 +1,169 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempooltest
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	"github.com/ava-labs/hypersdk/mempool"
+)
+
+// Model is a reference implementation of the ordering, quota, and eviction
+// rules of a [mempool.Mempool] (created without a byte limit and using the
+// default [mempool.EvictionPolicy]).
+//
+// Model favors being obviously correct over being fast (most operations are
+// O(N log N)), so it should only be used to check a [mempool.Mempool] in
+// tests.
+type Model[T mempool.Item] struct {
+	maxSize int
+	quota   int
+	exempt  set.Set[string]
+
+	items map[ids.ID]T
+}
+
+// NewModel returns an empty [Model] that holds at most [maxSize] items and at
+// most [quota] items from each payer (other than [exemptPayers]).
+func NewModel[T mempool.Item](maxSize int, quota int, exemptPayers []string) *Model[T] {
+	m := &Model[T]{
+		maxSize: maxSize,
+		quota:   quota,
+		exempt:  set.Set[string]{},
+		items:   map[ids.ID]T{},
+	}
+	m.exempt.Add(exemptPayers...)
+	return m
+}
+
+// less returns true if [a] is valued less than [b]. Ties are broken by ID so
+// that the order of all items is defined.
+func less[T mempool.Item](a, b T) bool {
+	pa, pb := mempool.EffectivePrice(a, nil), mempool.EffectivePrice(b, nil)
+	if pa != pb {
+		return pa < pb
+	}
+	aID, bID := a.ID(), b.ID()
+	return bytes.Compare(aID[:], bID[:]) < 0
+}
+
+// Items returns all items in m ordered from the highest to the lowest valued.
+func (m *Model[T]) Items() []T {
+	items := make([]T, 0, len(m.items))
+	for _, item := range m.items {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return less(items[j], items[i]) })
+	return items
+}
+
+// Len returns the number of items in m.
+func (m *Model[T]) Len() int {
+	return len(m.items)
+}
+
+// Has returns true if the item with [id] is in m.
+func (m *Model[T]) Has(id ids.ID) bool {
+	_, ok := m.items[id]
+	return ok
+}
+
+// Count returns the number of items from [payer] in m.
+func (m *Model[T]) Count(payer string) int {
+	count := 0
+	for _, item := range m.items {
+		if item.Payer() == payer {
+			count++
+		}
+	}
+	return count
+}
+
+// Add adds each of [items] to m (in order) unless it is already in m or its
+// payer is at their quota. Whenever m holds more than its maximum number of
+// items, the lowest valued item is evicted (which may be the item that was
+// just added).
+func (m *Model[T]) Add(items []T) {
+	for _, item := range items {
+		if m.Has(item.ID()) {
+			continue
+		}
+		if !m.exempt.Contains(item.Payer()) && m.Count(item.Payer()) >= m.quota {
+			continue
+		}
+		m.items[item.ID()] = item
+		if len(m.items) > m.maxSize {
+			m.PopMin()
+		}
+	}
+}
+
+// Remove removes each of [items] that is in m.
+func (m *Model[T]) Remove(items []T) {
+	for _, item := range items {
+		delete(m.items, item.ID())
+	}
+}
+
+// PeekMax returns the highest valued item in m.
+func (m *Model[T]) PeekMax() (T, bool) {
+	items := m.Items()
+	if len(items) == 0 {
+		return *new(T), false
+	}
+	return items[0], true
+}
+
+// PeekMin returns the lowest valued item in m.
+func (m *Model[T]) PeekMin() (T, bool) {
+	items := m.Items()
+	if len(items) == 0 {
+		return *new(T), false
+	}
+	return items[len(items)-1], true
+}
+
+// PopMax removes and returns the highest valued item in m.
+func (m *Model[T]) PopMax() (T, bool) {
+	item, ok := m.PeekMax()
+	if ok {
+		delete(m.items, item.ID())
+	}
+	return item, ok
+}
+
+// PopMin removes and returns the lowest valued item in m.
+func (m *Model[T]) PopMin() (T, bool) {
+	item, ok := m.PeekMin()
+	if ok {
+		delete(m.items, item.ID())
+	}
+	return item, ok
+}
+
+// SetMinTimestamp removes and returns all items in m with an expiry lower
+// than [t] (in no particular order).
+func (m *Model[T]) SetMinTimestamp(t int64) []T {
+	removed := []T{}
+	for id, item := range m.items {
+		if item.Expiry() < t {
+			removed = append(removed, item)
+			delete(m.items, id)
+		}
+	}
+	return removed
+}
+
+// RemoveAccount removes all items from [payer] in m.
+func (m *Model[T]) RemoveAccount(payer string) {
+	for id, item := range m.items {
+		if item.Payer() == payer {
+			delete(m.items, id)
+		}
+	}
+}
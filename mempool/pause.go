@@ -0,0 +1,97 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// Pause makes [Add] hold new items in an overflow buffer (instead of adding
+// them to th) until [Resume] is called. The buffer holds at most as many items
+// as th, and items passed to [Add] once it is full are dropped with
+// [DropPaused].
+//
+// This should be called while th can't be serviced (like during state sync or
+// a disruptive upgrade) so that th doesn't fill up with items that may be
+// invalid by the time blocks can be built again. Items already in th are not
+// removed.
+func (th *Mempool[T]) Pause(ctx context.Context) {
+	_, span := th.tracer.Start(ctx, "Mempool.Pause")
+	defer span.End()
+
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	if th.paused {
+		return
+	}
+	th.paused = true
+	th.overflowIDs = set.Set[ids.ID]{}
+}
+
+// Resume adds all items held while th was paused (in the order they were
+// passed to [Add]) and makes [Add] insert items into th again. The held items
+// are subject to the same checks as any other items passed to [Add]
+// (including admission).
+func (th *Mempool[T]) Resume(ctx context.Context) {
+	ctx, span := th.tracer.Start(ctx, "Mempool.Resume")
+	defer span.End()
+
+	th.mu.Lock()
+	if !th.paused {
+		th.mu.Unlock()
+		return
+	}
+	overflow := th.overflow
+	th.paused = false
+	th.overflow = nil
+	th.overflowIDs = nil
+	th.mu.Unlock()
+
+	th.Add(ctx, overflow)
+}
+
+// Paused returns true if th is paused (see [Pause]).
+func (th *Mempool[T]) Paused() bool {
+	th.mu.RLock()
+	defer th.mu.RUnlock()
+
+	return th.paused
+}
+
+// Overflow returns the number of items held while th is paused.
+func (th *Mempool[T]) Overflow(ctx context.Context) int {
+	_, span := th.tracer.Start(ctx, "Mempool.Overflow")
+	defer span.End()
+
+	th.mu.RLock()
+	defer th.mu.RUnlock()
+
+	return len(th.overflow)
+}
+
+// hold adds [items] to the overflow buffer of th (if there is space).
+//
+// Assumes th.mu is held and th is paused.
+func (th *Mempool[T]) hold(items []T) {
+	for _, item := range items {
+		id := item.ID()
+		if th.banned.Contains(item.Payer()) {
+			th.drops.Put(id, DropBanned)
+			continue
+		}
+		if th.tm.Has(id) || th.overflowIDs.Contains(id) {
+			continue
+		}
+		if len(th.overflow) >= th.maxSize {
+			th.drops.Put(id, DropPaused)
+			continue
+		}
+		th.overflow = append(th.overflow, item)
+		th.overflowIDs.Add(id)
+	}
+}
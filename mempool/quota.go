@@ -0,0 +1,36 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import "context"
+
+// PayerQuota determines the maximum number of items each payer may have in a
+// [Mempool] at once (unless the payer is exempt). This allows a VM to weight
+// the quota of each payer (e.g. by the amount they have staked) so that
+// high-value accounts can hold more pending items while small accounts
+// remain bounded.
+//
+// [Quota] is called without holding any locks in the [Mempool], so it may be
+// somewhat expensive (like reading from state). If the quota of a payer is
+// lowered, none of their existing items are removed but no new items are
+// added until they fall below it.
+type PayerQuota interface {
+	Quota(ctx context.Context, payer string) int
+}
+
+// FixedQuota allows every payer to have the same number of items in a
+// [Mempool].
+type FixedQuota int
+
+func (q FixedQuota) Quota(context.Context, string) int {
+	return int(q)
+}
+
+// QuotaFunc is an adapter to allow the use of ordinary functions as a
+// [PayerQuota].
+type QuotaFunc func(ctx context.Context, payer string) int
+
+func (f QuotaFunc) Quota(ctx context.Context, payer string) int {
+	return f(ctx, payer)
+}
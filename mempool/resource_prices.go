@@ -0,0 +1,89 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"github.com/ava-labs/avalanchego/utils/math"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// ResourceItem is an [Item] that consumes multiple resources, each of which
+// may be priced differently (see [Mempool.SetResourcePrices]).
+type ResourceItem interface {
+	Item
+	// ResourceUnits returns the units of each resource consumed by the item
+	// (indexed the same as the prices passed to [Mempool.SetResourcePrices]).
+	ResourceUnits() []uint64
+}
+
+// EffectivePrice returns the fee [item] pays for the resources it consumes at
+// [prices]. If [prices] is empty or [item] is not a [ResourceItem], this is
+// just [Item.UnitPrice]. Resources without a price are free.
+func EffectivePrice[T Item](item T, prices []uint64) uint64 {
+	if len(prices) == 0 {
+		return item.UnitPrice()
+	}
+	ri, ok := any(item).(ResourceItem)
+	if !ok {
+		return item.UnitPrice()
+	}
+	var price uint64
+	for i, units := range ri.ResourceUnits() {
+		if i == len(prices) {
+			break
+		}
+		fee, err := math.Mul64(units, prices[i])
+		if err != nil {
+			return consts.MaxUint64
+		}
+		price, err = math.Add64(price, fee)
+		if err != nil {
+			return consts.MaxUint64
+		}
+	}
+	return price
+}
+
+// byEffectivePrice orders items by [EffectivePrice] at [prices].
+func byEffectivePrice[T Item](prices []uint64) Compare[T] {
+	return ByValue(func(item T) uint64 { return EffectivePrice(item, prices) })
+}
+
+// SetResourcePrices sets the price of each resource consumed by items (see
+// [ResourceItem]). th.pm is re-sorted by [EffectivePrice] at [prices] the next
+// time it is modified (usually by [Build]) rather than immediately, so that
+// prices can be updated as often as needed. Until then, [PeekMax],
+// [PeekTopN], and [Floor] may reflect the previous prices.
+func (th *Mempool[T]) SetResourcePrices(prices []uint64) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	if slices.Equal(th.resourcePrices, prices) {
+		return
+	}
+	th.resourcePrices = slices.Clone(prices)
+	th.repricePending = true
+}
+
+// reprice rebuilds th.pm if the resource prices have changed since it was
+// last built.
+//
+// Assumes th.mu is held.
+func (th *Mempool[T]) reprice() {
+	if !th.repricePending {
+		return
+	}
+	th.repricePending = false
+	pm := NewSortedMempool(
+		math.Min(th.maxSize, maxPrealloc),
+		byEffectivePrice[T](th.resourcePrices),
+	)
+	for _, item := range th.pm.Items() {
+		pm.Add(item)
+	}
+	th.pm = pm
+	th.pmPrices = th.resourcePrices
+}
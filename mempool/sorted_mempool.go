@@ -15,28 +15,58 @@ type Item interface {
 	Payer() string
 	Expiry() int64
 	UnitPrice() uint64
+	Size() int
+}
+
+// NoncedItem is an [Item] that must be executed in nonce order with the other
+// items of its payer (see [Mempool.EnableNonceOrdering]).
+type NoncedItem interface {
+	Item
+	Nonce() uint64
+}
+
+// Compare returns a negative number if [a] is valued less than [b], a positive
+// number if [a] is valued more than [b], and 0 if they are valued equally.
+type Compare[T Item] func(a, b T) int
+
+// ByValue returns a [Compare] that orders items by the value returned by [f].
+func ByValue[T Item](f func(item T) uint64) Compare[T] {
+	return func(a, b T) int {
+		va, vb := f(a), f(b)
+		switch {
+		case va < vb:
+			return -1
+		case va > vb:
+			return 1
+		default:
+			return 0
+		}
+	}
 }
 
 // SortedMempool contains a max-heap and min-heap. The order within each
-// heap is determined by using GetValue.
+// heap is determined by using Compare.
 //
 // This data structure does not perform any synchronization and is not
 // safe to use concurrently without external locking.
 type SortedMempool[T Item] struct {
-	// GetValue informs heaps how to get the an entry's value for ordering.
-	GetValue func(item T) uint64
+	// Compare informs heaps how to order entries. Using a full comparator
+	// (instead of a single value) allows items to be ordered by
+	// multi-dimensional fees or with custom tiebreakers.
+	Compare Compare[T]
 
-	minHeap *heap.Heap[T, uint64] // only includes lowest nonce
-	maxHeap *heap.Heap[T, uint64] // only includes lowest nonce
+	minHeap *heap.Heap[T, T] // only includes lowest nonce
+	maxHeap *heap.Heap[T, T] // only includes lowest nonce
 }
 
 // NewSortedMempool returns an instance of SortedMempool with minHeap and maxHeap
-// containing [items] and prioritized with [f]
-func NewSortedMempool[T Item](items int, f func(item T) uint64) *SortedMempool[T] {
+// containing [items] and prioritized with [compare] (use [ByValue] to
+// prioritize items by a single value).
+func NewSortedMempool[T Item](items int, compare Compare[T]) *SortedMempool[T] {
 	return &SortedMempool[T]{
-		GetValue: f,
-		minHeap:  heap.New[T, uint64](items, true),
-		maxHeap:  heap.New[T, uint64](items, false),
+		Compare: compare,
+		minHeap: heap.NewFunc[T](items, func(a, b T) bool { return compare(a, b) < 0 }),
+		maxHeap: heap.NewFunc[T](items, func(a, b T) bool { return compare(a, b) > 0 }),
 	}
 }
 
@@ -44,16 +74,15 @@ func NewSortedMempool[T Item](items int, f func(item T) uint64) *SortedMempool[T
 func (sm *SortedMempool[T]) Add(item T) {
 	itemID := item.ID()
 	poolLen := sm.maxHeap.Len()
-	val := sm.GetValue(item)
-	sm.maxHeap.Push(&heap.Entry[T, uint64]{
+	sm.maxHeap.Push(&heap.Entry[T, T]{
 		ID:    itemID,
-		Val:   val,
+		Val:   item,
 		Item:  item,
 		Index: poolLen,
 	})
-	sm.minHeap.Push(&heap.Entry[T, uint64]{
+	sm.minHeap.Push(&heap.Entry[T, T]{
 		ID:    itemID,
-		Val:   val,
+		Val:   item,
 		Item:  item,
 		Index: poolLen,
 	})
@@ -75,16 +104,16 @@ func (sm *SortedMempool[T]) Remove(id ids.ID) {
 	sm.minHeap.Remove(minEntry.Index) // O(log N)
 }
 
-// SetMinVal removes all elements in sm with a value less than [val]. Returns
-// the list of removed elements.
-func (sm *SortedMempool[T]) SetMinVal(val uint64) []T {
+// PopMinWhile removes the minimum element in sm until [f] returns false for
+// it (or sm is empty). Returns the list of removed elements.
+func (sm *SortedMempool[T]) PopMinWhile(f func(item T) bool) []T {
 	removed := []T{}
 	for {
 		min, ok := sm.PeekMin()
 		if !ok {
 			break
 		}
-		if sm.GetValue(min) < val {
+		if f(min) {
 			sm.PopMin() // Assumes that there is not concurrent access to [SortedMempool]
 			removed = append(removed, min)
 			continue
@@ -123,6 +152,17 @@ func (sm *SortedMempool[T]) PeekMax() (T, bool) {
 	return first.Item, true
 }
 
+// PeekMaxN returns (up to) the [n] highest valued items in sm (in descending
+// order) without removing them.
+func (sm *SortedMempool[T]) PeekMaxN(n int) []T {
+	entries := sm.maxHeap.Top(n)
+	items := make([]T, len(entries))
+	for i, entry := range entries {
+		items[i] = entry.Item
+	}
+	return items
+}
+
 // PopMin removes the maximum value in sm.
 func (sm *SortedMempool[T]) PopMax() (T, bool) {
 	first := sm.maxHeap.First()
@@ -134,11 +174,40 @@ func (sm *SortedMempool[T]) PopMax() (T, bool) {
 	return item, true
 }
 
+// Get returns the item with [id] in sm.
+func (sm *SortedMempool[T]) Get(id ids.ID) (T, bool) {
+	entry, ok := sm.minHeap.Get(id)
+	if !ok {
+		return *new(T), false
+	}
+	return entry.Item, true
+}
+
 // Has returns if [item] is in sm.
 func (sm *SortedMempool[T]) Has(item ids.ID) bool {
 	return sm.minHeap.Has(item)
 }
 
+// IDs returns the IDs of all items in sm (in no particular order).
+func (sm *SortedMempool[T]) IDs() []ids.ID {
+	entries := sm.minHeap.Items()
+	itemIDs := make([]ids.ID, len(entries))
+	for i, entry := range entries {
+		itemIDs[i] = entry.ID
+	}
+	return itemIDs
+}
+
+// Items returns all items in sm (in no particular order).
+func (sm *SortedMempool[T]) Items() []T {
+	entries := sm.minHeap.Items()
+	items := make([]T, len(entries))
+	for i, entry := range entries {
+		items[i] = entry.Item
+	}
+	return items
+}
+
 // Len returns the number of elements in sm.
 func (sm *SortedMempool[T]) Len() int {
 	return sm.minHeap.Len()
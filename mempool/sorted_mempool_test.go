@@ -11,13 +11,17 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 )
 
-const testPayer = "testPayer"
+const (
+	testPayer    = "testPayer"
+	testItemSize = 100
+)
 
 type MempoolTestItem struct {
 	id        ids.ID
 	payer     string
 	timestamp int64
 	unitPrice uint64
+	size      int
 }
 
 func (mti *MempoolTestItem) ID() ids.ID {
@@ -36,6 +40,10 @@ func (mti *MempoolTestItem) Expiry() int64 {
 	return mti.timestamp
 }
 
+func (mti *MempoolTestItem) Size() int {
+	return mti.size
+}
+
 func GenerateTestItem(payer string, t int64, unitPrice uint64) *MempoolTestItem {
 	id := ids.GenerateTestID()
 	return &MempoolTestItem{
@@ -43,13 +51,14 @@ func GenerateTestItem(payer string, t int64, unitPrice uint64) *MempoolTestItem
 		payer:     payer,
 		timestamp: t,
 		unitPrice: unitPrice,
+		size:      testItemSize,
 	}
 }
 
 func TestSortedMempoolNew(t *testing.T) {
 	// Creates empty min and max heaps
 	require := require.New(t)
-	sortedMempool := NewSortedMempool(0, func(tx Item) uint64 { return tx.UnitPrice() })
+	sortedMempool := NewSortedMempool(0, ByValue(func(tx Item) uint64 { return tx.UnitPrice() }))
 	require.Equal(sortedMempool.minHeap.Len(), 0, "MinHeap not initialized correctly")
 	require.Equal(sortedMempool.maxHeap.Len(), 0, "MaxHeap not initialized correctly")
 }
@@ -57,7 +66,7 @@ func TestSortedMempoolNew(t *testing.T) {
 func TestSortedMempoolAdd(t *testing.T) {
 	// Adds to the mempool.
 	require := require.New(t)
-	sortedMempool := NewSortedMempool(0, func(tx Item) uint64 { return tx.UnitPrice() })
+	sortedMempool := NewSortedMempool(0, ByValue(func(tx Item) uint64 { return tx.UnitPrice() }))
 	mempoolItem := GenerateTestItem("payer", 1, 10)
 	sortedMempool.Add(mempoolItem)
 	require.Equal(sortedMempool.minHeap.Len(), 1, "MaxHeap not pushed correctly")
@@ -69,7 +78,7 @@ func TestSortedMempoolAdd(t *testing.T) {
 func TestSortedMempoolRemove(t *testing.T) {
 	// Removes from the mempool.
 	require := require.New(t)
-	sortedMempool := NewSortedMempool(0, func(tx Item) uint64 { return tx.UnitPrice() })
+	sortedMempool := NewSortedMempool(0, ByValue(func(tx Item) uint64 { return tx.UnitPrice() }))
 	mempoolItem := GenerateTestItem("payer", 1, 10)
 	// Add first
 	sortedMempool.Add(mempoolItem)
@@ -89,24 +98,24 @@ func TestSortedMempoolRemoveEmpty(t *testing.T) {
 	// Try to remove a non existing entry.
 	// Removes from the mempool.
 	require := require.New(t)
-	sortedMempool := NewSortedMempool(0, func(tx Item) uint64 { return tx.UnitPrice() })
+	sortedMempool := NewSortedMempool(0, ByValue(func(tx Item) uint64 { return tx.UnitPrice() }))
 	mempoolItem := GenerateTestItem("payer", 1, 10)
 	// Require this returns
 	sortedMempool.Remove(mempoolItem.ID())
 	require.True(true, "not true")
 }
 
-func TestSetMinVal(t *testing.T) {
+func TestPopMinWhile(t *testing.T) {
 	require := require.New(t)
 	payer := "payer"
-	sortedMempool := NewSortedMempool(0, func(tx Item) uint64 { return tx.UnitPrice() })
+	sortedMempool := NewSortedMempool(0, ByValue(func(tx Item) uint64 { return tx.UnitPrice() }))
 	for i := uint64(0); i <= 9; i++ {
 		item := GenerateTestItem(payer, 1, i)
 		sortedMempool.Add(item)
 		require.True(sortedMempool.Has(item.ID()), "TX not included")
 	}
 	// Remove half
-	removed := sortedMempool.SetMinVal(5)
+	removed := sortedMempool.PopMinWhile(func(item Item) bool { return item.UnitPrice() < 5 })
 	require.Equal(5, len(removed), "Returned an incorrect number of txs.")
 	// All timestamps less than 5
 	seen := make(map[uint64]bool)
@@ -120,10 +129,10 @@ func TestSetMinVal(t *testing.T) {
 	require.Equal(5, sortedMempool.Len(), "Mempool has incorrect number of txs.")
 }
 
-func TestSetMinValRemovesAll(t *testing.T) {
+func TestPopMinWhileRemovesAll(t *testing.T) {
 	require := require.New(t)
 	payer := "payer"
-	sortedMempool := NewSortedMempool(0, func(tx Item) uint64 { return tx.UnitPrice() })
+	sortedMempool := NewSortedMempool(0, ByValue(func(tx Item) uint64 { return tx.UnitPrice() }))
 	var items []Item
 	for i := uint64(0); i <= 4; i++ {
 		item := GenerateTestItem(payer, 1, i)
@@ -132,7 +141,7 @@ func TestSetMinValRemovesAll(t *testing.T) {
 		require.True(sortedMempool.Has(item.ID()), "TX not included")
 	}
 	// Remove more than exists
-	removed := sortedMempool.SetMinVal(10)
+	removed := sortedMempool.PopMinWhile(func(item Item) bool { return item.UnitPrice() < 10 })
 	require.Equal(5, len(removed), "Returned an incorrect number of txs.")
 	require.Equal(0, sortedMempool.Len(), "Mempool has incorrect number of txs.")
 	require.Equal(items, removed, "Removed items are not as expected.")
@@ -140,7 +149,7 @@ func TestSetMinValRemovesAll(t *testing.T) {
 
 func TestPeekMin(t *testing.T) {
 	require := require.New(t)
-	sortedMempool := NewSortedMempool(0, func(tx Item) uint64 { return tx.UnitPrice() })
+	sortedMempool := NewSortedMempool(0, ByValue(func(tx Item) uint64 { return tx.UnitPrice() }))
 
 	itemMin := GenerateTestItem(testPayer, 1, 1)
 	itemMed := GenerateTestItem(testPayer, 1, 2)
@@ -171,7 +180,7 @@ func TestPeekMin(t *testing.T) {
 func TestPeekMax(t *testing.T) {
 	require := require.New(t)
 
-	sortedMempool := NewSortedMempool(0, func(tx Item) uint64 { return tx.UnitPrice() })
+	sortedMempool := NewSortedMempool(0, ByValue(func(tx Item) uint64 { return tx.UnitPrice() }))
 
 	itemMin := GenerateTestItem(testPayer, 1, 1)
 	itemMed := GenerateTestItem(testPayer, 1, 2)
@@ -202,7 +211,7 @@ func TestPeekMax(t *testing.T) {
 func TestPopMin(t *testing.T) {
 	require := require.New(t)
 
-	sortedMempool := NewSortedMempool(0, func(tx Item) uint64 { return tx.UnitPrice() })
+	sortedMempool := NewSortedMempool(0, ByValue(func(tx Item) uint64 { return tx.UnitPrice() }))
 
 	itemMin := GenerateTestItem(testPayer, 1, 1)
 	itemMed := GenerateTestItem(testPayer, 1, 2)
@@ -228,7 +237,7 @@ func TestPopMin(t *testing.T) {
 func TestPopMax(t *testing.T) {
 	require := require.New(t)
 
-	sortedMempool := NewSortedMempool(0, func(tx Item) uint64 { return tx.UnitPrice() })
+	sortedMempool := NewSortedMempool(0, ByValue(func(tx Item) uint64 { return tx.UnitPrice() }))
 
 	itemMin := GenerateTestItem(testPayer, 1, 1)
 	itemMed := GenerateTestItem(testPayer, 1, 2)
@@ -255,7 +264,7 @@ func TestPopMax(t *testing.T) {
 func TestHas(t *testing.T) {
 	require := require.New(t)
 
-	sortedMempool := NewSortedMempool(0, func(tx Item) uint64 { return tx.UnitPrice() })
+	sortedMempool := NewSortedMempool(0, ByValue(func(tx Item) uint64 { return tx.UnitPrice() }))
 	item := GenerateTestItem(testPayer, 1, 1)
 	require.False(sortedMempool.Has(item.ID()), "Found an item that was not added.")
 	sortedMempool.Add(item)
@@ -265,7 +274,7 @@ func TestHas(t *testing.T) {
 func TestLen(t *testing.T) {
 	require := require.New(t)
 
-	sortedMempool := NewSortedMempool(0, func(tx Item) uint64 { return tx.UnitPrice() })
+	sortedMempool := NewSortedMempool(0, ByValue(func(tx Item) uint64 { return tx.UnitPrice() }))
 	for i := uint64(0); i <= 4; i++ {
 		item := GenerateTestItem(testPayer, 1, 10)
 		sortedMempool.Add(item)
@@ -274,3 +283,25 @@ func TestLen(t *testing.T) {
 	// Remove more than exists
 	require.Equal(5, sortedMempool.Len(), "Length of mempool is not as expected.")
 }
+
+func TestSortedMempoolCompare(t *testing.T) {
+	require := require.New(t)
+
+	// Order by unit price and then by earliest expiry
+	sortedMempool := NewSortedMempool(0, func(a, b Item) int {
+		if c := ByValue(func(tx Item) uint64 { return tx.UnitPrice() })(a, b); c != 0 {
+			return c
+		}
+		return ByValue(func(tx Item) uint64 { return uint64(tx.Expiry()) })(b, a)
+	})
+	itemLow := GenerateTestItem(testPayer, 1, 1)
+	itemLate := GenerateTestItem(testPayer, 10, 5)
+	itemEarly := GenerateTestItem(testPayer, 5, 5)
+	for _, item := range []Item{itemLow, itemLate, itemEarly} {
+		sortedMempool.Add(item)
+	}
+	require.Equal([]Item{itemEarly, itemLate, itemLow}, sortedMempool.PeekMaxN(3))
+	min, ok := sortedMempool.PeekMin()
+	require.True(ok)
+	require.Equal(itemLow, min)
+}
@@ -37,6 +37,9 @@ type Manager struct {
 	handlers        map[uint8]Handler
 
 	requesters map[ids.NodeID]*nodeIDRequester
+
+	peersL sync.Mutex
+	peers  map[ids.NodeID]*PeerInfo
 }
 
 func NewManager(log logging.Logger, nodeID ids.NodeID, sender common.AppSender) *Manager {
@@ -47,6 +50,7 @@ func NewManager(log logging.Logger, nodeID ids.NodeID, sender common.AppSender)
 		handlers:        map[uint8]Handler{},
 		pendingHandlers: map[uint8]struct{}{},
 		requesters:      map[ids.NodeID]*nodeIDRequester{},
+		peers:           map[ids.NodeID]*PeerInfo{},
 	}
 }
 
@@ -167,6 +171,7 @@ func (n *Manager) handleSharedRequestID(
 // assume gossip via proposervm has been activated
 // ref. "avalanchego/vms/platformvm/network.AppGossip"
 func (n *Manager) AppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	n.updatePeer(nodeID, func(info *PeerInfo) { info.LastGossipReceived = time.Now().UnixMilli() })
 	parsedMsg, handler, ok := n.routeIncomingMessage(msg)
 	if !ok {
 		n.log.Debug(
@@ -186,6 +191,7 @@ func (n *Manager) AppRequest(
 	deadline time.Time,
 	request []byte,
 ) error {
+	n.updatePeer(nodeID, func(info *PeerInfo) { info.RequestsServed++ })
 	parsedMsg, handler, ok := n.routeIncomingMessage(request)
 	if !ok {
 		n.log.Debug(
@@ -204,6 +210,7 @@ func (n *Manager) AppRequestFailed(
 	nodeID ids.NodeID,
 	requestID uint32,
 ) error {
+	n.updatePeer(nodeID, func(info *PeerInfo) { info.RequestsFailed++ })
 	handler, cRequestID, ok := n.handleSharedRequestID(nodeID, requestID)
 	if !ok {
 		n.log.Debug(
@@ -241,6 +248,7 @@ func (n *Manager) Connected(
 	nodeID ids.NodeID,
 	v *version.Application,
 ) error {
+	n.peerConnected(nodeID, v)
 	n.l.RLock()
 	defer n.l.RUnlock()
 	for k, handler := range n.handlers {
@@ -258,6 +266,7 @@ func (n *Manager) Connected(
 
 // implements "block.ChainVM.commom.VM.validators.Connector"
 func (n *Manager) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
+	n.peerDisconnected(nodeID)
 	n.l.RLock()
 	defer n.l.RUnlock()
 	for k, handler := range n.handlers {
@@ -350,6 +359,7 @@ func (w *WrappedAppSender) SendAppRequest(
 	appRequestBytes = w.createMessageBytes(appRequestBytes)
 	for nodeID := range nodeIDs {
 		newRequestID := w.n.getSharedRequestID(w.handler, nodeID, requestID)
+		w.n.updatePeer(nodeID, func(info *PeerInfo) { info.RequestsSent++ })
 		if err := w.n.sender.SendAppRequest(
 			ctx,
 			set.Set[ids.NodeID]{nodeID: struct{}{}},
@@ -396,6 +406,10 @@ func (w *WrappedAppSender) SendAppGossipSpecific(
 	nodeIDs set.Set[ids.NodeID],
 	appGossipBytes []byte,
 ) error {
+	now := time.Now().UnixMilli()
+	for nodeID := range nodeIDs {
+		w.n.updatePeer(nodeID, func(info *PeerInfo) { info.LastGossipSent = now })
+	}
 	return w.n.sender.SendAppGossipSpecific(
 		ctx,
 		nodeIDs,
@@ -0,0 +1,85 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// PeerInfo describes a peer connected to this chain and how it has interacted
+// with this node since it connected. All times are unix milliseconds (0 if
+// the event has not occurred).
+type PeerInfo struct {
+	NodeID      ids.NodeID `json:"nodeId"`
+	Version     string     `json:"version"`
+	ConnectedAt int64      `json:"connectedAt"`
+
+	// Validator is not populated by [Manager] (it is not aware of the
+	// validator set).
+	Validator bool `json:"validator"`
+
+	LastGossipReceived int64 `json:"lastGossipReceived"`
+	LastGossipSent     int64 `json:"lastGossipSent"` // only gossip sent directly to the peer
+
+	// RequestsServed is the number of requests the peer has sent this node.
+	RequestsServed uint64 `json:"requestsServed"`
+	// RequestsSent is the number of requests this node has sent the peer (of
+	// which RequestsFailed failed or timed out).
+	RequestsSent   uint64 `json:"requestsSent"`
+	RequestsFailed uint64 `json:"requestsFailed"`
+}
+
+// peerConnected starts tracking [nodeID].
+func (n *Manager) peerConnected(nodeID ids.NodeID, v *version.Application) {
+	n.peersL.Lock()
+	defer n.peersL.Unlock()
+
+	info := &PeerInfo{
+		NodeID:      nodeID,
+		ConnectedAt: time.Now().UnixMilli(),
+	}
+	if v != nil {
+		info.Version = v.String()
+	}
+	n.peers[nodeID] = info
+}
+
+// peerDisconnected stops tracking [nodeID].
+func (n *Manager) peerDisconnected(nodeID ids.NodeID) {
+	n.peersL.Lock()
+	defer n.peersL.Unlock()
+
+	delete(n.peers, nodeID)
+}
+
+// updatePeer calls [f] on the info of [nodeID] (if it is connected).
+func (n *Manager) updatePeer(nodeID ids.NodeID, f func(*PeerInfo)) {
+	n.peersL.Lock()
+	defer n.peersL.Unlock()
+
+	if info, ok := n.peers[nodeID]; ok {
+		f(info)
+	}
+}
+
+// Peers returns a snapshot of all connected peers (sorted by node ID).
+func (n *Manager) Peers() []*PeerInfo {
+	n.peersL.Lock()
+	defer n.peersL.Unlock()
+
+	peers := make([]*PeerInfo, 0, len(n.peers))
+	for _, info := range n.peers {
+		peer := *info
+		peers = append(peers, &peer)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return bytes.Compare(peers[i].NodeID[:], peers[j].NodeID[:]) < 0
+	})
+	return peers
+}
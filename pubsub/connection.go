@@ -5,6 +5,7 @@ package pubsub
 
 import (
 	"io"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -29,6 +30,10 @@ type Connection struct {
 
 	// Represents if the connection can receive new messages.
 	active atomic.Bool
+
+	// IP of the peer (used to enforce connection limits)
+	ip         string
+	removeOnce sync.Once
 }
 
 // isActive returns whether the connection is active
@@ -12,4 +12,5 @@ var (
 	ErrInvalidCommand       = errors.New("invalid command")
 	ErrMessageTooLarge      = errors.New("message too large")
 	ErrClosed               = errors.New("closed")
+	ErrTooManyConnections   = errors.New("too many connections")
 )
@@ -4,7 +4,9 @@
 package pubsub
 
 import (
+	"net"
 	"net/http"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -34,6 +36,10 @@ type ServerConfig struct {
 	PongWait time.Duration
 	// Send pings to peer with this period. Must be less than pongWait.
 	PingPeriod time.Duration
+	// Maximum number of open connections (0 is unlimited).
+	MaxConnections int
+	// Maximum number of open connections from a single IP (0 is unlimited).
+	MaxConnectionsPerIP int
 }
 
 func NewDefaultServerConfig() *ServerConfig {
@@ -59,6 +65,12 @@ type Server struct {
 	callback Callback
 	upgrader *websocket.Upgrader
 	conns    *Connections
+
+	// track open connections (including those still being upgraded) to
+	// enforce connection limits
+	limitL    sync.Mutex
+	openConns int
+	ipConns   map[string]int
 }
 
 // New returns a new Server instance. The callback function [f] is called
@@ -79,17 +91,30 @@ func New(
 			ReadBufferSize:  config.ReadBufferSize,
 			WriteBufferSize: config.WriteBufferSize,
 		},
-		conns: NewConnections(),
+		conns:   NewConnections(),
+		ipConns: map[string]int{},
 	}
 }
 
 // ServeHTTP adds a connection to the server, and starts go routines for
 // reading and writing.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Reject the connection before upgrading if it would exceed our limits
+	ip := remoteIP(r)
+	if !s.reserve(ip) {
+		s.log.Debug("rejecting pubsub connection",
+			zap.String("ip", ip),
+			zap.Error(ErrTooManyConnections),
+		)
+		http.Error(w, ErrTooManyConnections.Error(), http.StatusTooManyRequests)
+		return
+	}
+
 	// Upgrader.upgrade() is called to upgrade the HTTP connection.
 	// No nead to set any headers so we pass nil as the last argument.
 	wsConn, err := s.upgrader.Upgrade(w, r, nil)
 	if err != nil {
+		s.release(ip)
 		s.log.Warn("failed to upgrade",
 			zap.Error(err),
 		)
@@ -98,6 +123,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.addConnection(&Connection{
 		s:      s,
 		conn:   wsConn,
+		ip:     ip,
 		mb:     NewMessageBuffer(s.log, s.config.MaxPendingMessages, s.config.MaxWriteMessageSize, s.config.MaxMessageWait),
 		active: atomic.Bool{},
 	})
@@ -132,8 +158,52 @@ func (s *Server) addConnection(conn *Connection) {
 }
 
 // removeConnection removes [conn] from the servers connection set.
+//
+// This is called by both the readPump and the writePump, so we ensure we only
+// release [conn]'s reservation once.
 func (s *Server) removeConnection(conn *Connection) {
-	s.conns.Remove(conn)
+	conn.removeOnce.Do(func() {
+		s.release(conn.ip)
+		s.conns.Remove(conn)
+	})
+}
+
+// reserve returns false if accepting a connection from [ip] would exceed the
+// configured connection limits.
+func (s *Server) reserve(ip string) bool {
+	s.limitL.Lock()
+	defer s.limitL.Unlock()
+
+	if s.config.MaxConnections > 0 && s.openConns >= s.config.MaxConnections {
+		return false
+	}
+	if s.config.MaxConnectionsPerIP > 0 && s.ipConns[ip] >= s.config.MaxConnectionsPerIP {
+		return false
+	}
+	s.openConns++
+	s.ipConns[ip]++
+	return true
+}
+
+func (s *Server) release(ip string) {
+	s.limitL.Lock()
+	defer s.limitL.Unlock()
+
+	s.openConns--
+	s.ipConns[ip]--
+	if s.ipConns[ip] <= 0 {
+		delete(s.ipConns, ip)
+	}
+}
+
+// remoteIP returns the IP of the peer that sent [r]. We intentionally ignore
+// any forwarding headers because they can be set arbitrarily by the peer.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
 }
 
 func (s *Server) Connections() *Connections {
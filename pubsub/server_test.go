@@ -7,7 +7,9 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -270,3 +272,41 @@ func TestServerPublishSpecific(t *testing.T) {
 	// Wait for the server to finish shutting down
 	<-serverDone
 }
+
+// TestServerConnectionLimits ensures connections exceeding the configured
+// limits are rejected before being upgraded and that closed connections
+// release their reservation.
+func TestServerConnectionLimits(t *testing.T) {
+	require := require.New(t)
+	cfg := NewDefaultServerConfig()
+	cfg.MaxConnectionsPerIP = 1
+	handler := New(logging.NoLog{}, cfg, nil)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	u := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	webCon1, resp1, err := websocket.DefaultDialer.Dial(u, nil)
+	require.NoError(err, "Error connecting to the server.")
+	resp1.Body.Close()
+	require.Eventually(
+		func() bool { return handler.conns.Len() == 1 },
+		10*time.Second, 10*time.Millisecond, "Server didn't add connection correctly.",
+	)
+
+	// Second connection from the same IP is rejected
+	_, resp2, err := websocket.DefaultDialer.Dial(u, nil)
+	require.ErrorIs(err, websocket.ErrBadHandshake)
+	require.Equal(http.StatusTooManyRequests, resp2.StatusCode)
+	resp2.Body.Close()
+
+	// Closing the first connection frees up a slot
+	webCon1.Close()
+	require.Eventually(
+		func() bool { return handler.conns.Len() == 0 },
+		10*time.Second, 10*time.Millisecond, "Server didn't close connection correctly.",
+	)
+	webCon3, resp3, err := websocket.DefaultDialer.Dial(u, nil)
+	require.NoError(err, "Error connecting to the server.")
+	resp3.Body.Close()
+	webCon3.Close()
+}
@@ -6,6 +6,7 @@ package requester
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,6 +16,15 @@ import (
 	rpc "github.com/gorilla/rpc/v2/json2"
 )
 
+var (
+	// ErrUnreachable is returned when a request could not be delivered to
+	// (or a response read from) the endpoint.
+	ErrUnreachable = errors.New("unreachable")
+	// ErrUnexpectedStatus is returned when the endpoint responds with a non-2xx
+	// status code.
+	ErrUnexpectedStatus = errors.New("unexpected status code")
+)
+
 type Option func(*Options)
 
 type Options struct {
@@ -132,7 +142,7 @@ func SendJSONRequest(
 
 	resp, err := cli.Do(request)
 	if err != nil {
-		return fmt.Errorf("failed to issue request: %w", err)
+		return fmt.Errorf("%w: failed to issue request: %w", ErrUnreachable, err)
 	}
 
 	// Return an error for any non successful status code
@@ -140,7 +150,7 @@ func SendJSONRequest(
 		// Drop any error during close to report the original error
 		all, _ := io.ReadAll(resp.Body)
 		_ = resp.Body.Close()
-		return fmt.Errorf("received status code: %d %s %s", resp.StatusCode, all, uri.String())
+		return fmt.Errorf("%w: %d %s %s", ErrUnexpectedStatus, resp.StatusCode, all, uri.String())
 	}
 
 	if err := rpc.DecodeClientResponse(resp.Body, reply); err != nil {
@@ -0,0 +1,108 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/network"
+	"github.com/ava-labs/hypersdk/requester"
+)
+
+type AdminJSONRPCClient struct {
+	requester *requester.EndpointRequester
+}
+
+func NewAdminJSONRPCClient(uri string) *AdminJSONRPCClient {
+	uri = strings.TrimSuffix(uri, "/")
+	uri += AdminEndpoint
+	req := requester.New(uri, Name)
+	return &AdminJSONRPCClient{requester: req}
+}
+
+// ExecutionProfiles returns the execution breakdown of the last [count]
+// accepted blocks (or all retained blocks if [count] is 0).
+func (cli *AdminJSONRPCClient) ExecutionProfiles(
+	ctx context.Context,
+	count int,
+) ([]*chain.ExecutionProfile, error) {
+	resp := new(ExecutionProfilesReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"executionProfiles",
+		&ExecutionProfilesArgs{Count: count},
+		resp,
+	)
+	return resp.Profiles, err
+}
+
+// BanPayer drops all transactions paid for by [payer] from the mempool of the
+// node and rejects any new ones until [UnbanPayer] is called. BanPayer returns
+// the number of dropped transactions.
+func (cli *AdminJSONRPCClient) BanPayer(ctx context.Context, payer []byte) (int, error) {
+	resp := new(BanPayerReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"banPayer",
+		&PayerArgs{Payer: payer},
+		resp,
+	)
+	return resp.Dropped, err
+}
+
+// UnbanPayer allows transactions paid for by [payer] to be submitted to the
+// node again.
+func (cli *AdminJSONRPCClient) UnbanPayer(ctx context.Context, payer []byte) error {
+	return cli.requester.SendRequest(
+		ctx,
+		"unbanPayer",
+		&PayerArgs{Payer: payer},
+		new(struct{}),
+	)
+}
+
+// Peers returns all peers connected to the chain on the node.
+func (cli *AdminJSONRPCClient) Peers(ctx context.Context) ([]*network.PeerInfo, error) {
+	resp := new(PeersReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"peers",
+		nil,
+		resp,
+	)
+	return resp.Peers, err
+}
+
+// RegisterConsumer registers consumer [name] (which has processed all blocks up
+// to and including [height]) on the node.
+func (cli *AdminJSONRPCClient) RegisterConsumer(ctx context.Context, name string, height uint64) error {
+	return cli.requester.SendRequest(
+		ctx,
+		"registerConsumer",
+		&ConsumerArgs{Name: name, Height: height},
+		new(struct{}),
+	)
+}
+
+// AckConsumer advances the cursor of consumer [name] to [height].
+func (cli *AdminJSONRPCClient) AckConsumer(ctx context.Context, name string, height uint64) error {
+	return cli.requester.SendRequest(
+		ctx,
+		"ackConsumer",
+		&ConsumerArgs{Name: name, Height: height},
+		new(struct{}),
+	)
+}
+
+// RemoveConsumer removes consumer [name] from the node.
+func (cli *AdminJSONRPCClient) RemoveConsumer(ctx context.Context, name string) error {
+	return cli.requester.SendRequest(
+		ctx,
+		"removeConsumer",
+		&ConsumerArgs{Name: name},
+		new(struct{}),
+	)
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"net/http"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/network"
+)
+
+// AdminJSONRPCServer serves debugging information that is too expensive (or
+// too sensitive) to collect on all nodes. It is only registered when enabled
+// in the VM config.
+type AdminJSONRPCServer struct {
+	vm AdminVM
+}
+
+func NewAdminJSONRPCServer(vm AdminVM) *AdminJSONRPCServer {
+	return &AdminJSONRPCServer{vm}
+}
+
+type ExecutionProfilesArgs struct {
+	// Count is the number of profiles to return (0 returns all retained
+	// profiles).
+	Count int `json:"count"`
+}
+
+type ExecutionProfilesReply struct {
+	Profiles []*chain.ExecutionProfile `json:"profiles"`
+}
+
+// ExecutionProfiles returns the execution breakdown of the most recently
+// accepted blocks (newest first).
+func (j *AdminJSONRPCServer) ExecutionProfiles(
+	_ *http.Request,
+	args *ExecutionProfilesArgs,
+	reply *ExecutionProfilesReply,
+) error {
+	reply.Profiles = j.vm.ExecutionProfiles(args.Count)
+	return nil
+}
+
+type PayerArgs struct {
+	Payer []byte `json:"payer"`
+}
+
+type BanPayerReply struct {
+	// Dropped is the number of transactions removed from the mempool.
+	Dropped int `json:"dropped"`
+}
+
+// BanPayer drops all transactions paid for by [args.Payer] from the mempool
+// and rejects any new ones until [UnbanPayer] is called.
+func (j *AdminJSONRPCServer) BanPayer(req *http.Request, args *PayerArgs, reply *BanPayerReply) error {
+	reply.Dropped = j.vm.BanPayer(req.Context(), args.Payer)
+	return nil
+}
+
+// UnbanPayer allows transactions paid for by [args.Payer] to be submitted
+// again.
+func (j *AdminJSONRPCServer) UnbanPayer(req *http.Request, args *PayerArgs, _ *struct{}) error {
+	j.vm.UnbanPayer(req.Context(), args.Payer)
+	return nil
+}
+
+type PeersReply struct {
+	Peers []*network.PeerInfo `json:"peers"`
+}
+
+// Peers returns all peers connected to this chain along with how they have
+// interacted with this node (to debug propagation issues).
+func (j *AdminJSONRPCServer) Peers(req *http.Request, _ *struct{}, reply *PeersReply) error {
+	peers, err := j.vm.Peers(req.Context())
+	if err != nil {
+		return err
+	}
+	reply.Peers = peers
+	return nil
+}
+
+// RegisterConsumer registers a durable cursor for an external consumer (like
+// an indexer) that has processed all blocks up to and including [Height].
+// The node will not prune blocks the consumer has yet to acknowledge. The
+// number of consumers is limited, and any that fall too far behind are
+// expired.
+func (j *AdminJSONRPCServer) RegisterConsumer(_ *http.Request, args *ConsumerArgs, _ *struct{}) error {
+	return j.vm.RegisterConsumer(args.Name, args.Height)
+}
+
+// AckConsumer advances the cursor of a registered consumer to [Height].
+func (j *AdminJSONRPCServer) AckConsumer(_ *http.Request, args *ConsumerArgs, _ *struct{}) error {
+	return j.vm.AckConsumer(args.Name, args.Height)
+}
+
+// RemoveConsumer removes the cursor of a registered consumer (allowing the
+// blocks it has yet to acknowledge to be pruned).
+func (j *AdminJSONRPCServer) RemoveConsumer(_ *http.Request, args *ConsumerArgs, _ *struct{}) error {
+	return j.vm.RemoveConsumer(args.Name)
+}
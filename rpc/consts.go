@@ -9,6 +9,32 @@ const (
 	Name              = "hypersdk"
 	JSONRPCEndpoint   = "/coreapi"
 	WebSocketEndpoint = "/corews"
+	AdminEndpoint     = "/coreadmin"
+	MempoolEndpoint   = "/coremempool"
 
 	DefaultHandshakeTimeout = 10 * time.Second
 )
+
+// Optional APIs that may be enabled on a node (see [JSONRPCClient.ChainInfo]).
+const (
+	FeatureAdmin      = "admin"      // [AdminEndpoint]
+	FeatureArchive    = "archive"    // accepted blocks are archived
+	FeatureStateDiffs = "stateDiffs" // state diffs can be fetched and streamed
+	FeatureNamespaces = "namespaces" // the VM serves additional endpoints
+)
+
+// Outcomes of submitting a tx (see [SubmitTxReply]). Clients can safely
+// retry a submission until they receive any of them.
+const (
+	TxSubmitted = "submitted" // the tx was added to the mempool
+	TxKnown     = "known"     // the tx was already in the mempool or a processing block
+	TxAccepted  = "accepted"  // the tx was already accepted (at [SubmitTxReply.Height])
+)
+
+// Protocol versions supported by the JSON-RPC and WebSocket APIs. A new
+// version should be added whenever a breaking change is made to either API so
+// that clients can adapt their behavior.
+var (
+	JSONRPCVersions   = []uint16{1}
+	WebSocketVersions = []uint16{1}
+)
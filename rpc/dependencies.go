@@ -11,23 +11,37 @@ import (
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/x/merkledb"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/network"
+	"github.com/ava-labs/hypersdk/throughput"
 )
 
 type VM interface {
 	ChainID() ids.ID
 	NetworkID() uint32
 	SubnetID() ids.ID
+	Version(context.Context) (string, error)
+	ActivatedUpgrades() []string
+	GenesisHash() ids.ID
+	Features() []string
 	Tracer() trace.Tracer
 	Logger() logging.Logger
 	Registry() (chain.ActionRegistry, chain.AuthRegistry)
+	Rules(int64) chain.Rules
 	Submit(
 		ctx context.Context,
 		verifySig bool,
 		txs []*chain.Transaction,
 	) (errs []error)
+	ForwardTxs([]*chain.Transaction)
+	MempoolHas(context.Context, ids.ID) bool
+	AcceptedTxHeight(ids.ID) (uint64, bool)
 	LastAcceptedBlock() *chain.StatelessBlock
-	SuggestedFee(context.Context) (uint64, error)
+	GetStatelessBlock(context.Context, ids.ID) (*chain.StatelessBlock, error)
+	GetBlockIDAtHeight(context.Context, uint64) (ids.ID, error)
+	SuggestedFee(context.Context) (fees.Dimensions, error)
 	GetOutgoingWarpMessage(ids.ID) (*warp.UnsignedMessage, error)
 	GetWarpSignatures(ids.ID) ([]*chain.WarpSignature, error)
 	CurrentValidators(
@@ -35,4 +49,31 @@ type VM interface {
 	) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{})
 	GatherSignatures(context.Context, ids.ID, []byte)
 	GetVerifySignatures() bool
+	ReplayProtectionStats() (int, int, int, uint64)
+	ActionStats(start int64, end int64, interval int64) ([]*chain.ActionStatsBucket, error)
+	AddressBlooms(start uint64, end uint64) ([]uint64, []chain.AddressBloom, error)
+	BlockProposer(height uint64) (ids.NodeID, error)
+	StateRoot(height uint64) (ids.ID, error)
+	StateProof(ctx context.Context, height uint64, key []byte) (ids.ID, *merkledb.RangeProof, error)
+	StateDiffs(start uint64, limit int) ([]*chain.StateDiff, error)
+	ConsumerHeight(name string) (uint64, bool)
+	Throughput() []*throughput.Summary
+	ShouldShed() bool
+	WarmingUp() bool
+}
+
+type MempoolVM interface {
+	Tracer() trace.Tracer
+	MempoolSnapshot(context.Context) []*chain.Transaction
+	ShouldShed() bool
+}
+
+type AdminVM interface {
+	ExecutionProfiles(n int) []*chain.ExecutionProfile
+	BanPayer(ctx context.Context, payer []byte) int
+	UnbanPayer(ctx context.Context, payer []byte)
+	Peers(ctx context.Context) ([]*network.PeerInfo, error)
+	RegisterConsumer(name string, height uint64) error
+	AckConsumer(name string, height uint64) error
+	RemoveConsumer(name string) error
 }
@@ -0,0 +1,31 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// DescriptorHash identifies a chain by what transactions on it mean: its
+// genesis, the version of its rules, and the action and auth types it
+// registers. Two chains with the same chainID alias (like a chain and an
+// incompatible fork of it) have different descriptor hashes, so wallets can
+// pin the descriptor hash of the chain they expect to use.
+func DescriptorHash(genesisHash ids.ID, rulesVersion uint16, actions []*TypeInfo, auths []*TypeInfo) ids.ID {
+	p := codec.NewWriter(consts.IDLen, consts.MaxInt)
+	p.PackID(genesisHash)
+	p.PackInt(int(rulesVersion))
+	for _, types := range [][]*TypeInfo{actions, auths} {
+		p.PackInt(len(types))
+		for _, t := range types {
+			p.PackByte(t.ID)
+			p.PackString(t.Name)
+		}
+	}
+	return utils.ToID(p.Bytes())
+}
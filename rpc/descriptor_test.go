@@ -0,0 +1,28 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescriptorHash(t *testing.T) {
+	require := require.New(t)
+
+	genesis := ids.GenerateTestID()
+	actions := []*TypeInfo{{ID: 0, Name: "transfer"}}
+	auths := []*TypeInfo{{ID: 0, Name: "ed25519"}}
+
+	h := DescriptorHash(genesis, 1, actions, auths)
+	require.Equal(h, DescriptorHash(genesis, 1, actions, auths))
+	require.NotEqual(h, DescriptorHash(ids.GenerateTestID(), 1, actions, auths))
+	require.NotEqual(h, DescriptorHash(genesis, 2, actions, auths))
+	require.NotEqual(h, DescriptorHash(genesis, 1, append(actions, &TypeInfo{ID: 1, Name: "mint"}), auths))
+
+	// Moving a type between registries changes the hash
+	require.NotEqual(h, DescriptorHash(genesis, 1, append(actions, auths...), nil))
+}
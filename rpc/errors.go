@@ -3,10 +3,123 @@
 
 package rpc
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/ava-labs/hypersdk/requester"
+)
 
 var (
 	ErrClosed         = errors.New("closed")
+	ErrDisconnected   = errors.New("disconnected")
 	ErrExpired        = errors.New("expired")
 	ErrMessageMissing = errors.New("message missing")
+	ErrTxNotFound     = errors.New("tx not found")
+	ErrUnknownAuth    = errors.New("unknown auth type")
+	ErrOverloaded     = errors.New("overloaded")
+	ErrWarmingUp      = errors.New("warming up")
+	ErrInvalidCursor  = errors.New("invalid cursor")
+
+	ErrTooManySubscriptions  = errors.New("too many subscriptions")
+	ErrDuplicateSubscription = errors.New("duplicate subscription")
+	ErrBackfillTooLarge      = errors.New("backfill too large")
+	ErrStateDiffGap          = errors.New("state diff gap")
+
+	ErrInvalidNamespace   = errors.New("invalid namespace")
+	ErrDuplicateNamespace = errors.New("duplicate namespace")
+
+	ErrInvalidListener = errors.New("invalid listener")
+
+	ErrDescriptorMismatch = errors.New("chain descriptor mismatch")
 )
+
+// ChainMismatchError is returned when a remote node is not serving the
+// network and chain a client was configured for.
+type ChainMismatchError struct {
+	ExpectedNetworkID uint32
+	NetworkID         uint32
+	ExpectedChainID   ids.ID
+	ChainID           ids.ID
+}
+
+func (e *ChainMismatchError) Error() string {
+	return fmt.Sprintf(
+		"chain mismatch: expected networkID=%d chainID=%s but node has networkID=%d chainID=%s",
+		e.ExpectedNetworkID, e.ExpectedChainID, e.NetworkID, e.ChainID,
+	)
+}
+
+// ErrorClass is the broad category of an error returned by a client in this
+// package (so callers can react to a failure without matching on its message).
+type ErrorClass uint8
+
+const (
+	// ErrorUnknown is any error that doesn't fit another class.
+	ErrorUnknown ErrorClass = iota
+	// ErrorConnection means the node could not be reached, is not serving the
+	// expected chain, or did not respond with a valid JSON-RPC response.
+	ErrorConnection
+	// ErrorRejected means the node processed the request and returned an error
+	// (for example, a tx failed verification).
+	ErrorRejected
+	// ErrorExpired means a tx expired before it was included in a block.
+	ErrorExpired
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorConnection:
+		return "connection"
+	case ErrorRejected:
+		return "rejected"
+	case ErrorExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// Classify returns the [ErrorClass] of [err].
+func Classify(err error) ErrorClass {
+	var (
+		jerr *json2.Error
+		nerr net.Error
+		merr *ChainMismatchError
+	)
+	switch {
+	case err == nil:
+		return ErrorUnknown
+	case errors.Is(err, ErrExpired):
+		return ErrorExpired
+	case errors.As(err, &jerr):
+		return ErrorRejected
+	case errors.Is(err, requester.ErrUnreachable),
+		errors.Is(err, requester.ErrUnexpectedStatus),
+		errors.Is(err, ErrDisconnected),
+		errors.Is(err, ErrClosed),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, ErrDescriptorMismatch),
+		errors.As(err, &nerr),
+		errors.As(err, &merr):
+		return ErrorConnection
+	default:
+		return ErrorUnknown
+	}
+}
+
+// IsRemote returns true if [err] is an error returned by a node whose message
+// includes [target] (errors lose their identity when sent over JSON-RPC).
+func IsRemote(err error, target error) bool {
+	var jerr *json2.Error
+	if !errors.As(err, &jerr) {
+		return false
+	}
+	return strings.Contains(jerr.Message, target.Error())
+}
@@ -0,0 +1,33 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gorilla/rpc/v2/json2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/requester"
+)
+
+func TestClassify(t *testing.T) {
+	require := require.New(t)
+
+	remote := fmt.Errorf("failed to decode client response: %w", &json2.Error{Message: "invalid balance: 0 < 10"})
+	require.Equal(ErrorRejected, Classify(remote))
+	require.Equal(ErrorExpired, Classify(fmt.Errorf("%w: tx", ErrExpired)))
+	require.Equal(ErrorConnection, Classify(fmt.Errorf("%w: refused", requester.ErrUnreachable)))
+	require.Equal(ErrorConnection, Classify(fmt.Errorf("%w: 503", requester.ErrUnexpectedStatus)))
+	require.Equal(ErrorConnection, Classify(&ChainMismatchError{}))
+	require.Equal(ErrorConnection, Classify(fmt.Errorf("%w: other fork", ErrDescriptorMismatch)))
+	require.Equal(ErrorUnknown, Classify(errors.New("other")))
+	require.Equal(ErrorUnknown, Classify(nil))
+
+	require.True(IsRemote(remote, errors.New("invalid balance")))
+	require.False(IsRemote(remote, errors.New("invalid auth")))
+	require.False(IsRemote(errors.New("invalid balance"), errors.New("invalid balance")))
+}
@@ -4,6 +4,7 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
@@ -16,10 +17,13 @@ import (
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/x/merkledb"
 	"golang.org/x/exp/maps"
 
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/requester"
+	"github.com/ava-labs/hypersdk/throughput"
 	"github.com/ava-labs/hypersdk/utils"
 )
 
@@ -36,7 +40,7 @@ type JSONRPCClient struct {
 	chainID   ids.ID
 
 	lastSuggestedFee time.Time
-	unitPrice        uint64
+	unitPrices       fees.Dimensions
 }
 
 func NewJSONRPCClient(uri string) *JSONRPCClient {
@@ -77,6 +81,101 @@ func (cli *JSONRPCClient) Network(ctx context.Context) (uint32, ids.ID, ids.ID,
 	return resp.NetworkID, resp.SubnetID, resp.ChainID, nil
 }
 
+// VerifyChain ensures the remote node is serving [networkID] and [chainID],
+// returning a [*ChainMismatchError] if it is not. The remote values are
+// fetched once per client, so this is cheap to call before every issuance.
+func (cli *JSONRPCClient) VerifyChain(ctx context.Context, networkID uint32, chainID ids.ID) error {
+	rNetworkID, _, rChainID, err := cli.Network(ctx)
+	if err != nil {
+		return err
+	}
+	if rNetworkID != networkID || rChainID != chainID {
+		return &ChainMismatchError{
+			ExpectedNetworkID: networkID,
+			NetworkID:         rNetworkID,
+			ExpectedChainID:   chainID,
+			ChainID:           rChainID,
+		}
+	}
+	return nil
+}
+
+func (cli *JSONRPCClient) Version(ctx context.Context) (*VersionReply, error) {
+	resp := new(VersionReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"version",
+		nil,
+		resp,
+	)
+	return resp, err
+}
+
+// ChainInfo returns the genesis hash, rules version, fee schedule, registered
+// action and auth types, and enabled optional APIs of the chain (which can be
+// used to verify compatibility before submitting transactions).
+func (cli *JSONRPCClient) ChainInfo(ctx context.Context) (*ChainInfoReply, error) {
+	resp := new(ChainInfoReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"chainInfo",
+		nil,
+		resp,
+	)
+	return resp, err
+}
+
+// VerifyDescriptor ensures the remote node is serving a chain with descriptor
+// hash [expected] (see [DescriptorHash]), returning [ErrDescriptorMismatch] if
+// it is not.
+func (cli *JSONRPCClient) VerifyDescriptor(ctx context.Context, expected ids.ID) error {
+	info, err := cli.ChainInfo(ctx)
+	if err != nil {
+		return err
+	}
+	if info.DescriptorHash != expected {
+		return fmt.Errorf("%w: expected %s but node has %s", ErrDescriptorMismatch, expected, info.DescriptorHash)
+	}
+	return nil
+}
+
+// DecodeTx returns the canonical JSON encoding of signed transaction [tx].
+func (cli *JSONRPCClient) DecodeTx(ctx context.Context, tx []byte) (*chain.TransactionJSON, error) {
+	resp := new(chain.TransactionJSON)
+	err := cli.requester.SendRequest(
+		ctx,
+		"decodeTx",
+		&DecodeTxArgs{Tx: tx},
+		resp,
+	)
+	return resp, err
+}
+
+// EncodeTx returns the unsigned bytes (digest) of [tx].
+func (cli *JSONRPCClient) EncodeTx(ctx context.Context, tx *chain.TransactionJSON) ([]byte, error) {
+	resp := new(EncodeTxReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"encodeTx",
+		tx,
+		resp,
+	)
+	return resp.UnsignedTx, err
+}
+
+// BuildTx returns an unsigned transaction (with the current fee and expiry)
+// that performs [action].
+func (cli *JSONRPCClient) BuildTx(ctx context.Context, args *BuildTxArgs) (*BuildTxReply, error) {
+	resp := new(BuildTxReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"buildTx",
+		args,
+		resp,
+	)
+	return resp, err
+}
+
 func (cli *JSONRPCClient) Accepted(ctx context.Context) (ids.ID, uint64, int64, error) {
 	resp := new(LastAcceptedReply)
 	err := cli.requester.SendRequest(
@@ -88,9 +187,218 @@ func (cli *JSONRPCClient) Accepted(ctx context.Context) (ids.ID, uint64, int64,
 	return resp.BlockID, resp.Height, resp.Timestamp, err
 }
 
-func (cli *JSONRPCClient) SuggestedRawFee(ctx context.Context) (uint64, error) {
+// WarmingUp returns true if the node is still catching up after startup (and
+// will reject expensive requests, like historical queries, until it is done).
+func (cli *JSONRPCClient) WarmingUp(ctx context.Context) (bool, error) {
+	resp := new(LastAcceptedReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"lastAccepted",
+		nil,
+		resp,
+	)
+	return resp.WarmingUp, err
+}
+
+// RawBlock returns the stored bytes of block [blkID] (or the block at
+// [height] if [blkID] is empty).
+func (cli *JSONRPCClient) RawBlock(ctx context.Context, blkID ids.ID, height uint64) (*RawBlockReply, error) {
+	resp := new(RawBlockReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"rawBlock",
+		&RawBlockArgs{BlockID: blkID, Height: height},
+		resp,
+	)
+	return resp, err
+}
+
+// RawTx returns the stored bytes of [txID] included in block [blkID] (or the
+// block at [height] if [blkID] is empty).
+func (cli *JSONRPCClient) RawTx(ctx context.Context, txID ids.ID, blkID ids.ID, height uint64) (*RawTxReply, error) {
+	resp := new(RawTxReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"rawTx",
+		&RawTxArgs{TxID: txID, BlockID: blkID, Height: height},
+		resp,
+	)
+	return resp, err
+}
+
+// StateRoot returns the root of the state after the block at [height] was
+// accepted.
+func (cli *JSONRPCClient) StateRoot(ctx context.Context, height uint64) (ids.ID, error) {
+	resp := new(StateRootReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"stateRoot",
+		&StateRootArgs{Height: height},
+		resp,
+	)
+	return resp.Root, err
+}
+
+// StateProof returns the value of [key] (and whether it exists) in the state
+// after the block at [height] was accepted, along with the root the proof of
+// it was verified against.
+//
+// The proof is only verified against the root provided by the node. Auditors
+// should compare it to a root obtained independently (like the StateRoot of
+// the block at [height]).
+func (cli *JSONRPCClient) StateProof(
+	ctx context.Context,
+	height uint64,
+	key []byte,
+) (ids.ID, []byte, bool, error) {
+	resp := new(StateProofReply)
+	if err := cli.requester.SendRequest(
+		ctx,
+		"stateProof",
+		&StateProofArgs{Height: height, Key: key},
+		resp,
+	); err != nil {
+		return ids.Empty, nil, false, err
+	}
+	var proof merkledb.RangeProof
+	if err := proof.UnmarshalProto(resp.Proof); err != nil {
+		return ids.Empty, nil, false, err
+	}
+	if err := proof.Verify(ctx, key, key, resp.Root); err != nil {
+		return ids.Empty, nil, false, err
+	}
+	for _, kv := range proof.KeyValues {
+		if bytes.Equal(kv.Key, key) {
+			return resp.Root, kv.Value, true, nil
+		}
+	}
+	return resp.Root, nil, false, nil
+}
+
+func (cli *JSONRPCClient) ReplayProtection(ctx context.Context) (*ReplayProtectionReply, error) {
+	resp := new(ReplayProtectionReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"replayProtection",
+		nil,
+		resp,
+	)
+	return resp, err
+}
+
+// ActionStats returns the number of accepted transactions (and units
+// consumed) of each action type in each [interval] (in ms) of [start, end).
+func (cli *JSONRPCClient) ActionStats(
+	ctx context.Context,
+	start int64,
+	end int64,
+	interval int64,
+) ([]*chain.ActionStatsBucket, error) {
+	resp := new(ActionStatsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"actionStats",
+		&ActionStatsArgs{
+			Start:    start,
+			End:      end,
+			Interval: interval,
+		},
+		resp,
+	)
+	return resp.Buckets, err
+}
+
+// AddressBlooms returns the address bloom of each block in [start, end]
+// (that has not been pruned).
+func (cli *JSONRPCClient) AddressBlooms(
+	ctx context.Context,
+	start uint64,
+	end uint64,
+) ([]*AddressBloom, error) {
+	resp := new(AddressBloomsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"addressBlooms",
+		&AddressBloomsArgs{
+			Start: start,
+			End:   end,
+		},
+		resp,
+	)
+	return resp.Blooms, err
+}
+
+// StateDiffs returns up to [limit] state diffs of consecutive blocks starting
+// at [start] (0 uses the max allowed by the node).
+func (cli *JSONRPCClient) StateDiffs(
+	ctx context.Context,
+	start uint64,
+	limit int,
+) ([]*chain.StateDiff, error) {
+	resp := new(StateDiffsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"stateDiffs",
+		&StateDiffsArgs{
+			Start: start,
+			Limit: limit,
+		},
+		resp,
+	)
+	return resp.Diffs, err
+}
+
+// BlocksWithAddress returns the heights of blocks in [start, end] that may
+// involve [addr] (all other blocks can be skipped when syncing [addr]'s
+// history).
+func (cli *JSONRPCClient) BlocksWithAddress(
+	ctx context.Context,
+	addr []byte,
+	start uint64,
+	end uint64,
+) ([]uint64, error) {
+	blooms, err := cli.AddressBlooms(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	heights := []uint64{}
+	for _, b := range blooms {
+		if b.Bloom.Contains(addr) {
+			heights = append(heights, b.Height)
+		}
+	}
+	return heights, nil
+}
+
+// Consumer returns the last height acknowledged by consumer [name] and
+// whether it is registered.
+func (cli *JSONRPCClient) Consumer(ctx context.Context, name string) (uint64, bool, error) {
+	resp := new(ConsumerReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"consumer",
+		&ConsumerArgs{Name: name},
+		resp,
+	)
+	return resp.Height, resp.Registered, err
+}
+
+// Throughput returns the rolling throughput of accepted blocks over the last
+// 1m, 10m, and 1h (in that order).
+func (cli *JSONRPCClient) Throughput(ctx context.Context) ([]*throughput.Summary, error) {
+	resp := new(ThroughputReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"throughput",
+		nil,
+		resp,
+	)
+	return resp.Windows, err
+}
+
+func (cli *JSONRPCClient) SuggestedRawFee(ctx context.Context) (fees.Dimensions, error) {
 	if time.Since(cli.lastSuggestedFee) < suggestedFeeCacheRefresh {
-		return cli.unitPrice, nil
+		return cli.unitPrices, nil
 	}
 
 	resp := new(SuggestedRawFeeReply)
@@ -101,16 +409,24 @@ func (cli *JSONRPCClient) SuggestedRawFee(ctx context.Context) (uint64, error) {
 		resp,
 	)
 	if err != nil {
-		return 0, err
+		return fees.Dimensions{}, err
 	}
-	cli.unitPrice = resp.UnitPrice
+	cli.unitPrices = resp.UnitPrices
 	// We update the time last in case there are concurrent requests being
 	// processed (we don't want them to get an inconsistent view).
 	cli.lastSuggestedFee = time.Now()
-	return resp.UnitPrice, nil
+	return resp.UnitPrices, nil
 }
 
 func (cli *JSONRPCClient) SubmitTx(ctx context.Context, d []byte) (ids.ID, error) {
+	resp, err := cli.SubmitTxStatus(ctx, d)
+	return resp.TxID, err
+}
+
+// SubmitTxStatus is like [SubmitTx] but also returns whether the tx was
+// submitted by this call, was already pending, or was already accepted (see
+// [TxSubmitted], [TxKnown], and [TxAccepted]).
+func (cli *JSONRPCClient) SubmitTxStatus(ctx context.Context, d []byte) (*SubmitTxReply, error) {
 	resp := new(SubmitTxReply)
 	err := cli.requester.SendRequest(
 		ctx,
@@ -118,7 +434,7 @@ func (cli *JSONRPCClient) SubmitTx(ctx context.Context, d []byte) (ids.ID, error
 		&SubmitTxArgs{Tx: d},
 		resp,
 	)
-	return resp.TxID, err
+	return resp, err
 }
 
 func (cli *JSONRPCClient) GetWarpSignatures(
@@ -160,6 +476,18 @@ type Modifier interface {
 	Base(*chain.Base)
 }
 
+type sequenceModifier uint64
+
+func (s sequenceModifier) Base(b *chain.Base) {
+	b.Sequence = uint64(s)
+}
+
+// WithSequence sets the sequence of a generated transaction (required on
+// chains that enable sequence mode).
+func WithSequence(sequence uint64) Modifier {
+	return sequenceModifier(sequence)
+}
+
 func (cli *JSONRPCClient) GenerateTransaction(
 	ctx context.Context,
 	parser chain.Parser,
@@ -168,13 +496,20 @@ func (cli *JSONRPCClient) GenerateTransaction(
 	authFactory chain.AuthFactory,
 	modifiers ...Modifier,
 ) (func(context.Context) error, *chain.Transaction, uint64, error) {
+	// Ensure we are talking to the chain [parser] is configured for (otherwise
+	// any tx we generate will fail signature verification)
+	rules := parser.Rules(time.Now().UnixMilli())
+	if err := cli.VerifyChain(ctx, rules.NetworkID(), rules.ChainID()); err != nil {
+		return nil, nil, 0, err
+	}
+
 	// Get latest fee info
-	unitPrice, err := cli.SuggestedRawFee(ctx)
+	unitPrices, err := cli.SuggestedRawFee(ctx)
 	if err != nil {
 		return nil, nil, 0, err
 	}
 
-	return cli.GenerateTransactionManual(parser, wm, action, authFactory, unitPrice, modifiers...)
+	return cli.GenerateTransactionManual(parser, wm, action, authFactory, unitPrices, modifiers...)
 }
 
 func (cli *JSONRPCClient) GenerateTransactionManual(
@@ -182,16 +517,16 @@ func (cli *JSONRPCClient) GenerateTransactionManual(
 	wm *warp.Message,
 	action chain.Action,
 	authFactory chain.AuthFactory,
-	unitPrice uint64,
+	unitPrices fees.Dimensions,
 	modifiers ...Modifier,
 ) (func(context.Context) error, *chain.Transaction, uint64, error) {
 	// Construct transaction
 	now := time.Now().UnixMilli()
 	rules := parser.Rules(now)
 	base := &chain.Base{
-		Timestamp: utils.UnixRMilli(now, rules.GetValidityWindow()),
-		ChainID:   rules.ChainID(),
-		UnitPrice: unitPrice, // never pay blockCost
+		Timestamp:     utils.UnixRMilli(now, rules.GetValidityWindow()),
+		ChainID:       rules.ChainID(),
+		MaxUnitPrices: unitPrices,
 	}
 
 	// Modify gathered data
@@ -213,17 +548,16 @@ func (cli *JSONRPCClient) GenerateTransactionManual(
 	if err != nil {
 		return nil, nil, 0, fmt.Errorf("%w: failed to sign transaction", err)
 	}
-	maxUnits, err := tx.MaxUnits(rules)
-	if err != nil {
-		return nil, nil, 0, err
-	}
-	fee, err := math.Mul64(maxUnits, unitPrice)
+	fee, err := tx.MaxFee(rules, unitPrices)
 	if err != nil {
 		return nil, nil, 0, err
 	}
 
 	// Return max fee and transaction for issuance
 	return func(ictx context.Context) error {
+		if err := cli.VerifyChain(ictx, rules.NetworkID(), rules.ChainID()); err != nil {
+			return err
+		}
 		_, err := cli.SubmitTx(ictx, tx.Bytes())
 		return err
 	}, tx, fee, nil
@@ -8,13 +8,19 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	syncpb "github.com/ava-labs/avalanchego/proto/pb/sync"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/throughput"
+	"github.com/ava-labs/hypersdk/utils"
 	"go.uber.org/zap"
 )
 
@@ -49,14 +55,128 @@ func (j *JSONRPCServer) Network(_ *http.Request, _ *struct{}, reply *NetworkRepl
 	return nil
 }
 
+type VersionReply struct {
+	VMVersion         string   `json:"vmVersion"`
+	HyperSDKVersion   string   `json:"hypersdkVersion"`
+	JSONRPCVersions   []uint16 `json:"jsonrpcVersions"`
+	WebSocketVersions []uint16 `json:"websocketVersions"`
+	Upgrades          []string `json:"upgrades"`
+}
+
+func (j *JSONRPCServer) Version(req *http.Request, _ *struct{}, reply *VersionReply) (err error) {
+	reply.VMVersion, err = j.vm.Version(req.Context())
+	if err != nil {
+		return err
+	}
+	reply.HyperSDKVersion = consts.Version.String()
+	reply.JSONRPCVersions = JSONRPCVersions
+	reply.WebSocketVersions = WebSocketVersions
+	reply.Upgrades = j.vm.ActivatedUpgrades()
+	return nil
+}
+
+// TypeInfo describes a type registered in an action or auth registry.
+type TypeInfo struct {
+	ID   uint8  `json:"id"`
+	Name string `json:"name"`
+}
+
+// FeeSummary describes how transactions are charged under the rules active
+// as of the last accepted block.
+//
+// Each [fees.Dimensions] has one entry per dimension (in the order of
+// [Dimensions]). [BaseUnits], [WarpBaseUnits], and [WarpUnitsPerSigner] are
+// compute units.
+type FeeSummary struct {
+	Dimensions                  []string        `json:"dimensions"`
+	UnitPrices                  fees.Dimensions `json:"unitPrices"` // of the last accepted block
+	SuggestedUnitPrices         fees.Dimensions `json:"suggestedUnitPrices"`
+	MinUnitPrices               fees.Dimensions `json:"minUnitPrices"`
+	UnitPriceChangeDenominators fees.Dimensions `json:"unitPriceChangeDenominators"`
+	WindowTargetUnits           fees.Dimensions `json:"windowTargetUnits"`
+	MaxBlockUnits               fees.Dimensions `json:"maxBlockUnits"`
+	BaseUnits                   uint64          `json:"baseUnits"`
+	WarpBaseUnits               uint64          `json:"warpBaseUnits"`
+	WarpUnitsPerSigner          uint64          `json:"warpUnitsPerSigner"`
+}
+
+type ChainInfoReply struct {
+	GenesisHash    ids.ID      `json:"genesisHash"`
+	RulesVersion   uint16      `json:"rulesVersion"` // 0 if the VM doesn't version its rules
+	DescriptorHash ids.ID      `json:"descriptorHash"`
+	Fees           *FeeSummary `json:"fees"`
+	Actions        []*TypeInfo `json:"actions"`
+	Auths          []*TypeInfo `json:"auths"`
+	Features       []string    `json:"features"`
+}
+
+// ChainInfo returns what tooling needs to verify it is compatible with the
+// chain (and this node) before submitting transactions.
+func (j *JSONRPCServer) ChainInfo(req *http.Request, _ *struct{}, reply *ChainInfoReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.ChainInfo")
+	defer span.End()
+
+	blk := j.vm.LastAcceptedBlock()
+	r := j.vm.Rules(blk.Tmstmp)
+	suggested, err := j.vm.SuggestedFee(ctx)
+	if err != nil {
+		return err
+	}
+	reply.GenesisHash = j.vm.GenesisHash()
+	if vr, ok := r.(chain.VersionedRules); ok {
+		reply.RulesVersion = vr.GetRulesVersion()
+	}
+	dimensions := make([]string, fees.FeeDimensions)
+	for i := range dimensions {
+		dimensions[i] = fees.Dimension(i).String()
+	}
+	reply.Fees = &FeeSummary{
+		Dimensions:                  dimensions,
+		UnitPrices:                  blk.UnitPrices,
+		SuggestedUnitPrices:         suggested,
+		MinUnitPrices:               r.GetMinUnitPrice(),
+		UnitPriceChangeDenominators: r.GetUnitPriceChangeDenominator(),
+		WindowTargetUnits:           r.GetWindowTargetUnits(),
+		MaxBlockUnits:               r.GetMaxBlockUnits(),
+		BaseUnits:                   r.GetBaseUnits(),
+		WarpBaseUnits:               r.GetWarpBaseUnits(),
+		WarpUnitsPerSigner:          r.GetWarpUnitsPerSigner(),
+	}
+	actionRegistry, authRegistry := j.vm.Registry()
+	actionParser := (*codec.TypeParser[chain.Action, *warp.Message, bool])(actionRegistry)
+	authParser := (*codec.TypeParser[chain.Auth, *warp.Message, bool])(authRegistry)
+	reply.Actions = registryTypes(actionParser.Len(), actionParser.Name)
+	reply.Auths = registryTypes(authParser.Len(), authParser.Name)
+	reply.DescriptorHash = DescriptorHash(reply.GenesisHash, reply.RulesVersion, reply.Actions, reply.Auths)
+	reply.Features = j.vm.Features()
+	sort.Strings(reply.Features)
+	return nil
+}
+
+// registryTypes returns the [TypeInfo] of each of the [n] types in a registry.
+func registryTypes(n int, name func(uint8) (string, bool)) []*TypeInfo {
+	types := make([]*TypeInfo, 0, n)
+	for i := 0; i < n; i++ {
+		typeName, _ := name(uint8(i))
+		types = append(types, &TypeInfo{ID: uint8(i), Name: typeName})
+	}
+	return types
+}
+
 type SubmitTxArgs struct {
 	Tx []byte `json:"tx"`
 }
 
 type SubmitTxReply struct {
-	TxID ids.ID `json:"txId"`
+	TxID   ids.ID `json:"txId"`
+	Status string `json:"status"`
+	Height uint64 `json:"height,omitempty"` // only set if [Status] is [TxAccepted]
 }
 
+// SubmitTx adds a tx to the mempool and gossips it. Submitting a tx that was
+// already submitted is not an error (so clients can retry until they get a
+// response) and instead returns whether the tx is still pending or was
+// already accepted.
 func (j *JSONRPCServer) SubmitTx(
 	req *http.Request,
 	args *SubmitTxArgs,
@@ -65,6 +185,9 @@ func (j *JSONRPCServer) SubmitTx(
 	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.SubmitTx")
 	defer span.End()
 
+	if err := chain.VerifyTxSize(j.vm.Rules(time.Now().UnixMilli()), len(args.Tx)); err != nil {
+		return err
+	}
 	actionRegistry, authRegistry := j.vm.Registry()
 	rtx := codec.NewReader(args.Tx, consts.NetworkSizeLimit) // will likely be much smaller than this
 	tx, err := chain.UnmarshalTx(rtx, actionRegistry, authRegistry)
@@ -79,13 +202,185 @@ func (j *JSONRPCServer) SubmitTx(
 	}
 	txID := tx.ID()
 	reply.TxID = txID
-	return j.vm.Submit(ctx, false, []*chain.Transaction{tx})[0]
+	if height, ok := j.vm.AcceptedTxHeight(txID); ok {
+		reply.Status = TxAccepted
+		reply.Height = height
+		return nil
+	}
+	txs := []*chain.Transaction{tx}
+	if j.vm.MempoolHas(ctx, txID) {
+		// Gossip again in case the tx was not received by the next proposers
+		reply.Status = TxKnown
+		j.vm.ForwardTxs(txs)
+		return nil
+	}
+	if err := j.vm.Submit(ctx, false, txs)[0]; err != nil {
+		// [chain.ErrDuplicateTx] is also returned if the tx was added to the
+		// mempool since we checked
+		if !errors.Is(err, chain.ErrDuplicateTx) {
+			return err
+		}
+		// The tx may have been accepted since we checked
+		if height, ok := j.vm.AcceptedTxHeight(txID); ok {
+			reply.Status = TxAccepted
+			reply.Height = height
+			return nil
+		}
+		reply.Status = TxKnown
+		return nil
+	}
+	reply.Status = TxSubmitted
+	j.vm.ForwardTxs(txs)
+	return nil
+}
+
+type DecodeTxArgs struct {
+	Tx []byte `json:"tx"`
+}
+
+// DecodeTx returns the canonical JSON encoding of a signed transaction.
+func (j *JSONRPCServer) DecodeTx(
+	req *http.Request,
+	args *DecodeTxArgs,
+	reply *chain.TransactionJSON,
+) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.DecodeTx")
+	defer span.End()
+
+	actionRegistry, authRegistry := j.vm.Registry()
+	rtx := codec.NewReader(args.Tx, consts.NetworkSizeLimit)
+	tx, err := chain.UnmarshalTx(rtx, actionRegistry, authRegistry)
+	if err != nil {
+		return fmt.Errorf("%w: unable to unmarshal tx", err)
+	}
+	if !rtx.Empty() {
+		return errors.New("tx has extra bytes")
+	}
+	txJSON, err := chain.MarshalTxJSON(tx, actionRegistry, authRegistry)
+	if err != nil {
+		return err
+	}
+	*reply = *txJSON
+	return nil
+}
+
+type EncodeTxReply struct {
+	UnsignedTx []byte `json:"unsignedTx"` // the digest that must be signed
+}
+
+// EncodeTx returns the unsigned bytes of a transaction provided in its
+// canonical JSON encoding. Any provided auth is ignored.
+func (j *JSONRPCServer) EncodeTx(
+	req *http.Request,
+	args *chain.TransactionJSON,
+	reply *EncodeTxReply,
+) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.EncodeTx")
+	defer span.End()
+
+	actionRegistry, _ := j.vm.Registry()
+	tx, err := chain.UnmarshalUnsignedTxJSON(args, actionRegistry)
+	if err != nil {
+		return err
+	}
+	reply.UnsignedTx, err = tx.Digest(actionRegistry)
+	return err
+}
+
+type BuildTxArgs struct {
+	Action      *chain.TypedJSON `json:"action"`
+	WarpMessage []byte           `json:"warpMessage"`
+	AuthType    string           `json:"authType"` // name of the auth that will sign the tx
+	Expiry      int64            `json:"expiry"`   // if 0, the max validity window is used
+	Sequence    uint64           `json:"sequence"` // only used if sequence mode is enabled
+
+	// MaxUnitPrices are the max unit prices of the tx. If all are 0, the
+	// suggested unit prices are used.
+	MaxUnitPrices fees.Dimensions `json:"maxUnitPrices"`
+}
+
+type BuildTxReply struct {
+	Tx         *chain.TransactionJSON `json:"tx"`
+	UnsignedTx []byte                 `json:"unsignedTx"` // the digest that must be signed
+	AuthTypeID uint8                  `json:"authTypeId"`
+	MaxFee     uint64                 `json:"maxFee"`
+}
+
+// BuildTx constructs an unsigned transaction from the canonical JSON
+// encoding of an action (filling in the base with the current fee and
+// expiry) so that clients don't need to implement the VM's marshaling to
+// issue transactions.
+//
+// To issue the transaction, sign [UnsignedTx] and submit
+// [UnsignedTx] + [AuthTypeID] + <marshaled auth> with SubmitTx.
+func (j *JSONRPCServer) BuildTx(
+	req *http.Request,
+	args *BuildTxArgs,
+	reply *BuildTxReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.BuildTx")
+	defer span.End()
+
+	if err := j.shed(); err != nil {
+		return err
+	}
+
+	actionRegistry, authRegistry := j.vm.Registry()
+	authParser := (*codec.TypeParser[chain.Auth, *warp.Message, bool])(authRegistry)
+	authTypeID, ok := authParser.LookupName(args.AuthType)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownAuth, args.AuthType)
+	}
+	auth, _ := authParser.New(authTypeID)
+
+	now := time.Now().UnixMilli()
+	rules := j.vm.Rules(now)
+	unitPrices := args.MaxUnitPrices
+	if unitPrices == (fees.Dimensions{}) {
+		var err error
+		unitPrices, err = j.vm.SuggestedFee(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	expiry := args.Expiry
+	if expiry == 0 {
+		expiry = utils.UnixRMilli(now, rules.GetValidityWindow())
+	}
+	tx, err := chain.UnmarshalUnsignedTxJSON(&chain.TransactionJSON{
+		Base: &chain.Base{
+			Timestamp:     expiry,
+			ChainID:       j.vm.ChainID(),
+			MaxUnitPrices: unitPrices,
+			Sequence:      args.Sequence,
+		},
+		WarpMessage: args.WarpMessage,
+		Action:      args.Action,
+	}, actionRegistry)
+	if err != nil {
+		return err
+	}
+	reply.Tx, err = chain.MarshalTxJSON(tx, actionRegistry, authRegistry)
+	if err != nil {
+		return err
+	}
+	reply.UnsignedTx, err = tx.Digest(actionRegistry)
+	if err != nil {
+		return err
+	}
+	reply.AuthTypeID = authTypeID
+
+	// Use an empty instance of the auth type to estimate the max fee
+	tx.SetAuth(auth, authTypeID)
+	reply.MaxFee, err = tx.MaxFee(rules, unitPrices)
+	return err
 }
 
 type LastAcceptedReply struct {
 	Height    uint64 `json:"height"`
 	BlockID   ids.ID `json:"blockId"`
 	Timestamp int64  `json:"timestamp"`
+	WarmingUp bool   `json:"warmingUp"` // expensive requests are shed while true
 }
 
 func (j *JSONRPCServer) LastAccepted(_ *http.Request, _ *struct{}, reply *LastAcceptedReply) error {
@@ -93,11 +388,296 @@ func (j *JSONRPCServer) LastAccepted(_ *http.Request, _ *struct{}, reply *LastAc
 	reply.Height = blk.Hght
 	reply.BlockID = blk.ID()
 	reply.Timestamp = blk.Tmstmp
+	reply.WarmingUp = j.vm.WarmingUp()
+	return nil
+}
+
+type RawBlockArgs struct {
+	BlockID ids.ID `json:"blockId"` // if empty, [Height] is used
+	Height  uint64 `json:"height"`
+}
+
+type RawBlockReply struct {
+	BlockID ids.ID `json:"blockId"`
+	Height  uint64 `json:"height"`
+	Block   []byte `json:"block"`
+
+	// Proposer is the validator that proposed the block (or the empty node ID
+	// if it is unknown).
+	Proposer ids.NodeID `json:"proposer"`
+}
+
+// shed returns an error if expensive requests should not be served because
+// the VM is warming up or overloaded.
+func (j *JSONRPCServer) shed() error {
+	if j.vm.WarmingUp() {
+		return ErrWarmingUp
+	}
+	if j.vm.ShouldShed() {
+		return ErrOverloaded
+	}
+	return nil
+}
+
+// getBlock looks up a historical block (which is shed while the VM is
+// warming up or overloaded).
+func (j *JSONRPCServer) getBlock(ctx context.Context, blkID ids.ID, height uint64) (*chain.StatelessBlock, error) {
+	if err := j.shed(); err != nil {
+		return nil, err
+	}
+	if blkID == ids.Empty {
+		var err error
+		blkID, err = j.vm.GetBlockIDAtHeight(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return j.vm.GetStatelessBlock(ctx, blkID)
+}
+
+// RawBlock returns the bytes of a block exactly as they are stored (and
+// hashed to compute its ID).
+func (j *JSONRPCServer) RawBlock(req *http.Request, args *RawBlockArgs, reply *RawBlockReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.RawBlock")
+	defer span.End()
+
+	blk, err := j.getBlock(ctx, args.BlockID, args.Height)
+	if err != nil {
+		return err
+	}
+	proposer, err := j.vm.BlockProposer(blk.Hght)
+	if err != nil {
+		return err
+	}
+	reply.BlockID = blk.ID()
+	reply.Height = blk.Hght
+	reply.Block = blk.Bytes()
+	reply.Proposer = proposer
+	return nil
+}
+
+type RawTxArgs struct {
+	TxID    ids.ID `json:"txId"`
+	BlockID ids.ID `json:"blockId"` // if empty, [Height] is used
+	Height  uint64 `json:"height"`
+}
+
+type RawTxReply struct {
+	TxID    ids.ID `json:"txId"`
+	BlockID ids.ID `json:"blockId"`
+	Height  uint64 `json:"height"`
+	Index   int    `json:"index"`
+	Tx      []byte `json:"tx"`
+}
+
+// RawTx returns the bytes of a transaction included in the provided block
+// exactly as they are stored (and hashed to compute its ID).
+func (j *JSONRPCServer) RawTx(req *http.Request, args *RawTxArgs, reply *RawTxReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.RawTx")
+	defer span.End()
+
+	blk, err := j.getBlock(ctx, args.BlockID, args.Height)
+	if err != nil {
+		return err
+	}
+	for i, tx := range blk.Txs {
+		if tx.ID() != args.TxID {
+			continue
+		}
+		reply.TxID = tx.ID()
+		reply.BlockID = blk.ID()
+		reply.Height = blk.Hght
+		reply.Index = i
+		reply.Tx = tx.Bytes()
+		return nil
+	}
+	return ErrTxNotFound
+}
+
+type StateRootArgs struct {
+	Height uint64 `json:"height"`
+}
+
+type StateRootReply struct {
+	Root ids.ID `json:"root"`
+}
+
+// StateRoot returns the root of the state after the block at [args.Height]
+// was accepted (even if the block has since been pruned).
+func (j *JSONRPCServer) StateRoot(_ *http.Request, args *StateRootArgs, reply *StateRootReply) error {
+	root, err := j.vm.StateRoot(args.Height)
+	if err != nil {
+		return err
+	}
+	reply.Root = root
+	return nil
+}
+
+type StateProofArgs struct {
+	Height uint64 `json:"height"`
+	Key    []byte `json:"key"`
+}
+
+type StateProofReply struct {
+	Root  ids.ID             `json:"root"`
+	Proof *syncpb.RangeProof `json:"proof"`
+}
+
+// StateProof returns a proof of the value of [args.Key] (or of its absence)
+// in the state after the block at [args.Height] was accepted. Proofs can only
+// be generated for recent heights (see [StateRoot] for older heights).
+func (j *JSONRPCServer) StateProof(req *http.Request, args *StateProofArgs, reply *StateProofReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.StateProof")
+	defer span.End()
+
+	if err := j.shed(); err != nil {
+		return err
+	}
+	root, proof, err := j.vm.StateProof(ctx, args.Height, args.Key)
+	if err != nil {
+		return err
+	}
+	reply.Root = root
+	reply.Proof = proof.ToProto()
+	return nil
+}
+
+type ReplayProtectionReply struct {
+	TrackedIDs      int    `json:"trackedIds"`
+	Buckets         int    `json:"buckets"`
+	LastEvicted     int    `json:"lastEvicted"`
+	EstimatedMemory uint64 `json:"estimatedMemory"`
+}
+
+func (j *JSONRPCServer) ReplayProtection(
+	_ *http.Request,
+	_ *struct{},
+	reply *ReplayProtectionReply,
+) error {
+	reply.TrackedIDs, reply.Buckets, reply.LastEvicted, reply.EstimatedMemory = j.vm.ReplayProtectionStats()
+	return nil
+}
+
+type ActionStatsArgs struct {
+	Start    int64 `json:"start"`    // ms
+	End      int64 `json:"end"`      // ms
+	Interval int64 `json:"interval"` // ms (0 returns a single bucket)
+}
+
+type ActionStatsReply struct {
+	Buckets []*chain.ActionStatsBucket `json:"buckets"`
+}
+
+// ActionStats returns the number of accepted transactions (and units
+// consumed) of each action type in each [Interval] of [Start, End).
+func (j *JSONRPCServer) ActionStats(
+	req *http.Request,
+	args *ActionStatsArgs,
+	reply *ActionStatsReply,
+) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.ActionStats")
+	defer span.End()
+
+	if err := j.shed(); err != nil {
+		return err
+	}
+
+	buckets, err := j.vm.ActionStats(args.Start, args.End, args.Interval)
+	if err != nil {
+		return err
+	}
+	reply.Buckets = buckets
+	return nil
+}
+
+type AddressBloomsArgs struct {
+	Start uint64 `json:"start"` // height
+	End   uint64 `json:"end"`   // height (inclusive)
+}
+
+type AddressBloom struct {
+	Height uint64             `json:"height"`
+	Bloom  chain.AddressBloom `json:"bloom"`
+}
+
+type AddressBloomsReply struct {
+	Blooms []*AddressBloom `json:"blooms"`
+}
+
+// AddressBlooms returns a bloom filter of the addresses touched by each block
+// in [Start, End] (that has not been pruned). Blocks whose bloom does not
+// contain an address definitely don't involve that address.
+func (j *JSONRPCServer) AddressBlooms(
+	req *http.Request,
+	args *AddressBloomsArgs,
+	reply *AddressBloomsReply,
+) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.AddressBlooms")
+	defer span.End()
+
+	if err := j.shed(); err != nil {
+		return err
+	}
+
+	heights, blooms, err := j.vm.AddressBlooms(args.Start, args.End)
+	if err != nil {
+		return err
+	}
+	reply.Blooms = make([]*AddressBloom, len(heights))
+	for i, height := range heights {
+		reply.Blooms[i] = &AddressBloom{Height: height, Bloom: blooms[i]}
+	}
+	return nil
+}
+
+type StateDiffsArgs struct {
+	Start uint64 `json:"start"` // height
+	Limit int    `json:"limit"` // 0 uses the max
+}
+
+type StateDiffsReply struct {
+	Diffs []*chain.StateDiff `json:"diffs"`
+}
+
+// StateDiffs returns the state diffs of consecutive blocks starting at
+// [Start] (no diffs are returned if the block at [Start] has not been
+// accepted yet). Replicas should backfill with this before subscribing to
+// state diffs over the WebSocket API.
+func (j *JSONRPCServer) StateDiffs(req *http.Request, args *StateDiffsArgs, reply *StateDiffsReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.StateDiffs")
+	defer span.End()
+
+	if err := j.shed(); err != nil {
+		return err
+	}
+
+	diffs, err := j.vm.StateDiffs(args.Start, ClampLimit(args.Limit, maxStateDiffs))
+	if err != nil {
+		return err
+	}
+	reply.Diffs = diffs
+	return nil
+}
+
+type ConsumerArgs struct {
+	Name   string `json:"name"`
+	Height uint64 `json:"height"`
+}
+
+type ConsumerReply struct {
+	Registered bool   `json:"registered"`
+	Height     uint64 `json:"height"`
+}
+
+// Consumer returns the last height acknowledged by consumer [Name] (consumers
+// are managed with the admin API).
+func (j *JSONRPCServer) Consumer(_ *http.Request, args *ConsumerArgs, reply *ConsumerReply) error {
+	reply.Height, reply.Registered = j.vm.ConsumerHeight(args.Name)
 	return nil
 }
 
 type SuggestedRawFeeReply struct {
-	UnitPrice uint64 `json:"unitPrice"`
+	UnitPrices fees.Dimensions `json:"unitPrices"`
 }
 
 func (j *JSONRPCServer) SuggestedRawFee(
@@ -108,11 +688,24 @@ func (j *JSONRPCServer) SuggestedRawFee(
 	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.SuggestedRawFee")
 	defer span.End()
 
-	unitPrice, err := j.vm.SuggestedFee(ctx)
+	unitPrices, err := j.vm.SuggestedFee(ctx)
 	if err != nil {
 		return err
 	}
-	reply.UnitPrice = unitPrice
+	reply.UnitPrices = unitPrices
+	return nil
+}
+
+type ThroughputReply struct {
+	// Windows summarizes the accepted blocks of the last 1m, 10m, and 1h (in
+	// that order).
+	Windows []*throughput.Summary `json:"windows"`
+}
+
+// Throughput returns the rolling TPS, units/sec, and block fullness of
+// accepted blocks (so that dashboards don't need to scrape metrics).
+func (j *JSONRPCServer) Throughput(_ *http.Request, _ *struct{}, reply *ThroughputReply) error {
+	reply.Windows = j.vm.Throughput()
 	return nil
 }
 
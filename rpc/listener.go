@@ -0,0 +1,154 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const listenerReadHeaderTimeout = 10 * time.Second
+
+// ListenerConfig describes an address (besides the HTTP server of the node)
+// that the APIs of the VM are served on. This can be used to expose the APIs
+// to co-located indexers and sidecars over a unix socket, or on an IPv6
+// address.
+//
+// Each endpoint is served at its path without the chain prefix used by the
+// node (like "/coreapi" instead of "/ext/bc/<chainID>/coreapi").
+type ListenerConfig struct {
+	// Network is "tcp", "tcp4", "tcp6", or "unix".
+	Network string `json:"network"`
+
+	// Address is a host:port (IPv6 hosts must be bracketed, like
+	// "[::1]:9660") or the path of a unix socket.
+	Address string `json:"address"`
+
+	// AuthToken, if provided, must be included as a bearer token in the
+	// "Authorization" header of all requests to the listener.
+	AuthToken string `json:"authToken"`
+}
+
+// Verify returns an error if [c] can't be listened on.
+func (c *ListenerConfig) Verify() error {
+	switch c.Network {
+	case "tcp", "tcp4", "tcp6":
+		if _, _, err := net.SplitHostPort(c.Address); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidListener, err) //nolint:errorlint
+		}
+	case "unix":
+		if len(c.Address) == 0 {
+			return fmt.Errorf("%w: missing socket path", ErrInvalidListener)
+		}
+	default:
+		return fmt.Errorf("%w: unknown network %q", ErrInvalidListener, c.Network)
+	}
+	return nil
+}
+
+// Listeners serves handlers on the addresses of a set of [ListenerConfig]s.
+type Listeners struct {
+	servers []*http.Server
+}
+
+// Listen serves [handlers] (keyed by endpoint) on each of [cfgs]. Handlers
+// that require the engine lock acquire [lock] while serving a request.
+func Listen(
+	cfgs []*ListenerConfig,
+	handlers map[string]*common.HTTPHandler,
+	lock *sync.RWMutex,
+) (*Listeners, error) {
+	l := &Listeners{}
+	for _, cfg := range cfgs {
+		if err := cfg.Verify(); err != nil {
+			_ = l.Close()
+			return nil, err
+		}
+		if cfg.Network == "unix" {
+			// Remove any socket left behind by an unclean shutdown
+			if info, err := os.Stat(cfg.Address); err == nil && info.Mode()&fs.ModeSocket != 0 {
+				if err := os.Remove(cfg.Address); err != nil {
+					_ = l.Close()
+					return nil, err
+				}
+			}
+		}
+		listener, err := net.Listen(cfg.Network, cfg.Address)
+		if err != nil {
+			_ = l.Close()
+			return nil, err
+		}
+		mux := http.NewServeMux()
+		for endpoint, h := range handlers {
+			mux.Handle(endpoint, withLock(h, lock))
+		}
+		server := &http.Server{
+			Handler:           withAuthToken(cfg.AuthToken, mux),
+			ReadHeaderTimeout: listenerReadHeaderTimeout,
+		}
+		l.servers = append(l.servers, server)
+		go func() {
+			_ = server.Serve(listener)
+		}()
+	}
+	return l, nil
+}
+
+// Close stops serving on all listeners.
+func (l *Listeners) Close() error {
+	errs := wrappers.Errs{}
+	for _, server := range l.servers {
+		if err := server.Close(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errs.Add(err)
+		}
+	}
+	return errs.Err
+}
+
+// withLock acquires [lock] (as required by the [common.LockOption] of [h])
+// while serving each request.
+func withLock(h *common.HTTPHandler, lock *sync.RWMutex) http.Handler {
+	switch h.LockOptions {
+	case common.WriteLock:
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			lock.Lock()
+			defer lock.Unlock()
+			h.Handler.ServeHTTP(w, req)
+		})
+	case common.ReadLock:
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			lock.RLock()
+			defer lock.RUnlock()
+			h.Handler.ServeHTTP(w, req)
+		})
+	default:
+		return h.Handler
+	}
+}
+
+// withAuthToken rejects requests to [h] that do not include [token] as a
+// bearer token (if [token] is not empty).
+func withAuthToken(token string, h http.Handler) http.Handler {
+	if len(token) == 0 {
+		return h
+	}
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), expected) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenerConfigVerify(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError((&ListenerConfig{Network: "tcp6", Address: "[::1]:9660"}).Verify())
+	require.NoError((&ListenerConfig{Network: "unix", Address: "/tmp/vm.sock"}).Verify())
+	require.ErrorIs((&ListenerConfig{Network: "tcp", Address: "::1"}).Verify(), ErrInvalidListener)
+	require.ErrorIs((&ListenerConfig{Network: "unix"}).Verify(), ErrInvalidListener)
+	require.ErrorIs((&ListenerConfig{Network: "udp", Address: ":9660"}).Verify(), ErrInvalidListener)
+}
+
+func TestListenUnix(t *testing.T) {
+	require := require.New(t)
+
+	socket := filepath.Join(t.TempDir(), "vm.sock")
+	handlers := map[string]*common.HTTPHandler{
+		"/coreapi": {
+			LockOptions: common.WriteLock,
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}),
+		},
+	}
+	l, err := Listen([]*ListenerConfig{{Network: "unix", Address: socket, AuthToken: "secret"}}, handlers, &sync.RWMutex{})
+	require.NoError(err)
+	defer func() {
+		require.NoError(l.Close())
+	}()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	get := func(path string, token string) int {
+		req, err := http.NewRequest(http.MethodGet, "http://unix"+path, nil)
+		require.NoError(err)
+		if len(token) > 0 {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := client.Do(req)
+		require.NoError(err)
+		require.NoError(resp.Body.Close())
+		return resp.StatusCode
+	}
+	require.Equal(http.StatusUnauthorized, get("/coreapi", ""))
+	require.Equal(http.StatusUnauthorized, get("/coreapi", "wrong"))
+	require.Equal(http.StatusOK, get("/coreapi", "secret"))
+	require.Equal(http.StatusNotFound, get("/other", "secret"))
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ava-labs/hypersdk/requester"
+)
+
+type MempoolJSONRPCClient struct {
+	requester *requester.EndpointRequester
+}
+
+func NewMempoolJSONRPCClient(uri string) *MempoolJSONRPCClient {
+	uri = strings.TrimSuffix(uri, "/")
+	uri += MempoolEndpoint
+	req := requester.New(uri, Name)
+	return &MempoolJSONRPCClient{requester: req}
+}
+
+// Transactions returns at most [limit] transactions in the mempool of the node
+// (only those paid for by [payer], if provided) after [cursor], from the
+// highest to the lowest unit price. It also returns the cursor of the next
+// page ("" if there are no more transactions).
+func (cli *MempoolJSONRPCClient) Transactions(
+	ctx context.Context,
+	payer []byte,
+	cursor string,
+	limit int,
+) ([]*MempoolTx, string, error) {
+	resp := new(MempoolTxsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"transactions",
+		&MempoolTxsArgs{
+			PageArgs: PageArgs{
+				Cursor: cursor,
+				Limit:  limit,
+			},
+			Payer: payer,
+		},
+		resp,
+	)
+	return resp.Txs, resp.Next, err
+}
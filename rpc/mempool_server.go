@@ -0,0 +1,107 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"encoding/binary"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// maxMempoolTxs is the max number of transactions returned in a single page
+// by [MempoolJSONRPCServer.Transactions].
+const maxMempoolTxs = 1_024
+
+// MempoolJSONRPCServer serves the contents of the mempool of a node so that
+// operators can see why a transaction isn't being included.
+type MempoolJSONRPCServer struct {
+	vm MempoolVM
+}
+
+func NewMempoolJSONRPCServer(vm MempoolVM) *MempoolJSONRPCServer {
+	return &MempoolJSONRPCServer{vm}
+}
+
+// MempoolTx describes a transaction pending in the mempool.
+type MempoolTx struct {
+	TxID      ids.ID `json:"txId"`
+	Payer     []byte `json:"payer"`
+	UnitPrice uint64 `json:"unitPrice"` // see [chain.Transaction.UnitPrice]
+	Expiry    int64  `json:"expiry"`    // ms
+	Size      int    `json:"size"`
+}
+
+type MempoolTxsArgs struct {
+	PageArgs
+
+	Payer []byte `json:"payer"` // optional
+}
+
+type MempoolTxsReply struct {
+	PageReply
+
+	Txs []*MempoolTx `json:"txs"`
+}
+
+// mempoolKey orders transactions from the highest to the lowest unit price
+// (and then by ID).
+func mempoolKey(tx *chain.Transaction) []byte {
+	k := make([]byte, consts.Uint64Len+consts.IDLen)
+	binary.BigEndian.PutUint64(k, ^tx.UnitPrice())
+	id := tx.ID()
+	copy(k[consts.Uint64Len:], id[:])
+	return k
+}
+
+// Transactions returns a page of the transactions in the mempool (only those
+// paid for by [args.Payer], if provided) from the highest to the lowest unit
+// price. Transactions are read from a snapshot of the mempool taken for each
+// request, so transactions added between requests may be missed if they
+// price above the cursor.
+func (j *MempoolJSONRPCServer) Transactions(
+	req *http.Request,
+	args *MempoolTxsArgs,
+	reply *MempoolTxsReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "MempoolJSONRPCServer.Transactions")
+	defer span.End()
+
+	if j.vm.ShouldShed() {
+		return ErrOverloaded
+	}
+
+	var filter func(*chain.Transaction) bool
+	if len(args.Payer) > 0 {
+		payer := string(args.Payer)
+		filter = func(tx *chain.Transaction) bool {
+			return tx.Payer() == payer
+		}
+	}
+	txs, next, err := Page(
+		j.vm.MempoolSnapshot(ctx),
+		mempoolKey,
+		filter,
+		args.Cursor,
+		args.Limit,
+		maxMempoolTxs,
+	)
+	if err != nil {
+		return err
+	}
+	reply.Txs = make([]*MempoolTx, len(txs))
+	for i, tx := range txs {
+		reply.Txs[i] = &MempoolTx{
+			TxID:      tx.ID(),
+			Payer:     []byte(tx.Payer()),
+			UnitPrice: tx.UnitPrice(),
+			Expiry:    tx.Expiry(),
+			Size:      tx.Size(),
+		}
+	}
+	reply.Next = next
+	return nil
+}
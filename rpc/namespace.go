@@ -0,0 +1,165 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/hypersdk/requester"
+)
+
+const WebSocketSuffix = "/ws"
+
+// Namespace is a named API served by the VM. A VM can register many
+// namespaces (like "public", "trading", and "admin") so that they can be
+// exposed, secured, and monitored independently.
+type Namespace struct {
+	// Name identifies the namespace in metrics.
+	Name string
+
+	// Endpoint is the path of the namespace (relative to the chain's base
+	// path), like "/tradingapi".
+	Endpoint string
+
+	// Service is registered as a JSON-RPC service at [Endpoint] (if not nil).
+	Service any
+
+	// ServiceName is the prefix of all [Service] methods (like
+	// "trading.getOrders"). This defaults to [Name] if not provided.
+	ServiceName string
+
+	// WebSocket is served at [Endpoint] + [WebSocketSuffix] (if not nil).
+	WebSocket http.Handler
+
+	// AuthToken, if provided, must be included as a bearer token in the
+	// "Authorization" header of all requests to the namespace.
+	AuthToken string
+
+	// LockOption is the lock the engine should hold while serving requests
+	// to [Service].
+	LockOption common.LockOption
+}
+
+type namespaceMetrics struct {
+	requests     *prometheus.CounterVec
+	unauthorized *prometheus.CounterVec
+	duration     *prometheus.CounterVec
+}
+
+func newNamespaceMetrics(r prometheus.Registerer) (*namespaceMetrics, error) {
+	m := &namespaceMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "rpc",
+			Name:      "requests",
+			Help:      "number of requests served by each namespace",
+		}, []string{"namespace"}),
+		unauthorized: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "rpc",
+			Name:      "unauthorized",
+			Help:      "number of requests rejected by each namespace for missing auth",
+		}, []string{"namespace"}),
+		duration: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "rpc",
+			Name:      "request_duration",
+			Help:      "time spent serving requests (in ns) by each namespace",
+		}, []string{"namespace"}),
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		r.Register(m.requests),
+		r.Register(m.unauthorized),
+		r.Register(m.duration),
+	)
+	return m, errs.Err
+}
+
+// NewNamespaceHandlers creates the handlers for [namespaces] (keyed by
+// endpoint). Metrics for all namespaces are registered with [r].
+func NewNamespaceHandlers(
+	namespaces []*Namespace,
+	r prometheus.Registerer,
+) (map[string]*common.HTTPHandler, error) {
+	m, err := newNamespaceMetrics(r)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		handlers = map[string]*common.HTTPHandler{}
+		names    = map[string]struct{}{}
+	)
+	for _, ns := range namespaces {
+		if len(ns.Name) == 0 || !strings.HasPrefix(ns.Endpoint, "/") {
+			return nil, fmt.Errorf("%w: name=%q endpoint=%q", ErrInvalidNamespace, ns.Name, ns.Endpoint)
+		}
+		if _, ok := names[ns.Name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateNamespace, ns.Name)
+		}
+		names[ns.Name] = struct{}{}
+		if ns.Service != nil {
+			serviceName := ns.ServiceName
+			if len(serviceName) == 0 {
+				serviceName = ns.Name
+			}
+			h, err := NewJSONRPCHandler(serviceName, ns.Service, ns.LockOption)
+			if err != nil {
+				return nil, err
+			}
+			h.Handler = m.wrap(ns, h.Handler)
+			if err := addHandler(handlers, ns.Endpoint, h); err != nil {
+				return nil, err
+			}
+		}
+		if ns.WebSocket != nil {
+			h := NewWebSocketHandler(m.wrap(ns, ns.WebSocket))
+			if err := addHandler(handlers, ns.Endpoint+WebSocketSuffix, h); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return handlers, nil
+}
+
+// WithAuthToken authenticates a request to a [Namespace] that requires
+// [token].
+func WithAuthToken(token string) requester.Option {
+	return requester.WithHeader("Authorization", "Bearer "+token)
+}
+
+func addHandler(handlers map[string]*common.HTTPHandler, endpoint string, h *common.HTTPHandler) error {
+	if _, ok := handlers[endpoint]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateNamespace, endpoint)
+	}
+	handlers[endpoint] = h
+	return nil
+}
+
+// wrap records metrics for (and enforces the auth of) [ns].
+func (m *namespaceMetrics) wrap(ns *Namespace, h http.Handler) http.Handler {
+	var (
+		requests     = m.requests.WithLabelValues(ns.Name)
+		unauthorized = m.unauthorized.WithLabelValues(ns.Name)
+		duration     = m.duration.WithLabelValues(ns.Name)
+		token        = []byte("Bearer " + ns.AuthToken)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests.Inc()
+		if len(ns.AuthToken) > 0 &&
+			subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), token) != 1 {
+			unauthorized.Inc()
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		start := time.Now()
+		h.ServeHTTP(w, req)
+		duration.Add(float64(time.Since(start)))
+	})
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+// PageArgs can be embedded in the arguments of any RPC that returns an
+// unbounded number of items.
+type PageArgs struct {
+	// Cursor is the [PageReply.Next] of the previous page ("" for the first
+	// page).
+	Cursor string `json:"cursor"`
+
+	// Limit is the max number of items to return. It is clamped to the max
+	// allowed by the RPC (0 uses the max).
+	Limit int `json:"limit"`
+}
+
+// PageReply can be embedded in the reply of any RPC that accepts [PageArgs].
+type PageReply struct {
+	// Next is the cursor of the next page ("" if there are no more items).
+	Next string `json:"next"`
+}
+
+// ClampLimit returns [limit] if it is in (0, max] and [max] otherwise.
+func ClampLimit(limit int, max int) int {
+	if limit <= 0 || limit > max {
+		return max
+	}
+	return limit
+}
+
+// EncodeCursor encodes the key of the last item returned in a page so that it
+// can be sent to a client.
+func EncodeCursor(key []byte) string {
+	return base64.RawURLEncoding.EncodeToString(key)
+}
+
+// DecodeCursor decodes a cursor created by [EncodeCursor]. An empty cursor
+// decodes to a nil key (the start of the first page).
+func DecodeCursor(cursor string) ([]byte, error) {
+	if len(cursor) == 0 {
+		return nil, nil
+	}
+	key, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return key, nil
+}
+
+// Page returns the items in [items] that match [filter] (all items if nil)
+// ordered by [key], starting after the item with key [cursor] and containing
+// at most [limit] (clamped to [max]) items. It also returns the cursor of the
+// next page.
+//
+// Keys must be unique and should be chosen such that they sort in the order
+// items should be returned (for example, by price and then by ID). Because
+// pages are defined by key rather than offset, items that are added or removed
+// between requests do not cause other items to be skipped or repeated.
+func Page[T any](
+	items []T,
+	key func(T) []byte,
+	filter func(T) bool,
+	cursor string,
+	limit int,
+	max int,
+) ([]T, string, error) {
+	start, err := DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	limit = ClampLimit(limit, max)
+
+	keys := make([][]byte, len(items))
+	sorted := make([]int, len(items))
+	for i, item := range items {
+		keys[i] = key(item)
+		sorted[i] = i
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(keys[sorted[i]], keys[sorted[j]]) < 0
+	})
+	i := 0
+	if start != nil {
+		i = sort.Search(len(sorted), func(i int) bool {
+			return bytes.Compare(keys[sorted[i]], start) > 0
+		})
+	}
+
+	page := make([]T, 0, limit)
+	var last []byte
+	for ; i < len(sorted); i++ {
+		item := items[sorted[i]]
+		if filter != nil && !filter(item) {
+			continue
+		}
+		if len(page) == limit {
+			// There is at least one more item
+			return page, EncodeCursor(last), nil
+		}
+		page = append(page, item)
+		last = keys[sorted[i]]
+	}
+	return page, "", nil
+}
+
+// PagePrefix iterates over the keys in [db] with [prefix] (in lexicographic
+// order) starting after [cursor] and calls [f] on each. [f] returns whether
+// the item was included in the page. At most [limit] (clamped to [max]) items
+// are included. PagePrefix returns the cursor of the next page.
+func PagePrefix(
+	db database.Iteratee,
+	prefix []byte,
+	cursor string,
+	limit int,
+	max int,
+	f func(key []byte, value []byte) (bool, error),
+) (string, error) {
+	start, err := DecodeCursor(cursor)
+	if err != nil {
+		return "", err
+	}
+	if start != nil && !bytes.HasPrefix(start, prefix) {
+		return "", ErrInvalidCursor
+	}
+	limit = ClampLimit(limit, max)
+
+	iter := db.NewIteratorWithStartAndPrefix(start, prefix)
+	defer iter.Release()
+
+	var (
+		included int
+		last     []byte
+	)
+	for iter.Next() {
+		k := iter.Key()
+		if start != nil && bytes.Equal(k, start) {
+			// Skip the last item of the previous page
+			continue
+		}
+		if included == limit {
+			// There is at least one more item
+			return EncodeCursor(last), iter.Error()
+		}
+		include, err := f(k, iter.Value())
+		if err != nil {
+			return "", err
+		}
+		if include {
+			included++
+			last = k
+		}
+	}
+	return "", iter.Error()
+}
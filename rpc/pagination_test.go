@@ -0,0 +1,82 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClampLimit(t *testing.T) {
+	require := require.New(t)
+	require.Equal(10, ClampLimit(0, 10))
+	require.Equal(10, ClampLimit(-1, 10))
+	require.Equal(5, ClampLimit(5, 10))
+	require.Equal(10, ClampLimit(11, 10))
+}
+
+func TestPage(t *testing.T) {
+	require := require.New(t)
+	items := []byte{5, 3, 1, 4, 2, 6}
+	key := func(i byte) []byte { return []byte{i} }
+	odd := func(i byte) bool { return i%2 == 1 }
+
+	page, next, err := Page(items, key, nil, "", 4, 10)
+	require.NoError(err)
+	require.Equal([]byte{1, 2, 3, 4}, page)
+	require.NotEmpty(next)
+
+	// Removing an item between pages does not skip any items
+	page, next, err = Page(items[1:], key, nil, next, 4, 10)
+	require.NoError(err)
+	require.Equal([]byte{6}, page)
+	require.Empty(next)
+
+	page, next, err = Page(items, key, odd, "", 2, 10)
+	require.NoError(err)
+	require.Equal([]byte{1, 3}, page)
+	page, next, err = Page(items, key, odd, next, 2, 10)
+	require.NoError(err)
+	require.Equal([]byte{5}, page)
+	require.Empty(next)
+
+	// Limit is clamped
+	page, _, err = Page(items, key, nil, "", 0, 2)
+	require.NoError(err)
+	require.Len(page, 2)
+
+	_, _, err = Page(items, key, nil, "!", 2, 10)
+	require.ErrorIs(err, ErrInvalidCursor)
+}
+
+func TestPagePrefix(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+	for i := byte(0); i < 5; i++ {
+		require.NoError(db.Put([]byte{0, i}, []byte{i}))
+		require.NoError(db.Put([]byte{1, i}, []byte{i}))
+	}
+
+	values := []byte{}
+	collect := func(_ []byte, v []byte) (bool, error) {
+		if v[0] == 2 {
+			return false, nil
+		}
+		values = append(values, v[0])
+		return true, nil
+	}
+	next, err := PagePrefix(db, []byte{1}, "", 2, 10, collect)
+	require.NoError(err)
+	require.Equal([]byte{0, 1}, values)
+	next, err = PagePrefix(db, []byte{1}, next, 2, 10, collect)
+	require.NoError(err)
+	require.Equal([]byte{0, 1, 3, 4}, values)
+	require.Empty(next)
+
+	// Cursors cannot be reused across prefixes
+	_, err = PagePrefix(db, []byte{0}, EncodeCursor([]byte{1, 1}), 2, 10, collect)
+	require.ErrorIs(err, ErrInvalidCursor)
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Recover wraps [h] so that a panic while serving a request is passed to
+// [onPanic] and answered with a 500 (instead of unwinding into the HTTP
+// server of the node).
+//
+// [http.ErrAbortHandler] is re-raised, as it is used to intentionally abort
+// a response.
+func Recover(h http.Handler, onPanic func(req *http.Request, p any)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		defer func() {
+			p := recover()
+			if p == nil {
+				return
+			}
+			if err, ok := p.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(p)
+			}
+			onPanic(req, p)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}()
+		h.ServeHTTP(w, req)
+	})
+}
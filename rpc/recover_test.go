@@ -0,0 +1,44 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecover(t *testing.T) {
+	require := require.New(t)
+
+	var recovered any
+	h := Recover(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/panic" {
+			panic("bug")
+		}
+		w.WriteHeader(http.StatusOK)
+	}), func(_ *http.Request, p any) {
+		recovered = p
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/ok", nil))
+	require.Equal(http.StatusOK, w.Code)
+	require.Nil(recovered)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/panic", nil))
+	require.Equal(http.StatusInternalServerError, w.Code)
+	require.Equal("bug", recovered)
+
+	// Intentional aborts are not recovered
+	abort := Recover(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}), func(*http.Request, any) {})
+	require.PanicsWithValue(http.ErrAbortHandler, func() {
+		abort.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+	})
+}
@@ -0,0 +1,293 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/neilotoole/errgroup"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/pubsub"
+)
+
+const (
+	DefaultMinReconnectDelay = 500 * time.Millisecond
+	DefaultMaxReconnectDelay = 30 * time.Second
+)
+
+// StreamClient wraps a [WebSocketClient] with typed callbacks, automatic
+// reconnection, and subscription management so that consumers don't need to
+// write their own listen loops.
+//
+// All callbacks must be registered before calling [Run] and are invoked
+// sequentially per stream (blocks, decisions, floors, and state diffs are
+// delivered on separate goroutines).
+type StreamClient struct {
+	uri    string
+	parser chain.Parser
+
+	// MinReconnectDelay is how long to wait before the first attempt to
+	// reconnect. The delay doubles (up to MaxReconnectDelay) after each
+	// failed attempt.
+	MinReconnectDelay time.Duration
+	MaxReconnectDelay time.Duration
+
+	onBlock      func(*chain.StatefulBlock, []*chain.Result)
+	onTx         func(ids.ID, error, *chain.Result)
+	onFloor      func(uint64)
+	onStateDiff  func(*chain.StateDiff)
+	onDisconnect func(error)
+
+	// [nextDiff] is the height of the next state diff to deliver (only
+	// accessed by [serve])
+	nextDiff uint64
+
+	l       sync.Mutex
+	cli     *WebSocketClient
+	pending map[ids.ID]struct{}
+}
+
+// NewStreamClient creates a [StreamClient] for the node at [uri]. [parser] is
+// used to parse blocks (and is not needed if [OnBlock] is not used).
+func NewStreamClient(uri string, parser chain.Parser) *StreamClient {
+	return &StreamClient{
+		uri:               uri,
+		parser:            parser,
+		MinReconnectDelay: DefaultMinReconnectDelay,
+		MaxReconnectDelay: DefaultMaxReconnectDelay,
+		pending:           map[ids.ID]struct{}{},
+	}
+}
+
+// OnBlock subscribes to accepted blocks. Blocks accepted while disconnected
+// are not delivered.
+func (s *StreamClient) OnBlock(f func(blk *chain.StatefulBlock, results []*chain.Result)) {
+	s.onBlock = f
+}
+
+// OnTxDecision is called with the decision of each transaction submitted
+// with [SubmitTx]. If the connection is lost before a decision is received,
+// it is called with [ErrDisconnected] (the transaction may still be
+// accepted).
+func (s *StreamClient) OnTxDecision(f func(txID ids.ID, dErr error, result *chain.Result)) {
+	s.onTx = f
+}
+
+// OnFloor subscribes to mempool floor hints.
+func (s *StreamClient) OnFloor(f func(floor uint64)) {
+	s.onFloor = f
+}
+
+// OnStateDiff subscribes to the state diff of each accepted block, starting
+// with the block at [start] (the node must be configured to record state
+// diffs). Unlike blocks, diffs are delivered in order without any gaps: after
+// reconnecting, the diffs of blocks accepted while disconnected are fetched
+// before any new diffs.
+//
+// Delivery is at-least-once: a replica that persists the height of the last
+// diff it applied should pass the height after it as [start] when restarting
+// (and may receive a diff it applied again if it stops before recording its
+// height).
+func (s *StreamClient) OnStateDiff(start uint64, f func(diff *chain.StateDiff)) {
+	s.nextDiff = start
+	s.onStateDiff = f
+}
+
+// OnDisconnect is called with the reason the connection was lost (or could
+// not be established) before each reconnection attempt.
+func (s *StreamClient) OnDisconnect(f func(err error)) {
+	s.onDisconnect = f
+}
+
+// SubmitTx sends [tx] to the node and tracks its decision (which is passed to
+// the [OnTxDecision] callback).
+func (s *StreamClient) SubmitTx(tx *chain.Transaction) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	if s.cli == nil {
+		return ErrDisconnected
+	}
+	txID := tx.ID()
+	s.pending[txID] = struct{}{}
+	if err := s.cli.RegisterTx(tx); err != nil {
+		delete(s.pending, txID)
+		return err
+	}
+	return nil
+}
+
+// Run connects to the node and delivers messages to the registered callbacks
+// until [ctx] is done, reconnecting (with exponential backoff) whenever the
+// connection is lost.
+func (s *StreamClient) Run(ctx context.Context) error {
+	delay := s.MinReconnectDelay
+	for {
+		cli, err := NewWebSocketClient(s.uri, DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+		if err == nil {
+			// Only back off if we can't connect
+			delay = s.MinReconnectDelay
+			err = s.serve(ctx, cli)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if s.onDisconnect != nil {
+			s.onDisconnect(err)
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil
+		}
+		delay *= 2
+		if delay > s.MaxReconnectDelay {
+			delay = s.MaxReconnectDelay
+		}
+	}
+}
+
+// serve subscribes to all registered streams on [cli] and delivers messages
+// until [cli] fails or [ctx] is done.
+func (s *StreamClient) serve(ctx context.Context, cli *WebSocketClient) error {
+	defer s.disconnect(cli)
+
+	if s.onBlock != nil {
+		if err := cli.RegisterBlocks(); err != nil {
+			return err
+		}
+	}
+	if s.onFloor != nil {
+		if err := cli.RegisterFloor(); err != nil {
+			return err
+		}
+	}
+	if s.onStateDiff != nil {
+		// The node only backfills a limited number of diffs when subscribing
+		if err := s.backfillStateDiffs(ctx); err != nil {
+			return err
+		}
+		if err := cli.RegisterStateDiffs(s.nextDiff); err != nil {
+			return err
+		}
+	}
+	s.l.Lock()
+	s.cli = cli
+	s.l.Unlock()
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		// Close the connection to stop all listeners if one fails
+		<-gctx.Done()
+		return cli.Close()
+	})
+	if s.onBlock != nil {
+		g.Go(func() error {
+			for {
+				blk, results, err := cli.ListenBlock(gctx, s.parser)
+				if err != nil {
+					return err
+				}
+				s.onBlock(blk, results)
+			}
+		})
+	}
+	if s.onFloor != nil {
+		g.Go(func() error {
+			for {
+				floor, err := cli.ListenFloor(gctx)
+				if err != nil {
+					return err
+				}
+				s.onFloor(floor)
+			}
+		})
+	}
+	if s.onStateDiff != nil {
+		g.Go(func() error {
+			for {
+				diff, err := cli.ListenStateDiff(gctx)
+				if err != nil {
+					return err
+				}
+				if err := s.deliverStateDiff(diff); err != nil {
+					return err
+				}
+			}
+		})
+	}
+	g.Go(func() error {
+		for {
+			txID, dErr, result, err := cli.ListenTx(gctx)
+			if err != nil {
+				return err
+			}
+			s.l.Lock()
+			_, ok := s.pending[txID]
+			delete(s.pending, txID)
+			s.l.Unlock()
+			if ok && s.onTx != nil {
+				s.onTx(txID, dErr, result)
+			}
+		}
+	})
+	return g.Wait()
+}
+
+// disconnect stops using [cli] and fails all transactions that are still
+// awaiting a decision.
+func (s *StreamClient) disconnect(cli *WebSocketClient) {
+	_ = cli.Close()
+
+	s.l.Lock()
+	s.cli = nil
+	pending := s.pending
+	s.pending = map[ids.ID]struct{}{}
+	s.l.Unlock()
+
+	if s.onTx == nil {
+		return
+	}
+	for txID := range pending {
+		s.onTx(txID, ErrDisconnected, nil)
+	}
+}
+
+// backfillStateDiffs delivers the diffs of all accepted blocks from
+// [s.nextDiff] using the JSON-RPC API.
+func (s *StreamClient) backfillStateDiffs(ctx context.Context) error {
+	cli := NewJSONRPCClient(s.uri)
+	for {
+		diffs, err := cli.StateDiffs(ctx, s.nextDiff, 0)
+		if err != nil {
+			return err
+		}
+		for _, diff := range diffs {
+			if err := s.deliverStateDiff(diff); err != nil {
+				return err
+			}
+		}
+		if len(diffs) < maxStateDiffs {
+			return nil
+		}
+	}
+}
+
+// deliverStateDiff passes [diff] to [s.onStateDiff] unless it was already
+// delivered. It returns [ErrStateDiffGap] if an earlier diff is missing.
+func (s *StreamClient) deliverStateDiff(diff *chain.StateDiff) error {
+	switch {
+	case diff.Height < s.nextDiff:
+		return nil
+	case diff.Height > s.nextDiff:
+		return ErrStateDiffGap
+	}
+	s.onStateDiff(diff)
+	s.nextDiff++
+	return nil
+}
@@ -28,6 +28,11 @@ type WebSocketClient struct {
 
 	pendingBlocks chan []byte
 	pendingTxs    chan []byte
+	blockErrors   chan error
+	pendingFloors chan []byte
+	floorErrors   chan error
+	pendingDiffs  chan []byte
+	diffErrors    chan error
 
 	startedClose bool
 	closed       bool
@@ -62,6 +67,11 @@ func NewWebSocketClient(uri string, handshakeTimeout time.Duration, pending int,
 		writeStopped:  make(chan struct{}),
 		pendingBlocks: make(chan []byte, pending),
 		pendingTxs:    make(chan []byte, pending),
+		blockErrors:   make(chan error, 1),
+		pendingFloors: make(chan []byte, pending),
+		floorErrors:   make(chan error, 1),
+		pendingDiffs:  make(chan []byte, pending),
+		diffErrors:    make(chan error, 1),
 	}
 	go func() {
 		defer close(wc.readStopped)
@@ -89,6 +99,36 @@ func NewWebSocketClient(uri string, handshakeTimeout time.Duration, pending int,
 					wc.pendingBlocks <- tmsg
 				case TxMode:
 					wc.pendingTxs <- tmsg
+				case StateDiffMode:
+					wc.pendingDiffs <- tmsg
+				case FloorMode:
+					select {
+					case wc.pendingFloors <- tmsg:
+					default:
+						// Only the latest hints are useful
+					}
+				case ErrorMode:
+					mode, rerr, err := UnpackErrorMessage(tmsg)
+					if err != nil {
+						utils.Outf("{{orange}}received invalid error message:{{/}} %v\n", err)
+						continue
+					}
+					var errs chan error
+					switch mode {
+					case BlockMode:
+						errs = wc.blockErrors
+					case FloorMode:
+						errs = wc.floorErrors
+					case StateDiffMode:
+						errs = wc.diffErrors
+					default:
+						utils.Outf("{{orange}}unexpected error message mode:{{/}} %x\n", mode)
+						continue
+					}
+					select {
+					case errs <- rerr:
+					default:
+					}
 				default:
 					utils.Outf("{{orange}}unexpected message mode:{{/}} %x\n", msg[0])
 					continue
@@ -145,6 +185,8 @@ func (c *WebSocketClient) ListenBlock(
 	select {
 	case msg := <-c.pendingBlocks:
 		return UnpackBlockMessage(msg, parser)
+	case err := <-c.blockErrors:
+		return nil, nil, err
 	case <-c.readStopped:
 		return nil, nil, c.err
 	case <-ctx.Done():
@@ -152,6 +194,58 @@ func (c *WebSocketClient) ListenBlock(
 	}
 }
 
+// RegisterFloor subscribes to hints about the max fee a tx must exceed to stay
+// in the mempool of the streaming server (sent when it is full).
+func (c *WebSocketClient) RegisterFloor() error {
+	if c.closed {
+		return ErrClosed
+	}
+	return c.mb.Send([]byte{FloorMode})
+}
+
+// ListenFloor listens for mempool floor hints from the streaming server.
+func (c *WebSocketClient) ListenFloor(ctx context.Context) (uint64, error) {
+	select {
+	case msg := <-c.pendingFloors:
+		return UnpackFloorMessage(msg)
+	case err := <-c.floorErrors:
+		return 0, err
+	case <-c.readStopped:
+		return 0, c.err
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// RegisterStateDiffs subscribes to the state diff of each accepted block,
+// starting with the block at [start] (diffs of blocks that were already
+// accepted are sent first).
+func (c *WebSocketClient) RegisterStateDiffs(start uint64) error {
+	if c.closed {
+		return ErrClosed
+	}
+	msg, err := PackStateDiffRequest(start)
+	if err != nil {
+		return err
+	}
+	return c.mb.Send(append([]byte{StateDiffMode}, msg...))
+}
+
+// ListenStateDiff listens for state diffs from the streaming server (in order
+// of height).
+func (c *WebSocketClient) ListenStateDiff(ctx context.Context) (*chain.StateDiff, error) {
+	select {
+	case msg := <-c.pendingDiffs:
+		return chain.UnmarshalStateDiff(msg)
+	case err := <-c.diffErrors:
+		return nil, err
+	case <-c.readStopped:
+		return nil, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // IssueTx sends [tx] to the streaming rpc server.
 func (c *WebSocketClient) RegisterTx(tx *chain.Transaction) error {
 	if c.closed {
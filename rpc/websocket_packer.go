@@ -15,6 +15,17 @@ import (
 const (
 	BlockMode byte = 0
 	TxMode    byte = 1
+	ErrorMode byte = 2
+	FloorMode byte = 3
+
+	StateDiffMode byte = 4
+)
+
+// Statuses of a [TxMode] message
+const (
+	txAccepted byte = 0
+	txRemoved  byte = 1
+	txExpired  byte = 2
 )
 
 func PackBlockMessage(b *chain.StatelessBlock) ([]byte, error) {
@@ -56,10 +67,10 @@ func UnpackBlockMessage(
 // Could be a better place for these methods
 // Packs an accepted block message
 func PackAcceptedTxMessage(txID ids.ID, result *chain.Result) ([]byte, error) {
-	size := consts.IDLen + consts.BoolLen + result.Size()
+	size := consts.IDLen + consts.ByteLen + result.Size()
 	p := codec.NewWriter(size, consts.MaxInt)
 	p.PackID(txID)
-	p.PackBool(false)
+	p.PackByte(txAccepted)
 	result.Marshal(p)
 	return p.Bytes(), p.Err()
 }
@@ -67,24 +78,44 @@ func PackAcceptedTxMessage(txID ids.ID, result *chain.Result) ([]byte, error) {
 // Packs a removed block message
 func PackRemovedTxMessage(txID ids.ID, err error) ([]byte, error) {
 	errString := err.Error()
-	size := consts.IDLen + consts.BoolLen + codec.StringLen(errString)
+	size := consts.IDLen + consts.ByteLen + codec.StringLen(errString)
 	p := codec.NewWriter(size, consts.MaxInt)
 	p.PackID(txID)
-	p.PackBool(true)
+	p.PackByte(txRemoved)
 	p.PackString(errString)
 	return p.Bytes(), p.Err()
 }
 
+// PackExpiredTxMessage packs a message informing listeners that [txID] can no
+// longer be included in a block.
+func PackExpiredTxMessage(txID ids.ID) ([]byte, error) {
+	p := codec.NewWriter(consts.IDLen+consts.ByteLen, consts.MaxInt)
+	p.PackID(txID)
+	p.PackByte(txExpired)
+	return p.Bytes(), p.Err()
+}
+
 // Unpacks a tx message from [msg]. Returns the txID, an error regarding the status
 // of the tx, the result of the tx, and an error if there was a
 // problem unpacking the message.
+//
+// If the tx expired without being included, the status error is [ErrExpired].
 func UnpackTxMessage(msg []byte) (ids.ID, error, *chain.Result, error) {
 	p := codec.NewReader(msg, consts.MaxInt)
 	var txID ids.ID
 	p.UnpackID(true, &txID)
-	if p.UnpackBool() {
+	switch p.UnpackByte() {
+	case txAccepted:
+	case txRemoved:
 		err := p.UnpackString(true)
 		return ids.Empty, errors.New(err), nil, p.Err()
+	case txExpired:
+		if !p.Empty() {
+			return ids.Empty, nil, nil, chain.ErrInvalidObject
+		}
+		return txID, ErrExpired, nil, p.Err()
+	default:
+		return ids.Empty, nil, nil, chain.ErrInvalidObject
 	}
 	result, err := chain.UnmarshalResult(p)
 	if err != nil {
@@ -95,3 +126,60 @@ func UnpackTxMessage(msg []byte) (ids.ID, error, *chain.Result, error) {
 	}
 	return txID, nil, result, p.Err()
 }
+
+// PackErrorMessage packs a message informing the client that a request with
+// [mode] was rejected.
+func PackErrorMessage(mode byte, err error) ([]byte, error) {
+	errString := err.Error()
+	size := consts.ByteLen + codec.StringLen(errString)
+	p := codec.NewWriter(size, consts.MaxInt)
+	p.PackByte(mode)
+	p.PackString(errString)
+	return p.Bytes(), p.Err()
+}
+
+// UnpackErrorMessage returns the mode of the rejected request and the reason
+// it was rejected.
+func UnpackErrorMessage(msg []byte) (byte, error, error) {
+	p := codec.NewReader(msg, consts.MaxInt)
+	mode := p.UnpackByte()
+	err := p.UnpackString(true)
+	if !p.Empty() {
+		return 0, nil, chain.ErrInvalidObject
+	}
+	return mode, errors.New(err), p.Err()
+}
+
+// PackFloorMessage packs a hint that the mempool is full and will evict any
+// transaction that doesn't pay more than [floor] per unit.
+func PackFloorMessage(floor uint64) ([]byte, error) {
+	p := codec.NewWriter(consts.Uint64Len, consts.MaxInt)
+	p.PackUint64(floor)
+	return p.Bytes(), p.Err()
+}
+
+func UnpackFloorMessage(msg []byte) (uint64, error) {
+	p := codec.NewReader(msg, consts.MaxInt)
+	floor := p.UnpackUint64(false)
+	if !p.Empty() {
+		return 0, chain.ErrInvalidObject
+	}
+	return floor, p.Err()
+}
+
+// PackStateDiffRequest packs a request to subscribe to state diffs starting
+// at [start].
+func PackStateDiffRequest(start uint64) ([]byte, error) {
+	p := codec.NewWriter(consts.Uint64Len, consts.MaxInt)
+	p.PackUint64(start)
+	return p.Bytes(), p.Err()
+}
+
+func UnpackStateDiffRequest(msg []byte) (uint64, error) {
+	p := codec.NewReader(msg, consts.MaxInt)
+	start := p.UnpackUint64(false)
+	if !p.Empty() {
+		return 0, chain.ErrInvalidObject
+	}
+	return start, p.Err()
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+func TestTxMessageEvents(t *testing.T) {
+	require := require.New(t)
+
+	txID := ids.GenerateTestID()
+	result := &chain.Result{
+		Success:  true,
+		Units:    10,
+		Consumed: fees.Dimensions{1, 10, 2, 1, 1},
+		Fee:      15,
+		Events: []*chain.Event{
+			{Topic: "transfer", Payload: []byte{1, 2, 3}},
+			{Topic: "empty"},
+		},
+	}
+	msg, err := PackAcceptedTxMessage(txID, result)
+	require.NoError(err)
+	require.Len(msg, consts.IDLen+consts.ByteLen+result.Size())
+
+	parsedID, status, parsed, err := UnpackTxMessage(msg)
+	require.NoError(err)
+	require.NoError(status)
+	require.Equal(txID, parsedID)
+	require.Equal(result, parsed)
+
+	// Results without events are standardized to nil
+	result.Events = []*chain.Event{}
+	msg, err = PackAcceptedTxMessage(txID, result)
+	require.NoError(err)
+	_, _, parsed, err = UnpackTxMessage(msg)
+	require.NoError(err)
+	require.Nil(parsed.Events)
+}
@@ -6,6 +6,7 @@ package rpc
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -18,43 +19,198 @@ import (
 	"github.com/ava-labs/hypersdk/pubsub"
 )
 
+// maxStateDiffs is the max number of state diffs returned by
+// [JSONRPCServer.StateDiffs] (or backfilled when subscribing to state diffs).
+const maxStateDiffs = 256
+
+type WebSocketServerConfig struct {
+	// Maximum number of pending messages to send to a connection
+	MaxPendingMessages int
+	// Maximum number of open connections (0 is unlimited)
+	MaxConnections int
+	// Maximum number of open connections from a single IP (0 is unlimited)
+	MaxConnectionsPerIP int
+	// Maximum number of block and tx subscriptions held by a single
+	// connection (0 is unlimited)
+	MaxSubscriptionsPerConnection int
+	// Maximum number of block and tx subscriptions held by all connections
+	// (0 is unlimited)
+	MaxSubscriptions int
+}
+
 type WebSocketServer struct {
+	vm     VM
 	logger logging.Logger
+	config *WebSocketServerConfig
 	s      *pubsub.Server
 
 	blockListeners *pubsub.Connections
+	floorListeners *pubsub.Connections
 
 	txL         sync.Mutex
 	txListeners map[ids.ID]*pubsub.Connections
 	expiringTxs *emap.EMap[*chain.Transaction] // ensures all tx listeners are eventually responded to
+
+	// [diffListeners] maps each state diff listener to the height of the next
+	// diff it should receive
+	diffL         sync.Mutex
+	diffListeners map[*pubsub.Connection]uint64
+
+	// track subscriptions to enforce [MaxSubscriptionsPerConnection] and
+	// [MaxSubscriptions]
+	subL          sync.Mutex
+	subscriptions map[*pubsub.Connection]int
+	subscribers   int
 }
 
-func NewWebSocketServer(vm VM, maxPendingMessages int) (*WebSocketServer, *pubsub.Server) {
+func NewWebSocketServer(vm VM, config *WebSocketServerConfig) (*WebSocketServer, *pubsub.Server) {
 	w := &WebSocketServer{
+		vm:             vm,
 		logger:         vm.Logger(),
+		config:         config,
 		blockListeners: pubsub.NewConnections(),
+		floorListeners: pubsub.NewConnections(),
 		txListeners:    map[ids.ID]*pubsub.Connections{},
 		expiringTxs:    emap.NewEMap[*chain.Transaction](),
+		diffListeners:  map[*pubsub.Connection]uint64{},
+		subscriptions:  map[*pubsub.Connection]int{},
 	}
 	cfg := pubsub.NewDefaultServerConfig()
-	cfg.MaxPendingMessages = maxPendingMessages
+	cfg.MaxPendingMessages = config.MaxPendingMessages
+	cfg.MaxConnections = config.MaxConnections
+	cfg.MaxConnectionsPerIP = config.MaxConnectionsPerIP
 	w.s = pubsub.New(w.logger, cfg, w.MessageCallback(vm))
 	return w, w.s
 }
 
+// reserveSubscription returns false if adding a subscription for [c] would
+// exceed the configured subscription limits.
+func (w *WebSocketServer) reserveSubscription(c *pubsub.Connection) bool {
+	w.subL.Lock()
+	defer w.subL.Unlock()
+
+	if w.config.MaxSubscriptions > 0 && w.subscribers >= w.config.MaxSubscriptions {
+		return false
+	}
+	if w.config.MaxSubscriptionsPerConnection > 0 && w.subscriptions[c] >= w.config.MaxSubscriptionsPerConnection {
+		return false
+	}
+	w.subscriptions[c]++
+	w.subscribers++
+	return true
+}
+
+// releaseSubscriptions releases a subscription held by each of [conns].
+func (w *WebSocketServer) releaseSubscriptions(conns []*pubsub.Connection) {
+	w.subL.Lock()
+	defer w.subL.Unlock()
+
+	for _, c := range conns {
+		w.subscriptions[c]--
+		w.subscribers--
+		if w.subscriptions[c] <= 0 {
+			delete(w.subscriptions, c)
+		}
+	}
+}
+
 // Note: no need to have a tx listener removal, this will happen when all
 // submitted transactions are cleared.
-func (w *WebSocketServer) AddTxListener(tx *chain.Transaction, c *pubsub.Connection) {
+//
+// AddTxListener returns [ErrTooManySubscriptions] if [c] can't listen to any
+// more transactions.
+func (w *WebSocketServer) AddTxListener(tx *chain.Transaction, c *pubsub.Connection) error {
 	w.txL.Lock()
 	defer w.txL.Unlock()
 
-	// TODO: limit max number of tx listeners a single connection can create
 	txID := tx.ID()
-	if _, ok := w.txListeners[txID]; !ok {
-		w.txListeners[txID] = pubsub.NewConnections()
+	listeners, ok := w.txListeners[txID]
+	if ok && listeners.Has(c) {
+		return nil
+	}
+	if !w.reserveSubscription(c) {
+		return ErrTooManySubscriptions
+	}
+	if !ok {
+		listeners = pubsub.NewConnections()
+		w.txListeners[txID] = listeners
 	}
-	w.txListeners[txID].Add(c)
+	listeners.Add(c)
 	w.expiringTxs.Add([]*chain.Transaction{tx})
+	return nil
+}
+
+// AddBlockListener returns [ErrTooManySubscriptions] if [c] can't listen to
+// blocks.
+func (w *WebSocketServer) AddBlockListener(c *pubsub.Connection) error {
+	return w.addListener(w.blockListeners, c)
+}
+
+// AddFloorListener returns [ErrTooManySubscriptions] if [c] can't listen to
+// mempool floor hints.
+func (w *WebSocketServer) AddFloorListener(c *pubsub.Connection) error {
+	return w.addListener(w.floorListeners, c)
+}
+
+// AddStateDiffListener sends [c] the state diff of each accepted block
+// starting at [start]. Diffs of blocks that have already been accepted are
+// sent before any new diffs (and diffs are always sent in order), so a
+// replica that resubscribes from the height after the last diff it applied
+// will not miss any.
+//
+// AddStateDiffListener returns [ErrBackfillTooLarge] if more than
+// [maxStateDiffs] diffs would need to be backfilled (use
+// [JSONRPCServer.StateDiffs] to catch up first).
+func (w *WebSocketServer) AddStateDiffListener(c *pubsub.Connection, start uint64) error {
+	w.diffL.Lock()
+	defer w.diffL.Unlock()
+
+	if _, ok := w.diffListeners[c]; ok {
+		return ErrDuplicateSubscription
+	}
+	// Holding [diffL] ensures no diffs are published until the backfill is
+	// sent
+	diffs, err := w.vm.StateDiffs(start, maxStateDiffs+1)
+	if err != nil {
+		return err
+	}
+	if len(diffs) > maxStateDiffs {
+		return ErrBackfillTooLarge
+	}
+	if !w.reserveSubscription(c) {
+		return ErrTooManySubscriptions
+	}
+	for _, diff := range diffs {
+		bytes, err := diff.Marshal()
+		if err != nil {
+			w.releaseSubscriptions([]*pubsub.Connection{c})
+			return err
+		}
+		c.Send(append([]byte{StateDiffMode}, bytes...))
+	}
+	w.diffListeners[c] = start + uint64(len(diffs))
+	return nil
+}
+
+func (w *WebSocketServer) addListener(listeners *pubsub.Connections, c *pubsub.Connection) error {
+	if listeners.Has(c) {
+		return nil
+	}
+	if !w.reserveSubscription(c) {
+		return ErrTooManySubscriptions
+	}
+	listeners.Add(c)
+	return nil
+}
+
+// publish sends [msg] to all [listeners], removing any that are no longer
+// active.
+func (w *WebSocketServer) publish(msg []byte, listeners *pubsub.Connections) {
+	inactiveConnection := w.s.Publish(msg, listeners)
+	for _, conn := range inactiveConnection {
+		listeners.Remove(conn)
+	}
+	w.releaseSubscriptions(inactiveConnection)
 }
 
 // If never possible for a tx to enter mempool, call this
@@ -66,18 +222,28 @@ func (w *WebSocketServer) RemoveTx(txID ids.ID, err error) error {
 }
 
 func (w *WebSocketServer) removeTx(txID ids.ID, err error) error {
-	listeners, ok := w.txListeners[txID]
-	if !ok {
+	if _, ok := w.txListeners[txID]; !ok {
 		return nil
 	}
 	bytes, err := PackRemovedTxMessage(txID, err)
 	if err != nil {
 		return err
 	}
-	w.s.Publish(append([]byte{TxMode}, bytes...), listeners)
+	w.publishTx(txID, bytes)
+	return nil
+}
+
+// publishTx sends the decision [msg] to the listeners of [txID] and then
+// removes them.
+func (w *WebSocketServer) publishTx(txID ids.ID, msg []byte) {
+	listeners, ok := w.txListeners[txID]
+	if !ok {
+		return
+	}
+	w.s.Publish(append([]byte{TxMode}, msg...), listeners)
+	w.releaseSubscriptions(listeners.Conns())
 	delete(w.txListeners, txID)
 	// [expiringTxs] will be cleared eventually (does not support removal)
-	return nil
 }
 
 func (w *WebSocketServer) SetMinTx(t int64) error {
@@ -86,9 +252,14 @@ func (w *WebSocketServer) SetMinTx(t int64) error {
 
 	expired := w.expiringTxs.SetMin(t)
 	for _, id := range expired {
-		if err := w.removeTx(id, ErrExpired); err != nil {
+		if _, ok := w.txListeners[id]; !ok {
+			continue
+		}
+		bytes, err := PackExpiredTxMessage(id)
+		if err != nil {
 			return err
 		}
+		w.publishTx(id, bytes)
 	}
 	if exp := len(expired); exp > 0 {
 		w.logger.Debug("expired listeners", zap.Int("count", exp))
@@ -102,10 +273,7 @@ func (w *WebSocketServer) AcceptBlock(b *chain.StatelessBlock) error {
 		if err != nil {
 			return err
 		}
-		inactiveConnection := w.s.Publish(append([]byte{BlockMode}, bytes...), w.blockListeners)
-		for _, conn := range inactiveConnection {
-			w.blockListeners.Remove(conn)
-		}
+		w.publish(append([]byte{BlockMode}, bytes...), w.blockListeners)
 	}
 
 	w.txL.Lock()
@@ -113,8 +281,7 @@ func (w *WebSocketServer) AcceptBlock(b *chain.StatelessBlock) error {
 	results := b.Results()
 	for i, tx := range b.Txs {
 		txID := tx.ID()
-		listeners, ok := w.txListeners[txID]
-		if !ok {
+		if _, ok := w.txListeners[txID]; !ok {
 			continue
 		}
 		// Publish to tx listener
@@ -122,13 +289,64 @@ func (w *WebSocketServer) AcceptBlock(b *chain.StatelessBlock) error {
 		if err != nil {
 			return err
 		}
-		w.s.Publish(append([]byte{TxMode}, bytes...), listeners)
-		delete(w.txListeners, txID)
-		// [expiringTxs] will be cleared eventually (does not support removal)
+		w.publishTx(txID, bytes)
 	}
 	return nil
 }
 
+// AcceptStateDiff sends [diff] to all listeners waiting for it. It must be
+// called with the diff of each accepted block in order (after it is persisted).
+//
+// Listeners that are waiting for an earlier diff (because the diff of a block
+// was not recorded) are sent [ErrStateDiffGap] and removed.
+func (w *WebSocketServer) AcceptStateDiff(diff *chain.StateDiff) error {
+	w.diffL.Lock()
+	defer w.diffL.Unlock()
+
+	if len(w.diffListeners) == 0 {
+		return nil
+	}
+	bytes, err := diff.Marshal()
+	if err != nil {
+		return err
+	}
+	msg := append([]byte{StateDiffMode}, bytes...)
+	removed := []*pubsub.Connection{}
+	for c, next := range w.diffListeners {
+		switch {
+		case diff.Height < next:
+			// Already sent in backfill
+		case diff.Height > next:
+			w.reject(c, StateDiffMode, ErrStateDiffGap)
+			removed = append(removed, c)
+		case c.Send(msg):
+			w.diffListeners[c] = next + 1
+		default:
+			// Connection is no longer active
+			removed = append(removed, c)
+		}
+	}
+	for _, c := range removed {
+		delete(w.diffListeners, c)
+	}
+	w.releaseSubscriptions(removed)
+	return nil
+}
+
+// PublishFloor notifies listeners that the mempool is full and will evict any
+// transaction whose max fee isn't more than [floor].
+func (w *WebSocketServer) PublishFloor(floor uint64) error {
+	if w.floorListeners.Len() == 0 {
+		return nil
+	}
+	bytes, err := PackFloorMessage(floor)
+	if err != nil {
+		return err
+	}
+	w.publish(append([]byte{FloorMode}, bytes...), w.floorListeners)
+	return nil
+}
+
 func (w *WebSocketServer) MessageCallback(vm VM) pubsub.Callback {
 	// Assumes controller is initialized before this is called
 	var (
@@ -153,10 +371,39 @@ func (w *WebSocketServer) MessageCallback(vm VM) pubsub.Callback {
 		// implementations
 		switch msgBytes[0] {
 		case BlockMode:
-			w.blockListeners.Add(c)
+			if err := w.AddBlockListener(c); err != nil {
+				log.Debug("rejected block listener", zap.Error(err))
+				w.reject(c, BlockMode, err)
+				return
+			}
 			log.Debug("added block listener")
+		case FloorMode:
+			if err := w.AddFloorListener(c); err != nil {
+				log.Debug("rejected floor listener", zap.Error(err))
+				w.reject(c, FloorMode, err)
+				return
+			}
+			log.Debug("added floor listener")
+		case StateDiffMode:
+			start, err := UnpackStateDiffRequest(msgBytes[1:])
+			if err != nil {
+				log.Debug("invalid state diff request", zap.Error(err))
+				w.reject(c, StateDiffMode, err)
+				return
+			}
+			if err := w.AddStateDiffListener(c, start); err != nil {
+				log.Debug("rejected state diff listener", zap.Error(err))
+				w.reject(c, StateDiffMode, err)
+				return
+			}
+			log.Debug("added state diff listener", zap.Uint64("start", start))
 		case TxMode:
 			msgBytes = msgBytes[1:]
+			if err := chain.VerifyTxSize(vm.Rules(time.Now().UnixMilli()), len(msgBytes)); err != nil {
+				log.Debug("rejected tx", zap.Error(err))
+				w.reject(c, TxMode, err)
+				return
+			}
 			// Unmarshal TX
 			p := codec.NewReader(msgBytes, consts.NetworkSizeLimit) // will likely be much smaller
 			tx, err := chain.UnmarshalTx(p, actionRegistry, authRegistry)
@@ -178,17 +425,29 @@ func (w *WebSocketServer) MessageCallback(vm VM) pubsub.Callback {
 					return
 				}
 			}
-			w.AddTxListener(tx, c)
-
 			// Submit will remove from [txWaiters] if it is not added
 			txID := tx.ID()
-			if err := vm.Submit(ctx, false, []*chain.Transaction{tx})[0]; err != nil {
+			if err := w.AddTxListener(tx, c); err != nil {
+				log.Debug("rejected tx listener",
+					zap.Stringer("txID", txID),
+					zap.Error(err),
+				)
+				bytes, err := PackRemovedTxMessage(txID, err)
+				if err != nil {
+					return
+				}
+				c.Send(append([]byte{TxMode}, bytes...))
+				return
+			}
+			txs := []*chain.Transaction{tx}
+			if err := vm.Submit(ctx, false, txs)[0]; err != nil {
 				log.Error("failed to submit tx",
 					zap.Stringer("txID", txID),
 					zap.Error(err),
 				)
 				return
 			}
+			vm.ForwardTxs(txs)
 			log.Debug("submitted tx", zap.Stringer("id", txID))
 		default:
 			log.Error("unexpected message type",
@@ -198,3 +457,13 @@ func (w *WebSocketServer) MessageCallback(vm VM) pubsub.Callback {
 		}
 	}
 }
+
+// reject notifies [c] that its request with [mode] was not processed.
+func (w *WebSocketServer) reject(c *pubsub.Connection, mode byte, err error) {
+	bytes, err := PackErrorMessage(mode, err)
+	if err != nil {
+		w.logger.Warn("unable to pack error message", zap.Error(err))
+		return
+	}
+	c.Send(append([]byte{ErrorMode}, bytes...))
+}
@@ -0,0 +1,229 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throughput
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+type Dimension int
+
+const (
+	Txs Dimension = iota
+	Units
+	Bytes
+	Blocks   // only recorded by [Tracker.AddBlock]
+	MaxUnits // only recorded by [Tracker.AddBlock]
+
+	dimensions
+)
+
+type counts [dimensions]uint64
+
+// Tracker records per-interval counts of [Txs], [Units], and [Bytes] (and,
+// for blocks, [Blocks] and [MaxUnits]) over a sliding window of [size]
+// intervals.
+//
+// Unlike [window.Window], [Tracker] is not serialized into blocks and is
+// intended for local observations (like fee suggestions and dashboards).
+type Tracker struct {
+	l sync.Mutex
+
+	interval int64 // ms
+	buckets  []counts
+
+	head    int   // index of current interval in [buckets]
+	current int64 // current interval number (t / interval)
+	filled  int   // number of intervals observed (capped at len(buckets))
+}
+
+// New creates a [Tracker] that tracks [size] intervals of [interval]
+// milliseconds.
+func New(interval int64, size int) *Tracker {
+	return &Tracker{
+		interval: interval,
+		buckets:  make([]counts, size),
+	}
+}
+
+// roll advances [head] to the interval containing [t]. Observations older
+// than the current interval are attributed to the current interval.
+//
+// Assumes [l] is held.
+func (t *Tracker) roll(now int64) {
+	next := now / t.interval
+	if t.filled == 0 {
+		t.current = next
+		t.filled = 1
+		return
+	}
+	if next <= t.current {
+		return
+	}
+	size := len(t.buckets)
+	steps := int(smath.Min(next-t.current, int64(size)))
+	for i := 0; i < steps; i++ {
+		t.head = (t.head + 1) % size
+		t.buckets[t.head] = counts{}
+	}
+	t.filled = smath.Min(t.filled+steps, size)
+	t.current = next
+}
+
+// Add records [txs], [units], and [bytes] at time [now] (in ms).
+func (t *Tracker) Add(now int64, txs uint64, units uint64, bytes uint64) {
+	t.add(now, counts{Txs: txs, Units: units, Bytes: bytes})
+}
+
+// AddBlock records a block containing [txs] that consumed [units] (of
+// [maxUnits]) and is [bytes] long at time [now] (in ms).
+func (t *Tracker) AddBlock(now int64, txs uint64, units uint64, bytes uint64, maxUnits uint64) {
+	t.add(now, counts{Txs: txs, Units: units, Bytes: bytes, Blocks: 1, MaxUnits: maxUnits})
+}
+
+func (t *Tracker) add(now int64, obs counts) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	t.roll(now)
+	c := &t.buckets[t.head]
+	for d, v := range obs {
+		sum, err := smath.Add64(c[d], v)
+		if err != nil {
+			sum = consts.MaxUint64
+		}
+		c[d] = sum
+	}
+}
+
+// values returns the counts of [d] for all observed intervals (oldest first).
+//
+// Assumes [l] is held.
+func (t *Tracker) values(d Dimension) []uint64 {
+	size := len(t.buckets)
+	vals := make([]uint64, t.filled)
+	for i := 0; i < t.filled; i++ {
+		vals[t.filled-1-i] = t.buckets[(t.head-i+size)%size][d]
+	}
+	return vals
+}
+
+// sum returns the total of [d] over all observed intervals.
+//
+// Assumes [l] is held.
+func (t *Tracker) sum(d Dimension) uint64 {
+	var sum uint64
+	for _, v := range t.values(d) {
+		next, err := smath.Add64(sum, v)
+		if err != nil {
+			return consts.MaxUint64
+		}
+		sum = next
+	}
+	return sum
+}
+
+// Sum returns the total of [d] over all observed intervals in the window
+// ending at [now].
+func (t *Tracker) Sum(now int64, d Dimension) uint64 {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	if t.filled == 0 {
+		return 0
+	}
+	t.roll(now)
+	return t.sum(d)
+}
+
+// Rate returns the average of [d] per second over all observed intervals in
+// the window ending at [now].
+func (t *Tracker) Rate(now int64, d Dimension) float64 {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	if t.filled == 0 {
+		return 0
+	}
+	t.roll(now)
+	return t.rate(d)
+}
+
+// rate returns the average of [d] per second over all observed intervals.
+//
+// Assumes [l] is held and [filled] > 0.
+func (t *Tracker) rate(d Dimension) float64 {
+	seconds := float64(int64(t.filled)*t.interval) / float64(consts.MillisecondsPerSecond)
+	return float64(t.sum(d)) / seconds
+}
+
+// Summary describes the throughput observed by a [Tracker] over its window.
+type Summary struct {
+	// Window is the duration (in ms) the summary covers. This is less than
+	// the size of the window until the [Tracker] has observed all of it.
+	Window int64 `json:"window"`
+
+	Blocks         uint64  `json:"blocks"`
+	TPS            float64 `json:"tps"`
+	UnitsPerSecond float64 `json:"unitsPerSecond"`
+	BytesPerSecond float64 `json:"bytesPerSecond"`
+
+	// Fullness is the fraction of [MaxUnits] consumed by the blocks in the
+	// window (0 if no blocks were recorded with [Tracker.AddBlock]).
+	Fullness float64 `json:"fullness"`
+}
+
+// Summary returns the throughput observed in the window ending at [now].
+func (t *Tracker) Summary(now int64) *Summary {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	if t.filled == 0 {
+		return &Summary{}
+	}
+	t.roll(now)
+	s := &Summary{
+		Window:         int64(t.filled) * t.interval,
+		Blocks:         t.sum(Blocks),
+		TPS:            t.rate(Txs),
+		UnitsPerSecond: t.rate(Units),
+		BytesPerSecond: t.rate(Bytes),
+	}
+	if maxUnits := t.sum(MaxUnits); maxUnits > 0 {
+		s.Fullness = float64(t.sum(Units)) / float64(maxUnits)
+	}
+	return s
+}
+
+// Percentile returns the [p]th percentile (0 < [p] <= 1) of the per-interval
+// counts of [d] in the window ending at [now], using the nearest-rank method.
+// Intervals where nothing was recorded count as 0.
+func (t *Tracker) Percentile(now int64, d Dimension, p float64) uint64 {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	if t.filled == 0 || p <= 0 {
+		return 0
+	}
+	t.roll(now)
+	vals := t.values(d)
+	sort.Slice(vals, func(i, j int) bool { return vals[i] < vals[j] })
+	rank := int(math.Ceil(p*float64(len(vals)))) - 1
+	rank = smath.Max(0, smath.Min(rank, len(vals)-1))
+	return vals[rank]
+}
+
+// Intervals returns the number of intervals observed in the window (capped at
+// the window size).
+func (t *Tracker) Intervals() int {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	return t.filled
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throughput
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackerEmpty(t *testing.T) {
+	require := require.New(t)
+
+	tr := New(1_000, 10)
+	require.Zero(tr.Sum(5_000, Txs))
+	require.Zero(tr.Rate(5_000, Txs))
+	require.Zero(tr.Percentile(5_000, Txs, 0.5))
+	require.Zero(tr.Intervals())
+}
+
+func TestTrackerRoll(t *testing.T) {
+	require := require.New(t)
+
+	tr := New(1_000, 3)
+	tr.Add(1_000, 1, 10, 100)
+	tr.Add(1_500, 1, 10, 100)
+	tr.Add(2_000, 2, 20, 200)
+	require.Equal(2, tr.Intervals())
+	require.Equal(uint64(4), tr.Sum(2_000, Txs))
+	require.Equal(uint64(40), tr.Sum(2_000, Units))
+	require.Equal(uint64(400), tr.Sum(2_000, Bytes))
+	require.Equal(2.0, tr.Rate(2_000, Txs))
+
+	// Older observations are attributed to the current interval
+	tr.Add(500, 1, 0, 0)
+	require.Equal(uint64(5), tr.Sum(2_000, Txs))
+
+	// First interval falls out of the window
+	tr.Add(4_000, 4, 0, 0)
+	require.Equal(3, tr.Intervals())
+	require.Equal(uint64(7), tr.Sum(4_000, Txs))
+
+	// All intervals fall out of the window
+	require.Zero(tr.Sum(10_000, Txs))
+	require.Equal(3, tr.Intervals())
+}
+
+func TestTrackerPercentile(t *testing.T) {
+	require := require.New(t)
+
+	tr := New(1_000, 10)
+	for i := int64(0); i < 10; i++ {
+		tr.Add(i*1_000, uint64(i+1), 0, 0)
+	}
+	require.Equal(uint64(1), tr.Percentile(9_000, Txs, 0.01))
+	require.Equal(uint64(5), tr.Percentile(9_000, Txs, 0.5))
+	require.Equal(uint64(9), tr.Percentile(9_000, Txs, 0.9))
+	require.Equal(uint64(10), tr.Percentile(9_000, Txs, 1))
+
+	// Empty intervals count as 0
+	require.Equal(uint64(0), tr.Percentile(14_000, Txs, 0.5))
+	require.Equal(uint64(10), tr.Percentile(14_000, Txs, 1))
+}
+
+func TestTrackerSummary(t *testing.T) {
+	require := require.New(t)
+
+	tr := New(1_000, 10)
+	require.Equal(&Summary{}, tr.Summary(1_000))
+
+	tr.AddBlock(1_000, 2, 50, 100, 100)
+	tr.AddBlock(1_500, 2, 25, 100, 100)
+	tr.AddBlock(2_000, 4, 75, 200, 100)
+	require.Equal(&Summary{
+		Window:         2_000,
+		Blocks:         3,
+		TPS:            4,
+		UnitsPerSecond: 75,
+		BytesPerSecond: 200,
+		Fullness:       0.5,
+	}, tr.Summary(2_000))
+}
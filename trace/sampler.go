@@ -0,0 +1,76 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+var _ sdktrace.Sampler = (*familySampler)(nil)
+
+// familySampler samples each span at the rate configured for its family,
+// falling back to [defaultSampler] if no rate is configured.
+//
+// The family of a span is found by trimming "."-separated suffixes from its
+// name until a configured family is found. For example, "Mempool.Has" is
+// sampled using the rate for "Mempool.Has" if set, otherwise the rate for
+// "Mempool".
+//
+// Each sampler is [sdktrace.TraceIDRatioBased], so all spans of a sampled
+// trace with a rate of at least that of the root span are also sampled.
+type familySampler struct {
+	defaultSampler sdktrace.Sampler
+	samplers       map[string]sdktrace.Sampler
+	description    string
+}
+
+func newFamilySampler(defaultRate float64, rates map[string]float64) sdktrace.Sampler {
+	defaultSampler := sdktrace.TraceIDRatioBased(defaultRate)
+	if len(rates) == 0 {
+		return defaultSampler
+	}
+	var (
+		samplers = make(map[string]sdktrace.Sampler, len(rates))
+		parts    = make([]string, 0, len(rates))
+	)
+	for family, rate := range rates {
+		samplers[family] = sdktrace.TraceIDRatioBased(rate)
+		parts = append(parts, fmt.Sprintf("%s=%g", family, rate))
+	}
+	sort.Strings(parts)
+	return &familySampler{
+		defaultSampler: defaultSampler,
+		samplers:       samplers,
+		description: fmt.Sprintf(
+			"FamilySampler{default=%g,%s}",
+			defaultRate,
+			strings.Join(parts, ","),
+		),
+	}
+}
+
+func (s *familySampler) sampler(name string) sdktrace.Sampler {
+	for {
+		if sampler, ok := s.samplers[name]; ok {
+			return sampler
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return s.defaultSampler
+		}
+		name = name[:i]
+	}
+}
+
+func (s *familySampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return s.sampler(p.Name).ShouldSample(p)
+}
+
+func (s *familySampler) Description() string {
+	return s.description
+}
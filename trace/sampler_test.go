@@ -0,0 +1,43 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestFamilySampler(t *testing.T) {
+	require := require.New(t)
+
+	sampler := newFamilySampler(1, map[string]float64{
+		"Mempool":       0,
+		"Mempool.Build": 1,
+	})
+	require.Equal("FamilySampler{default=1,Mempool.Build=1,Mempool=0}", sampler.Description())
+
+	traceID := oteltrace.TraceID{1}
+	for name, decision := range map[string]sdktrace.SamplingDecision{
+		"Mempool.Has":           sdktrace.Drop,
+		"Mempool":               sdktrace.Drop,
+		"Mempool.Build":         sdktrace.RecordAndSample,
+		"Mempool.BuildSkipping": sdktrace.Drop,
+		"VM.BuildBlock":         sdktrace.RecordAndSample,
+		"MempoolX":              sdktrace.RecordAndSample,
+	} {
+		result := sampler.ShouldSample(sdktrace.SamplingParameters{
+			ParentContext: context.Background(),
+			TraceID:       traceID,
+			Name:          name,
+		})
+		require.Equal(decision, result.Decision, name)
+	}
+
+	// Without overrides, the default sampler is used directly
+	require.Equal(sdktrace.TraceIDRatioBased(0.5).Description(), newFamilySampler(0.5, nil).Description())
+}
@@ -33,6 +33,11 @@ type Config struct {
 	// If <= 0 never samples.
 	TraceSampleRate float64 `json:"traceSampleRate"`
 
+	// The fraction of traces to sample for each span family (e.g.
+	// "Mempool.Has" or "Mempool"), overriding [TraceSampleRate]. The most
+	// specific family of each span is used.
+	TraceSampleRates map[string]float64 `json:"traceSampleRates"`
+
 	AppName string `json:"appName"`
 	Agent   string `json:"agent"`
 	Version string `json:"version"`
@@ -73,7 +78,7 @@ func New(config *Config) (trace.Tracer, error) {
 				semconv.ServiceNameKey.String(config.Agent),
 			),
 		),
-		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(config.TraceSampleRate)),
+		sdktrace.WithSampler(newFamilySampler(config.TraceSampleRate, config.TraceSampleRates)),
 	}
 
 	tracerProvider := sdktrace.NewTracerProvider(tracerProviderOpts...)
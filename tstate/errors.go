@@ -5,4 +5,7 @@ package tstate
 
 import "errors"
 
-var ErrKeyNotSpecified = errors.New("key not specified")
+var (
+	ErrKeyNotSpecified = errors.New("key not specified")
+	ErrKeyReadOnly     = errors.New("key is read-only")
+)
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"sort"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/trace"
@@ -43,6 +44,10 @@ type TState struct {
 	scope        [][]byte // stores a list of managed keys in the TState struct
 	scopeStorage map[string][]byte
 
+	// readOnly stores the keys in scope that can't be modified (see
+	// [SetReadOnly]).
+	readOnly map[string]struct{}
+
 	// Ops is a record of all operations performed on [TState]. Tracking
 	// operations allows for reverting state to a certain point-in-time.
 	ops []*op
@@ -123,6 +128,30 @@ func (ts *TState) SetScope(_ context.Context, keys [][]byte, storage map[string]
 	ts.scopeStorage = storage
 }
 
+// SetReadOnly prevents [keys] from being modified until SetReadOnly is called
+// again (passing nil allows all keys in scope to be modified).
+func (ts *TState) SetReadOnly(_ context.Context, keys [][]byte) {
+	if len(keys) == 0 {
+		ts.readOnly = nil
+		return
+	}
+	ts.readOnly = make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		ts.readOnly[string(k)] = struct{}{}
+	}
+}
+
+// checkWrite returns an error if [k] can't be modified.
+func (ts *TState) checkWrite(ctx context.Context, k []byte) error {
+	if !ts.checkScope(ctx, k) {
+		return ErrKeyNotSpecified
+	}
+	if _, ok := ts.readOnly[string(k)]; ok {
+		return ErrKeyReadOnly
+	}
+	return nil
+}
+
 // checkScope returns whether [k] is in ts.readScope.
 func (ts *TState) checkScope(_ context.Context, k []byte) bool {
 	for _, s := range ts.scope {
@@ -136,8 +165,8 @@ func (ts *TState) checkScope(_ context.Context, k []byte) bool {
 
 // Insert sets or updates ts.storage[key] to equal {value, false}.
 func (ts *TState) Insert(ctx context.Context, key []byte, value []byte) error {
-	if !ts.checkScope(ctx, key) {
-		return ErrKeyNotSpecified
+	if err := ts.checkWrite(ctx, key); err != nil {
+		return err
 	}
 	k := string(key)
 	past, changed, exists := ts.getValue(ctx, k)
@@ -153,8 +182,8 @@ func (ts *TState) Insert(ctx context.Context, key []byte, value []byte) error {
 
 // Renove deletes a key-value pair from ts.storage.
 func (ts *TState) Remove(ctx context.Context, key []byte) error {
-	if !ts.checkScope(ctx, key) {
-		return ErrKeyNotSpecified
+	if err := ts.checkWrite(ctx, key); err != nil {
+		return err
 	}
 	k := string(key)
 	past, changed, exists := ts.getValue(ctx, k)
@@ -176,10 +205,97 @@ func (ts *TState) OpIndex() int {
 	return len(ts.ops)
 }
 
+// KeyUsage returns the number of keys created ([allocated]) and the number
+// of existing keys modified or removed ([written]) by the operations done on
+// ts since [since] (i.e. [OpIndex] at some earlier point).
+func (ts *TState) KeyUsage(since int) (allocated int, written int) {
+	seen := map[string]struct{}{}
+	for _, op := range ts.ops[since:] {
+		if _, ok := seen[op.k]; ok {
+			continue
+		}
+		seen[op.k] = struct{}{}
+		tstorage, ok := ts.changedKeys[op.k]
+		switch {
+		case !ok:
+		case !op.pastExists && tstorage.removed:
+		case !op.pastExists:
+			allocated++
+		default:
+			written++
+		}
+	}
+	return allocated, written
+}
+
 func (ts *TState) PendingChanges() int {
 	return len(ts.changedKeys)
 }
 
+// Value is the value of a key in a [TState]. [Exists] is false if the key was
+// removed.
+type Value struct {
+	V      []byte
+	Exists bool
+}
+
+// ChangedValues returns the current value of each key modified by ts. This
+// can be used to apply the changes of a [TState] that was used to execute a
+// single transaction to another [TState].
+func (ts *TState) ChangedValues() map[string]Value {
+	values := make(map[string]Value, len(ts.changedKeys))
+	for k, tstorage := range ts.changedKeys {
+		values[k] = Value{V: tstorage.v, Exists: !tstorage.removed}
+	}
+	return values
+}
+
+// Change is the net effect of a [TState] on a single key. [Old] is nil if the
+// key did not exist before and [New] is nil if it was removed.
+type Change struct {
+	Key []byte `json:"key"`
+	Old []byte `json:"old"`
+	New []byte `json:"new"`
+}
+
+// Changes returns the net change made to each key modified by ts (ordered by
+// key). Keys that were modified but ended up with their original value are
+// omitted.
+func (ts *TState) Changes() []*Change {
+	changes := make([]*Change, 0, len(ts.changedKeys))
+	seen := make(map[string]struct{}, len(ts.changedKeys))
+	for _, op := range ts.ops {
+		// The first op on a key (that was not rolled back) records the value
+		// it had before ts was created
+		if op.pastChanged {
+			continue
+		}
+		if _, ok := seen[op.k]; ok {
+			continue
+		}
+		seen[op.k] = struct{}{}
+		tstorage, ok := ts.changedKeys[op.k]
+		if !ok {
+			continue
+		}
+		var before, after []byte
+		if op.pastExists {
+			before = op.pastV
+		}
+		if !tstorage.removed {
+			after = tstorage.v
+		}
+		if op.pastExists == !tstorage.removed && bytes.Equal(before, after) {
+			continue
+		}
+		changes = append(changes, &Change{Key: []byte(op.k), Old: before, New: after})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return bytes.Compare(changes[i].Key, changes[j].Key) < 0
+	})
+	return changes
+}
+
 // Rollback restores the TState to before the ts.op[restorePoint] operation.
 func (ts *TState) Rollback(_ context.Context, restorePoint int) {
 	for i := len(ts.ops) - 1; i >= restorePoint; i-- {
@@ -212,6 +212,30 @@ func TestRemoveNotInScope(t *testing.T) {
 	require.ErrorIs(err, ErrKeyNotSpecified, "ErrKeyNotSpecified should be thrown.")
 }
 
+func TestReadOnly(t *testing.T) {
+	require := require.New(t)
+	ts := New(10)
+	ctx := context.TODO()
+	otherKey := []byte("other")
+	ts.SetScope(ctx, [][]byte{TestKey, otherKey}, map[string][]byte{string(TestKey): TestVal})
+	ts.SetReadOnly(ctx, [][]byte{TestKey})
+
+	// Read-only keys can be read but not modified
+	val, err := ts.GetValue(ctx, TestKey)
+	require.NoError(err)
+	require.Equal(TestVal, val)
+	require.ErrorIs(ts.Insert(ctx, TestKey, []byte("newVal")), ErrKeyReadOnly)
+	require.ErrorIs(ts.Remove(ctx, TestKey), ErrKeyReadOnly)
+	require.NoError(ts.Insert(ctx, otherKey, TestVal))
+	require.Equal(1, ts.OpIndex())
+
+	// Clearing the read-only keys allows them to be modified again
+	ts.SetReadOnly(ctx, nil)
+	require.NoError(ts.Remove(ctx, TestKey))
+	_, err = ts.GetValue(ctx, TestKey)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
 func TestRestoreInsert(t *testing.T) {
 	require := require.New(t)
 	ts := New(10)
@@ -324,3 +348,81 @@ func TestWriteChanges(t *testing.T) {
 		require.ErrorIs(err, database.ErrNotFound, "Value not removed from db.")
 	}
 }
+
+func TestChanges(t *testing.T) {
+	require := require.New(t)
+	ts := New(10)
+	ctx := context.TODO()
+	keys := [][]byte{[]byte("key1"), []byte("key2"), []byte("key3"), []byte("key4")}
+	ts.SetScope(ctx, keys, map[string][]byte{
+		string(keys[0]): []byte("old1"),
+		string(keys[1]): []byte("old2"),
+		string(keys[2]): []byte("old3"),
+	})
+	require.NoError(ts.Insert(ctx, keys[0], []byte("new1")))
+	require.NoError(ts.Insert(ctx, keys[0], []byte("newer1")))
+	require.NoError(ts.Remove(ctx, keys[1]))
+	require.NoError(ts.Insert(ctx, keys[3], []byte("new4")))
+
+	// Net no-op
+	require.NoError(ts.Remove(ctx, keys[2]))
+	require.NoError(ts.Insert(ctx, keys[2], []byte("old3")))
+
+	// Rolled back
+	restore := ts.OpIndex()
+	require.NoError(ts.Insert(ctx, keys[1], []byte("new2")))
+	ts.Rollback(ctx, restore)
+
+	require.Equal([]*Change{
+		{Key: keys[0], Old: []byte("old1"), New: []byte("newer1")},
+		{Key: keys[1], Old: []byte("old2")},
+		{Key: keys[3], New: []byte("new4")},
+	}, ts.Changes())
+}
+
+func TestChangedValues(t *testing.T) {
+	require := require.New(t)
+	ts := New(10)
+	ctx := context.TODO()
+	keys := [][]byte{[]byte("key1"), []byte("key2"), []byte("key3")}
+	ts.SetScope(ctx, keys, map[string][]byte{
+		string(keys[0]): []byte("old1"),
+		string(keys[1]): []byte("old2"),
+	})
+	require.NoError(ts.Insert(ctx, keys[0], []byte("new1")))
+	require.NoError(ts.Remove(ctx, keys[1]))
+	require.NoError(ts.Insert(ctx, keys[2], nil))
+
+	require.Equal(map[string]Value{
+		string(keys[0]): {V: []byte("new1"), Exists: true},
+		string(keys[1]): {},
+		string(keys[2]): {Exists: true},
+	}, ts.ChangedValues())
+}
+
+func TestKeyUsage(t *testing.T) {
+	require := require.New(t)
+	ts := New(10)
+	ctx := context.TODO()
+	keys := [][]byte{[]byte("key1"), []byte("key2"), []byte("key3"), []byte("key4")}
+	ts.SetScope(ctx, keys, map[string][]byte{
+		string(keys[0]): []byte("old1"),
+		string(keys[1]): []byte("old2"),
+	})
+	require.NoError(ts.Insert(ctx, keys[0], []byte("new1")))
+	since := ts.OpIndex()
+	require.NoError(ts.Insert(ctx, keys[0], []byte("newer1")))
+	require.NoError(ts.Remove(ctx, keys[1]))
+	require.NoError(ts.Insert(ctx, keys[2], []byte("new3")))
+	require.NoError(ts.Insert(ctx, keys[2], []byte("newer3")))
+	require.NoError(ts.Insert(ctx, keys[3], []byte("new4")))
+	require.NoError(ts.Remove(ctx, keys[3]))
+
+	allocated, written := ts.KeyUsage(since)
+	require.Equal(1, allocated)
+	require.Equal(2, written)
+
+	allocated, written = ts.KeyUsage(ts.OpIndex())
+	require.Zero(allocated)
+	require.Zero(written)
+}
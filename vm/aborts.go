@@ -0,0 +1,119 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"go.uber.org/zap"
+)
+
+// inflight is a block that is being built or verified.
+type inflight struct {
+	blk    ids.ID // empty when building
+	parent ids.ID
+	cancel context.CancelFunc
+}
+
+// inflightWork tracks the blocks that are being built or verified so that
+// their work can be aborted once it is no longer needed.
+type inflightWork struct {
+	l    sync.Mutex
+	next uint64
+	work map[uint64]*inflight
+}
+
+func (w *inflightWork) start(ctx context.Context, blk ids.ID, parent ids.ID) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	if w.work == nil {
+		w.work = map[uint64]*inflight{}
+	}
+	id := w.next
+	w.next++
+	w.work[id] = &inflight{blk: blk, parent: parent, cancel: cancel}
+	return ctx, func() {
+		w.l.Lock()
+		delete(w.work, id)
+		w.l.Unlock()
+		cancel()
+	}
+}
+
+// abort cancels all work that [f] returns true for and returns how much work
+// was cancelled.
+func (w *inflightWork) abort(f func(*inflight) bool) int {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	aborted := 0
+	for id, work := range w.work {
+		if !f(work) {
+			continue
+		}
+		work.cancel()
+		delete(w.work, id)
+		aborted++
+	}
+	return aborted
+}
+
+func (vm *VM) BuildContext(ctx context.Context, parent ids.ID) (context.Context, func()) {
+	return vm.builds.start(ctx, ids.Empty, parent)
+}
+
+func (vm *VM) VerifyContext(ctx context.Context, blk ids.ID, parent ids.ID) (context.Context, func()) {
+	return vm.verifies.start(ctx, blk, parent)
+}
+
+func (vm *VM) RecordBuildAborted(saved time.Duration) {
+	vm.metrics.buildsAborted.Inc()
+	vm.metrics.abortSaved.Observe(float64(saved))
+}
+
+func (vm *VM) RecordVerifyAborted(saved time.Duration) {
+	vm.metrics.verifiesAborted.Inc()
+	vm.metrics.abortSaved.Observe(float64(saved))
+}
+
+// abortSuperseded aborts building any block that isn't built on [preferred]
+// (the block would never be issued).
+func (vm *VM) abortSuperseded(preferred ids.ID) {
+	if aborted := vm.builds.abort(func(w *inflight) bool {
+		return w.parent != preferred
+	}); aborted > 0 {
+		vm.snowCtx.Log.Debug(
+			"aborted superseded block building",
+			zap.Stringer("preferred", preferred),
+			zap.Int("aborted", aborted),
+		)
+	}
+}
+
+// abortRejected aborts verifying [blkID] and its children (they can never be
+// accepted).
+func (vm *VM) abortRejected(blkID ids.ID) {
+	if aborted := vm.verifies.abort(func(w *inflight) bool {
+		return w.blk == blkID || w.parent == blkID
+	}); aborted > 0 {
+		vm.snowCtx.Log.Debug(
+			"aborted verification of rejected block",
+			zap.Stringer("blkID", blkID),
+			zap.Int("aborted", aborted),
+		)
+	}
+}
+
+// abortAll aborts all building and verification (called during shutdown).
+func (vm *VM) abortAll() {
+	all := func(*inflight) bool { return true }
+	vm.builds.abort(all)
+	vm.verifies.abort(all)
+}
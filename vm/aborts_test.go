@@ -0,0 +1,45 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInflightWork(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		w       inflightWork
+		parent  = ids.GenerateTestID()
+		other   = ids.GenerateTestID()
+		blk     = ids.GenerateTestID()
+		child   = ids.GenerateTestID()
+		unknown = ids.GenerateTestID()
+	)
+	blkCtx, blkDone := w.start(context.Background(), blk, parent)
+	childCtx, childDone := w.start(context.Background(), child, blk)
+	otherCtx, otherDone := w.start(context.Background(), ids.Empty, other)
+
+	// Nothing matches
+	require.Zero(w.abort(func(f *inflight) bool { return f.blk == unknown }))
+	require.NoError(blkCtx.Err())
+
+	// A block and its children are aborted
+	require.Equal(2, w.abort(func(f *inflight) bool { return f.blk == blk || f.parent == blk }))
+	require.ErrorIs(blkCtx.Err(), context.Canceled)
+	require.ErrorIs(childCtx.Err(), context.Canceled)
+	require.NoError(otherCtx.Err())
+
+	// Finished work is no longer tracked
+	blkDone()
+	childDone()
+	otherDone()
+	require.ErrorIs(otherCtx.Err(), context.Canceled)
+	require.Zero(w.abort(func(*inflight) bool { return true }))
+}
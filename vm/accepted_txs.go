@@ -0,0 +1,35 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+// acceptedTxsCacheSize is the number of recently accepted txs whose height we
+// remember (so that resubmitting them can be answered without an index).
+const acceptedTxsCacheSize = 65_536
+
+// AcceptedTxHeight returns the height of the block that included [txID] (if
+// it was recently accepted).
+func (vm *VM) AcceptedTxHeight(txID ids.ID) (uint64, bool) {
+	return vm.acceptedTxs.Get(txID)
+}
+
+// MempoolHas returns true if [txID] is in the mempool.
+func (vm *VM) MempoolHas(ctx context.Context, txID ids.ID) bool {
+	return vm.mempool.MaybeHas(txID) && vm.mempool.Has(ctx, txID)
+}
+
+// recordAcceptedTxs remembers the height each of the txs in [b] was accepted
+// at.
+func (vm *VM) recordAcceptedTxs(b *chain.StatelessBlock) {
+	for _, tx := range b.Txs {
+		vm.acceptedTxs.Put(tx.ID(), b.Hght)
+	}
+}
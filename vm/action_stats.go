@@ -0,0 +1,98 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"encoding/binary"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+const (
+	// maxActionStatsBlocks limits the number of blocks that can be aggregated
+	// by a single call to [ActionStats] (~1 day of 1s blocks).
+	maxActionStatsBlocks = 100_000
+
+	// maxActionStatsBuckets limits the number of buckets that can be returned
+	// by a single call to [ActionStats].
+	maxActionStatsBuckets = 1_024
+)
+
+// PrefixActionStatsKey sorts action stats by block timestamp (and then by
+// height, because multiple blocks can have the same timestamp).
+func PrefixActionStatsKey(timestamp int64, height uint64) []byte {
+	k := make([]byte, 1+consts.Uint64Len*2)
+	k[0] = actionStatsPrefix
+	binary.BigEndian.PutUint64(k[1:], uint64(timestamp))
+	binary.BigEndian.PutUint64(k[1+consts.Uint64Len:], height)
+	return k
+}
+
+// storeActionStats persists the number of transactions (and units consumed)
+// of each action type in [blk].
+func (vm *VM) storeActionStats(blk *chain.StatelessBlock) error {
+	stats := chain.BlockActionStats(blk, vm.actionRegistry)
+	b, err := chain.MarshalActionStats(stats)
+	if err != nil {
+		return err
+	}
+	return vm.vmDB.Put(PrefixActionStatsKey(blk.Tmstmp, blk.Hght), b)
+}
+
+// ActionStats aggregates the action stats of all blocks accepted in
+// [start, end) into buckets of [interval] ms (a single bucket if [interval] is
+// 0).
+func (vm *VM) ActionStats(start int64, end int64, interval int64) ([]*chain.ActionStatsBucket, error) {
+	if start < 0 || end <= start || interval < 0 {
+		return nil, ErrInvalidRange
+	}
+	if interval == 0 {
+		interval = end - start
+	}
+	count := (end - start + interval - 1) / interval
+	if count > maxActionStatsBuckets {
+		return nil, ErrRangeTooLarge
+	}
+	buckets := make([]*chain.ActionStatsBucket, count)
+	for i := range buckets {
+		buckets[i] = &chain.ActionStatsBucket{
+			Start:   start + int64(i)*interval,
+			Actions: map[uint8]*chain.ActionStats{},
+		}
+	}
+
+	iter := vm.vmDB.NewIteratorWithStartAndPrefix(
+		PrefixActionStatsKey(start, 0),
+		[]byte{actionStatsPrefix},
+	)
+	defer iter.Release()
+	blocks := 0
+	for iter.Next() {
+		k := iter.Key()
+		timestamp := int64(binary.BigEndian.Uint64(k[1:]))
+		if timestamp >= end {
+			break
+		}
+		blocks++
+		if blocks > maxActionStatsBlocks {
+			return nil, ErrRangeTooLarge
+		}
+		stats, err := chain.UnmarshalActionStats(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		bucket := buckets[(timestamp-start)/interval]
+		bucket.Blocks++
+		for typeID, s := range stats {
+			agg, ok := bucket.Actions[typeID]
+			if !ok {
+				agg = &chain.ActionStats{}
+				bucket.Actions[typeID] = agg
+			}
+			agg.Add(s)
+		}
+	}
+	return buckets, iter.Error()
+}
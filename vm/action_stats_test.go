@@ -0,0 +1,51 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+func TestActionStats(t *testing.T) {
+	require := require.New(t)
+	vm := &VM{vmDB: memdb.New()}
+
+	put := func(timestamp int64, height uint64, stats map[uint8]*chain.ActionStats) {
+		b, err := chain.MarshalActionStats(stats)
+		require.NoError(err)
+		require.NoError(vm.vmDB.Put(PrefixActionStatsKey(timestamp, height), b))
+	}
+	put(900, 1, map[uint8]*chain.ActionStats{0: {Count: 100, Success: 100, Units: 100}})
+	put(1_000, 2, map[uint8]*chain.ActionStats{0: {Count: 2, Success: 1, Units: 10}})
+	put(1_000, 3, map[uint8]*chain.ActionStats{0: {Count: 1, Success: 1, Units: 5}, 1: {Count: 1, Units: 7}})
+	put(2_500, 4, map[uint8]*chain.ActionStats{1: {Count: 3, Success: 3, Units: 21}})
+	put(3_000, 5, map[uint8]*chain.ActionStats{0: {Count: 100, Success: 100, Units: 100}})
+
+	buckets, err := vm.ActionStats(1_000, 3_000, 1_000)
+	require.NoError(err)
+	require.Len(buckets, 2)
+	require.Equal(int64(1_000), buckets[0].Start)
+	require.Equal(uint64(2), buckets[0].Blocks)
+	require.Equal(&chain.ActionStats{Count: 3, Success: 2, Units: 15}, buckets[0].Actions[0])
+	require.Equal(&chain.ActionStats{Count: 1, Units: 7}, buckets[0].Actions[1])
+	require.Equal(int64(2_000), buckets[1].Start)
+	require.Equal(uint64(1), buckets[1].Blocks)
+	require.Equal(&chain.ActionStats{Count: 3, Success: 3, Units: 21}, buckets[1].Actions[1])
+
+	// A single bucket is returned without an interval
+	buckets, err = vm.ActionStats(0, 10_000, 0)
+	require.NoError(err)
+	require.Len(buckets, 1)
+	require.Equal(uint64(5), buckets[0].Blocks)
+
+	_, err = vm.ActionStats(1_000, 1_000, 0)
+	require.ErrorIs(err, ErrInvalidRange)
+	_, err = vm.ActionStats(0, maxActionStatsBuckets+1, 1)
+	require.ErrorIs(err, ErrRangeTooLarge)
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"encoding/binary"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// maxAddressBloomBlocks limits the number of blooms that can be returned by a
+// single call to [AddressBlooms].
+const maxAddressBloomBlocks = 10_000
+
+func PrefixAddressBloomKey(height uint64) []byte {
+	k := make([]byte, 1+consts.Uint64Len)
+	k[0] = addressBloomPrefix
+	binary.BigEndian.PutUint64(k[1:], height)
+	return k
+}
+
+// storeAddressBloom persists a bloom filter of all addresses touched by [blk]
+// (it is removed when [blk] is pruned).
+func (vm *VM) storeAddressBloom(blk *chain.StatelessBlock) error {
+	return vm.vmDB.Put(PrefixAddressBloomKey(blk.Hght), chain.BlockAddressBloom(blk))
+}
+
+// AddressBlooms returns the address bloom (and height) of each block in
+// [start, end] that is still stored on disk.
+func (vm *VM) AddressBlooms(start uint64, end uint64) ([]uint64, []chain.AddressBloom, error) {
+	if end < start {
+		return nil, nil, ErrInvalidRange
+	}
+	if end-start >= maxAddressBloomBlocks {
+		return nil, nil, ErrRangeTooLarge
+	}
+	iter := vm.vmDB.NewIteratorWithStartAndPrefix(
+		PrefixAddressBloomKey(start),
+		[]byte{addressBloomPrefix},
+	)
+	defer iter.Release()
+
+	heights := []uint64{}
+	blooms := []chain.AddressBloom{}
+	for iter.Next() {
+		height := binary.BigEndian.Uint64(iter.Key()[1:])
+		if height > end {
+			break
+		}
+		heights = append(heights, height)
+		blooms = append(blooms, iter.Value())
+	}
+	return heights, blooms, iter.Error()
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+func TestAddressBlooms(t *testing.T) {
+	require := require.New(t)
+	vm := &VM{vmDB: memdb.New()}
+
+	addrs := [][]byte{[]byte("alice"), []byte("bob")}
+	for height := uint64(1); height <= 5; height++ {
+		bloom := chain.NewAddressBloom(addrs[:height%2+1])
+		require.NoError(vm.vmDB.Put(PrefixAddressBloomKey(height), bloom))
+	}
+
+	heights, blooms, err := vm.AddressBlooms(2, 4)
+	require.NoError(err)
+	require.Equal([]uint64{2, 3, 4}, heights)
+	for i, bloom := range blooms {
+		require.True(bloom.Contains(addrs[0]))
+		// Only odd heights include [addrs[1]] (false positives are possible
+		// but these blooms are deterministic)
+		require.Equal(heights[i]%2 == 1, bloom.Contains(addrs[1]))
+	}
+
+	// Pruned blocks are skipped
+	require.NoError(vm.DeleteDiskBlocks(1, 2))
+	heights, _, err = vm.AddressBlooms(0, 10)
+	require.NoError(err)
+	require.Equal([]uint64{3, 4, 5}, heights)
+
+	_, _, err = vm.AddressBlooms(4, 2)
+	require.ErrorIs(err, ErrInvalidRange)
+	_, _, err = vm.AddressBlooms(0, maxAddressBloomBlocks)
+	require.ErrorIs(err, ErrRangeTooLarge)
+}
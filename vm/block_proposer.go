@@ -0,0 +1,94 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// builtBlocksCacheSize is the number of blocks built by this node that are
+// remembered until they are accepted (so they can be attributed to it).
+const builtBlocksCacheSize = 128
+
+func PrefixBlockProposerKey(height uint64) []byte {
+	k := make([]byte, 1+consts.Uint64Len)
+	k[0] = blockProposerPrefix
+	binary.BigEndian.PutUint64(k[1:], height)
+	return k
+}
+
+// attributeProposer returns the validator that proposed [blk] (or
+// [ids.EmptyNodeID] if it can't be determined).
+//
+// Blocks built by this node are always attributed to it. Otherwise, because
+// the inner block does not include its proposer, [blk] is attributed to the
+// validator whose proposer window it was built in (using the time elapsed since
+// its parent). Blocks built after all proposer windows have elapsed (when any
+// validator may propose) are not attributed.
+func (vm *VM) attributeProposer(ctx context.Context, blk *chain.StatelessBlock) (ids.NodeID, error) {
+	if _, ok := vm.builtBlocks.Get(blk.ID()); ok {
+		vm.builtBlocks.Evict(blk.ID())
+		return vm.snowCtx.NodeID, nil
+	}
+	parent, err := vm.GetStatelessBlock(ctx, blk.Prnt)
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+	slot := int(time.Duration(blk.Tmstmp-parent.Tmstmp) * time.Millisecond / proposer.WindowDuration)
+	if slot >= proposer.MaxWindows {
+		return ids.EmptyNodeID, nil
+	}
+	pHeight, ok := blk.PChainHeight()
+	if !ok {
+		// Blocks are only provided a context if they must be verified with one,
+		// so we fall back to the current P-Chain height (which only differs if
+		// the validator set changed).
+		pHeight, err = vm.proposerMonitor.PChainHeight(ctx)
+		if err != nil {
+			return ids.EmptyNodeID, err
+		}
+	}
+	nodeID, _, err := vm.proposerMonitor.Proposer(ctx, blk.Hght, pHeight, slot)
+	return nodeID, err
+}
+
+// storeBlockProposer persists the proposer of [blk] (it is removed when [blk]
+// is pruned).
+func (vm *VM) storeBlockProposer(ctx context.Context, blk *chain.StatelessBlock) error {
+	nodeID, err := vm.attributeProposer(ctx, blk)
+	if err != nil {
+		return err
+	}
+	switch nodeID {
+	case ids.EmptyNodeID:
+		vm.metrics.blocksUnattributed.Inc()
+		return nil
+	case vm.snowCtx.NodeID:
+		vm.metrics.blocksProposed.Inc()
+	}
+	return vm.vmDB.Put(PrefixBlockProposerKey(blk.Hght), nodeID.Bytes())
+}
+
+// BlockProposer returns the validator that proposed the block at [height] (or
+// [ids.EmptyNodeID] if it is unknown or the block has been pruned).
+func (vm *VM) BlockProposer(height uint64) (ids.NodeID, error) {
+	v, err := vm.vmDB.Get(PrefixBlockProposerKey(height))
+	if errors.Is(err, database.ErrNotFound) {
+		return ids.EmptyNodeID, nil
+	}
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+	return ids.ToNodeID(v)
+}
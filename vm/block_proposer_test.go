@@ -0,0 +1,35 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockProposer(t *testing.T) {
+	require := require.New(t)
+	vm := &VM{vmDB: memdb.New()}
+
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(vm.vmDB.Put(PrefixBlockProposerKey(1), nodeID.Bytes()))
+
+	proposer, err := vm.BlockProposer(1)
+	require.NoError(err)
+	require.Equal(nodeID, proposer)
+
+	// Unattributed blocks have no proposer
+	proposer, err = vm.BlockProposer(2)
+	require.NoError(err)
+	require.Equal(ids.EmptyNodeID, proposer)
+
+	// Proposers are pruned with their block
+	require.NoError(vm.DeleteDiskBlocks(1, 1))
+	proposer, err = vm.BlockProposer(1)
+	require.NoError(err)
+	require.Equal(ids.EmptyNodeID, proposer)
+}
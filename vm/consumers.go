@@ -0,0 +1,188 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"go.uber.org/zap"
+)
+
+const (
+	MaxConsumerNameLen = 64
+
+	// MaxConsumers limits the number of consumers that can be registered at
+	// once (each may hold back pruning for up to [GetConsumerRetention]
+	// blocks).
+	MaxConsumers = 32
+
+	// maxPrunedPerBlock limits the number of blocks deleted after each accepted
+	// block so that enabling pruning on a long chain doesn't stall the acceptor.
+	maxPrunedPerBlock = 1_024
+)
+
+// loadConsumers populates the in-memory view of registered consumers (and the
+// last pruned height) from disk.
+func (vm *VM) loadConsumers() error {
+	consumers, err := vm.GetDiskConsumers()
+	if err != nil {
+		return err
+	}
+	lastPruned, err := vm.GetDiskLastPruned()
+	if err != nil {
+		return err
+	}
+	vm.consumersL.Lock()
+	defer vm.consumersL.Unlock()
+	vm.consumers = consumers
+	vm.lastPruned = lastPruned
+	return nil
+}
+
+// RegisterConsumer durably records a named consumer (like an external
+// indexer) that has processed all blocks up to and including [height]. Blocks
+// after [height] will not be pruned until the consumer acknowledges them.
+//
+// Consumers that fall more than [GetConsumerRetention] blocks behind are
+// expired (see [expireConsumers]), so [height] must be within that many
+// blocks of the last accepted block.
+func (vm *VM) RegisterConsumer(name string, height uint64) error {
+	if len(name) == 0 || len(name) > MaxConsumerNameLen {
+		return ErrInvalidConsumer
+	}
+	vm.consumersL.Lock()
+	defer vm.consumersL.Unlock()
+
+	if _, ok := vm.consumers[name]; ok {
+		return ErrDuplicateConsumer
+	}
+	if len(vm.consumers) >= MaxConsumers {
+		return ErrTooManyConsumers
+	}
+	if height < vm.lastPruned {
+		return ErrBlockPruned
+	}
+	lastAccepted := vm.LastAcceptedBlock().Hght
+	if height > lastAccepted || lastAccepted-height > vm.config.GetConsumerRetention() {
+		return ErrInvalidConsumerHeight
+	}
+	if err := vm.PutDiskConsumer(name, height); err != nil {
+		return err
+	}
+	vm.consumers[name] = height
+	return nil
+}
+
+// AckConsumer updates the last height processed by consumer [name].
+func (vm *VM) AckConsumer(name string, height uint64) error {
+	vm.consumersL.Lock()
+	defer vm.consumersL.Unlock()
+
+	prev, ok := vm.consumers[name]
+	if !ok {
+		return ErrUnknownConsumer
+	}
+	if height < prev || height > vm.LastAcceptedBlock().Hght {
+		return ErrInvalidConsumerHeight
+	}
+	if err := vm.PutDiskConsumer(name, height); err != nil {
+		return err
+	}
+	vm.consumers[name] = height
+	return nil
+}
+
+// RemoveConsumer stops tracking consumer [name].
+func (vm *VM) RemoveConsumer(name string) error {
+	vm.consumersL.Lock()
+	defer vm.consumersL.Unlock()
+
+	if _, ok := vm.consumers[name]; !ok {
+		return ErrUnknownConsumer
+	}
+	if err := vm.DeleteDiskConsumer(name); err != nil {
+		return err
+	}
+	delete(vm.consumers, name)
+	return nil
+}
+
+// ConsumerHeight returns the last height acknowledged by consumer [name].
+func (vm *VM) ConsumerHeight(name string) (uint64, bool) {
+	vm.consumersL.Lock()
+	defer vm.consumersL.Unlock()
+
+	height, ok := vm.consumers[name]
+	return height, ok
+}
+
+// expireConsumers removes all consumers that are more than
+// [GetConsumerRetention] blocks behind [height] (so that a consumer that
+// stops acknowledging blocks doesn't occupy a slot forever).
+//
+// Assumes [consumersL] is held.
+func (vm *VM) expireConsumers(height uint64) {
+	retention := vm.config.GetConsumerRetention()
+	for name, consumed := range vm.consumers {
+		if consumed >= height || height-consumed <= retention {
+			continue
+		}
+		if err := vm.DeleteDiskConsumer(name); err != nil {
+			vm.snowCtx.Log.Warn("unable to expire consumer", zap.String("name", name), zap.Error(err))
+			continue
+		}
+		delete(vm.consumers, name)
+		vm.snowCtx.Log.Info(
+			"expired stale consumer",
+			zap.String("name", name),
+			zap.Uint64("height", consumed),
+		)
+	}
+}
+
+// pruneBlocks expires stale consumers and deletes blocks that are more than
+// [GetAcceptedBlockWindow] blocks older than [height], unless they are still
+// needed by a registered consumer.
+//
+// The genesis block (and any block that has not yet been archived) is never
+// pruned.
+func (vm *VM) pruneBlocks(height uint64) {
+	vm.consumersL.Lock()
+	defer vm.consumersL.Unlock()
+
+	vm.expireConsumers(height)
+	window := vm.config.GetAcceptedBlockWindow()
+	if window == 0 || height <= window {
+		return
+	}
+	target := height - window
+
+	// Never prune blocks that haven't been archived
+	if vm.archiver != nil {
+		next := vm.archiver.Next()
+		if next == 0 {
+			return
+		}
+		if next-1 < target {
+			target = next - 1
+		}
+	}
+
+	for _, consumed := range vm.consumers {
+		if consumed < target {
+			target = consumed
+		}
+	}
+	start := vm.lastPruned + 1
+	if target < start {
+		return
+	}
+	if target-start >= maxPrunedPerBlock {
+		target = start + maxPrunedPerBlock - 1
+	}
+	if err := vm.DeleteDiskBlocks(start, target); err != nil {
+		vm.snowCtx.Log.Warn("unable to prune blocks", zap.Error(err))
+		return
+	}
+	vm.lastPruned = target
+	vm.metrics.blocksPruned.Add(float64(target - start + 1))
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+// testConsumerConfig is a [Config] that retains [window] blocks (and
+// consumers within [retention] blocks of the last accepted block). Unused
+// methods of [Config] panic.
+type testConsumerConfig struct {
+	Config
+
+	window    uint64
+	retention uint64
+}
+
+func (c *testConsumerConfig) GetAcceptedBlockWindow() uint64 { return c.window }
+func (c *testConsumerConfig) GetConsumerRetention() uint64   { return c.retention }
+
+// newConsumerTestVM returns a [VM] that has accepted the block at [height].
+func newConsumerTestVM(t *testing.T, height uint64, window uint64, retention uint64) *VM {
+	require := require.New(t)
+
+	_, m, err := newMetrics()
+	require.NoError(err)
+	vm := &VM{
+		vmDB:    memdb.New(),
+		snowCtx: &snow.Context{Log: logging.NoLog{}},
+		config:  &testConsumerConfig{window: window, retention: retention},
+		metrics: m,
+	}
+	setLastAccepted(vm, height)
+	require.NoError(vm.loadConsumers())
+	return vm
+}
+
+func setLastAccepted(vm *VM, height uint64) {
+	vm.lastAccepted = &chain.StatelessBlock{StatefulBlock: &chain.StatefulBlock{Hght: height}}
+}
+
+func TestRegisterConsumer(t *testing.T) {
+	require := require.New(t)
+	vm := newConsumerTestVM(t, 100, 0, 50)
+
+	// Names must be non-empty and bounded
+	require.ErrorIs(vm.RegisterConsumer("", 100), ErrInvalidConsumer)
+	require.ErrorIs(vm.RegisterConsumer(strings.Repeat("a", MaxConsumerNameLen+1), 100), ErrInvalidConsumer)
+	require.NoError(vm.RegisterConsumer(strings.Repeat("a", MaxConsumerNameLen), 100))
+
+	// Cursors must be between the retention limit and the last accepted
+	// block
+	require.ErrorIs(vm.RegisterConsumer("indexer", 101), ErrInvalidConsumerHeight)
+	require.ErrorIs(vm.RegisterConsumer("indexer", 49), ErrInvalidConsumerHeight)
+	require.NoError(vm.RegisterConsumer("indexer", 50))
+	require.ErrorIs(vm.RegisterConsumer("indexer", 60), ErrDuplicateConsumer)
+	height, ok := vm.ConsumerHeight("indexer")
+	require.True(ok)
+	require.Equal(uint64(50), height)
+
+	// Cursors can't start at pruned blocks
+	vm.lastPruned = 60
+	require.ErrorIs(vm.RegisterConsumer("late", 55), ErrBlockPruned)
+
+	// The number of consumers is capped
+	for i := len(vm.consumers); i < MaxConsumers; i++ {
+		require.NoError(vm.RegisterConsumer(fmt.Sprintf("consumer-%d", i), 100))
+	}
+	require.ErrorIs(vm.RegisterConsumer("extra", 100), ErrTooManyConsumers)
+	require.NoError(vm.RemoveConsumer("indexer"))
+	require.NoError(vm.RegisterConsumer("extra", 100))
+
+	// Consumers are persisted
+	restarted := &VM{vmDB: vm.vmDB}
+	require.NoError(restarted.loadConsumers())
+	require.Len(restarted.consumers, MaxConsumers)
+	height, ok = restarted.ConsumerHeight("extra")
+	require.True(ok)
+	require.Equal(uint64(100), height)
+	_, ok = restarted.ConsumerHeight("indexer")
+	require.False(ok)
+}
+
+func TestAckConsumer(t *testing.T) {
+	require := require.New(t)
+	vm := newConsumerTestVM(t, 100, 0, 50)
+
+	require.ErrorIs(vm.AckConsumer("indexer", 90), ErrUnknownConsumer)
+	require.NoError(vm.RegisterConsumer("indexer", 80))
+
+	// Cursors only move forward (up to the last accepted block)
+	require.ErrorIs(vm.AckConsumer("indexer", 79), ErrInvalidConsumerHeight)
+	require.ErrorIs(vm.AckConsumer("indexer", 101), ErrInvalidConsumerHeight)
+	require.NoError(vm.AckConsumer("indexer", 90))
+	require.NoError(vm.AckConsumer("indexer", 90))
+	height, ok := vm.ConsumerHeight("indexer")
+	require.True(ok)
+	require.Equal(uint64(90), height)
+
+	consumers, err := vm.GetDiskConsumers()
+	require.NoError(err)
+	require.Equal(map[string]uint64{"indexer": 90}, consumers)
+}
+
+func TestPruneBlocksConsumers(t *testing.T) {
+	require := require.New(t)
+	vm := newConsumerTestVM(t, 100, 10, 50)
+	require.NoError(vm.RegisterConsumer("indexer", 80))
+	require.NoError(vm.RegisterConsumer("stale", 60))
+
+	// Blocks that haven't been consumed aren't pruned
+	vm.pruneBlocks(100)
+	require.Equal(uint64(60), vm.lastPruned)
+
+	// Consumers that fall too far behind are expired (and no longer hold back
+	// pruning)
+	setLastAccepted(vm, 111)
+	vm.pruneBlocks(111)
+	_, ok := vm.ConsumerHeight("stale")
+	require.False(ok)
+	require.Equal(uint64(80), vm.lastPruned)
+
+	require.NoError(vm.AckConsumer("indexer", 95))
+	setLastAccepted(vm, 120)
+	vm.pruneBlocks(120)
+	require.Equal(uint64(95), vm.lastPruned)
+	consumers, err := vm.GetDiskConsumers()
+	require.NoError(err)
+	require.Equal(map[string]uint64{"indexer": 95}, consumers)
+
+	// Consumers are expired even if blocks aren't pruned
+	vm = newConsumerTestVM(t, 100, 0, 50)
+	require.NoError(vm.RegisterConsumer("indexer", 60))
+	vm.pruneBlocks(111)
+	_, ok = vm.ConsumerHeight("indexer")
+	require.False(ok)
+	consumers, err = vm.GetDiskConsumers()
+	require.NoError(err)
+	require.Empty(consumers)
+}
@@ -9,14 +9,18 @@ import (
 
 	ametrics "github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	atrace "github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 
+	"github.com/ava-labs/hypersdk/archive"
 	"github.com/ava-labs/hypersdk/builder"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/mempool"
+	"github.com/ava-labs/hypersdk/rpc"
 	trace "github.com/ava-labs/hypersdk/trace"
 )
 
@@ -24,21 +28,48 @@ type Handlers map[string]*common.HTTPHandler
 
 type Config interface {
 	GetTraceConfig() *trace.Config
-	GetParallelism() int // how many cores to use during verification
+	GetParallelism() int                      // how many cores to use during verification
+	GetMinParallelism() int                   // how many verification workers to keep running when idle
+	GetParallelismIdleTimeout() time.Duration // how long extra workers can be idle before exiting
+	GetBuildPrefetchConcurrency() int         // how many workers read tx state while building (0 disables)
+	GetBuildPrefetchBatchSize() int           // how many txs each build prefetch worker reads at a time
+	GetExecutionWorkers() int                 // how many txs to execute at once during building/verification (1 is sequential)
+	GetExecutionMaxParallelism() int          // how many txs can be executed ahead of the first unexecuted tx
 	GetMempoolSize() int
+	GetMempoolMaxBytes() int // 0 is unlimited
 	GetMempoolPayerSize() int
 	GetMempoolExemptPayers() [][]byte
+	GetMempoolSweepInterval() time.Duration
+	GetMempoolSweepBatchSize() int
+	GetMempoolExpiryInterval() time.Duration // how often to purge expired txs between blocks (0 disables)
+	GetMempoolJournal() bool                 // persist the mempool across restarts
+	GetAcceptedBlockWindow() uint64
+	GetConsumerRetention() uint64
+	GetArchiveConfig() *archive.Config
+	GetRPCListeners() []*rpc.ListenerConfig // additional addresses to serve APIs on
 	GetVerifySignatures() bool
 	GetStreamingBacklogSize() int
+	GetStreamingMaxConnections() int
+	GetStreamingMaxConnectionsPerIP() int
+	GetStreamingMaxSubscriptionsPerConnection() int
+	GetStreamingMaxSubscriptions() int
+	GetStateDiffs() bool        // persist (and stream) the state diff of each block
 	GetStateHistoryLength() int // how many roots back of data to keep to serve state queries
 	GetStateCacheSize() int     // how many items to keep in value cache and node cache
 	GetAcceptorSize() int       // how far back we can fall in processing accepted blocks
+	GetAcceptorWorkers() int    // how many workers process the side effects of accepted blocks
 	GetStateSyncParallelism() int
 	GetStateSyncMinBlocks() uint64
 	GetStateSyncServerDelay() time.Duration
 	GetParsedBlockCacheSize() int
 	GetAcceptedBlockCacheSize() int
 	GetContinuousProfilerConfig() *profiler.Config
+	GetExecutionProfileBlocks() int
+	GetOverloadProcessingBlocks() int    // processing blocks that trigger load shedding (0 disables)
+	GetOverloadVerificationBacklog() int // queued signature verification jobs that trigger load shedding (0 disables)
+	GetWarmUpPeriod() time.Duration      // time to shed expensive work after catching up on startup (0 disables)
+	GetMetricsPushURL() string           // pushgateway to push metrics to ("" disables)
+	GetMetricsPushInterval() time.Duration
 }
 
 type Genesis interface {
@@ -85,3 +116,92 @@ type Controller interface {
 	// `vm.Shutdown` is called.
 	Shutdown(context.Context) error
 }
+
+// ValidatorChange describes how a single validator changed between two
+// refreshes of the tracked validator set. A [PreviousWeight] of 0 indicates
+// the validator was added and a [Weight] of 0 indicates it was removed.
+type ValidatorChange struct {
+	NodeID         ids.NodeID
+	PreviousWeight uint64
+	Weight         uint64
+}
+
+// ValidatorSetListener can optionally be implemented by a [Controller] to be
+// notified whenever the tracked validator set changes (including when it is
+// first loaded).
+//
+// Notifications are delivered in order on their own goroutine (not the one
+// that observed the change) and are not made while any VM locks are held, so
+// it is safe to call back into the VM.
+type ValidatorSetListener interface {
+	ValidatorSetChanged(ctx context.Context, pHeight uint64, changes []*ValidatorChange)
+}
+
+// TxChecker can optionally be implemented by a [Controller] to apply
+// additional admission checks (e.g. balance sanity or custom policy) to
+// transactions before they are added to the mempool. It is invoked for
+// transactions submitted over RPC and received via gossip, after they have
+// been pre-executed against the preferred block's state.
+//
+// [db] must not be modified. Because transactions rejected by [CheckTx] may
+// still be valid in a future block, it should only reject transactions that
+// are very likely to fail.
+type TxChecker interface {
+	CheckTx(ctx context.Context, r chain.Rules, db chain.Database, tx *chain.Transaction) error
+}
+
+// TxAdmitter can optionally be implemented by a [Controller] to validate
+// transactions (e.g. size limits or custom policy) before they are inserted
+// into the mempool. Unlike [TxChecker], it is invoked for every transaction
+// added to the mempool (including those restored from the mempool journal and
+// those re-added when a block is rejected) and is not provided any state.
+//
+// Transactions rejected by [AdmitTx] are dropped from the mempool.
+type TxAdmitter interface {
+	AdmitTx(ctx context.Context, tx *chain.Transaction) error
+}
+
+// PayerQuoter can optionally be implemented by a [Controller] to weight the
+// number of transactions each payer may have in the mempool (e.g. by the
+// amount they have staked), instead of allowing every payer
+// [Config.GetMempoolPayerSize] transactions. [base] is the configured
+// [Config.GetMempoolPayerSize].
+//
+// [PayerQuota] is called for each payer whenever transactions are added to the
+// mempool (without holding any mempool locks) and [payer] must not be
+// modified. Payers in [Config.GetMempoolExemptPayers] are not subject to any
+// quota.
+type PayerQuoter interface {
+	PayerQuota(ctx context.Context, payer []byte, base int) int
+}
+
+// MempoolEvictionPolicy can optionally be implemented by a [Controller] to
+// select which transaction is evicted when the mempool is full (instead of the
+// lowest valued one). [EvictionPolicy] is called once, after [Initialize].
+type MempoolEvictionPolicy interface {
+	EvictionPolicy() mempool.EvictionPolicy[*chain.Transaction]
+}
+
+// UpgradeSchedule can optionally be implemented by a [Controller] to report
+// the names of the network upgrades that are active at time [t] (in ms). This
+// is surfaced over RPC so that clients can adapt to new behavior.
+type UpgradeSchedule interface {
+	ActivatedUpgrades(t int64) []string
+}
+
+// TxExpiryListener can optionally be implemented by a [Controller] to be
+// notified when transactions submitted to this node (over RPC) expire without
+// being included in an accepted block (e.g. to record them in a transaction
+// index). [TxsExpired] is called after [Accepted] is called for [blk], the
+// first block that [txIDs] could not be included in.
+type TxExpiryListener interface {
+	TxsExpired(ctx context.Context, blk *chain.StatelessBlock, txIDs []ids.ID) error
+}
+
+// NamespaceProvider can optionally be implemented by a [Controller] to serve
+// its APIs as multiple [rpc.Namespace]s (each with its own endpoints, metrics,
+// and auth) instead of (or in addition to) the [Handlers] returned by
+// [Initialize]. [Namespaces] is called once, after [Initialize].
+type NamespaceProvider interface {
+	Namespaces() []*rpc.Namespace
+}
@@ -11,6 +11,23 @@ var (
 	ErrNotAdded     = errors.New("not added")
 	ErrDropped      = errors.New("dropped")
 	ErrNotReady     = errors.New("not ready")
+	ErrWarmingUp    = errors.New("warming up")
 	ErrStateMissing = errors.New("state missing")
 	ErrStateSyncing = errors.New("state still syncing")
+	ErrTxRejected   = errors.New("tx rejected")
+	ErrPayerBanned  = errors.New("payer banned")
+
+	ErrInvalidConsumer       = errors.New("invalid consumer")
+	ErrDuplicateConsumer     = errors.New("duplicate consumer")
+	ErrTooManyConsumers      = errors.New("too many consumers")
+	ErrUnknownConsumer       = errors.New("unknown consumer")
+	ErrInvalidConsumerHeight = errors.New("invalid consumer height")
+	ErrBlockPruned           = errors.New("block pruned")
+	ErrStateDiffMissing      = errors.New("state diff missing")
+
+	ErrInvalidRange  = errors.New("invalid range")
+	ErrRangeTooLarge = errors.New("range too large")
+
+	ErrUnsupportedDBVersion = errors.New("unsupported database version")
+	ErrUnknownBlockVersion  = errors.New("unknown block version")
 )
@@ -0,0 +1,48 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+var _ chain.ExecutionProfiler = (*VM)(nil)
+
+// ExecutionProfiling returns true if the VM should record an
+// [chain.ExecutionProfile] for each block it verifies.
+func (vm *VM) ExecutionProfiling() bool {
+	return vm.config.GetExecutionProfileBlocks() > 0
+}
+
+// recordExecutionProfile retains [p] (evicting the oldest profile if we
+// already have [GetExecutionProfileBlocks]).
+func (vm *VM) recordExecutionProfile(p *chain.ExecutionProfile) {
+	limit := vm.config.GetExecutionProfileBlocks()
+	if limit <= 0 {
+		return
+	}
+	vm.profilesL.Lock()
+	defer vm.profilesL.Unlock()
+
+	if len(vm.profiles) >= limit {
+		vm.profiles = vm.profiles[len(vm.profiles)-limit+1:]
+	}
+	vm.profiles = append(vm.profiles, p)
+}
+
+// ExecutionProfiles returns the [chain.ExecutionProfile] of the last [n]
+// accepted blocks that were verified by this node (newest first).
+func (vm *VM) ExecutionProfiles(n int) []*chain.ExecutionProfile {
+	vm.profilesL.Lock()
+	defer vm.profilesL.Unlock()
+
+	if n <= 0 || n > len(vm.profiles) {
+		n = len(vm.profiles)
+	}
+	profiles := make([]*chain.ExecutionProfile, 0, n)
+	for i := len(vm.profiles) - 1; i >= len(vm.profiles)-n; i-- {
+		profiles = append(profiles, vm.profiles[i])
+	}
+	return profiles
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	controllerLane   = "controller"
+	actionStatsLane  = "actionStats"
+	addressBloomLane = "addressBloom"
+	proposerLane     = "proposer"
+	stateDiffLane    = "stateDiff"
+)
+
+// fanout runs the side effects of accepting a block (like indexing and
+// notifying websocket subscribers) on a bounded pool of workers so that a slow
+// consumer doesn't delay the acceptor (or any other consumer).
+//
+// Work is enqueued on named lanes. Work on the same lane is executed in order
+// (and never concurrently) but lanes are executed in parallel.
+type fanout struct {
+	ready chan *lane
+	tasks sync.WaitGroup
+	done  sync.WaitGroup
+
+	lanes map[string]*lane
+}
+
+type lane struct {
+	name  string
+	depth prometheus.Gauge
+
+	// [slots] bounds the number of tasks that can be queued on the lane
+	slots chan struct{}
+
+	l       sync.Mutex
+	queue   []func()
+	running bool // held by a worker or waiting in [ready]
+}
+
+// newFanout creates a [fanout] with [workers] workers and [names] lanes that
+// can each hold up to [depth] queued tasks. The depth of each lane is
+// recorded in [depths].
+func newFanout(workers int, depth int, depths *prometheus.GaugeVec, names ...string) *fanout {
+	if workers < 1 {
+		workers = 1
+	}
+	f := &fanout{
+		// Each lane is in [ready] at most once, so this never blocks
+		ready: make(chan *lane, len(names)),
+		lanes: make(map[string]*lane, len(names)),
+	}
+	for _, name := range names {
+		f.lanes[name] = &lane{
+			name:  name,
+			depth: depths.WithLabelValues(name),
+			slots: make(chan struct{}, depth),
+		}
+	}
+	f.done.Add(workers)
+	for i := 0; i < workers; i++ {
+		go f.work()
+	}
+	return f
+}
+
+// Enqueue adds [task] to the lane [name]. If the lane is full, Enqueue blocks
+// until a task on the lane completes.
+func (f *fanout) Enqueue(name string, task func()) {
+	ln := f.lanes[name]
+	ln.slots <- struct{}{}
+	f.tasks.Add(1)
+
+	ln.l.Lock()
+	defer ln.l.Unlock()
+	ln.queue = append(ln.queue, task)
+	ln.depth.Set(float64(len(ln.queue)))
+	if !ln.running {
+		ln.running = true
+		f.ready <- ln
+	}
+}
+
+func (f *fanout) work() {
+	defer f.done.Done()
+
+	for ln := range f.ready {
+		ln.l.Lock()
+		task := ln.queue[0]
+		ln.queue = ln.queue[1:]
+		ln.l.Unlock()
+
+		task()
+		<-ln.slots
+		f.tasks.Done()
+
+		ln.l.Lock()
+		ln.depth.Set(float64(len(ln.queue)))
+		if len(ln.queue) > 0 {
+			// Yield to other lanes between tasks
+			f.ready <- ln
+		} else {
+			ln.running = false
+		}
+		ln.l.Unlock()
+	}
+}
+
+// Close waits for all enqueued tasks to complete and stops all workers. No
+// tasks can be enqueued after Close is called.
+func (f *fanout) Close() {
+	f.tasks.Wait()
+	close(f.ready)
+	f.done.Wait()
+}
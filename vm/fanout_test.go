@@ -0,0 +1,45 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFanout(t *testing.T) {
+	require := require.New(t)
+	depths := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "depth"}, []string{"lane"})
+	f := newFanout(4, 2, depths, "a", "b")
+
+	// A blocked lane doesn't prevent other lanes from making progress
+	unblock := make(chan struct{})
+	f.Enqueue("a", func() { <-unblock })
+	done := make(chan struct{})
+	f.Enqueue("b", func() { close(done) })
+	<-done
+	close(unblock)
+
+	// Tasks on the same lane are executed in order (even if the lane is full)
+	var (
+		l     sync.Mutex
+		order []int
+	)
+	for i := 0; i < 10; i++ {
+		i := i
+		f.Enqueue("a", func() {
+			l.Lock()
+			defer l.Unlock()
+			order = append(order, i)
+		})
+	}
+	f.Close()
+	require.Len(order, 10)
+	for i, v := range order {
+		require.Equal(i, v)
+	}
+}
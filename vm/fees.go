@@ -9,24 +9,71 @@ import (
 
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/throughput"
+	"github.com/ava-labs/hypersdk/window"
 )
 
-const feeScaler = 0.8
+const (
+	feeScaler = 0.8
 
-func (vm *VM) SuggestedFee(ctx context.Context) (uint64, error) {
+	// We track throughput at the same granularity as [window.Window] so that
+	// observed usage can be compared against [GetWindowTargetUnits].
+	throughputInterval   = consts.MillisecondsPerSecond
+	throughputWindow     = window.WindowSize
+	throughputPercentile = 0.9
+)
+
+// SuggestedFee returns the unit price of each [fees.Dimension] that a tx
+// should use to be included in the next few blocks.
+func (vm *VM) SuggestedFee(ctx context.Context) (fees.Dimensions, error) {
 	ctx, span := vm.tracer.Start(ctx, "VM.SuggestedFee")
 	defer span.End()
 
 	rpreferred, err := vm.GetBlock(ctx, vm.preferred)
 	if err != nil {
-		return 0, err
+		return fees.Dimensions{}, err
 	}
 	preferred := rpreferred.(*chain.StatelessBlock)
 
-	// We scale down unit price to prevent a spiral up in price
-	r := vm.c.Rules(time.Now().UnixMilli())
-	return math.Max(
-		uint64(float64(preferred.UnitPrice)*feeScaler),
-		r.GetMinUnitPrice(),
-	), nil
+	// If recent usage is at or above the target, the unit price will keep
+	// rising so we don't scale it down (otherwise, txs using the suggestion
+	// would not be included).
+	now := time.Now().UnixMilli()
+	r := vm.c.Rules(now)
+	var (
+		minUnitPrices = r.GetMinUnitPrice()
+		targets       = r.GetWindowTargetUnits()
+		suggested     = fees.Dimensions{}
+	)
+
+	// If the mempool is full, anything that doesn't pay more than the lowest
+	// paying tx in it will be evicted immediately (txs are valued by the max
+	// fee they pay, see [chain.Transaction.UnitPrice]). Every tx consumes
+	// compute, so paying more than the floor per compute unit is enough.
+	if floor, full := vm.mempool.Floor(ctx); full {
+		minUnitPrices[fees.Compute] = math.Max(minUnitPrices[fees.Compute], floor+1)
+	}
+	for i := range suggested {
+		// Compute usage is smoothed over recent blocks, the usage of other
+		// dimensions is read from the window of the preferred block.
+		var usage uint64
+		if fees.Dimension(i) == fees.Compute {
+			usage = vm.throughput.Percentile(now, throughput.Units, throughputPercentile) * throughputWindow
+		} else {
+			usage = window.Sum(preferred.UnitWindows[i])
+		}
+		if usage >= targets[i] {
+			suggested[i] = math.Max(preferred.UnitPrices[i], minUnitPrices[i])
+			continue
+		}
+
+		// We scale down unit price to prevent a spiral up in price
+		suggested[i] = math.Max(
+			uint64(float64(preferred.UnitPrices[i])*feeScaler),
+			minUnitPrices[i],
+		)
+	}
+	return suggested, nil
 }
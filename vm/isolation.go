@@ -0,0 +1,52 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+// Controller RPC handlers and accept hooks only maintain indexes and serve
+// APIs (they don't affect consensus), so a panic in any of them is logged and
+// counted instead of taking down the node.
+
+// recovered records a panic [p] raised by the controller while running
+// [hook].
+func (vm *VM) recovered(hook string, p any) {
+	vm.metrics.controllerPanics.WithLabelValues(hook).Inc()
+	vm.snowCtx.Log.Error(
+		"recovered from controller panic",
+		zap.String("hook", hook),
+		zap.String("panic", fmt.Sprint(p)),
+		zap.ByteString("stack", debug.Stack()),
+	)
+}
+
+// isolate runs the controller hook [f] and returns its error. If [f] panics,
+// the panic is recovered (and recorded) and nil is returned.
+func (vm *VM) isolate(hook string, f func() error) error {
+	defer func() {
+		if p := recover(); p != nil {
+			vm.recovered(hook, p)
+		}
+	}()
+	return f()
+}
+
+// isolateHandlers wraps each of [handlers] so that a panic while serving a
+// request is recovered (and recorded) and answered with a 500.
+func (vm *VM) isolateHandlers(handlers Handlers) {
+	for endpoint, h := range handlers {
+		hook := "rpc" + endpoint
+		h.Handler = rpc.Recover(h.Handler, func(_ *http.Request, p any) {
+			vm.recovered(hook, p)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/emap"
+)
+
+// localTxs tracks transactions submitted to this node (over RPC) until they
+// are either included in an accepted block or expire, so that their
+// submitters can be told when they will never be included.
+type localTxs struct {
+	l       sync.Mutex
+	pending set.Set[ids.ID]
+	expiry  *emap.EMap[*chain.Transaction]
+}
+
+func newLocalTxs() *localTxs {
+	return &localTxs{
+		pending: set.Set[ids.ID]{},
+		expiry:  emap.NewEMap[*chain.Transaction](),
+	}
+}
+
+func (l *localTxs) Add(txs []*chain.Transaction) {
+	l.l.Lock()
+	defer l.l.Unlock()
+
+	for _, tx := range txs {
+		l.pending.Add(tx.ID())
+	}
+	l.expiry.Add(txs)
+}
+
+// Accept stops tracking the transactions included in [b] and returns the IDs
+// of any tracked transactions that expired before [b].
+func (l *localTxs) Accept(b *chain.StatelessBlock) []ids.ID {
+	l.l.Lock()
+	defer l.l.Unlock()
+
+	for _, tx := range b.Txs {
+		l.pending.Remove(tx.ID())
+	}
+	expired := []ids.ID{}
+	for _, txID := range l.expiry.SetMin(b.Tmstmp) {
+		if !l.pending.Contains(txID) {
+			continue
+		}
+		l.pending.Remove(txID)
+		expired = append(expired, txID)
+	}
+	return expired
+}
@@ -0,0 +1,34 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// BanPayer drops all transactions paid for by [payer] from the mempool and
+// rejects any new ones until [UnbanPayer] is called. Bans are not persisted
+// across restarts (use [Config.GetMempoolExemptPayers] for the opposite,
+// permanent, behavior). BanPayer returns the number of dropped transactions.
+func (vm *VM) BanPayer(ctx context.Context, payer []byte) int {
+	ctx, span := vm.tracer.Start(ctx, "VM.BanPayer")
+	defer span.End()
+
+	removed := vm.mempool.BanPayer(ctx, string(payer))
+	vm.snowCtx.Log.Info("banned payer",
+		zap.Binary("payer", payer),
+		zap.Int("dropped", len(removed)),
+	)
+	vm.recordMempoolSize(ctx)
+	vm.updateFloor(ctx)
+	return len(removed)
+}
+
+// UnbanPayer allows transactions paid for by [payer] to be submitted again.
+func (vm *VM) UnbanPayer(ctx context.Context, payer []byte) {
+	vm.mempool.UnbanPayer(ctx, string(payer))
+	vm.snowCtx.Log.Info("unbanned payer", zap.Binary("payer", payer))
+}
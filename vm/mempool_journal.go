@@ -0,0 +1,73 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/mempool"
+)
+
+// enableMempoolJournal persists all transactions in the mempool to [vm.vmDB]
+// so that they can be restored by [restoreMempool] after a restart.
+func (vm *VM) enableMempoolJournal() {
+	vm.mempoolJournal = mempool.NewJournal(vm.snowCtx.Log, vm.vmDB, mempoolJournalPrefix)
+	vm.mempool.SetJournal(vm.mempoolJournal, func(tx *chain.Transaction) []byte {
+		return tx.Bytes()
+	})
+}
+
+// restoreMempool re-admits all journaled transactions to the mempool and then
+// drops any that expired while the node was offline.
+//
+// Journaled transactions were verified before they were added to the mempool,
+// so we don't verify them again (any that are no longer valid will be dropped
+// during block building).
+func (vm *VM) restoreMempool(ctx context.Context) error {
+	items, err := vm.mempoolJournal.Items()
+	if err != nil {
+		return err
+	}
+	var (
+		txs = make([]*chain.Transaction, 0, len(items))
+		r   = vm.c.Rules(time.Now().UnixMilli())
+	)
+	for _, item := range items {
+		p := codec.NewReader(item, consts.NetworkSizeLimit)
+		tx, err := chain.UnmarshalTx(p, vm.actionRegistry, vm.authRegistry)
+		if err != nil {
+			// The registries may have changed since the transaction was
+			// journaled
+			vm.snowCtx.Log.Warn("unable to parse journaled tx", zap.Error(err))
+			continue
+		}
+
+		// Record the units of [tx] before it is valued by the mempool (see
+		// [chain.Transaction.UnitPrice])
+		if _, err := tx.MaxUnits(r); err != nil {
+			vm.snowCtx.Log.Warn("unable to compute units of journaled tx", zap.Error(err))
+			continue
+		}
+		txs = append(txs, tx)
+	}
+
+	// Rewrite the journal with only the transactions we were able to restore
+	if err := vm.mempoolJournal.Clear(); err != nil {
+		return err
+	}
+	vm.mempool.Add(ctx, txs)
+	expired := vm.mempool.SetMinTimestamp(ctx, vm.lastAccepted.Tmstmp)
+	vm.snowCtx.Log.Info("restored mempool from journal",
+		zap.Int("journaled", len(items)),
+		zap.Int("restored", vm.mempool.Len(ctx)),
+		zap.Int("expired", len(expired)),
+	)
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+// sweepMempool periodically re-validates the lowest paying transactions in the
+// mempool against the preferred block, evicting any that can no longer pay the
+// current unit price (or are otherwise invalid). Without this, transactions
+// submitted before a fee spike can occupy the mempool until they expire.
+func (vm *VM) sweepMempool() {
+	interval := vm.config.GetMempoolSweepInterval()
+	if interval <= 0 {
+		return
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if !vm.isReady() {
+				continue
+			}
+			removed, err := vm.sweep(context.Background(), vm.config.GetMempoolSweepBatchSize())
+			if err != nil {
+				vm.snowCtx.Log.Warn("unable to sweep mempool", zap.Error(err))
+				continue
+			}
+			if removed > 0 {
+				vm.snowCtx.Log.Debug("swept mempool", zap.Int("removed", removed))
+			}
+		case <-vm.stop:
+			return
+		}
+	}
+}
+
+// purgeExpired periodically removes transactions that have expired from the
+// mempool. Otherwise, expired transactions are only removed when a block is
+// accepted and can occupy capacity (and be gossiped pointlessly) during long
+// gaps between blocks.
+func (vm *VM) purgeExpired() {
+	interval := vm.config.GetMempoolExpiryInterval()
+	if interval <= 0 {
+		return
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if !vm.isReady() {
+				continue
+			}
+			if removed := vm.purge(context.Background(), time.Now().UnixMilli()); removed > 0 {
+				vm.snowCtx.Log.Debug("purged expired txs from mempool", zap.Int("removed", removed))
+			}
+		case <-vm.stop:
+			return
+		}
+	}
+}
+
+// purge removes all transactions that expire before [now] from the mempool.
+// These transactions can't be included in any block we build (which will have
+// a timestamp of at least [now]).
+func (vm *VM) purge(ctx context.Context, now int64) int {
+	ctx, span := vm.tracer.Start(ctx, "VM.purge")
+	defer span.End()
+
+	removed := vm.mempool.SetMinTimestamp(ctx, now)
+	if len(removed) == 0 {
+		return 0
+	}
+	vm.metrics.txsPurged.Add(float64(len(removed)))
+	vm.recordMempoolSize(ctx)
+	vm.updateFloor(ctx)
+	return len(removed)
+}
+
+func (vm *VM) sweep(ctx context.Context, batch int) (int, error) {
+	ctx, span := vm.tracer.Start(ctx, "VM.sweep")
+	defer span.End()
+
+	blk, err := vm.GetStatelessBlock(ctx, vm.preferred)
+	if err != nil {
+		return 0, err
+	}
+	state, err := blk.State()
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now().UnixMilli()
+	r := vm.c.Rules(now)
+	ectx, err := chain.GenerateExecutionContext(ctx, now, blk, vm.tracer, r)
+	if err != nil {
+		return 0, err
+	}
+	checker, hasChecker := vm.c.(TxChecker)
+	removed := vm.mempool.Sweep(ctx, batch, func(sctx context.Context, tx *chain.Transaction) bool {
+		if err := tx.PreExecute(sctx, ectx, r, vm.c.StateManager(), state, now); err != nil && !errors.Is(err, chain.ErrSequenceTooHigh) {
+			return false
+		}
+		if hasChecker {
+			if err := checker.CheckTx(sctx, r, state, tx); err != nil {
+				return false
+			}
+		}
+		return true
+	})
+	vm.metrics.txsSwept.Add(float64(len(removed)))
+	vm.recordMempoolSize(ctx)
+	vm.updateFloor(ctx)
+	return len(removed), nil
+}
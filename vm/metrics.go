@@ -7,19 +7,60 @@ import (
 	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/hypersdk/fees"
 )
 
 type Metrics struct {
-	unitsVerified   prometheus.Counter
-	unitsAccepted   prometheus.Counter
-	txsSubmitted    prometheus.Counter // includes gossip
-	txsVerified     prometheus.Counter
-	txsAccepted     prometheus.Counter
-	stateChanges    prometheus.Counter
-	stateOperations prometheus.Counter
-	mempoolSize     prometheus.Gauge
-	rootCalculated  metric.Averager
-	waitSignatures  metric.Averager
+	unitsVerified      *prometheus.CounterVec
+	unitsAccepted      *prometheus.CounterVec
+	txsSubmitted       prometheus.Counter // includes gossip
+	txsRejected        prometheus.Counter
+	txsNotAdmitted     prometheus.Counter
+	txsExpired         prometheus.Counter
+	txsVerified        prometheus.Counter
+	txsAccepted        prometheus.Counter
+	stateChanges       prometheus.Counter
+	stateOperations    prometheus.Counter
+	txsSkipped         prometheus.Counter
+	buildsAborted      prometheus.Counter
+	verifiesAborted    prometheus.Counter
+	prefetchHits       prometheus.Counter
+	prefetchMisses     prometheus.Counter
+	mempoolSize        prometheus.Gauge
+	mempoolBytes       prometheus.Gauge
+	mempoolPayers      prometheus.Gauge
+	mempoolFloor       prometheus.Gauge
+	mempoolEventsDrop  prometheus.Counter
+	txsSwept           prometheus.Counter
+	txsPurged          prometheus.Counter
+	blocksPruned       prometheus.Counter
+	blocksProposed     prometheus.Counter
+	blocksUnattributed prometheus.Counter
+	seenSize           prometheus.Gauge
+	seenEvicted        prometheus.Counter
+	seenBytes          prometheus.Gauge
+	txsGossiped        prometheus.Counter
+	msgsSuppressed     prometheus.Counter
+	txsSuppressed      prometheus.Counter
+	txsFiltered        prometheus.Counter
+	sigsCached         prometheus.Counter
+	txsRegossiped      prometheus.Counter
+	syncBytesServed    prometheus.Counter
+	syncDuration       prometheus.Gauge
+	overloaded         prometheus.Gauge
+	workShed           prometheus.Counter
+	warmingUp          prometheus.Gauge
+	acceptorQueueDepth prometheus.Gauge
+	acceptorLaneDepth  *prometheus.GaugeVec
+	controllerPanics   *prometheus.CounterVec
+	rootCalculated     metric.Averager
+	waitSignatures     metric.Averager
+	prefetchStall      metric.Averager
+	execParallelism    metric.Averager
+	abortSaved         metric.Averager
+	gossipBatchFill    metric.Averager
+	gossipCompression  metric.Averager
 }
 
 func newMetrics() (*prometheus.Registry, *Metrics, error) {
@@ -43,23 +84,84 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	prefetchStall, err := metric.NewAverager(
+		"chain",
+		"build_prefetch_stall",
+		"time spent waiting for prefetched state in build",
+		r,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	abortSaved, err := metric.NewAverager(
+		"chain",
+		"abort_time_saved",
+		"estimated time saved by aborting block building and verification",
+		r,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	execParallelism, err := metric.NewAverager(
+		"chain",
+		"execution_parallelism",
+		"average number of txs executing at once when verifying a block in parallel",
+		r,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gossipBatchFill, err := metric.NewAverager(
+		"vm",
+		"gossip_batch_fill",
+		"fraction of the target message size used by each gossip batch",
+		r,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	gossipCompression, err := metric.NewAverager(
+		"vm",
+		"gossip_compression_ratio",
+		"compressed size of each gossip batch as a fraction of its uncompressed size",
+		r,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	m := &Metrics{
-		unitsVerified: prometheus.NewCounter(prometheus.CounterOpts{
+		unitsVerified: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: "chain",
 			Name:      "units_verified",
-			Help:      "amount of units verified",
-		}),
-		unitsAccepted: prometheus.NewCounter(prometheus.CounterOpts{
+			Help:      "amount of units verified (of each fee dimension)",
+		}, []string{"dimension"}),
+		unitsAccepted: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: "chain",
 			Name:      "units_accepted",
-			Help:      "amount of units accepted",
-		}),
+			Help:      "amount of units accepted (of each fee dimension)",
+		}, []string{"dimension"}),
 		txsSubmitted: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: "vm",
 			Name:      "txs_submitted",
 			Help:      "number of txs submitted to vm",
 		}),
+		txsRejected: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "txs_rejected",
+			Help:      "number of submitted txs rejected by the controller",
+		}),
+		txsNotAdmitted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "txs_not_admitted",
+			Help:      "number of txs dropped by the controller before being added to the mempool",
+		}),
+		txsExpired: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "txs_expired",
+			Help:      "number of locally submitted txs that expired without being included",
+		}),
 		txsVerified: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: "vm",
 			Name:      "txs_verified",
@@ -80,24 +182,225 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 			Name:      "state_operations",
 			Help:      "number of state operations",
 		}),
+		txsSkipped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "txs_skipped",
+			Help:      "number of txs not attempted during block building because an earlier tx of their payer was restored",
+		}),
+		buildsAborted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "builds_aborted",
+			Help:      "number of block builds aborted because the block was no longer needed",
+		}),
+		verifiesAborted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "verifies_aborted",
+			Help:      "number of block verifications aborted because the block was no longer needed",
+		}),
+		prefetchHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "build_prefetch_hits",
+			Help:      "number of keys read during block building that were prefetched",
+		}),
+		prefetchMisses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "build_prefetch_misses",
+			Help:      "number of keys read during block building that were not prefetched",
+		}),
 		mempoolSize: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: "chain",
 			Name:      "mempool_size",
 			Help:      "number of transactions in the mempool",
 		}),
-		rootCalculated: rootCalculated,
-		waitSignatures: waitSignatures,
+		mempoolBytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "chain",
+			Name:      "mempool_bytes",
+			Help:      "size of all transactions in the mempool",
+		}),
+		mempoolPayers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "chain",
+			Name:      "mempool_payers",
+			Help:      "number of distinct payers with transactions in the mempool",
+		}),
+		mempoolFloor: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "chain",
+			Name:      "mempool_floor",
+			Help:      "max fee below which a full mempool evicts transactions",
+		}),
+		mempoolEventsDrop: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "mempool_events_dropped",
+			Help:      "number of mempool events dropped because a subscriber fell behind",
+		}),
+		txsSwept: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "mempool_swept",
+			Help:      "number of transactions evicted from the mempool during re-validation",
+		}),
+		txsPurged: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "mempool_purged",
+			Help:      "number of expired transactions purged from the mempool between blocks",
+		}),
+		blocksPruned: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "blocks_pruned",
+			Help:      "number of accepted blocks pruned from disk",
+		}),
+		blocksProposed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "blocks_proposed",
+			Help:      "number of accepted blocks proposed by this node",
+		}),
+		blocksUnattributed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "blocks_unattributed",
+			Help:      "number of accepted blocks whose proposer could not be determined",
+		}),
+		seenSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "chain",
+			Name:      "seen_size",
+			Help:      "number of transaction IDs tracked for replay protection",
+		}),
+		seenEvicted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "seen_evicted",
+			Help:      "number of transaction IDs evicted from replay protection",
+		}),
+		seenBytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "chain",
+			Name:      "seen_bytes",
+			Help:      "estimated bytes used for replay protection",
+		}),
+		txsGossiped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "txs_gossiped",
+			Help:      "number of txs gossiped by vm",
+		}),
+		msgsSuppressed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "gossip_msgs_suppressed",
+			Help:      "number of duplicate gossip messages dropped",
+		}),
+		txsSuppressed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "gossip_txs_suppressed",
+			Help:      "number of duplicate gossiped txs dropped",
+		}),
+		txsFiltered: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "gossip_txs_filtered",
+			Help:      "number of txs not gossiped because they were in the mempool filter of a peer",
+		}),
+		sigsCached: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "signatures_cached",
+			Help:      "number of tx signatures in blocks not verified because they were verified when the tx was gossiped",
+		}),
+		txsRegossiped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "gossip_txs_regossiped",
+			Help:      "number of stale txs re-gossiped to proposers",
+		}),
+		syncBytesServed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "state_sync_bytes_served",
+			Help:      "number of bytes sent in response to state sync requests",
+		}),
+		syncDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "vm",
+			Name:      "state_sync_duration",
+			Help:      "time (in ms) spent fetching state during the last state sync",
+		}),
+		overloaded: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "vm",
+			Name:      "overloaded",
+			Help:      "1 if the vm is shedding low priority work to keep up with verification",
+		}),
+		workShed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "work_shed",
+			Help:      "number of low priority requests dropped while overloaded or warming up",
+		}),
+		warmingUp: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "vm",
+			Name:      "warming_up",
+			Help:      "1 if the vm is shedding expensive work while catching up after startup",
+		}),
+		acceptorQueueDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "chain",
+			Name:      "acceptor_queue_depth",
+			Help:      "number of accepted blocks waiting to be processed",
+		}),
+		acceptorLaneDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "chain",
+			Name:      "acceptor_lane_depth",
+			Help:      "number of accepted blocks waiting to be processed by each consumer",
+		}, []string{"lane"}),
+		controllerPanics: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "controller_panics",
+			Help:      "number of panics recovered from controller RPC handlers and accept hooks",
+		}, []string{"hook"}),
+		rootCalculated:    rootCalculated,
+		waitSignatures:    waitSignatures,
+		prefetchStall:     prefetchStall,
+		execParallelism:   execParallelism,
+		abortSaved:        abortSaved,
+		gossipBatchFill:   gossipBatchFill,
+		gossipCompression: gossipCompression,
 	}
 	errs := wrappers.Errs{}
 	errs.Add(
 		r.Register(m.unitsVerified),
 		r.Register(m.unitsAccepted),
 		r.Register(m.txsSubmitted),
+		r.Register(m.txsRejected),
+		r.Register(m.txsNotAdmitted),
+		r.Register(m.txsExpired),
 		r.Register(m.txsVerified),
 		r.Register(m.txsAccepted),
 		r.Register(m.stateChanges),
 		r.Register(m.stateOperations),
+		r.Register(m.txsSkipped),
+		r.Register(m.buildsAborted),
+		r.Register(m.verifiesAborted),
+		r.Register(m.prefetchHits),
+		r.Register(m.prefetchMisses),
 		r.Register(m.mempoolSize),
+		r.Register(m.mempoolBytes),
+		r.Register(m.mempoolPayers),
+		r.Register(m.mempoolFloor),
+		r.Register(m.mempoolEventsDrop),
+		r.Register(m.txsSwept),
+		r.Register(m.txsPurged),
+		r.Register(m.blocksPruned),
+		r.Register(m.blocksProposed),
+		r.Register(m.blocksUnattributed),
+		r.Register(m.seenSize),
+		r.Register(m.seenEvicted),
+		r.Register(m.seenBytes),
+		r.Register(m.txsGossiped),
+		r.Register(m.msgsSuppressed),
+		r.Register(m.txsSuppressed),
+		r.Register(m.txsFiltered),
+		r.Register(m.sigsCached),
+		r.Register(m.txsRegossiped),
+		r.Register(m.syncBytesServed),
+		r.Register(m.syncDuration),
+		r.Register(m.overloaded),
+		r.Register(m.workShed),
+		r.Register(m.warmingUp),
+		r.Register(m.acceptorQueueDepth),
+		r.Register(m.acceptorLaneDepth),
+		r.Register(m.controllerPanics),
 	)
 	return r, m, errs.Err
 }
+
+// recordUnits adds [units] to [c] (labeled by [fees.Dimension]).
+func recordUnits(c *prometheus.CounterVec, units fees.Dimensions) {
+	for i, v := range units {
+		c.WithLabelValues(fees.Dimension(i).String()).Add(float64(v))
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"go.uber.org/zap"
+)
+
+const metricsPushJob = "hypersdk"
+
+// pushMetrics periodically pushes all metrics in [gatherer] to the configured
+// pushgateway (grouped by nodeID and chainID). This allows operators to
+// collect metrics from nodes that can't be scraped (like those behind a NAT).
+func (vm *VM) pushMetrics(gatherer prometheus.Gatherer) {
+	url := vm.config.GetMetricsPushURL()
+	interval := vm.config.GetMetricsPushInterval()
+	if len(url) == 0 || interval <= 0 {
+		return
+	}
+	pusher := push.New(url, metricsPushJob).
+		Gatherer(gatherer).
+		Grouping("nodeID", vm.snowCtx.NodeID.String()).
+		Grouping("chainID", vm.snowCtx.ChainID.String())
+	vm.snowCtx.Log.Info("pushing metrics", zap.String("url", url), zap.Duration("interval", interval))
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			err := pusher.PushContext(ctx)
+			cancel()
+			if err != nil {
+				vm.snowCtx.Log.Warn("unable to push metrics", zap.Error(err))
+			}
+		case <-vm.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"go.uber.org/zap"
+)
+
+const (
+	// dbVersion is the version of the on-disk layout of [vmDB] written by this
+	// VM. It must be incremented (and a [migration] added to [migrations])
+	// whenever the layout of anything persisted in [vmDB] changes.
+	dbVersion = 1
+
+	// blockVersion is prepended to all blocks persisted in [vmDB].
+	blockVersion = 0x1
+
+	migrationBatchSize   = 1_024
+	migrationLogInterval = 10 * time.Second
+)
+
+var (
+	dbVersionKey       = []byte("db_version")
+	migrationCursorKey = []byte("migration_cursor")
+)
+
+// migration upgrades the layout of [vmDB] to [version] (from [version]-1) by
+// rewriting all values under [prefix].
+//
+// Migrations are applied in batches and the last key migrated is persisted
+// atomically with each batch, so an interrupted migration resumes where it
+// left off (each value is migrated exactly once).
+type migration struct {
+	version uint64
+	name    string
+	prefix  byte
+	migrate func(k []byte, v []byte) ([]byte, error)
+}
+
+var migrations = []*migration{
+	{
+		version: 1,
+		name:    "tag blocks with version",
+		prefix:  idPrefix,
+		migrate: func(_ []byte, v []byte) ([]byte, error) {
+			return append([]byte{blockVersion}, v...), nil
+		},
+	},
+}
+
+func (vm *VM) GetDiskVersion() (uint64, bool, error) {
+	v, err := vm.vmDB.Get(dbVersionKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return binary.BigEndian.Uint64(v), true, nil
+}
+
+// migrateDB applies all [migrations] that have not yet been applied to
+// [vmDB]. A [vmDB] without any accepted blocks is already up-to-date.
+func (vm *VM) migrateDB(migrations []*migration) error {
+	version, ok, err := vm.GetDiskVersion()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		has, err := vm.HasLastAccepted()
+		if err != nil {
+			return err
+		}
+		if !has {
+			return vm.vmDB.Put(dbVersionKey, binary.BigEndian.AppendUint64(nil, dbVersion))
+		}
+		// [vmDB] was created before versioning was added
+	}
+	if version > dbVersion {
+		return fmt.Errorf("%w: found=%d supported=%d", ErrUnsupportedDBVersion, version, dbVersion)
+	}
+	for _, m := range migrations {
+		if m.version <= version {
+			continue
+		}
+		if err := vm.runMigration(m); err != nil {
+			return fmt.Errorf("%w: migration %d (%s) failed", err, m.version, m.name)
+		}
+		version = m.version
+	}
+	return nil
+}
+
+func (vm *VM) runMigration(m *migration) error {
+	cursor, err := vm.vmDB.Get(migrationCursorKey)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		cursor = []byte{m.prefix}
+	case err != nil:
+		return err
+	}
+	vm.snowCtx.Log.Info(
+		"migrating database",
+		zap.Uint64("version", m.version),
+		zap.String("name", m.name),
+		zap.Bool("resumed", len(cursor) > 1),
+	)
+	var (
+		start    = time.Now()
+		lastLog  = start
+		migrated = 0
+	)
+	for {
+		// We collect each batch before writing it so that we never modify
+		// [vmDB] while iterating over it.
+		iter := vm.vmDB.NewIteratorWithStartAndPrefix(cursor, []byte{m.prefix})
+		keys := [][]byte{}
+		values := [][]byte{}
+		for len(keys) < migrationBatchSize && iter.Next() {
+			k := iter.Key()
+			if bytes.Equal(k, cursor) {
+				// [cursor] was migrated in the last batch
+				continue
+			}
+			keys = append(keys, k)
+			values = append(values, iter.Value())
+		}
+		err := iter.Error()
+		iter.Release()
+		if err != nil {
+			return err
+		}
+
+		batch := vm.vmDB.NewBatch()
+		if len(keys) == 0 {
+			if err := batch.Put(dbVersionKey, binary.BigEndian.AppendUint64(nil, m.version)); err != nil {
+				return err
+			}
+			if err := batch.Delete(migrationCursorKey); err != nil {
+				return err
+			}
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			vm.snowCtx.Log.Info(
+				"migrated database",
+				zap.Uint64("version", m.version),
+				zap.Int("migrated", migrated),
+				zap.Duration("t", time.Since(start)),
+			)
+			return nil
+		}
+		for i, k := range keys {
+			v, err := m.migrate(k, values[i])
+			if err != nil {
+				return err
+			}
+			if v == nil {
+				err = batch.Delete(k)
+			} else {
+				err = batch.Put(k, v)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		cursor = keys[len(keys)-1]
+		if err := batch.Put(migrationCursorKey, cursor); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		migrated += len(keys)
+		if time.Since(lastLog) > migrationLogInterval {
+			vm.snowCtx.Log.Info(
+				"migrating database",
+				zap.Uint64("version", m.version),
+				zap.Int("migrated", migrated),
+				zap.Duration("t", time.Since(start)),
+			)
+			lastLog = time.Now()
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDBFresh(t *testing.T) {
+	require := require.New(t)
+	vm := &VM{vmDB: memdb.New(), snowCtx: &snow.Context{Log: logging.NoLog{}}}
+
+	require.NoError(vm.migrateDB(migrations))
+	version, ok, err := vm.GetDiskVersion()
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(uint64(dbVersion), version)
+
+	// A newer database can't be opened
+	require.NoError(vm.vmDB.Put(dbVersionKey, binary.BigEndian.AppendUint64(nil, dbVersion+1)))
+	require.ErrorIs(vm.migrateDB(migrations), ErrUnsupportedDBVersion)
+}
+
+func TestMigrateDBResume(t *testing.T) {
+	require := require.New(t)
+	vm := &VM{vmDB: memdb.New(), snowCtx: &snow.Context{Log: logging.NoLog{}}}
+
+	// Populate a database created before versioning
+	require.NoError(vm.vmDB.Put(lastAccepted, ids.Empty[:]))
+	count := migrationBatchSize + 10
+	keys := make([][]byte, count)
+	for i := range keys {
+		keys[i] = PrefixBlockIDKey(ids.GenerateTestID())
+		require.NoError(vm.vmDB.Put(keys[i], []byte{0xff}))
+	}
+
+	// Interrupt the migration after the first batch
+	calls := 0
+	interrupted := []*migration{{
+		version: 1,
+		prefix:  idPrefix,
+		migrate: func(_ []byte, v []byte) ([]byte, error) {
+			calls++
+			if calls > migrationBatchSize {
+				return nil, ErrNotReady
+			}
+			return migrations[0].migrate(nil, v)
+		},
+	}}
+	require.ErrorIs(vm.migrateDB(interrupted), ErrNotReady)
+	_, ok, err := vm.GetDiskVersion()
+	require.NoError(err)
+	require.False(ok)
+
+	// Resuming migrates each value exactly once
+	require.NoError(vm.migrateDB(migrations))
+	for _, k := range keys {
+		v, err := vm.vmDB.Get(k)
+		require.NoError(err)
+		require.Equal([]byte{blockVersion, 0xff}, v)
+	}
+	version, ok, err := vm.GetDiskVersion()
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(uint64(1), version)
+	has, err := vm.vmDB.Has(migrationCursorKey)
+	require.NoError(err)
+	require.False(has)
+}
@@ -8,9 +8,27 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/version"
 )
 
+// stateSyncServerSender records the number of bytes served to peers that are
+// state syncing.
+type stateSyncServerSender struct {
+	common.AppSender
+	vm *VM
+}
+
+func (s *stateSyncServerSender) SendAppResponse(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	response []byte,
+) error {
+	s.vm.metrics.syncBytesServed.Add(float64(len(response)))
+	return s.AppSender.SendAppResponse(ctx, nodeID, requestID, response)
+}
+
 type StateSyncHandler struct {
 	vm *VM
 }
@@ -42,6 +60,11 @@ func (s *StateSyncHandler) AppRequest(
 	deadline time.Time,
 	request []byte,
 ) error {
+	// Serving state is expensive, so we drop requests while overloaded (the
+	// requester will retry with another peer once the request times out)
+	if s.vm.ShouldShed() {
+		return nil
+	}
 	if delay := s.vm.config.GetStateSyncServerDelay(); delay > 0 {
 		time.Sleep(delay)
 	}
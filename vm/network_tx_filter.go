@@ -0,0 +1,88 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/version"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/gossiper"
+)
+
+// TxFilterHandler receives the mempool filters sent by peers (see
+// [gossiper.FilterGossiper]).
+type TxFilterHandler struct {
+	vm *VM
+	g  gossiper.FilterGossiper
+}
+
+func NewTxFilterHandler(vm *VM, g gossiper.FilterGossiper) *TxFilterHandler {
+	return &TxFilterHandler{vm, g}
+}
+
+func (*TxFilterHandler) Connected(context.Context, ids.NodeID, *version.Application) error {
+	return nil
+}
+
+func (*TxFilterHandler) Disconnected(context.Context, ids.NodeID) error {
+	return nil
+}
+
+func (t *TxFilterHandler) AppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	if !t.vm.isReady() {
+		t.vm.snowCtx.Log.Warn("handle filter failed", zap.Error(ErrNotReady))
+		return nil
+	}
+
+	return t.g.HandleFilter(ctx, nodeID, msg)
+}
+
+func (*TxFilterHandler) AppRequest(
+	context.Context,
+	ids.NodeID,
+	uint32,
+	time.Time,
+	[]byte,
+) error {
+	return nil
+}
+
+func (*TxFilterHandler) AppRequestFailed(
+	context.Context,
+	ids.NodeID,
+	uint32,
+) error {
+	return nil
+}
+
+func (*TxFilterHandler) AppResponse(
+	context.Context,
+	ids.NodeID,
+	uint32,
+	[]byte,
+) error {
+	return nil
+}
+
+func (*TxFilterHandler) CrossChainAppRequest(
+	context.Context,
+	ids.ID,
+	uint32,
+	time.Time,
+	[]byte,
+) error {
+	return nil
+}
+
+func (*TxFilterHandler) CrossChainAppRequestFailed(context.Context, ids.ID, uint32) error {
+	return nil
+}
+
+func (*TxFilterHandler) CrossChainAppResponse(context.Context, ids.ID, uint32, []byte) error {
+	return nil
+}
@@ -33,6 +33,11 @@ func (t *TxGossipHandler) AppGossip(ctx context.Context, nodeID ids.NodeID, msg
 		t.vm.snowCtx.Log.Warn("handle app gossip failed", zap.Error(ErrNotReady))
 		return nil
 	}
+	if t.vm.shedTxs() {
+		// Txs are re-gossiped, so they will be received again once we are
+		// caught up
+		return nil
+	}
 
 	return t.vm.gossiper.HandleAppGossip(ctx, nodeID, msg)
 }
@@ -0,0 +1,53 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+// Overloaded returns true if more blocks are processing (or more signature
+// verification jobs are queued) than the configured thresholds allow.
+//
+// While overloaded, the VM sheds low priority work (like serving state sync
+// requests, RPC simulations, historical queries, and archive uploads) so that
+// a validator under attack keeps verifying blocks and voting.
+func (vm *VM) Overloaded() bool {
+	vm.verifiedL.RLock()
+	processing := len(vm.verifiedBlocks)
+	vm.verifiedL.RUnlock()
+
+	overloaded := exceeds(processing, vm.config.GetOverloadProcessingBlocks()) ||
+		exceeds(vm.workers.Backlog(), vm.config.GetOverloadVerificationBacklog())
+	if overloaded {
+		vm.metrics.overloaded.Set(1)
+	} else {
+		vm.metrics.overloaded.Set(0)
+	}
+	return overloaded
+}
+
+// ShouldShed returns true if low priority work should be dropped because the
+// VM is [Overloaded] (and records that it was dropped).
+func (vm *VM) ShouldShed() bool {
+	if !vm.Overloaded() {
+		return false
+	}
+	vm.metrics.workShed.Inc()
+	return true
+}
+
+// shedTxs returns true if txs received over gossip (or submitted over RPC)
+// should be dropped because the VM is [WarmingUp] (and records that they were
+// dropped). Unlike [ShouldShed], this doesn't apply to state sync requests, so
+// peers can still sync from a node that is warming up.
+func (vm *VM) shedTxs() bool {
+	if !vm.WarmingUp() {
+		return false
+	}
+	vm.metrics.workShed.Inc()
+	return true
+}
+
+// exceeds returns true if [v] has reached [threshold] (a threshold of 0 is
+// disabled).
+func exceeds(v int, threshold int) bool {
+	return threshold > 0 && v >= threshold
+}
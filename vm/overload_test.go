@@ -0,0 +1,54 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/workers"
+)
+
+// testOverloadConfig is a [Config] that sheds work once [processing] blocks
+// are processing. Unused methods of [Config] panic.
+type testOverloadConfig struct {
+	Config
+
+	processing int
+}
+
+func (c *testOverloadConfig) GetOverloadProcessingBlocks() int  { return c.processing }
+func (*testOverloadConfig) GetOverloadVerificationBacklog() int { return 0 }
+
+func TestShedWarmingUp(t *testing.T) {
+	require := require.New(t)
+
+	_, m, err := newMetrics()
+	require.NoError(err)
+	w := workers.New(1, 1)
+	defer w.Stop()
+	vm := &VM{
+		config:         &testOverloadConfig{processing: 1},
+		metrics:        m,
+		workers:        w,
+		verifiedBlocks: map[ids.ID]*chain.StatelessBlock{},
+	}
+
+	// While warming up, txs are shed but state sync requests (and other low
+	// priority work) are still served
+	require.True(vm.WarmingUp())
+	require.True(vm.shedTxs())
+	require.False(vm.ShouldShed())
+
+	// Once warmed up, only overload sheds work
+	vm.endWarmUp()
+	require.False(vm.shedTxs())
+	require.False(vm.ShouldShed())
+	vm.verifiedBlocks[ids.GenerateTestID()] = nil
+	require.True(vm.ShouldShed())
+	require.False(vm.shedTxs())
+}
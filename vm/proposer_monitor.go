@@ -6,6 +6,7 @@ package vm
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -35,9 +36,17 @@ type ProposerMonitor struct {
 
 	proposerCache *cache.LRU[string, []ids.NodeID]
 
+	notifying      bool
+	pendingChanges []*validatorSetChange
+
 	rl sync.Mutex
 }
 
+type validatorSetChange struct {
+	pHeight uint64
+	changes []*ValidatorChange
+}
+
 func NewProposerMonitor(vm *VM) *ProposerMonitor {
 	return &ProposerMonitor{
 		vm: vm,
@@ -51,6 +60,42 @@ func NewProposerMonitor(vm *VM) *ProposerMonitor {
 }
 
 func (p *ProposerMonitor) refresh(ctx context.Context) error {
+	if err := p.update(ctx); err != nil {
+		return err
+	}
+	l, ok := p.vm.c.(ValidatorSetListener)
+	if !ok {
+		return nil
+	}
+
+	// Notifications are delivered by a single goroutine at a time so that they
+	// are received in order (and so that a slow listener never delays gossip,
+	// block building, or RPC calls that refresh the validator set).
+	p.rl.Lock()
+	defer p.rl.Unlock()
+	if p.notifying || len(p.pendingChanges) == 0 {
+		return nil
+	}
+	p.notifying = true
+	go p.notify(l)
+	return nil
+}
+
+// notify delivers pending changes to [l] until there are none left.
+func (p *ProposerMonitor) notify(l ValidatorSetListener) {
+	p.rl.Lock()
+	for len(p.pendingChanges) > 0 {
+		next := p.pendingChanges[0]
+		p.pendingChanges = p.pendingChanges[1:]
+		p.rl.Unlock()
+		l.ValidatorSetChanged(context.TODO(), next.pHeight, next.changes)
+		p.rl.Lock()
+	}
+	p.notifying = false
+	p.rl.Unlock()
+}
+
+func (p *ProposerMonitor) update(ctx context.Context) error {
 	p.rl.Lock()
 	defer p.rl.Unlock()
 
@@ -63,7 +108,7 @@ func (p *ProposerMonitor) refresh(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	p.validators, err = p.vm.snowCtx.ValidatorState.GetValidatorSet(
+	vdrs, err := p.vm.snowCtx.ValidatorState.GetValidatorSet(
 		ctx,
 		pHeight,
 		p.vm.snowCtx.SubnetID,
@@ -71,6 +116,8 @@ func (p *ProposerMonitor) refresh(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	changes := diffValidators(p.validators, vdrs)
+	p.validators = vdrs
 	pks := map[string]struct{}{}
 	for _, v := range p.validators {
 		if v.PublicKey == nil {
@@ -83,13 +130,54 @@ func (p *ProposerMonitor) refresh(ctx context.Context) error {
 		"refreshed proposer monitor",
 		zap.Uint64("previous", p.currentPHeight),
 		zap.Uint64("new", pHeight),
+		zap.Int("changes", len(changes)),
 		zap.Duration("t", time.Since(start)),
 	)
 	p.currentPHeight = pHeight
 	p.lastFetchedPHeight = time.Now()
+	if _, ok := p.vm.c.(ValidatorSetListener); ok && len(changes) > 0 {
+		p.pendingChanges = append(p.pendingChanges, &validatorSetChange{pHeight, changes})
+	}
 	return nil
 }
 
+// diffValidators returns all validators whose weight differs between [prev]
+// and [next] (sorted by [ids.NodeID], so that every node observes changes in
+// the same order).
+func diffValidators(
+	prev map[ids.NodeID]*validators.GetValidatorOutput,
+	next map[ids.NodeID]*validators.GetValidatorOutput,
+) []*ValidatorChange {
+	changes := []*ValidatorChange{}
+	for nodeID, v := range next {
+		var prevWeight uint64
+		if pv, ok := prev[nodeID]; ok {
+			prevWeight = pv.Weight
+		}
+		if prevWeight == v.Weight {
+			continue
+		}
+		changes = append(changes, &ValidatorChange{
+			NodeID:         nodeID,
+			PreviousWeight: prevWeight,
+			Weight:         v.Weight,
+		})
+	}
+	for nodeID, v := range prev {
+		if _, ok := next[nodeID]; ok {
+			continue
+		}
+		changes = append(changes, &ValidatorChange{
+			NodeID:         nodeID,
+			PreviousWeight: v.Weight,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].NodeID.Less(changes[j].NodeID)
+	})
+	return changes
+}
+
 func (p *ProposerMonitor) IsValidator(ctx context.Context, nodeID ids.NodeID) (bool, error) {
 	if err := p.refresh(ctx); err != nil {
 		return false, err
@@ -114,16 +202,9 @@ func (p *ProposerMonitor) Proposers(
 	udepth := uint64(depth)
 	for i := uint64(1); i <= uint64(diff); i++ {
 		height := preferredBlk.Hght + i
-		key := fmt.Sprintf("%d-%d", height, p.currentPHeight)
-		var proposers []ids.NodeID
-		if v, ok := p.proposerCache.Get(key); ok {
-			proposers = v
-		} else {
-			proposers, err = p.proposer.Proposers(ctx, height, p.currentPHeight)
-			if err != nil {
-				return nil, err
-			}
-			p.proposerCache.Put(key, proposers)
+		proposers, err := p.proposers(ctx, height, p.currentPHeight)
+		if err != nil {
+			return nil, err
 		}
 		arrLen := math.Min(udepth, uint64(len(proposers)))
 		proposersToGossip.Add(proposers[:arrLen]...)
@@ -131,6 +212,49 @@ func (p *ProposerMonitor) Proposers(
 	return proposersToGossip, nil
 }
 
+// proposers returns the validators (in order of their proposer windows) that
+// may propose the block at [height] using the validator set at [pHeight].
+func (p *ProposerMonitor) proposers(ctx context.Context, height uint64, pHeight uint64) ([]ids.NodeID, error) {
+	key := fmt.Sprintf("%d-%d", height, pHeight)
+	if v, ok := p.proposerCache.Get(key); ok {
+		return v, nil
+	}
+	proposers, err := p.proposer.Proposers(ctx, height, pHeight)
+	if err != nil {
+		return nil, err
+	}
+	p.proposerCache.Put(key, proposers)
+	return proposers, nil
+}
+
+// Proposer returns the validator whose proposer window [slot] is for the
+// block at [height] (using the validator set at [pHeight]), if any.
+func (p *ProposerMonitor) Proposer(
+	ctx context.Context,
+	height uint64,
+	pHeight uint64,
+	slot int,
+) (ids.NodeID, bool, error) {
+	proposers, err := p.proposers(ctx, height, pHeight)
+	if err != nil {
+		return ids.EmptyNodeID, false, err
+	}
+	if slot < 0 || slot >= len(proposers) {
+		return ids.EmptyNodeID, false, nil
+	}
+	return proposers[slot], true, nil
+}
+
+// PChainHeight returns the most recently fetched P-Chain height.
+func (p *ProposerMonitor) PChainHeight(ctx context.Context) (uint64, error) {
+	if err := p.refresh(ctx); err != nil {
+		return 0, err
+	}
+	p.rl.Lock()
+	defer p.rl.Unlock()
+	return p.currentPHeight, nil
+}
+
 func (p *ProposerMonitor) Validators(
 	ctx context.Context,
 ) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{}) {
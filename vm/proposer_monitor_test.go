@@ -0,0 +1,134 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffValidators(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		unchanged = ids.GenerateTestNodeID()
+		changed   = ids.GenerateTestNodeID()
+		removed   = ids.GenerateTestNodeID()
+		prev      = map[ids.NodeID]*validators.GetValidatorOutput{
+			unchanged: {NodeID: unchanged, Weight: 10},
+			changed:   {NodeID: changed, Weight: 20},
+			removed:   {NodeID: removed, Weight: 30},
+		}
+		next = map[ids.NodeID]*validators.GetValidatorOutput{
+			unchanged: {NodeID: unchanged, Weight: 10},
+			changed:   {NodeID: changed, Weight: 25},
+		}
+		expected = []*ValidatorChange{
+			{NodeID: changed, PreviousWeight: 20, Weight: 25},
+			{NodeID: removed, PreviousWeight: 30},
+		}
+	)
+	for i := 0; i < 16; i++ {
+		added := ids.GenerateTestNodeID()
+		next[added] = &validators.GetValidatorOutput{NodeID: added, Weight: 5}
+		expected = append(expected, &ValidatorChange{NodeID: added, Weight: 5})
+	}
+	sort.Slice(expected, func(i, j int) bool {
+		return expected[i].NodeID.Less(expected[j].NodeID)
+	})
+
+	// Changes are always reported in the same order (regardless of map
+	// iteration order)
+	for i := 0; i < 8; i++ {
+		require.Equal(expected, diffValidators(prev, next))
+	}
+
+	// The initial validator set is reported as additions
+	changes := diffValidators(nil, prev)
+	require.Len(changes, len(prev))
+	for _, change := range changes {
+		require.Zero(change.PreviousWeight)
+		require.Equal(prev[change.NodeID].Weight, change.Weight)
+	}
+}
+
+// testValidatorSetListener is a [Controller] that records the validator set
+// changes it is notified of (blocking each notification until [release] is
+// closed).
+type testValidatorSetListener struct {
+	Controller
+
+	release chan struct{}
+	changes chan uint64
+}
+
+func (l *testValidatorSetListener) ValidatorSetChanged(_ context.Context, pHeight uint64, _ []*ValidatorChange) {
+	<-l.release
+	l.changes <- pHeight
+}
+
+func TestProposerMonitorNotify(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx    = context.TODO()
+		nodeID = ids.GenerateTestNodeID()
+
+		l       sync.Mutex
+		pHeight uint64 = 1
+	)
+	state := &validators.TestState{
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			l.Lock()
+			defer l.Unlock()
+			return pHeight, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			l.Lock()
+			defer l.Unlock()
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID: {NodeID: nodeID, Weight: pHeight},
+			}, nil
+		},
+	}
+	listener := &testValidatorSetListener{
+		release: make(chan struct{}),
+		changes: make(chan uint64, 2),
+	}
+	vm := &VM{
+		c:       listener,
+		snowCtx: &snow.Context{Log: logging.NoLog{}, ValidatorState: state},
+	}
+	p := NewProposerMonitor(vm)
+
+	// Refreshing doesn't wait for the listener
+	isValidator, err := p.IsValidator(ctx, nodeID)
+	require.NoError(err)
+	require.True(isValidator)
+
+	// Changes observed while the listener is busy are delivered after it
+	// returns (in order)
+	l.Lock()
+	pHeight = 2
+	l.Unlock()
+	p.rl.Lock()
+	p.lastFetchedPHeight = time.Time{}
+	p.rl.Unlock()
+	height, err := p.PChainHeight(ctx)
+	require.NoError(err)
+	require.Equal(uint64(2), height)
+
+	close(listener.release)
+	require.Equal(uint64(1), <-listener.changes)
+	require.Equal(uint64(2), <-listener.changes)
+}
@@ -21,11 +21,17 @@ import (
 	"github.com/ava-labs/hypersdk/builder"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/mempool"
+	"github.com/ava-labs/hypersdk/network"
+	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/workers"
 )
 
 var (
 	_ chain.VM                           = (*VM)(nil)
+	_ chain.PrefetchingBuilder           = (*VM)(nil)
+	_ chain.ParallelExecutor             = (*VM)(nil)
+	_ chain.AbortableVM                  = (*VM)(nil)
 	_ gossiper.VM                        = (*VM)(nil)
 	_ builder.VM                         = (*VM)(nil)
 	_ block.ChainVM                      = (*VM)(nil)
@@ -90,6 +96,18 @@ func (vm *VM) Mempool() chain.Mempool {
 	return vm.mempool
 }
 
+// SubscribeMempool returns a subscription to all changes to the mempool (so
+// that components like indexers can react to them without polling).
+func (vm *VM) SubscribeMempool() *mempool.Subscription[*chain.Transaction] {
+	return vm.mempool.Subscribe()
+}
+
+// MempoolSnapshot returns all transactions in the mempool from the highest to
+// the lowest unit price.
+func (vm *VM) MempoolSnapshot(ctx context.Context) []*chain.Transaction {
+	return vm.mempool.Snapshot(ctx)
+}
+
 func (vm *VM) IsRepeat(ctx context.Context, txs []*chain.Transaction) bool {
 	_, span := vm.tracer.Start(ctx, "VM.IsRepeat")
 	defer span.End()
@@ -101,13 +119,16 @@ func (vm *VM) Verified(ctx context.Context, b *chain.StatelessBlock) {
 	ctx, span := vm.tracer.Start(ctx, "VM.Verified")
 	defer span.End()
 
-	vm.metrics.unitsVerified.Add(float64(b.UnitsConsumed))
+	recordUnits(vm.metrics.unitsVerified, b.UnitsConsumed)
 	vm.metrics.txsVerified.Add(float64(len(b.Txs)))
 	vm.verifiedL.Lock()
 	vm.verifiedBlocks[b.ID()] = b
 	vm.verifiedL.Unlock()
+	vm.Overloaded() // updates the overloaded metric
 	vm.parsedBlocks.Evict(b.ID())
 	vm.mempool.Remove(ctx, b.Txs)
+	vm.recordMempoolSize(ctx)
+	vm.updateFloor(ctx)
 	vm.gossiper.BlockVerified(b.Tmstmp)
 	vm.builder.QueueNotify()
 	vm.snowCtx.Log.Info(
@@ -123,10 +144,14 @@ func (vm *VM) Rejected(ctx context.Context, b *chain.StatelessBlock) {
 	ctx, span := vm.tracer.Start(ctx, "VM.Rejected")
 	defer span.End()
 
+	vm.abortRejected(b.ID())
 	vm.verifiedL.Lock()
 	delete(vm.verifiedBlocks, b.ID())
 	vm.verifiedL.Unlock()
+	vm.Overloaded() // updates the overloaded metric
 	vm.mempool.Add(ctx, b.Txs)
+	vm.recordMempoolSize(ctx)
+	vm.updateFloor(ctx)
 
 	// TODO: handle async?
 	if err := vm.c.Rejected(ctx, b); err != nil {
@@ -144,6 +169,7 @@ func (vm *VM) processAcceptedBlocks() {
 	// persist indexed state) instead of just exiting as soon as `vm.stop` is
 	// closed.
 	for b := range vm.acceptedQueue {
+		vm.metrics.acceptorQueueDepth.Set(float64(len(vm.acceptedQueue)))
 		// We skip blocks that were not processed because metadata required to
 		// process blocks opaquely (like looking at results) is not populated.
 		//
@@ -154,10 +180,8 @@ func (vm *VM) processAcceptedBlocks() {
 			continue
 		}
 
-		// Update controller
-		if err := vm.c.Accepted(context.TODO(), b); err != nil {
-			vm.snowCtx.Log.Fatal("accepted processing failed", zap.Error(err))
-		}
+		// Track throughput
+		vm.recordThroughput(b)
 
 		// Sign and store any warp messages (regardless if validator now, may become one)
 		results := b.Results()
@@ -188,21 +212,92 @@ func (vm *VM) processAcceptedBlocks() {
 			vm.warpManager.GatherSignatures(context.TODO(), tx.ID(), result.WarpMessage.Bytes())
 		}
 
-		// Update server
-		if err := vm.webSocketServer.AcceptBlock(b); err != nil {
-			vm.snowCtx.Log.Fatal("unable to accept block in websocket server", zap.Error(err))
+		// Queue block for archival
+		if vm.archiver != nil {
+			vm.archiver.Accepted(b)
 		}
-		// Must clear accepted txs before [SetMinTx] or else we will errnoueously
-		// send [ErrExpired] messages.
-		if err := vm.webSocketServer.SetMinTx(b.Tmstmp); err != nil {
-			vm.snowCtx.Log.Fatal("unable to set min tx in websocket server", zap.Error(err))
+
+		// Remove blocks outside of the accepted window
+		vm.pruneBlocks(b.Hght)
+
+		// Update controller and then server (so that subscribers aren't notified
+		// of a block before the controller has processed it), and notify the
+		// controller of any locally submitted txs that can no longer be included
+		expired := vm.localTxs.Accept(b)
+		vm.metrics.txsExpired.Add(float64(len(expired)))
+		vm.fanout.Enqueue(controllerLane, func() {
+			if err := vm.isolate("accepted", func() error {
+				return vm.c.Accepted(context.TODO(), b)
+			}); err != nil {
+				vm.snowCtx.Log.Fatal("accepted processing failed", zap.Error(err))
+			}
+			if err := vm.webSocketServer.AcceptBlock(b); err != nil {
+				vm.snowCtx.Log.Fatal("unable to accept block in websocket server", zap.Error(err))
+			}
+			// Must clear accepted txs before [SetMinTx] or else we will errnoueously
+			// send [ErrExpired] messages.
+			if err := vm.webSocketServer.SetMinTx(b.Tmstmp); err != nil {
+				vm.snowCtx.Log.Fatal("unable to set min tx in websocket server", zap.Error(err))
+			}
+			if len(expired) == 0 {
+				return
+			}
+			if listener, ok := vm.c.(TxExpiryListener); ok {
+				if err := vm.isolate("txsExpired", func() error {
+					return listener.TxsExpired(context.TODO(), b, expired)
+				}); err != nil {
+					vm.snowCtx.Log.Fatal("expired tx processing failed", zap.Error(err))
+				}
+			}
+		})
+
+		// Index actions for analytics
+		vm.fanout.Enqueue(actionStatsLane, func() {
+			if err := vm.storeActionStats(b); err != nil {
+				vm.snowCtx.Log.Fatal("unable to store action stats", zap.Error(err))
+			}
+		})
+
+		// Index touched addresses for wallet sync
+		vm.fanout.Enqueue(addressBloomLane, func() {
+			if err := vm.storeAddressBloom(b); err != nil {
+				vm.snowCtx.Log.Fatal("unable to store address bloom", zap.Error(err))
+			}
+		})
+
+		// Index proposer for production monitoring
+		vm.fanout.Enqueue(proposerLane, func() {
+			if err := vm.storeBlockProposer(context.TODO(), b); err != nil {
+				vm.snowCtx.Log.Warn("unable to store block proposer", zap.Uint64("height", b.Hght), zap.Error(err))
+			}
+		})
+
+		// Persist state changes for replication (before they are streamed so
+		// that subscribers can backfill from disk without missing any)
+		if vm.RecordingStateDiffs() {
+			vm.fanout.Enqueue(stateDiffLane, func() {
+				diff, err := vm.storeStateDiff(b)
+				if err != nil {
+					vm.snowCtx.Log.Fatal("unable to store state diff", zap.Error(err))
+				}
+				if diff == nil {
+					return
+				}
+				if err := vm.webSocketServer.AcceptStateDiff(diff); err != nil {
+					vm.snowCtx.Log.Fatal("unable to accept state diff in websocket server", zap.Error(err))
+				}
+			})
 		}
+
 		vm.snowCtx.Log.Info(
 			"block processed",
 			zap.Stringer("blkID", b.ID()),
 			zap.Uint64("height", b.Hght),
 		)
 	}
+
+	// Wait for all side effects to be processed
+	vm.fanout.Close()
 	close(vm.acceptorDone)
 	vm.snowCtx.Log.Info("acceptor queue shutdown")
 }
@@ -211,13 +306,17 @@ func (vm *VM) Accepted(ctx context.Context, b *chain.StatelessBlock) {
 	ctx, span := vm.tracer.Start(ctx, "VM.Accepted")
 	defer span.End()
 
-	vm.metrics.unitsAccepted.Add(float64(b.UnitsConsumed))
+	recordUnits(vm.metrics.unitsAccepted, b.UnitsConsumed)
 	vm.metrics.txsAccepted.Add(float64(len(b.Txs)))
 	vm.blocks.Put(b.ID(), b)
 	vm.verifiedL.Lock()
 	delete(vm.verifiedBlocks, b.ID())
 	vm.verifiedL.Unlock()
+	vm.Overloaded() // updates the overloaded metric
 	vm.lastAccepted = b
+	if p := b.ExecutionProfile(); p != nil {
+		vm.recordExecutionProfile(p)
+	}
 
 	// Update replay protection heap
 	//
@@ -227,6 +326,11 @@ func (vm *VM) Accepted(ctx context.Context, b *chain.StatelessBlock) {
 	evicted := vm.seen.SetMin(blkTime)
 	vm.Logger().Debug("txs evicted from seen", zap.Int("len", len(evicted)))
 	vm.seen.Add(b.Txs)
+	vm.recordAcceptedTxs(b)
+	vm.lastSeenEvicted.Store(int64(len(evicted)))
+	vm.metrics.seenEvicted.Add(float64(len(evicted)))
+	vm.metrics.seenSize.Set(float64(vm.seen.Len()))
+	vm.metrics.seenBytes.Set(float64(vm.seen.EstimatedSize()))
 
 	// Verify if emap is now sufficient (we need a consecutive run of blocks with
 	// timestamps of at least [ValidityWindow] for this to occur).
@@ -259,6 +363,7 @@ func (vm *VM) Accepted(ctx context.Context, b *chain.StatelessBlock) {
 
 	// Enqueue block for processing
 	vm.acceptedQueue <- b
+	vm.metrics.acceptorQueueDepth.Set(float64(len(vm.acceptedQueue)))
 
 	vm.snowCtx.Log.Info(
 		"accepted block",
@@ -266,16 +371,71 @@ func (vm *VM) Accepted(ctx context.Context, b *chain.StatelessBlock) {
 		zap.Uint64("height", b.Hght),
 		zap.Int("txs", len(b.Txs)),
 		zap.Int("size", len(b.Bytes())),
-		zap.Uint64("units", b.UnitsConsumed),
+		zap.Stringer("units", b.UnitsConsumed),
 		zap.Int("dropped mempool txs", len(removed)),
 		zap.Bool("state ready", vm.StateReady()),
 	)
 }
 
+// ReplayProtectionStats returns the number of transaction IDs and expiry
+// buckets tracked for replay protection, the number of IDs evicted when the
+// last block was accepted, and an estimate of the memory used.
+func (vm *VM) ReplayProtectionStats() (int, int, int, uint64) {
+	return vm.seen.Len(), vm.seen.Buckets(), int(vm.lastSeenEvicted.Load()), vm.seen.EstimatedSize()
+}
+
+// ActivatedUpgrades returns the names of the upgrades active as of the last
+// accepted block (if the [Controller] implements [UpgradeSchedule]).
+func (vm *VM) ActivatedUpgrades() []string {
+	s, ok := vm.c.(UpgradeSchedule)
+	if !ok {
+		return []string{}
+	}
+	return s.ActivatedUpgrades(vm.LastAcceptedBlock().Tmstmp)
+}
+
+// GenesisHash returns the hash of the genesis the chain was created with.
+func (vm *VM) GenesisHash() ids.ID {
+	return vm.genesisHash
+}
+
+// Features returns the names of the optional APIs enabled on this node (in
+// no particular order).
+func (vm *VM) Features() []string {
+	features := []string{}
+	if vm.ExecutionProfiling() {
+		features = append(features, rpc.FeatureAdmin)
+	}
+	if vm.archiver != nil {
+		features = append(features, rpc.FeatureArchive)
+	}
+	if vm.RecordingStateDiffs() {
+		features = append(features, rpc.FeatureStateDiffs)
+	}
+	if _, ok := vm.c.(NamespaceProvider); ok {
+		features = append(features, rpc.FeatureNamespaces)
+	}
+	return features
+}
+
 func (vm *VM) IsValidator(ctx context.Context, nid ids.NodeID) (bool, error) {
 	return vm.proposerMonitor.IsValidator(ctx, nid)
 }
 
+// Peers returns all peers connected to this chain (and whether each is a
+// validator).
+func (vm *VM) Peers(ctx context.Context) ([]*network.PeerInfo, error) {
+	peers := vm.networkManager.Peers()
+	for _, peer := range peers {
+		isValidator, err := vm.proposerMonitor.IsValidator(ctx, peer.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		peer.Validator = isValidator
+	}
+	return peers, nil
+}
+
 func (vm *VM) Proposers(ctx context.Context, diff int, depth int) (set.Set[ids.NodeID], error) {
 	return vm.proposerMonitor.Proposers(ctx, diff, depth)
 }
@@ -315,6 +475,15 @@ func (vm *VM) Gossiper() gossiper.Gossiper {
 	return vm.gossiper
 }
 
+// ForwardTxs sends [txs] directly to upcoming proposers without waiting for
+// the next gossip. [txs] are queued and sent by the gossiper, so the caller
+// (usually an RPC handler) is not blocked on the network.
+func (vm *VM) ForwardTxs(txs []*chain.Transaction) {
+	if err := vm.gossiper.Forward(context.Background(), txs); err != nil {
+		vm.snowCtx.Log.Warn("unable to forward txs", zap.Int("txs", len(txs)), zap.Error(err))
+	}
+}
+
 func (vm *VM) AcceptedSyncableBlock(
 	ctx context.Context,
 	sb *chain.SyncableBlock,
@@ -362,6 +531,62 @@ func (vm *VM) RecordStateOperations(c int) {
 	vm.metrics.stateOperations.Add(float64(c))
 }
 
+func (vm *VM) RecordTxsSkipped(c int) {
+	vm.metrics.txsSkipped.Add(float64(c))
+}
+
+func (vm *VM) GetBuildPrefetchConcurrency() int {
+	return vm.config.GetBuildPrefetchConcurrency()
+}
+
+func (vm *VM) GetBuildPrefetchBatchSize() int {
+	return vm.config.GetBuildPrefetchBatchSize()
+}
+
+func (vm *VM) RecordBuildPrefetch(hits int, misses int, stall time.Duration) {
+	vm.metrics.prefetchHits.Add(float64(hits))
+	vm.metrics.prefetchMisses.Add(float64(misses))
+	vm.metrics.prefetchStall.Observe(float64(stall))
+}
+
+func (vm *VM) GetExecutionWorkers() int {
+	return vm.config.GetExecutionWorkers()
+}
+
+func (vm *VM) GetExecutionMaxParallelism() int {
+	return vm.config.GetExecutionMaxParallelism()
+}
+
+func (vm *VM) RecordExecutionParallelism(p float64) {
+	vm.metrics.execParallelism.Observe(p)
+}
+
+func (vm *VM) RecordGossipBatch(txs int, fill float64) {
+	vm.metrics.txsGossiped.Add(float64(txs))
+	vm.metrics.gossipBatchFill.Observe(fill)
+}
+
+func (vm *VM) RecordGossipCompression(ratio float64) {
+	vm.metrics.gossipCompression.Observe(ratio)
+}
+
+func (vm *VM) RecordSuppressedGossip(msgs int, txs int) {
+	vm.metrics.msgsSuppressed.Add(float64(msgs))
+	vm.metrics.txsSuppressed.Add(float64(txs))
+}
+
+func (vm *VM) RecordFilteredGossip(txs int) {
+	vm.metrics.txsFiltered.Add(float64(txs))
+}
+
+func (vm *VM) RecordRegossip(txs int) {
+	vm.metrics.txsRegossiped.Add(float64(txs))
+}
+
+func (vm *VM) MempoolFilter() []byte {
+	return vm.mempool.Filter()
+}
+
 func (vm *VM) GetVerifySignatures() bool {
 	return vm.config.GetVerifySignatures()
 }
@@ -0,0 +1,72 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+// verifiedSigsCacheSize is the number of txs whose signatures we remember
+// verifying (so they aren't verified again when included in a block).
+const verifiedSigsCacheSize = 65_536
+
+var _ chain.SignatureCache = (*VM)(nil)
+
+// SignatureVerified returns true if the signature of [txID] was recently
+// verified.
+func (vm *VM) SignatureVerified(txID ids.ID) bool {
+	_, ok := vm.verifiedSigs.Get(txID)
+	if ok {
+		vm.metrics.sigsCached.Inc()
+	}
+	return ok
+}
+
+// verifySignatures verifies the signatures of [txs] in parallel (on a pool
+// separate from the one used to verify blocks) and returns the result for each
+// tx. Txs whose signatures were recently verified (or that are too large to be
+// included) are skipped.
+func (vm *VM) verifySignatures(ctx context.Context, r chain.Rules, txs []*chain.Transaction) []error {
+	_, span := vm.tracer.Start(ctx, "VM.verifySignatures")
+	defer span.End()
+
+	errs := make([]error, len(txs))
+	job, err := vm.sigWorkers.NewJob(len(txs))
+	if err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+	for i, tx := range txs {
+		if _, ok := vm.verifiedSigs.Get(tx.ID()); ok {
+			continue
+		}
+		if chain.VerifyTxSize(r, tx.Size()) != nil {
+			continue
+		}
+		i, verify := i, tx.AuthAsyncVerify()
+		job.Go(func() error {
+			errs[i] = verify()
+			return nil
+		})
+	}
+	job.Done(nil)
+
+	// Each task records its own result, so [Wait] never returns an error
+	_ = job.Wait()
+	return errs
+}
+
+// signatureVerified remembers that the signature of [tx] was verified (if it
+// was).
+func (vm *VM) signatureVerified(tx *chain.Transaction) {
+	if tx.AuthVerified() {
+		vm.verifiedSigs.Put(tx.ID(), struct{}{})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/trace"
+	"github.com/ava-labs/hypersdk/workers"
+)
+
+// newSignedTx returns a tx (that expires at [expiry]) signed by the returned
+// [chain.MockAuth].
+func newSignedTx(t *testing.T, ctrl *gomock.Controller, expiry int64) (*chain.Transaction, *chain.MockAuth) {
+	require := require.New(t)
+
+	action := chain.NewMockAction(ctrl)
+	action.EXPECT().Size().Return(0).AnyTimes()
+	action.EXPECT().Marshal(gomock.Any()).AnyTimes()
+	auth := chain.NewMockAuth(ctrl)
+	auth.EXPECT().Size().Return(0).AnyTimes()
+	auth.EXPECT().Marshal(gomock.Any()).AnyTimes()
+	factory := chain.NewMockAuthFactory(ctrl)
+	factory.EXPECT().Sign(gomock.Any(), gomock.Any()).Return(auth, nil)
+
+	actionRegistry := codec.NewTypeParser[chain.Action, *warp.Message, bool]()
+	require.NoError(actionRegistry.Register(action, func(*codec.Packer, *warp.Message) (chain.Action, error) {
+		return action, nil
+	}, false))
+	authRegistry := codec.NewTypeParser[chain.Auth, *warp.Message, bool]()
+	require.NoError(authRegistry.Register(auth, func(*codec.Packer, *warp.Message) (chain.Auth, error) {
+		return auth, nil
+	}, false))
+
+	tx, err := chain.NewTx(
+		&chain.Base{Timestamp: expiry, ChainID: ids.GenerateTestID(), MaxUnitPrices: fees.Dimensions{1, 1, 1, 1, 1}},
+		nil,
+		action,
+	).Sign(factory, actionRegistry, authRegistry)
+	require.NoError(err)
+	return tx, auth
+}
+
+func TestVerifySignatures(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	_, m, err := newMetrics()
+	require.NoError(err)
+	vm := &VM{
+		tracer:       tracer,
+		metrics:      m,
+		sigWorkers:   workers.New(2, 4),
+		verifiedSigs: &cache.LRU[ids.ID, struct{}]{Size: 4},
+	}
+	defer vm.sigWorkers.Stop()
+
+	var (
+		now          = time.Now().UnixMilli() / 1000 * 1000
+		errBadSig    = errors.New("bad signature")
+		valid, a     = newSignedTx(t, ctrl, now+1_000)
+		invalid, b   = newSignedTx(t, ctrl, now+2_000)
+		cached, c    = newSignedTx(t, ctrl, now+3_000)
+		txs          = []*chain.Transaction{valid, invalid, cached}
+		rules        = chain.NewMockRules(ctrl)
+		verifiedSigs = []ids.ID{}
+	)
+	a.EXPECT().AsyncVerify(gomock.Any()).Return(nil)
+	b.EXPECT().AsyncVerify(gomock.Any()).Return(errBadSig)
+	c.EXPECT().AsyncVerify(gomock.Any()).Times(0)
+
+	// [cached] was verified recently (so it isn't verified again)
+	vm.verifiedSigs.Put(cached.ID(), struct{}{})
+	errs := vm.verifySignatures(ctx, rules, txs)
+	require.Len(errs, len(txs))
+	require.NoError(errs[0])
+	require.ErrorIs(errs[1], errBadSig)
+	require.NoError(errs[2])
+
+	// Only successful verifications are remembered
+	require.True(valid.AuthVerified())
+	require.False(invalid.AuthVerified())
+	for _, tx := range txs {
+		vm.signatureVerified(tx)
+		if vm.SignatureVerified(tx.ID()) {
+			verifiedSigs = append(verifiedSigs, tx.ID())
+		}
+	}
+	require.Equal([]ids.ID{valid.ID(), cached.ID()}, verifiedSigs)
+
+	// A tx that was already verified by [verifySignatures] isn't verified
+	// again when included in a block
+	require.NoError(valid.AuthAsyncVerify()())
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"encoding/binary"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+var _ chain.StateDiffRecorder = (*VM)(nil)
+
+func PrefixStateDiffKey(height uint64) []byte {
+	k := make([]byte, 1+consts.Uint64Len)
+	k[0] = stateDiffPrefix
+	binary.BigEndian.PutUint64(k[1:], height)
+	return k
+}
+
+// RecordingStateDiffs returns true if the VM should record a
+// [chain.StateDiff] for each block it builds or verifies.
+func (vm *VM) RecordingStateDiffs() bool {
+	return vm.config.GetStateDiffs()
+}
+
+// storeStateDiff persists the [chain.StateDiff] of [blk] (it is removed when
+// [blk] is pruned). It returns nil if [blk] has no diff.
+func (vm *VM) storeStateDiff(blk *chain.StatelessBlock) (*chain.StateDiff, error) {
+	diff := blk.StateDiff()
+	if diff == nil {
+		return nil, nil
+	}
+	b, err := diff.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return diff, vm.vmDB.Put(PrefixStateDiffKey(blk.Hght), b)
+}
+
+// StateDiffs returns up to [limit] stored state diffs of consecutive blocks,
+// starting at [start]. No diffs are returned if the block at [start] has not
+// been accepted (and stored) yet.
+//
+// If the diff at [start] is missing (because it was pruned or the block was
+// accepted while state syncing or while [Config.GetStateDiffs] was disabled),
+// [ErrStateDiffMissing] is returned. If a later diff is missing, only the
+// diffs before it are returned.
+func (vm *VM) StateDiffs(start uint64, limit int) ([]*chain.StateDiff, error) {
+	vm.consumersL.Lock()
+	lastPruned := vm.lastPruned
+	vm.consumersL.Unlock()
+	if lastPruned > 0 && start <= lastPruned {
+		return nil, ErrBlockPruned
+	}
+
+	iter := vm.vmDB.NewIteratorWithStartAndPrefix(
+		PrefixStateDiffKey(start),
+		[]byte{stateDiffPrefix},
+	)
+	defer iter.Release()
+
+	diffs := []*chain.StateDiff{}
+	for len(diffs) < limit && iter.Next() {
+		height := binary.BigEndian.Uint64(iter.Key()[1:])
+		if height != start+uint64(len(diffs)) {
+			if len(diffs) == 0 {
+				return nil, ErrStateDiffMissing
+			}
+			break
+		}
+		diff, err := chain.UnmarshalStateDiff(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, iter.Error()
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+func TestStateDiffs(t *testing.T) {
+	require := require.New(t)
+	vm := &VM{vmDB: memdb.New()}
+
+	// Height 4 was not recorded
+	for _, height := range []uint64{1, 2, 3, 5} {
+		diff := &chain.StateDiff{
+			Height: height,
+			Changes: []*tstate.Change{
+				{Key: []byte("key"), Old: []byte{byte(height - 1)}, New: []byte{byte(height)}},
+				{Key: []byte("removed"), Old: []byte{}},
+			},
+		}
+		b, err := diff.Marshal()
+		require.NoError(err)
+		require.NoError(vm.vmDB.Put(PrefixStateDiffKey(height), b))
+	}
+
+	diffs, err := vm.StateDiffs(1, 2)
+	require.NoError(err)
+	require.Len(diffs, 2)
+	require.Equal(uint64(2), diffs[1].Height)
+	require.Equal([]byte{1}, diffs[1].Changes[0].Old)
+	require.Equal([]byte{2}, diffs[1].Changes[0].New)
+	require.Equal([]byte{}, diffs[1].Changes[1].Old)
+	require.Nil(diffs[1].Changes[1].New)
+
+	// Stops before a missing diff
+	diffs, err = vm.StateDiffs(2, 10)
+	require.NoError(err)
+	require.Len(diffs, 2)
+	_, err = vm.StateDiffs(4, 10)
+	require.ErrorIs(err, ErrStateDiffMissing)
+
+	// Future diffs are not available yet
+	diffs, err = vm.StateDiffs(6, 10)
+	require.NoError(err)
+	require.Empty(diffs)
+
+	// State diffs are pruned with their block
+	require.NoError(vm.DeleteDiskBlocks(1, 2))
+	vm.lastPruned = 2
+	_, err = vm.StateDiffs(2, 10)
+	require.ErrorIs(err, ErrBlockPruned)
+	diffs, err = vm.StateDiffs(3, 10)
+	require.NoError(err)
+	require.Len(diffs, 1)
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+func PrefixStateRootKey(height uint64) []byte {
+	k := make([]byte, 1+consts.Uint64Len)
+	k[0] = stateRootPrefix
+	binary.BigEndian.PutUint64(k[1:], height)
+	return k
+}
+
+// StateRoot returns the root of the state after the block at [height] was
+// accepted. Unlike blocks, state roots are never pruned (so proofs can be
+// verified against them long after the block is gone).
+func (vm *VM) StateRoot(height uint64) (ids.ID, error) {
+	v, err := vm.vmDB.Get(PrefixStateRootKey(height))
+	if err != nil {
+		return ids.Empty, err
+	}
+	return ids.ToID(v)
+}
+
+// StateProof returns a proof of the value of [key] (or of its absence) in the
+// state after the block at [height] was accepted, along with the root it can
+// be verified against. Proofs can only be generated for the last
+// [Config.GetStateHistoryLength] roots.
+func (vm *VM) StateProof(ctx context.Context, height uint64, key []byte) (ids.ID, *merkledb.RangeProof, error) {
+	ctx, span := vm.tracer.Start(ctx, "VM.StateProof")
+	defer span.End()
+
+	root, err := vm.StateRoot(height)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	proof, err := vm.stateDB.GetRangeProofAtRoot(ctx, root, key, key, 1)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	return root, proof, nil
+}
+
+// ReadStateAtHeight is like [ReadState] but reads [keys] from the state after
+// the block at [height] was accepted (which must be one of the last
+// [Config.GetStateHistoryLength] roots). Missing keys return
+// [database.ErrNotFound].
+func (vm *VM) ReadStateAtHeight(ctx context.Context, height uint64, keys [][]byte) ([][]byte, []error) {
+	values := make([][]byte, len(keys))
+	errs := make([]error, len(keys))
+	for i, key := range keys {
+		_, proof, err := vm.StateProof(ctx, height, key)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		errs[i] = database.ErrNotFound
+		for _, kv := range proof.KeyValues {
+			if bytes.Equal(kv.Key, key) {
+				values[i], errs[i] = kv.Value, nil
+				break
+			}
+		}
+	}
+	return values, errs
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateProof(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(trace.Config{Enabled: false})
+	stateDB, err := merkledb.New(ctx, memdb.New(), merkledb.Config{
+		HistoryLength: 16,
+		NodeCacheSize: 1_024,
+		Reg:           prometheus.NewRegistry(),
+		Tracer:        tracer,
+	})
+	require.NoError(err)
+	vm := &VM{vmDB: memdb.New(), stateDB: stateDB, tracer: tracer}
+
+	// Record the root after each "block"
+	key := []byte("key")
+	for height, value := range []string{"a", "b"} {
+		require.NoError(stateDB.Put(key, []byte(value)))
+		root, err := stateDB.GetMerkleRoot(ctx)
+		require.NoError(err)
+		require.NoError(vm.vmDB.Put(PrefixStateRootKey(uint64(height)), root[:]))
+	}
+
+	// Historical values can be proven against their root
+	for height, value := range []string{"a", "b"} {
+		root, proof, err := vm.StateProof(ctx, uint64(height), key)
+		require.NoError(err)
+		expected, err := vm.StateRoot(uint64(height))
+		require.NoError(err)
+		require.Equal(expected, root)
+		require.NoError(proof.Verify(ctx, key, key, root))
+		require.Len(proof.KeyValues, 1)
+		require.Equal([]byte(value), proof.KeyValues[0].Value)
+	}
+
+	// Absence can be proven
+	missing := []byte("missing")
+	root, proof, err := vm.StateProof(ctx, 1, missing)
+	require.NoError(err)
+	require.NoError(proof.Verify(ctx, missing, missing, root))
+	require.Empty(proof.KeyValues)
+
+	// State roots are not pruned with their block
+	require.NoError(vm.DeleteDiskBlocks(0, 1))
+	_, err = vm.StateRoot(1)
+	require.NoError(err)
+
+	// Unknown heights have no root
+	_, _, err = vm.StateProof(ctx, 2, key)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Historical values can be read directly
+	values, errs := vm.ReadStateAtHeight(ctx, 0, [][]byte{key, missing})
+	require.NoError(errs[0])
+	require.Equal([]byte("a"), values[0])
+	require.ErrorIs(errs[1], database.ErrNotFound)
+}
@@ -21,15 +21,23 @@ import (
 )
 
 const (
-	idPrefix            = 0x0
-	heightPrefix        = 0x1
-	warpSignaturePrefix = 0x2
-	warpFetchPrefix     = 0x3
+	idPrefix             = 0x0
+	heightPrefix         = 0x1
+	warpSignaturePrefix  = 0x2
+	warpFetchPrefix      = 0x3
+	consumerPrefix       = 0x4
+	mempoolJournalPrefix = 0x5
+	actionStatsPrefix    = 0x6
+	addressBloomPrefix   = 0x7
+	stateRootPrefix      = 0x8
+	blockProposerPrefix  = 0x9
+	stateDiffPrefix      = 0xa
 )
 
 var (
 	lastAccepted = []byte("last_accepted")
 	isSyncing    = []byte("is_syncing")
+	lastPruned   = []byte("last_pruned")
 
 	signatureLRU = &cache.LRU[string, *chain.WarpSignature]{Size: 1024}
 )
@@ -56,13 +64,16 @@ func (vm *VM) SetLastAccepted(block *chain.StatelessBlock) error {
 	if err := vmDB.Put(lastAccepted, bid[:]); err != nil {
 		return err
 	}
-	if err := vmDB.Put(PrefixBlockIDKey(bid), block.Bytes()); err != nil {
+	if err := vmDB.Put(PrefixBlockIDKey(bid), append([]byte{blockVersion}, block.Bytes()...)); err != nil {
 		return err
 	}
 	// TODO: store block bytes at height to reduce amount of compaction
 	if err := vmDB.Put(PrefixBlockHeightKey(block.Height()), bid[:]); err != nil {
 		return err
 	}
+	if err := vmDB.Put(PrefixStateRootKey(block.Height()), block.StateRoot[:]); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -86,7 +97,10 @@ func (vm *VM) GetDiskBlock(bid ids.ID) (*chain.StatefulBlock, error) {
 	if err != nil {
 		return nil, err
 	}
-	return chain.UnmarshalBlock(b, vm)
+	if len(b) == 0 || b[0] != blockVersion {
+		return nil, ErrUnknownBlockVersion
+	}
+	return chain.UnmarshalBlock(b[1:], vm)
 }
 
 func (vm *VM) DeleteDiskBlock(bid ids.ID) error {
@@ -210,3 +224,87 @@ func (vm *VM) GetWarpFetch(txID ids.ID) (int64, error) {
 	}
 	return int64(binary.BigEndian.Uint64(v)), nil
 }
+
+func PrefixConsumerKey(name string) []byte {
+	k := make([]byte, 1+len(name))
+	k[0] = consumerPrefix
+	copy(k[1:], name)
+	return k
+}
+
+func (vm *VM) PutDiskConsumer(name string, height uint64) error {
+	return vm.vmDB.Put(PrefixConsumerKey(name), binary.BigEndian.AppendUint64(nil, height))
+}
+
+func (vm *VM) DeleteDiskConsumer(name string) error {
+	return vm.vmDB.Delete(PrefixConsumerKey(name))
+}
+
+func (vm *VM) GetDiskConsumers() (map[string]uint64, error) {
+	iter := vm.vmDB.NewIteratorWithPrefix([]byte{consumerPrefix})
+	defer iter.Release()
+
+	consumers := map[string]uint64{}
+	for iter.Next() {
+		consumers[string(iter.Key()[1:])] = binary.BigEndian.Uint64(iter.Value())
+	}
+	return consumers, iter.Error()
+}
+
+func (vm *VM) GetDiskLastPruned() (uint64, error) {
+	v, err := vm.vmDB.Get(lastPruned)
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+// DeleteDiskBlocks removes all blocks in [start, end] (and their height
+// index, address bloom, proposer, and state diff) and records [end] as the last pruned height.
+func (vm *VM) DeleteDiskBlocks(start uint64, end uint64) error {
+	batch := vm.vmDB.NewBatch()
+	for height := start; height <= end; height++ {
+		hk := PrefixBlockHeightKey(height)
+		v, err := vm.vmDB.Get(hk)
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		bid, err := ids.ToID(v)
+		if err != nil {
+			return err
+		}
+		if err := batch.Delete(PrefixBlockIDKey(bid)); err != nil {
+			return err
+		}
+		if err := batch.Delete(hk); err != nil {
+			return err
+		}
+		if err := batch.Delete(PrefixAddressBloomKey(height)); err != nil {
+			return err
+		}
+		if err := batch.Delete(PrefixBlockProposerKey(height)); err != nil {
+			return err
+		}
+		if err := batch.Delete(PrefixStateDiffKey(height)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Put(lastPruned, binary.BigEndian.AppendUint64(nil, end)); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (vm *VM) getArchiveBlock(ctx context.Context, height uint64) (*chain.StatelessBlock, error) {
+	bid, err := vm.GetDiskBlockIDAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return vm.GetStatelessBlock(ctx, bid)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	ametrics "github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/database"
@@ -165,6 +166,7 @@ func (s *stateSyncerClient) AcceptedSyncableBlock(
 	}
 
 	// Kickoff state syncing from [s.target]
+	start := time.Now()
 	if err := s.syncManager.Start(context.Background()); err != nil {
 		s.vm.snowCtx.Log.Warn("not starting state syncing", zap.Error(err))
 		return block.StateSyncSkipped, err
@@ -175,7 +177,9 @@ func (s *stateSyncerClient) AcceptedSyncableBlock(
 		// [syncManager] guarantees this will always return so it isn't possible to
 		// deadlock.
 		s.stateSyncErr = s.syncManager.Wait(context.Background())
-		s.vm.snowCtx.Log.Info("state sync done", zap.Error(s.stateSyncErr))
+		duration := time.Since(start)
+		s.vm.metrics.syncDuration.Set(float64(duration.Milliseconds()))
+		s.vm.snowCtx.Log.Info("state sync done", zap.Duration("t", duration), zap.Error(s.stateSyncErr))
 		if s.stateSyncErr == nil {
 			// if the sync was successful, update the last accepted pointers.
 			s.stateSyncErr = s.finishSync()
@@ -0,0 +1,59 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"time"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/throughput"
+)
+
+// throughputIntervals are the intervals (in ms) of the windows reported by
+// [Throughput]. Each window is [throughputIntervalsPerWindow] intervals long,
+// so they cover the last 1m, 10m, and 1h.
+var throughputIntervals = []int64{
+	consts.MillisecondsPerSecond,
+	10 * consts.MillisecondsPerSecond,
+	60 * consts.MillisecondsPerSecond,
+}
+
+const throughputIntervalsPerWindow = 60
+
+func newThroughputWindows() []*throughput.Tracker {
+	windows := make([]*throughput.Tracker, len(throughputIntervals))
+	for i, interval := range throughputIntervals {
+		windows[i] = throughput.New(interval, throughputIntervalsPerWindow)
+	}
+	return windows
+}
+
+// recordThroughput records [b] in the fee tracker and in each of the windows
+// reported by [Throughput].
+func (vm *VM) recordThroughput(b *chain.StatelessBlock) {
+	var (
+		txs   = uint64(len(b.Txs))
+		bytes = uint64(len(b.Bytes()))
+	)
+	// Only compute units are tracked (bandwidth is tracked as bytes)
+	units := b.UnitsConsumed[fees.Compute]
+	vm.throughput.Add(b.Tmstmp, txs, units, bytes)
+	maxUnits := vm.c.Rules(b.Tmstmp).GetMaxBlockUnits()[fees.Compute]
+	for _, w := range vm.throughputWindows {
+		w.AddBlock(b.Tmstmp, txs, units, bytes, maxUnits)
+	}
+}
+
+// Throughput returns the throughput of accepted blocks over the last 1m, 10m,
+// and 1h (in that order).
+func (vm *VM) Throughput() []*throughput.Summary {
+	now := time.Now().UnixMilli()
+	summaries := make([]*throughput.Summary, len(vm.throughputWindows))
+	for i, w := range vm.throughputWindows {
+		summaries[i] = w.Summary(now)
+	}
+	return summaries
+}
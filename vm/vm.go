@@ -5,9 +5,11 @@ package vm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	ametrics "github.com/ava-labs/avalanchego/api/metrics"
@@ -31,6 +33,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/hypersdk/archive"
 	"github.com/ava-labs/hypersdk/builder"
 	hcache "github.com/ava-labs/hypersdk/cache"
 	"github.com/ava-labs/hypersdk/chain"
@@ -39,6 +42,7 @@ import (
 	"github.com/ava-labs/hypersdk/mempool"
 	"github.com/ava-labs/hypersdk/network"
 	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/throughput"
 	htrace "github.com/ava-labs/hypersdk/trace"
 	hutils "github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/workers"
@@ -50,6 +54,7 @@ type VM struct {
 
 	snowCtx         *snow.Context
 	pkBytes         []byte
+	genesisHash     ids.ID
 	proposerMonitor *ProposerMonitor
 	manager         manager.Manager
 
@@ -67,11 +72,39 @@ type VM struct {
 	tracer  trace.Tracer
 	mempool *mempool.Mempool[*chain.Transaction]
 
+	// persists the mempool across restarts (nil if disabled)
+	mempoolJournal *mempool.Journal
+
+	// last value the mempool evicted below (0 if not full, see
+	// [mempool.Mempool.Floor])
+	lastFloor atomic.Uint64
+
+	// txs submitted over RPC that have not yet been included
+	localTxs *localTxs
+
 	// track all accepted but still valid txs (replay protection)
 	seen                   *emap.EMap[*chain.Transaction]
 	startSeenTime          int64
 	seenValidityWindowOnce sync.Once
 	seenValidityWindow     chan struct{}
+	lastSeenEvicted        atomic.Int64
+
+	// track recently accepted txs, units, and bytes
+	throughput        *throughput.Tracker
+	throughputWindows []*throughput.Tracker
+
+	// track the last height acknowledged by external consumers (like indexers)
+	// so we don't prune blocks they still need
+	consumersL sync.Mutex
+	consumers  map[string]uint64
+	lastPruned uint64
+
+	// uploads accepted blocks to an object store (if enabled)
+	archiver *archive.Archiver
+
+	// execution breakdown of recently accepted blocks (if enabled)
+	profilesL sync.Mutex
+	profiles  []*chain.ExecutionProfile
 
 	// cache block objects to optimize "GetBlockStateless"
 	// only put when a block is accepted
@@ -80,22 +113,45 @@ type VM struct {
 	// We cannot use a map here because we may parse blocks up in the ancestry
 	parsedBlocks *cache.LRU[ids.ID, *chain.StatelessBlock]
 
+	// IDs of blocks built by this node (used to attribute their proposer)
+	builtBlocks *cache.LRU[ids.ID, struct{}]
+
 	// Each element is a block that passed verification but
 	// hasn't yet been accepted/rejected
 	verifiedL      sync.RWMutex
 	verifiedBlocks map[ids.ID]*chain.StatelessBlock
 
+	// Blocks being built or verified (aborted once no longer needed)
+	builds   inflightWork
+	verifies inflightWork
+
 	// Accepted block queue
 	acceptedQueue chan *chain.StatelessBlock
 	acceptorDone  chan struct{}
 
+	// Processes the side effects of accepted blocks
+	fanout *fanout
+
 	// Transactions that streaming users are currently subscribed to
 	webSocketServer *rpc.WebSocketServer
 
+	// Serves the APIs on any addresses configured by the operator (in
+	// addition to the HTTP server of the node)
+	listeners *rpc.Listeners
+
 	// Reuse gorotuine group to avoid constant re-allocation
 	workers *workers.Workers
 
+	// Verifies the signatures of gossiped txs (and remembers which were
+	// verified so that they are not verified again in a block)
+	sigWorkers   *workers.Workers
+	verifiedSigs *cache.LRU[ids.ID, struct{}]
+
+	// Heights of recently accepted txs (used to answer resubmissions)
+	acceptedTxs *cache.LRU[ids.ID, uint64]
+
 	bootstrapped utils.Atomic[bool]
+	warmedUp     utils.Atomic[bool]
 	preferred    ids.ID
 	lastAccepted *chain.StatelessBlock
 	toEngine     chan<- common.Message
@@ -137,12 +193,16 @@ func (vm *VM) Initialize(
 ) error {
 	vm.snowCtx = snowCtx
 	vm.pkBytes = bls.PublicKeyToBytes(vm.snowCtx.PublicKey)
+	vm.genesisHash = hutils.ToID(genesisBytes)
 	// This will be overwritten when we accept the first block (in state sync) or
 	// backfill existing blocks (during normal bootstrapping).
 	vm.startSeenTime = -1
 	// Init seen for tracking transactions that have been accepted on-chain
 	vm.seen = emap.NewEMap[*chain.Transaction]()
 	vm.seenValidityWindow = make(chan struct{})
+	// Init throughput tracker for fee suggestions
+	vm.throughput = throughput.New(throughputInterval, throughputWindow)
+	vm.throughputWindows = newThroughputWindows()
 	vm.ready = make(chan struct{})
 	vm.stop = make(chan struct{})
 	gatherer := ametrics.NewMultiGatherer()
@@ -210,26 +270,99 @@ func (vm *VM) Initialize(
 	}
 
 	// Setup worker cluster
-	vm.workers = workers.New(vm.config.GetParallelism(), 100)
+	vm.workers = workers.NewScaling(
+		vm.config.GetMinParallelism(),
+		vm.config.GetParallelism(),
+		vm.config.GetParallelismIdleTimeout(),
+		100,
+	)
+	vm.sigWorkers = workers.NewScaling(
+		1,
+		vm.config.GetParallelism(),
+		vm.config.GetParallelismIdleTimeout(),
+		100,
+	)
+	vm.verifiedSigs = &cache.LRU[ids.ID, struct{}]{Size: verifiedSigsCacheSize}
+	vm.acceptedTxs = &cache.LRU[ids.ID, uint64]{Size: acceptedTxsCacheSize}
 
 	// Init channels before initializing other structs
 	vm.toEngine = toEngine
 
 	vm.parsedBlocks = &cache.LRU[ids.ID, *chain.StatelessBlock]{Size: vm.config.GetParsedBlockCacheSize()}
+	vm.builtBlocks = &cache.LRU[ids.ID, struct{}]{Size: builtBlocksCacheSize}
 	vm.verifiedBlocks = make(map[ids.ID]*chain.StatelessBlock)
 	vm.blocks, err = hcache.NewFIFO[ids.ID, *chain.StatelessBlock](vm.config.GetAcceptedBlockCacheSize())
 	if err != nil {
 		return err
 	}
+	if err := vm.migrateDB(migrations); err != nil {
+		return err
+	}
+	if err := vm.loadConsumers(); err != nil {
+		return err
+	}
+	if cfg := vm.config.GetArchiveConfig(); cfg.Enabled {
+		store, err := archive.NewStore(cfg.Location, cfg.Headers)
+		if err != nil {
+			return err
+		}
+		vm.archiver, err = archive.New(vm.snowCtx.Log, store, vm.vmDB, vm.getArchiveBlock, cfg.Backlog, vm.Overloaded)
+		if err != nil {
+			return err
+		}
+		go vm.archiver.Run()
+	}
 	vm.acceptedQueue = make(chan *chain.StatelessBlock, vm.config.GetAcceptorSize())
 	vm.acceptorDone = make(chan struct{})
+	vm.fanout = newFanout(
+		vm.config.GetAcceptorWorkers(),
+		vm.config.GetAcceptorSize(),
+		vm.metrics.acceptorLaneDepth,
+		controllerLane,
+		actionStatsLane,
+		addressBloomLane,
+		proposerLane,
+		stateDiffLane,
+	)
 
+	var quota mempool.PayerQuota = mempool.FixedQuota(vm.config.GetMempoolPayerSize())
+	if quoter, ok := vm.c.(PayerQuoter); ok {
+		base := vm.config.GetMempoolPayerSize()
+		quota = mempool.QuotaFunc(func(ctx context.Context, payer string) int {
+			return quoter.PayerQuota(ctx, []byte(payer), base)
+		})
+	}
 	vm.mempool = mempool.New[*chain.Transaction](
 		vm.tracer,
 		vm.config.GetMempoolSize(),
-		vm.config.GetMempoolPayerSize(),
+		vm.config.GetMempoolMaxBytes(),
+		quota,
 		vm.config.GetMempoolExemptPayers(),
 	)
+	vm.mempool.SetDroppedEvents(vm.metrics.mempoolEventsDrop)
+	if policy, ok := vm.c.(MempoolEvictionPolicy); ok {
+		vm.mempool.SetEvictionPolicy(policy.EvictionPolicy())
+	}
+	if chain.SequenceMode(vm.c.Rules(time.Now().UnixMilli())) {
+		// Only offer the next sequence of each payer to the builder (instead
+		// of deferring those that arrive out of order)
+		if err := vm.mempool.EnableNonceOrdering(); err != nil {
+			return err
+		}
+	}
+	if admitter, ok := vm.c.(TxAdmitter); ok {
+		vm.mempool.SetAdmission(func(ctx context.Context, tx *chain.Transaction) error {
+			if err := admitter.AdmitTx(ctx, tx); err != nil {
+				vm.metrics.txsNotAdmitted.Inc()
+				return err
+			}
+			return nil
+		})
+	}
+	if vm.config.GetMempoolJournal() {
+		vm.enableMempoolJournal()
+	}
+	vm.localTxs = newLocalTxs()
 
 	// Try to load last accepted
 	has, err := vm.HasLastAccepted()
@@ -293,6 +426,12 @@ func (vm *VM) Initialize(
 		vm.blocks.Put(gBlkID, genesisBlk)
 		snowCtx.Log.Info("initialized vm from genesis", zap.Stringer("block", gBlkID))
 	}
+	if vm.mempoolJournal != nil {
+		if err := vm.restoreMempool(ctx); err != nil {
+			snowCtx.Log.Error("could not restore mempool", zap.Error(err))
+			return err
+		}
+	}
 	go vm.processAcceptedBlocks()
 
 	// Setup state syncing
@@ -304,19 +443,34 @@ func (vm *VM) Initialize(
 		vm.Logger(),
 	)
 	vm.stateSyncClient = vm.NewStateSyncClient(gatherer)
-	vm.stateSyncNetworkServer = syncEng.NewNetworkServer(stateSyncSender, vm.stateDB, vm.Logger())
+	vm.stateSyncNetworkServer = syncEng.NewNetworkServer(
+		&stateSyncServerSender{stateSyncSender, vm},
+		vm.stateDB,
+		vm.Logger(),
+	)
 	vm.networkManager.SetHandler(stateSyncHandler, NewStateSyncHandler(vm))
 
 	// Startup block builder and gossiper
 	go vm.builder.Run()
 	gossipHandler, gossipSender := vm.networkManager.Register()
 	vm.networkManager.SetHandler(gossipHandler, NewTxGossipHandler(vm))
+	if fg, ok := vm.gossiper.(gossiper.FilterGossiper); ok {
+		filterHandler, filterSender := vm.networkManager.Register()
+		vm.networkManager.SetHandler(filterHandler, NewTxFilterHandler(vm, fg))
+		fg.SetFilterSender(filterSender)
+	}
 	go vm.gossiper.Run(gossipSender)
+	go vm.sweepMempool()
+	go vm.purgeExpired()
+	go vm.pushMetrics(gatherer)
+	go vm.warmUp()
 
 	// Wait until VM is ready and then send a state sync message to engine
 	go vm.markReady()
 
-	// Setup handlers
+	// Setup handlers (a panic in one provided by the controller should not
+	// crash the node)
+	vm.isolateHandlers(vm.handlers)
 	jsonRPCHandler, err := rpc.NewJSONRPCHandler(rpc.Name, rpc.NewJSONRPCServer(vm), common.NoLock)
 	if err != nil {
 		return fmt.Errorf("unable to create handler: %w", err)
@@ -328,9 +482,60 @@ func (vm *VM) Initialize(
 	if _, ok := vm.handlers[rpc.WebSocketEndpoint]; ok {
 		return fmt.Errorf("duplicate WebSocket handler found: %s", rpc.WebSocketEndpoint)
 	}
-	webSocketServer, pubsubServer := rpc.NewWebSocketServer(vm, vm.config.GetStreamingBacklogSize())
+	webSocketServer, pubsubServer := rpc.NewWebSocketServer(vm, &rpc.WebSocketServerConfig{
+		MaxPendingMessages:            vm.config.GetStreamingBacklogSize(),
+		MaxConnections:                vm.config.GetStreamingMaxConnections(),
+		MaxConnectionsPerIP:           vm.config.GetStreamingMaxConnectionsPerIP(),
+		MaxSubscriptionsPerConnection: vm.config.GetStreamingMaxSubscriptionsPerConnection(),
+		MaxSubscriptions:              vm.config.GetStreamingMaxSubscriptions(),
+	})
 	vm.webSocketServer = webSocketServer
 	vm.handlers[rpc.WebSocketEndpoint] = rpc.NewWebSocketHandler(pubsubServer)
+	mempoolHandler, err := rpc.NewJSONRPCHandler(rpc.Name, rpc.NewMempoolJSONRPCServer(vm), common.NoLock)
+	if err != nil {
+		return fmt.Errorf("unable to create handler: %w", err)
+	}
+	if _, ok := vm.handlers[rpc.MempoolEndpoint]; ok {
+		return fmt.Errorf("duplicate mempool handler found: %s", rpc.MempoolEndpoint)
+	}
+	vm.handlers[rpc.MempoolEndpoint] = mempoolHandler
+
+	// The admin API is only exposed in debug mode
+	if vm.ExecutionProfiling() {
+		adminHandler, err := rpc.NewJSONRPCHandler(rpc.Name, rpc.NewAdminJSONRPCServer(vm), common.NoLock)
+		if err != nil {
+			return fmt.Errorf("unable to create handler: %w", err)
+		}
+		if _, ok := vm.handlers[rpc.AdminEndpoint]; ok {
+			return fmt.Errorf("duplicate admin handler found: %s", rpc.AdminEndpoint)
+		}
+		vm.handlers[rpc.AdminEndpoint] = adminHandler
+	}
+
+	// Setup any namespaces defined by the controller
+	if np, ok := vm.c.(NamespaceProvider); ok {
+		registry := prometheus.NewRegistry()
+		namespaceHandlers, err := rpc.NewNamespaceHandlers(np.Namespaces(), registry)
+		if err != nil {
+			return fmt.Errorf("unable to create namespace handlers: %w", err)
+		}
+		vm.isolateHandlers(namespaceHandlers)
+		for endpoint, handler := range namespaceHandlers {
+			if _, ok := vm.handlers[endpoint]; ok {
+				return fmt.Errorf("duplicate namespace handler found: %s", endpoint)
+			}
+			vm.handlers[endpoint] = handler
+		}
+		if err := gatherer.Register("rpc", registry); err != nil {
+			return err
+		}
+	}
+
+	// Serve all handlers on any additional listeners
+	vm.listeners, err = rpc.Listen(vm.config.GetRPCListeners(), vm.handlers, &vm.snowCtx.Lock)
+	if err != nil {
+		return fmt.Errorf("unable to start rpc listeners: %w", err)
+	}
 	return nil
 }
 
@@ -380,10 +585,13 @@ func (vm *VM) ReadState(ctx context.Context, keys [][]byte) ([][]byte, []error)
 	return vm.stateDB.GetValues(ctx, keys)
 }
 
-func (vm *VM) SetState(_ context.Context, state snow.State) error {
+func (vm *VM) SetState(ctx context.Context, state snow.State) error {
 	switch state {
 	case snow.StateSyncing:
 		vm.Logger().Info("state sync started")
+		// Txs can't be verified against synced state (or built) until we are
+		// caught up
+		vm.mempool.Pause(ctx)
 		return nil
 	case snow.Bootstrapping:
 		syncStarted := vm.stateSyncClient.Started()
@@ -407,10 +615,13 @@ func (vm *VM) SetState(_ context.Context, state snow.State) error {
 			vm.stateSyncClient.ForceDone()
 		}
 		vm.Logger().Info("bootstrapping started", zap.Bool("state sync started", syncStarted))
+		vm.mempool.Pause(ctx)
 		return vm.onBootstrapStarted()
 	case snow.NormalOp:
 		vm.Logger().
 			Info("normal operation started", zap.Bool("state sync started", vm.stateSyncClient.Started()))
+		vm.Logger().Info("resuming mempool", zap.Int("held", vm.mempool.Overflow(ctx)))
+		vm.mempool.Resume(ctx)
 		return vm.onNormalOperationsStarted()
 	default:
 		return snow.ErrUnknownState
@@ -430,6 +641,7 @@ func (vm *VM) ForceReady() {
 	vm.seenValidityWindowOnce.Do(func() {
 		close(vm.seenValidityWindow)
 	})
+	vm.endWarmUp()
 }
 
 // onNormalOperationsStarted marks this VM as bootstrapped
@@ -444,6 +656,7 @@ func (vm *VM) onNormalOperationsStarted() error {
 // implements "block.ChainVM.common.VM"
 func (vm *VM) Shutdown(ctx context.Context) error {
 	close(vm.stop)
+	vm.abortAll()
 
 	// Shutdown state sync client if still running
 	if err := vm.stateSyncClient.Shutdown(); err != nil {
@@ -453,12 +666,23 @@ func (vm *VM) Shutdown(ctx context.Context) error {
 	// Process remaining accepted blocks before shutdown
 	close(vm.acceptedQueue)
 	<-vm.acceptorDone
+	if vm.archiver != nil {
+		vm.archiver.Close()
+	}
+
+	// Stop serving APIs on additional listeners
+	if vm.listeners != nil {
+		if err := vm.listeners.Close(); err != nil {
+			vm.snowCtx.Log.Warn("unable to close rpc listeners", zap.Error(err))
+		}
+	}
 
 	// Shutdown other async VM mechanisms
 	vm.warpManager.Done()
 	vm.builder.Done()
 	vm.gossiper.Done()
 	vm.workers.Stop()
+	vm.sigWorkers.Stop()
 	if vm.profiler != nil {
 		vm.profiler.Shutdown()
 	}
@@ -508,7 +732,14 @@ func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
 	if !vm.isReady() {
 		return http.StatusServiceUnavailable, ErrNotReady
 	}
-	return http.StatusOK, nil
+	// A node that is warming up is healthy (it is participating in consensus)
+	// but will shed expensive requests until the warm-up phase is complete.
+	return &HealthDetails{WarmingUp: vm.WarmingUp()}, nil
+}
+
+// HealthDetails is returned by [HealthCheck] when the VM is ready.
+type HealthDetails struct {
+	WarmingUp bool `json:"warmingUp"`
 }
 
 // implements "block.ChainVM.commom.VM.Getter"
@@ -610,6 +841,7 @@ func (vm *VM) buildBlock(
 		return nil, err
 	}
 	vm.parsedBlocks.Put(blk.ID(), blk)
+	vm.builtBlocks.Put(blk.ID(), struct{}{})
 	return blk, nil
 }
 
@@ -647,6 +879,9 @@ func (vm *VM) Submit(
 	if !vm.isReady() {
 		return []error{ErrNotReady}
 	}
+	if vm.shedTxs() {
+		return []error{ErrWarmingUp}
+	}
 
 	// Create temporary execution context
 	blk, err := vm.GetStatelessBlock(ctx, vm.preferred)
@@ -665,13 +900,24 @@ func (vm *VM) Submit(
 		return []error{err}
 	}
 	oldestAllowed := now - r.GetValidityWindow()
+	checker, hasChecker := vm.c.(TxChecker)
 	validTxs := []*chain.Transaction{}
-	for _, tx := range txs {
+
+	// We already verify in streamer, let's avoid re-verification
+	var sigErrs []error
+	if verifySig && vm.config.GetVerifySignatures() {
+		sigErrs = vm.verifySignatures(ctx, r, txs)
+	}
+	for i, tx := range txs {
 		txID := tx.ID()
-		// We already verify in streamer, let's avoid re-verification
-		if verifySig && vm.config.GetVerifySignatures() {
-			sigVerify := tx.AuthAsyncVerify()
-			if err := sigVerify(); err != nil {
+		// Drop oversized txs before doing any expensive work
+		if err := chain.VerifyTxSize(r, tx.Size()); err != nil {
+			vm.metrics.txsRejected.Inc()
+			errs = append(errs, err)
+			continue
+		}
+		if sigErrs != nil {
+			if err := sigErrs[i]; err != nil {
 				// Failed signature verification is the only safe place to remove
 				// a transaction in listeners. Every other case may still end up with
 				// the transaction in a block.
@@ -682,11 +928,17 @@ func (vm *VM) Submit(
 				continue
 			}
 		}
+		vm.signatureVerified(tx)
+		if vm.mempool.Banned(tx.Payer()) {
+			vm.metrics.txsRejected.Inc()
+			errs = append(errs, ErrPayerBanned)
+			continue
+		}
 		// Avoid any state lookup if we already have tx in mempool
-		if vm.mempool.Has(ctx, txID) {
+		if vm.MempoolHas(ctx, txID) {
 			// Don't remove from listeners, it will be removed elsewhere if not
 			// included
-			errs = append(errs, ErrNotAdded)
+			errs = append(errs, fmt.Errorf("%w: %w", ErrNotAdded, chain.ErrDuplicateTx))
 			continue
 		}
 		// TODO: Batch this repeat check (and collect multiple txs at once)
@@ -704,24 +956,69 @@ func (vm *VM) Submit(
 		// This may fail if the state we are utilizing is invalidated (if a trie
 		// view from a different branch is committed underneath it). We prefer this
 		// instead of putting a lock around all commits.
-		if err := tx.PreExecute(ctx, ectx, r, state, now); err != nil {
+		//
+		// Transactions that are waiting on earlier sequences from the same payer
+		// are kept until they can be included.
+		if err := tx.PreExecute(ctx, ectx, r, vm.c.StateManager(), state, now); err != nil && !errors.Is(err, chain.ErrSequenceTooHigh) {
 			errs = append(errs, err)
 			continue
 		}
+		if hasChecker {
+			if err := checker.CheckTx(ctx, r, state, tx); err != nil {
+				vm.metrics.txsRejected.Inc()
+				errs = append(errs, fmt.Errorf("%w: %v", ErrTxRejected, err)) //nolint:errorlint
+				continue
+			}
+		}
 		errs = append(errs, nil)
 		validTxs = append(validTxs, tx)
 	}
 	vm.mempool.Add(ctx, validTxs)
+	if !verifySig {
+		// Transactions submitted over RPC (which verifies signatures before
+		// calling [Submit]) are tracked until they are included or expire
+		vm.localTxs.Add(validTxs)
+	}
 	vm.builder.QueueNotify()
-	vm.metrics.mempoolSize.Set(float64(vm.mempool.Len(ctx)))
+	vm.recordMempoolSize(ctx)
+	vm.updateFloor(ctx)
 	return errs
 }
 
+// recordMempoolSize updates the gauges that describe the composition of the
+// mempool.
+func (vm *VM) recordMempoolSize(ctx context.Context) {
+	vm.metrics.mempoolSize.Set(float64(vm.mempool.Len(ctx)))
+	vm.metrics.mempoolBytes.Set(float64(vm.mempool.Bytes(ctx)))
+	vm.metrics.mempoolPayers.Set(float64(vm.mempool.Payers(ctx)))
+}
+
+// updateFloor notifies streaming clients when the value (max fee) below which
+// the mempool evicts transactions changes, so they can price their transactions
+// accordingly during congestion.
+func (vm *VM) updateFloor(ctx context.Context) {
+	floor, full := vm.mempool.Floor(ctx)
+	if !full {
+		floor = 0
+	}
+	if vm.lastFloor.Swap(floor) == floor {
+		return
+	}
+	vm.metrics.mempoolFloor.Set(float64(floor))
+	if !full {
+		return
+	}
+	if err := vm.webSocketServer.PublishFloor(floor); err != nil {
+		vm.snowCtx.Log.Warn("unable to publish mempool floor", zap.Error(err))
+	}
+}
+
 // "SetPreference" implements "block.ChainVM"
 // replaces "core.SnowmanVM.SetPreference"
 func (vm *VM) SetPreference(_ context.Context, id ids.ID) error {
 	vm.snowCtx.Log.Debug("set preference", zap.Stringer("id", id))
 	vm.preferred = id
+	vm.abortSuperseded(id)
 	return nil
 }
 
@@ -17,6 +17,7 @@ import (
 	hcache "github.com/ava-labs/hypersdk/cache"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/emap"
+	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/mempool"
 	"github.com/ava-labs/hypersdk/trace"
 )
@@ -29,9 +30,9 @@ func TestBlockCache(t *testing.T) {
 	// create a block with "Unknown" status
 	blk := &chain.StatelessBlock{
 		StatefulBlock: &chain.StatefulBlock{
-			Prnt:      ids.GenerateTestID(),
-			Hght:      10000,
-			UnitPrice: 1000,
+			Prnt:       ids.GenerateTestID(),
+			Hght:       10000,
+			UnitPrices: fees.Dimensions{1000, 1000, 1000, 1000, 1000},
 		},
 	}
 	blkID := blk.ID()
@@ -47,7 +48,7 @@ func TestBlockCache(t *testing.T) {
 		blocks:         bcache,
 		verifiedBlocks: make(map[ids.ID]*chain.StatelessBlock),
 		seen:           emap.NewEMap[*chain.Transaction](),
-		mempool:        mempool.New[*chain.Transaction](tracer, 100, 32, nil),
+		mempool:        mempool.New[*chain.Transaction](tracer, 100, 0, mempool.FixedQuota(32), nil),
 		acceptedQueue:  make(chan *chain.StatelessBlock, 1024), // don't block on queue
 		c:              controller,
 	}
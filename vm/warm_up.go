@@ -0,0 +1,63 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const warmUpCheckInterval = 1 * time.Second
+
+// warmUp ends the warm-up phase once the VM is ready (state synced and the
+// validity window has been seen), has finished bootstrapping, and has then
+// been running for [GetWarmUpPeriod].
+//
+// While warming up, the VM prioritizes catching up (verification and state
+// sync) and sheds expensive work (like serving historical queries and
+// verifying new txs) so that a restarted RPC node isn't overwhelmed by clients
+// while it is still behind. State sync requests from peers are still served.
+func (vm *VM) warmUp() {
+	period := vm.config.GetWarmUpPeriod()
+	if period <= 0 {
+		vm.endWarmUp()
+		return
+	}
+	vm.metrics.warmingUp.Set(1)
+
+	select {
+	case <-vm.stop:
+		return
+	case <-vm.ready:
+	}
+	t := time.NewTicker(warmUpCheckInterval)
+	defer t.Stop()
+	for !vm.bootstrapped.Get() {
+		select {
+		case <-vm.stop:
+			return
+		case <-t.C:
+		}
+	}
+	vm.snowCtx.Log.Info("warming up", zap.Duration("period", period))
+	select {
+	case <-vm.stop:
+		return
+	case <-time.After(period):
+	}
+	vm.endWarmUp()
+	vm.snowCtx.Log.Info("warm up complete")
+}
+
+func (vm *VM) endWarmUp() {
+	vm.warmedUp.Set(true)
+	vm.metrics.warmingUp.Set(0)
+}
+
+// WarmingUp returns true if the VM has not yet completed its warm-up phase
+// after startup.
+func (vm *VM) WarmingUp() bool {
+	return !vm.warmedUp.Get()
+}
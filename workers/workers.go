@@ -5,15 +5,23 @@ package workers
 
 import (
 	"sync"
+	"time"
 )
 
 // Workers is a struct representing a workers pool.
 //
 // Limit number of concurrent goroutines with resetable error
 // Ensure minimal overhead for parallel ops
+//
+// The pool scales between [min] and [max] workers. A new worker is started
+// only when a task cannot be handed to an idle worker and a worker only exits
+// after being idle for [idleTimeout] (to avoid thrashing when load is bursty).
 type Workers struct {
-	count int       // Number of workers in the pool
-	queue chan *Job // A channel for passing jobs
+	min         int
+	max         int
+	idleTimeout time.Duration
+	count       int       // Number of workers in the pool (requires lock)
+	queue       chan *Job // A channel for passing jobs
 
 	// tracking state
 	lock              sync.RWMutex
@@ -26,9 +34,9 @@ type Workers struct {
 	tasks chan func() error
 
 	// shutdown coordination
-	ackShutdown    chan struct{}
-	stopWorkers    chan struct{}
-	stoppedWorkers chan struct{}
+	ackShutdown chan struct{}
+	stopWorkers chan struct{}
+	running     sync.WaitGroup
 }
 
 // Goroutines allocate a minimum of 2KB of memory, we can save this by reusing
@@ -38,22 +46,76 @@ type Workers struct {
 // Current size: https://github.com/golang/go/blob/fa463cc96d797c218be4e218723f83be47e814c8/src/runtime/stack.go#L74-L75
 // Backstory: https://medium.com/a-journey-with-go/go-how-does-the-goroutine-stack-size-evolve-447fc02085e5
 func New(workers int, maxJobs int) *Workers {
+	return NewScaling(workers, workers, 0, maxJobs)
+}
+
+// NewScaling creates a pool that starts with [min] workers and adds workers
+// (up to [max]) when all existing workers are busy. Workers beyond [min] exit
+// after being idle for [idleTimeout].
+//
+// At least 1 worker is always kept running.
+func NewScaling(min int, max int, idleTimeout time.Duration, maxJobs int) *Workers {
+	if min < 1 {
+		min = 1
+	}
+	if max < min {
+		max = min
+	}
 	w := &Workers{
-		count: workers,
-		queue: make(chan *Job, maxJobs),
+		min:         min,
+		max:         max,
+		idleTimeout: idleTimeout,
+		count:       min,
+		queue:       make(chan *Job, maxJobs),
 
-		tasks:          make(chan func() error),
-		ackShutdown:    make(chan struct{}),
-		stopWorkers:    make(chan struct{}),
-		stoppedWorkers: make(chan struct{}),
+		tasks:       make(chan func() error),
+		ackShutdown: make(chan struct{}),
+		stopWorkers: make(chan struct{}),
 	}
 	w.processQueue()
-	for i := 0; i < workers; i++ {
+	for i := 0; i < min; i++ {
 		w.startWorker()
 	}
 	return w
 }
 
+// Count returns the number of workers currently in the pool.
+func (w *Workers) Count() int {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	return w.count
+}
+
+// Backlog returns the number of jobs waiting for an earlier job to finish.
+func (w *Workers) Backlog() int {
+	return len(w.queue)
+}
+
+// grow starts a new worker if there are fewer than [max].
+func (w *Workers) grow() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.count >= w.max {
+		return
+	}
+	w.count++
+	w.startWorker()
+}
+
+// shrink returns true if the calling worker should exit.
+func (w *Workers) shrink() bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.count <= w.min {
+		return false
+	}
+	w.count--
+	return true
+}
+
 // processQueue starts a new goroutine that listens to the queue channel for jobs.
 // It assigns that jobs' tasks to w until shouldShutdown is set.
 func (w *Workers) processQueue() {
@@ -70,7 +132,13 @@ func (w *Workers) processQueue() {
 			// Process tasks
 			for t := range j.tasks {
 				w.sg.Add(1)
-				w.tasks <- t
+				select {
+				case w.tasks <- t:
+				default:
+					// All workers are busy
+					w.grow()
+					w.tasks <- t
+				}
 			}
 			w.sg.Wait()
 			// Send result to queue and reset err
@@ -90,34 +158,55 @@ func (w *Workers) processQueue() {
 	}()
 }
 
-// startWorker starts a new goroutine that listens to two channels.
+// startWorker starts a new goroutine that listens to three channels.
 // The stopWorkers channel signals the worker to stop processing tasks.
+// The idle timer signals the worker to exit if the pool can shrink.
 // The tasks channel attempts to process a job.
 func (w *Workers) startWorker() {
+	w.running.Add(1)
 	go func() {
+		defer w.running.Done()
+
+		var (
+			timer *time.Timer
+			idle  <-chan time.Time
+		)
+		if w.min < w.max {
+			timer = time.NewTimer(w.idleTimeout)
+			defer timer.Stop()
+			idle = timer.C
+		}
 		for {
 			select {
 			case <-w.stopWorkers:
-				w.stoppedWorkers <- struct{}{}
 				return
+			case <-idle:
+				if w.shrink() {
+					return
+				}
+				timer.Reset(w.idleTimeout)
 			case j := <-w.tasks:
+				if timer != nil && !timer.Stop() {
+					<-timer.C
+				}
 				// Check if we should even do the work
 				w.lock.RLock()
 				err := w.err
 				w.lock.RUnlock()
-				if err != nil {
-					w.sg.Done()
-					return
-				}
-				// Attempt to process the job
-				if err := j(); err != nil {
-					w.lock.Lock()
-					if w.err == nil {
-						w.err = err
+				if err == nil {
+					// Attempt to process the job
+					if err := j(); err != nil {
+						w.lock.Lock()
+						if w.err == nil {
+							w.err = err
+						}
+						w.lock.Unlock()
 					}
-					w.lock.Unlock()
 				}
 				w.sg.Done()
+				if timer != nil {
+					timer.Reset(w.idleTimeout)
+				}
 			}
 		}
 	}()
@@ -136,9 +225,7 @@ func (w *Workers) Stop() {
 	close(w.stopWorkers)
 
 	// Wait for all workers to return
-	for i := 0; i < w.count; i++ {
-		<-w.stoppedWorkers
-	}
+	w.running.Wait()
 }
 
 type Job struct {
@@ -202,7 +202,6 @@ func TestWorker(t *testing.T) {
 	// Shutdown fields
 	require.Empty(w.ackShutdown, "Worker ackShutdown not empty")
 	require.Empty(w.stopWorkers, "Worker stopWorkers not empty")
-	require.Empty(w.stoppedWorkers, "Worker stoppedWorkers not empty")
 	// Value updated by the workers
 	var valLock sync.Mutex
 	val := 0
@@ -261,6 +260,60 @@ func TestWorkerStop(t *testing.T) {
 	require.ErrorIs(ErrShutdown, err, "Incorrect error thrown from NewJob.")
 }
 
+func TestWorkerScaling(t *testing.T) {
+	require := require.New(t)
+	w := NewScaling(1, 4, 10*time.Millisecond, 100)
+	require.Equal(1, w.Count())
+
+	// Block all tasks until released to force the pool to grow
+	release := make(chan struct{})
+	job, err := w.NewJob(8)
+	require.NoError(err)
+	for j := 0; j < 8; j++ {
+		job.Go(func() error {
+			<-release
+			return nil
+		})
+	}
+	job.Done(nil)
+	require.Eventually(func() bool { return w.Count() == 4 }, time.Second, time.Millisecond)
+	close(release)
+	require.NoError(job.Wait())
+
+	// Pool shrinks back to min once workers are idle
+	require.Eventually(func() bool { return w.Count() == 1 }, time.Second, time.Millisecond)
+	w.Stop()
+}
+
+func TestWorkerBacklog(t *testing.T) {
+	require := require.New(t)
+	w := New(1, 10)
+	require.Zero(w.Backlog())
+
+	// Block the first job so that later jobs are queued behind it
+	release := make(chan struct{})
+	jobs := make([]*Job, 3)
+	for i := range jobs {
+		job, err := w.NewJob(1)
+		require.NoError(err)
+		if i == 0 {
+			job.Go(func() error {
+				<-release
+				return nil
+			})
+		}
+		job.Done(nil)
+		jobs[i] = job
+	}
+	require.Eventually(func() bool { return w.Backlog() == 2 }, time.Second, time.Millisecond)
+	close(release)
+	for _, job := range jobs {
+		require.NoError(job.Wait())
+	}
+	require.Zero(w.Backlog())
+	w.Stop()
+}
+
 func TestNewJobShutdown(t *testing.T) {
 	require := require.New(t)
 	w := New(2, 10)